@@ -36,6 +36,7 @@ import (
 	vtadminhttp "vitess.io/vitess/go/vt/vtadmin/http"
 	"vitess.io/vitess/go/vt/vtadmin/http/debug"
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
+	"vitess.io/vitess/go/vt/vtctl/audit"
 	"vitess.io/vitess/go/vt/vtenv"
 )
 
@@ -147,10 +148,15 @@ func run(cmd *cobra.Command, args []string) {
 	if err != nil {
 		fatal(err)
 	}
+	auditLogger, err := audit.NewFromFlags("vtadmin", nil)
+	if err != nil {
+		fatal(err)
+	}
 	s := vtadmin.NewAPI(env, clusters, vtadmin.Options{
 		GRPCOpts:              opts,
 		HTTPOpts:              httpOpts,
 		RBAC:                  rbacConfig,
+		AuditLogger:           auditLogger,
 		EnableDynamicClusters: enableDynamicClusters,
 	})
 	bootSpan.Finish()
@@ -172,6 +178,9 @@ func main() {
 	rootCmd.Flags().Var(&defaultClusterConfig, "cluster-defaults", "default options for all clusters")
 	rootCmd.Flags().BoolVar(&enableDynamicClusters, "enable-dynamic-clusters", false, "whether to enable dynamic clusters that are set by request header cookies or gRPC metadata")
 
+	// Audit log flags
+	audit.RegisterFlags(rootCmd.Flags()) // defined in go/vt/vtctl/audit
+
 	// Tracing flags
 	trace.RegisterFlags(rootCmd.Flags()) // defined in go/vt/trace
 	utils.SetFlagBoolVar(rootCmd.Flags(), &opts.EnableTracing, "grpc-tracing", false, "whether to enable tracing on the gRPC server")
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"time"
+
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/utils"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var backupPruningInterval time.Duration
+
+func init() {
+	utils.SetFlagDurationVar(Main.Flags(), &backupPruningInterval, "backup-pruning-interval", backupPruningInterval, "How often the backups of the keyspaces with a backup retention policy are pruned. If zero, the backups are only pruned by the PruneBackups command.")
+}
+
+func initBackupPruning(ctx context.Context) {
+	if backupPruningInterval <= 0 {
+		return
+	}
+
+	server := grpcvtctldserver.NewVtctldServer(env, ts)
+	timer := timer.NewTimer(backupPruningInterval)
+	timer.Start(func() {
+		keyspaces, err := ts.GetKeyspaces(ctx)
+		if err != nil {
+			log.Errorf("Backup pruning failed to get the keyspaces, error: %v", err)
+			return
+		}
+		for _, keyspace := range keyspaces {
+			ki, err := ts.GetKeyspace(ctx, keyspace)
+			if err != nil {
+				log.Errorf("Backup pruning failed to get keyspace %s, error: %v", keyspace, err)
+				continue
+			}
+			if ki.BackupRetentionPolicy == nil {
+				continue
+			}
+			resp, err := server.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{Keyspace: keyspace})
+			if err != nil {
+				log.Errorf("Backup pruning of keyspace %s failed, error: %v", keyspace, err)
+				continue
+			}
+			if len(resp.RemovedBackups) > 0 {
+				log.Infof("Backup pruning removed %d backups of keyspace %s", len(resp.RemovedBackups), keyspace)
+			}
+		}
+	})
+	servenv.OnClose(func() { timer.Stop() })
+}
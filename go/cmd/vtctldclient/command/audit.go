@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// GetAuditLog makes a GetAuditLog gRPC call to a vtctld.
+var GetAuditLog = &cobra.Command{
+	Use:   "GetAuditLog [--limit <limit>] [--actor <actor>] [--method <method>]",
+	Short: "Lists the entries of the audit log kept in the topo by the vtctlds, newest first.",
+	Long: `Lists the entries of the audit log kept in the topo by the vtctlds, newest first.
+
+The vtctlds only keep an audit log in the topo when they run with --audit-log-sinks=topo.`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.NoArgs,
+	RunE:                  commandGetAuditLog,
+}
+
+var getAuditLogOptions = struct {
+	Limit  uint32
+	Actor  string
+	Method string
+}{}
+
+func commandGetAuditLog(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetAuditLog(commandCtx, &vtctldatapb.GetAuditLogRequest{
+		Limit:  getAuditLogOptions.Limit,
+		Actor:  getAuditLogOptions.Actor,
+		Method: getAuditLogOptions.Method,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func init() {
+	GetAuditLog.Flags().Uint32Var(&getAuditLogOptions.Limit, "limit", 0, "Maximum number of entries to list. If zero, all the entries are listed.")
+	GetAuditLog.Flags().StringVar(&getAuditLogOptions.Actor, "actor", "", "Only list the entries of this actor.")
+	GetAuditLog.Flags().StringVar(&getAuditLogOptions.Method, "method", "", "Only list the entries of this method, e.g. PlannedReparentShard.")
+	Root.AddCommand(GetAuditLog)
+}
@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
@@ -60,6 +61,18 @@ If no replica-type tablet can be found, the backup can be taken on the primary i
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandGetBackups,
 	}
+	// PruneBackups makes a PruneBackups gRPC call to a vtctld.
+	PruneBackups = &cobra.Command{
+		Use:   "PruneBackups [--dry-run] <keyspace|keyspace/shard>",
+		Short: "Removes the backups that the backup retention policy of the keyspace does not keep from the BackupStorage used by vtctld.",
+		Long: `Removes the backups that the backup retention policy of the keyspace does not keep from the BackupStorage used by vtctld.
+
+The backups of all the shards of the keyspace are pruned, unless a shard is given.
+The full and incremental backups needed to restore a kept incremental backup are always kept, as well as the newest full backup.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandPruneBackups,
+	}
 	// RemoveBackup makes a RemoveBackup gRPC call to a vtctld.
 	RemoveBackup = &cobra.Command{
 		Use:                   "RemoveBackup <keyspace/shard> <backup name>",
@@ -76,6 +89,20 @@ If no replica-type tablet can be found, the backup can be taken on the primary i
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandRestoreFromBackup,
 	}
+	// SetKeyspaceBackupRetentionPolicy makes a SetKeyspaceBackupRetentionPolicy gRPC call to a vtctld.
+	SetKeyspaceBackupRetentionPolicy = &cobra.Command{
+		Use:   "SetKeyspaceBackupRetentionPolicy [--keep-latest <n>] [--keep-daily-days <days>] [--keep-weekly-weeks <weeks>] <keyspace>",
+		Short: "Sets the policy that decides which backups of the keyspace are kept when they are pruned.",
+		Long: `Sets the policy that decides which backups of the keyspace are kept when they are pruned, with PruneBackups or periodically by vtctld.
+
+A backup is kept if any of the rules keeps it. Setting no rule removes the policy, so the backups of the keyspace are no longer pruned.
+
+To keep the 3 latest backups of the customer keyspace, and one backup a day for a week, you would use the following command:
+SetKeyspaceBackupRetentionPolicy --keep-latest 3 --keep-daily-days 7 customer`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetKeyspaceBackupRetentionPolicy,
+	}
 )
 
 var backupOptions = struct {
@@ -210,6 +237,40 @@ func commandGetBackups(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var pruneBackupsOptions = struct {
+	DryRun bool
+}{}
+
+func commandPruneBackups(cmd *cobra.Command, args []string) error {
+	keyspace, shard := cmd.Flags().Arg(0), ""
+	if strings.Contains(keyspace, "/") {
+		var err error
+		keyspace, shard, err = topoproto.ParseKeyspaceShard(keyspace)
+		if err != nil {
+			return err
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.PruneBackups(commandCtx, &vtctldatapb.PruneBackupsRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+		DryRun:   pruneBackupsOptions.DryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func commandRemoveBackup(cmd *cobra.Command, args []string) error {
 	keyspace, shard, err := topoproto.ParseKeyspaceShard(cmd.Flags().Arg(0))
 	if err != nil {
@@ -291,6 +352,37 @@ func commandRestoreFromBackup(cmd *cobra.Command, args []string) error {
 	}
 }
 
+var setKeyspaceBackupRetentionPolicyOptions = struct {
+	KeepLatest      int32
+	KeepDailyDays   int32
+	KeepWeeklyWeeks int32
+}{}
+
+func commandSetKeyspaceBackupRetentionPolicy(cmd *cobra.Command, args []string) error {
+	keyspace := cmd.Flags().Arg(0)
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetKeyspaceBackupRetentionPolicy(commandCtx, &vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest{
+		Keyspace: keyspace,
+		BackupRetentionPolicy: &topodatapb.BackupRetentionPolicy{
+			KeepLatest:      setKeyspaceBackupRetentionPolicyOptions.KeepLatest,
+			KeepDailyDays:   setKeyspaceBackupRetentionPolicyOptions.KeepDailyDays,
+			KeepWeeklyWeeks: setKeyspaceBackupRetentionPolicyOptions.KeepWeeklyWeeks,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func init() {
 	Backup.Flags().BoolVar(&backupOptions.AllowPrimary, "allow-primary", false, "Allow the primary of a shard to be used for the backup. WARNING: If using the builtin backup engine, this will shutdown mysqld on the primary and stop writes for the duration of the backup.")
 	Backup.Flags().Int32Var(&backupOptions.Concurrency, "concurrency", 4, "Specifies the number of compression/checksum jobs to run simultaneously.")
@@ -312,6 +404,9 @@ func init() {
 	GetBackups.Flags().BoolVarP(&getBackupsOptions.OutputJSON, "json", "j", false, "Output backup info in JSON format rather than a list of backups.")
 	Root.AddCommand(GetBackups)
 
+	PruneBackups.Flags().BoolVar(&pruneBackupsOptions.DryRun, "dry-run", false, "Only list the backups that would be removed, without removing them.")
+	Root.AddCommand(PruneBackups)
+
 	Root.AddCommand(RemoveBackup)
 
 	RestoreFromBackup.Flags().StringVarP(&restoreFromBackupOptions.BackupTimestamp, "backup-timestamp", "t", "", "Use the backup taken at, or closest before, this timestamp. Omit to use the latest backup. Timestamp format is \"YYYY-mm-DD.HHMMSS\".")
@@ -320,4 +415,9 @@ func init() {
 	RestoreFromBackup.Flags().StringVar(&restoreFromBackupOptions.RestoreToTimestamp, "restore-to-timestamp", "", "Run a point in time recovery that restores up to, and excluding, given timestamp in RFC3339 format (`2006-01-02T15:04:05Z07:00`). This will attempt to use one full backup followed by zero or more incremental backups")
 	RestoreFromBackup.Flags().BoolVar(&restoreFromBackupOptions.DryRun, "dry-run", false, "Only validate restore steps, do not actually restore data")
 	Root.AddCommand(RestoreFromBackup)
+
+	SetKeyspaceBackupRetentionPolicy.Flags().Int32Var(&setKeyspaceBackupRetentionPolicyOptions.KeepLatest, "keep-latest", 0, "Number of most recent backups to keep.")
+	SetKeyspaceBackupRetentionPolicy.Flags().Int32Var(&setKeyspaceBackupRetentionPolicyOptions.KeepDailyDays, "keep-daily-days", 0, "Keep the most recent backup of each of the given number of last days (in UTC).")
+	SetKeyspaceBackupRetentionPolicy.Flags().Int32Var(&setKeyspaceBackupRetentionPolicyOptions.KeepWeeklyWeeks, "keep-weekly-weeks", 0, "Keep the most recent backup of each of the given number of last ISO weeks (in UTC).")
+	Root.AddCommand(SetKeyspaceBackupRetentionPolicy)
 }
@@ -103,13 +103,33 @@ Otherwise, the keyspace must be empty (have no shards), or returns an error.`,
 		Long: `Sets the durability-policy used by the specified keyspace. 
 Durability policy governs the durability of the keyspace by describing which tablets should be sending semi-sync acknowledgements to the primary.
 Possible values include 'semi_sync', 'none' and others as dictated by registered plugins.
+The durability policy can also be declared as JSON rules, which set the promotion rules of the tablets and the
+semi-sync ackers of the primaries by cell, tablet type and tablet alias.
 
 To set the durability policy of customer keyspace to semi_sync, you would use the following command:
-SetKeyspaceDurabilityPolicy --durability-policy='semi_sync' customer`,
+SetKeyspaceDurabilityPolicy --durability-policy='semi_sync' customer
+
+To require 2 semi-sync acks from the replicas of other cells than the primary's, you would use the following command:
+SetKeyspaceDurabilityPolicy --durability-policy='{"semi_sync_rules": [{"ackers": 2, "min_cells": 2}]}' customer`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandSetKeyspaceDurabilityPolicy,
 	}
+	// SetKeyspaceSchemaLintPolicy makes a SetKeyspaceSchemaLintPolicy gRPC call to a vtctld.
+	SetKeyspaceSchemaLintPolicy = &cobra.Command{
+		Use:   "SetKeyspaceSchemaLintPolicy [--rule-severity <rule>=<severity> ...] [--max-index-key-length <bytes>] <keyspace>",
+		Short: "Sets the policy that configures the schema lint rules evaluated for the keyspace by LintSchema and ApplySchema.",
+		Long: `Sets the policy that configures the schema lint rules evaluated for the keyspace by LintSchema and ApplySchema.
+
+The severity of a rule is one of off, warning or error. ApplySchema rejects the tables it creates if they have findings with the error severity.
+The rules that the policy does not mention use their default severity. Setting no option removes the policy.
+
+To reject tables without a primary key in the customer keyspace, and to ignore the use of utf8mb3, you would use the following command:
+SetKeyspaceSchemaLintPolicy --rule-severity missing-primary-key=error --rule-severity utf8mb3=off customer`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandSetKeyspaceSchemaLintPolicy,
+	}
 	// ValidateVersionKeyspace makes a ValidateVersionKeyspace gRPC call to a vtctld.
 	ValidateVersionKeyspace = &cobra.Command{
 		Use:                   "ValidateVersionKeyspace <keyspace>",
@@ -342,6 +362,35 @@ func commandSetKeyspaceDurabilityPolicy(cmd *cobra.Command, args []string) error
 	return nil
 }
 
+var setKeyspaceSchemaLintPolicyOptions = struct {
+	RuleSeverities    map[string]string
+	MaxIndexKeyLength int32
+}{}
+
+func commandSetKeyspaceSchemaLintPolicy(cmd *cobra.Command, args []string) error {
+	keyspace := cmd.Flags().Arg(0)
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.SetKeyspaceSchemaLintPolicy(commandCtx, &vtctldatapb.SetKeyspaceSchemaLintPolicyRequest{
+		Keyspace: keyspace,
+		SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+			RuleSeverities:    setKeyspaceSchemaLintPolicyOptions.RuleSeverities,
+			MaxIndexKeyLength: setKeyspaceSchemaLintPolicyOptions.MaxIndexKeyLength,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func commandValidateVersionKeyspace(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
@@ -388,5 +437,9 @@ func init() {
 	SetKeyspaceDurabilityPolicy.Flags().StringVar(&setKeyspaceDurabilityPolicyOptions.DurabilityPolicy, "durability-policy", policy.DurabilityNone, "Type of durability to enforce for this keyspace. Default is none. Other values include 'semi_sync' and others as dictated by registered plugins.")
 	Root.AddCommand(SetKeyspaceDurabilityPolicy)
 
+	SetKeyspaceSchemaLintPolicy.Flags().StringToStringVar(&setKeyspaceSchemaLintPolicyOptions.RuleSeverities, "rule-severity", nil, "Severity of a lint rule, as <rule>=<severity>, where the severity is one of off, warning or error. Repeatable.")
+	SetKeyspaceSchemaLintPolicy.Flags().Int32Var(&setKeyspaceSchemaLintPolicyOptions.MaxIndexKeyLength, "max-index-key-length", 0, "Key length, in bytes, above which the wide-index rule reports an index. Defaults to 767 if zero.")
+	Root.AddCommand(SetKeyspaceSchemaLintPolicy)
+
 	Root.AddCommand(ValidateVersionKeyspace)
 }
@@ -75,6 +75,27 @@ For --sql, semi-colons and repeated values may be mixed, for example:
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandGetSchema,
 	}
+	// LintSchema makes a LintSchema gRPC call to a vtctld.
+	LintSchema = &cobra.Command{
+		Use:   "LintSchema [--sql-file <file> | --sql <sql>] [--rule-severity <rule>=<severity> ...] [--max-index-key-length <bytes>] <keyspace>",
+		Short: "Evaluates the schema lint rules of the keyspace on its schema, or on the given schema.",
+		Long: `Evaluates the schema lint rules of the keyspace on its schema, or on the given schema.
+
+Without --sql or --sql-file, the tables on the primary tablet of the first shard of the keyspace are linted.
+The severities of the rules are those of the schema lint policy of the keyspace, unless --rule-severity or --max-index-key-length are given.
+
+The rules are:
+	missing-primary-key: tables must have a PRIMARY KEY.
+	missing-vindex-column: tables of a sharded keyspace must have a primary vindex over existing columns in the VSchema.
+	cross-keyspace-foreign-key: foreign keys must not reference tables in other keyspaces.
+	utf8mb3: tables and columns should not use the deprecated utf8mb3 (utf8) character set.
+	nullable-unique-key: unique keys should not cover nullable columns.
+	wide-index: index keys should not be wider than the maximum index key length.
+	auto-increment-without-sequence: AUTO_INCREMENT columns of tables in a sharded keyspace must be backed by a sequence in the VSchema.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandLintSchema,
+	}
 	// ReloadSchema makes a ReloadSchema gRPC call to a vtctld.
 	ReloadSchema = &cobra.Command{
 		Use:                   "ReloadSchema <tablet_alias>",
@@ -124,6 +145,7 @@ For --sql, semi-colons and repeated values may be mixed, for example:
 // OnlineDDL subcommands.
 type ApplySchemaOptions struct {
 	AllowLongUnavailability bool
+	SkipLint                bool
 	SQL                     []string
 	SQLFile                 string
 	DDLStrategy             string
@@ -183,15 +205,68 @@ func commandApplySchema(cmd *cobra.Command, args []string) error {
 		WaitReplicasTimeout: protoutil.DurationToProto(applySchemaOptions.WaitReplicasTimeout),
 		CallerId:            cid,
 		BatchSize:           applySchemaOptions.BatchSize,
+		SkipLint:            applySchemaOptions.SkipLint,
 	})
 	if err != nil {
 		return err
 	}
 
+	for _, finding := range resp.LintFindings {
+		fmt.Fprintf(os.Stderr, "%s: %s: table %s: %s\n", finding.Severity, finding.Rule, finding.Entity, finding.Message)
+	}
 	fmt.Println(strings.Join(resp.UuidList, "\n"))
 	return nil
 }
 
+var lintSchemaOptions = struct {
+	SQL               []string
+	SQLFile           string
+	RuleSeverities    map[string]string
+	MaxIndexKeyLength int32
+}{}
+
+func commandLintSchema(cmd *cobra.Command, args []string) error {
+	sql := strings.Join(lintSchemaOptions.SQL, ";")
+	if lintSchemaOptions.SQLFile != "" {
+		if len(lintSchemaOptions.SQL) != 0 {
+			return errors.New("Only one of --sql and --sql-file may be specified.") // nolint
+		}
+
+		data, err := os.ReadFile(lintSchemaOptions.SQLFile)
+		if err != nil {
+			return err
+		}
+
+		sql = string(data)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.LintSchemaRequest{
+		Keyspace: cmd.Flags().Arg(0),
+		Sql:      sql,
+	}
+	if cmd.Flags().Changed("rule-severity") || cmd.Flags().Changed("max-index-key-length") {
+		req.SchemaLintPolicy = &topodatapb.SchemaLintPolicy{
+			RuleSeverities:    lintSchemaOptions.RuleSeverities,
+			MaxIndexKeyLength: lintSchemaOptions.MaxIndexKeyLength,
+		}
+	}
+
+	resp, err := client.LintSchema(commandCtx, req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 var copySchemaShardOptions = struct {
 	tables              []string
 	excludeTables       []string
@@ -449,6 +524,7 @@ func init() {
 	ApplySchema.Flags().StringArrayVar(&applySchemaOptions.SQL, "sql", nil, "Semicolon-delimited, repeatable SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().StringVar(&applySchemaOptions.SQLFile, "sql-file", "", "Path to a file containing semicolon-delimited SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().Int64Var(&applySchemaOptions.BatchSize, "batch-size", 0, "How many queries to batch together. Only applicable when all queries are CREATE TABLE|VIEW")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.SkipLint, "skip-lint", false, "Apply the schema changes even if the schema lint rules of the keyspace find errors in the created tables.")
 	Root.AddCommand(ApplySchema)
 
 	CopySchemaShard.Flags().StringSliceVar(&copySchemaShardOptions.tables, "tables", nil, "Specifies a comma-separated list of tables to copy. Each is either an exact match, or a regular expression of the form /regexp/")
@@ -466,6 +542,12 @@ func init() {
 	GetSchema.Flags().BoolVarP(&getSchemaOptions.TableSchemaOnly, "table-schema-only", "", false, "Skip introspecting columns and fields metadata.")
 	Root.AddCommand(GetSchema)
 
+	LintSchema.Flags().StringArrayVar(&lintSchemaOptions.SQL, "sql", nil, "Semicolon-delimited, repeatable CREATE statements of the schema to lint.")
+	LintSchema.Flags().StringVar(&lintSchemaOptions.SQLFile, "sql-file", "", "Path to a file containing the semicolon-delimited CREATE statements of the schema to lint.")
+	LintSchema.Flags().StringToStringVar(&lintSchemaOptions.RuleSeverities, "rule-severity", nil, "Severity of a lint rule, as <rule>=<severity>, where the severity is one of off, warning or error. Repeatable. Overrides the schema lint policy of the keyspace.")
+	LintSchema.Flags().Int32Var(&lintSchemaOptions.MaxIndexKeyLength, "max-index-key-length", 0, "Key length, in bytes, above which the wide-index rule reports an index. Overrides the schema lint policy of the keyspace.")
+	Root.AddCommand(LintSchema)
+
 	Root.AddCommand(ReloadSchema)
 
 	ReloadSchemaKeyspace.Flags().Int32Var(&reloadSchemaKeyspaceOptions.Concurrency, "concurrency", 10, "Number of tablets to reload in parallel. Set to zero for unbounded concurrency.")
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+// This plugin imports otelbackend to push stats to an OpenTelemetry collector.
+
+import (
+	"vitess.io/vitess/go/stats/otelbackend"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+)
+
+func init() {
+	servenv.OnRun(func() {
+		attrs := make(map[string]string)
+		if tablet := tm.Tablet(); tablet != nil {
+			attrs[otelbackend.CellAttribute] = tablet.Alias.GetCell()
+			attrs[otelbackend.KeyspaceAttribute] = tablet.Keyspace
+			attrs[otelbackend.ShardAttribute] = tablet.Shard
+			attrs[otelbackend.TabletAliasAttribute] = topoproto.TabletAliasString(tablet.Alias)
+		}
+		otelbackend.Init("vttablet", attrs)
+	})
+}
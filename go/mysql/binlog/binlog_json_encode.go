@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// AppendBinaryJSON appends the MySQL binary representation of a JSON
+// value to buf, as found in JSON columns of row events. It is the reverse
+// of ParseBinaryJSON.
+func AppendBinaryJSON(buf []byte, v *json.Value) ([]byte, error) {
+	typ, data, err := binaryJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	buf = append(buf, byte(typ))
+	return append(buf, data...), nil
+}
+
+// binaryJSONValue returns the type and the binary representation of a
+// JSON value, without its type byte.
+func binaryJSONValue(v *json.Value) (jsonDataType, []byte, error) {
+	switch v.Type() {
+	case json.TypeNull:
+		return jsonLiteral, []byte{jsonNullLiteral}, nil
+	case json.TypeBoolean:
+		if v == json.ValueTrue {
+			return jsonLiteral, []byte{jsonTrueLiteral}, nil
+		}
+		return jsonLiteral, []byte{jsonFalseLiteral}, nil
+	case json.TypeNumber:
+		return binaryJSONNumber(v)
+	case json.TypeString, json.TypeDate, json.TypeTime, json.TypeDateTime, json.TypeBlob, json.TypeBit, json.TypeOpaque:
+		// Like MySQL does when a JSON document is parsed from its text,
+		// the temporal and binary values are stored as strings.
+		s := v.Raw()
+		if b, ok := v.StringBytes(); ok {
+			s = string(b)
+		}
+		data := appendVariableLength(nil, len(s))
+		return jsonString, append(data, s...), nil
+	case json.TypeArray:
+		elems, _ := v.Array()
+		return binaryJSONContainer(nil, elems, jsonSmallArray, jsonLargeArray)
+	case json.TypeObject:
+		obj, _ := v.Object()
+		var keys []string
+		var values []*json.Value
+		obj.Visit(func(key string, v *json.Value) {
+			keys = append(keys, key)
+			values = append(values, v)
+		})
+		// MySQL sorts the keys by length first, so it can binary search them.
+		idx := make([]int, len(keys))
+		for i := range idx {
+			idx[i] = i
+		}
+		slices.SortStableFunc(idx, func(a, b int) int {
+			if len(keys[a]) != len(keys[b]) {
+				return len(keys[a]) - len(keys[b])
+			}
+			return strings.Compare(keys[a], keys[b])
+		})
+		sortedKeys := make([]string, len(keys))
+		sortedValues := make([]*json.Value, len(values))
+		for i, j := range idx {
+			sortedKeys[i], sortedValues[i] = keys[j], values[j]
+		}
+		return binaryJSONContainer(sortedKeys, sortedValues, jsonSmallObject, jsonLargeObject)
+	default:
+		return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unsupported JSON value type: %v", v.Type())
+	}
+}
+
+// binaryJSONNumber returns the smallest binary representation of a JSON number.
+func binaryJSONNumber(v *json.Value) (jsonDataType, []byte, error) {
+	switch v.NumberType() {
+	case json.NumberTypeSigned:
+		i, _ := v.Int64()
+		switch {
+		case i >= math.MinInt16 && i <= math.MaxInt16:
+			return jsonInt16, binary.LittleEndian.AppendUint16(nil, uint16(i)), nil
+		case i >= math.MinInt32 && i <= math.MaxInt32:
+			return jsonInt32, binary.LittleEndian.AppendUint32(nil, uint32(i)), nil
+		}
+		return jsonInt64, binary.LittleEndian.AppendUint64(nil, uint64(i)), nil
+	case json.NumberTypeUnsigned:
+		u, _ := v.Uint64()
+		return jsonUint64, binary.LittleEndian.AppendUint64(nil, u), nil
+	}
+	f, ok := v.Float64()
+	if !ok {
+		return 0, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid JSON number: %s", v.Raw())
+	}
+	return jsonDouble, binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)), nil
+}
+
+// binaryJSONContainer returns the binary representation of an object, when
+// keys is not nil, or of an array. The small format, with 16-bit offsets,
+// is used when the document fits in it.
+func binaryJSONContainer(keys []string, values []*json.Value, small, large jsonDataType) (jsonDataType, []byte, error) {
+	types := make([]jsonDataType, len(values))
+	datas := make([][]byte, len(values))
+	for i, v := range values {
+		var err error
+		if types[i], datas[i], err = binaryJSONValue(v); err != nil {
+			return 0, nil, err
+		}
+	}
+	if data, ok := binaryJSONLayout(keys, types, datas, false); ok {
+		return small, data, nil
+	}
+	data, _ := binaryJSONLayout(keys, types, datas, true)
+	return large, data, nil
+}
+
+// binaryJSONLayout lays out the elements of an object or array:
+// | elem count | size | key entries | value entries | keys | values |
+// It returns false if the offsets do not fit in the small format.
+func binaryJSONLayout(keys []string, types []jsonDataType, datas [][]byte, large bool) ([]byte, bool) {
+	width := 2
+	if large {
+		width = 4
+	}
+	appendInt := func(buf []byte, v int) []byte {
+		if large {
+			return binary.LittleEndian.AppendUint32(buf, uint32(v))
+		}
+		return binary.LittleEndian.AppendUint16(buf, uint16(v))
+	}
+
+	count := len(types)
+	header := 2*width + count*(1+width)
+	if keys != nil {
+		header += count * (width + 2)
+	}
+	size := header
+	for _, key := range keys {
+		size += len(key)
+	}
+	for i, typ := range types {
+		if !isInline(typ, large) {
+			size += len(datas[i])
+		}
+	}
+	if !large && size > math.MaxUint16 {
+		return nil, false
+	}
+
+	buf := make([]byte, 0, size)
+	buf = appendInt(buf, count)
+	buf = appendInt(buf, size)
+	offset := header
+	for _, key := range keys {
+		if len(key) > math.MaxUint16 {
+			return nil, false
+		}
+		buf = appendInt(buf, offset)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(len(key)))
+		offset += len(key)
+	}
+	for i, typ := range types {
+		buf = append(buf, byte(typ))
+		if isInline(typ, large) {
+			inlined := make([]byte, width)
+			copy(inlined, datas[i])
+			buf = append(buf, inlined...)
+			continue
+		}
+		buf = appendInt(buf, offset)
+		offset += len(datas[i])
+	}
+	for _, key := range keys {
+		buf = append(buf, key...)
+	}
+	for i, typ := range types {
+		if !isInline(typ, large) {
+			buf = append(buf, datas[i]...)
+		}
+	}
+	return buf, true
+}
+
+// appendVariableLength is the reverse of readVariableLength.
+func appendVariableLength(buf []byte, length int) []byte {
+	for {
+		b := byte(length & 0x7f)
+		length >>= 7
+		if length == 0 {
+			return append(buf, b)
+		}
+		buf = append(buf, b|0x80)
+	}
+}
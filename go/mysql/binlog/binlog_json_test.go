@@ -17,6 +17,7 @@ limitations under the License.
 package binlog
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -467,3 +468,46 @@ func TestMarshalJSONToSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendBinaryJSON(t *testing.T) {
+	testcases := []struct {
+		in       string
+		expected []byte
+	}{
+		{in: `{"a": "b"}`, expected: []byte{0, 1, 0, 14, 0, 11, 0, 1, 0, 12, 12, 0, 97, 1, 98}},
+		{in: `[1, 2]`, expected: []byte{2, 2, 0, 10, 0, 5, 1, 0, 5, 2, 0}},
+		{in: `{"a": "b", "c": "d", "ab": "abc", "bc": ["x", "y"]}`, expected: []byte{0, 4, 0, 60, 0, 32, 0, 1, 0, 33, 0, 1, 0, 34, 0, 2, 0, 36, 0, 2, 0, 12, 38, 0, 12, 40, 0, 12, 42, 0, 2, 46, 0, 97, 99, 97, 98, 98, 99, 1, 98, 1, 100, 3, 97, 98, 99, 2, 0, 14, 0, 12, 10, 0, 12, 12, 0, 1, 120, 1, 121}},
+		{in: `["here", ["I", "am"], "!!!"]`, expected: []byte{2, 3, 0, 37, 0, 12, 13, 0, 2, 18, 0, 12, 33, 0, 4, 104, 101, 114, 101, 2, 0, 15, 0, 12, 10, 0, 12, 12, 0, 1, 73, 2, 97, 109, 3, 33, 33, 33}},
+		{in: `true`, expected: []byte{4, 1}},
+		{in: `null`, expected: []byte{4, 0}},
+		{in: `-1`, expected: []byte{5, 255, 255}},
+		{in: `32768`, expected: []byte{7, 0, 128, 0, 0}},
+		{in: `-2147483649`, expected: []byte{9, 255, 255, 255, 127, 255, 255, 255, 255}},
+		{in: `18446744073709551615`, expected: []byte{10, 255, 255, 255, 255, 255, 255, 255, 255}},
+		{in: `3.14159`, expected: []byte{11, 110, 134, 27, 240, 249, 33, 9, 64}},
+		{in: `{}`, expected: []byte{0, 0, 0, 4, 0}},
+		{in: `[]`, expected: []byte{2, 0, 0, 4, 0}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			var p json.Parser
+			doc, err := p.ParseBytes([]byte(tc.in))
+			require.NoError(t, err)
+			data, err := AppendBinaryJSON(nil, doc)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, data)
+		})
+	}
+
+	// Documents over 64KB use the large format.
+	var p json.Parser
+	in := `{"a": [1, 100000, "` + strings.Repeat("x", 70000) + `"], "b": {"c": true}}`
+	doc, err := p.ParseBytes([]byte(in))
+	require.NoError(t, err)
+	data, err := AppendBinaryJSON(nil, doc)
+	require.NoError(t, err)
+	require.EqualValues(t, jsonLargeObject, data[0])
+	val, err := ParseBinaryJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, doc.String(), val.String())
+}
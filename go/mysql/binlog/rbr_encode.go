@@ -0,0 +1,492 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/json"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var integerSizes = map[byte]int{
+	TypeTiny:     1,
+	TypeShort:    2,
+	TypeInt24:    3,
+	TypeLong:     4,
+	TypeLongLong: 8,
+}
+
+// FieldType returns the type and metadata of the column described by
+// field, as MySQL writes them in a TableMap event.
+func FieldType(field *querypb.Field) (byte, uint16, error) {
+	switch field.Type {
+	case querypb.Type_INT8, querypb.Type_UINT8:
+		return TypeTiny, 0, nil
+	case querypb.Type_INT16, querypb.Type_UINT16:
+		return TypeShort, 0, nil
+	case querypb.Type_INT24, querypb.Type_UINT24:
+		return TypeInt24, 0, nil
+	case querypb.Type_INT32, querypb.Type_UINT32:
+		return TypeLong, 0, nil
+	case querypb.Type_INT64, querypb.Type_UINT64:
+		return TypeLongLong, 0, nil
+	case querypb.Type_FLOAT32:
+		// The metadata is the storage size.
+		return TypeFloat, 4, nil
+	case querypb.Type_FLOAT64:
+		return TypeDouble, 8, nil
+	case querypb.Type_YEAR:
+		return TypeYear, 0, nil
+	case querypb.Type_DATE:
+		return TypeDate, 0, nil
+	case querypb.Type_TIME:
+		return TypeTime2, fractionalDigits(field), nil
+	case querypb.Type_DATETIME:
+		return TypeDateTime2, fractionalDigits(field), nil
+	case querypb.Type_TIMESTAMP:
+		return TypeTimestamp2, fractionalDigits(field), nil
+	case querypb.Type_DECIMAL:
+		precision, scale := decimalPrecision(field)
+		return TypeNewDecimal, uint16(precision)<<8 | uint16(scale), nil
+	case querypb.Type_VARCHAR, querypb.Type_VARBINARY:
+		length := field.ColumnLength
+		if length == 0 || length > math.MaxUint16 {
+			length = math.MaxUint16
+		}
+		return TypeVarchar, uint16(length), nil
+	case querypb.Type_CHAR, querypb.Type_BINARY:
+		// The two upper bits of the length, which can be up to 1020
+		// bytes, are stored inverted in the byte of the real type.
+		length := min(field.ColumnLength, 1023)
+		return TypeString, uint16(TypeString^byte((length&0x300)>>4))<<8 | uint16(length&0xff), nil
+	case querypb.Type_ENUM:
+		size := 1
+		if len(EnumOrSetValues(field.ColumnType)) > 255 {
+			size = 2
+		}
+		return TypeString, uint16(TypeEnum)<<8 | uint16(size), nil
+	case querypb.Type_SET:
+		return TypeString, uint16(TypeSet)<<8 | uint16(setStorageSize(len(EnumOrSetValues(field.ColumnType)))), nil
+	case querypb.Type_BIT:
+		bits := field.ColumnLength
+		return TypeBit, uint16(bits/8)<<8 | uint16(bits%8), nil
+	case querypb.Type_TEXT, querypb.Type_BLOB:
+		// The metadata is the number of bytes of the length.
+		return TypeBlob, blobLengthSize(field), nil
+	case querypb.Type_JSON:
+		return TypeJSON, 4, nil
+	case querypb.Type_GEOMETRY:
+		return TypeGeometry, 4, nil
+	case querypb.Type_VECTOR:
+		return TypeVector, 4, nil
+	default:
+		return 0, 0, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported type %v for column %s in row events", field.Type, field.Name)
+	}
+}
+
+// fractionalDigits returns the number of fractional digits of the seconds
+// of a temporal field.
+func fractionalDigits(field *querypb.Field) uint16 {
+	if field.Decimals > 6 {
+		return 0
+	}
+	return uint16(field.Decimals)
+}
+
+// decimalPrecision returns the precision and scale of a DECIMAL field,
+// from its column type when known, or from its display length.
+func decimalPrecision(field *querypb.Field) (int, int) {
+	var precision, scale int
+	if n, _ := fmt.Sscanf(strings.ToLower(field.ColumnType), "decimal(%d,%d)", &precision, &scale); n == 2 {
+		return precision, scale
+	}
+	// The display length counts the sign and the decimal point.
+	precision, scale = int(field.ColumnLength), int(field.Decimals)
+	if scale > 0 {
+		precision--
+	}
+	if field.Flags&uint32(querypb.MySqlFlag_UNSIGNED_FLAG) == 0 {
+		precision--
+	}
+	return max(precision, 1), scale
+}
+
+// EnumOrSetValues returns the values of an ENUM or SET column type, such
+// as enum('a','b').
+func EnumOrSetValues(columnType string) []string {
+	var values []string
+	var value strings.Builder
+	quoted := false
+	for i := 0; i < len(columnType); i++ {
+		c := columnType[i]
+		switch {
+		case !quoted:
+			quoted = c == '\''
+		case c == '\\' && i+1 < len(columnType):
+			i++
+			value.WriteByte(columnType[i])
+		case c == '\'' && i+1 < len(columnType) && columnType[i+1] == '\'':
+			i++
+			value.WriteByte(c)
+		case c == '\'':
+			values = append(values, value.String())
+			value.Reset()
+			quoted = false
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return values
+}
+
+// setStorageSize returns the number of bytes used to store a SET value.
+func setStorageSize(count int) int {
+	size := (count + 7) / 8
+	if size == 3 {
+		return 4
+	}
+	if size > 4 {
+		return 8
+	}
+	return max(size, 1)
+}
+
+// blobLengthSize returns the number of bytes used to store the length of
+// a BLOB or TEXT value.
+func blobLengthSize(field *querypb.Field) uint16 {
+	columnType := strings.ToLower(field.ColumnType)
+	switch {
+	case strings.HasPrefix(columnType, "tiny"):
+		return 1
+	case strings.HasPrefix(columnType, "medium"):
+		return 3
+	case strings.HasPrefix(columnType, "long"):
+		return 4
+	case columnType == "text" || columnType == "blob":
+		return 2
+	}
+	switch length := field.ColumnLength; {
+	case length <= math.MaxUint8:
+		return 1
+	case length <= math.MaxUint16:
+		return 2
+	case length <= 1<<24-1:
+		return 3
+	}
+	return 4
+}
+
+// AppendCellValue appends the value of a column with the given type and
+// metadata to buf, as MySQL writes it in a row event. This is the reverse
+// of CellValue: the values of ENUM and SET columns are their index and
+// bitmask.
+func AppendCellValue(buf []byte, v sqltypes.Value, typ byte, metadata uint16) ([]byte, error) {
+	raw := v.Raw()
+	switch typ {
+	case TypeTiny, TypeShort, TypeInt24, TypeLong, TypeLongLong:
+		n, err := parseInteger(v)
+		if err != nil {
+			return nil, err
+		}
+		return appendLittleEndian(buf, n, integerSizes[typ]), nil
+	case TypeYear:
+		n, err := parseInteger(v)
+		if err != nil {
+			return nil, err
+		}
+		if n != 0 {
+			n -= 1900
+		}
+		return append(buf, byte(n)), nil
+	case TypeFloat:
+		f, err := strconv.ParseFloat(string(raw), 32)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f))), nil
+	case TypeDouble:
+		f, err := strconv.ParseFloat(string(raw), 64)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(f)), nil
+	case TypeDate:
+		t, err := parseTemporal(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		return appendLittleEndian(buf, uint64(t.day|t.month<<5|t.year<<9), 3), nil
+	case TypeTime2:
+		t, err := parseTemporal(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		return appendTime2(buf, t, int(metadata)), nil
+	case TypeDateTime2:
+		t, err := parseTemporal(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		ymd := uint64((t.year*13+t.month)<<5 | t.day)
+		hms := uint64(t.hour<<12 | t.minute<<6 | t.second)
+		buf = appendBigEndian(buf, (ymd<<17|hms)+0x8000000000, 5)
+		return appendFraction(buf, t.micro, int(metadata)), nil
+	case TypeTimestamp2:
+		t, err := parseTemporal(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		var seconds int64
+		if t.year != 0 || t.month != 0 || t.day != 0 {
+			seconds = time.Date(t.year, time.Month(t.month), t.day, t.hour, t.minute, t.second, 0, time.UTC).Unix()
+		}
+		buf = binary.BigEndian.AppendUint32(buf, uint32(seconds))
+		return appendFraction(buf, t.micro, int(metadata)), nil
+	case TypeNewDecimal:
+		return appendDecimal(buf, string(raw), int(metadata>>8), int(metadata&0xff))
+	case TypeVarchar:
+		if metadata > 255 {
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(len(raw)))
+		} else {
+			buf = append(buf, byte(len(raw)))
+		}
+		return append(buf, raw...), nil
+	case TypeString:
+		switch realType := byte(metadata >> 8); realType {
+		case TypeEnum, TypeSet:
+			n, err := parseInteger(v)
+			if err != nil {
+				return nil, err
+			}
+			return appendLittleEndian(buf, n, int(metadata&0xff)), nil
+		}
+		maxLength := int((((metadata >> 4) & 0x300) ^ 0x300) + (metadata & 0xff))
+		if maxLength > 255 {
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(len(raw)))
+		} else {
+			buf = append(buf, byte(len(raw)))
+		}
+		return append(buf, raw...), nil
+	case TypeBit:
+		nbits := int((metadata>>8)*8 + metadata&0xff)
+		length := (nbits + 7) / 8
+		// The value is right aligned.
+		if len(raw) > length {
+			raw = raw[len(raw)-length:]
+		}
+		for range length - len(raw) {
+			buf = append(buf, 0)
+		}
+		return append(buf, raw...), nil
+	case TypeJSON:
+		doc, err := json.NewFromSQL(v)
+		if err != nil {
+			return nil, err
+		}
+		data, err := AppendBinaryJSON(nil, doc)
+		if err != nil {
+			return nil, err
+		}
+		buf = appendLittleEndian(buf, uint64(len(data)), int(metadata))
+		return append(buf, data...), nil
+	case TypeBlob, TypeGeometry, TypeVector:
+		buf = appendLittleEndian(buf, uint64(len(raw)), int(metadata))
+		return append(buf, raw...), nil
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unsupported type %v", typ)
+	}
+}
+
+// parseInteger returns the bits of an integer value, in two's complement
+// for negative values.
+func parseInteger(v sqltypes.Value) (uint64, error) {
+	if i, err := strconv.ParseInt(string(v.Raw()), 10, 64); err == nil {
+		return uint64(i), nil
+	}
+	return strconv.ParseUint(string(v.Raw()), 10, 64)
+}
+
+func appendLittleEndian(buf []byte, n uint64, size int) []byte {
+	for i := range size {
+		buf = append(buf, byte(n>>(8*i)))
+	}
+	return buf
+}
+
+func appendBigEndian(buf []byte, n uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		buf = append(buf, byte(n>>(8*i)))
+	}
+	return buf
+}
+
+// temporal has the parts of the text of a DATE, TIME, DATETIME or
+// TIMESTAMP value. Unlike the datetime package, zero dates are valid.
+type temporal struct {
+	neg                         bool
+	year, month, day            int
+	hour, minute, second, micro int
+}
+
+func parseTemporal(s string) (t temporal, err error) {
+	invalid := vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid temporal value: %q", s)
+	date, clock, hasDate := strings.Cut(s, " ")
+	if !hasDate {
+		if strings.Count(s, "-") == 2 && !strings.Contains(s, ":") {
+			date, clock, hasDate = s, "", true
+		} else {
+			date, clock = "", s
+		}
+	}
+	atoi := func(s string) int {
+		n, e := strconv.Atoi(s)
+		if e != nil || n < 0 {
+			err = invalid
+		}
+		return n
+	}
+	if hasDate {
+		parts := strings.Split(date, "-")
+		if len(parts) != 3 {
+			return t, invalid
+		}
+		t.year, t.month, t.day = atoi(parts[0]), atoi(parts[1]), atoi(parts[2])
+	}
+	if clock != "" {
+		if clock[0] == '-' {
+			t.neg, clock = true, clock[1:]
+		}
+		clock, fraction, _ := strings.Cut(clock, ".")
+		parts := strings.Split(clock, ":")
+		if len(parts) != 3 {
+			return t, invalid
+		}
+		t.hour, t.minute, t.second = atoi(parts[0]), atoi(parts[1]), atoi(parts[2])
+		if fraction != "" {
+			fraction = (fraction + "000000")[:6]
+			t.micro = atoi(fraction)
+		}
+	}
+	return t, err
+}
+
+// appendFraction appends the fractional part of the seconds of a
+// TIMESTAMP2 or DATETIME2 value, stored in one byte per two digits.
+func appendFraction(buf []byte, micro int, digits int) []byte {
+	switch digits {
+	case 1, 2:
+		return appendBigEndian(buf, uint64(micro/10000), 1)
+	case 3, 4:
+		return appendBigEndian(buf, uint64(micro/100), 2)
+	case 5, 6:
+		return appendBigEndian(buf, uint64(micro), 3)
+	}
+	return buf
+}
+
+// appendTime2 appends a TIME2 value, which is stored like MySQL does in
+// my_time_packed_to_binary.
+func appendTime2(buf []byte, t temporal, digits int) []byte {
+	packed := int64(t.hour<<12|t.minute<<6|t.second)<<24 + int64(t.micro)
+	if t.neg {
+		packed = -packed
+	}
+	intPart, fracPart := packed>>24, packed%(1<<24)
+	switch digits {
+	case 1, 2:
+		buf = appendBigEndian(buf, uint64(intPart+0x800000), 3)
+		return append(buf, byte(fracPart/10000))
+	case 3, 4:
+		buf = appendBigEndian(buf, uint64(intPart+0x800000), 3)
+		return appendBigEndian(buf, uint64(fracPart/100), 2)
+	case 5, 6:
+		return appendBigEndian(buf, uint64(packed+0x800000000000), 6)
+	}
+	return appendBigEndian(buf, uint64(intPart+0x800000), 3)
+}
+
+// appendDecimal appends a DECIMAL value in the binary format of MySQL,
+// which stores groups of nine digits in four bytes. See CellValue.
+func appendDecimal(buf []byte, s string, precision, scale int) ([]byte, error) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "-+")
+	intDigits, fracDigits, _ := strings.Cut(s, ".")
+	intDigits = strings.TrimLeft(intDigits, "0")
+
+	intg := precision - scale
+	if len(intDigits) > intg {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "value %s does not fit in decimal(%d,%d)", s, precision, scale)
+	}
+	intDigits = strings.Repeat("0", intg-len(intDigits)) + intDigits
+	if len(fracDigits) > scale {
+		fracDigits = fracDigits[:scale]
+	}
+	fracDigits += strings.Repeat("0", scale-len(fracDigits))
+
+	var d []byte
+	appendDigits := func(digits string) error {
+		n, err := strconv.ParseUint(digits, 10, 32)
+		if err != nil {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid decimal value: %q", s)
+		}
+		d = appendBigEndian(d, n, dig2bytes[len(digits)])
+		return nil
+	}
+	// The leftover digits come first for the integral part, and last
+	// for the fractional part.
+	leftover := intg % 9
+	if leftover > 0 {
+		if err := appendDigits(intDigits[:leftover]); err != nil {
+			return nil, err
+		}
+	}
+	for i := leftover; i < intg; i += 9 {
+		if err := appendDigits(intDigits[i : i+9]); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i+9 <= scale; i += 9 {
+		if err := appendDigits(fracDigits[i : i+9]); err != nil {
+			return nil, err
+		}
+	}
+	if leftover := scale % 9; leftover > 0 {
+		if err := appendDigits(fracDigits[scale-leftover:]); err != nil {
+			return nil, err
+		}
+	}
+
+	if neg {
+		for i := range d {
+			d[i] ^= 0xff
+		}
+	}
+	if len(d) > 0 {
+		d[0] ^= 0x80
+	}
+	return append(buf, d...), nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestAppendCellValue(t *testing.T) {
+	testcases := []struct {
+		field *querypb.Field
+		in    string
+		out   string
+	}{
+		{field: &querypb.Field{Type: querypb.Type_INT8}, in: "-2"},
+		{field: &querypb.Field{Type: querypb.Type_UINT8}, in: "250"},
+		{field: &querypb.Field{Type: querypb.Type_INT16}, in: "-300"},
+		{field: &querypb.Field{Type: querypb.Type_INT24}, in: "-8388608"},
+		{field: &querypb.Field{Type: querypb.Type_UINT24}, in: "16777215"},
+		{field: &querypb.Field{Type: querypb.Type_INT32}, in: "-2147483648"},
+		{field: &querypb.Field{Type: querypb.Type_UINT64}, in: "18446744073709551615"},
+		{field: &querypb.Field{Type: querypb.Type_INT64}, in: "-9223372036854775808"},
+		{field: &querypb.Field{Type: querypb.Type_FLOAT32}, in: "1.5", out: "1.5E+00"},
+		{field: &querypb.Field{Type: querypb.Type_FLOAT64}, in: "-3.25", out: "-3.25E+00"},
+		{field: &querypb.Field{Type: querypb.Type_YEAR}, in: "2030"},
+		{field: &querypb.Field{Type: querypb.Type_YEAR}, in: "0", out: "0000"},
+		{field: &querypb.Field{Type: querypb.Type_DATE}, in: "2026-10-17"},
+		{field: &querypb.Field{Type: querypb.Type_DATE}, in: "0000-00-00"},
+		{field: &querypb.Field{Type: querypb.Type_TIME}, in: "838:59:59"},
+		{field: &querypb.Field{Type: querypb.Type_TIME}, in: "-12:34:56"},
+		{field: &querypb.Field{Type: querypb.Type_TIME, Decimals: 1}, in: "-00:00:01.5"},
+		{field: &querypb.Field{Type: querypb.Type_TIME, Decimals: 2}, in: "01:02:03.45"},
+		{field: &querypb.Field{Type: querypb.Type_TIME, Decimals: 4}, in: "-01:02:03.4567"},
+		{field: &querypb.Field{Type: querypb.Type_TIME, Decimals: 6}, in: "-01:02:03.000001"},
+		{field: &querypb.Field{Type: querypb.Type_DATETIME}, in: "2026-10-17 12:34:56"},
+		{field: &querypb.Field{Type: querypb.Type_DATETIME, Decimals: 3}, in: "2026-10-17 12:34:56.789"},
+		{field: &querypb.Field{Type: querypb.Type_DATETIME, Decimals: 6}, in: "0000-00-00 00:00:00.000000"},
+		{field: &querypb.Field{Type: querypb.Type_TIMESTAMP}, in: "2026-10-17 12:34:56"},
+		{field: &querypb.Field{Type: querypb.Type_TIMESTAMP, Decimals: 5}, in: "1970-01-02 00:00:00.12345"},
+		{field: &querypb.Field{Type: querypb.Type_TIMESTAMP}, in: "0000-00-00 00:00:00"},
+		{field: &querypb.Field{Type: querypb.Type_DECIMAL, ColumnType: "decimal(10,2)"}, in: "12345678.90"},
+		{field: &querypb.Field{Type: querypb.Type_DECIMAL, ColumnType: "decimal(10,2)"}, in: "-1.5", out: "-1.50"},
+		{field: &querypb.Field{Type: querypb.Type_DECIMAL, ColumnType: "decimal(30,12)"}, in: "-123456789012345678.123456789012"},
+		{field: &querypb.Field{Type: querypb.Type_DECIMAL, ColumnLength: 6, Decimals: 0}, in: "0"},
+		{field: &querypb.Field{Type: querypb.Type_DECIMAL, ColumnLength: 7, Decimals: 2, Flags: uint32(querypb.MySqlFlag_UNSIGNED_FLAG)}, in: "1234.56"},
+		{field: &querypb.Field{Type: querypb.Type_VARCHAR, ColumnLength: 40}, in: "abc"},
+		{field: &querypb.Field{Type: querypb.Type_VARBINARY, ColumnLength: 1000}, in: "xyz"},
+		{field: &querypb.Field{Type: querypb.Type_CHAR, ColumnLength: 1020}, in: "abc"},
+		{field: &querypb.Field{Type: querypb.Type_CHAR, ColumnLength: 10}, in: "abc"},
+		{field: &querypb.Field{Type: querypb.Type_ENUM, ColumnType: "enum('a','b''c','d')"}, in: "2"},
+		{field: &querypb.Field{Type: querypb.Type_SET, ColumnType: "set('a','b','c')"}, in: "5"},
+		{field: &querypb.Field{Type: querypb.Type_BIT, ColumnLength: 12}, in: "\x0a\xbc"},
+		{field: &querypb.Field{Type: querypb.Type_BLOB, ColumnType: "mediumblob"}, in: "\x00\x01\x02"},
+		{field: &querypb.Field{Type: querypb.Type_TEXT, ColumnLength: 255}, in: "text"},
+		{field: &querypb.Field{Type: querypb.Type_JSON}, in: `{"a": [1, -2.5, "x", null, true], "bb": {"c": 70000}}`},
+		{field: &querypb.Field{Type: querypb.Type_GEOMETRY}, in: "\x00\x00\x00\x00\x01\x01"},
+	}
+	for _, tcase := range testcases {
+		t.Run(tcase.field.Type.String()+"/"+tcase.in, func(t *testing.T) {
+			typ, metadata, err := FieldType(tcase.field)
+			require.NoError(t, err)
+
+			data, err := AppendCellValue([]byte{0xff}, sqltypes.MakeTrusted(tcase.field.Type, []byte(tcase.in)), typ, metadata)
+			require.NoError(t, err)
+
+			length, err := CellLength(data, 1, typ, metadata)
+			require.NoError(t, err)
+			assert.Equal(t, len(data)-1, length)
+
+			out, length, err := CellValue(data, 1, typ, metadata, tcase.field, false)
+			require.NoError(t, err)
+			assert.Equal(t, len(data)-1, length)
+			expected := tcase.out
+			if expected == "" {
+				expected = tcase.in
+			}
+			assert.Equal(t, expected, string(out.Raw()))
+		})
+	}
+}
+
+func TestFieldTypeUnsupported(t *testing.T) {
+	_, _, err := FieldType(&querypb.Field{Name: "t", Type: querypb.Type_TUPLE})
+	assert.ErrorContains(t, err, "unsupported type TUPLE for column t in row events")
+}
+
+func TestEnumOrSetValues(t *testing.T) {
+	assert.Equal(t, []string{"a", "b'c", "d\\e", ""}, EnumOrSetValues(`enum('a','b''c','d\\e','')`))
+	assert.Equal(t, []string{"x,y", "z"}, EnumOrSetValues("set('x,y','z')"))
+	assert.Empty(t, EnumOrSetValues("int"))
+}
@@ -183,10 +183,16 @@ func NewRotateEvent(f BinlogFormat, s *FakeBinlogStream, position uint64, filena
 }
 
 func NewFakeRotateEvent(f BinlogFormat, s *FakeBinlogStream, filename string) BinlogEvent {
+	return NewFakeRotateEventWithPosition(f, s, 4, filename)
+}
+
+// NewFakeRotateEventWithPosition returns the artificial RotateEvent sent
+// by a source at the start of a binlog dump, with the requested position.
+func NewFakeRotateEventWithPosition(f BinlogFormat, s *FakeBinlogStream, position uint64, filename string) BinlogEvent {
 	length := 8 + // position
 		len(filename)
 	data := make([]byte, length)
-	binary.LittleEndian.PutUint64(data[0:8], 4)
+	binary.LittleEndian.PutUint64(data[0:8], position)
 	copy(data[8:], filename)
 
 	ev := s.Packetize(f, eRotateEvent, FlagLogEventArtificial, data)
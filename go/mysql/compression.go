@@ -0,0 +1,399 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// This file implements the compressed protocol:
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_compression.html
+//
+// Once negotiated, the stream of packets is cut into compressed packets,
+// each of them with its own header and sequence number. A compressed
+// packet can contain several packets, and a packet can span several
+// compressed packets.
+
+// CompressionAlgorithm is an algorithm of the compressed protocol.
+// The empty value means the protocol is not compressed.
+type CompressionAlgorithm string
+
+const (
+	// CompressionZlib is negotiated with CLIENT_COMPRESS.
+	CompressionZlib CompressionAlgorithm = "zlib"
+
+	// CompressionZstd is negotiated with CLIENT_ZSTD_COMPRESSION_ALGORITHM.
+	CompressionZstd CompressionAlgorithm = "zstd"
+)
+
+const (
+	// compressedPacketHeaderSize is the size of the header of a
+	// compressed packet: the length of the compressed payload, the
+	// sequence number and the length of the uncompressed payload.
+	compressedPacketHeaderSize = 7
+
+	// minCompressLength is the size under which payloads are sent
+	// uncompressed, like MySQL does.
+	minCompressLength = 50
+
+	// DefaultZstdCompressionLevel is the zstd level used when the client
+	// does not ask for one.
+	DefaultZstdCompressionLevel = 3
+)
+
+// ParseCompressionAlgorithms parses a comma separated list of compression
+// algorithms.
+func ParseCompressionAlgorithms(s string) ([]CompressionAlgorithm, error) {
+	var algorithms []CompressionAlgorithm
+	for _, name := range strings.Split(s, ",") {
+		switch alg := CompressionAlgorithm(strings.ToLower(strings.TrimSpace(name))); alg {
+		case "":
+		case CompressionZlib, CompressionZstd:
+			algorithms = append(algorithms, alg)
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unknown compression algorithm: %s", name)
+		}
+	}
+	return algorithms, nil
+}
+
+// CompressionStats counts the bytes of a connection using the compressed
+// protocol. Compressed bytes are the payloads sent over the network,
+// uncompressed bytes are the packets they contain.
+type CompressionStats struct {
+	BytesSent                 int64
+	BytesReceived             int64
+	UncompressedBytesSent     int64
+	UncompressedBytesReceived int64
+}
+
+// compressedIO reads and writes the compressed packets of a connection.
+// Conn reads and writes its packets through it, and it is itself on
+// top of the network connection.
+type compressedIO struct {
+	algorithm CompressionAlgorithm
+	zstdLevel int
+
+	r io.Reader
+	w io.Writer
+
+	// server is set for the connections of a Listener, which also
+	// count their bytes in the server stats.
+	server bool
+
+	// sequence is the sequence number of the compressed packets. It is
+	// reset at the start of each command, like Conn.sequence, and is
+	// shared by both directions.
+	sequence uint8
+
+	header [compressedPacketHeaderSize]byte
+	// data is the part of the last decompressed payload that wasn't read yet.
+	data     []byte
+	readBuf  []byte
+	dataBuf  []byte
+	writeBuf []byte
+
+	zlibReader  io.ReadCloser
+	zlibWriter  *zlib.Writer
+	zstdDecoder *zstd.Decoder
+	zstdEncoder *zstd.Encoder
+
+	bytesSent                 atomic.Int64
+	bytesReceived             atomic.Int64
+	uncompressedBytesSent     atomic.Int64
+	uncompressedBytesReceived atomic.Int64
+}
+
+func newCompressedIO(algorithm CompressionAlgorithm, zstdLevel int, r io.Reader, w io.Writer) *compressedIO {
+	if zstdLevel == 0 {
+		zstdLevel = DefaultZstdCompressionLevel
+	}
+	return &compressedIO{
+		algorithm: algorithm,
+		zstdLevel: zstdLevel,
+		r:         r,
+		w:         w,
+	}
+}
+
+// Read is part of the io.Reader interface. It returns the content of the
+// compressed packets, reading a new one when needed.
+func (cio *compressedIO) Read(p []byte) (int, error) {
+	for len(cio.data) == 0 {
+		if err := cio.readCompressedPacket(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, cio.data)
+	cio.data = cio.data[n:]
+	return n, nil
+}
+
+func (cio *compressedIO) readCompressedPacket() error {
+	if _, err := io.ReadFull(cio.r, cio.header[:]); err != nil {
+		return err
+	}
+	length := int(uint32(cio.header[0]) | uint32(cio.header[1])<<8 | uint32(cio.header[2])<<16)
+	sequence := cio.header[3]
+	uncompressedLength := int(uint32(cio.header[4]) | uint32(cio.header[5])<<8 | uint32(cio.header[6])<<16)
+	if sequence != cio.sequence {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid compressed packet sequence, expected %v got %v", cio.sequence, sequence)
+	}
+	cio.sequence++
+
+	cio.readBuf = grow(cio.readBuf, length)
+	if _, err := io.ReadFull(cio.r, cio.readBuf); err != nil {
+		return vterrors.Wrapf(err, "io.ReadFull(compressed packet body of length %v) failed", length)
+	}
+	// An uncompressed length of 0 means the payload is not compressed.
+	if uncompressedLength == 0 {
+		cio.data = cio.readBuf
+		cio.countReceived(length, length)
+		return nil
+	}
+
+	var err error
+	cio.dataBuf, err = cio.decompress(cio.readBuf, grow(cio.dataBuf, uncompressedLength))
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot decompress packet with %s", cio.algorithm)
+	}
+	if len(cio.dataBuf) != uncompressedLength {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid decompressed packet length, expected %v got %v", uncompressedLength, len(cio.dataBuf))
+	}
+	cio.data = cio.dataBuf
+	cio.countReceived(length, uncompressedLength)
+	return nil
+}
+
+// decompress decompresses src into dst, which has the expected size.
+func (cio *compressedIO) decompress(src, dst []byte) ([]byte, error) {
+	switch cio.algorithm {
+	case CompressionZstd:
+		if cio.zstdDecoder == nil {
+			decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(MaxPacketSize))
+			if err != nil {
+				return nil, err
+			}
+			cio.zstdDecoder = decoder
+		}
+		return cio.zstdDecoder.DecodeAll(src, dst[:0])
+	default:
+		if cio.zlibReader == nil {
+			reader, err := zlib.NewReader(bytes.NewReader(src))
+			if err != nil {
+				return nil, err
+			}
+			cio.zlibReader = reader
+		} else if err := cio.zlibReader.(zlib.Resetter).Reset(bytes.NewReader(src), nil); err != nil {
+			return nil, err
+		}
+		n, err := io.ReadFull(cio.zlibReader, dst)
+		return dst[:n], err
+	}
+}
+
+// Write is part of the io.Writer interface. It sends p in compressed
+// packets right away, so it should be called with as much data as
+// possible, which is why Conn buffers its writes on top of it.
+func (cio *compressedIO) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		payload := p[:min(len(p), MaxPacketSize)]
+		if err := cio.writeCompressedPacket(payload); err != nil {
+			return written, err
+		}
+		written += len(payload)
+		p = p[len(payload):]
+	}
+	return written, nil
+}
+
+func (cio *compressedIO) writeCompressedPacket(payload []byte) error {
+	data := grow(cio.writeBuf, compressedPacketHeaderSize)
+	uncompressedLength := 0
+	if len(payload) >= minCompressLength {
+		compressed, err := cio.compress(data, payload)
+		if err != nil {
+			return vterrors.Wrapf(err, "cannot compress packet with %s", cio.algorithm)
+		}
+		data = compressed
+		uncompressedLength = len(payload)
+	}
+	// Send the payload as is if it is small or doesn't compress well.
+	if uncompressedLength == 0 || len(data)-compressedPacketHeaderSize >= len(payload) {
+		data = append(data[:compressedPacketHeaderSize], payload...)
+		uncompressedLength = 0
+	}
+	cio.writeBuf = data
+
+	length := len(data) - compressedPacketHeaderSize
+	data[0] = byte(length)
+	data[1] = byte(length >> 8)
+	data[2] = byte(length >> 16)
+	data[3] = cio.sequence
+	data[4] = byte(uncompressedLength)
+	data[5] = byte(uncompressedLength >> 8)
+	data[6] = byte(uncompressedLength >> 16)
+	if n, err := cio.w.Write(data); err != nil {
+		return err
+	} else if n != len(data) {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "Write(compressed packet) returned a short write: %v < %v", n, len(data))
+	}
+	cio.sequence++
+	cio.countSent(length, len(payload))
+	return nil
+}
+
+// compress appends the compressed payload to dst.
+func (cio *compressedIO) compress(dst, payload []byte) ([]byte, error) {
+	switch cio.algorithm {
+	case CompressionZstd:
+		if cio.zstdEncoder == nil {
+			encoder, err := zstd.NewWriter(nil,
+				zstd.WithEncoderConcurrency(1),
+				zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(cio.zstdLevel)),
+				zstd.WithLowerEncoderMem(true))
+			if err != nil {
+				return nil, err
+			}
+			cio.zstdEncoder = encoder
+		}
+		return cio.zstdEncoder.EncodeAll(payload, dst), nil
+	default:
+		buf := bytes.NewBuffer(dst)
+		if cio.zlibWriter == nil {
+			cio.zlibWriter = zlib.NewWriter(buf)
+		} else {
+			cio.zlibWriter.Reset(buf)
+		}
+		if _, err := cio.zlibWriter.Write(payload); err != nil {
+			return nil, err
+		}
+		if err := cio.zlibWriter.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+func (cio *compressedIO) countSent(compressed, uncompressed int) {
+	cio.bytesSent.Add(int64(compressed + compressedPacketHeaderSize))
+	cio.uncompressedBytesSent.Add(int64(uncompressed))
+	if cio.server {
+		compressedBytesSent.Add(int64(compressed + compressedPacketHeaderSize))
+		uncompressedBytesSent.Add(int64(uncompressed))
+	}
+}
+
+func (cio *compressedIO) countReceived(compressed, uncompressed int) {
+	cio.bytesReceived.Add(int64(compressed + compressedPacketHeaderSize))
+	cio.uncompressedBytesReceived.Add(int64(uncompressed))
+	if cio.server {
+		compressedBytesReceived.Add(int64(compressed + compressedPacketHeaderSize))
+		uncompressedBytesReceived.Add(int64(uncompressed))
+	}
+}
+
+func (cio *compressedIO) stats() CompressionStats {
+	return CompressionStats{
+		BytesSent:                 cio.bytesSent.Load(),
+		BytesReceived:             cio.bytesReceived.Load(),
+		UncompressedBytesSent:     cio.uncompressedBytesSent.Load(),
+		UncompressedBytesReceived: cio.uncompressedBytesReceived.Load(),
+	}
+}
+
+// grow returns a slice of length n, reusing buf if it is large enough.
+func grow(buf []byte, n int) []byte {
+	if cap(buf) < n {
+		return make([]byte, n)
+	}
+	return buf[:n]
+}
+
+// enableCompression makes the connection use the compressed protocol
+// for the following packets. It is called once the handshake is
+// complete, on both the client and server sides.
+func (c *Conn) enableCompression(algorithm CompressionAlgorithm, zstdLevel int) {
+	c.bufMu.Lock()
+	defer c.bufMu.Unlock()
+	c.compression = newCompressedIO(algorithm, zstdLevel, c.getReader(), c.conn)
+}
+
+// CompressionAlgorithm returns the compression algorithm negotiated for
+// the connection, or an empty string if it is not compressed.
+func (c *Conn) CompressionAlgorithm() CompressionAlgorithm {
+	if c.compression == nil {
+		return ""
+	}
+	return c.compression.algorithm
+}
+
+// CompressionStats returns the byte counts of the compressed protocol
+// for the connection. It can be called concurrently with the connection
+// being used.
+func (c *Conn) CompressionStats() CompressionStats {
+	if c.compression == nil {
+		return CompressionStats{}
+	}
+	return c.compression.stats()
+}
+
+// compressionCapabilities returns the capability flags of the
+// compression algorithms.
+func compressionCapabilities(algorithms []CompressionAlgorithm) uint32 {
+	var capabilities uint32
+	for _, algorithm := range algorithms {
+		switch algorithm {
+		case CompressionZlib:
+			capabilities |= CapabilityClientCompress
+		case CompressionZstd:
+			capabilities |= CapabilityClientZstdCompressionAlgorithm
+		}
+	}
+	return capabilities
+}
+
+// negotiatedCompression returns the compression algorithm selected by
+// the capabilities of the connection, if any.
+func (c *Conn) negotiatedCompression() CompressionAlgorithm {
+	switch {
+	case c.Capabilities&CapabilityClientCompress != 0:
+		return CompressionZlib
+	case c.Capabilities&CapabilityClientZstdCompressionAlgorithm != 0:
+		return CompressionZstd
+	default:
+		return ""
+	}
+}
+
+// resetSequence resets the sequence numbers at the start of a command.
+func (c *Conn) resetSequence() {
+	c.sequence = 0
+	if c.compression != nil {
+		c.compression.sequence = 0
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vttls"
+)
+
+func TestParseCompressionAlgorithms(t *testing.T) {
+	algorithms, err := ParseCompressionAlgorithms("")
+	require.NoError(t, err)
+	assert.Empty(t, algorithms)
+
+	algorithms, err = ParseCompressionAlgorithms("zstd, ZLIB")
+	require.NoError(t, err)
+	assert.Equal(t, []CompressionAlgorithm{CompressionZstd, CompressionZlib}, algorithms)
+
+	_, err = ParseCompressionAlgorithms("zlib,lz4")
+	require.ErrorContains(t, err, "unknown compression algorithm: lz4")
+}
+
+func TestCompressedIO(t *testing.T) {
+	random := make([]byte, 100_000)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+
+	payloads := map[string][]byte{
+		"small":           []byte("select 1"),
+		"compressible":    bytes.Repeat([]byte("select * from t where id = 1;"), 10_000),
+		"incompressible":  random,
+		"several packets": bytes.Repeat([]byte("0123456789"), MaxPacketSize/10+100),
+	}
+	for _, algorithm := range []CompressionAlgorithm{CompressionZlib, CompressionZstd} {
+		for name, payload := range payloads {
+			t.Run(string(algorithm)+"/"+name, func(t *testing.T) {
+				var network bytes.Buffer
+				writer := newCompressedIO(algorithm, 0, nil, &network)
+				n, err := writer.Write(payload)
+				require.NoError(t, err)
+				assert.Equal(t, len(payload), n)
+
+				reader := newCompressedIO(algorithm, 0, &network, nil)
+				read, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				assert.True(t, bytes.Equal(payload, read))
+				assert.Equal(t, writer.sequence, reader.sequence)
+
+				sent, received := writer.stats(), reader.stats()
+				assert.EqualValues(t, len(payload), sent.UncompressedBytesSent)
+				assert.Equal(t, sent.UncompressedBytesSent, received.UncompressedBytesReceived)
+				assert.Equal(t, sent.BytesSent, received.BytesReceived)
+				if name == "compressible" {
+					assert.Less(t, sent.BytesSent, sent.UncompressedBytesSent/10)
+				}
+			})
+		}
+	}
+}
+
+func TestCompressedIOInvalidSequence(t *testing.T) {
+	var network bytes.Buffer
+	writer := newCompressedIO(CompressionZlib, 0, nil, &network)
+	writer.sequence = 3
+	_, err := writer.Write([]byte("select 1"))
+	require.NoError(t, err)
+
+	reader := newCompressedIO(CompressionZlib, 0, &network, nil)
+	_, err = reader.Read(make([]byte, 10))
+	require.ErrorContains(t, err, "invalid compressed packet sequence, expected 0 got 3")
+}
+
+func TestCompressedProtocol(t *testing.T) {
+	tcases := []struct {
+		name     string
+		server   []CompressionAlgorithm
+		client   CompressionAlgorithm
+		expected CompressionAlgorithm
+	}{{
+		name:     "zlib",
+		server:   []CompressionAlgorithm{CompressionZlib, CompressionZstd},
+		client:   CompressionZlib,
+		expected: CompressionZlib,
+	}, {
+		name:     "zstd",
+		server:   []CompressionAlgorithm{CompressionZlib, CompressionZstd},
+		client:   CompressionZstd,
+		expected: CompressionZstd,
+	}, {
+		name:   "algorithm not allowed by the server",
+		server: []CompressionAlgorithm{CompressionZlib},
+		client: CompressionZstd,
+	}, {
+		name:   "compression not asked by the client",
+		server: []CompressionAlgorithm{CompressionZlib, CompressionZstd},
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			ctx := utils.LeakCheckContext(t)
+			th := &testHandler{}
+
+			authServer := NewAuthServerStaticWithAuthMethodDescription("", "", 0, MysqlNativePassword)
+			authServer.entries["user1"] = []*AuthServerStaticEntry{
+				{Password: "password1"},
+			}
+			defer authServer.close()
+
+			l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0, false)
+			require.NoError(t, err)
+			l.CompressionAlgorithms = tcase.server
+			params := &ConnParams{
+				Host:        l.Addr().(*net.TCPAddr).IP.String(),
+				Port:        l.Addr().(*net.TCPAddr).Port,
+				Uname:       "user1",
+				Pass:        "password1",
+				SslMode:     vttls.Disabled,
+				Compression: tcase.client,
+			}
+			go l.Accept()
+			defer cleanupListener(ctx, l, params)
+
+			conn, err := Connect(ctx, params)
+			require.NoError(t, err)
+			defer conn.Close()
+			assert.Equal(t, tcase.expected, conn.CompressionAlgorithm())
+
+			result, err := conn.ExecuteFetch("select rows", 10000, true)
+			require.NoError(t, err)
+			utils.MustMatch(t, result, selectRowsResult)
+
+			// The query is echoed back, so it is compressed in both directions.
+			query := benchmarkQueryPrefix + strings.Repeat("x", 100_000)
+			result, err = conn.ExecuteFetch(query, 10000, true)
+			require.NoError(t, err)
+			require.Len(t, result.Rows, 1)
+			assert.Equal(t, query, result.Rows[0][0].ToString())
+
+			serverConn := th.LastConn()
+			assert.Equal(t, tcase.expected, serverConn.CompressionAlgorithm())
+			clientStats, serverStats := conn.CompressionStats(), serverConn.CompressionStats()
+			if tcase.expected == "" {
+				assert.Zero(t, clientStats)
+				assert.Zero(t, serverStats)
+			} else {
+				assert.Less(t, clientStats.BytesSent, int64(10_000))
+				assert.Greater(t, clientStats.UncompressedBytesSent, int64(100_000))
+				assert.Equal(t, clientStats.BytesSent, serverStats.BytesReceived)
+				assert.Equal(t, clientStats.UncompressedBytesSent, serverStats.UncompressedBytesReceived)
+				assert.Equal(t, serverStats.BytesSent, clientStats.BytesReceived)
+				assert.Equal(t, serverStats.UncompressedBytesSent, clientStats.UncompressedBytesReceived)
+			}
+
+			// Send a ComQuit to avoid the error message on the server side.
+			conn.writeComQuit()
+		})
+	}
+}
+
+func TestCompressedWritePacket(t *testing.T) {
+	tcases := []struct {
+		name   string
+		length int
+		// compressedPackets is the number of compressed packets the
+		// packet is sent in.
+		compressedPackets uint8
+	}{{
+		name:              "small",
+		length:            100,
+		compressedPackets: 1,
+	}, {
+		name:              "max packet size",
+		length:            MaxPacketSize,
+		compressedPackets: 2,
+	}, {
+		name:              "several packets",
+		length:            MaxPacketSize + 1000,
+		compressedPackets: 2,
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			listener, sConn, cConn := createSocketPair(t)
+			defer func() {
+				listener.Close()
+				sConn.Close()
+				cConn.Close()
+			}()
+			sConn.enableCompression(CompressionZlib, 0)
+			cConn.enableCompression(CompressionZlib, 0)
+
+			data := bytes.Repeat([]byte("x"), tcase.length)
+			written := make(chan error, 1)
+			go func() {
+				written <- cConn.writePacket(append(make([]byte, packetHeaderSize), data...))
+			}()
+			read, err := sConn.ReadPacket()
+			require.NoError(t, err)
+			require.NoError(t, <-written)
+			assert.True(t, bytes.Equal(data, read))
+
+			// The headers of the packets are compressed together with
+			// the payloads.
+			assert.Equal(t, tcase.compressedPackets, cConn.compression.sequence)
+			assert.Equal(t, tcase.compressedPackets, sConn.compression.sequence)
+		})
+	}
+}
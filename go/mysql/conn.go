@@ -158,8 +158,15 @@ type Conn struct {
 	// the client and the server, and currently in use.
 	// It is set during the initial handshake.
 	//
-	// It is only used for CapabilityClientDeprecateEOF
-	// and CapabilityClientFoundRows.
+	// On the server side, it holds the client flags of:
+	// - CapabilityClientDeprecateEOF and CapabilityClientFoundRows, which
+	//   change the results that are sent.
+	// - CapabilityClientLocalFiles, if the listener allows LOAD DATA LOCAL.
+	// - CapabilityClientSecureConnection, CapabilityClientPluginAuth and
+	//   CapabilityClientConnAttr, which are needed to parse the
+	//   COM_CHANGE_USER packets of the client.
+	// - The compression capabilities negotiated with the client, of which
+	//   at most one is set.
 	Capabilities uint32
 
 	// closed is set to true when Close() is called on the connection.
@@ -208,6 +215,19 @@ type Conn struct {
 
 	multiQuery bool
 
+	// localInfile is the file content being sent by the client for
+	// the current LOAD DATA LOCAL INFILE statement, if any.
+	localInfile *localInfileReader
+
+	// compression is set once the compressed protocol is negotiated.
+	// Packets are then read from and written to it instead of the
+	// network connection.
+	compression *compressedIO
+
+	// zstdCompressionLevel is the level of zstd compression asked by
+	// the client during the handshake.
+	zstdCompressionLevel int
+
 	// mu protects the fields below
 	mu sync.Mutex
 	// cancel keep the cancel function for the current executing query.
@@ -228,6 +248,12 @@ type PrepareData struct {
 	BindVars    map[string]*querypb.BindVariable
 	StatementID uint32
 	ParamsCount uint16
+	// CursorType is the cursor type asked by the current execution.
+	// With CursorTypeReadOnly, the rows are handed out by COM_STMT_FETCH.
+	CursorType byte
+
+	cursor    *cursor
+	cursorCtx context.Context
 }
 
 // execResult is an enum signifying the result of executing a query
@@ -325,7 +351,7 @@ func (c *Conn) startWriterBuffering() {
 	defer c.bufMu.Unlock()
 
 	c.bufferedWriter = writersPool.Get().(*bufio.Writer)
-	c.bufferedWriter.Reset(c.conn)
+	c.bufferedWriter.Reset(c.getWriter())
 }
 
 // endWriterBuffering must be called to terminate startWriteBuffering.
@@ -373,14 +399,27 @@ func (c *Conn) startFlushTimer() {
 }
 
 // getReader returns reader for connection. It can be *bufio.Reader or net.Conn
-// depending on which buffer size was passed to newServerConn.
+// depending on which buffer size was passed to newServerConn, or the
+// compressed protocol reader on top of them.
 func (c *Conn) getReader() io.Reader {
+	if c.compression != nil {
+		return c.compression
+	}
 	if c.bufferedReader != nil {
 		return c.bufferedReader
 	}
 	return c.conn
 }
 
+// getWriter returns the unbuffered writer for connection. It is net.Conn,
+// or the compressed protocol writer on top of it.
+func (c *Conn) getWriter() io.Writer {
+	if c.compression != nil {
+		return c.compression
+	}
+	return c.conn
+}
+
 func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 	// Note io.ReadFull will return two different types of errors:
 	// 1. if the socket is already closed, and the go runtime knows it,
@@ -402,12 +441,17 @@ func (c *Conn) readHeaderFrom(r io.Reader) (int, error) {
 	}
 
 	sequence := c.header[3]
-	if sequence != c.sequence {
-		return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid sequence, expected %v got %v", c.sequence, sequence)
+	if c.compression != nil {
+		// The sequence of the compressed packets is checked instead, and
+		// the packets we send next follow it, like MySQL does.
+		c.sequence = c.compression.sequence
+	} else {
+		if sequence != c.sequence {
+			return 0, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid sequence, expected %v got %v", c.sequence, sequence)
+		}
+		c.sequence++
 	}
 
-	c.sequence++
-
 	return int(uint32(c.header[0]) | uint32(c.header[1])<<8 | uint32(c.header[2])<<16), nil
 }
 
@@ -598,7 +642,7 @@ func (c *Conn) ReadPacket() ([]byte, error) {
 // Try to use startEphemeralPacketWithHeader/writeEphemeralPacket instead.
 //
 // This method returns a generic error, not a SQLError.
-func (c *Conn) writePacket(data []byte) error {
+func (c *Conn) writePacket(data []byte) (err error) {
 	index := 0
 	dataLength := len(data) - packetHeaderSize
 
@@ -613,7 +657,20 @@ func (c *Conn) writePacket(data []byte) error {
 		}()
 	} else {
 		c.bufMu.Unlock()
-		w = c.conn
+		w = c.getWriter()
+		if c.compression != nil && dataLength >= MaxPacketSize {
+			// The packet is sent in several writes, each of which would be
+			// compressed on its own, leaving its tail and the next header
+			// in a compressed packet of their own. Buffer them so they are
+			// compressed together.
+			bw := bufio.NewWriterSize(w, dataLength+packetHeaderSize*(dataLength/MaxPacketSize+1))
+			w = bw
+			defer func() {
+				if flushErr := bw.Flush(); err == nil && flushErr != nil {
+					err = vterrors.Wrapf(flushErr, "Write(packet) failed")
+				}
+			}()
+		}
 	}
 
 	var header [packetHeaderSize]byte
@@ -717,7 +774,7 @@ func (c *Conn) recycleWritePacket() {
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) writeComQuit() error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 
 	data, pos := c.startEphemeralPacketWithHeader(1)
 	data[pos] = ComQuit
@@ -890,7 +947,7 @@ func (c *Conn) writeEOFPacket(flags uint16, warnings uint16) error {
 // handleNextCommand is called in the server loop to process
 // incoming packets.
 func (c *Conn) handleNextCommand(handler Handler) bool {
-	c.sequence = 0
+	c.resetSequence()
 	data, err := c.readEphemeralPacket()
 	if err != nil {
 		// Don't log EOF errors. They cause too much spam.
@@ -935,13 +992,20 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 		stmtID, ok := c.parseComStmtClose(data)
 		c.recycleReadPacket()
 		if ok {
+			if prepare, ok := c.PrepareData[stmtID]; ok {
+				prepare.closeCursor()
+			}
 			delete(c.PrepareData, stmtID)
 		}
+	case ComStmtFetch:
+		return c.handleComStmtFetch(handler, data)
 	case ComStmtReset:
 		return c.handleComStmtReset(data)
 	case ComResetConnection:
 		c.handleComResetConnection(handler)
 		return true
+	case ComChangeUser:
+		return c.handleComChangeUser(handler, data)
 	case ComFieldList:
 		c.recycleReadPacket()
 		if !c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "command handling not implemented yet: %v", data[0]) {
@@ -1001,6 +1065,7 @@ func (c *Conn) handleComBinlogDump(handler Handler, data []byte) (kontinue bool)
 	}
 	if err := handler.ComBinlogDump(c, logfile, binlogPos); err != nil {
 		log.Error(err.Error())
+		c.writeErrorPacketFromError(err)
 		return false
 	}
 	return kontinue
@@ -1025,6 +1090,7 @@ func (c *Conn) handleComBinlogDumpGTID(handler Handler, data []byte) (kontinue b
 	}
 	if err := handler.ComBinlogDumpGTID(c, logFile, logPos, position.GTIDSet); err != nil {
 		log.Error(err.Error())
+		c.writeErrorPacketFromError(err)
 		return false
 	}
 	return kontinue
@@ -1033,6 +1099,7 @@ func (c *Conn) handleComBinlogDumpGTID(handler Handler, data []byte) (kontinue b
 func (c *Conn) handleComResetConnection(handler Handler) {
 	// Clean up and reset the connection
 	c.recycleReadPacket()
+	c.closeCursors()
 	handler.ComResetConnection(c)
 	// Reset prepared statements
 	c.PrepareData = make(map[uint32]*PrepareData)
@@ -1042,6 +1109,53 @@ func (c *Conn) handleComResetConnection(handler Handler) {
 	}
 }
 
+// handleComChangeUser authenticates the connection as another user. Like
+// MySQL, the connection is closed if the authentication fails. Otherwise
+// the session is reset as with a COM_RESET_CONNECTION.
+func (c *Conn) handleComChangeUser(handler Handler, data []byte) (kontinue bool) {
+	user, clientAuthMethod, clientAuthResponse, schemaName, err := c.parseComChangeUser(data)
+	c.recycleReadPacket()
+	if err != nil {
+		log.Errorf("Cannot parse COM_CHANGE_USER from %s: %v", c, err)
+		return false
+	}
+
+	userData, err := c.listener.authenticate(c, user, clientAuthMethod, clientAuthResponse, c.salt)
+	if err != nil {
+		return false
+	}
+
+	c.closeCursors()
+	handler.ComResetConnection(c)
+	c.PrepareData = make(map[uint32]*PrepareData)
+
+	if c.User != "" {
+		connCountPerUser.Add(c.User, -1)
+	}
+	c.User = user
+	c.UserData = userData
+	c.schemaName = schemaName
+	if c.User != "" {
+		connCountPerUser.Add(c.User, 1)
+	}
+	handler.ComChangeUser(c)
+
+	if c.schemaName != "" {
+		err = handler.ComQuery(c, "use "+sqlescape.EscapeID(c.schemaName), func(result *sqltypes.Result) error {
+			return nil
+		})
+		if err != nil {
+			return c.writeErrorPacketFromErrorAndLog(err)
+		}
+	}
+
+	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
+		log.Errorf("Cannot write OK packet to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
 func (c *Conn) handleComStmtReset(data []byte) bool {
 	stmtID, ok := c.parseComStmtReset(data)
 	c.recycleReadPacket()
@@ -1065,6 +1179,7 @@ func (c *Conn) handleComStmtReset(data []byte) bool {
 			prepare.BindVars[k] = nil
 		}
 	}
+	prepare.closeCursor()
 
 	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
 		log.Error("Error writing ComStmtReset OK packet to client %v: %v", c.ConnectionID, err)
@@ -1073,6 +1188,31 @@ func (c *Conn) handleComStmtReset(data []byte) bool {
 	return true
 }
 
+func (c *Conn) handleComStmtFetch(handler Handler, data []byte) (kontinue bool) {
+	c.startWriterBuffering()
+	defer func() {
+		if err := c.endWriterBuffering(); err != nil {
+			log.Errorf("conn %v: flush() failed: %v", c.ID(), err)
+			kontinue = false
+		}
+	}()
+
+	stmtID, numRows, ok := c.parseComStmtFetch(data)
+	c.recycleReadPacket()
+	if !ok {
+		return c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "error handling packet: %v", data)
+	}
+
+	prepare, ok := c.PrepareData[stmtID]
+	if !ok {
+		return c.writeErrorAndLog(sqlerror.ERUnknownStmtHandler, sqlerror.SSUnknownSQLState, "Unknown prepared statement handler (%v) given to mysqld_stmt_fetch", stmtID)
+	}
+	if prepare.cursor == nil {
+		return c.writeErrorAndLog(sqlerror.ERStmtHasNoOpenCursor, sqlerror.SSUnknownSQLState, "The statement (%v) has no open cursor.", stmtID)
+	}
+	return c.fetchCursor(handler, prepare, numRows)
+}
+
 func (c *Conn) handleComStmtSendLongData(data []byte) bool {
 	stmtID, paramID, chunk, ok := c.parseComStmtSendLongData(data)
 	c.recycleReadPacket()
@@ -1112,7 +1252,7 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		}
 	}()
 	queryStart := time.Now()
-	stmtID, _, err := c.parseComStmtExecute(c.PrepareData, data)
+	stmtID, cursorType, err := c.parseComStmtExecute(c.PrepareData, data)
 	c.recycleReadPacket()
 
 	if stmtID != uint32(0) {
@@ -1127,10 +1267,20 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		return c.writeErrorPacketFromErrorAndLog(err)
 	}
 
+	prepare := c.PrepareData[stmtID]
+	prepare.closeCursor()
+	prepare.CursorType = cursorType
+	if cursorType&CursorTypeReadOnly != 0 {
+		if !c.openCursor(handler, prepare) {
+			return false
+		}
+		timings.Record(queryTimingKey, queryStart)
+		return true
+	}
+
 	receivedResult := false
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
-	prepare := c.PrepareData[stmtID]
 	err = handler.ComStmtExecute(c, prepare, func(qr *sqltypes.Result) error {
 		if sendFinished {
 			// Failsafe: Unreachable if server is well-behaved.
@@ -1324,6 +1474,9 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 
 	err := handler.ComQueryMulti(c, query, func(qr sqltypes.QueryResponse, more bool, firstPacket bool) error {
 		callbackCalled = true
+		if err := c.finishLocalInfile(); err != nil {
+			return err
+		}
 		flag := c.StatusFlags
 		if more {
 			flag |= ServerMoreResultsExists
@@ -1382,6 +1535,10 @@ func (c *Conn) execQueryMulti(query string, handler Handler) execResult {
 		return c.writeRows(qr.QueryResult)
 	})
 
+	if c.finishLocalInfile() != nil {
+		return connErr
+	}
+
 	// If callback was not called, we expect an error.
 	// It is possible that we don't get a callback if some condition checks
 	// fail before the query starts execution. In this case, we need to write some
@@ -1472,6 +1629,11 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 	sendFinished := false
 
 	err := handler.ComQuery(c, query, func(qr *sqltypes.Result) error {
+		// The client expects the end of the local infile to be read
+		// before it receives the result.
+		if err := c.finishLocalInfile(); err != nil {
+			return err
+		}
 		flag := c.StatusFlags
 		if more {
 			flag |= ServerMoreResultsExists
@@ -1511,6 +1673,10 @@ func (c *Conn) execQuery(query string, handler Handler, more bool) execResult {
 		return c.writeRows(qr)
 	})
 
+	if c.finishLocalInfile() != nil {
+		return connErr
+	}
+
 	// If callback was not called, we expect an error.
 	if !callbackCalled {
 		// This is just a failsafe. Should never happen.
@@ -61,6 +61,15 @@ type ConnParams struct {
 	// disabled by default.
 	EnableQueryInfo bool
 
+	// Compression is the algorithm of the compressed protocol to use if
+	// the server supports it. The protocol is not compressed if it is
+	// empty, or if the server does not support the algorithm.
+	Compression CompressionAlgorithm
+
+	// ZstdCompressionLevel is the level of zstd compression, from 1 to 22.
+	// DefaultZstdCompressionLevel is used if it is not set.
+	ZstdCompressionLevel int
+
 	// FlushDelay is the delay after which buffered response will be flushed to the client.
 	FlushDelay time.Duration
 
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"context"
+	"errors"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// errCursorClosed is returned to the execution of a cursor that is
+// closed before all its rows were fetched.
+var errCursorClosed = errors.New("cursor closed")
+
+// cursor is a read-only cursor opened by a COM_STMT_EXECUTE. The
+// execution of the statement runs in its own goroutine, and is paused
+// after each result until its rows were handed out by COM_STMT_FETCH,
+// so only the result being fetched is kept in memory.
+type cursor struct {
+	fields []*querypb.Field
+
+	// results receives the results of the execution. It is closed
+	// when the execution ends, after err is set.
+	results chan *sqltypes.Result
+	err     error
+
+	// release lets the execution go on once the rows of the current
+	// result were sent, as the result may be reused afterwards.
+	release chan struct{}
+
+	// done is closed to stop the execution.
+	done chan struct{}
+
+	// ctx is the context of the execution, and cancel cancels it when the
+	// cursor is closed. The execution outlives the COM_STMT_EXECUTE, so it
+	// doesn't use the context of the commands of the connection.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// current is the result being fetched, and pos its next row.
+	current *sqltypes.Result
+	pos     int
+}
+
+// newCursor starts the execution of the prepared statement for a cursor.
+func newCursor(c *Conn, handler Handler, prepare *PrepareData) *cursor {
+	cur := &cursor{
+		results: make(chan *sqltypes.Result),
+		release: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	cur.ctx, cur.cancel = context.WithCancel(context.Background())
+	prepare.cursorCtx = cur.ctx
+	// The execution gets its own copy of the prepared statement, as the
+	// next commands of the connection change its bind variables while
+	// the cursor is open.
+	stmt := *prepare
+	stmt.BindVars = make(map[string]*querypb.BindVariable, len(prepare.BindVars))
+	for k, bv := range prepare.BindVars {
+		stmt.BindVars[k] = bv.CloneVT()
+	}
+	stmt.cursor = nil
+	go func() {
+		defer close(cur.results)
+		cur.err = handler.ComStmtExecute(c, &stmt, func(qr *sqltypes.Result) error {
+			select {
+			case cur.results <- qr:
+			case <-cur.done:
+				return errCursorClosed
+			}
+			select {
+			case <-cur.release:
+				return nil
+			case <-cur.done:
+				return errCursorClosed
+			}
+		})
+	}()
+	return cur
+}
+
+// next returns the next result of the execution, or nil once it ended.
+func (cur *cursor) next() (*sqltypes.Result, error) {
+	if cur.current != nil {
+		cur.current = nil
+		cur.release <- struct{}{}
+	}
+	qr, ok := <-cur.results
+	if !ok {
+		return nil, cur.err
+	}
+	cur.current, cur.pos = qr, 0
+	return qr, nil
+}
+
+// close stops the execution and waits for it to return.
+func (cur *cursor) close() {
+	close(cur.done)
+	cur.cancel()
+	for range cur.results {
+	}
+}
+
+// CursorContext returns the context to execute the statement with when it
+// opens a read-only cursor. It is canceled once the cursor is closed, by
+// COM_STMT_RESET, COM_STMT_CLOSE, the next execution of the statement or
+// the end of the connection.
+func (prepare *PrepareData) CursorContext() context.Context {
+	if prepare.cursorCtx == nil {
+		return context.Background()
+	}
+	return prepare.cursorCtx
+}
+
+// closeCursor closes the cursor of the prepared statement, if any.
+func (prepare *PrepareData) closeCursor() {
+	if prepare.cursor != nil {
+		prepare.cursor.close()
+		prepare.cursor = nil
+	}
+}
+
+// closeCursors closes the cursors of all the prepared statements.
+func (c *Conn) closeCursors() {
+	for _, prepare := range c.PrepareData {
+		prepare.closeCursor()
+	}
+}
+
+// openCursor runs a COM_STMT_EXECUTE that asked for a read-only cursor.
+// Only the metadata of the result set is sent, followed by an EOF packet
+// with ServerStatusCursorExists, and the rows are sent by COM_STMT_FETCH.
+// Like MySQL, no cursor is opened if the statement has no result set.
+func (c *Conn) openCursor(handler Handler, prepare *PrepareData) bool {
+	cur := newCursor(c, handler, prepare)
+	qr, err := cur.next()
+	if qr == nil {
+		cur.close()
+		if err == nil {
+			// This is just a failsafe. Should never happen.
+			err = sqlerror.NewSQLErrorFromError(errors.New("unexpected: query ended without no results and no error"))
+		}
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	if len(qr.Fields) == 0 {
+		cur.close()
+		ok := PacketOK{
+			affectedRows:     qr.RowsAffected,
+			lastInsertID:     qr.InsertID,
+			statusFlags:      c.StatusFlags,
+			sessionStateData: qr.SessionStateChanges,
+		}
+		if err := c.writeOKPacket(&ok); err != nil {
+			log.Errorf("Error writing result to %s: %v", c, err)
+			return false
+		}
+		return true
+	}
+
+	cur.fields = qr.Fields
+	prepare.cursor = cur
+	if err := c.sendColumnCount(uint64(len(qr.Fields))); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+	for _, field := range qr.Fields {
+		if err := c.writeColumnDefinition(field); err != nil {
+			log.Errorf("Error writing result to %s: %v", c, err)
+			return false
+		}
+	}
+	// The client needs this EOF packet to see the cursor was opened,
+	// even with CapabilityClientDeprecateEOF.
+	if err := c.writeEOFPacket(c.StatusFlags|ServerStatusCursorExists, 0); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
+// fetchCursor sends up to numRows rows of the cursor, followed by the end
+// of the result. The cursor is closed once its last row was sent.
+func (c *Conn) fetchCursor(handler Handler, prepare *PrepareData, numRows uint32) bool {
+	cur := prepare.cursor
+	flags := c.StatusFlags | ServerStatusCursorExists
+	for sent := uint32(0); sent < numRows; {
+		if cur.current == nil || cur.pos == len(cur.current.Rows) {
+			qr, err := cur.next()
+			if err != nil {
+				prepare.closeCursor()
+				return c.writeErrorPacketFromErrorAndLog(err)
+			}
+			if qr == nil {
+				prepare.closeCursor()
+				flags |= ServerStatusLastRowSent
+				break
+			}
+			continue
+		}
+		if err := c.writeBinaryRow(cur.fields, cur.current.Rows[cur.pos]); err != nil {
+			log.Errorf("Error writing row to %s: %v", c, err)
+			return false
+		}
+		cur.pos++
+		sent++
+	}
+
+	if err := c.writeEndResultWithFlags(flags, 0, 0, handler.WarningCount(c)); err != nil {
+		log.Errorf("Error writing result to %s: %v", c, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+type cursorHandler struct {
+	testRun
+	results []*sqltypes.Result
+	err     error
+	ctx     context.Context
+
+	bindVars map[string]*querypb.BindVariable
+}
+
+func (th *cursorHandler) ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error {
+	th.ctx = prepare.CursorContext()
+	for _, qr := range th.results {
+		if err := callback(qr); err != nil {
+			th.err = err
+			return err
+		}
+	}
+	th.bindVars = prepare.BindVars
+	return nil
+}
+
+func writeComStmtExecute(t *testing.T, cConn *Conn, stmtID uint32, cursorType byte) {
+	packet := []byte{0, 0, 0, 0, ComStmtExecute}
+	packet = binary.LittleEndian.AppendUint32(packet, stmtID)
+	packet = append(packet, cursorType)
+	packet = binary.LittleEndian.AppendUint32(packet, 1)
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(packet))
+}
+
+func writeComStmtFetch(t *testing.T, cConn *Conn, stmtID uint32, numRows uint32) {
+	packet := []byte{0, 0, 0, 0, ComStmtFetch}
+	packet = binary.LittleEndian.AppendUint32(packet, stmtID)
+	packet = binary.LittleEndian.AppendUint32(packet, numRows)
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(packet))
+}
+
+// readFetchedRows reads the rows sent for a COM_STMT_FETCH, and the status
+// flags of the EOF packet following them.
+func readFetchedRows(t *testing.T, cConn *Conn) (int, uint16) {
+	rows := 0
+	for {
+		data, err := cConn.ReadPacket()
+		require.NoError(t, err)
+		if cConn.isEOFPacket(data) {
+			_, flags, err := parseEOFPacket(data)
+			require.NoError(t, err)
+			return rows, flags
+		}
+		require.EqualValues(t, 0, data[0], "not a binary row: %v", data)
+		rows++
+	}
+}
+
+func TestCursorFetch(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	fields := sqltypes.MakeTestFields("id|name", "int64|varchar")
+	handler := &cursorHandler{results: []*sqltypes.Result{
+		{Fields: fields},
+		{Rows: sqltypes.MakeTestResult(fields, "1|a", "2|b", "3|c").Rows},
+		{Rows: sqltypes.MakeTestResult(fields, "4|d", "5|e").Rows},
+	}}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id, name from t"}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	require.NotNil(t, sConn.PrepareData[1].cursor)
+
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, data)
+	for range fields {
+		_, err = cConn.ReadPacket()
+		require.NoError(t, err)
+	}
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	require.True(t, cConn.isEOFPacket(data))
+	_, flags, err := parseEOFPacket(data)
+	require.NoError(t, err)
+	assert.NotZero(t, flags&ServerStatusCursorExists)
+
+	// The rows are handed out across the results of the execution.
+	for _, fetch := range []struct {
+		numRows  uint32
+		expected int
+		lastRow  bool
+	}{
+		{numRows: 2, expected: 2},
+		{numRows: 2, expected: 2},
+		{numRows: 5, expected: 1, lastRow: true},
+	} {
+		writeComStmtFetch(t, cConn, 1, fetch.numRows)
+		require.True(t, sConn.handleNextCommand(handler))
+		rows, flags := readFetchedRows(t, cConn)
+		assert.Equal(t, fetch.expected, rows)
+		assert.NotZero(t, flags&ServerStatusCursorExists)
+		assert.Equal(t, fetch.lastRow, flags&ServerStatusLastRowSent != 0)
+	}
+	assert.Nil(t, sConn.PrepareData[1].cursor)
+	assert.NoError(t, handler.err)
+
+	// The cursor was closed with its last row.
+	writeComStmtFetch(t, cConn, 1, 1)
+	require.True(t, sConn.handleNextCommand(handler))
+	data, err = cConn.ReadPacket()
+	require.NoError(t, err)
+	assert.EqualError(t, ParseErrorPacket(data), "The statement (1) has no open cursor. (errno 1421) (sqlstate HY000)")
+}
+
+func TestCursorClose(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	fields := sqltypes.MakeTestFields("id", "int64")
+	handler := &cursorHandler{results: []*sqltypes.Result{
+		sqltypes.MakeTestResult(fields, "1", "2"),
+		{Rows: sqltypes.MakeTestResult(fields, "3").Rows},
+	}}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id from t"}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	writeComStmtFetch(t, cConn, 1, 1)
+	require.True(t, sConn.handleNextCommand(handler))
+	require.NoError(t, handler.ctx.Err())
+
+	// Closing the statement stops the execution.
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(binary.LittleEndian.AppendUint32([]byte{0, 0, 0, 0, ComStmtClose}, 1)))
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Equal(t, errCursorClosed, handler.err)
+	assert.Equal(t, context.Canceled, handler.ctx.Err())
+	assert.Empty(t, sConn.PrepareData)
+}
+
+func TestCursorReset(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	fields := sqltypes.MakeTestFields("id", "int64")
+	handler := &cursorHandler{results: []*sqltypes.Result{
+		sqltypes.MakeTestResult(fields, "1", "2"),
+		{Rows: sqltypes.MakeTestResult(fields, "3").Rows},
+	}}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "select id from t"}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	ctx := handler.ctx
+	require.NoError(t, ctx.Err())
+
+	// Executing the statement again closes the cursor and opens a new one
+	// with its own context.
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Equal(t, context.Canceled, ctx.Err())
+	require.NoError(t, handler.ctx.Err())
+
+	// Resetting the statement cancels the execution of its cursor.
+	cConn.sequence = 0
+	require.NoError(t, cConn.writePacket(binary.LittleEndian.AppendUint32([]byte{0, 0, 0, 0, ComStmtReset}, 1)))
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Equal(t, errCursorClosed, handler.err)
+	assert.Equal(t, context.Canceled, handler.ctx.Err())
+	assert.Nil(t, sConn.PrepareData[1].cursor)
+}
+
+func TestCursorBindVars(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	fields := sqltypes.MakeTestFields("id", "int64")
+	handler := &cursorHandler{results: []*sqltypes.Result{
+		sqltypes.MakeTestResult(fields, "1", "2"),
+		{Rows: sqltypes.MakeTestResult(fields, "3").Rows},
+	}}
+	sConn.PrepareData[1] = &PrepareData{
+		StatementID: 1,
+		PrepareStmt: "select id from t where name = :v1",
+		BindVars:    map[string]*querypb.BindVariable{"v1": sqltypes.StringBindVariable("a")},
+	}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	require.NotNil(t, sConn.PrepareData[1].cursor)
+	// Skip the column count, the column definition and the EOF packet.
+	for range 3 {
+		_, err := cConn.ReadPacket()
+		require.NoError(t, err)
+	}
+
+	// The bind variables of the statement are changed while its cursor
+	// is open, which must not change those of the execution.
+	prepare := sConn.PrepareData[1]
+	prepare.ParamsCount = 1
+	prepare.BindVars["v1"] = sqltypes.StringBindVariable("b")
+	cConn.sequence = 0
+	packet := binary.LittleEndian.AppendUint32([]byte{0, 0, 0, 0, ComStmtSendLongData}, 1)
+	packet = binary.LittleEndian.AppendUint16(packet, 0)
+	require.NoError(t, cConn.writePacket(append(packet, 'c')))
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Equal(t, []byte("bc"), prepare.BindVars["v1"].Value)
+
+	writeComStmtFetch(t, cConn, 1, 4)
+	require.True(t, sConn.handleNextCommand(handler))
+	rows, flags := readFetchedRows(t, cConn)
+	assert.Equal(t, 3, rows)
+	assert.NotZero(t, flags&ServerStatusLastRowSent)
+	assert.Equal(t, map[string]*querypb.BindVariable{"v1": sqltypes.StringBindVariable("a")}, handler.bindVars)
+}
+
+func TestCursorWithoutResultSet(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	handler := &cursorHandler{results: []*sqltypes.Result{{RowsAffected: 3}}}
+	sConn.PrepareData[1] = &PrepareData{StatementID: 1, PrepareStmt: "update t set a = 1"}
+
+	writeComStmtExecute(t, cConn, 1, CursorTypeReadOnly)
+	require.True(t, sConn.handleNextCommand(handler))
+	assert.Nil(t, sConn.PrepareData[1].cursor)
+
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, OKPacket, data[0])
+}
@@ -607,6 +607,115 @@ func (dt DateTime) ToSeconds() int64 {
 	return int64(numDays*24*3600) + dt.Time.ToSeconds()
 }
 
+// AddTime adds the given time to dt (or subtracts it, if sub is set), like
+// MySQL's ADDTIME and SUBTIME do for datetime arguments. It returns false if
+// the result is out of the supported date range.
+func (dt DateTime) AddTime(t Time, sub bool) (DateTime, bool) {
+	dur := t.ToDuration()
+	if sub {
+		dur = -dur
+	}
+	dur += dt.Time.toDuration()
+
+	days := MysqlDayNumber(dt.Date.Year(), dt.Date.Month(), dt.Date.Day()) + int(dur/durationPerDay)
+	dur %= durationPerDay
+	if dur < 0 {
+		dur += durationPerDay
+		days--
+	}
+	if days < 0 || days > maxDay {
+		return DateTime{}, false
+	}
+
+	var r DateTime
+	r.Date.year, r.Date.month, r.Date.day = mysqlDateFromDayNumber(days)
+	r.Time = newTimeFromDuration(dur)
+	return r, true
+}
+
+// AddTime adds the given time to t (or subtracts it, if sub is set), like
+// MySQL's ADDTIME and SUBTIME do for time arguments. The result is clamped
+// to the valid TIME range.
+func (t Time) AddTime(t2 Time, sub bool) Time {
+	dur := t2.ToDuration()
+	if sub {
+		dur = -dur
+	}
+	return newTimeFromDuration(t.ToDuration() + dur)
+}
+
+func newTimeFromDuration(dur time.Duration) Time {
+	var neg bool
+	if dur < 0 {
+		neg = true
+		dur = -dur
+	}
+
+	var t Time
+	if dur >= (MaxHours+1)*time.Hour {
+		t = Time{hour: MaxHours, minute: 59, second: 59}
+	} else {
+		t.nanosecond = uint32((dur % time.Second) / time.Nanosecond)
+		t.second = uint8((dur % time.Minute) / time.Second)
+		t.minute = uint8((dur % time.Hour) / time.Minute)
+		t.hour = uint16(dur / time.Hour)
+	}
+	if neg {
+		t.hour |= negMask
+	}
+	return t
+}
+
+// TimestampDiff returns the difference between from and to expressed in the
+// given unit and truncated towards zero, like MySQL's TIMESTAMPDIFF.
+func TimestampDiff(unit IntervalType, from, to DateTime) int64 {
+	dayMicro := func(dt DateTime) (int64, int64) {
+		days := int64(MysqlDayNumber(dt.Date.Year(), dt.Date.Month(), dt.Date.Day()))
+		return days, dt.Time.toDuration().Microseconds()
+	}
+
+	d1, us1 := dayMicro(from)
+	d2, us2 := dayMicro(to)
+	diff := (d2-d1)*int64(durationPerDay/time.Microsecond) + us2 - us1
+
+	neg := int64(1)
+	if diff < 0 {
+		neg = -1
+		diff = -diff
+		from, to = to, from
+	}
+
+	switch unit {
+	case IntervalYear, IntervalQuarter, IntervalMonth:
+		months := int64(to.Date.Year()-from.Date.Year())*12 + int64(to.Date.Month()-from.Date.Month())
+		if to.Date.Day() < from.Date.Day() || (to.Date.Day() == from.Date.Day() && to.Time.toDuration() < from.Time.toDuration()) {
+			months--
+		}
+		switch unit {
+		case IntervalYear:
+			return months / 12 * neg
+		case IntervalQuarter:
+			return months / 3 * neg
+		default:
+			return months * neg
+		}
+	case IntervalWeek:
+		return diff / int64(7*durationPerDay/time.Microsecond) * neg
+	case IntervalDay:
+		return diff / int64(durationPerDay/time.Microsecond) * neg
+	case IntervalHour:
+		return diff / int64(time.Hour/time.Microsecond) * neg
+	case IntervalMinute:
+		return diff / int64(time.Minute/time.Microsecond) * neg
+	case IntervalSecond:
+		return diff / int64(time.Second/time.Microsecond) * neg
+	case IntervalMicrosecond:
+		return diff * neg
+	default:
+		panic("unexpected IntervalType")
+	}
+}
+
 func (dt *DateTime) addInterval(itv *Interval) bool {
 	switch {
 	case itv.unit.HasTimeParts():
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/decimal"
 	"vitess.io/vitess/go/vt/vthash"
@@ -941,3 +942,49 @@ func TestHash(t *testing.T) {
 	}
 	assert.Equal(t, want, h.Sum128())
 }
+
+func TestTimestampDiff(t *testing.T) {
+	parse := func(s string) DateTime {
+		dt, _, ok := ParseDateTime(s, -1)
+		require.True(t, ok)
+		return dt
+	}
+
+	testCases := []struct {
+		unit     IntervalType
+		from, to string
+		want     int64
+	}{
+		{IntervalMonth, "2003-02-01 00:00:00", "2003-05-01 00:00:00", 3},
+		{IntervalMonth, "2003-05-01 00:00:00", "2003-02-01 00:00:00", -3},
+		{IntervalMonth, "2003-02-01 12:00:00", "2003-05-01 11:59:59", 2},
+		{IntervalYear, "2002-05-01 00:00:00", "2001-01-01 00:00:00", -1},
+		{IntervalQuarter, "2001-01-31 00:00:00", "2001-12-30 00:00:00", 3},
+		{IntervalWeek, "2003-02-01 00:00:00", "2003-02-15 00:00:00", 2},
+		{IntervalDay, "2003-02-28 00:00:00", "2003-03-01 00:00:00", 1},
+		{IntervalMinute, "2003-02-01 00:00:00", "2003-05-01 12:05:55", 128885},
+		{IntervalSecond, "2003-02-01 00:00:01", "2003-02-01 00:00:00", -1},
+		{IntervalMicrosecond, "2003-02-01 00:00:00.5", "2003-02-01 00:00:01", 500000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.from+"/"+tc.to, func(t *testing.T) {
+			assert.Equal(t, tc.want, TimestampDiff(tc.unit, parse(tc.from), parse(tc.to)))
+		})
+	}
+}
+
+func TestAddTime(t *testing.T) {
+	dt := DateTime{Date: Date{2007, 12, 31}, Time: Time{23, 59, 59, 999999000}}
+	r, ok := dt.AddTime(Time{hour: 1, minute: 1, second: 1, nanosecond: 1000}, false)
+	require.True(t, ok)
+	assert.Equal(t, "2008-01-01 01:01:01.000000", string(r.Format(6)))
+
+	r, ok = dt.AddTime(Time{hour: 48}, true)
+	require.True(t, ok)
+	assert.Equal(t, "2007-12-29 23:59:59.999999", string(r.Format(6)))
+
+	tm := Time{hour: 1, minute: 0, second: 0}
+	assert.Equal(t, "-01:00:00", string(tm.AddTime(Time{hour: 2}, true).Format(0)))
+	assert.Equal(t, "838:59:59", string(tm.AddTime(Time{hour: 838}, false).Format(0)))
+}
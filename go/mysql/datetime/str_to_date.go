@@ -0,0 +1,390 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"strings"
+	"time"
+)
+
+// StrToDateParts describes which temporal parts a STR_TO_DATE format string
+// can set. MySQL uses this to decide the type of STR_TO_DATE's result when the
+// format is known ahead of time.
+type StrToDateParts uint8
+
+const (
+	StrToDateDate StrToDateParts = 1 << iota
+	StrToDateTime
+	StrToDateFrac
+)
+
+// ParseStrToDateParts returns the parts that the given STR_TO_DATE format sets.
+func ParseStrToDateParts(format string) StrToDateParts {
+	var parts StrToDateParts
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'f':
+			parts |= StrToDateTime | StrToDateFrac
+		case 'H', 'h', 'I', 'i', 'k', 'l', 'p', 'r', 'S', 's', 'T':
+			parts |= StrToDateTime
+		case 'a', 'b', 'c', 'D', 'd', 'e', 'j', 'M', 'm', 'U', 'u', 'V', 'v', 'W', 'w', 'X', 'x', 'Y', 'y':
+			parts |= StrToDateDate
+		}
+	}
+	return parts
+}
+
+// strToDateState holds the values extracted by StrToDate before they are
+// combined into a DateTime.
+type strToDateState struct {
+	year, month, day     int
+	hour, minute, second int
+	nsec                 int
+
+	daypart  int
+	usaTime  bool
+	yearday  int
+	weekday  int
+	week     int
+	weekYear int
+
+	sundayFirst  bool
+	strictWeek   bool
+	strictYearTy bool
+}
+
+// StrToDate parses value using a MySQL STR_TO_DATE format. Unlike the
+// strict parsers used for casting, numeric fields are read leniently up to
+// their maximum width, whitespace in the value is skipped between fields and
+// parts that are not present in the format are left as zero, so the resulting
+// date can contain zero months or days. Trailing characters in the value are
+// ignored, like MySQL does (with a warning). The caller is responsible for
+// validating zero dates against the session's SQL mode.
+func StrToDate(value, format string) (DateTime, bool) {
+	st := strToDateState{week: -1, weekYear: -1}
+	if _, ok := st.parse(value, format); !ok {
+		return DateTime{}, false
+	}
+	return st.toDateTime()
+}
+
+func skipSpaces(s string) string {
+	for len(s) > 0 && isSpace(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isPunct(c byte) bool {
+	return c > ' ' && c < 0x7f && !isAlpha(c) && (c < '0' || c > '9')
+}
+
+// strToDateNum reads an unsigned number of at most n digits from the start of s.
+func strToDateNum(s string, n int) (int, string, bool) {
+	var x, i int
+	for i < n && i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		x = x*10 + int(s[i]-'0')
+		i++
+	}
+	return x, s[i:], i > 0
+}
+
+// strToDateWord reads an alphabetic word from the start of s and matches it
+// against names. Like MySQL, unambiguous prefixes of a name are accepted.
+func strToDateWord(s string, names []string) (int, string, bool) {
+	var i int
+	for i < len(s) && isAlpha(s[i]) {
+		i++
+	}
+	word := s[:i]
+	if word == "" {
+		return 0, s, false
+	}
+
+	found := -1
+	for n, name := range names {
+		if len(word) > len(name) || !match(word, name[:len(word)]) {
+			continue
+		}
+		if len(word) == len(name) {
+			return n, s[i:], true
+		}
+		if found >= 0 {
+			return 0, s, false
+		}
+		found = n
+	}
+	return found, s[i:], found >= 0
+}
+
+var longMonthNames = []string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var longDayNames = []string{
+	"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday",
+}
+
+var mondayFirstShortDayNames = []string{
+	"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun",
+}
+
+func year2000(y int) int {
+	if y < 70 {
+		return y + 2000
+	}
+	return y + 1900
+}
+
+func (st *strToDateState) parse(val, format string) (string, bool) {
+	var ok bool
+	for f := 0; f < len(format) && len(val) > 0; f++ {
+		if val = skipSpaces(val); len(val) == 0 {
+			break
+		}
+
+		if format[f] != '%' || f+1 == len(format) {
+			if !isSpace(format[f]) {
+				if val[0] != format[f] {
+					return val, false
+				}
+				val = val[1:]
+			}
+			continue
+		}
+
+		f++
+		switch format[f] {
+		case 'Y':
+			rem := val
+			if st.year, val, ok = strToDateNum(val, 4); ok && len(rem)-len(val) <= 2 {
+				st.year = year2000(st.year)
+			}
+		case 'y':
+			if st.year, val, ok = strToDateNum(val, 2); ok {
+				st.year = year2000(st.year)
+			}
+		case 'm', 'c':
+			st.month, val, ok = strToDateNum(val, 2)
+		case 'M':
+			if st.month, val, ok = strToDateWord(val, longMonthNames); ok {
+				st.month++
+			}
+		case 'b':
+			if st.month, val, ok = strToDateWord(val, shortMonthNames); ok {
+				st.month++
+			}
+		case 'd', 'e':
+			st.day, val, ok = strToDateNum(val, 2)
+		case 'D':
+			st.day, val, ok = strToDateNum(val, 2)
+			val = val[min(len(val), 2):]
+		case 'h', 'I', 'l':
+			st.usaTime = true
+			st.hour, val, ok = strToDateNum(val, 2)
+		case 'k', 'H':
+			st.hour, val, ok = strToDateNum(val, 2)
+		case 'i':
+			st.minute, val, ok = strToDateNum(val, 2)
+		case 's', 'S':
+			st.second, val, ok = strToDateNum(val, 2)
+		case 'f':
+			rem := val
+			if st.nsec, val, ok = strToDateNum(val, 6); ok {
+				for range 9 - (len(rem) - len(val)) {
+					st.nsec *= 10
+				}
+			}
+		case 'p':
+			if len(val) < 2 || !st.usaTime {
+				return val, false
+			}
+			switch {
+			case match(val[:2], "PM"):
+				st.daypart = 12
+			case match(val[:2], "AM"):
+				st.daypart = 0
+			default:
+				return val, false
+			}
+			val, ok = val[2:], true
+		case 'W':
+			if st.weekday, val, ok = strToDateWord(val, longDayNames); ok {
+				st.weekday++
+			}
+		case 'a':
+			if st.weekday, val, ok = strToDateWord(val, mondayFirstShortDayNames); ok {
+				st.weekday++
+			}
+		case 'w':
+			if st.weekday, val, ok = strToDateNum(val, 1); ok && st.weekday == 0 {
+				st.weekday = 7
+			}
+		case 'j':
+			st.yearday, val, ok = strToDateNum(val, 3)
+		case 'U', 'u', 'V', 'v':
+			st.strictWeek = format[f] == 'V' || format[f] == 'v'
+			st.sundayFirst = format[f] == 'U' || format[f] == 'V'
+			st.week, val, ok = strToDateNum(val, 2)
+			if ok && (st.week > 53 || (st.strictWeek && st.week == 0)) {
+				return val, false
+			}
+		case 'X', 'x':
+			st.strictYearTy = format[f] == 'X'
+			st.weekYear, val, ok = strToDateNum(val, 4)
+		case 'r':
+			val, ok = st.parse(val, "%I:%i:%S %p")
+		case 'T':
+			val, ok = st.parse(val, "%H:%i:%S")
+		case '.':
+			for len(val) > 0 && isPunct(val[0]) {
+				val = val[1:]
+			}
+			ok = true
+		case '@':
+			for len(val) > 0 && isAlpha(val[0]) {
+				val = val[1:]
+			}
+			ok = true
+		case '#':
+			for len(val) > 0 && val[0] >= '0' && val[0] <= '9' {
+				val = val[1:]
+			}
+			ok = true
+		default:
+			return val, false
+		}
+		if !ok {
+			return val, false
+		}
+	}
+	return val, true
+}
+
+func (st *strToDateState) toDateTime() (DateTime, bool) {
+	if st.usaTime {
+		if st.hour > 12 || st.hour < 1 {
+			return DateTime{}, false
+		}
+		st.hour = st.hour%12 + st.daypart
+	}
+
+	if st.yearday > 0 {
+		days := MysqlDayNumber(st.year, 1, 1) + st.yearday - 1
+		if days <= 0 || days > maxDay {
+			return DateTime{}, false
+		}
+		y, m, d := mysqlDateFromDayNumber(days)
+		st.year, st.month, st.day = int(y), int(m), int(d)
+	}
+
+	if st.week >= 0 && st.weekday > 0 {
+		// %V and %v require %X and %x respectively; %U and %u must be used
+		// with %Y instead.
+		if st.strictWeek && (st.weekYear < 0 || st.strictYearTy != st.sundayFirst) {
+			return DateTime{}, false
+		}
+		if !st.strictWeek && st.weekYear >= 0 {
+			return DateTime{}, false
+		}
+
+		year := st.year
+		if st.strictWeek {
+			year = st.weekYear
+		}
+		days := MysqlDayNumber(year, 1, 1)
+		first := calcWeekday(days, st.sundayFirst)
+		if st.sundayFirst {
+			if first != 0 {
+				days += 7
+			}
+			days += -first + (st.week-1)*7 + st.weekday%7
+		} else {
+			if first > 3 {
+				days += 7
+			}
+			days += -first + (st.week-1)*7 + st.weekday - 1
+		}
+		if days <= 0 || days > maxDay {
+			return DateTime{}, false
+		}
+		y, m, d := mysqlDateFromDayNumber(days)
+		st.year, st.month, st.day = int(y), int(m), int(d)
+	}
+
+	if st.year > 9999 || st.month > 12 || st.day > 31 || st.hour > 23 || st.minute > 59 || st.second > 59 {
+		return DateTime{}, false
+	}
+	if st.month > 0 && st.day > daysIn(time.Month(st.month), st.year) {
+		return DateTime{}, false
+	}
+
+	return DateTime{
+		Date: Date{year: uint16(st.year), month: uint8(st.month), day: uint8(st.day)},
+		Time: Time{hour: uint16(st.hour), minute: uint8(st.minute), second: uint8(st.second), nanosecond: uint32(st.nsec)},
+	}, true
+}
+
+// calcWeekday returns the day of the week for the given MySQL day number,
+// where 0 is Sunday when sundayFirst is set and Monday otherwise.
+func calcWeekday(daynr int, sundayFirst bool) int {
+	if sundayFirst {
+		return (daynr + 6) % 7
+	}
+	return (daynr + 5) % 7
+}
+
+// GetFormat returns the format string used by MySQL's GET_FORMAT for the
+// given temporal type (DATE, TIME, DATETIME or TIMESTAMP) and locale
+// (EUR, USA, JIS, ISO or INTERNAL).
+func GetFormat(typ, locale string) (string, bool) {
+	var formats [5]string
+	switch strings.ToUpper(typ) {
+	case "DATE":
+		formats = [...]string{"%d.%m.%Y", "%m.%d.%Y", "%Y-%m-%d", "%Y-%m-%d", "%Y%m%d"}
+	case "DATETIME", "TIMESTAMP":
+		formats = [...]string{"%Y-%m-%d %H.%i.%s", "%Y-%m-%d %H.%i.%s", "%Y-%m-%d %H:%i:%s", "%Y-%m-%d %H:%i:%s", "%Y%m%d%H%i%s"}
+	case "TIME":
+		formats = [...]string{"%H.%i.%s", "%h:%i:%s %p", "%H:%i:%s", "%H:%i:%s", "%H%i%s"}
+	default:
+		return "", false
+	}
+
+	switch strings.ToUpper(locale) {
+	case "EUR":
+		return formats[0], true
+	case "USA":
+		return formats[1], true
+	case "JIS":
+		return formats[2], true
+	case "ISO":
+		return formats[3], true
+	case "INTERNAL":
+		return formats[4], true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrToDate(t *testing.T) {
+	var cases = []struct {
+		value  string
+		format string
+		output string
+		ok     bool
+	}{
+		{"01,5,2013", "%d,%m,%Y", "2013-05-01 00:00:00.000000", true},
+		{"May 1, 2013", "%M %d,%Y", "2013-05-01 00:00:00.000000", true},
+		{"a09:30:17", "a%h:%i:%s", "0000-00-00 09:30:17.000000", true},
+		{"a09:30:17", "%h:%i:%s", "", false},
+		{"09:30:17a", "%h:%i:%s", "0000-00-00 09:30:17.000000", true},
+		{"abc", "abc", "0000-00-00 00:00:00.000000", true},
+		{"9", "%m", "0000-09-00 00:00:00.000000", true},
+		{"9", "%s", "0000-00-00 00:00:09.000000", true},
+		{"00/00/0000", "%m/%d/%Y", "0000-00-00 00:00:00.000000", true},
+		{"04/31/2004", "%m/%d/%Y", "", false},
+		{"2004", "%Y-%m-%d", "2004-00-00 00:00:00.000000", true},
+		{"13-1-5", "%y-%c-%e", "2013-01-05 00:00:00.000000", true},
+		{"99-01-05", "%Y-%m-%d", "1999-01-05 00:00:00.000000", true},
+		{"15:35:00.123", "%H:%i:%s.%f", "0000-00-00 15:35:00.123000", true},
+		{"03:12:59 PM", "%r", "0000-00-00 15:12:59.000000", true},
+		{"12:12:59 am", "%h:%i:%s %p", "0000-00-00 00:12:59.000000", true},
+		{"13:12:59 PM", "%h:%i:%s %p", "", false},
+		{"15:12:59 PM", "%H:%i:%s %p", "", false},
+		{"2020-32", "%Y-%j", "2020-02-01 00:00:00.000000", true},
+		{"200442 Monday", "%X%V %W", "2004-10-18 00:00:00.000000", true},
+		{"200442 Mon", "%Y%u %a", "2004-10-11 00:00:00.000000", true},
+		{"1st of Jan 2021", "%D of %b %Y", "2021-01-01 00:00:00.000000", true},
+		{"2021 Ju", "%Y %b", "", false},
+		{"2021-06-01 10:11:12", "%Y-%m-%d %T", "2021-06-01 10:11:12.000000", true},
+		{"2021!!06?01", "%Y%.%m%.%d", "2021-06-01 00:00:00.000000", true},
+		{"25:00", "%H:%i", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value+"/"+tc.format, func(t *testing.T) {
+			dt, ok := StrToDate(tc.value, tc.format)
+			assert.Equal(t, tc.ok, ok)
+			if ok {
+				assert.Equal(t, tc.output, string(dt.Format(6)))
+			}
+		})
+	}
+}
+
+func TestStrToDateParts(t *testing.T) {
+	assert.Equal(t, StrToDateDate, ParseStrToDateParts("%Y-%m-%d"))
+	assert.Equal(t, StrToDateTime, ParseStrToDateParts("%H:%i"))
+	assert.Equal(t, StrToDateTime|StrToDateFrac, ParseStrToDateParts("%s.%f"))
+	assert.Equal(t, StrToDateDate|StrToDateTime, ParseStrToDateParts("%Y %r"))
+	assert.Equal(t, StrToDateParts(0), ParseStrToDateParts("abc%"))
+}
+
+func TestGetFormat(t *testing.T) {
+	f, ok := GetFormat("date", "usa")
+	assert.True(t, ok)
+	assert.Equal(t, "%m.%d.%Y", f)
+
+	f, ok = GetFormat("TIMESTAMP", "INTERNAL")
+	assert.True(t, ok)
+	assert.Equal(t, "%Y%m%d%H%i%s", f)
+
+	_, ok = GetFormat("time", "nope")
+	assert.False(t, ok)
+}
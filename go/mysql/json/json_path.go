@@ -217,20 +217,45 @@ func (jp *Path) Match(doc *Value, wrap bool, match func(value *Value)) {
 	m.value(jp, doc)
 }
 
-func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
-	if v == nil {
+// Walk calls f for v and every value nested in it in document order, along with
+// the path expression that leads to each value when v is found at prefix.
+// The values nested in a value are skipped when f returns false for it.
+func (v *Value) Walk(prefix string, f func(path string, value *Value) bool) {
+	if !f(prefix, v) {
 		return
 	}
+	switch v.t {
+	case TypeObject:
+		v.o.Visit(func(key string, vv *Value) {
+			var b strings.Builder
+			b.WriteString(prefix)
+			(&Path{kind: jpMember, name: key}).format(&b)
+			vv.Walk(b.String(), f)
+		})
+	case TypeArray:
+		for i, vv := range v.a {
+			vv.Walk(prefix+"["+strconv.Itoa(i)+"]", f)
+		}
+	}
+}
+
+// transform calls t with the last leg of jp and the value it applies to,
+// and replaces that value in v with the one returned by t.
+func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value) *Value) *Value {
+	if v == nil {
+		return nil
+	}
 	if jp.next == nil {
-		t(jp, v)
-		return
+		return t(jp, v)
 	}
 	switch jp.kind {
 	case jpDocumentRoot:
-		jp.next.transform(v, t)
+		return jp.next.transform(v, t)
 	case jpMember:
 		if obj, ok := v.Object(); ok {
-			jp.next.transform(obj.Get(jp.name), t)
+			if vv := obj.Get(jp.name); vv != nil {
+				obj.Set(jp.name, jp.next.transform(vv, t), Replace)
+			}
 		}
 	case jpArrayLocation:
 		if ary, ok := v.Array(); ok {
@@ -239,7 +264,7 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				panic("range in transformation path expression")
 			}
 			if from >= 0 && from < len(ary) {
-				jp.next.transform(ary[from], t)
+				ary[from] = jp.next.transform(ary[from], t)
 			}
 		} else if jp.offset0 == 0 || jp.offset0 == -1 {
 			/*
@@ -247,11 +272,12 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				the result of the evaluation is the same as if the value had been
 				wrapped in a single-element array:
 			*/
-			jp.next.transform(v, t)
+			return jp.next.transform(v, t)
 		}
 	case jpMemberAny, jpArrayLocationAny, jpAny:
 		panic("wildcard in transformation path expression")
 	}
+	return v
 }
 
 type Transformation int
@@ -263,38 +289,74 @@ const (
 	Remove
 )
 
-func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) error {
+var (
+	errInvalidPathForTransform = vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
+	errRootPathForRemove       = vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "The path expression '$' is not allowed in this context.")
+)
+
+// ApplyTransform applies t to doc at each one of the paths in order, with the
+// semantics of MySQL's JSON_SET, JSON_INSERT, JSON_REPLACE and JSON_REMOVE.
+// doc is modified in place, so it must be cloned first if it is shared. The
+// transformed document is returned, since the root of doc can be replaced.
+func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) (*Value, error) {
 	if t != Remove && len(paths) != len(values) {
 		panic("missing Values for transformation")
 	}
+	for _, p := range paths {
+		if p.ContainsWildcards() {
+			return nil, errInvalidPathForTransform
+		}
+		if t == Remove && p.next == nil {
+			return nil, errRootPathForRemove
+		}
+	}
 	for i, p := range paths {
-		transform := func(pp *Path, vv *Value) {
+		var value *Value
+		if t != Remove {
+			value = values[i].Clone()
+		}
+		transform := func(pp *Path, vv *Value) *Value {
 			switch pp.kind {
+			case jpDocumentRoot:
+				if t == Set || t == Replace {
+					return value
+				}
 			case jpArrayLocation:
 				if ary, ok := vv.Array(); ok {
-					from, to := pp.arrayOffsets(ary)
-					if from != to {
-						return
-					}
+					from, _ := pp.arrayOffsets(ary)
 					if t == Remove {
 						vv.DelArrayItem(from)
 					} else {
-						vv.SetArrayItem(from, values[i], t)
+						vv.SetArrayItem(from, value, t)
 					}
+					break
+				}
+				// A value that is not an array is handled as if it had been
+				// wrapped in a single-element array.
+				idx := pp.offset0
+				if idx < 0 {
+					idx++
+				}
+				switch {
+				case idx == 0 && (t == Set || t == Replace):
+					return value
+				case idx > 0 && (t == Set || t == Insert):
+					return NewArray([]*Value{vv, value})
 				}
 			case jpMember:
 				if obj, ok := vv.Object(); ok {
 					if t == Remove {
 						obj.Del(pp.name)
 					} else {
-						obj.Set(pp.name, values[i], t)
+						obj.Set(pp.name, value, t)
 					}
 				}
 			}
+			return vv
 		}
-		p.transform(doc, transform)
+		doc = p.transform(doc, transform)
 	}
-	return nil
+	return doc, nil
 }
 
 func MatchPath(rawJSON, rawPath []byte, match func(value *Value)) error {
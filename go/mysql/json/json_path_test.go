@@ -171,6 +171,34 @@ func TestTransformations(t *testing.T) {
 			Paths:    []string{`$[2]`, `$[1].b[1]`, `$[1].b[1]`},
 			Expected: `["a", {"b": [true]}]`,
 		},
+		{
+			T:        Set,
+			Document: `{"a": 1, "b": [2, 3]}`,
+			Paths:    []string{`$.a[1]`, `$.b[5]`, `$.c`},
+			Values:   []string{"10", "20", `{"d": 30}`},
+			Expected: `{"a": [1, 10], "b": [2, 3, 20], "c": {"d": 30}}`,
+		},
+		{
+			T:        Insert,
+			Document: `{"a": 1, "b": [2, 3]}`,
+			Paths:    []string{`$.a[0]`, `$.b[1]`, `$.x.y`},
+			Values:   []string{"10", "20", "30"},
+			Expected: `{"a": 1, "b": [2, 3]}`,
+		},
+		{
+			T:        Replace,
+			Document: `{"a": 1, "b": [2, 3]}`,
+			Paths:    []string{`$.a[0]`, `$.b[last]`, `$.b[2]`},
+			Values:   []string{"10", "20", "30"},
+			Expected: `{"a": 10, "b": [2, 20]}`,
+		},
+		{
+			T:        Set,
+			Document: `[1, 2]`,
+			Paths:    []string{`$`},
+			Values:   []string{"true"},
+			Expected: `true`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -186,7 +214,7 @@ func TestTransformations(t *testing.T) {
 			values = append(values, json(t, v))
 		}
 
-		err := ApplyTransform(tc.T, doc, paths, values)
+		doc, err := ApplyTransform(tc.T, doc, paths, values)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -197,3 +225,30 @@ func TestTransformations(t *testing.T) {
 		}
 	}
 }
+
+func TestTransformationErrors(t *testing.T) {
+	doc := json(t, `{"a": [1, 2]}`)
+
+	_, err := ApplyTransform(Set, doc, []*Path{path(t, `$.a[*]`)}, []*Value{ValueNull})
+	if err == nil {
+		t.Errorf("expected an error for a wildcard path")
+	}
+	_, err = ApplyTransform(Remove, doc, []*Path{path(t, `$`)}, nil)
+	if err == nil {
+		t.Errorf("expected an error when removing the root")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	doc := json(t, `{"a": [1, {"b c": 2}], "d": 3}`)
+
+	var paths []string
+	doc.Walk("$", func(path string, _ *Value) bool {
+		paths = append(paths, path)
+		return path != "$.d"
+	})
+	expected := []string{"$", "$.a", "$.a[0]", "$.a[1]", `$.a[1]."b c"`, "$.d"}
+	if !slices.Equal(expected, paths) {
+		t.Errorf("Walk() = %v (expected %v)", paths, expected)
+	}
+}
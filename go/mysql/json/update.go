@@ -65,25 +65,19 @@ func (o *Object) Set(key string, value *Value, t Transformation) {
 }
 
 // SetArrayItem sets the value in the array v at idx position.
+// Like in MySQL, an idx past the end of the array appends the value.
 //
 // The value must be unchanged during v lifetime.
 func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
 	if v == nil || v.t != TypeArray || idx < 0 {
 		return
 	}
-	switch t {
-	case Insert:
-		if idx < len(v.a) {
-			return
-		}
-		fallthrough
-	case Set:
-		for idx >= len(v.a) {
-			v.a = append(v.a, ValueNull)
-		}
-	}
 	if idx < len(v.a) {
-		v.a[idx] = value
+		if t != Insert {
+			v.a[idx] = value
+		}
+	} else if t != Replace {
+		v.a = append(v.a, value)
 	}
 }
 
@@ -96,3 +90,85 @@ func (v *Value) DelArrayItem(n int) {
 	}
 	v.a = append(v.a[:n], v.a[n+1:]...)
 }
+
+// Clone returns a deep copy of v that can be transformed without modifying v.
+// Scalar values are never modified in place, so they are shared with v.
+func (v *Value) Clone() *Value {
+	switch v.t {
+	case TypeObject:
+		kvs := make([]kv, len(v.o.kvs))
+		for i, e := range v.o.kvs {
+			kvs[i] = kv{k: e.k, v: e.v.Clone()}
+		}
+		return &Value{o: Object{kvs: kvs}, t: TypeObject}
+	case TypeArray:
+		a := make([]*Value, len(v.a))
+		for i, e := range v.a {
+			a[i] = e.Clone()
+		}
+		return &Value{a: a, t: TypeArray}
+	default:
+		return v
+	}
+}
+
+// MergePreserve merges two documents like MySQL's JSON_MERGE_PRESERVE: two objects
+// are merged into one, merging the values of the keys they have in common, and any other
+// two values are concatenated as arrays, wrapping those which are not arrays.
+// Neither left nor right are modified.
+func MergePreserve(left, right *Value) *Value {
+	lo, lok := left.Object()
+	ro, rok := right.Object()
+	if lok && rok {
+		var obj Object
+		lo.Visit(func(key string, v *Value) {
+			obj.Add(key, v)
+		})
+		ro.Visit(func(key string, v *Value) {
+			if prev := obj.Get(key); prev != nil {
+				obj.Set(key, MergePreserve(prev, v), Replace)
+			} else {
+				obj.Set(key, v, Set)
+			}
+		})
+		return NewObject(obj)
+	}
+
+	var ary []*Value
+	for _, v := range []*Value{left, right} {
+		if a, ok := v.Array(); ok {
+			ary = append(ary, a...)
+		} else {
+			ary = append(ary, v)
+		}
+	}
+	return NewArray(ary)
+}
+
+// MergePatch applies patch to target as described in RFC 7396, like MySQL's
+// JSON_MERGE_PATCH. Neither target nor patch are modified.
+func MergePatch(target, patch *Value) *Value {
+	po, ok := patch.Object()
+	if !ok {
+		return patch
+	}
+
+	var obj Object
+	if to, ok := target.Object(); ok {
+		to.Visit(func(key string, v *Value) {
+			obj.Add(key, v)
+		})
+	}
+	po.Visit(func(key string, v *Value) {
+		if v.t == TypeNull {
+			obj.Del(key)
+			return
+		}
+		prev := obj.Get(key)
+		if prev == nil {
+			prev = ValueNull
+		}
+		obj.Set(key, MergePatch(prev, v), Set)
+	})
+	return NewObject(obj)
+}
@@ -106,3 +106,47 @@ func TestValueDelSet(t *testing.T) {
 		t.Fatalf("unexpected number of items left in the array; got %d; want %d", len(a), 2)
 	}
 }
+
+func TestClone(t *testing.T) {
+	v := MustParse(`{"a": [1, {"b": true}]}`)
+	c := v.Clone()
+
+	o, _ := c.Object()
+	a, _ := o.Get("a").Array()
+	b, _ := a[1].Object()
+	b.Set("b", ValueFalse, Set)
+	o.Del("a")
+
+	if got := string(v.MarshalTo(nil)); got != `{"a": [1, {"b": true}]}` {
+		t.Fatalf("original value was modified: %s", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		Left, Right     string
+		Preserve, Patch string
+	}{
+		{`[1, 2]`, `[true, false]`, `[1, 2, true, false]`, `[true, false]`},
+		{`{"name": "x"}`, `{"id": 47}`, `{"id": 47, "name": "x"}`, `{"id": 47, "name": "x"}`},
+		{`1`, `true`, `[1, true]`, `true`},
+		{`[1, 2]`, `{"id": 47}`, `[1, 2, {"id": 47}]`, `{"id": 47}`},
+		{`{"a": 1, "b": 2}`, `{"a": 3, "c": 4}`, `{"a": [1, 3], "b": 2, "c": 4}`, `{"a": 3, "b": 2, "c": 4}`},
+		{`{"a": 1, "b": 2}`, `{"b": null}`, `{"a": 1, "b": [2, null]}`, `{"a": 1}`},
+		{`{"a": {"x": 1}}`, `{"a": {"y": 2, "z": null}}`, `{"a": {"x": 1, "y": 2, "z": null}}`, `{"a": {"x": 1, "y": 2}}`},
+	}
+
+	for _, tc := range cases {
+		left, right := MustParse(tc.Left), MustParse(tc.Right)
+
+		if got := string(MergePreserve(left, right).MarshalTo(nil)); got != tc.Preserve {
+			t.Errorf("MergePreserve(%s, %s) = %s (expected %s)", tc.Left, tc.Right, got, tc.Preserve)
+		}
+		if got := string(MergePatch(left, right).MarshalTo(nil)); got != tc.Patch {
+			t.Errorf("MergePatch(%s, %s) = %s (expected %s)", tc.Left, tc.Right, got, tc.Patch)
+		}
+		if got := string(left.MarshalTo(nil)); got != tc.Left {
+			t.Errorf("left document was modified: %s", got)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"context"
+	"io"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/vt/log"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// LocalInfileRequester requests the content of a client side file
+// while a LOAD DATA LOCAL INFILE statement is executed.
+type LocalInfileRequester interface {
+	// RequestLocalInfile asks the client for the content of the file.
+	// The returned reader must be read until io.EOF.
+	RequestLocalInfile(fileName string) (io.Reader, error)
+}
+
+type localInfileRequesterKey struct{}
+
+// NewContextWithLocalInfileRequester returns a context that carries
+// the requester used by LOAD DATA LOCAL INFILE statements.
+func NewContextWithLocalInfileRequester(ctx context.Context, requester LocalInfileRequester) context.Context {
+	return context.WithValue(ctx, localInfileRequesterKey{}, requester)
+}
+
+// LocalInfileRequesterFromContext returns the requester stored in ctx, if any.
+func LocalInfileRequesterFromContext(ctx context.Context) (LocalInfileRequester, bool) {
+	requester, ok := ctx.Value(localInfileRequesterKey{}).(LocalInfileRequester)
+	return requester, ok
+}
+
+// RequestLocalInfile implements LocalInfileRequester. It sends a
+// LOCAL INFILE request for fileName to the client, and returns a reader
+// over the packets the client sends back. The reader reaches io.EOF on
+// the empty packet that terminates the file.
+// It can only be called on the server side while a query is executed.
+func (c *Conn) RequestLocalInfile(fileName string) (io.Reader, error) {
+	if c.Capabilities&CapabilityClientLocalFiles == 0 {
+		return nil, sqlerror.NewSQLError(sqlerror.ERClientLocalFilesDisabled, sqlerror.SSClientError, "Loading local data is disabled; this must be enabled on both the client and server sides")
+	}
+	if c.localInfile != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "a local infile was already requested for this statement")
+	}
+
+	data, pos := c.startEphemeralPacketWithHeader(1 + len(fileName))
+	pos = writeByte(data, pos, LocalInfilePacket)
+	copy(data[pos:], fileName)
+	if err := c.writeEphemeralPacket(); err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	// The client will not answer until it has seen the request.
+	if err := c.flush(); err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+
+	c.localInfile = &localInfileReader{c: c}
+	return c.localInfile, nil
+}
+
+// flush writes the buffered packets to the client.
+func (c *Conn) flush() error {
+	c.bufMu.Lock()
+	defer c.bufMu.Unlock()
+
+	if c.bufferedWriter == nil {
+		return nil
+	}
+	return c.bufferedWriter.Flush()
+}
+
+// finishLocalInfile discards the rest of the local infile, if one was
+// requested, so that the result of the statement can be sent.
+// An error means the connection is no longer usable, and is returned
+// again by subsequent calls.
+func (c *Conn) finishLocalInfile() error {
+	if c.localInfile == nil {
+		return nil
+	}
+	if _, err := io.Copy(io.Discard, c.localInfile); err != nil {
+		log.Errorf("Error reading local infile from %s: %v", c, err)
+		return err
+	}
+	c.localInfile = nil
+	return nil
+}
+
+// localInfileReader reads the content of a local infile sent by the client.
+type localInfileReader struct {
+	c    *Conn
+	data []byte
+	err  error
+}
+
+// Read is part of the io.Reader interface.
+func (r *localInfileReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		data, err := r.c.readPacket()
+		switch {
+		case err != nil:
+			r.err = err
+		case len(data) == 0:
+			r.err = io.EOF
+		default:
+			r.data = data
+		}
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysql
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+// localInfileHandler requests a local infile and reads up to limit
+// bytes of it.
+type localInfileHandler struct {
+	testRun
+	limit   int64
+	content []byte
+}
+
+func (h *localInfileHandler) ComQuery(c *Conn, query string, callback func(*sqltypes.Result) error) error {
+	r, err := c.RequestLocalInfile(query)
+	if err != nil {
+		return err
+	}
+	h.content, err = io.ReadAll(io.LimitReader(r, h.limit))
+	if err != nil {
+		return err
+	}
+	return callback(&sqltypes.Result{RowsAffected: uint64(len(h.content))})
+}
+
+func TestLocalInfile(t *testing.T) {
+	tcases := []struct {
+		name     string
+		limit    int64
+		expected string
+	}{{
+		name:     "whole file",
+		limit:    1 << 20,
+		expected: "1,a\n2,b\n3,c\n",
+	}, {
+		name:     "partially read file",
+		limit:    5,
+		expected: "1,a\n2",
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			listener, sConn, cConn := createSocketPair(t)
+			defer func() {
+				listener.Close()
+				sConn.Close()
+				cConn.Close()
+			}()
+			sConn.Capabilities |= CapabilityClientLocalFiles
+
+			handler := &localInfileHandler{limit: tcase.limit}
+			done := make(chan bool)
+			go func() {
+				done <- sConn.handleNextCommand(handler)
+			}()
+
+			require.NoError(t, cConn.WriteComQuery("/tmp/data.csv"))
+			request, err := cConn.readPacket()
+			require.NoError(t, err)
+			assert.EqualValues(t, LocalInfilePacket, request[0])
+			assert.Equal(t, "/tmp/data.csv", string(request[1:]))
+
+			useWritePacket(t, cConn, []byte("1,a\n2,"))
+			useWritePacket(t, cConn, []byte("b\n3,c\n"))
+			useWritePacket(t, cConn, nil)
+
+			result, _, _, err := cConn.ReadQueryResult(100, true)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(tcase.expected), result.RowsAffected)
+			assert.Equal(t, tcase.expected, string(handler.content))
+			assert.True(t, <-done)
+		})
+	}
+}
+
+func TestLocalInfileDisabled(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	require.NoError(t, cConn.WriteComQuery("/tmp/data.csv"))
+	assert.True(t, sConn.handleNextCommand(&localInfileHandler{}))
+
+	_, _, _, err := cConn.ReadQueryResult(100, true)
+	require.Error(t, err)
+	assert.Equal(t, sqlerror.ERClientLocalFilesDisabled, err.(*sqlerror.SQLError).Number())
+}
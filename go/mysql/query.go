@@ -58,7 +58,7 @@ const (
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) WriteComQuery(query string) error {
 	// This is a new command, need to reset the sequence.
-	c.sequence = 0
+	c.resetSequence()
 
 	data, pos := c.startEphemeralPacketWithHeader(len(query) + 1)
 	data[pos] = ComQuery
@@ -84,6 +84,30 @@ func (c *Conn) writeComInitDB(db string) error {
 	return nil
 }
 
+// writeComChangeUser writes a COM_CHANGE_USER packet, with the
+// password scrambled for the current auth method of the connection.
+// Returns SQLError(CRServerGone) if it can't.
+func (c *Conn) writeComChangeUser(user string, scrambledPassword []byte, db string, characterSet uint16) error {
+	length := 1 + // ComChangeUser
+		lenNullString(user) +
+		1 + len(scrambledPassword) +
+		lenNullString(db) +
+		2 + // Character set.
+		lenNullString(string(c.authPluginName))
+	data, pos := c.startEphemeralPacketWithHeader(length)
+	pos = writeByte(data, pos, ComChangeUser)
+	pos = writeNullString(data, pos, user)
+	pos = writeByte(data, pos, byte(len(scrambledPassword)))
+	pos += copy(data[pos:], scrambledPassword)
+	pos = writeNullString(data, pos, db)
+	pos = writeUint16(data, pos, characterSet)
+	_ = writeNullString(data, pos, string(c.authPluginName))
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLError(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, err.Error())
+	}
+	return nil
+}
+
 // writeComSetOption changes the connection's capability of executing multi statements.
 // Returns SQLError(CRServerGone) if it can't.
 func (c *Conn) writeComSetOption(operation uint16) error {
@@ -957,6 +981,15 @@ func (c *Conn) parseComStmtReset(data []byte) (uint32, bool) {
 	return val, ok
 }
 
+func (c *Conn) parseComStmtFetch(data []byte) (stmtID uint32, numRows uint32, ok bool) {
+	stmtID, pos, ok := readUint32(data, 1)
+	if !ok {
+		return 0, 0, false
+	}
+	numRows, _, ok = readUint32(data, pos)
+	return stmtID, numRows, ok
+}
+
 func (c *Conn) parseComInitDB(data []byte) string {
 	return string(data[1:])
 }
@@ -1077,12 +1110,17 @@ func (c *Conn) writeRows(result *sqltypes.Result) error {
 // writeEndResult concludes the sending of a Result.
 // if more is set to true, then it means there are more results afterwords
 func (c *Conn) writeEndResult(more bool, affectedRows, lastInsertID uint64, warnings uint16) error {
-	// Send either an EOF, or an OK packet.
-	// See doc.go.
 	flags := c.StatusFlags
 	if more {
 		flags |= ServerMoreResultsExists
 	}
+	return c.writeEndResultWithFlags(flags, affectedRows, lastInsertID, warnings)
+}
+
+// writeEndResultWithFlags is writeEndResult with the given status flags.
+func (c *Conn) writeEndResultWithFlags(flags uint16, affectedRows, lastInsertID uint64, warnings uint16) error {
+	// Send either an EOF, or an OK packet.
+	// See doc.go.
 	if c.Capabilities&CapabilityClientDeprecateEOF == 0 {
 		if err := c.writeEOFPacket(flags, warnings); err != nil {
 			return err
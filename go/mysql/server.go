@@ -74,6 +74,12 @@ var (
 		}
 		return connCount.Get() - totalUsers
 	})
+
+	connCountByCompression    = stats.NewGaugesWithSingleLabel("MysqlServerConnCountByCompression", "Active MySQL server connections using the compressed protocol by algorithm", "algorithm")
+	compressedBytesSent       = stats.NewCounter("MysqlServerCompressedBytesSent", "Bytes sent by MySQL server in compressed packets, as sent over the network")
+	compressedBytesReceived   = stats.NewCounter("MysqlServerCompressedBytesReceived", "Bytes received by MySQL server in compressed packets, as received over the network")
+	uncompressedBytesSent     = stats.NewCounter("MysqlServerUncompressedBytesSent", "Bytes sent by MySQL server in compressed packets, before compression")
+	uncompressedBytesReceived = stats.NewCounter("MysqlServerUncompressedBytesReceived", "Bytes received by MySQL server in compressed packets, after decompression")
 )
 
 // A Handler is an interface used by Listener to send queries.
@@ -115,7 +121,9 @@ type Handler interface {
 	ComPrepare(c *Conn, query string) ([]*querypb.Field, uint16, error)
 
 	// ComStmtExecute is called when a connection receives a statement
-	// execute query.
+	// execute query. If prepare.CursorType is CursorTypeReadOnly, it runs
+	// in its own goroutine while the connection processes other commands,
+	// and each callback blocks until the rows were fetched by the client.
 	ComStmtExecute(c *Conn, prepare *PrepareData, callback func(*sqltypes.Result) error) error
 
 	// ComRegisterReplica is called when a connection receives a ComRegisterReplica request
@@ -136,6 +144,12 @@ type Handler interface {
 
 	ComResetConnection(c *Conn)
 
+	// ComChangeUser is called when a connection was authenticated
+	// as another user with a COM_CHANGE_USER, after its session was
+	// reset with ComResetConnection. c.User and c.UserData are those
+	// of the new user.
+	ComChangeUser(c *Conn)
+
 	Env() *vtenv.Environment
 }
 
@@ -149,6 +163,7 @@ func (UnimplementedHandler) NewConnection(*Conn)      {}
 func (UnimplementedHandler) ConnectionReady(*Conn)    {}
 func (UnimplementedHandler) ConnectionClosed(*Conn)   {}
 func (UnimplementedHandler) ComResetConnection(*Conn) {}
+func (UnimplementedHandler) ComChangeUser(*Conn)      {}
 
 // Listener is the MySQL server protocol listener.
 type Listener struct {
@@ -181,6 +196,15 @@ type Listener struct {
 	// by the server when TLS is not in use.
 	AllowClearTextWithoutTLS atomic.Bool
 
+	// AllowLocalInfile needs to be set for the server to advertise
+	// CLIENT_LOCAL_FILES and accept LOAD DATA LOCAL INFILE.
+	AllowLocalInfile atomic.Bool
+
+	// CompressionAlgorithms are the algorithms of the compressed protocol
+	// the server advertises and accepts. The protocol is never
+	// compressed if it is empty.
+	CompressionAlgorithms []CompressionAlgorithm
+
 	// SlowConnectWarnThreshold if non-zero specifies an amount of time
 	// beyond which a warning is logged to identify the slow connection
 	SlowConnectWarnThreshold atomic.Int64
@@ -396,12 +420,13 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	// Tell the handler about the connection coming and going.
 	l.handler.NewConnection(c)
 	defer l.handler.ConnectionClosed(c)
+	defer c.closeCursors()
 
 	// Adjust the count of open connections
 	defer connCount.Add(-1)
 
 	// First build and send the server handshake packet.
-	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil)
+	serverAuthPluginData, err := c.writeHandshakeV10(l.ServerVersion, l.authServer, uint8(l.charset), l.TLSConfig.Load() != nil, l.AllowLocalInfile.Load(), l.CompressionAlgorithms)
 	if err != nil {
 		if err != io.EOF {
 			log.Errorf("Cannot send HandshakeV10 packet to %s: %v", c, err)
@@ -460,62 +485,8 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		defer connCountByTLSVer.Add(versionNoTLS, -1)
 	}
 
-	// See what auth method the AuthServer wants to use for that user.
-	negotiatedAuthMethod, err := negotiateAuthMethod(c, l.authServer, user, clientAuthMethod)
-
-	// We need to send down an additional packet if we either have no negotiated method
-	// at all or incomplete authentication data.
-	//
-	// The latter case happens for example for MySQL 8.0 clients until 8.0.25 who advertise
-	// support for caching_sha2_password by default but with no plugin data.
-	if err != nil || len(clientAuthResponse) == 0 {
-		// If we have no negotiated method yet, we pick the first one
-		// we know about ourselves as that's the last resort option we have here.
-		if err != nil {
-			// The client will disconnect if it doesn't understand
-			// the first auth method that we send, so we only have to send the
-			// first one that we allow for the user.
-			for _, m := range l.authServer.AuthMethods() {
-				if m.HandleUser(c, user) {
-					negotiatedAuthMethod = m
-					break
-				}
-			}
-		}
-
-		if negotiatedAuthMethod == nil {
-			c.writeErrorPacket(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "No authentication methods available for authentication.")
-			return
-		}
-
-		if !l.AllowClearTextWithoutTLS.Load() && !c.TLSEnabled() && !negotiatedAuthMethod.AllowClearTextWithoutTLS() {
-			c.writeErrorPacket(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "Cannot use clear text authentication over non-SSL connections.")
-			return
-		}
-
-		serverAuthPluginData, err = negotiatedAuthMethod.AuthPluginData()
-		if err != nil {
-			log.Errorf("Error generating auth switch packet for %s: %v", c, err)
-			return
-		}
-
-		if err := c.writeAuthSwitchRequest(string(negotiatedAuthMethod.Name()), serverAuthPluginData); err != nil {
-			log.Errorf("Error writing auth switch packet for %s: %v", c, err)
-			return
-		}
-
-		clientAuthResponse, err = c.readEphemeralPacket()
-		if err != nil {
-			log.Errorf("Error reading auth switch response for %s: %v", c, err)
-			return
-		}
-		c.recycleReadPacket()
-	}
-
-	userData, err := negotiatedAuthMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, conn.RemoteAddr())
+	userData, err := l.authenticate(c, user, clientAuthMethod, clientAuthResponse, serverAuthPluginData)
 	if err != nil {
-		log.Warningf("Error authenticating user %s using: %s", user, negotiatedAuthMethod.Name())
-		c.writeErrorPacketFromError(err)
 		return
 	}
 
@@ -524,8 +495,13 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 
 	if c.User != "" {
 		connCountPerUser.Add(c.User, 1)
-		defer connCountPerUser.Add(c.User, -1)
 	}
+	// The user can change with a COM_CHANGE_USER.
+	defer func() {
+		if c.User != "" {
+			connCountPerUser.Add(c.User, -1)
+		}
+	}()
 
 	// Set initial db name.
 	if c.schemaName != "" {
@@ -544,6 +520,14 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 		return
 	}
 
+	// The packets following the OK packet are compressed.
+	if algorithm := c.negotiatedCompression(); algorithm != "" {
+		c.enableCompression(algorithm, c.zstdCompressionLevel)
+		c.compression.server = true
+		connCountByCompression.Add(string(algorithm), 1)
+		defer connCountByCompression.Add(string(algorithm), -1)
+	}
+
 	// Record how long we took to establish the connection
 	timings.Record(connectTimingKey, acceptTime)
 
@@ -567,6 +551,76 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 	}
 }
 
+// authenticate runs the authentication of user with the auth method
+// negotiated with the AuthServer, once the client sent its first auth
+// response computed with serverAuthPluginData. It writes the error packet
+// to the client when the authentication fails, and remembers the last auth
+// plugin data sent to the client so a COM_CHANGE_USER can reuse it.
+func (l *Listener) authenticate(c *Conn, user string, clientAuthMethod AuthMethodDescription, clientAuthResponse, serverAuthPluginData []byte) (Getter, error) {
+	// See what auth method the AuthServer wants to use for that user.
+	negotiatedAuthMethod, err := negotiateAuthMethod(c, l.authServer, user, clientAuthMethod)
+
+	// We need to send down an additional packet if we either have no negotiated method
+	// at all or incomplete authentication data.
+	//
+	// The latter case happens for example for MySQL 8.0 clients until 8.0.25 who advertise
+	// support for caching_sha2_password by default but with no plugin data.
+	if err != nil || len(clientAuthResponse) == 0 {
+		// If we have no negotiated method yet, we pick the first one
+		// we know about ourselves as that's the last resort option we have here.
+		if err != nil {
+			// The client will disconnect if it doesn't understand
+			// the first auth method that we send, so we only have to send the
+			// first one that we allow for the user.
+			for _, m := range l.authServer.AuthMethods() {
+				if m.HandleUser(c, user) {
+					negotiatedAuthMethod = m
+					break
+				}
+			}
+		}
+
+		if negotiatedAuthMethod == nil {
+			err := sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "No authentication methods available for authentication.")
+			c.writeErrorPacketFromError(err)
+			return nil, err
+		}
+
+		if !l.AllowClearTextWithoutTLS.Load() && !c.TLSEnabled() && !negotiatedAuthMethod.AllowClearTextWithoutTLS() {
+			err := sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "Cannot use clear text authentication over non-SSL connections.")
+			c.writeErrorPacketFromError(err)
+			return nil, err
+		}
+
+		serverAuthPluginData, err = negotiatedAuthMethod.AuthPluginData()
+		if err != nil {
+			log.Errorf("Error generating auth switch packet for %s: %v", c, err)
+			return nil, err
+		}
+
+		if err := c.writeAuthSwitchRequest(string(negotiatedAuthMethod.Name()), serverAuthPluginData); err != nil {
+			log.Errorf("Error writing auth switch packet for %s: %v", c, err)
+			return nil, err
+		}
+
+		clientAuthResponse, err = c.readEphemeralPacket()
+		if err != nil {
+			log.Errorf("Error reading auth switch response for %s: %v", c, err)
+			return nil, err
+		}
+		c.recycleReadPacket()
+	}
+
+	userData, err := negotiatedAuthMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, c.conn.RemoteAddr())
+	if err != nil {
+		log.Warningf("Error authenticating user %s using: %s", user, negotiatedAuthMethod.Name())
+		c.writeErrorPacketFromError(err)
+		return nil, err
+	}
+	c.salt = serverAuthPluginData
+	return userData, nil
+}
+
 // Close stops the listener, which prevents accept of any new connections. Existing connections won't be closed.
 func (l *Listener) Close() {
 	l.listener.Close()
@@ -582,7 +636,7 @@ func (l *Listener) Shutdown() {
 
 // writeHandshakeV10 writes the Initial Handshake Packet, server side.
 // It returns the salt data.
-func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool) ([]byte, error) {
+func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, charset uint8, enableTLS bool, enableLocalInfile bool, compressionAlgorithms []CompressionAlgorithm) ([]byte, error) {
 	capabilities := CapabilityClientLongPassword |
 		CapabilityClientFoundRows |
 		CapabilityClientLongFlag |
@@ -599,6 +653,10 @@ func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, ch
 	if enableTLS {
 		capabilities |= CapabilityClientSSL
 	}
+	if enableLocalInfile {
+		capabilities |= CapabilityClientLocalFiles
+	}
+	capabilities |= int(compressionCapabilities(compressionAlgorithms))
 
 	// Grab the default auth method. This can only be either
 	// mysql_native_password or caching_sha2_password. Both
@@ -717,6 +775,18 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 	// after SSL negotiation, do not overwrite capabilities.
 	if firstTime {
 		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows)
+		// The format of a COM_CHANGE_USER packet depends on these flags.
+		c.Capabilities |= clientFlags & (CapabilityClientSecureConnection | CapabilityClientPluginAuth | CapabilityClientConnAttr)
+		if l.AllowLocalInfile.Load() {
+			c.Capabilities |= clientFlags & CapabilityClientLocalFiles
+		}
+		// Like MySQL, zlib is used if the client asks for both algorithms.
+		switch allowed := compressionCapabilities(l.CompressionAlgorithms); {
+		case clientFlags&allowed&CapabilityClientCompress != 0:
+			c.Capabilities |= CapabilityClientCompress
+		case clientFlags&allowed&CapabilityClientZstdCompressionAlgorithm != 0:
+			c.Capabilities |= CapabilityClientZstdCompressionAlgorithm
+		}
 	}
 
 	// set connection capability for executing multi statements
@@ -816,14 +886,90 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 
 	// Decode connection attributes send by the client
 	if clientFlags&CapabilityClientConnAttr != 0 {
-		if _, _, err := parseConnAttrs(data, pos); err != nil {
+		var err error
+		if _, pos, err = parseConnAttrs(data, pos); err != nil {
 			log.Warningf("Decode connection attributes send by the client: %v", err)
 		}
 	}
 
+	// zstd compression level, sent by the client if it asks for zstd.
+	// pos is 0 if the connection attributes could not be decoded.
+	if clientFlags&CapabilityClientZstdCompressionAlgorithm != 0 && pos != 0 {
+		if level, _, ok := readByte(data, pos); ok {
+			c.zstdCompressionLevel = int(level)
+		}
+	}
+
 	return username, AuthMethodDescription(authMethod), authResponse, nil
 }
 
+// parseComChangeUser parses a COM_CHANGE_USER packet. Its format depends
+// on the capabilities the client sent in its handshake response.
+func (c *Conn) parseComChangeUser(data []byte) (user string, authMethod AuthMethodDescription, authResponse []byte, schemaName string, err error) {
+	// Skip the command byte.
+	pos := 1
+
+	user, pos, ok := readNullString(data, pos)
+	if !ok {
+		return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read username")
+	}
+
+	if c.Capabilities&CapabilityClientSecureConnection != 0 {
+		var l byte
+		l, pos, ok = readByte(data, pos)
+		if !ok {
+			return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response length")
+		}
+		authResponse, pos, ok = readBytesCopy(data, pos, int(l))
+		if !ok {
+			return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response")
+		}
+	} else {
+		var a string
+		a, pos, ok = readNullString(data, pos)
+		if !ok {
+			return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read auth-response")
+		}
+		authResponse = []byte(a)
+	}
+
+	schemaName, pos, ok = readNullString(data, pos)
+	if !ok {
+		return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read dbname")
+	}
+
+	// The rest of the packet is optional.
+	authMethod = MysqlNativePassword
+	if pos == len(data) {
+		return user, authMethod, authResponse, schemaName, nil
+	}
+
+	characterSet, pos, ok := readUint16(data, pos)
+	if !ok {
+		return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read characterSet")
+	}
+	c.CharacterSet = collations.ID(characterSet)
+
+	if c.Capabilities&CapabilityClientPluginAuth != 0 {
+		var authMethodStr string
+		authMethodStr, pos, ok = readNullString(data, pos)
+		if !ok {
+			return "", "", nil, "", vterrors.Errorf(vtrpc.Code_INTERNAL, "parseComChangeUser: can't read authMethod")
+		}
+		if authMethodStr != "" {
+			authMethod = AuthMethodDescription(authMethodStr)
+		}
+	}
+
+	if c.Capabilities&CapabilityClientConnAttr != 0 && pos < len(data) {
+		if _, _, err := parseConnAttrs(data, pos); err != nil {
+			log.Warningf("Decode connection attributes send by the client: %v", err)
+		}
+	}
+
+	return user, authMethod, authResponse, schemaName, nil
+}
+
 func parseConnAttrs(data []byte, pos int) (map[string]string, int, error) {
 	var attrLen uint64
 
@@ -94,6 +94,11 @@ func (h *Histogram) Add(value int64) {
 	if defaultStatsdHook.histogramHook != nil && h.name != "" {
 		defaultStatsdHook.histogramHook(h.name, value)
 	}
+	if observers := histogramObservers.Load(); observers != nil && h.name != "" {
+		for _, observe := range *observers {
+			observe(h.name, value, h)
+		}
+	}
 }
 
 // String returns a string representation of the Histogram.
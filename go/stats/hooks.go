@@ -16,6 +16,12 @@ limitations under the License.
 
 package stats
 
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 type statsdHook struct {
 	timerHook     func(string, string, int64, *Timings)
 	histogramHook func(string, int64)
@@ -32,3 +38,44 @@ func RegisterTimerHook(hook func(string, string, int64, *Timings)) {
 func RegisterHistogramHook(hook func(string, int64)) {
 	defaultStatsdHook.histogramHook = hook
 }
+
+type (
+	timingsObserver   func(statsName, name string, elapsed time.Duration, t *Timings)
+	histogramObserver func(statsName string, value int64, h *Histogram)
+)
+
+var (
+	observersMu sync.Mutex
+	// The observer lists are replaced rather than appended to in place, so
+	// that Add can load them without taking observersMu.
+	timingsObservers   atomic.Pointer[[]timingsObserver]
+	histogramObservers atomic.Pointer[[]histogramObserver]
+)
+
+// RegisterTimingsObserver registers a function that is called with every
+// sample added to a published Timings or MultiTimings. Unlike the timer hook,
+// the sample is passed at full precision and several observers may be
+// registered.
+func RegisterTimingsObserver(observer func(statsName, name string, elapsed time.Duration, t *Timings)) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	var observers []timingsObserver
+	if old := timingsObservers.Load(); old != nil {
+		observers = append(observers, *old...)
+	}
+	observers = append(observers, observer)
+	timingsObservers.Store(&observers)
+}
+
+// RegisterHistogramObserver registers a function that is called with every
+// value added to a published Histogram. Several observers may be registered.
+func RegisterHistogramObserver(observer func(statsName string, value int64, h *Histogram)) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	var observers []histogramObserver
+	if old := histogramObservers.Load(); old != nil {
+		observers = append(observers, *old...)
+	}
+	observers = append(observers, observer)
+	histogramObservers.Store(&observers)
+}
@@ -16,7 +16,9 @@ limitations under the License.
 package stats
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -56,3 +58,29 @@ func TestStatsdHook(t *testing.T) {
 		defaultStatsdHook.histogramHook("dummyName", 42)
 	})
 }
+
+func TestObservers(t *testing.T) {
+	defaultStatsdHook = statsdHook{}
+	defer timingsObservers.Store(nil)
+	defer histogramObservers.Store(nil)
+
+	var timingsSamples, histogramSamples []string
+	for _, prefix := range []string{"a", "b"} {
+		RegisterTimingsObserver(func(statsName, name string, elapsed time.Duration, timings *Timings) {
+			timingsSamples = append(timingsSamples, fmt.Sprintf("%s:%s:%s:%v", prefix, statsName, name, elapsed))
+		})
+		RegisterHistogramObserver(func(statsName string, value int64, h *Histogram) {
+			histogramSamples = append(histogramSamples, fmt.Sprintf("%s:%s:%d", prefix, statsName, value))
+		})
+	}
+
+	clearStats()
+	timings := NewTimings("ObservedTimings", "help", "Category")
+	timings.Add("Read", 1500*time.Microsecond)
+	NewTimings("", "help", "Category").Add("Unpublished", time.Second)
+	hist := NewHistogram("ObservedHistogram", "help", []int64{1, 5})
+	hist.Add(3)
+
+	assert.Equal(t, []string{"a:ObservedTimings:Read:1.5ms", "b:ObservedTimings:Read:1.5ms"}, timingsSamples)
+	assert.Equal(t, []string{"a:ObservedHistogram:3", "b:ObservedHistogram:3"}, histogramSamples)
+}
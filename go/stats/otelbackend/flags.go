@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelbackend
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/utils"
+)
+
+var (
+	otelMetricsEndpoint     string
+	otelMetricsInsecure     bool
+	otelMetricsPushInterval = time.Minute
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	utils.SetFlagStringVar(fs, &otelMetricsEndpoint, "otel-metrics-endpoint", otelMetricsEndpoint, "host:port of the OTLP gRPC collector to push metrics to. The OpenTelemetry metrics backend is disabled when empty.")
+	utils.SetFlagBoolVar(fs, &otelMetricsInsecure, "otel-metrics-insecure", otelMetricsInsecure, "Push metrics to the OTLP collector without TLS.")
+	utils.SetFlagDurationVar(fs, &otelMetricsPushInterval, "otel-metrics-push-interval", otelMetricsPushInterval, "Interval at which metrics are pushed to the OTLP collector.")
+}
+
+func init() {
+	servenv.OnParseFor("vtctld", registerFlags)
+	servenv.OnParseFor("vtgate", registerFlags)
+	servenv.OnParseFor("vttablet", registerFlags)
+}
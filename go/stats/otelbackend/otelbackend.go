@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package otelbackend exports go/stats variables as OpenTelemetry metrics and
+// pushes them to an OTLP collector.
+//
+// Counters and gauges are read from the published variables every time the
+// metrics are collected. Timings, MultiTimings and Histograms are recorded
+// sample by sample into exponential histograms.
+package otelbackend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+// Resource attribute keys describing where the metrics come from.
+const (
+	CellAttribute        = "vitess.cell"
+	KeyspaceAttribute    = "vitess.keyspace"
+	ShardAttribute       = "vitess.shard"
+	TabletAliasAttribute = "vitess.tablet_alias"
+)
+
+// instrumentationScope is the name of the meter the metrics are reported under.
+const instrumentationScope = "vitess.io/vitess/go/stats"
+
+var (
+	initOnce sync.Once
+	// active is the backend the stats observers record into.
+	active *backend
+)
+
+// Init creates the OpenTelemetry metrics backend for the given namespace and
+// starts pushing metrics to --otel-metrics-endpoint. It is a noop if no
+// endpoint is configured. The resource attributes, e.g. the cell, keyspace,
+// shard and tablet alias, are attached to every exported metric along with
+// the --stats-common-tags.
+func Init(namespace string, resourceAttributes map[string]string) {
+	if otelMetricsEndpoint == "" {
+		return
+	}
+	initOnce.Do(func() {
+		log.Info("Initializing OpenTelemetry metrics backend...")
+		b, err := InitWithoutServenv(namespace, resourceAttributes)
+		if err != nil {
+			log.Errorf("Failed to initialize OpenTelemetry metrics backend: %v", err)
+			return
+		}
+		servenv.OnClose(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := b.Shutdown(ctx); err != nil {
+				log.Warningf("Failed to flush OpenTelemetry metrics: %v", err)
+			}
+		})
+		log.Info("Initialized OpenTelemetry metrics backend.")
+	})
+}
+
+// InitWithoutServenv creates the OpenTelemetry metrics backend without
+// servenv, and returns it so the caller can shut it down.
+func InitWithoutServenv(namespace string, resourceAttributes map[string]string) (*sdkmetric.MeterProvider, error) {
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(otelMetricsEndpoint)}
+	if otelMetricsInsecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	exporter, err := otlpmetricgrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metrics exporter for %s: %w", otelMetricsEndpoint, err)
+	}
+	b, err := newBackend(namespace, resourceAttributes, func(p sdkmetric.Producer) sdkmetric.Reader {
+		return sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(otelMetricsPushInterval), sdkmetric.WithProducer(p))
+	})
+	if err != nil {
+		return nil, err
+	}
+	active = b
+	stats.RegisterTimingsObserver(func(statsName, name string, elapsed time.Duration, t *stats.Timings) {
+		active.recordTiming(statsName, name, elapsed, t)
+	})
+	stats.RegisterHistogramObserver(func(statsName string, value int64, h *stats.Histogram) {
+		active.recordHistogram(statsName, value, h)
+	})
+	return b.provider, nil
+}
+
+// backend owns the meter provider the stats are exported through. It acts as
+// the metric producer for the variables that are read at collection time, and
+// keeps the synchronous histogram instruments that samples are recorded into.
+type backend struct {
+	namespace string
+	start     time.Time
+	provider  *sdkmetric.MeterProvider
+	meter     metric.Meter
+
+	// timings and histograms cache the instruments by variable name, so
+	// that recording a sample doesn't take a lock once they are created.
+	timings    sync.Map // map[string]metric.Float64Histogram
+	histograms sync.Map // map[string]metric.Int64Histogram
+}
+
+func newBackend(namespace string, resourceAttributes map[string]string, newReader func(sdkmetric.Producer) sdkmetric.Reader) (*backend, error) {
+	res, err := newResource(namespace, resourceAttributes)
+	if err != nil {
+		return nil, err
+	}
+	b := &backend{
+		namespace: namespace,
+		start:     time.Now(),
+	}
+	b.provider = sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(newReader(b)),
+		sdkmetric.WithView(sdkmetric.NewView(
+			sdkmetric.Instrument{Kind: sdkmetric.InstrumentKindHistogram},
+			sdkmetric.Stream{Aggregation: sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 20}},
+		)),
+	)
+	b.meter = b.provider.Meter(instrumentationScope)
+	return b, nil
+}
+
+// newResource describes the process the metrics are exported from.
+func newResource(namespace string, resourceAttributes map[string]string) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{attribute.String("service.name", namespace)}
+	for k, v := range stats.ParseCommonTags(stats.CommonTags) {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	for k, v := range resourceAttributes {
+		if v != "" {
+			attrs = append(attrs, attribute.String(k, v))
+		}
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return resource.Merge(resource.Default(), resource.NewSchemaless(attrs...))
+}
+
+// Shutdown flushes the pending metrics and stops pushing.
+func (b *backend) Shutdown(ctx context.Context) error {
+	return b.provider.Shutdown(ctx)
+}
+
+// recordTiming records a Timings or MultiTimings sample, in seconds.
+func (b *backend) recordTiming(statsName, name string, elapsed time.Duration, t *stats.Timings) {
+	hist, ok := b.timings.Load(statsName)
+	if !ok {
+		created, err := b.meter.Float64Histogram(b.metricName(statsName), metric.WithDescription(t.Help()), metric.WithUnit("s"))
+		if err != nil {
+			log.Errorf("Failed to create OpenTelemetry histogram for %v: %v", statsName, err)
+			return
+		}
+		hist, _ = b.timings.LoadOrStore(statsName, created)
+	}
+	hist.(metric.Float64Histogram).Record(context.Background(), elapsed.Seconds(), metric.WithAttributeSet(timingsAttributes(t.Label(), name)))
+}
+
+// recordHistogram records a Histogram value.
+func (b *backend) recordHistogram(statsName string, value int64, h *stats.Histogram) {
+	hist, ok := b.histograms.Load(statsName)
+	if !ok {
+		created, err := b.meter.Int64Histogram(b.metricName(statsName), metric.WithDescription(h.Help()))
+		if err != nil {
+			log.Errorf("Failed to create OpenTelemetry histogram for %v: %v", statsName, err)
+			return
+		}
+		hist, _ = b.histograms.LoadOrStore(statsName, created)
+	}
+	hist.(metric.Int64Histogram).Record(context.Background(), value)
+}
+
+// metricName prefixes the snake case name of a variable with the namespace,
+// the same way the Prometheus backend names its metrics.
+func (b *backend) metricName(name string) string {
+	name = strings.TrimPrefix(normalize(name), b.namespace+"_")
+	if b.namespace == "" {
+		return name
+	}
+	return b.namespace + "_" + name
+}
+
+// timingsAttributes maps the "."-separated category of a Timings sample to
+// its label names. A MultiTimings has one label per component.
+func timingsAttributes(label, name string) attribute.Set {
+	labels := strings.Split(label, ".")
+	if len(labels) == 1 {
+		return attribute.NewSet(attribute.String(normalize(label), name))
+	}
+	return labelAttributes(labels, name)
+}
+
+// labelAttributes builds the attribute set of a multi-label variable from the
+// vitess representation of its label values ("."-separated list).
+func labelAttributes(labels []string, labelValsCombined string) attribute.Set {
+	labelVals := strings.Split(labelValsCombined, ".")
+	kvs := make([]attribute.KeyValue, 0, len(labels))
+	for i, l := range labels {
+		if i >= len(labelVals) {
+			break
+		}
+		kvs = append(kvs, attribute.String(normalize(l), labelVals[i]))
+	}
+	return attribute.NewSet(kvs...)
+}
+
+// normalize converts a camel case variable or label name to snake case,
+// special casing a few words the converter would otherwise split.
+func normalize(name string) string {
+	name = strings.NewReplacer("VSchema", "vschema", "VtGate", "vtgate").Replace(name)
+	return stats.GetSnakeName(name)
+}
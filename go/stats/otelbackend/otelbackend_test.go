@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelbackend
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"vitess.io/vitess/go/stats"
+)
+
+const namespace = "otelns"
+
+func newTestBackend(t *testing.T, resourceAttributes map[string]string) (*backend, *sdkmetric.ManualReader) {
+	var reader *sdkmetric.ManualReader
+	b, err := newBackend(namespace, resourceAttributes, func(p sdkmetric.Producer) sdkmetric.Reader {
+		reader = sdkmetric.NewManualReader(sdkmetric.WithProducer(p))
+		return reader
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = b.Shutdown(context.Background())
+	})
+	return b, reader
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) (metricdata.ResourceMetrics, map[string]metricdata.Metrics) {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	metrics := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return rm, metrics
+}
+
+func TestResourceAttributes(t *testing.T) {
+	_, reader := newTestBackend(t, map[string]string{
+		CellAttribute:        "zone1",
+		KeyspaceAttribute:    "commerce",
+		ShardAttribute:       "-80",
+		TabletAliasAttribute: "zone1-0000000100",
+	})
+	rm, _ := collect(t, reader)
+
+	for key, want := range map[string]string{
+		"service.name":       namespace,
+		CellAttribute:        "zone1",
+		KeyspaceAttribute:    "commerce",
+		ShardAttribute:       "-80",
+		TabletAliasAttribute: "zone1-0000000100",
+	} {
+		got, ok := rm.Resource.Set().Value(attribute.Key(key))
+		require.True(t, ok, key)
+		assert.Equal(t, want, got.AsString(), key)
+	}
+}
+
+func TestCounters(t *testing.T) {
+	b, reader := newTestBackend(t, nil)
+
+	c := stats.NewCounter("OtelCounter", "counter help")
+	c.Add(3)
+	cm := stats.NewCountersWithMultiLabels("OtelCountersMulti", "multi help", []string{"Keyspace", "TabletType"})
+	cm.Add([]string{"ks", "primary"}, 2)
+	cm.Add([]string{"ks", "replica"}, 5)
+	cd := stats.NewCounterDuration("OtelCounterDuration", "duration help")
+	cd.Add(1500 * time.Millisecond)
+
+	_, metrics := collect(t, reader)
+
+	m := metrics[b.metricName("OtelCounter")]
+	assert.Equal(t, "otelns_otel_counter", m.Name)
+	assert.Equal(t, "counter help", m.Description)
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.True(t, sum.IsMonotonic)
+	assert.Equal(t, metricdata.CumulativeTemporality, sum.Temporality)
+	require.Len(t, sum.DataPoints, 1)
+	assert.EqualValues(t, 3, sum.DataPoints[0].Value)
+
+	sum, ok = metrics["otelns_otel_counters_multi"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	got := make(map[string]int64)
+	for _, dp := range sum.DataPoints {
+		keyspace, _ := dp.Attributes.Value("keyspace")
+		tabletType, _ := dp.Attributes.Value("tablet_type")
+		got[keyspace.AsString()+"/"+tabletType.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{"ks/primary": 2, "ks/replica": 5}, got)
+
+	m = metrics["otelns_otel_counter_duration"]
+	assert.Equal(t, "s", m.Unit)
+	fsum, ok := m.Data.(metricdata.Sum[float64])
+	require.True(t, ok)
+	require.Len(t, fsum.DataPoints, 1)
+	assert.Equal(t, 1.5, fsum.DataPoints[0].Value)
+}
+
+func TestGauges(t *testing.T) {
+	_, reader := newTestBackend(t, nil)
+
+	g := stats.NewGauge("OtelGauge", "gauge help")
+	g.Set(-4)
+	gs := stats.NewGaugesWithSingleLabel("OtelGaugesSingle", "single help", "Pool")
+	gs.Set("read", 7)
+	stats.NewGaugeDurationFunc("OtelGaugeDurationFunc", "func help", func() time.Duration {
+		return 250 * time.Millisecond
+	})
+
+	_, metrics := collect(t, reader)
+
+	gauge, ok := metrics["otelns_otel_gauge"].Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.EqualValues(t, -4, gauge.DataPoints[0].Value)
+
+	gauge, ok = metrics["otelns_otel_gauges_single"].Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	pool, _ := gauge.DataPoints[0].Attributes.Value("pool")
+	assert.Equal(t, "read", pool.AsString())
+	assert.EqualValues(t, 7, gauge.DataPoints[0].Value)
+
+	fgauge, ok := metrics["otelns_otel_gauge_duration_func"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, fgauge.DataPoints, 1)
+	assert.Equal(t, 0.25, fgauge.DataPoints[0].Value)
+}
+
+func TestRates(t *testing.T) {
+	_, reader := newTestBackend(t, nil)
+
+	stats.NewRateFunc("OtelRates", "rates help", func() map[string][]float64 {
+		return map[string][]float64{
+			"All":    {1, 2, 3},
+			"Select": {1, 2},
+			"Empty":  {},
+		}
+	})
+
+	_, metrics := collect(t, reader)
+
+	gauge, ok := metrics["otelns_otel_rates"].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	got := make(map[string]float64)
+	for _, dp := range gauge.DataPoints {
+		category, _ := dp.Attributes.Value(ratesCategory)
+		got[category.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]float64{"All": 3, "Select": 2}, got)
+}
+
+func TestTimingsExponentialHistogram(t *testing.T) {
+	b, reader := newTestBackend(t, nil)
+
+	timings := stats.NewTimings("", "timings help", "Operation")
+	b.recordTiming("OtelTimings", "Read", 10*time.Millisecond, timings)
+	b.recordTiming("OtelTimings", "Read", 30*time.Millisecond, timings)
+	b.recordTiming("OtelTimings", "Write", 2*time.Second, timings)
+
+	multi := stats.NewMultiTimings("", "multi help", []string{"Keyspace", "Operation"})
+	b.recordTiming("OtelMultiTimings", "ks.Read", time.Millisecond, &multi.Timings)
+
+	hist := stats.NewHistogram("", "histogram help", []int64{1, 10})
+	b.recordHistogram("OtelHistogram", 5, hist)
+	b.recordHistogram("OtelHistogram", 500, hist)
+
+	_, metrics := collect(t, reader)
+
+	m := metrics["otelns_otel_timings"]
+	assert.Equal(t, "s", m.Unit)
+	assert.Equal(t, "timings help", m.Description)
+	eh, ok := m.Data.(metricdata.ExponentialHistogram[float64])
+	require.True(t, ok)
+	got := make(map[string]uint64)
+	for _, dp := range eh.DataPoints {
+		op, _ := dp.Attributes.Value("operation")
+		got[op.AsString()] = dp.Count
+		if op.AsString() == "Read" {
+			assert.InDelta(t, 0.04, dp.Sum, 1e-9)
+		}
+	}
+	assert.Equal(t, map[string]uint64{"Read": 2, "Write": 1}, got)
+
+	eh, ok = metrics["otelns_otel_multi_timings"].Data.(metricdata.ExponentialHistogram[float64])
+	require.True(t, ok)
+	require.Len(t, eh.DataPoints, 1)
+	keyspace, _ := eh.DataPoints[0].Attributes.Value("keyspace")
+	op, _ := eh.DataPoints[0].Attributes.Value("operation")
+	assert.Equal(t, "ks", keyspace.AsString())
+	assert.Equal(t, "Read", op.AsString())
+
+	ieh, ok := metrics["otelns_otel_histogram"].Data.(metricdata.ExponentialHistogram[int64])
+	require.True(t, ok)
+	require.Len(t, ieh.DataPoints, 1)
+	assert.EqualValues(t, 2, ieh.DataPoints[0].Count)
+	assert.EqualValues(t, 505, ieh.DataPoints[0].Sum)
+}
+
+func TestConcurrentRecording(t *testing.T) {
+	b, reader := newTestBackend(t, nil)
+
+	timings := stats.NewTimings("", "timings help", "Operation")
+	hist := stats.NewHistogram("", "histogram help", []int64{1, 10})
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				b.recordTiming("OtelConcurrentTimings", "Read", time.Millisecond, timings)
+				b.recordHistogram("OtelConcurrentHistogram", 1, hist)
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, metrics := collect(t, reader)
+	eh, ok := metrics["otelns_otel_concurrent_timings"].Data.(metricdata.ExponentialHistogram[float64])
+	require.True(t, ok)
+	require.Len(t, eh.DataPoints, 1)
+	assert.EqualValues(t, 1000, eh.DataPoints[0].Count)
+	ieh, ok := metrics["otelns_otel_concurrent_histogram"].Data.(metricdata.ExponentialHistogram[int64])
+	require.True(t, ok)
+	require.Len(t, ieh.DataPoints, 1)
+	assert.EqualValues(t, 1000, ieh.DataPoints[0].Count)
+}
+
+func TestMetricName(t *testing.T) {
+	b := &backend{namespace: "vtgate"}
+	assert.Equal(t, "vtgate_vschema_errors", b.metricName("VSchemaErrors"))
+	assert.Equal(t, "vtgate_api_error_counts", b.metricName("VtGateApiErrorCounts"))
+	assert.Equal(t, "vtgate_queries", b.metricName("Queries"))
+}
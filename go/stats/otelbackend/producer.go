@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelbackend
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"vitess.io/vitess/go/stats"
+)
+
+// ratesCategory is the attribute naming the category of a Rates time series.
+const ratesCategory = "category"
+
+// Produce implements sdkmetric.Producer. It reads the current value of every
+// published counter, gauge and rate.
+func (b *backend) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	c := collector{backend: b, now: time.Now()}
+	expvar.Do(c.collect)
+	if len(c.metrics) == 0 {
+		return nil, nil
+	}
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: instrumentationScope},
+		Metrics: c.metrics,
+	}}, nil
+}
+
+// collector accumulates the metrics of a single collection.
+type collector struct {
+	backend *backend
+	now     time.Time
+	metrics []metricdata.Metrics
+}
+
+func (c *collector) collect(kv expvar.KeyValue) {
+	name := kv.Key
+	switch v := kv.Value.(type) {
+	case *stats.Counter:
+		c.addSum(name, v.Help(), "", point(attribute.NewSet(), v.Get()))
+	case *stats.CounterFunc:
+		c.addSum(name, v.Help(), "", point(attribute.NewSet(), v.F()))
+	case *stats.Gauge:
+		c.addGauge(name, v.Help(), "", point(attribute.NewSet(), v.Get()))
+	case *stats.GaugeFloat64:
+		c.addFloatGauge(name, v.Help(), "", point(attribute.NewSet(), v.Get()))
+	case *stats.GaugeFunc:
+		c.addGauge(name, v.Help(), "", point(attribute.NewSet(), v.F()))
+	case stats.FloatFunc:
+		c.addFloatGauge(name, v.Help(), "", point(attribute.NewSet(), v()))
+	case *stats.CounterDuration:
+		c.addFloatSum(name, v.Help(), "s", point(attribute.NewSet(), v.Get().Seconds()))
+	case *stats.CounterDurationFunc:
+		c.addFloatSum(name, v.Help(), "s", point(attribute.NewSet(), v.F().Seconds()))
+	case *stats.GaugeDuration:
+		c.addFloatGauge(name, v.Help(), "s", point(attribute.NewSet(), v.Get().Seconds()))
+	case *stats.GaugeDurationFunc:
+		c.addFloatGauge(name, v.Help(), "s", point(attribute.NewSet(), v.F().Seconds()))
+	case *stats.CountersWithSingleLabel:
+		c.addSum(name, v.Help(), "", singleLabelPoints(v.Label(), v.Counts())...)
+	case *stats.CountersWithMultiLabels:
+		c.addSum(name, v.Help(), "", multiLabelPoints(v.Labels(), v.Counts())...)
+	case *stats.CountersFuncWithMultiLabels:
+		c.addSum(name, v.Help(), "", multiLabelPoints(v.Labels(), v.Counts())...)
+	case *stats.GaugesWithSingleLabel:
+		c.addGauge(name, v.Help(), "", singleLabelPoints(v.Label(), v.Counts())...)
+	case *stats.GaugesWithMultiLabels:
+		c.addGauge(name, v.Help(), "", multiLabelPoints(v.Labels(), v.Counts())...)
+	case *stats.GaugesFuncWithMultiLabels:
+		c.addGauge(name, v.Help(), "", multiLabelPoints(v.Labels(), v.Counts())...)
+	case *stats.StringMapFuncWithMultiLabels:
+		var points []metricdata.DataPoint[int64]
+		labels := append(append([]string{}, v.KeyLabels()...), v.ValueLabel())
+		for labelVals, val := range v.StringMapFunc() {
+			points = append(points, point(labelAttributes(labels, labelVals+"."+val), int64(1)))
+		}
+		c.addGauge(name, v.Help(), "", points...)
+	case *stats.Rates:
+		c.addFloatGauge(name, "", "", ratePoints(v.Get())...)
+	case *stats.RatesFunc:
+		c.addFloatGauge(name, v.Help(), "", ratePoints(v.F())...)
+	case *stats.Timings, *stats.MultiTimings, *stats.Histogram:
+		// These are recorded sample by sample through the stats observers,
+		// see recordTiming and recordHistogram.
+	default:
+		// Strings and the variables published by expvar itself, e.g.
+		// memstats, have no numeric value to export.
+	}
+}
+
+func (c *collector) addSum(name, help, unit string, points ...metricdata.DataPoint[int64]) {
+	c.add(name, help, unit, metricdata.Sum[int64]{
+		DataPoints:  stamp(c, points, true),
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+	})
+}
+
+func (c *collector) addFloatSum(name, help, unit string, points ...metricdata.DataPoint[float64]) {
+	c.add(name, help, unit, metricdata.Sum[float64]{
+		DataPoints:  stamp(c, points, true),
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+	})
+}
+
+func (c *collector) addGauge(name, help, unit string, points ...metricdata.DataPoint[int64]) {
+	c.add(name, help, unit, metricdata.Gauge[int64]{DataPoints: stamp(c, points, false)})
+}
+
+func (c *collector) addFloatGauge(name, help, unit string, points ...metricdata.DataPoint[float64]) {
+	c.add(name, help, unit, metricdata.Gauge[float64]{DataPoints: stamp(c, points, false)})
+}
+
+func (c *collector) add(name, help, unit string, data metricdata.Aggregation) {
+	c.metrics = append(c.metrics, metricdata.Metrics{
+		Name:        c.backend.metricName(name),
+		Description: help,
+		Unit:        unit,
+		Data:        data,
+	})
+}
+
+// stamp sets the collection time of the points, and the start time of the
+// cumulative ones.
+func stamp[N int64 | float64](c *collector, points []metricdata.DataPoint[N], cumulative bool) []metricdata.DataPoint[N] {
+	for i := range points {
+		points[i].Time = c.now
+		if cumulative {
+			points[i].StartTime = c.backend.start
+		}
+	}
+	return points
+}
+
+func point[N int64 | float64](attrs attribute.Set, value N) metricdata.DataPoint[N] {
+	return metricdata.DataPoint[N]{Attributes: attrs, Value: value}
+}
+
+func singleLabelPoints(label string, counts map[string]int64) []metricdata.DataPoint[int64] {
+	points := make([]metricdata.DataPoint[int64], 0, len(counts))
+	key := normalize(label)
+	for labelVal, val := range counts {
+		points = append(points, point(attribute.NewSet(attribute.String(key, labelVal)), val))
+	}
+	return points
+}
+
+func multiLabelPoints(labels []string, counts map[string]int64) []metricdata.DataPoint[int64] {
+	points := make([]metricdata.DataPoint[int64], 0, len(counts))
+	for labelVals, val := range counts {
+		points = append(points, point(labelAttributes(labels, labelVals), val))
+	}
+	return points
+}
+
+// ratePoints reports the most recent rate of every category.
+func ratePoints(rates map[string][]float64) []metricdata.DataPoint[float64] {
+	points := make([]metricdata.DataPoint[float64], 0, len(rates))
+	for category, samples := range rates {
+		if len(samples) == 0 {
+			continue
+		}
+		points = append(points, point(attribute.NewSet(attribute.String(ratesCategory, category)), samples[len(samples)-1]))
+	}
+	return points
+}
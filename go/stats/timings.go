@@ -93,6 +93,11 @@ func (t *Timings) Add(name string, elapsed time.Duration) {
 	if defaultStatsdHook.timerHook != nil && t.name != "" {
 		defaultStatsdHook.timerHook(t.name, name, elapsed.Milliseconds(), t)
 	}
+	if observers := timingsObservers.Load(); observers != nil && t.name != "" {
+		for _, observe := range *observers {
+			observe(t.name, name, elapsed, t)
+		}
+	}
 
 	elapsedNs := int64(elapsed)
 	hist.Add(elapsedNs)
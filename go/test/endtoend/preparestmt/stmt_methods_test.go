@@ -512,7 +512,7 @@ func TestSpecializedPlan(t *testing.T) {
 		query: `select 1 from t1 tbl1, t1 tbl2, t1 tbl3, t1 tbl4 where tbl1.id = ? and tbl2.id = ? and tbl3.id = ? and tbl4.id = ?`,
 		args:  []any{1, 1, 1, 1},
 	}, {
-		query: `SELECT e.id, e.name, s.age, ROW_NUMBER() OVER (PARTITION BY e.age ORDER BY s.name DESC) AS age_rank FROM t1 e, t1 s where e.id = ? and s.id = ?`,
+		query: `SELECT e.id, e.name, s.age, ROW_NUMBER() OVER (PARTITION BY e.age ORDER BY s.name DESC) AS age_rank, RANK() OVER (ORDER BY e.id) AS id_rank FROM t1 e, t1 s where e.id = ? and s.id = ?`,
 		args:  []any{1, 1},
 	}}
 
@@ -570,7 +570,7 @@ func validateBaselineErrSpecializedPlan(t *testing.T, p map[string]any) {
 	pm, ok := plan.(map[string]any)
 	require.True(t, ok, "plan is not of type map[string]any")
 	require.EqualValues(t, "PlanSwitcher", pm["OperatorType"])
-	require.EqualValues(t, "VT12001: unsupported: OVER CLAUSE with sharded keyspace", pm["BaselineErr"])
+	require.EqualValues(t, "VT12001: unsupported: window functions with different window specifications in a cross-shard query", pm["BaselineErr"])
 
 	pd, err := engine.PrimitiveDescriptionFromMap(plan.(map[string]any))
 	require.NoError(t, err)
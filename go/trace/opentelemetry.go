@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var _ Span = (*openTelemetrySpan)(nil)
+
+type openTelemetrySpan struct {
+	otelSpan oteltrace.Span
+}
+
+// Finish will mark a span as finished
+func (s openTelemetrySpan) Finish() {
+	s.otelSpan.End()
+}
+
+// Annotate will add information to an existing span
+func (s openTelemetrySpan) Annotate(key string, value any) {
+	s.otelSpan.SetAttributes(otelAttribute(key, value))
+}
+
+func otelAttribute(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int32:
+		return attribute.Int(key, int(v))
+	case int64:
+		return attribute.Int64(key, v)
+	case uint32:
+		return attribute.Int64(key, int64(v))
+	case float64:
+		return attribute.Float64(key, v)
+	case []string:
+		return attribute.StringSlice(key, v)
+	case fmt.Stringer:
+		return attribute.String(key, v.String())
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
+
+var _ tracingService = (*openTelemetryService)(nil)
+
+// openTelemetryService is a tracingService using an OpenTelemetry tracer. The
+// span contexts are propagated with the W3C Trace Context headers.
+type openTelemetryService struct {
+	tracer     oteltrace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// New is part of an interface implementation
+func (s openTelemetryService) New(parent Span, label string) Span {
+	ctx := context.Background()
+	if parent, ok := parent.(openTelemetrySpan); ok {
+		ctx = oteltrace.ContextWithSpan(ctx, parent.otelSpan)
+	}
+	_, span := s.tracer.Start(ctx, label)
+	return openTelemetrySpan{otelSpan: span}
+}
+
+// NewFromString is part of an interface implementation. The parent is either
+// a W3C traceparent, like 00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01,
+// or the base64 of a JSON map of the Trace Context headers, like the one of the
+// opentracing tracers.
+func (s openTelemetryService) NewFromString(parent, label string) (Span, error) {
+	carrier := propagation.MapCarrier{}
+	if strings.HasPrefix(parent, "00-") {
+		carrier["traceparent"] = parent
+	} else {
+		textMap, err := extractMapFromString(parent)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range textMap {
+			carrier[strings.ToLower(k)] = v
+		}
+	}
+
+	ctx := s.propagator.Extract(context.Background(), carrier)
+	if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "failed to deserialize span context: %s", parent)
+	}
+	_, span := s.tracer.Start(ctx, label)
+	return openTelemetrySpan{otelSpan: span}, nil
+}
+
+func (s openTelemetryService) acceptsTraceParent() {}
+
+// FromContext is part of an interface implementation
+func (s openTelemetryService) FromContext(ctx context.Context) (Span, bool) {
+	span := oteltrace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return nil, false
+	}
+	return openTelemetrySpan{otelSpan: span}, true
+}
+
+// NewContext is part of an interface implementation
+func (s openTelemetryService) NewContext(parent context.Context, span Span) context.Context {
+	otelSpan, ok := span.(openTelemetrySpan)
+	if !ok {
+		return nil
+	}
+	return oteltrace.ContextWithSpan(parent, otelSpan.otelSpan)
+}
+
+// AddGrpcServerOptions is part of an interface implementation
+func (s openTelemetryService) AddGrpcServerOptions(addInterceptors func(s grpc.StreamServerInterceptor, u grpc.UnaryServerInterceptor)) {
+	addInterceptors(s.streamServerInterceptor, s.unaryServerInterceptor)
+}
+
+// AddGrpcClientOptions is part of an interface implementation
+func (s openTelemetryService) AddGrpcClientOptions(addInterceptors func(s grpc.StreamClientInterceptor, u grpc.UnaryClientInterceptor)) {
+	addInterceptors(s.streamClientInterceptor, s.unaryClientInterceptor)
+}
+
+// metadataCarrier adapts the gRPC metadata to a propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+// Get is part of the propagation.TextMapCarrier interface.
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set is part of the propagation.TextMapCarrier interface.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys is part of the propagation.TextMapCarrier interface.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// startServerSpan starts the span of an incoming RPC, as a child of the span
+// propagated in its metadata, if any.
+func (s openTelemetryService) startServerSpan(ctx context.Context, method string) (context.Context, oteltrace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = s.propagator.Extract(ctx, metadataCarrier(md))
+	}
+	return s.tracer.Start(ctx, method, oteltrace.WithSpanKind(oteltrace.SpanKindServer))
+}
+
+// startClientSpan starts the span of an outgoing RPC, and propagates it in the
+// metadata of the RPC.
+func (s openTelemetryService) startClientSpan(ctx context.Context, method string) (context.Context, oteltrace.Span) {
+	ctx, span := s.tracer.Start(ctx, method, oteltrace.WithSpanKind(oteltrace.SpanKindClient))
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	s.propagator.Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+func endSpan(span oteltrace.Span, err error) {
+	if err != nil && err != io.EOF {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (s openTelemetryService) unaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, span := s.startServerSpan(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	endSpan(span, err)
+	return resp, err
+}
+
+func (s openTelemetryService) streamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := s.startServerSpan(ss.Context(), info.FullMethod)
+	err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx})
+	endSpan(span, err)
+	return err
+}
+
+// tracedServerStream overrides the context of a server stream with the one of
+// its span.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context is part of the grpc.ServerStream interface.
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s openTelemetryService) unaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := s.startClientSpan(ctx, method)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (s openTelemetryService) streamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, span := s.startClientSpan(ctx, method)
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	return &tracedClientStream{ClientStream: cs, span: span}, nil
+}
+
+// tracedClientStream ends the span of a client stream once the stream ends.
+type tracedClientStream struct {
+	grpc.ClientStream
+	span oteltrace.Span
+	once sync.Once
+}
+
+// RecvMsg is part of the grpc.ClientStream interface.
+func (s *tracedClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() { endSpan(s.span, err) })
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func newTestOpenTelemetryService() (openTelemetryService, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return openTelemetryService{
+		tracer:     provider.Tracer("test"),
+		propagator: propagation.TraceContext{},
+	}, exporter
+}
+
+func TestOpenTelemetrySpans(t *testing.T) {
+	svc, exporter := newTestOpenTelemetryService()
+
+	_, ok := svc.FromContext(context.Background())
+	require.False(t, ok)
+
+	parent := svc.New(nil, "parent")
+	ctx := svc.NewContext(context.Background(), parent)
+	spanFromCtx, ok := svc.FromContext(ctx)
+	require.True(t, ok)
+
+	child := svc.New(spanFromCtx, "child")
+	child.Annotate("keyspace", "ks")
+	child.Annotate("rows", 42)
+	child.Annotate("cached_plan", true)
+	child.Finish()
+	parent.Finish()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	assert.Len(t, spans[0].Attributes, 3)
+
+	assert.Nil(t, svc.NewContext(context.Background(), &mockSpan{}))
+}
+
+func TestOpenTelemetryNewFromString(t *testing.T) {
+	svc, exporter := newTestOpenTelemetryService()
+
+	span, err := svc.NewFromString("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "from-traceparent")
+	require.NoError(t, err)
+	span.Finish()
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(`{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}`))
+	span, err = svc.NewFromString(encoded, "from-text-map")
+	require.NoError(t, err)
+	span.Finish()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext.TraceID().String())
+		assert.Equal(t, "b7ad6b7169203331", span.Parent.SpanID().String())
+		assert.True(t, span.Parent.IsRemote())
+	}
+
+	_, err = svc.NewFromString("00-123", "label")
+	assert.ErrorContains(t, err, "failed to deserialize span context")
+	_, err = svc.NewFromString("this is not base64", "label")
+	assert.Error(t, err)
+}
+
+func TestOpenTelemetryGrpcPropagation(t *testing.T) {
+	svc, exporter := newTestOpenTelemetryService()
+
+	parent := svc.New(nil, "parent")
+	ctx := svc.NewContext(context.Background(), parent)
+	ctx = metadata.AppendToOutgoingContext(ctx, "key", "value")
+
+	// The client propagates its span in the outgoing metadata, which the server
+	// receives as the incoming metadata.
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, []string{"value"}, md.Get("key"))
+		require.Len(t, md.Get("traceparent"), 1)
+
+		serverCtx := metadata.NewIncomingContext(context.Background(), md)
+		_, err := svc.unaryServerInterceptor(serverCtx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			span, ok := svc.FromContext(ctx)
+			require.True(t, ok)
+			span.Annotate("served", true)
+			return nil, nil
+		})
+		return err
+	}
+	err := svc.unaryClientInterceptor(ctx, "/queryservice.Query/Execute", nil, nil, nil, invoker)
+	require.NoError(t, err)
+	parent.Finish()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	server, client, root := spans[0], spans[1], spans[2]
+	assert.Equal(t, "/queryservice.Query/Execute", server.Name)
+	assert.Equal(t, "/queryservice.Query/Execute", client.Name)
+	assert.Equal(t, root.SpanContext.SpanID(), client.Parent.SpanID())
+	assert.Equal(t, client.SpanContext.SpanID(), server.Parent.SpanID())
+	assert.Equal(t, root.SpanContext.TraceID(), server.SpanContext.TraceID())
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"vitess.io/vitess/go/viperutil"
+	"vitess.io/vitess/go/vt/log"
+)
+
+/*
+This file makes it easy to build Vitess without including the OpenTelemetry
+SDK and exporters. All that is needed is to delete this file.
+*/
+
+var (
+	otelConfigKey = viperutil.KeyPrefixFunc(configKey("opentelemetry"))
+
+	otelExporter = viperutil.Configure(
+		otelConfigKey("exporter"),
+		viperutil.Options[string]{
+			Default:  "grpc",
+			FlagName: "otel-exporter",
+		},
+	)
+	otelEndpoint = viperutil.Configure(
+		otelConfigKey("endpoint"),
+		viperutil.Options[string]{
+			FlagName: "otel-endpoint",
+		},
+	)
+	otelInsecure = viperutil.Configure(
+		otelConfigKey("insecure"),
+		viperutil.Options[bool]{
+			FlagName: "otel-insecure",
+		},
+	)
+	otelFilePath = viperutil.Configure(
+		otelConfigKey("file-path"),
+		viperutil.Options[string]{
+			FlagName: "otel-file-path",
+		},
+	)
+)
+
+func init() {
+	// If compiled with plugin_opentelemetry, ensure that trace.RegisterFlags
+	// includes opentelemetry tracing flags.
+	pluginFlags = append(pluginFlags, func(fs *pflag.FlagSet) {
+		fs.String("otel-exporter", otelExporter.Default(), "exporter of the spans of the opentelemetry tracer. possible values are 'grpc' and 'http' for OTLP, or 'file' to write them as JSON to --otel-file-path")
+		fs.String("otel-endpoint", "", "host:port of the OTLP collector the opentelemetry tracer sends spans to. if empty, the OTEL_EXPORTER_OTLP_ENDPOINT environment variable or the OTLP default is used")
+		fs.Bool("otel-insecure", false, "whether the opentelemetry tracer sends spans to the OTLP collector without TLS")
+		fs.String("otel-file-path", "", "path of the file the opentelemetry tracer writes spans to with --otel-exporter=file")
+
+		viperutil.BindFlags(fs, otelExporter, otelEndpoint, otelInsecure, otelFilePath)
+	})
+}
+
+// newOpenTelemetryExporter returns the span exporter of --otel-exporter, and
+// the closer of its resources other than the exporter itself, if any.
+func newOpenTelemetryExporter(ctx context.Context) (sdktrace.SpanExporter, io.Closer, error) {
+	switch exporter := otelExporter.Get(); exporter {
+	case "grpc":
+		var opts []otlptracegrpc.Option
+		if endpoint := otelEndpoint.Get(); endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+		}
+		if otelInsecure.Get() {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		return exp, &nilCloser{}, err
+	case "http":
+		var opts []otlptracehttp.Option
+		if endpoint := otelEndpoint.Get(); endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+		}
+		if otelInsecure.Get() {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exp, err := otlptracehttp.New(ctx, opts...)
+		return exp, &nilCloser{}, err
+	case "file":
+		path := otelFilePath.Get()
+		if path == "" {
+			return nil, nil, fmt.Errorf("need --otel-file-path to use the file exporter")
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exp, file, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown opentelemetry exporter %q. possible values are 'grpc', 'http' or 'file'", exporter)
+	}
+}
+
+// newOpenTelemetryTracer will instantiate a tracingService implemented by
+// OpenTelemetry. The standard OTEL_* environment variables of the exporters,
+// like OTEL_EXPORTER_OTLP_HEADERS, are also honored.
+func newOpenTelemetryTracer(serviceName string) (tracingService, io.Closer, error) {
+	ctx := context.Background()
+	exp, expCloser, err := newOpenTelemetryExporter(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		expCloser.Close()
+		return nil, nil, err
+	}
+
+	rate := samplingRate.Get()
+	log.Infof("Tracing to OpenTelemetry %s exporter as %v (sampling rate: %v)", otelExporter.Get(), serviceName, rate)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(rate))),
+	)
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	return openTelemetryService{
+		tracer:     provider.Tracer("vitess.io/vitess/go/trace"),
+		propagator: propagator,
+	}, &otelCloser{provider: provider, closer: expCloser}, nil
+}
+
+var _ io.Closer = (*otelCloser)(nil)
+
+// otelCloser flushes the pending spans and shuts the exporter down.
+type otelCloser struct {
+	provider *sdktrace.TracerProvider
+	closer   io.Closer
+}
+
+func (c *otelCloser) Close() error {
+	if err := c.provider.Shutdown(context.Background()); err != nil {
+		return err
+	}
+	return c.closer.Close()
+}
+
+func init() {
+	tracingBackendFactories["opentelemetry"] = newOpenTelemetryTracer
+}
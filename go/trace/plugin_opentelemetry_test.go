@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpenTelemetryTracerFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.json")
+	otelExporter.Set("file")
+	otelFilePath.Set(path)
+	samplingRate.Set(1)
+	defer func() {
+		otelExporter.Set(otelExporter.Default())
+		otelFilePath.Set("")
+		samplingRate.Set(samplingRate.Default())
+	}()
+
+	tracingSvc, closer, err := newOpenTelemetryTracer("vtgate")
+	require.NoError(t, err)
+	assert.Implements(t, (*traceParentService)(nil), tracingSvc)
+
+	span := tracingSvc.New(nil, "test-span")
+	span.Annotate("keyspace", "ks")
+	span.Finish()
+	require.NoError(t, closer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"test-span"`)
+	assert.Contains(t, string(data), `"Value":"vtgate"`)
+	assert.Contains(t, string(data), `"Value":"ks"`)
+}
+
+func TestNewOpenTelemetryTracerErrors(t *testing.T) {
+	defer otelExporter.Set(otelExporter.Default())
+
+	otelExporter.Set("file")
+	_, _, err := newOpenTelemetryTracer("vtgate")
+	assert.EqualError(t, err, "need --otel-file-path to use the file exporter")
+
+	otelExporter.Set("zipkin")
+	_, _, err = newOpenTelemetryTracer("vtgate")
+	assert.EqualError(t, err, `unknown opentelemetry exporter "zipkin". possible values are 'grpc', 'http' or 'file'`)
+}
@@ -65,6 +65,19 @@ func NewFromString(inCtx context.Context, parent, label string) (Span, context.C
 	return span, outCtx, nil
 }
 
+// traceParentService is implemented by the tracing services whose
+// NewFromString accepts a W3C traceparent.
+type traceParentService interface {
+	acceptsTraceParent()
+}
+
+// AcceptsTraceParent returns whether the currently installed tracing plugin
+// can create a span from a W3C traceparent with NewFromString.
+func AcceptsTraceParent() bool {
+	_, ok := currentTracer.(traceParentService)
+	return ok
+}
+
 // AnnotateSQL annotates information about a sql query in the span. This is done in a way
 // so as to not leak personally identifying information (PII), or sensitive personal information (SPI)
 func AnnotateSQL(span Span, strippedSQL fmt.Stringer) {
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// FindBackupsToPrune returns the backups of a shard that the retention policy
+// does not keep at the given time. A backup is kept if:
+//   - it is one of the policy.KeepLatest most recent backups,
+//   - it is the most recent backup of one of the last policy.KeepDailyDays
+//     days, or of one of the last policy.KeepWeeklyWeeks ISO weeks (in UTC),
+//   - it is the most recent full backup,
+//   - it is needed to restore a kept incremental backup, so point in time
+//     recovery remains possible up to every kept backup.
+//
+// The backups whose MANIFEST cannot be read, like the backups in progress, are
+// always kept.
+func FindBackupsToPrune(ctx context.Context, policy *topodatapb.BackupRetentionPolicy, bhs []backupstorage.BackupHandle, now time.Time, logger logutil.Logger) []backupstorage.BackupHandle {
+	manifests := make([]*BackupManifest, 0, len(bhs))
+	handles := make(map[*BackupManifest]backupstorage.BackupHandle, len(bhs))
+	for _, bh := range bhs {
+		manifest, err := GetBackupManifest(ctx, bh)
+		if err != nil {
+			logger.Warningf("Keeping backup %v: can't read MANIFEST: %v", bh.Name(), err)
+			continue
+		}
+		if _, err := ParseRFC3339(manifest.BackupTime); err != nil {
+			logger.Warningf("Keeping backup %v: can't parse its BackupTime %q: %v", bh.Name(), manifest.BackupTime, err)
+			continue
+		}
+		manifests = append(manifests, manifest)
+		handles[manifest] = bh
+	}
+
+	var pruned []backupstorage.BackupHandle
+	for _, manifest := range findManifestsToPrune(policy, manifests, now) {
+		pruned = append(pruned, handles[manifest])
+	}
+	return pruned
+}
+
+// findManifestsToPrune returns the manifests that the retention policy does
+// not keep at the given time, in the order in which they are given. The
+// BackupTime of all the manifests must be valid.
+func findManifestsToPrune(policy *topodatapb.BackupRetentionPolicy, manifests []*BackupManifest, now time.Time) []*BackupManifest {
+	backupTime := func(m *BackupManifest) time.Time {
+		t, _ := ParseRFC3339(m.BackupTime)
+		return t.UTC()
+	}
+	// The most recent backups first.
+	sorted := append([]*BackupManifest(nil), manifests...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return backupTime(sorted[i]).After(backupTime(sorted[j]))
+	})
+
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	thisWeek := startOfWeek(today)
+	keptDays := make(map[time.Time]bool)
+	keptWeeks := make(map[time.Time]bool)
+	kept := make(map[*BackupManifest]bool)
+	newestFullBackupKept := false
+	for i, m := range sorted {
+		t := backupTime(m)
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		week := startOfWeek(day)
+
+		if i < int(policy.GetKeepLatest()) {
+			kept[m] = true
+		}
+		if daysAgo := int(today.Sub(day).Hours() / 24); daysAgo < int(policy.GetKeepDailyDays()) && !keptDays[day] {
+			keptDays[day] = true
+			kept[m] = true
+		}
+		if weeksAgo := int(thisWeek.Sub(week).Hours() / (24 * 7)); weeksAgo < int(policy.GetKeepWeeklyWeeks()) && !keptWeeks[week] {
+			keptWeeks[week] = true
+			kept[m] = true
+		}
+		if !m.Incremental && !newestFullBackupKept {
+			newestFullBackupKept = true
+			kept[m] = true
+		}
+	}
+
+	// An incremental backup can only be restored along with the full backup,
+	// and the incremental backups, it is based on.
+	for _, m := range sorted {
+		if !kept[m] || !m.Incremental {
+			continue
+		}
+		path, err := FindPITRPath(m.Position.GTIDSet, manifests)
+		if err != nil {
+			// The backup cannot be restored anyway.
+			continue
+		}
+		for _, pm := range path {
+			kept[pm] = true
+		}
+	}
+
+	var pruned []*BackupManifest
+	for _, m := range manifests {
+		if !kept[m] {
+			pruned = append(pruned, m)
+		}
+	}
+	return pruned
+}
+
+// startOfWeek returns the Monday of the ISO week of the given day.
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/mysql/replication"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestFindManifestsToPrune(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	generatePosition := func(posRange string) replication.Position {
+		return replication.MustParsePosition(replication.Mysql56FlavorID, fmt.Sprintf("16b1039f-22b6-11ed-b765-0a43f95f28a3:%s", posRange))
+	}
+	fullManifest := func(name string, age time.Duration, backupPos string) *BackupManifest {
+		return &BackupManifest{
+			BackupName: name,
+			BackupTime: FormatRFC3339(now.Add(-age)),
+			Position:   generatePosition(backupPos),
+		}
+	}
+	incrementalManifest := func(name string, age time.Duration, backupPos string, backupFromPos string) *BackupManifest {
+		return &BackupManifest{
+			BackupName:   name,
+			BackupTime:   FormatRFC3339(now.Add(-age)),
+			Position:     generatePosition(backupPos),
+			FromPosition: generatePosition(backupFromPos),
+			Incremental:  true,
+		}
+	}
+	day := 24 * time.Hour
+
+	// Two full backups a day, for four weeks.
+	var fullBackups []*BackupManifest
+	for i := 55; i >= 0; i-- {
+		fullBackups = append(fullBackups, fullManifest(fmt.Sprintf("full-%d", i), time.Duration(i)*12*time.Hour, fmt.Sprintf("1-%d", 1000-10*i)))
+	}
+	// A full backup, followed by incremental backups.
+	chain := []*BackupManifest{
+		fullManifest("full", 10*day, "1-100"),
+		incrementalManifest("incr-1", 9*day, "1-110", "1-100"),
+		incrementalManifest("incr-2", 8*day, "1-120", "1-110"),
+		incrementalManifest("incr-3", 7*day, "1-130", "1-120"),
+		incrementalManifest("incr-4", 6*day, "1-140", "1-130"),
+	}
+
+	names := func(manifests []*BackupManifest) []string {
+		var names []string
+		for _, m := range manifests {
+			names = append(names, m.BackupName)
+		}
+		return names
+	}
+	keptNames := func(manifests []*BackupManifest, pruned []*BackupManifest) []string {
+		isPruned := make(map[*BackupManifest]bool)
+		for _, m := range pruned {
+			isPruned[m] = true
+		}
+		var names []string
+		for _, m := range manifests {
+			if !isPruned[m] {
+				names = append(names, m.BackupName)
+			}
+		}
+		return names
+	}
+
+	tcases := []struct {
+		name      string
+		policy    *topodatapb.BackupRetentionPolicy
+		manifests []*BackupManifest
+		expected  []string
+	}{{
+		name:      "keep latest",
+		policy:    &topodatapb.BackupRetentionPolicy{KeepLatest: 3},
+		manifests: fullBackups,
+		expected:  []string{"full-2", "full-1", "full-0"},
+	}, {
+		name:      "keep daily",
+		policy:    &topodatapb.BackupRetentionPolicy{KeepDailyDays: 3},
+		manifests: fullBackups,
+		// Today's backup is at noon, the others are at midnight and noon.
+		expected: []string{"full-4", "full-2", "full-0"},
+	}, {
+		name:      "keep weekly",
+		policy:    &topodatapb.BackupRetentionPolicy{KeepWeeklyWeeks: 2},
+		manifests: fullBackups,
+		// The last backup of the previous week is on Sunday at noon.
+		expected: []string{"full-6", "full-0"},
+	}, {
+		name:      "combined",
+		policy:    &topodatapb.BackupRetentionPolicy{KeepLatest: 1, KeepDailyDays: 2, KeepWeeklyWeeks: 3},
+		manifests: fullBackups,
+		expected:  []string{"full-20", "full-6", "full-2", "full-0"},
+	}, {
+		name:      "newest full backup",
+		policy:    &topodatapb.BackupRetentionPolicy{KeepLatest: 2},
+		manifests: append([]*BackupManifest{fullManifest("old", 20*day, "1-50")}, chain...),
+		// The chain of the incremental backups is kept.
+		expected: []string{"full", "incr-1", "incr-2", "incr-3", "incr-4"},
+	}, {
+		name:      "incremental chain",
+		policy:    &topodatapb.BackupRetentionPolicy{KeepDailyDays: 1},
+		manifests: append(append([]*BackupManifest{}, chain...), fullManifest("new", time.Hour, "1-150"), incrementalManifest("new-incr", 0, "1-160", "1-150")),
+		// The incremental backup of today needs the full backup of today.
+		expected: []string{"new", "new-incr"},
+	}, {
+		name:   "incremental chain of a daily backup",
+		policy: &topodatapb.BackupRetentionPolicy{KeepDailyDays: 8},
+		manifests: append(append([]*BackupManifest{}, chain...),
+			fullManifest("new", 0, "1-150"),
+		),
+		expected: []string{"full", "incr-1", "incr-2", "incr-3", "incr-4", "new"},
+	}, {
+		name:   "broken chain",
+		policy: &topodatapb.BackupRetentionPolicy{KeepLatest: 1},
+		manifests: []*BackupManifest{
+			fullManifest("full", 2*day, "1-100"),
+			incrementalManifest("incr", day, "1-140", "1-130"),
+		},
+		expected: []string{"full", "incr"},
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			pruned := findManifestsToPrune(tcase.policy, tcase.manifests, now)
+			assert.Equal(t, tcase.expected, keptNames(tcase.manifests, pruned), "pruned: %v", names(pruned))
+		})
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	monday := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, monday, startOfWeek(monday.AddDate(0, 0, i)))
+	}
+	assert.Equal(t, monday.AddDate(0, 0, -7), startOfWeek(monday.AddDate(0, 0, -1)))
+}
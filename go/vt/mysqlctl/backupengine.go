@@ -339,6 +339,9 @@ type BackupManifest struct {
 
 	// IncrementalDetails is nil for non-incremental backups
 	IncrementalDetails *IncrementalBackupDetails
+
+	// Encryption is nil unless the backup storage encrypted the files of the backup
+	Encryption *backupstorage.Encryption `json:",omitempty"`
 }
 
 func (m *BackupManifest) HashKey() string {
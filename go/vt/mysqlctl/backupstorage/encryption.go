@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupstorage
+
+// Encryption describes how a BackupStorage encrypted the files of a
+// backup. Backup engines record it in the MANIFEST of the backup, so the
+// files can be decrypted on restore.
+type Encryption struct {
+	// Algorithm is the format of the encrypted files.
+	Algorithm string
+
+	// KeyID identifies the key that wrapped the data key of the backup.
+	KeyID string
+
+	// WrappedKey is the data key of the backup, wrapped by the key KeyID.
+	WrappedKey []byte
+}
+
+// EncryptedBackupHandle is implemented by the BackupHandles that encrypt
+// the files of their backup.
+type EncryptedBackupHandle interface {
+	// Encryption returns how the files of the backup are encrypted.
+	Encryption() *Encryption
+}
+
+// GetEncryption returns how the files of a backup are encrypted, or nil
+// if they are not.
+func GetEncryption(bh BackupHandle) *Encryption {
+	if ebh, ok := bh.(EncryptedBackupHandle); ok {
+		return ebh.Encryption()
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryptedbackupstorage implements the BackupStorage interface
+// on top of another BackupStorage, with client-side envelope encryption
+// of the backups.
+//
+// Each backup has its own random data key, which encrypts its files with
+// AES-256-GCM. The data key is wrapped by a KeyManager, with a key from a
+// local keyfile or from a KMS, and recorded with the id of that key in the
+// MANIFEST of the backup, which is itself not encrypted. The keys are thus
+// held independently of the provider of the underlying storage.
+package encryptedbackupstorage
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/utils"
+)
+
+const (
+	// implementationName is the name of the encrypted backup storage,
+	// to use with --backup-storage-implementation.
+	implementationName = "encrypted"
+
+	// manifestFileName is the MANIFEST file of the backups, which is not
+	// encrypted, as it records how the other files are.
+	manifestFileName = "MANIFEST"
+)
+
+var (
+	// storageImplementation is the implementation of the storage of the
+	// encrypted backups.
+	storageImplementation string
+
+	// keyManager, keyfile and keyID configure the wrapping of the data
+	// keys.
+	keyManager = "keyfile"
+	keyfile    string
+	keyID      string
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	utils.SetFlagStringVar(fs, &storageImplementation, "encrypted-backup-storage-implementation", storageImplementation, "Which backup storage implementation stores the backups encrypted by the encrypted backup storage.")
+	utils.SetFlagStringVar(fs, &keyManager, "encrypted-backup-storage-key-manager", keyManager, "Which key manager wraps the data keys of the encrypted backups: keyfile, or a registered KMS plugin.")
+	utils.SetFlagStringVar(fs, &keyfile, "encrypted-backup-storage-keyfile", keyfile, "Path to the JSON file of the keyfile key manager, which maps key ids to base64-encoded 256-bit keys.")
+	utils.SetFlagStringVar(fs, &keyID, "encrypted-backup-storage-key-id", keyID, "Id of the key of the keyfile that wraps the data keys of new backups; optional if the keyfile has a single key.")
+}
+
+func init() {
+	servenv.OnParseFor("vtbackup", registerFlags)
+	servenv.OnParseFor("vtctl", registerFlags)
+	servenv.OnParseFor("vtctld", registerFlags)
+	servenv.OnParseFor("vttablet", registerFlags)
+}
+
+// EncryptedBackupHandle implements BackupHandle on top of the BackupHandle
+// of the underlying storage.
+type EncryptedBackupHandle struct {
+	backupstorage.BackupHandle
+	readOnly bool
+
+	// mu protects encryption and aead, which read-only handles load from
+	// the MANIFEST on the first read of a file.
+	mu         sync.Mutex
+	loaded     bool
+	encryption *backupstorage.Encryption
+	aead       cipher.AEAD
+}
+
+// Encryption is part of the backupstorage.EncryptedBackupHandle interface.
+func (bh *EncryptedBackupHandle) Encryption() *backupstorage.Encryption {
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+	return bh.encryption
+}
+
+// AddFile is part of the BackupHandle interface.
+func (bh *EncryptedBackupHandle) AddFile(ctx context.Context, filename string, filesize int64) (io.WriteCloser, error) {
+	if bh.readOnly {
+		return nil, fmt.Errorf("AddFile cannot be called on read-only backup")
+	}
+	if filename == manifestFileName {
+		return bh.BackupHandle.AddFile(ctx, filename, filesize)
+	}
+	wc, err := bh.BackupHandle.AddFile(ctx, filename, encryptedSize(bh.aead, filesize))
+	if err != nil {
+		return nil, err
+	}
+	ew, err := newEncryptingWriter(wc, bh.aead, filename)
+	if err != nil {
+		wc.Close()
+		return nil, err
+	}
+	return ew, nil
+}
+
+// ReadFile is part of the BackupHandle interface. The files of backups
+// without encryption in their MANIFEST are read as they are.
+func (bh *EncryptedBackupHandle) ReadFile(ctx context.Context, filename string) (io.ReadCloser, error) {
+	if !bh.readOnly {
+		return nil, fmt.Errorf("ReadFile cannot be called on read-write backup")
+	}
+	if filename == manifestFileName {
+		return bh.BackupHandle.ReadFile(ctx, filename)
+	}
+	aead, err := bh.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := bh.BackupHandle.ReadFile(ctx, filename)
+	if err != nil || aead == nil {
+		return rc, err
+	}
+	return newDecryptingReader(rc, aead, filename), nil
+}
+
+// load reads the encryption of the backup from its MANIFEST, and unwraps
+// its data key.
+func (bh *EncryptedBackupHandle) load(ctx context.Context) (cipher.AEAD, error) {
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+	if bh.loaded {
+		return bh.aead, nil
+	}
+
+	rc, err := bh.BackupHandle.ReadFile(ctx, manifestFileName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the MANIFEST of backup %s: %w", bh.Name(), err)
+	}
+	defer rc.Close()
+	var manifest struct {
+		Encryption *backupstorage.Encryption
+	}
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("cannot decode the MANIFEST of backup %s: %w", bh.Name(), err)
+	}
+
+	if e := manifest.Encryption; e != nil {
+		if e.Algorithm != algorithm {
+			return nil, fmt.Errorf("backup %s is encrypted with unsupported algorithm %q", bh.Name(), e.Algorithm)
+		}
+		km, err := getKeyManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := km.UnwrapKey(ctx, e.KeyID, e.WrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decrypt backup %s: %w", bh.Name(), err)
+		}
+		if bh.aead, err = newAEAD(dataKey); err != nil {
+			return nil, err
+		}
+	}
+	bh.encryption = manifest.Encryption
+	bh.loaded = true
+	return bh.aead, nil
+}
+
+// EncryptedBackupStorage implements BackupStorage on top of the storage
+// --encrypted-backup-storage-implementation.
+type EncryptedBackupStorage struct {
+	params backupstorage.Params
+
+	mu      sync.Mutex
+	storage backupstorage.BackupStorage
+}
+
+func newEncryptedBackupStorage(params backupstorage.Params) *EncryptedBackupStorage {
+	return &EncryptedBackupStorage{params: params}
+}
+
+// underlying returns the storage of the encrypted backups.
+func (ebs *EncryptedBackupStorage) underlying() (backupstorage.BackupStorage, error) {
+	ebs.mu.Lock()
+	defer ebs.mu.Unlock()
+	if ebs.storage == nil {
+		if storageImplementation == implementationName {
+			return nil, fmt.Errorf("the encrypted backup storage cannot store the backups in itself")
+		}
+		bs, ok := backupstorage.BackupStorageMap[storageImplementation]
+		if !ok {
+			return nil, fmt.Errorf("no registered implementation %q of BackupStorage for --encrypted-backup-storage-implementation", storageImplementation)
+		}
+		ebs.storage = bs.WithParams(ebs.params)
+	}
+	return ebs.storage, nil
+}
+
+// ListBackups is part of the BackupStorage interface.
+func (ebs *EncryptedBackupStorage) ListBackups(ctx context.Context, dir string) ([]backupstorage.BackupHandle, error) {
+	bs, err := ebs.underlying()
+	if err != nil {
+		return nil, err
+	}
+	bhs, err := bs.ListBackups(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]backupstorage.BackupHandle, 0, len(bhs))
+	for _, bh := range bhs {
+		result = append(result, &EncryptedBackupHandle{BackupHandle: bh, readOnly: true})
+	}
+	return result, nil
+}
+
+// StartBackup is part of the BackupStorage interface. It generates the
+// data key of the backup, and wraps it with the key manager.
+func (ebs *EncryptedBackupStorage) StartBackup(ctx context.Context, dir, name string) (backupstorage.BackupHandle, error) {
+	bs, err := ebs.underlying()
+	if err != nil {
+		return nil, err
+	}
+	km, err := getKeyManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	id, wrappedKey, err := km.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot wrap the data key of backup %s: %w", name, err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	bh, err := bs.StartBackup(ctx, dir, name)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedBackupHandle{
+		BackupHandle: bh,
+		loaded:       true,
+		encryption: &backupstorage.Encryption{
+			Algorithm:  algorithm,
+			KeyID:      id,
+			WrappedKey: wrappedKey,
+		},
+		aead: aead,
+	}, nil
+}
+
+// RemoveBackup is part of the BackupStorage interface.
+func (ebs *EncryptedBackupStorage) RemoveBackup(ctx context.Context, dir, name string) error {
+	bs, err := ebs.underlying()
+	if err != nil {
+		return err
+	}
+	return bs.RemoveBackup(ctx, dir, name)
+}
+
+// Close is part of the BackupStorage interface.
+func (ebs *EncryptedBackupStorage) Close() error {
+	ebs.mu.Lock()
+	defer ebs.mu.Unlock()
+	if ebs.storage == nil {
+		return nil
+	}
+	return ebs.storage.Close()
+}
+
+// WithParams is part of the BackupStorage interface.
+func (ebs *EncryptedBackupStorage) WithParams(params backupstorage.Params) backupstorage.BackupStorage {
+	return newEncryptedBackupStorage(params)
+}
+
+func init() {
+	backupstorage.BackupStorageMap[implementationName] = newEncryptedBackupStorage(backupstorage.NoParams())
+}
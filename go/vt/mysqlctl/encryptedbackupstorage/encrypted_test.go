@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptedbackupstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/mysqlctl/filebackupstorage"
+)
+
+// setupEncryptedBackupStorage stores the encrypted backups in a file
+// backup storage, with a keyfile of the given keys.
+func setupEncryptedBackupStorage(t *testing.T, keys map[string]byte) *EncryptedBackupStorage {
+	oldRoot, oldImplementation, oldKeyfile := filebackupstorage.FileBackupStorageRoot, storageImplementation, keyfile
+	t.Cleanup(func() {
+		filebackupstorage.FileBackupStorageRoot, storageImplementation, keyfile = oldRoot, oldImplementation, oldKeyfile
+	})
+
+	filebackupstorage.FileBackupStorageRoot = t.TempDir()
+	storageImplementation = "file"
+	encoded := make(map[string]string)
+	for id, b := range keys {
+		encoded[id] = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, dataKeySize))
+	}
+	data, err := json.Marshal(encoded)
+	require.NoError(t, err)
+	keyfile = path.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(keyfile, data, 0o600))
+
+	return newEncryptedBackupStorage(backupstorage.NoParams())
+}
+
+func writeBackupFile(t *testing.T, bh backupstorage.BackupHandle, filename string, data []byte) {
+	wc, err := bh.AddFile(context.Background(), filename, int64(len(data)))
+	require.NoError(t, err)
+	_, err = wc.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, wc.Close())
+}
+
+func readBackupFile(bh backupstorage.BackupHandle, filename string) ([]byte, error) {
+	rc, err := bh.ReadFile(context.Background(), filename)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func TestEncryptedBackupStorage(t *testing.T) {
+	ctx := context.Background()
+	ebs := setupEncryptedBackupStorage(t, map[string]byte{"k1": 1})
+	data := bytes.Repeat([]byte("backup data "), 20000)
+
+	bh, err := ebs.StartBackup(ctx, "ks/0", "backup1")
+	require.NoError(t, err)
+	encryption := backupstorage.GetEncryption(bh)
+	require.NotNil(t, encryption)
+	assert.Equal(t, algorithm, encryption.Algorithm)
+	assert.Equal(t, "k1", encryption.KeyID)
+
+	writeBackupFile(t, bh, "0", data)
+	manifest, err := json.Marshal(map[string]any{"BackupName": "backup1", "Encryption": encryption})
+	require.NoError(t, err)
+	writeBackupFile(t, bh, manifestFileName, manifest)
+	require.NoError(t, bh.EndBackup(ctx))
+
+	// The file is encrypted in the underlying storage, but not the
+	// MANIFEST.
+	raw, err := os.ReadFile(path.Join(filebackupstorage.FileBackupStorageRoot, "ks/0/backup1/0"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "backup data")
+	raw, err = os.ReadFile(path.Join(filebackupstorage.FileBackupStorageRoot, "ks/0/backup1", manifestFileName))
+	require.NoError(t, err)
+	assert.Equal(t, manifest, raw)
+
+	bhs, err := ebs.ListBackups(ctx, "ks/0")
+	require.NoError(t, err)
+	require.Len(t, bhs, 1)
+	out, err := readBackupFile(bhs[0], "0")
+	require.NoError(t, err)
+	assert.Equal(t, data, out)
+	assert.Equal(t, encryption, backupstorage.GetEncryption(bhs[0]))
+
+	// Without the key, the backup cannot be restored.
+	require.NoError(t, os.WriteFile(keyfile, []byte(fmt.Sprintf(`{"k2": %q}`, base64.StdEncoding.EncodeToString(make([]byte, dataKeySize)))), 0o600))
+	bhs, err = ebs.ListBackups(ctx, "ks/0")
+	require.NoError(t, err)
+	_, err = readBackupFile(bhs[0], "0")
+	assert.EqualError(t, err, "cannot decrypt backup backup1: key k1 is not in the backup encryption keyfile")
+
+	require.NoError(t, ebs.RemoveBackup(ctx, "ks/0", "backup1"))
+	bhs, err = ebs.ListBackups(ctx, "ks/0")
+	require.NoError(t, err)
+	assert.Empty(t, bhs)
+}
+
+func TestEncryptedBackupStorageUnencryptedBackup(t *testing.T) {
+	ctx := context.Background()
+	ebs := setupEncryptedBackupStorage(t, map[string]byte{"k1": 1})
+
+	// A backup taken before the encryption was enabled is restored as it is.
+	fbs := backupstorage.BackupStorageMap["file"]
+	bh, err := fbs.StartBackup(ctx, "ks/0", "backup1")
+	require.NoError(t, err)
+	writeBackupFile(t, bh, "0", []byte("plain"))
+	writeBackupFile(t, bh, manifestFileName, []byte(`{"BackupName": "backup1"}`))
+	require.NoError(t, bh.EndBackup(ctx))
+
+	bhs, err := ebs.ListBackups(ctx, "ks/0")
+	require.NoError(t, err)
+	require.Len(t, bhs, 1)
+	out, err := readBackupFile(bhs[0], "0")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(out))
+	assert.Nil(t, backupstorage.GetEncryption(bhs[0]))
+}
+
+func TestEncryptedBackupStorageImplementation(t *testing.T) {
+	ctx := context.Background()
+	ebs := setupEncryptedBackupStorage(t, map[string]byte{"k1": 1})
+
+	storageImplementation = implementationName
+	_, err := ebs.ListBackups(ctx, "ks/0")
+	assert.EqualError(t, err, "the encrypted backup storage cannot store the backups in itself")
+
+	storageImplementation = "unknown"
+	_, err = ebs.StartBackup(ctx, "ks/0", "backup1")
+	assert.EqualError(t, err, `no registered implementation "unknown" of BackupStorage for --encrypted-backup-storage-implementation`)
+}
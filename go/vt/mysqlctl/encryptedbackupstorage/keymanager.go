@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptedbackupstorage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// KeyManager wraps the data keys of the backups with keys it holds, which
+// never leave it. It can be backed by a local keyfile, or by a KMS.
+type KeyManager interface {
+	// WrapKey encrypts the data key of a backup. It returns the id of the
+	// key that wrapped it, which is recorded in the MANIFEST of the backup.
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrappedKey []byte, err error)
+
+	// UnwrapKey decrypts a data key wrapped by the key keyID.
+	UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// KeyManagerFactory creates a KeyManager. It is called once the flags are
+// parsed, each time a backup is started or listed, so it sees the changes
+// to its configuration, like rotated keys.
+type KeyManagerFactory func(ctx context.Context) (KeyManager, error)
+
+// keyManagers are the registered KeyManagers, by name.
+var keyManagers = map[string]KeyManagerFactory{
+	"keyfile": newKeyfileKeyManager,
+}
+
+// RegisterKeyManager registers a KeyManager, which is used when its name is
+// passed to --encrypted-backup-storage-key-manager. Plugins for KMSs call
+// it from their init function.
+func RegisterKeyManager(name string, factory KeyManagerFactory) {
+	if _, ok := keyManagers[name]; ok {
+		panic(fmt.Sprintf("key manager %s is already registered", name))
+	}
+	keyManagers[name] = factory
+}
+
+func getKeyManager(ctx context.Context) (KeyManager, error) {
+	factory, ok := keyManagers[keyManager]
+	if !ok {
+		return nil, fmt.Errorf("no registered key manager %q for the encrypted backup storage", keyManager)
+	}
+	return factory(ctx)
+}
+
+// keyfileKeyManager wraps the data keys with the AES-256 keys of a local
+// JSON file, which maps the ids of the keys to the base64 of the keys:
+//
+//	{"2026-01": "<base64 of 32 bytes>", "2026-07": "<base64 of 32 bytes>"}
+//
+// New data keys are wrapped with the key --encrypted-backup-storage-key-id,
+// which may be omitted when the file has a single key. The older keys are
+// kept in the file to restore the older backups.
+type keyfileKeyManager struct {
+	keys map[string][]byte
+}
+
+func newKeyfileKeyManager(ctx context.Context) (KeyManager, error) {
+	if keyfile == "" {
+		return nil, fmt.Errorf("--encrypted-backup-storage-keyfile is required by the keyfile key manager")
+	}
+	data, err := os.ReadFile(keyfile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the backup encryption keyfile: %w", err)
+	}
+	return parseKeyfile(data)
+}
+
+func parseKeyfile(data []byte) (*keyfileKeyManager, error) {
+	var encoded map[string]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("cannot parse the backup encryption keyfile: %w", err)
+	}
+	km := &keyfileKeyManager{keys: make(map[string][]byte, len(encoded))}
+	for id, value := range encoded {
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("key %s of the backup encryption keyfile is not valid base64: %w", id, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("key %s of the backup encryption keyfile has %d bytes instead of %d", id, len(key), dataKeySize)
+		}
+		km.keys[id] = key
+	}
+	return km, nil
+}
+
+// currentKeyID returns the id of the key that wraps the new data keys.
+func (km *keyfileKeyManager) currentKeyID() (string, error) {
+	if keyID != "" {
+		if _, ok := km.keys[keyID]; !ok {
+			return "", fmt.Errorf("key %s is not in the backup encryption keyfile", keyID)
+		}
+		return keyID, nil
+	}
+	if len(km.keys) != 1 {
+		ids := make([]string, 0, len(km.keys))
+		for id := range km.keys {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return "", fmt.Errorf("--encrypted-backup-storage-key-id must be one of the keys of the backup encryption keyfile: %v", ids)
+	}
+	for id := range km.keys {
+		return id, nil
+	}
+	return "", nil
+}
+
+// WrapKey is part of the KeyManager interface.
+func (km *keyfileKeyManager) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	id, err := km.currentKeyID()
+	if err != nil {
+		return "", nil, err
+	}
+	aead, err := km.aead(id)
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return id, aead.Seal(nonce, nonce, dataKey, []byte(id)), nil
+}
+
+// UnwrapKey is part of the KeyManager interface.
+func (km *keyfileKeyManager) UnwrapKey(ctx context.Context, id string, wrappedKey []byte) ([]byte, error) {
+	aead, err := km.aead(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrappedKey) < aead.NonceSize() {
+		return nil, fmt.Errorf("wrapped data key is too short")
+	}
+	nonce, ciphertext := wrappedKey[:aead.NonceSize()], wrappedKey[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("cannot unwrap the data key with key %s: %w", id, err)
+	}
+	return dataKey, nil
+}
+
+func (km *keyfileKeyManager) aead(id string) (cipher.AEAD, error) {
+	key, ok := km.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("key %s is not in the backup encryption keyfile", id)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
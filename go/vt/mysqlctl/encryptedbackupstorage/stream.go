@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptedbackupstorage
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// The files are encrypted in chunks, so they can be streamed. A file is:
+//
+//	| nonce prefix (7 bytes) | sealed chunk | ... | sealed last chunk |
+//
+// Each chunk has chunkSize bytes of plaintext, except the last one, which
+// may be shorter, down to empty for an empty file. It is sealed with AES-GCM, with the name of the file as
+// additional data, and a nonce made of the prefix of the file, the index
+// of the chunk, and whether it is the last one, so the chunks cannot be
+// reordered, and the file cannot be truncated, without being detected.
+const (
+	algorithm       = "AES-256-GCM-STREAM"
+	dataKeySize     = 32
+	chunkSize       = 64 * 1024
+	noncePrefixSize = 7
+)
+
+var errCorrupted = errors.New("the encrypted backup file is corrupted, truncated, or was encrypted with another key")
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptedSize returns the size of a file of the given size once
+// encrypted, or FileSizeUnknown.
+func encryptedSize(aead cipher.AEAD, size int64) int64 {
+	if size < 0 {
+		return size
+	}
+	chunks := max((size+chunkSize-1)/chunkSize, 1)
+	return noncePrefixSize + size + chunks*int64(aead.Overhead())
+}
+
+// chunkNonce returns the nonce of a chunk of a file.
+type chunkNonce [12]byte
+
+func (n *chunkNonce) set(index uint32, last bool) {
+	binary.BigEndian.PutUint32(n[noncePrefixSize:], index)
+	n[11] = 0
+	if last {
+		n[11] = 1
+	}
+}
+
+// encryptingWriter encrypts a file written to the storage.
+type encryptingWriter struct {
+	w     io.WriteCloser
+	aead  cipher.AEAD
+	ad    []byte
+	nonce chunkNonce
+	index uint32
+
+	// buf holds the plaintext of the current chunk, which is sealed once
+	// it is known not to be the last one.
+	buf    []byte
+	sealed []byte
+	err    error
+}
+
+func newEncryptingWriter(w io.WriteCloser, aead cipher.AEAD, filename string) (*encryptingWriter, error) {
+	ew := &encryptingWriter{
+		w:      w,
+		aead:   aead,
+		ad:     []byte(filename),
+		buf:    make([]byte, 0, chunkSize),
+		sealed: make([]byte, 0, chunkSize+aead.Overhead()),
+	}
+	if _, err := rand.Read(ew.nonce[:noncePrefixSize]); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(ew.nonce[:noncePrefixSize]); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// Write is part of the io.Writer interface.
+func (ew *encryptingWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n := 0
+	for len(p) > 0 {
+		if len(ew.buf) == chunkSize {
+			if ew.err = ew.seal(false); ew.err != nil {
+				return n, ew.err
+			}
+		}
+		c := copy(ew.buf[len(ew.buf):chunkSize], p)
+		ew.buf = ew.buf[:len(ew.buf)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func (ew *encryptingWriter) seal(last bool) error {
+	if ew.index == math.MaxUint32 {
+		return fmt.Errorf("the backup file is too large to be encrypted")
+	}
+	ew.nonce.set(ew.index, last)
+	ew.index++
+	ew.sealed = ew.aead.Seal(ew.sealed[:0], ew.nonce[:], ew.buf, ew.ad)
+	ew.buf = ew.buf[:0]
+	_, err := ew.w.Write(ew.sealed)
+	return err
+}
+
+// Close seals the last chunk, and closes the file.
+func (ew *encryptingWriter) Close() error {
+	err := ew.err
+	if err == nil {
+		err = ew.seal(true)
+	}
+	return errors.Join(err, ew.w.Close())
+}
+
+// decryptingReader decrypts a file read from the storage.
+type decryptingReader struct {
+	r     io.ReadCloser
+	br    *bufio.Reader
+	aead  cipher.AEAD
+	ad    []byte
+	nonce chunkNonce
+	index uint32
+
+	// sealed is the current chunk, and plain what remains of its
+	// plaintext.
+	sealed []byte
+	plain  []byte
+	last   bool
+	err    error
+}
+
+func newDecryptingReader(r io.ReadCloser, aead cipher.AEAD, filename string) *decryptingReader {
+	return &decryptingReader{
+		r:      r,
+		br:     bufio.NewReaderSize(r, chunkSize+aead.Overhead()),
+		aead:   aead,
+		ad:     []byte(filename),
+		sealed: make([]byte, chunkSize+aead.Overhead()),
+	}
+}
+
+// Read is part of the io.Reader interface.
+func (dr *decryptingReader) Read(p []byte) (int, error) {
+	for len(dr.plain) == 0 {
+		if dr.err != nil {
+			return 0, dr.err
+		}
+		if dr.last {
+			return 0, io.EOF
+		}
+		dr.err = dr.open()
+	}
+	n := copy(p, dr.plain)
+	dr.plain = dr.plain[n:]
+	return n, nil
+}
+
+// open reads and opens the next chunk.
+func (dr *decryptingReader) open() error {
+	if dr.index == 0 {
+		if _, err := io.ReadFull(dr.br, dr.nonce[:noncePrefixSize]); err != nil {
+			return errCorrupted
+		}
+	}
+	n, err := io.ReadFull(dr.br, dr.sealed)
+	switch err {
+	case nil:
+		// A full chunk is the last one if nothing follows it.
+		if _, err := dr.br.Peek(1); err == io.EOF {
+			dr.last = true
+		} else if err != nil {
+			return err
+		}
+	case io.ErrUnexpectedEOF:
+		dr.last = true
+	case io.EOF:
+		return errCorrupted
+	default:
+		return err
+	}
+	dr.nonce.set(dr.index, dr.last)
+	dr.index++
+	plain, err := dr.aead.Open(dr.sealed[:0], dr.nonce[:], dr.sealed[:n], dr.ad)
+	if err != nil {
+		return errCorrupted
+	}
+	dr.plain = plain
+	return nil
+}
+
+// Close is part of the io.Closer interface.
+func (dr *decryptingReader) Close() error {
+	return dr.r.Close()
+}
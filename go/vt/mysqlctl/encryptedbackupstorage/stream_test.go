@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptedbackupstorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (bc *bufferCloser) Close() error {
+	return nil
+}
+
+func encrypt(t *testing.T, dataKey []byte, filename string, plain []byte) []byte {
+	aead, err := newAEAD(dataKey)
+	require.NoError(t, err)
+	out := &bufferCloser{}
+	ew, err := newEncryptingWriter(out, aead, filename)
+	require.NoError(t, err)
+	// Small writes cross the chunks.
+	for len(plain) > 0 {
+		n := min(len(plain), 1000)
+		_, err := ew.Write(plain[:n])
+		require.NoError(t, err)
+		plain = plain[n:]
+	}
+	require.NoError(t, ew.Close())
+	return out.Bytes()
+}
+
+func decrypt(t *testing.T, dataKey []byte, filename string, data []byte) ([]byte, error) {
+	aead, err := newAEAD(dataKey)
+	require.NoError(t, err)
+	return io.ReadAll(newDecryptingReader(io.NopCloser(bytes.NewReader(data)), aead, filename))
+}
+
+func TestStream(t *testing.T) {
+	dataKey := make([]byte, dataKeySize)
+	_, err := rand.Read(dataKey)
+	require.NoError(t, err)
+	aead, err := newAEAD(dataKey)
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize, 3*chunkSize + 12345} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			plain := make([]byte, size)
+			_, err := rand.Read(plain)
+			require.NoError(t, err)
+
+			data := encrypt(t, dataKey, "file", plain)
+			assert.EqualValues(t, encryptedSize(aead, int64(size)), len(data))
+			if size > 16 {
+				assert.NotContains(t, string(data), string(plain))
+			}
+
+			out, err := decrypt(t, dataKey, "file", data)
+			require.NoError(t, err)
+			assert.Equal(t, plain, out)
+		})
+	}
+}
+
+func TestStreamTampering(t *testing.T) {
+	dataKey := make([]byte, dataKeySize)
+	plain := bytes.Repeat([]byte("0123456789"), chunkSize/4)
+	data := encrypt(t, dataKey, "file", plain)
+	sealedChunkSize := chunkSize + 16
+
+	testcases := []struct {
+		name     string
+		filename string
+		data     []byte
+	}{{
+		name:     "other file",
+		filename: "other",
+		data:     data,
+	}, {
+		name:     "truncated at a chunk",
+		filename: "file",
+		data:     data[:noncePrefixSize+sealedChunkSize],
+	}, {
+		name:     "truncated in a chunk",
+		filename: "file",
+		data:     data[:len(data)-1],
+	}, {
+		name:     "only the nonce prefix",
+		filename: "file",
+		data:     data[:noncePrefixSize],
+	}, {
+		name:     "empty",
+		filename: "file",
+		data:     nil,
+	}, {
+		name:     "reordered chunks",
+		filename: "file",
+		data: bytes.Join([][]byte{
+			data[:noncePrefixSize],
+			data[noncePrefixSize+sealedChunkSize : noncePrefixSize+2*sealedChunkSize],
+			data[noncePrefixSize : noncePrefixSize+sealedChunkSize],
+			data[noncePrefixSize+2*sealedChunkSize:],
+		}, nil),
+	}, {
+		name:     "flipped bit",
+		filename: "file",
+		data:     append(append([]byte{}, data[:100]...), append([]byte{data[100] ^ 1}, data[101:]...)...),
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			_, err := decrypt(t, dataKey, tcase.filename, tcase.data)
+			assert.ErrorIs(t, err, errCorrupted)
+		})
+	}
+
+	_, err := decrypt(t, bytes.Repeat([]byte{1}, dataKeySize), "file", data)
+	assert.ErrorIs(t, err, errCorrupted)
+}
+
+func TestKeyfileKeyManager(t *testing.T) {
+	defer func(id string) { keyID = id }(keyID)
+	ctx := context.Background()
+	key1 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, dataKeySize))
+	key2 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, dataKeySize))
+
+	km, err := parseKeyfile([]byte(fmt.Sprintf(`{"k1": %q}`, key1)))
+	require.NoError(t, err)
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	id, wrapped, err := km.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", id)
+	assert.NotContains(t, string(wrapped), string(dataKey))
+
+	// After a rotation, the old backups can still be restored.
+	km, err = parseKeyfile([]byte(fmt.Sprintf(`{"k1": %q, "k2": %q}`, key1, key2)))
+	require.NoError(t, err)
+	_, _, err = km.WrapKey(ctx, dataKey)
+	assert.EqualError(t, err, "--encrypted-backup-storage-key-id must be one of the keys of the backup encryption keyfile: [k1 k2]")
+	keyID = "k2"
+	id, _, err = km.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", id)
+
+	unwrapped, err := km.UnwrapKey(ctx, "k1", wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = km.UnwrapKey(ctx, "k2", wrapped)
+	assert.ErrorContains(t, err, "cannot unwrap the data key with key k2")
+	_, err = km.UnwrapKey(ctx, "k3", wrapped)
+	assert.EqualError(t, err, "key k3 is not in the backup encryption keyfile")
+
+	_, err = parseKeyfile([]byte(`{"k1": "AAAA"}`))
+	assert.EqualError(t, err, "key k1 of the backup encryption keyfile has 3 bytes instead of 32")
+	_, err = parseKeyfile([]byte(`{"k1": "!"}`))
+	assert.ErrorContains(t, err, "key k1 of the backup encryption keyfile is not valid base64")
+	_, err = parseKeyfile([]byte(`[]`))
+	assert.ErrorContains(t, err, "cannot parse the backup encryption keyfile")
+}
@@ -179,6 +179,13 @@ func NewOnlineDDLs(keyspace string, sql string, ddlStmt sqlparser.DDLStatement,
 		if err := appendOnlineDDL(ddlStmt.GetTable().Name.String(), ddlStmt); err != nil {
 			return nil, err
 		}
+	case *sqlparser.CreateProcedure, *sqlparser.DropProcedure,
+		*sqlparser.CreateFunction, *sqlparser.DropFunction,
+		*sqlparser.CreateTrigger, *sqlparser.DropTrigger,
+		*sqlparser.CreateEvent, *sqlparser.DropEvent:
+		if err := appendOnlineDDL(ddlStmt.GetTable().Name.String(), ddlStmt); err != nil {
+			return nil, err
+		}
 	case *sqlparser.DropTable, *sqlparser.DropView:
 		tables := ddlStmt.GetFromTables()
 		for _, table := range tables {
@@ -379,6 +386,23 @@ func (onlineDDL *OnlineDDL) IsView(parser *sqlparser.Parser) bool {
 	return false
 }
 
+// IsStoredProgram returns 'true' when the statement affects a stored procedure, a stored function,
+// a trigger or an event. These are created and dropped directly, rather than through a table migration.
+func (onlineDDL *OnlineDDL) IsStoredProgram(parser *sqlparser.Parser) bool {
+	stmt, _, err := ParseOnlineDDLStatement(onlineDDL.SQL, parser)
+	if err != nil {
+		return false
+	}
+	switch stmt.(type) {
+	case *sqlparser.CreateProcedure, *sqlparser.DropProcedure,
+		*sqlparser.CreateFunction, *sqlparser.DropFunction,
+		*sqlparser.CreateTrigger, *sqlparser.DropTrigger,
+		*sqlparser.CreateEvent, *sqlparser.DropEvent:
+		return true
+	}
+	return false
+}
+
 // GetActionStr returns a string representation of the DDL action
 func (onlineDDL *OnlineDDL) GetActionStr(parser *sqlparser.Parser) (action sqlparser.DDLAction, actionStr string, err error) {
 	action, err = onlineDDL.GetAction(parser)
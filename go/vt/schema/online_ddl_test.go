@@ -287,8 +287,18 @@ func TestNewOnlineDDLs(t *testing.T) {
 		isError         bool
 		expectErrorText string
 		isView          bool
+		isStoredProgram bool
 	}
 	tests := map[string]expect{
+		"create procedure p() select 1":                                   {sqls: []string{"create procedure p () select 1 from dual;"}, isStoredProgram: true},
+		"drop procedure p":                                                {sqls: []string{"drop procedure p"}, isStoredProgram: true},
+		"create function f() returns int return 1":                        {sqls: []string{"create function f () returns int return 1;"}, isStoredProgram: true},
+		"drop function if exists f":                                       {sqls: []string{"drop function if exists f"}, isStoredProgram: true},
+		"create trigger tr before insert on t for each row set new.i = 1": {sqls: []string{"create trigger tr before insert on t for each row set new.i = 1;"}, isStoredProgram: true},
+		"drop trigger tr":                                                 {sqls: []string{"drop trigger tr"}, isStoredProgram: true},
+		"create event e on schedule every 1 hour do delete from t":        {sqls: []string{"create event e on schedule every 1 hour do delete from t;"}, isStoredProgram: true},
+		"drop event e":                                                    {sqls: []string{"drop event e"}, isStoredProgram: true},
+
 		"alter table t add column i int, drop column d": {sqls: []string{"alter table t add column i int, drop column d"}},
 		"create table t (id int primary key)":           {sqls: []string{"create table t (id int primary key)"}},
 		"drop table t":                                  {sqls: []string{"drop table t"}},
@@ -347,6 +357,7 @@ func TestNewOnlineDDLs(t *testing.T) {
 				sql = strings.ReplaceAll(sql, "\t", "")
 				sqls = append(sqls, sql)
 				assert.Equal(t, expect.isView, onlineDDL.IsView(parser))
+				assert.Equal(t, expect.isStoredProgram, onlineDDL.IsStoredProgram(parser))
 			}
 			assert.Equal(t, expect.sqls, sqls)
 		})
@@ -402,6 +413,10 @@ func TestOnlineDDLFromCommentedStatement(t *testing.T) {
 		`create view v as select * from t`,
 		`drop view v`,
 		`alter view v as select * from t`,
+		`create procedure p() select 1`,
+		`drop function f`,
+		`create trigger tr before insert on t for each row set new.i = 1`,
+		`create event e on schedule every 1 hour do delete from t`,
 		`revert vitess_migration '4e5dcf80_354b_11eb_82cd_f875a4d24e90'`,
 	}
 	strategySetting := NewDDLStrategySetting(DDLStrategyVitess, `-singleton -declarative --max-load="Threads_running=5"`)
@@ -223,6 +223,22 @@ func EntityDiffByStatement(statement sqlparser.Statement) EntityDiff {
 		return &AlterViewEntityDiff{alterView: stmt}
 	case *sqlparser.DropView:
 		return &DropViewEntityDiff{dropView: stmt}
+	case *sqlparser.CreateProcedure:
+		return &CreateProcedureEntityDiff{createProcedure: stmt}
+	case *sqlparser.DropProcedure:
+		return &DropProcedureEntityDiff{dropProcedure: stmt}
+	case *sqlparser.CreateFunction:
+		return &CreateFunctionEntityDiff{createFunction: stmt}
+	case *sqlparser.DropFunction:
+		return &DropFunctionEntityDiff{dropFunction: stmt}
+	case *sqlparser.CreateTrigger:
+		return &CreateTriggerEntityDiff{createTrigger: stmt}
+	case *sqlparser.DropTrigger:
+		return &DropTriggerEntityDiff{dropTrigger: stmt}
+	case *sqlparser.CreateEvent:
+		return &CreateEventEntityDiff{createEvent: stmt}
+	case *sqlparser.DropEvent:
+		return &DropEventEntityDiff{dropEvent: stmt}
 	}
 	return nil
 }
@@ -1216,6 +1216,49 @@ func TestDiffSchemas(t *testing.T) {
 				"+CREATE TABLE `t4` (\n+\t`id` int,\n+\tPRIMARY KEY (`id`)\n+)",
 			},
 		},
+		{
+			name: "create, modify and drop triggers and routines",
+			from: "create table t1(id int primary key, i int); create trigger t1_bi before insert on t1 for each row set new.i = 1; create trigger t1_bu before update on t1 for each row set new.i = 1; create procedure p1 () begin select 1; end",
+			to:   "create table t1(id int primary key, i int); create trigger t1_bi before insert on t1 for each row set new.i = 2; create function f1 (a int) returns int deterministic return a + 1; create procedure p1 () begin select 1; end",
+			diffs: []string{
+				"drop trigger t1_bu",
+				"drop trigger t1_bi",
+				"create trigger t1_bi before insert on t1 for each row set new.i = 2;",
+				"create function f1 (a int) returns int deterministic return a + 1;",
+			},
+			cdiffs: []string{
+				"DROP TRIGGER `t1_bu`",
+				"DROP TRIGGER `t1_bi`",
+				"CREATE TRIGGER `t1_bi` BEFORE INSERT ON `t1` FOR EACH ROW SET NEW.`i` = 2;",
+				"CREATE FUNCTION `f1` (`a` int) RETURNS int DETERMINISTIC RETURN `a` + 1;",
+			},
+			annotated: []string{
+				"-CREATE TRIGGER `t1_bu` BEFORE UPDATE ON `t1` FOR EACH ROW SET NEW.`i` = 1;",
+				"-CREATE TRIGGER `t1_bi` BEFORE INSERT ON `t1` FOR EACH ROW SET NEW.`i` = 1;",
+				"+CREATE TRIGGER `t1_bi` BEFORE INSERT ON `t1` FOR EACH ROW SET NEW.`i` = 2;",
+				"+CREATE FUNCTION `f1` (`a` int) RETURNS int DETERMINISTIC RETURN `a` + 1;",
+			},
+		},
+		{
+			name: "drop table with trigger",
+			from: "create table t1(id int primary key, i int); create trigger t1_bi before insert on t1 for each row set new.i = 1; create event e1 on schedule every 1 day do delete from t1",
+			to:   "",
+			diffs: []string{
+				"drop event e1",
+				"drop trigger t1_bi",
+				"drop table t1",
+			},
+			cdiffs: []string{
+				"DROP EVENT `e1`",
+				"DROP TRIGGER `t1_bi`",
+				"DROP TABLE `t1`",
+			},
+			annotated: []string{
+				"-CREATE EVENT `e1` ON SCHEDULE EVERY 1 day DO DELETE FROM `t1`;",
+				"-CREATE TRIGGER `t1_bi` BEFORE INSERT ON `t1` FOR EACH ROW SET NEW.`i` = 1;",
+				"-CREATE TABLE `t1` (\n-\t`id` int,\n-\t`i` int,\n-\tPRIMARY KEY (`id`)\n-)",
+			},
+		},
 		{
 			// Making sure schemadiff distinguishes between VIEWs with different casing
 			name: "case insensitive views",
@@ -1434,6 +1477,28 @@ func TestEntityDiffByStatement(t *testing.T) {
 			query: "drop view v1",
 			valid: true,
 		},
+		{
+			query:          "create trigger t1_bi before insert on t1 for each row set new.id = 1",
+			valid:          true,
+			expectAnotated: true,
+		},
+		{
+			query: "drop trigger t1_bi",
+			valid: true,
+		},
+		{
+			query:          "create procedure p1 () begin select 1; end",
+			valid:          true,
+			expectAnotated: true,
+		},
+		{
+			query: "drop function f1",
+			valid: true,
+		},
+		{
+			query: "drop event e1",
+			valid: true,
+		},
 		{
 			query: "drop database d1",
 			valid: false,
@@ -32,6 +32,10 @@ var (
 	ErrUnexpectedTableSpec            = errors.New("unexpected table spec")
 	ErrExpectedCreateTable            = errors.New("expected a CREATE TABLE statement")
 	ErrExpectedCreateView             = errors.New("expected a CREATE VIEW statement")
+	ErrExpectedCreateTrigger          = errors.New("expected a CREATE TRIGGER statement")
+	ErrExpectedCreateProcedure        = errors.New("expected a CREATE PROCEDURE statement")
+	ErrExpectedCreateFunction         = errors.New("expected a CREATE FUNCTION statement")
+	ErrExpectedCreateEvent            = errors.New("expected a CREATE EVENT statement")
 )
 
 type ImpossibleApplyDiffOrderError struct {
@@ -116,6 +120,38 @@ func (e *ApplyViewNotFoundError) Error() string {
 	return fmt.Sprintf("view %s not found", sqlescape.EscapeID(e.View))
 }
 
+type ApplyTriggerNotFoundError struct {
+	Trigger string
+}
+
+func (e *ApplyTriggerNotFoundError) Error() string {
+	return fmt.Sprintf("trigger %s not found", sqlescape.EscapeID(e.Trigger))
+}
+
+type ApplyProcedureNotFoundError struct {
+	Procedure string
+}
+
+func (e *ApplyProcedureNotFoundError) Error() string {
+	return fmt.Sprintf("procedure %s not found", sqlescape.EscapeID(e.Procedure))
+}
+
+type ApplyFunctionNotFoundError struct {
+	Function string
+}
+
+func (e *ApplyFunctionNotFoundError) Error() string {
+	return fmt.Sprintf("function %s not found", sqlescape.EscapeID(e.Function))
+}
+
+type ApplyEventNotFoundError struct {
+	Event string
+}
+
+func (e *ApplyEventNotFoundError) Error() string {
+	return fmt.Sprintf("event %s not found", sqlescape.EscapeID(e.Event))
+}
+
 type ApplyKeyNotFoundError struct {
 	Table string
 	Key   string
@@ -426,6 +462,34 @@ func (e *InvalidStarExprInViewError) Error() string {
 	return fmt.Sprintf("view %s has invalid star expression", sqlescape.EscapeID(e.View))
 }
 
+type TriggerNonexistentTableError struct {
+	Trigger string
+	Table   string
+}
+
+func (e *TriggerNonexistentTableError) Error() string {
+	return fmt.Sprintf("trigger %s references nonexistent table %s", sqlescape.EscapeID(e.Trigger), sqlescape.EscapeID(e.Table))
+}
+
+type TriggerReferencesViewError struct {
+	Trigger string
+	View    string
+}
+
+func (e *TriggerReferencesViewError) Error() string {
+	return fmt.Sprintf("trigger %s references view %s", sqlescape.EscapeID(e.Trigger), sqlescape.EscapeID(e.View))
+}
+
+type TriggerOrderUnresolvedError struct {
+	Trigger           string
+	ReferencedTrigger string
+}
+
+func (e *TriggerOrderUnresolvedError) Error() string {
+	return fmt.Sprintf("trigger %s is ordered relative to %s, which is not a trigger with the same table, action time and event, or is part of an ordering loop",
+		sqlescape.EscapeID(e.Trigger), sqlescape.EscapeID(e.ReferencedTrigger))
+}
+
 type EntityNotFoundError struct {
 	Name string
 }
@@ -534,3 +598,20 @@ type DuplicateForeignKeyConstraintNameError struct {
 func (e *DuplicateForeignKeyConstraintNameError) Error() string {
 	return fmt.Sprintf("duplicate foreign key constraint name %s in table %s", sqlescape.EscapeID(e.Constraint), sqlescape.EscapeID(e.Table))
 }
+
+type UnknownLintRuleError struct {
+	Rule string
+}
+
+func (e *UnknownLintRuleError) Error() string {
+	return fmt.Sprintf("unknown lint rule: %s", e.Rule)
+}
+
+type InvalidLintSeverityError struct {
+	Rule     string
+	Severity string
+}
+
+func (e *InvalidLintSeverityError) Error() string {
+	return fmt.Sprintf("invalid severity %q for lint rule %s, expected one of off, warning, error", e.Severity, e.Rule)
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+type CreateEventEntityDiff struct {
+	createEvent *sqlparser.CreateEvent
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *CreateEventEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *CreateEventEntityDiff) EntityName() string {
+	_, to := d.Entities()
+	return to.Name()
+}
+
+// Entities implements EntityDiff
+func (d *CreateEventEntityDiff) Entities() (from Entity, to Entity) {
+	return nil, &CreateEventEntity{CreateEvent: d.createEvent}
+}
+
+func (d *CreateEventEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *CreateEventEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.createEvent
+}
+
+// CreateEvent returns the underlying sqlparser.CreateEvent that was generated for the diff.
+func (d *CreateEventEntityDiff) CreateEvent() *sqlparser.CreateEvent {
+	if d == nil {
+		return nil
+	}
+	return d.createEvent
+}
+
+// StatementString implements EntityDiff
+func (d *CreateEventEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *CreateEventEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *CreateEventEntityDiff) SubsequentDiff() EntityDiff {
+	return nil
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *CreateEventEntityDiff) SetSubsequentDiff(EntityDiff) {
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *CreateEventEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *CreateEventEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	return &CreateEventEntityDiff{
+		createEvent: sqlparser.Clone(d.createEvent),
+	}
+}
+
+// DropEventEntityDiff drops an event. A modified event is expressed as a DropEventEntityDiff
+// with a subsequent CreateEventEntityDiff.
+type DropEventEntityDiff struct {
+	from           *CreateEventEntity
+	dropEvent      *sqlparser.DropEvent
+	subsequentDiff *CreateEventEntityDiff
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *DropEventEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *DropEventEntityDiff) EntityName() string {
+	return d.from.Name()
+}
+
+// Entities implements EntityDiff
+func (d *DropEventEntityDiff) Entities() (from Entity, to Entity) {
+	return d.from, nil
+}
+
+func (d *DropEventEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *DropEventEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.dropEvent
+}
+
+// DropEvent returns the underlying sqlparser.DropEvent that was generated for the diff.
+func (d *DropEventEntityDiff) DropEvent() *sqlparser.DropEvent {
+	if d == nil {
+		return nil
+	}
+	return d.dropEvent
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *DropEventEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// StatementString implements EntityDiff
+func (d *DropEventEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *DropEventEntityDiff) SubsequentDiff() EntityDiff {
+	if d == nil || d.subsequentDiff == nil {
+		return nil
+	}
+	return d.subsequentDiff
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *DropEventEntityDiff) SetSubsequentDiff(subDiff EntityDiff) {
+	if d == nil {
+		return
+	}
+	if createDiff, ok := subDiff.(*CreateEventEntityDiff); ok {
+		d.subsequentDiff = createDiff
+	} else {
+		d.subsequentDiff = nil
+	}
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *DropEventEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *DropEventEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	clone := &DropEventEntityDiff{
+		dropEvent: sqlparser.Clone(d.dropEvent),
+	}
+	if d.from != nil {
+		clone.from = d.from.Clone().(*CreateEventEntity)
+	}
+	if d.subsequentDiff != nil {
+		clone.subsequentDiff = d.subsequentDiff.Clone().(*CreateEventEntityDiff)
+	}
+	return clone
+}
+
+// CreateEventEntity stands for a EVENT construct. It contains the event's CREATE statement.
+type CreateEventEntity struct {
+	*sqlparser.CreateEvent
+	env *Environment
+}
+
+func NewCreateEventEntity(env *Environment, c *sqlparser.CreateEvent) (*CreateEventEntity, error) {
+	entity := &CreateEventEntity{CreateEvent: c, env: env}
+	return entity, nil
+}
+
+func NewCreateEventEntityFromSQL(env *Environment, sql string) (*CreateEventEntity, error) {
+	stmt, err := env.Parser().ParseStrictDDL(sql)
+	if err != nil {
+		return nil, err
+	}
+	createEvent, ok := stmt.(*sqlparser.CreateEvent)
+	if !ok {
+		return nil, ErrExpectedCreateEvent
+	}
+	return NewCreateEventEntity(env, createEvent)
+}
+
+// Name implements Entity interface
+func (c *CreateEventEntity) Name() string {
+	return c.CreateEvent.Name.Name.String()
+}
+
+// Diff implements Entity interface function
+func (c *CreateEventEntity) Diff(other Entity, hints *DiffHints) (EntityDiff, error) {
+	otherCreateEvent, ok := other.(*CreateEventEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return c.EventDiff(otherCreateEvent, hints)
+}
+
+// EventDiff compares this event statement with another event statement, and sees what it takes to
+// change this event to look like the other event.
+// Any change is expressed as a DROP EVENT followed by a CREATE EVENT, rather than as an ALTER EVENT.
+// It returns nil if the two are identical. The other event may be of different name; its name is ignored.
+func (c *CreateEventEntity) EventDiff(other *CreateEventEntity, _ *DiffHints) (*DropEventEntityDiff, error) {
+	if c.identicalOtherThanName(other) {
+		return nil, nil
+	}
+	diff := c.Drop().(*DropEventEntityDiff)
+	diff.subsequentDiff = &CreateEventEntityDiff{createEvent: other.CreateEvent}
+	return diff, nil
+}
+
+// Create implements Entity interface
+func (c *CreateEventEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateEventEntityDiff{createEvent: c.CreateEvent}
+}
+
+// Drop implements Entity interface
+func (c *CreateEventEntity) Drop() EntityDiff {
+	dropEvent := &sqlparser.DropEvent{
+		Name: c.CreateEvent.Name,
+	}
+	return &DropEventEntityDiff{from: c, dropEvent: dropEvent}
+}
+
+// Apply attempts to apply given diff onto the event defined by this entity. The only supported diff
+// is the one generated by EventDiff(): a DROP EVENT followed by a CREATE EVENT.
+// This entity is unmodified. If successful, a new CREATE EVENT entity is returned.
+func (c *CreateEventEntity) Apply(diff EntityDiff) (Entity, error) {
+	dropDiff, ok := diff.(*DropEventEntityDiff)
+	if !ok || dropDiff.subsequentDiff == nil {
+		return nil, ErrEntityTypeMismatch
+	}
+	return &CreateEventEntity{CreateEvent: sqlparser.Clone(dropDiff.subsequentDiff.createEvent), env: c.env}, nil
+}
+
+func (c *CreateEventEntity) Clone() Entity {
+	return &CreateEventEntity{CreateEvent: sqlparser.Clone(c.CreateEvent), env: c.env}
+}
+
+func (c *CreateEventEntity) identicalOtherThanName(other *CreateEventEntity) bool {
+	if other == nil {
+		return false
+	}
+	return c.OnCompletion == other.OnCompletion &&
+		c.Status == other.Status &&
+		sqlparser.Equals.RefOfEventSchedule(c.Schedule, other.Schedule) &&
+		sqlparser.Equals.RefOfLiteral(c.EventComment, other.EventComment) &&
+		sqlparser.Equals.RefOfDefiner(c.Definer, other.Definer) &&
+		sqlparser.Equals.CompoundStatement(c.Body, other.Body) &&
+		sqlparser.Equals.RefOfParsedComments(c.Comments, other.Comments)
+}
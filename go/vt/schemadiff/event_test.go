@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEventDiff(t *testing.T) {
+	tt := []struct {
+		name   string
+		from   string
+		to     string
+		cdiffs []string
+	}{
+		{
+			name: "identical",
+			from: "create event e1 on schedule every 1 day do delete from t1",
+			to:   "CREATE EVENT `e1` ON SCHEDULE EVERY 1 DAY DO DELETE FROM `t1`",
+		},
+		{
+			name: "change of schedule",
+			from: "create event e1 on schedule every 1 day do delete from t1",
+			to:   "create event e1 on schedule every 1 hour do delete from t1",
+			cdiffs: []string{
+				"DROP EVENT `e1`",
+				"CREATE EVENT `e1` ON SCHEDULE EVERY 1 hour DO DELETE FROM `t1`;",
+			},
+		},
+		{
+			name: "change of status",
+			from: "create event e1 on schedule at '2030-01-01 00:00:00' do delete from t1",
+			to:   "create event e1 on schedule at '2030-01-01 00:00:00' on completion preserve disable do delete from t1",
+			cdiffs: []string{
+				"DROP EVENT `e1`",
+				"CREATE EVENT `e1` ON SCHEDULE AT '2030-01-01 00:00:00' ON COMPLETION PRESERVE DISABLE DO DELETE FROM `t1`;",
+			},
+		},
+	}
+	hints := EmptyDiffHints()
+	env := NewTestEnv()
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			c, err := NewCreateEventEntityFromSQL(env, ts.from)
+			require.NoError(t, err)
+			other, err := NewCreateEventEntityFromSQL(env, ts.to)
+			require.NoError(t, err)
+
+			diff, err := c.Diff(other, hints)
+			require.NoError(t, err)
+			if len(ts.cdiffs) == 0 {
+				assert.True(t, diff.IsEmpty())
+				return
+			}
+			var cdiffs []string
+			for _, d := range AllSubsequent(diff) {
+				cdiffs = append(cdiffs, d.CanonicalStatementString())
+			}
+			assert.Equal(t, ts.cdiffs, cdiffs)
+
+			applied, err := c.Apply(diff)
+			require.NoError(t, err)
+			appliedDiff, err := other.Diff(applied, hints)
+			require.NoError(t, err)
+			assert.True(t, appliedDiff.IsEmpty(), "expected empty diff, found changes: %v", appliedDiff.CanonicalStatementString())
+		})
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// LintSeverity is the severity of a lint rule, and of the findings it reports.
+type LintSeverity int
+
+const (
+	// LintSeverityOff disables a rule.
+	LintSeverityOff LintSeverity = iota
+	// LintSeverityWarning reports findings that do not prevent a schema change.
+	LintSeverityWarning
+	// LintSeverityError reports findings that prevent a schema change.
+	LintSeverityError
+)
+
+var lintSeverityNames = map[LintSeverity]string{
+	LintSeverityOff:     "off",
+	LintSeverityWarning: "warning",
+	LintSeverityError:   "error",
+}
+
+func (s LintSeverity) String() string {
+	if name, ok := lintSeverityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("LintSeverity(%d)", int(s))
+}
+
+// Names of the lint rules.
+const (
+	LintRuleMissingPrimaryKey            = "missing-primary-key"
+	LintRuleMissingVindexColumn          = "missing-vindex-column"
+	LintRuleCrossKeyspaceForeignKey      = "cross-keyspace-foreign-key"
+	LintRuleUtf8mb3                      = "utf8mb3"
+	LintRuleNullableUniqueKey            = "nullable-unique-key"
+	LintRuleWideIndex                    = "wide-index"
+	LintRuleAutoIncrementWithoutSequence = "auto-increment-without-sequence"
+)
+
+// DefaultLintMaxIndexKeyLength is the key length, in bytes, above which the wide-index rule reports an index.
+// It is the index key prefix limit of InnoDB tables with the REDUNDANT or COMPACT row format.
+const DefaultLintMaxIndexKeyLength = 767
+
+// LintRule is a named check that runs on every table of a schema.
+type LintRule struct {
+	Name            string
+	Description     string
+	DefaultSeverity LintSeverity
+
+	// check returns a message per issue that the rule finds in the given table.
+	check func(t *CreateTableEntity, opts *LintOptions) []string
+}
+
+var lintRules = []*LintRule{
+	{
+		Name:            LintRuleMissingPrimaryKey,
+		Description:     "Tables must have a PRIMARY KEY.",
+		DefaultSeverity: LintSeverityWarning,
+		check:           lintMissingPrimaryKey,
+	},
+	{
+		Name:            LintRuleMissingVindexColumn,
+		Description:     "Tables of a sharded keyspace must have a primary vindex over existing columns in the VSchema.",
+		DefaultSeverity: LintSeverityWarning,
+		check:           lintMissingVindexColumn,
+	},
+	{
+		Name:            LintRuleCrossKeyspaceForeignKey,
+		Description:     "Foreign keys must not reference tables in other keyspaces.",
+		DefaultSeverity: LintSeverityWarning,
+		check:           lintCrossKeyspaceForeignKey,
+	},
+	{
+		Name:            LintRuleUtf8mb3,
+		Description:     "Tables and columns should not use the deprecated utf8mb3 (utf8) character set.",
+		DefaultSeverity: LintSeverityWarning,
+		check:           lintUtf8mb3,
+	},
+	{
+		Name:            LintRuleNullableUniqueKey,
+		Description:     "Unique keys should not cover nullable columns, as they allow duplicate rows with NULL values.",
+		DefaultSeverity: LintSeverityWarning,
+		check:           lintNullableUniqueKey,
+	},
+	{
+		Name:            LintRuleWideIndex,
+		Description:     "Index keys should not be wider than the maximum index key length.",
+		DefaultSeverity: LintSeverityWarning,
+		check:           lintWideIndex,
+	},
+	{
+		Name:            LintRuleAutoIncrementWithoutSequence,
+		Description:     "AUTO_INCREMENT columns of tables in a sharded keyspace must be backed by a sequence in the VSchema.",
+		DefaultSeverity: LintSeverityWarning,
+		check:           lintAutoIncrementWithoutSequence,
+	},
+}
+
+// LintRules returns all the lint rules.
+func LintRules() []*LintRule {
+	return append([]*LintRule{}, lintRules...)
+}
+
+// ParseLintSeverities validates the given map of rule names to severity names ("off", "warning" or "error"),
+// and returns the equivalent map of rule names to severities.
+func ParseLintSeverities(severities map[string]string) (map[string]LintSeverity, error) {
+	known := make(map[string]bool, len(lintRules))
+	for _, rule := range lintRules {
+		known[rule.Name] = true
+	}
+	result := make(map[string]LintSeverity, len(severities))
+	for rule, severityName := range severities {
+		if !known[rule] {
+			return nil, &UnknownLintRuleError{Rule: rule}
+		}
+		found := false
+		for severity, name := range lintSeverityNames {
+			if strings.EqualFold(severityName, name) {
+				result[rule] = severity
+				found = true
+			}
+		}
+		if !found {
+			return nil, &InvalidLintSeverityError{Rule: rule, Severity: severityName}
+		}
+	}
+	return result, nil
+}
+
+// LintOptions configures how a schema is linted.
+type LintOptions struct {
+	// Keyspace is the name of the keyspace of the schema.
+	Keyspace string
+	// VSchema is the VSchema of the keyspace. Without it, the rules that depend on the VSchema find nothing.
+	VSchema *vschemapb.Keyspace
+	// Severities overrides the default severity of rules, by rule name.
+	Severities map[string]LintSeverity
+	// MaxIndexKeyLength is the key length, in bytes, above which the wide-index rule reports an index.
+	// If zero, DefaultLintMaxIndexKeyLength is used.
+	MaxIndexKeyLength int
+}
+
+func (opts *LintOptions) severity(rule *LintRule) LintSeverity {
+	if severity, ok := opts.Severities[rule.Name]; ok {
+		return severity
+	}
+	return rule.DefaultSeverity
+}
+
+// shardedVSchemaTable returns the VSchema table of the given table if the keyspace is sharded.
+// The second return value is false if the keyspace is not known to be sharded.
+func (opts *LintOptions) shardedVSchemaTable(t *CreateTableEntity) (*vschemapb.Table, bool) {
+	if opts.VSchema == nil || !opts.VSchema.Sharded {
+		return nil, false
+	}
+	return opts.VSchema.Tables[t.Name()], true
+}
+
+// LintFinding is an issue that a lint rule found in a table.
+type LintFinding struct {
+	Rule     string
+	Severity LintSeverity
+	// Entity is the name of the table.
+	Entity  string
+	Message string
+}
+
+func (f *LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: table %s: %s", f.Severity, f.Rule, sqlescape.EscapeID(f.Entity), f.Message)
+}
+
+// HasLintErrors returns true if any of the given findings has error severity.
+func HasLintErrors(findings []*LintFinding) bool {
+	for _, f := range findings {
+		if f.Severity == LintSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintSchema runs the lint rules that are not turned off on all the tables of the given schema,
+// and returns their findings, ordered by table and by rule.
+func LintSchema(s *Schema, opts *LintOptions) []*LintFinding {
+	var findings []*LintFinding
+	for _, t := range s.Tables() {
+		findings = append(findings, LintTable(t, opts)...)
+	}
+	return findings
+}
+
+// LintTable runs the lint rules that are not turned off on the given table, and returns their findings.
+func LintTable(t *CreateTableEntity, opts *LintOptions) []*LintFinding {
+	var findings []*LintFinding
+	for _, rule := range lintRules {
+		severity := opts.severity(rule)
+		if severity == LintSeverityOff {
+			continue
+		}
+		for _, message := range rule.check(t, opts) {
+			findings = append(findings, &LintFinding{
+				Rule:     rule.Name,
+				Severity: severity,
+				Entity:   t.Name(),
+				Message:  message,
+			})
+		}
+	}
+	return findings
+}
+
+func lintMissingPrimaryKey(t *CreateTableEntity, opts *LintOptions) []string {
+	for _, key := range t.TableSpec.Indexes {
+		if key.Info.Type == sqlparser.IndexTypePrimary {
+			return nil
+		}
+	}
+	return []string{"table has no PRIMARY KEY"}
+}
+
+func lintMissingVindexColumn(t *CreateTableEntity, opts *LintOptions) []string {
+	vschemaTable, sharded := opts.shardedVSchemaTable(t)
+	if !sharded {
+		return nil
+	}
+	if vschemaTable == nil {
+		return []string{fmt.Sprintf("table is not in the VSchema of sharded keyspace %s", opts.Keyspace)}
+	}
+	if vschemaTable.Type == vindexes.TypeReference || vschemaTable.Type == vindexes.TypeSequence || vschemaTable.Pinned != "" {
+		// These tables are not routed by a vindex.
+		return nil
+	}
+	if len(vschemaTable.ColumnVindexes) == 0 {
+		return []string{"table has no primary vindex in the VSchema"}
+	}
+	var messages []string
+	columns := t.ColumnDefinitionEntitiesList()
+	for _, columnVindex := range vschemaTable.ColumnVindexes {
+		vindexColumns := columnVindex.Columns
+		if columnVindex.Column != "" {
+			vindexColumns = append([]string{columnVindex.Column}, vindexColumns...)
+		}
+		for _, column := range vindexColumns {
+			if columns.GetColumn(column) == nil && columns.GetColumn(strings.ToLower(column)) == nil {
+				messages = append(messages, fmt.Sprintf("column %s of vindex %s is not a column of the table", sqlescape.EscapeID(column), columnVindex.Name))
+			}
+		}
+	}
+	return messages
+}
+
+func lintCrossKeyspaceForeignKey(t *CreateTableEntity, opts *LintOptions) []string {
+	var messages []string
+	for _, cs := range t.TableSpec.Constraints {
+		fk, ok := cs.Details.(*sqlparser.ForeignKeyDefinition)
+		if !ok {
+			continue
+		}
+		referencedTable := fk.ReferenceDefinition.ReferencedTable
+		if referencedTable.Qualifier.IsEmpty() || referencedTable.Qualifier.String() == opts.Keyspace {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("foreign key %s references table %s in keyspace %s",
+			sqlescape.EscapeID(cs.Name.String()), sqlescape.EscapeID(referencedTable.Name.String()), sqlescape.EscapeID(referencedTable.Qualifier.String())))
+	}
+	return messages
+}
+
+func isUtf8mb3(charset string) bool {
+	return charset == "utf8mb3" || charset == "utf8"
+}
+
+func lintUtf8mb3(t *CreateTableEntity, opts *LintOptions) []string {
+	var messages []string
+	if charset := t.GetCharset(); isUtf8mb3(charset) {
+		messages = append(messages, fmt.Sprintf("table uses the %s character set", charset))
+	}
+	for _, col := range t.ColumnDefinitionEntities() {
+		if !col.IsTextual() {
+			continue
+		}
+		// Columns that inherit the character set of the table are covered by the table's finding.
+		charset := col.Charset()
+		if charset == "" && col.Collate() != "" {
+			charset = t.Env.CollationEnv().LookupCharsetName(t.Env.CollationEnv().LookupByName(col.Collate()))
+		}
+		if isUtf8mb3(charset) {
+			messages = append(messages, fmt.Sprintf("column %s uses the %s character set", sqlescape.EscapeID(col.Name()), charset))
+		}
+	}
+	return messages
+}
+
+func lintNullableUniqueKey(t *CreateTableEntity, opts *LintOptions) []string {
+	var messages []string
+	for _, key := range t.IndexDefinitionEntities() {
+		if !key.IsUnique() || key.IsPrimary() {
+			continue
+		}
+		nullable := key.ColumnList.Filter(func(col *ColumnDefinitionEntity) bool {
+			return col.IsNullable()
+		})
+		if nullable.Len() > 0 {
+			messages = append(messages, fmt.Sprintf("unique key %s covers nullable columns: %s",
+				sqlescape.EscapeID(key.Name()), strings.Join(nullable.Names(), ", ")))
+		}
+	}
+	return messages
+}
+
+func lintWideIndex(t *CreateTableEntity, opts *LintOptions) []string {
+	maxLength := opts.MaxIndexKeyLength
+	if maxLength == 0 {
+		maxLength = DefaultLintMaxIndexKeyLength
+	}
+	var messages []string
+	for _, key := range t.IndexDefinitionEntities() {
+		if length := indexKeyLength(key); length > maxLength {
+			messages = append(messages, fmt.Sprintf("key %s is %d bytes wide, more than %d bytes", sqlescape.EscapeID(key.Name()), length, maxLength))
+		}
+	}
+	return messages
+}
+
+func lintAutoIncrementWithoutSequence(t *CreateTableEntity, opts *LintOptions) []string {
+	vschemaTable, sharded := opts.shardedVSchemaTable(t)
+	if !sharded {
+		return nil
+	}
+	if vschemaTable != nil && (vschemaTable.AutoIncrement != nil || vschemaTable.Type == vindexes.TypeReference || vschemaTable.Pinned != "") {
+		return nil
+	}
+	for _, col := range t.ColumnDefinitionEntities() {
+		if col.IsAutoIncrement() {
+			return []string{fmt.Sprintf("column %s is AUTO_INCREMENT, but the table has no sequence in the VSchema of sharded keyspace %s", sqlescape.EscapeID(col.Name()), opts.Keyspace)}
+		}
+	}
+	return nil
+}
+
+// indexKeyLength estimates the length, in bytes, of the key of the given index. Index expressions, and
+// columns of types whose length is unknown, are not counted.
+func indexKeyLength(key *IndexDefinitionEntity) int {
+	length := 0
+	for _, keyCol := range key.IndexDefinition.Columns {
+		col := key.ColumnList.GetColumn(keyCol.Column.Lowered())
+		if col == nil {
+			continue
+		}
+		length += indexColumnKeyLength(col, keyCol.Length)
+	}
+	return length
+}
+
+// indexColumnKeyLength estimates the length, in bytes, that the given column takes in an index key,
+// given the optional prefix length of the column in the index.
+func indexColumnKeyLength(col *ColumnDefinitionEntity, prefixLength *int) int {
+	if col.IsIntegralType() {
+		return IntegralTypeStorage(col.Type())
+	}
+	if col.IsFloatingPointType() {
+		return FloatingPointTypeStorage(col.Type())
+	}
+	length := col.Length()
+	if prefixLength != nil {
+		length = *prefixLength
+	}
+	switch col.Type() {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		_, _, maxWidth, _, err := col.InferCharsetCollate()
+		if err != nil || maxWidth == 0 {
+			maxWidth = 1
+		}
+		return length * maxWidth
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return length
+	case "decimal", "numeric":
+		// Nine decimal digits take four bytes.
+		return (col.Length() + 8) / 9 * 4
+	case "bit":
+		return (col.Length() + 7) / 8
+	case "year":
+		return 1
+	case "enum":
+		return 2
+	case "date", "time":
+		return 3
+	case "timestamp":
+		return 4
+	case "datetime":
+		return 5
+	case "set":
+		return 8
+	}
+	return 0
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestLintSchema(t *testing.T) {
+	shardedVSchema := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"xxhash": {Type: "xxhash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "id"}},
+				AutoIncrement:  &vschemapb.AutoIncrement{Column: "id", Sequence: "t1_seq"},
+			},
+			"t2": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Columns: []string{"user_id"}}},
+			},
+			"t3":  {},
+			"ref": {Type: "reference"},
+		},
+	}
+	tt := []struct {
+		name       string
+		schema     string
+		vschema    *vschemapb.Keyspace
+		severities map[string]LintSeverity
+		maxKeyLen  int
+		findings   []string
+	}{
+		{
+			name:   "clean",
+			schema: "create table t1 (id int primary key, name varchar(32) not null, unique key name_uidx (name))",
+		},
+		{
+			name:   "missing primary key",
+			schema: "create table t1 (id int, key id_idx (id)); create table t2 (id int not null, unique key id_uidx (id))",
+			findings: []string{
+				"warning: missing-primary-key: table `t1`: table has no PRIMARY KEY",
+				"warning: missing-primary-key: table `t2`: table has no PRIMARY KEY",
+			},
+		},
+		{
+			name:       "severity override",
+			schema:     "create table t1 (id int, key id_idx (id)); create table t2 (id int primary key, name varchar(32), unique key name_uidx (name))",
+			severities: map[string]LintSeverity{LintRuleMissingPrimaryKey: LintSeverityError, LintRuleNullableUniqueKey: LintSeverityOff},
+			findings: []string{
+				"error: missing-primary-key: table `t1`: table has no PRIMARY KEY",
+			},
+		},
+		{
+			name:   "nullable unique key",
+			schema: "create table t1 (id int primary key, a int, b int not null, c int, unique key ab_uidx (a, b), unique key bc_uidx (b, c), key ac_idx (a, c))",
+			findings: []string{
+				"warning: nullable-unique-key: table `t1`: unique key `ab_uidx` covers nullable columns: a",
+				"warning: nullable-unique-key: table `t1`: unique key `bc_uidx` covers nullable columns: c",
+			},
+		},
+		{
+			name:   "utf8mb3",
+			schema: "create table t1 (id int primary key, a varchar(32) charset utf8, b varchar(32) collate utf8mb3_bin, c varchar(32)) charset utf8mb4; create table t2 (id int primary key, a varchar(32)) charset utf8mb3",
+			findings: []string{
+				"warning: utf8mb3: table `t1`: column `a` uses the utf8mb3 character set",
+				"warning: utf8mb3: table `t1`: column `b` uses the utf8mb3 character set",
+				"warning: utf8mb3: table `t2`: table uses the utf8mb3 character set",
+			},
+		},
+		{
+			name:   "wide index",
+			schema: "create table t1 (id bigint primary key, a varchar(200), b varchar(200) charset latin1, c varchar(1000), key a_idx (a), key b_idx (b, id), key c_idx (c(100)), key ac_idx (id, a, c(10)))",
+			findings: []string{
+				"warning: wide-index: table `t1`: key `a_idx` is 800 bytes wide, more than 767 bytes",
+				"warning: wide-index: table `t1`: key `ac_idx` is 848 bytes wide, more than 767 bytes",
+			},
+		},
+		{
+			name:      "wide index with custom max key length",
+			schema:    "create table t1 (id bigint primary key, a varchar(200), b varchar(200) charset latin1, key a_idx (a), key b_idx (b, id))",
+			maxKeyLen: 200,
+			findings: []string{
+				"warning: wide-index: table `t1`: key `a_idx` is 800 bytes wide, more than 200 bytes",
+				"warning: wide-index: table `t1`: key `b_idx` is 208 bytes wide, more than 200 bytes",
+			},
+		},
+		{
+			name:   "cross keyspace foreign key",
+			schema: "create table t1 (id int primary key, p1 int, p2 int, key p1_idx (p1), key p2_idx (p2), constraint p1_fk foreign key (p1) references other.parent (id), constraint p2_fk foreign key (p2) references ks.t2 (id)); create table t2 (id int primary key)",
+			findings: []string{
+				"warning: cross-keyspace-foreign-key: table `t1`: foreign key `p1_fk` references table `parent` in keyspace `other`",
+			},
+		},
+		{
+			name:   "vschema rules without vschema",
+			schema: "create table t1 (id int auto_increment primary key)",
+		},
+		{
+			name:    "vschema rules in sharded keyspace",
+			schema:  "create table t1 (id int auto_increment primary key); create table t2 (id int auto_increment primary key, customer_id int); create table t3 (id int primary key); create table t4 (id int primary key); create table ref (id int auto_increment primary key)",
+			vschema: shardedVSchema,
+			findings: []string{
+				"warning: missing-vindex-column: table `t2`: column `user_id` of vindex xxhash is not a column of the table",
+				"warning: auto-increment-without-sequence: table `t2`: column `id` is AUTO_INCREMENT, but the table has no sequence in the VSchema of sharded keyspace ks",
+				"warning: missing-vindex-column: table `t3`: table has no primary vindex in the VSchema",
+				"warning: missing-vindex-column: table `t4`: table is not in the VSchema of sharded keyspace ks",
+			},
+		},
+		{
+			name:     "vschema rules in unsharded keyspace",
+			schema:   "create table t1 (id int auto_increment primary key)",
+			vschema:  &vschemapb.Keyspace{},
+			findings: nil,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			env := NewTestEnv()
+			schema, err := NewSchemaFromSQLWithHints(env, tc.schema, &DiffHints{ForeignKeyCheckStrategy: ForeignKeyCheckStrategyIgnore})
+			require.NoError(t, err)
+
+			findings := LintSchema(schema, &LintOptions{
+				Keyspace:          "ks",
+				VSchema:           tc.vschema,
+				Severities:        tc.severities,
+				MaxIndexKeyLength: tc.maxKeyLen,
+			})
+			var findingStrings []string
+			for _, f := range findings {
+				findingStrings = append(findingStrings, f.String())
+			}
+			assert.Equal(t, tc.findings, findingStrings)
+			assert.Equal(t, tc.severities[LintRuleMissingPrimaryKey] == LintSeverityError && len(findings) > 0, HasLintErrors(findings))
+		})
+	}
+}
+
+func TestParseLintSeverities(t *testing.T) {
+	severities, err := ParseLintSeverities(map[string]string{
+		LintRuleMissingPrimaryKey: "error",
+		LintRuleUtf8mb3:           "Off",
+		LintRuleWideIndex:         "warning",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]LintSeverity{
+		LintRuleMissingPrimaryKey: LintSeverityError,
+		LintRuleUtf8mb3:           LintSeverityOff,
+		LintRuleWideIndex:         LintSeverityWarning,
+	}, severities)
+
+	_, err = ParseLintSeverities(map[string]string{"no-such-rule": "error"})
+	assert.EqualError(t, err, "unknown lint rule: no-such-rule")
+
+	_, err = ParseLintSeverities(map[string]string{LintRuleWideIndex: "fatal"})
+	assert.EqualError(t, err, `invalid severity "fatal" for lint rule wide-index, expected one of off, warning, error`)
+}
+
+func TestLintRules(t *testing.T) {
+	names := map[string]bool{}
+	for _, rule := range LintRules() {
+		assert.NotEmpty(t, rule.Description)
+		assert.NotEqual(t, LintSeverityOff, rule.DefaultSeverity)
+		assert.False(t, names[rule.Name], "duplicate rule %s", rule.Name)
+		names[rule.Name] = true
+	}
+	assert.Len(t, names, 7)
+}
@@ -0,0 +1,597 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+type CreateProcedureEntityDiff struct {
+	createProcedure *sqlparser.CreateProcedure
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *CreateProcedureEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *CreateProcedureEntityDiff) EntityName() string {
+	_, to := d.Entities()
+	return to.Name()
+}
+
+// Entities implements EntityDiff
+func (d *CreateProcedureEntityDiff) Entities() (from Entity, to Entity) {
+	return nil, &CreateProcedureEntity{CreateProcedure: d.createProcedure}
+}
+
+func (d *CreateProcedureEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *CreateProcedureEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.createProcedure
+}
+
+// CreateProcedure returns the underlying sqlparser.CreateProcedure that was generated for the diff.
+func (d *CreateProcedureEntityDiff) CreateProcedure() *sqlparser.CreateProcedure {
+	if d == nil {
+		return nil
+	}
+	return d.createProcedure
+}
+
+// StatementString implements EntityDiff
+func (d *CreateProcedureEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *CreateProcedureEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *CreateProcedureEntityDiff) SubsequentDiff() EntityDiff {
+	return nil
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *CreateProcedureEntityDiff) SetSubsequentDiff(EntityDiff) {
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *CreateProcedureEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *CreateProcedureEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	return &CreateProcedureEntityDiff{
+		createProcedure: sqlparser.Clone(d.createProcedure),
+	}
+}
+
+// DropProcedureEntityDiff drops a procedure. A modified procedure is expressed as a DropProcedureEntityDiff
+// with a subsequent CreateProcedureEntityDiff.
+type DropProcedureEntityDiff struct {
+	from           *CreateProcedureEntity
+	dropProcedure  *sqlparser.DropProcedure
+	subsequentDiff *CreateProcedureEntityDiff
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *DropProcedureEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *DropProcedureEntityDiff) EntityName() string {
+	return d.from.Name()
+}
+
+// Entities implements EntityDiff
+func (d *DropProcedureEntityDiff) Entities() (from Entity, to Entity) {
+	return d.from, nil
+}
+
+func (d *DropProcedureEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *DropProcedureEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.dropProcedure
+}
+
+// DropProcedure returns the underlying sqlparser.DropProcedure that was generated for the diff.
+func (d *DropProcedureEntityDiff) DropProcedure() *sqlparser.DropProcedure {
+	if d == nil {
+		return nil
+	}
+	return d.dropProcedure
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *DropProcedureEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// StatementString implements EntityDiff
+func (d *DropProcedureEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *DropProcedureEntityDiff) SubsequentDiff() EntityDiff {
+	if d == nil || d.subsequentDiff == nil {
+		return nil
+	}
+	return d.subsequentDiff
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *DropProcedureEntityDiff) SetSubsequentDiff(subDiff EntityDiff) {
+	if d == nil {
+		return
+	}
+	if createDiff, ok := subDiff.(*CreateProcedureEntityDiff); ok {
+		d.subsequentDiff = createDiff
+	} else {
+		d.subsequentDiff = nil
+	}
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *DropProcedureEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *DropProcedureEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	clone := &DropProcedureEntityDiff{
+		dropProcedure: sqlparser.Clone(d.dropProcedure),
+	}
+	if d.from != nil {
+		clone.from = d.from.Clone().(*CreateProcedureEntity)
+	}
+	if d.subsequentDiff != nil {
+		clone.subsequentDiff = d.subsequentDiff.Clone().(*CreateProcedureEntityDiff)
+	}
+	return clone
+}
+
+// CreateProcedureEntity stands for a PROCEDURE construct. It contains the procedure's CREATE statement.
+type CreateProcedureEntity struct {
+	*sqlparser.CreateProcedure
+	env *Environment
+}
+
+func NewCreateProcedureEntity(env *Environment, c *sqlparser.CreateProcedure) (*CreateProcedureEntity, error) {
+	entity := &CreateProcedureEntity{CreateProcedure: c, env: env}
+	return entity, nil
+}
+
+func NewCreateProcedureEntityFromSQL(env *Environment, sql string) (*CreateProcedureEntity, error) {
+	stmt, err := env.Parser().ParseStrictDDL(sql)
+	if err != nil {
+		return nil, err
+	}
+	createProcedure, ok := stmt.(*sqlparser.CreateProcedure)
+	if !ok {
+		return nil, ErrExpectedCreateProcedure
+	}
+	return NewCreateProcedureEntity(env, createProcedure)
+}
+
+// Name implements Entity interface
+func (c *CreateProcedureEntity) Name() string {
+	return c.CreateProcedure.Name.Name.String()
+}
+
+// Diff implements Entity interface function
+func (c *CreateProcedureEntity) Diff(other Entity, hints *DiffHints) (EntityDiff, error) {
+	otherCreateProcedure, ok := other.(*CreateProcedureEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return c.ProcedureDiff(otherCreateProcedure, hints)
+}
+
+// ProcedureDiff compares this procedure statement with another procedure statement, and sees what it takes to
+// change this procedure to look like the other procedure.
+// ALTER PROCEDURE cannot change a procedure's parameters or body, so any change is expressed
+// as a DROP PROCEDURE followed by a CREATE PROCEDURE.
+// It returns nil if the two are identical. The other procedure may be of different name; its name is ignored.
+func (c *CreateProcedureEntity) ProcedureDiff(other *CreateProcedureEntity, _ *DiffHints) (*DropProcedureEntityDiff, error) {
+	if c.identicalOtherThanName(other) {
+		return nil, nil
+	}
+	diff := c.Drop().(*DropProcedureEntityDiff)
+	diff.subsequentDiff = &CreateProcedureEntityDiff{createProcedure: other.CreateProcedure}
+	return diff, nil
+}
+
+// Create implements Entity interface
+func (c *CreateProcedureEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateProcedureEntityDiff{createProcedure: c.CreateProcedure}
+}
+
+// Drop implements Entity interface
+func (c *CreateProcedureEntity) Drop() EntityDiff {
+	dropProcedure := &sqlparser.DropProcedure{
+		Name: c.CreateProcedure.Name,
+	}
+	return &DropProcedureEntityDiff{from: c, dropProcedure: dropProcedure}
+}
+
+// Apply attempts to apply given diff onto the procedure defined by this entity. The only supported diff
+// is the one generated by ProcedureDiff(): a DROP PROCEDURE followed by a CREATE PROCEDURE.
+// This entity is unmodified. If successful, a new CREATE PROCEDURE entity is returned.
+func (c *CreateProcedureEntity) Apply(diff EntityDiff) (Entity, error) {
+	dropDiff, ok := diff.(*DropProcedureEntityDiff)
+	if !ok || dropDiff.subsequentDiff == nil {
+		return nil, ErrEntityTypeMismatch
+	}
+	return &CreateProcedureEntity{CreateProcedure: sqlparser.Clone(dropDiff.subsequentDiff.createProcedure), env: c.env}, nil
+}
+
+func (c *CreateProcedureEntity) Clone() Entity {
+	return &CreateProcedureEntity{CreateProcedure: sqlparser.Clone(c.CreateProcedure), env: c.env}
+}
+
+func (c *CreateProcedureEntity) identicalOtherThanName(other *CreateProcedureEntity) bool {
+	if other == nil {
+		return false
+	}
+	return sqlparser.Equals.SliceOfRefOfProcParameter(c.Params, other.Params) &&
+		sqlparser.Equals.RefOfDefiner(c.Definer, other.Definer) &&
+		sqlparser.Equals.CompoundStatement(c.Body, other.Body) &&
+		sqlparser.Equals.RefOfParsedComments(c.Comments, other.Comments)
+}
+
+type CreateFunctionEntityDiff struct {
+	createFunction *sqlparser.CreateFunction
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *CreateFunctionEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *CreateFunctionEntityDiff) EntityName() string {
+	_, to := d.Entities()
+	return to.Name()
+}
+
+// Entities implements EntityDiff
+func (d *CreateFunctionEntityDiff) Entities() (from Entity, to Entity) {
+	return nil, &CreateFunctionEntity{CreateFunction: d.createFunction}
+}
+
+func (d *CreateFunctionEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *CreateFunctionEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.createFunction
+}
+
+// CreateFunction returns the underlying sqlparser.CreateFunction that was generated for the diff.
+func (d *CreateFunctionEntityDiff) CreateFunction() *sqlparser.CreateFunction {
+	if d == nil {
+		return nil
+	}
+	return d.createFunction
+}
+
+// StatementString implements EntityDiff
+func (d *CreateFunctionEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *CreateFunctionEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *CreateFunctionEntityDiff) SubsequentDiff() EntityDiff {
+	return nil
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *CreateFunctionEntityDiff) SetSubsequentDiff(EntityDiff) {
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *CreateFunctionEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *CreateFunctionEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	return &CreateFunctionEntityDiff{
+		createFunction: sqlparser.Clone(d.createFunction),
+	}
+}
+
+// DropFunctionEntityDiff drops a function. A modified function is expressed as a DropFunctionEntityDiff
+// with a subsequent CreateFunctionEntityDiff.
+type DropFunctionEntityDiff struct {
+	from           *CreateFunctionEntity
+	dropFunction   *sqlparser.DropFunction
+	subsequentDiff *CreateFunctionEntityDiff
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *DropFunctionEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *DropFunctionEntityDiff) EntityName() string {
+	return d.from.Name()
+}
+
+// Entities implements EntityDiff
+func (d *DropFunctionEntityDiff) Entities() (from Entity, to Entity) {
+	return d.from, nil
+}
+
+func (d *DropFunctionEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *DropFunctionEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.dropFunction
+}
+
+// DropFunction returns the underlying sqlparser.DropFunction that was generated for the diff.
+func (d *DropFunctionEntityDiff) DropFunction() *sqlparser.DropFunction {
+	if d == nil {
+		return nil
+	}
+	return d.dropFunction
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *DropFunctionEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// StatementString implements EntityDiff
+func (d *DropFunctionEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *DropFunctionEntityDiff) SubsequentDiff() EntityDiff {
+	if d == nil || d.subsequentDiff == nil {
+		return nil
+	}
+	return d.subsequentDiff
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *DropFunctionEntityDiff) SetSubsequentDiff(subDiff EntityDiff) {
+	if d == nil {
+		return
+	}
+	if createDiff, ok := subDiff.(*CreateFunctionEntityDiff); ok {
+		d.subsequentDiff = createDiff
+	} else {
+		d.subsequentDiff = nil
+	}
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *DropFunctionEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *DropFunctionEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	clone := &DropFunctionEntityDiff{
+		dropFunction: sqlparser.Clone(d.dropFunction),
+	}
+	if d.from != nil {
+		clone.from = d.from.Clone().(*CreateFunctionEntity)
+	}
+	if d.subsequentDiff != nil {
+		clone.subsequentDiff = d.subsequentDiff.Clone().(*CreateFunctionEntityDiff)
+	}
+	return clone
+}
+
+// CreateFunctionEntity stands for a FUNCTION construct. It contains the function's CREATE statement.
+type CreateFunctionEntity struct {
+	*sqlparser.CreateFunction
+	env *Environment
+}
+
+func NewCreateFunctionEntity(env *Environment, c *sqlparser.CreateFunction) (*CreateFunctionEntity, error) {
+	entity := &CreateFunctionEntity{CreateFunction: c, env: env}
+	return entity, nil
+}
+
+func NewCreateFunctionEntityFromSQL(env *Environment, sql string) (*CreateFunctionEntity, error) {
+	stmt, err := env.Parser().ParseStrictDDL(sql)
+	if err != nil {
+		return nil, err
+	}
+	createFunction, ok := stmt.(*sqlparser.CreateFunction)
+	if !ok {
+		return nil, ErrExpectedCreateFunction
+	}
+	return NewCreateFunctionEntity(env, createFunction)
+}
+
+// Name implements Entity interface
+func (c *CreateFunctionEntity) Name() string {
+	return c.CreateFunction.Name.Name.String()
+}
+
+// Diff implements Entity interface function
+func (c *CreateFunctionEntity) Diff(other Entity, hints *DiffHints) (EntityDiff, error) {
+	otherCreateFunction, ok := other.(*CreateFunctionEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return c.FunctionDiff(otherCreateFunction, hints)
+}
+
+// FunctionDiff compares this function statement with another function statement, and sees what it takes to
+// change this function to look like the other function.
+// ALTER FUNCTION cannot change a function's parameters, return type or body, so any change
+// is expressed as a DROP FUNCTION followed by a CREATE FUNCTION.
+// It returns nil if the two are identical. The other function may be of different name; its name is ignored.
+func (c *CreateFunctionEntity) FunctionDiff(other *CreateFunctionEntity, _ *DiffHints) (*DropFunctionEntityDiff, error) {
+	if c.identicalOtherThanName(other) {
+		return nil, nil
+	}
+	diff := c.Drop().(*DropFunctionEntityDiff)
+	diff.subsequentDiff = &CreateFunctionEntityDiff{createFunction: other.CreateFunction}
+	return diff, nil
+}
+
+// Create implements Entity interface
+func (c *CreateFunctionEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateFunctionEntityDiff{createFunction: c.CreateFunction}
+}
+
+// Drop implements Entity interface
+func (c *CreateFunctionEntity) Drop() EntityDiff {
+	dropFunction := &sqlparser.DropFunction{
+		Name: c.CreateFunction.Name,
+	}
+	return &DropFunctionEntityDiff{from: c, dropFunction: dropFunction}
+}
+
+// Apply attempts to apply given diff onto the function defined by this entity. The only supported diff
+// is the one generated by FunctionDiff(): a DROP FUNCTION followed by a CREATE FUNCTION.
+// This entity is unmodified. If successful, a new CREATE FUNCTION entity is returned.
+func (c *CreateFunctionEntity) Apply(diff EntityDiff) (Entity, error) {
+	dropDiff, ok := diff.(*DropFunctionEntityDiff)
+	if !ok || dropDiff.subsequentDiff == nil {
+		return nil, ErrEntityTypeMismatch
+	}
+	return &CreateFunctionEntity{CreateFunction: sqlparser.Clone(dropDiff.subsequentDiff.createFunction), env: c.env}, nil
+}
+
+func (c *CreateFunctionEntity) Clone() Entity {
+	return &CreateFunctionEntity{CreateFunction: sqlparser.Clone(c.CreateFunction), env: c.env}
+}
+
+func (c *CreateFunctionEntity) identicalOtherThanName(other *CreateFunctionEntity) bool {
+	if other == nil {
+		return false
+	}
+	return sqlparser.Equals.SliceOfRefOfProcParameter(c.Params, other.Params) &&
+		sqlparser.Equals.RefOfColumnType(c.Returns, other.Returns) &&
+		sqlparser.Equals.SliceOfRefOfRoutineCharacteristic(c.Characteristics, other.Characteristics) &&
+		sqlparser.Equals.RefOfDefiner(c.Definer, other.Definer) &&
+		sqlparser.Equals.CompoundStatement(c.Body, other.Body) &&
+		sqlparser.Equals.RefOfParsedComments(c.Comments, other.Comments)
+}
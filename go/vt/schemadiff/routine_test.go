@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProcedureDiff(t *testing.T) {
+	tt := []struct {
+		name   string
+		from   string
+		to     string
+		cdiffs []string
+	}{
+		{
+			name: "identical",
+			from: "create procedure p1 (in a int) begin select a; end",
+			to:   "CREATE PROCEDURE `p1` (IN `a` int) BEGIN SELECT a; END",
+		},
+		{
+			name: "change of parameters",
+			from: "create procedure p1 (in a int) begin select a; end",
+			to:   "create procedure p1 (in a bigint) begin select a; end",
+			cdiffs: []string{
+				"DROP PROCEDURE `p1`",
+				"CREATE PROCEDURE `p1` (IN `a` bigint) BEGIN SELECT `a` FROM `dual`; END;",
+			},
+		},
+		{
+			name: "change of definer",
+			from: "create procedure p1 () begin select 1; end",
+			to:   "create definer = root@localhost procedure p1 () begin select 1; end",
+			cdiffs: []string{
+				"DROP PROCEDURE `p1`",
+				"CREATE DEFINER = root@localhost PROCEDURE `p1` () BEGIN SELECT 1 FROM `dual`; END;",
+			},
+		},
+	}
+	hints := EmptyDiffHints()
+	env := NewTestEnv()
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			c, err := NewCreateProcedureEntityFromSQL(env, ts.from)
+			require.NoError(t, err)
+			other, err := NewCreateProcedureEntityFromSQL(env, ts.to)
+			require.NoError(t, err)
+
+			diff, err := c.Diff(other, hints)
+			require.NoError(t, err)
+			if len(ts.cdiffs) == 0 {
+				assert.True(t, diff.IsEmpty())
+				return
+			}
+			var cdiffs []string
+			for _, d := range AllSubsequent(diff) {
+				cdiffs = append(cdiffs, d.CanonicalStatementString())
+			}
+			assert.Equal(t, ts.cdiffs, cdiffs)
+
+			applied, err := c.Apply(diff)
+			require.NoError(t, err)
+			appliedDiff, err := other.Diff(applied, hints)
+			require.NoError(t, err)
+			assert.True(t, appliedDiff.IsEmpty(), "expected empty diff, found changes: %v", appliedDiff.CanonicalStatementString())
+		})
+	}
+}
+
+func TestCreateFunctionDiff(t *testing.T) {
+	tt := []struct {
+		name   string
+		from   string
+		to     string
+		cdiffs []string
+	}{
+		{
+			name: "identical",
+			from: "create function f1 (a int) returns int deterministic return a + 1",
+			to:   "CREATE FUNCTION `f1` (`a` int) RETURNS int DETERMINISTIC RETURN `a` + 1",
+		},
+		{
+			name: "change of return type",
+			from: "create function f1 (a int) returns int deterministic return a + 1",
+			to:   "create function f1 (a int) returns bigint deterministic return a + 1",
+			cdiffs: []string{
+				"DROP FUNCTION `f1`",
+				"CREATE FUNCTION `f1` (`a` int) RETURNS bigint DETERMINISTIC RETURN `a` + 1;",
+			},
+		},
+		{
+			name: "change of characteristics",
+			from: "create function f1 (a int) returns int deterministic return a + 1",
+			to:   "create function f1 (a int) returns int no sql deterministic return a + 1",
+			cdiffs: []string{
+				"DROP FUNCTION `f1`",
+				"CREATE FUNCTION `f1` (`a` int) RETURNS int NO SQL DETERMINISTIC RETURN `a` + 1;",
+			},
+		},
+	}
+	hints := EmptyDiffHints()
+	env := NewTestEnv()
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			c, err := NewCreateFunctionEntityFromSQL(env, ts.from)
+			require.NoError(t, err)
+			other, err := NewCreateFunctionEntityFromSQL(env, ts.to)
+			require.NoError(t, err)
+
+			diff, err := c.Diff(other, hints)
+			require.NoError(t, err)
+			if len(ts.cdiffs) == 0 {
+				assert.True(t, diff.IsEmpty())
+				return
+			}
+			var cdiffs []string
+			for _, d := range AllSubsequent(diff) {
+				cdiffs = append(cdiffs, d.CanonicalStatementString())
+			}
+			assert.Equal(t, ts.cdiffs, cdiffs)
+
+			applied, err := c.Apply(diff)
+			require.NoError(t, err)
+			appliedDiff, err := other.Diff(applied, hints)
+			require.NoError(t, err)
+			assert.True(t, appliedDiff.IsEmpty(), "expected empty diff, found changes: %v", appliedDiff.CanonicalStatementString())
+		})
+	}
+}
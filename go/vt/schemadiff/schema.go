@@ -26,15 +26,26 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 )
 
-// Schema represents a database schema, which may contain entities such as tables and views.
+// Schema represents a database schema, which may contain entities such as tables, views, stored routines,
+// triggers and events.
 // Schema is not in itself an Entity, since it is more of a collection of entities.
 type Schema struct {
-	tables []*CreateTableEntity
-	views  []*CreateViewEntity
-
-	named  map[string]Entity
+	tables     []*CreateTableEntity
+	views      []*CreateViewEntity
+	procedures []*CreateProcedureEntity
+	functions  []*CreateFunctionEntity
+	triggers   []*CreateTriggerEntity
+	events     []*CreateEventEntity
+
+	named  map[string]Entity // tables and views, which share a namespace
 	sorted []Entity
 
+	// procedures, functions, triggers and events each have their own namespace
+	namedProcedures map[string]Entity
+	namedFunctions  map[string]Entity
+	namedTriggers   map[string]Entity
+	namedEvents     map[string]Entity
+
 	fkChildToParents   map[string][]*CreateTableEntity
 	fkParentToChildren map[string][]*CreateTableEntity
 
@@ -44,10 +55,19 @@ type Schema struct {
 // newEmptySchema is used internally to initialize a Schema object
 func newEmptySchema(env *Environment) *Schema {
 	schema := &Schema{
-		tables: []*CreateTableEntity{},
-		views:  []*CreateViewEntity{},
-		named:  map[string]Entity{},
-		sorted: []Entity{},
+		tables:     []*CreateTableEntity{},
+		views:      []*CreateViewEntity{},
+		procedures: []*CreateProcedureEntity{},
+		functions:  []*CreateFunctionEntity{},
+		triggers:   []*CreateTriggerEntity{},
+		events:     []*CreateEventEntity{},
+		named:      map[string]Entity{},
+		sorted:     []Entity{},
+
+		namedProcedures: map[string]Entity{},
+		namedFunctions:  map[string]Entity{},
+		namedTriggers:   map[string]Entity{},
+		namedEvents:     map[string]Entity{},
 
 		fkChildToParents:   map[string][]*CreateTableEntity{},
 		fkParentToChildren: map[string][]*CreateTableEntity{},
@@ -59,6 +79,11 @@ func newEmptySchema(env *Environment) *Schema {
 
 // NewSchemaFromEntities creates a valid and normalized schema based on list of entities
 func NewSchemaFromEntities(env *Environment, entities []Entity) (*Schema, error) {
+	return newSchemaFromEntities(env, entities, EmptyDiffHints())
+}
+
+// newSchemaFromEntities creates a schema based on the given entities, normalized and validated according to the given hints
+func newSchemaFromEntities(env *Environment, entities []Entity, hints *DiffHints) (*Schema, error) {
 	schema := newEmptySchema(env)
 	for _, e := range entities {
 		switch c := e.(type) {
@@ -66,16 +91,33 @@ func NewSchemaFromEntities(env *Environment, entities []Entity) (*Schema, error)
 			schema.tables = append(schema.tables, c)
 		case *CreateViewEntity:
 			schema.views = append(schema.views, c)
+		case *CreateProcedureEntity:
+			schema.procedures = append(schema.procedures, c)
+		case *CreateFunctionEntity:
+			schema.functions = append(schema.functions, c)
+		case *CreateTriggerEntity:
+			schema.triggers = append(schema.triggers, c)
+		case *CreateEventEntity:
+			schema.events = append(schema.events, c)
 		default:
 			return nil, &UnsupportedEntityError{Entity: c.Name(), Statement: c.Create().CanonicalStatementString()}
 		}
 	}
-	err := schema.normalize(EmptyDiffHints())
+	err := schema.normalize(hints)
 	return schema, err
 }
 
 // NewSchemaFromStatements creates a valid and normalized schema based on list of valid statements
 func NewSchemaFromStatements(env *Environment, statements []sqlparser.Statement) (*Schema, error) {
+	entities, err := entitiesFromStatements(env, statements)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchemaFromEntities(env, entities)
+}
+
+// entitiesFromStatements creates the entities of the given CREATE statements
+func entitiesFromStatements(env *Environment, statements []sqlparser.Statement) ([]Entity, error) {
 	entities := make([]Entity, 0, len(statements))
 	for _, s := range statements {
 		switch stmt := s.(type) {
@@ -91,11 +133,35 @@ func NewSchemaFromStatements(env *Environment, statements []sqlparser.Statement)
 				return nil, err
 			}
 			entities = append(entities, v)
+		case *sqlparser.CreateProcedure:
+			p, err := NewCreateProcedureEntity(env, stmt)
+			if err != nil {
+				return nil, err
+			}
+			entities = append(entities, p)
+		case *sqlparser.CreateFunction:
+			f, err := NewCreateFunctionEntity(env, stmt)
+			if err != nil {
+				return nil, err
+			}
+			entities = append(entities, f)
+		case *sqlparser.CreateTrigger:
+			t, err := NewCreateTriggerEntity(env, stmt)
+			if err != nil {
+				return nil, err
+			}
+			entities = append(entities, t)
+		case *sqlparser.CreateEvent:
+			e, err := NewCreateEventEntity(env, stmt)
+			if err != nil {
+				return nil, err
+			}
+			entities = append(entities, e)
 		default:
 			return nil, &UnsupportedStatementError{Statement: sqlparser.CanonicalString(s)}
 		}
 	}
-	return NewSchemaFromEntities(env, entities)
+	return entities, nil
 }
 
 // NewSchemaFromQueries creates a valid and normalized schema based on list of queries
@@ -112,13 +178,34 @@ func NewSchemaFromQueries(env *Environment, queries []string) (*Schema, error) {
 }
 
 // NewSchemaFromSQL creates a valid and normalized schema based on a SQL blob that contains
-// CREATE statements for various objects (tables, views)
+// CREATE statements for various objects (tables, views, routines, triggers, events)
 func NewSchemaFromSQL(env *Environment, sql string) (*Schema, error) {
-	statements, err := env.Parser().ParseMultipleIgnoreEmpty(sql)
+	return NewSchemaFromSQLWithHints(env, sql, EmptyDiffHints())
+}
+
+// NewSchemaFromSQLWithHints is like NewSchemaFromSQL, but validates the schema according to the given hints.
+// For example, with ForeignKeyCheckStrategyIgnore the schema may have foreign keys that reference tables
+// outside of it.
+func NewSchemaFromSQLWithHints(env *Environment, sql string, hints *DiffHints) (*Schema, error) {
+	// The blob is split into pieces first, because routine, trigger and event bodies
+	// may themselves contain semicolons.
+	pieces, err := env.Parser().SplitStatementToPieces(sql)
 	if err != nil {
 		return nil, err
 	}
-	return NewSchemaFromStatements(env, statements)
+	statements := make([]sqlparser.Statement, 0, len(pieces))
+	for _, piece := range pieces {
+		stmts, err := env.Parser().ParseMultipleIgnoreEmpty(piece)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmts...)
+	}
+	entities, err := entitiesFromStatements(env, statements)
+	if err != nil {
+		return nil, err
+	}
+	return newSchemaFromEntities(env, entities, hints)
 }
 
 // getForeignKeyParentTableNames analyzes a CREATE TABLE definition and extracts all referenced foreign key tables names.
@@ -151,36 +238,93 @@ func getViewDependentTableNames(createView *sqlparser.CreateView) (names []strin
 	return names
 }
 
+// namespace returns the map of named entities the given entity belongs to. Tables and views share a
+// namespace, while procedures, functions, triggers and events each have their own.
+func (s *Schema) namespace(e Entity) map[string]Entity {
+	switch e.(type) {
+	case *CreateProcedureEntity:
+		return s.namedProcedures
+	case *CreateFunctionEntity:
+		return s.namedFunctions
+	case *CreateTriggerEntity:
+		return s.namedTriggers
+	case *CreateEventEntity:
+		return s.namedEvents
+	}
+	return s.named
+}
+
 // normalize is called as part of Schema creation process. The user may only get a hold of normalized schema.
 // It validates some cross-entity constraints, and orders entity based on dependencies (e.g. tables, views that read from tables, 2nd level views, etc.)
 func (s *Schema) normalize(hints *DiffHints) error {
 	var errs error
 
 	s.named = make(map[string]Entity, len(s.tables)+len(s.views))
-	s.sorted = make([]Entity, 0, len(s.tables)+len(s.views))
+	s.namedProcedures = make(map[string]Entity, len(s.procedures))
+	s.namedFunctions = make(map[string]Entity, len(s.functions))
+	s.namedTriggers = make(map[string]Entity, len(s.triggers))
+	s.namedEvents = make(map[string]Entity, len(s.events))
+	s.sorted = make([]Entity, 0, len(s.tables)+len(s.views)+len(s.procedures)+len(s.functions)+len(s.triggers)+len(s.events))
 	// Verify no two entities share same name
-	for _, t := range s.tables {
-		name := t.Name()
-		if _, ok := s.named[name]; ok {
+	addNamed := func(e Entity) error {
+		namespace := s.namespace(e)
+		name := e.Name()
+		if _, ok := namespace[name]; ok {
 			return &ApplyDuplicateEntityError{Entity: name}
 		}
-		s.named[name] = t
+		namespace[name] = e
+		return nil
+	}
+	for _, t := range s.tables {
+		if err := addNamed(t); err != nil {
+			return err
+		}
 	}
 	for _, v := range s.views {
-		name := v.Name()
-		if _, ok := s.named[name]; ok {
-			return &ApplyDuplicateEntityError{Entity: name}
+		if err := addNamed(v); err != nil {
+			return err
+		}
+	}
+	for _, p := range s.procedures {
+		if err := addNamed(p); err != nil {
+			return err
+		}
+	}
+	for _, f := range s.functions {
+		if err := addNamed(f); err != nil {
+			return err
+		}
+	}
+	for _, t := range s.triggers {
+		if err := addNamed(t); err != nil {
+			return err
+		}
+	}
+	for _, e := range s.events {
+		if err := addNamed(e); err != nil {
+			return err
 		}
-		s.named[name] = v
 	}
 
-	// Generally speaking, we want tables and views to be sorted alphabetically
+	// Generally speaking, we want entities to be sorted alphabetically
 	sort.SliceStable(s.tables, func(i, j int) bool {
 		return s.tables[i].Name() < s.tables[j].Name()
 	})
 	sort.SliceStable(s.views, func(i, j int) bool {
 		return s.views[i].Name() < s.views[j].Name()
 	})
+	sort.SliceStable(s.procedures, func(i, j int) bool {
+		return s.procedures[i].Name() < s.procedures[j].Name()
+	})
+	sort.SliceStable(s.functions, func(i, j int) bool {
+		return s.functions[i].Name() < s.functions[j].Name()
+	})
+	sort.SliceStable(s.triggers, func(i, j int) bool {
+		return s.triggers[i].Name() < s.triggers[j].Name()
+	})
+	sort.SliceStable(s.events, func(i, j int) bool {
+		return s.events[i].Name() < s.events[j].Name()
+	})
 
 	// More importantly, we want tables and views to be sorted in applicable order.
 	// For example, if a view v reads from table t, then t must be defined before v.
@@ -287,6 +431,15 @@ func (s *Schema) normalize(hints *DiffHints) error {
 		}
 	}
 
+	// MySQL does not validate the bodies of stored routines upon creation, so they only need to come
+	// before views (which may call stored functions) and triggers (which may call any routine).
+	for _, f := range s.functions {
+		s.sorted = append(s.sorted, f)
+	}
+	for _, p := range s.procedures {
+		s.sorted = append(s.sorted, p)
+	}
+
 	// We now iterate all views. We iterate "dependency levels":
 	// - first we want all views that only depend on tables. These are 1st level views.
 	// - then we only want views that depend on 1st level views or on tables. These are 2nd level views.
@@ -323,7 +476,7 @@ func (s *Schema) normalize(hints *DiffHints) error {
 		iterationLevel++
 	}
 
-	if len(s.sorted) != len(s.tables)+len(s.views) {
+	if len(s.sorted) != len(s.tables)+len(s.functions)+len(s.procedures)+len(s.views) {
 		// We have leftover tables or views. This can happen if the schema definition is invalid:
 		// - a table's foreign key references a nonexistent table
 		// - two or more tables have circular FK dependency
@@ -355,11 +508,68 @@ func (s *Schema) normalize(hints *DiffHints) error {
 		}
 	}
 
+	// Triggers come after the tables they are defined on. A trigger that FOLLOWS or PRECEDES another
+	// trigger must come after that other trigger. We iterate until we are unable to handle any more triggers.
+	handledTriggers := map[string]bool{}
+	for {
+		handledAnyTriggersInIteration := false
+		for _, t := range s.triggers {
+			name := t.Name()
+			if handledTriggers[name] {
+				// already handled; skip
+				continue
+			}
+			if t.Order != nil && !handledTriggers[t.Order.Name.String()] {
+				// The referenced trigger is not yet handled, or does not exist.
+				continue
+			}
+			s.sorted = append(s.sorted, t)
+			handledTriggers[name] = true
+			handledAnyTriggersInIteration = true
+		}
+		if !handledAnyTriggersInIteration {
+			break
+		}
+	}
+	for _, t := range s.triggers {
+		if !handledTriggers[t.Name()] {
+			// The trigger references a nonexistent trigger, or is part of an ordering loop.
+			errs = errors.Join(errs, &TriggerOrderUnresolvedError{Trigger: t.Name(), ReferencedTrigger: t.Order.Name.String()})
+			// We still add it so it shows up in the output if that is used for anything.
+			s.sorted = append(s.sorted, t)
+		}
+	}
+	// Events are last, as they may invoke any of the above.
+	for _, e := range s.events {
+		s.sorted = append(s.sorted, e)
+	}
+
 	// Validate views' referenced columns: do these columns actually exist in referenced tables/views?
 	if err := s.ValidateViewReferences(); err != nil {
 		errs = errors.Join(errs, err)
 	}
 
+	// Validate triggers' tables, and their ordering relative to other triggers.
+	for _, t := range s.triggers {
+		switch s.named[t.TableName()].(type) {
+		case *CreateTableEntity:
+			// good
+		case *CreateViewEntity:
+			errs = errors.Join(errs, &TriggerReferencesViewError{Trigger: t.Name(), View: t.TableName()})
+		default:
+			errs = errors.Join(errs, &TriggerNonexistentTableError{Trigger: t.Name(), Table: t.TableName()})
+		}
+		if t.Order == nil {
+			continue
+		}
+		// MySQL requires the referenced trigger to have same table, action time and event.
+		if referencedTrigger := s.Trigger(t.Order.Name.String()); referencedTrigger != nil {
+			if referencedTrigger.TableName() != t.TableName() || referencedTrigger.Time != t.Time || referencedTrigger.Event != t.Event {
+				errs = errors.Join(errs, &TriggerOrderUnresolvedError{Trigger: t.Name(), ReferencedTrigger: referencedTrigger.Name()})
+			}
+		}
+	}
+
 	// Validate table definitions
 	for _, t := range s.tables {
 		if err := t.validate(); err != nil {
@@ -556,13 +766,66 @@ func (s *Schema) ViewNames() []string {
 	return names
 }
 
+// Procedures returns this schema's stored procedures in good order
+func (s *Schema) Procedures() []*CreateProcedureEntity {
+	var procedures []*CreateProcedureEntity
+	for _, entity := range s.sorted {
+		if procedure, ok := entity.(*CreateProcedureEntity); ok {
+			procedures = append(procedures, procedure)
+		}
+	}
+	return procedures
+}
+
+// Functions returns this schema's stored functions in good order
+func (s *Schema) Functions() []*CreateFunctionEntity {
+	var functions []*CreateFunctionEntity
+	for _, entity := range s.sorted {
+		if function, ok := entity.(*CreateFunctionEntity); ok {
+			functions = append(functions, function)
+		}
+	}
+	return functions
+}
+
+// Triggers returns this schema's triggers in good order (may be applied without error)
+func (s *Schema) Triggers() []*CreateTriggerEntity {
+	var triggers []*CreateTriggerEntity
+	for _, entity := range s.sorted {
+		if trigger, ok := entity.(*CreateTriggerEntity); ok {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// TriggerNames is a convenience function that returns just the names of triggers, in good order
+func (s *Schema) TriggerNames() []string {
+	var names []string
+	for _, e := range s.Triggers() {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+// Events returns this schema's events in good order
+func (s *Schema) Events() []*CreateEventEntity {
+	var events []*CreateEventEntity
+	for _, entity := range s.sorted {
+		if event, ok := entity.(*CreateEventEntity); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
 // Diff compares this schema with another schema, and sees what it takes to make this schema look
 // like the other. It returns a list of diffs.
 func (s *Schema) diff(other *Schema, hints *DiffHints) (diffs []EntityDiff, err error) {
 	// dropped entities
 	var dropDiffs []EntityDiff
 	for _, e := range s.Entities() {
-		if _, ok := other.named[e.Name()]; !ok {
+		if _, ok := other.namespace(e)[e.Name()]; !ok {
 			// other schema does not have the entity
 			// Entities are sorted in foreign key CREATE TABLE valid order (create parents first, then children).
 			// When issuing DROPs, we want to reverse that order. We want to first do it for children, then parents.
@@ -576,7 +839,7 @@ func (s *Schema) diff(other *Schema, hints *DiffHints) (diffs []EntityDiff, err
 	var alterDiffs []EntityDiff
 	var createDiffs []EntityDiff
 	for _, e := range other.Entities() {
-		if fromEntity, ok := s.named[e.Name()]; ok {
+		if fromEntity, ok := s.namespace(e)[e.Name()]; ok {
 			// entities exist by same name in both schemas. Let's diff them.
 			diff, err := fromEntity.Diff(e, hints)
 
@@ -706,6 +969,38 @@ func (s *Schema) View(name string) *CreateViewEntity {
 	return nil
 }
 
+// Procedure returns a stored procedure by name, or nil if nonexistent
+func (s *Schema) Procedure(name string) *CreateProcedureEntity {
+	if procedure, ok := s.namedProcedures[name].(*CreateProcedureEntity); ok {
+		return procedure
+	}
+	return nil
+}
+
+// Function returns a stored function by name, or nil if nonexistent
+func (s *Schema) Function(name string) *CreateFunctionEntity {
+	if function, ok := s.namedFunctions[name].(*CreateFunctionEntity); ok {
+		return function
+	}
+	return nil
+}
+
+// Trigger returns a trigger by name, or nil if nonexistent
+func (s *Schema) Trigger(name string) *CreateTriggerEntity {
+	if trigger, ok := s.namedTriggers[name].(*CreateTriggerEntity); ok {
+		return trigger
+	}
+	return nil
+}
+
+// Event returns an event by name, or nil if nonexistent
+func (s *Schema) Event(name string) *CreateEventEntity {
+	if event, ok := s.namedEvents[name].(*CreateEventEntity); ok {
+		return event
+	}
+	return nil
+}
+
 // ToStatements returns an ordered list of statements which can be applied to create the schema
 func (s *Schema) ToStatements() []sqlparser.Statement {
 	stmts := make([]sqlparser.Statement, 0, len(s.Entities()))
@@ -742,17 +1037,37 @@ func (s *Schema) copy() *Schema {
 	copy(dup.tables, s.tables)
 	dup.views = make([]*CreateViewEntity, len(s.views))
 	copy(dup.views, s.views)
+	dup.procedures = make([]*CreateProcedureEntity, len(s.procedures))
+	copy(dup.procedures, s.procedures)
+	dup.functions = make([]*CreateFunctionEntity, len(s.functions))
+	copy(dup.functions, s.functions)
+	dup.triggers = make([]*CreateTriggerEntity, len(s.triggers))
+	copy(dup.triggers, s.triggers)
+	dup.events = make([]*CreateEventEntity, len(s.events))
+	copy(dup.events, s.events)
 	dup.named = make(map[string]Entity, len(s.named))
 	for k, v := range s.named {
 		dup.named[k] = v
 	}
+	for k, v := range s.namedProcedures {
+		dup.namedProcedures[k] = v
+	}
+	for k, v := range s.namedFunctions {
+		dup.namedFunctions[k] = v
+	}
+	for k, v := range s.namedTriggers {
+		dup.namedTriggers[k] = v
+	}
+	for k, v := range s.namedEvents {
+		dup.namedEvents[k] = v
+	}
 	dup.sorted = make([]Entity, len(s.sorted))
 	copy(dup.sorted, s.sorted)
 	return dup
 }
 
 // apply attempts to apply given list of diffs to this object.
-// These diffs are CREATE/DROP/ALTER TABLE/VIEW.
+// These diffs are CREATE/DROP/ALTER TABLE/VIEW, and CREATE/DROP PROCEDURE/FUNCTION/TRIGGER/EVENT.
 func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
 	for _, diff := range diffs {
 		switch diff := diff.(type) {
@@ -859,6 +1174,118 @@ func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
 			if !found {
 				return &ApplyTableNotFoundError{Table: diff.from.Table.Name.String()}
 			}
+		case *CreateProcedureEntityDiff:
+			// We expect the procedure to not exist
+			name := diff.createProcedure.Name.Name.String()
+			if _, ok := s.namedProcedures[name]; ok {
+				return &ApplyDuplicateEntityError{Entity: name}
+			}
+			s.procedures = append(s.procedures, &CreateProcedureEntity{CreateProcedure: diff.createProcedure, env: s.env})
+			_, s.namedProcedures[name] = diff.Entities()
+		case *DropProcedureEntityDiff:
+			// We expect the procedure to exist
+			found := false
+			for i, e := range s.procedures {
+				if name := e.Name(); name == diff.from.Name() {
+					s.procedures = append(s.procedures[0:i], s.procedures[i+1:]...)
+					delete(s.namedProcedures, name)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return &ApplyProcedureNotFoundError{Procedure: diff.from.Name()}
+			}
+			if diff.subsequentDiff != nil {
+				// The procedure is modified: it is recreated with its new definition
+				if err := s.apply([]EntityDiff{diff.subsequentDiff}, hints); err != nil {
+					return err
+				}
+			}
+		case *CreateFunctionEntityDiff:
+			// We expect the function to not exist
+			name := diff.createFunction.Name.Name.String()
+			if _, ok := s.namedFunctions[name]; ok {
+				return &ApplyDuplicateEntityError{Entity: name}
+			}
+			s.functions = append(s.functions, &CreateFunctionEntity{CreateFunction: diff.createFunction, env: s.env})
+			_, s.namedFunctions[name] = diff.Entities()
+		case *DropFunctionEntityDiff:
+			// We expect the function to exist
+			found := false
+			for i, e := range s.functions {
+				if name := e.Name(); name == diff.from.Name() {
+					s.functions = append(s.functions[0:i], s.functions[i+1:]...)
+					delete(s.namedFunctions, name)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return &ApplyFunctionNotFoundError{Function: diff.from.Name()}
+			}
+			if diff.subsequentDiff != nil {
+				// The function is modified: it is recreated with its new definition
+				if err := s.apply([]EntityDiff{diff.subsequentDiff}, hints); err != nil {
+					return err
+				}
+			}
+		case *CreateTriggerEntityDiff:
+			// We expect the trigger to not exist
+			name := diff.createTrigger.Name.Name.String()
+			if _, ok := s.namedTriggers[name]; ok {
+				return &ApplyDuplicateEntityError{Entity: name}
+			}
+			s.triggers = append(s.triggers, &CreateTriggerEntity{CreateTrigger: diff.createTrigger, env: s.env})
+			_, s.namedTriggers[name] = diff.Entities()
+		case *DropTriggerEntityDiff:
+			// We expect the trigger to exist
+			found := false
+			for i, e := range s.triggers {
+				if name := e.Name(); name == diff.from.Name() {
+					s.triggers = append(s.triggers[0:i], s.triggers[i+1:]...)
+					delete(s.namedTriggers, name)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return &ApplyTriggerNotFoundError{Trigger: diff.from.Name()}
+			}
+			if diff.subsequentDiff != nil {
+				// The trigger is modified: it is recreated with its new definition
+				if err := s.apply([]EntityDiff{diff.subsequentDiff}, hints); err != nil {
+					return err
+				}
+			}
+		case *CreateEventEntityDiff:
+			// We expect the event to not exist
+			name := diff.createEvent.Name.Name.String()
+			if _, ok := s.namedEvents[name]; ok {
+				return &ApplyDuplicateEntityError{Entity: name}
+			}
+			s.events = append(s.events, &CreateEventEntity{CreateEvent: diff.createEvent, env: s.env})
+			_, s.namedEvents[name] = diff.Entities()
+		case *DropEventEntityDiff:
+			// We expect the event to exist
+			found := false
+			for i, e := range s.events {
+				if name := e.Name(); name == diff.from.Name() {
+					s.events = append(s.events[0:i], s.events[i+1:]...)
+					delete(s.namedEvents, name)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return &ApplyEventNotFoundError{Event: diff.from.Name()}
+			}
+			if diff.subsequentDiff != nil {
+				// The event is modified: it is recreated with its new definition
+				if err := s.apply([]EntityDiff{diff.subsequentDiff}, hints); err != nil {
+					return err
+				}
+			}
 		default:
 			return &UnsupportedApplyOperationError{Statement: diff.CanonicalStatementString()}
 		}
@@ -870,7 +1297,7 @@ func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
 }
 
 // Apply attempts to apply given list of diffs to the schema described by this object.
-// These diffs are CREATE/DROP/ALTER TABLE/VIEW.
+// These diffs are CREATE/DROP/ALTER TABLE/VIEW, and CREATE/DROP PROCEDURE/FUNCTION/TRIGGER/EVENT.
 // The operation does not modify this object. Instead, if successful, a new (modified) Schema is returned.
 func (s *Schema) Apply(diffs []EntityDiff) (*Schema, error) {
 	dup := s.copy()
@@ -908,6 +1335,13 @@ func (s *Schema) SchemaDiff(other *Schema, hints *DiffHints) (*SchemaDiff, error
 		return dependentDiffs, relationsMade
 	}
 
+	// Utility function to relate a trigger diff with diffs on another trigger it is ordered by (FOLLOWS/PRECEDES)
+	checkTriggerDependencies := func(diff EntityDiff, triggerName string) {
+		for _, dependentDiff := range schemaDiff.triggerDiffsByName(triggerName) {
+			schemaDiff.addDep(diff, dependentDiff, DiffDependencyOrderUnknown)
+		}
+	}
+
 	checkChildForeignKeyDefinition := func(fk *sqlparser.ForeignKeyDefinition, diff EntityDiff) (bool, error) {
 		// We add a foreign key. Normally that's fine, expect for a couple specific scenarios
 		parentTableName := fk.ReferenceDefinition.ReferencedTable.Name.String()
@@ -1027,6 +1461,17 @@ func (s *Schema) SchemaDiff(other *Schema, hints *DiffHints) (*SchemaDiff, error
 			}, diff.Statement())
 		case *DropTableEntityDiff:
 			// No need to handle. Any dependencies will be resolved by any of the other cases
+		case *CreateTriggerEntityDiff:
+			checkDependencies(diff, []string{diff.createTrigger.Table.Name.String()})
+			if diff.createTrigger.Order != nil {
+				checkTriggerDependencies(diff, diff.createTrigger.Order.Name.String())
+			}
+		case *DropTriggerEntityDiff:
+			// A trigger must be dropped before its table is, and before any trigger it is ordered by
+			checkDependencies(diff, []string{diff.from.TableName()})
+			if diff.from.Order != nil {
+				checkTriggerDependencies(diff, diff.from.Order.Name.String())
+			}
 		}
 	}
 
@@ -1059,6 +1504,12 @@ func (s *Schema) ValidateViewReferences() error {
 	// that only depend on those tables (or on dual), then 2nd tier views, etc.
 	// Thus, the order of iteration below is valid and sufficient, to build
 	for _, e := range s.Entities() {
+		switch e.(type) {
+		case *CreateTableEntity, *CreateViewEntity:
+		default:
+			// Routines, triggers and events do not have columns
+			continue
+		}
 		entityColumns, err := s.getEntityColumnNames(e.Name(), schemaInformation)
 		if err != nil {
 			errs = errors.Join(errs, err)
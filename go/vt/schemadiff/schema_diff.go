@@ -262,6 +262,14 @@ func (d *SchemaDiff) diffByStatementString(s string) (EntityDiff, bool) {
 // diffsByEntityName returns all diffs that apply to a given entity (table/view)
 func (d *SchemaDiff) diffsByEntityName(name string) (diffs []EntityDiff) {
 	for _, diff := range d.diffs {
+		switch diff.(type) {
+		case *CreateProcedureEntityDiff, *DropProcedureEntityDiff,
+			*CreateFunctionEntityDiff, *DropFunctionEntityDiff,
+			*CreateTriggerEntityDiff, *DropTriggerEntityDiff,
+			*CreateEventEntityDiff, *DropEventEntityDiff:
+			// These entities do not share the tables/views namespace
+			continue
+		}
 		if diff.EntityName() == name {
 			diffs = append(diffs, diff)
 		}
@@ -269,6 +277,19 @@ func (d *SchemaDiff) diffsByEntityName(name string) (diffs []EntityDiff) {
 	return diffs
 }
 
+// triggerDiffsByName returns all diffs that apply to a given trigger
+func (d *SchemaDiff) triggerDiffsByName(name string) (diffs []EntityDiff) {
+	for _, diff := range d.diffs {
+		switch diff.(type) {
+		case *CreateTriggerEntityDiff, *DropTriggerEntityDiff:
+			if diff.EntityName() == name {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+	return diffs
+}
+
 // Empty returns 'true' when there are no diff entries
 func (d *SchemaDiff) Empty() bool {
 	return len(d.diffs) == 0
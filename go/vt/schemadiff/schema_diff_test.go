@@ -1292,6 +1292,61 @@ func TestSchemaDiff(t *testing.T) {
 			instantCapability:     InstantDDLCapabilityImpossible,
 			expectRelatedFKTables: []string{"t1", "t3"},
 		},
+		{
+			name: "create table and trigger",
+			toQueries: append(
+				createQueries,
+				"create table t3 (id int primary key, info int);",
+				"create trigger t3_bi before insert on t3 for each row set new.info = 0",
+			),
+			expectDiffs:       2,
+			expectDeps:        1,
+			entityOrder:       []string{"t3", "t3_bi"},
+			instantCapability: InstantDDLCapabilityIrrelevant,
+		},
+		{
+			name: "create trigger following a modified trigger",
+			fromQueries: append(
+				createQueries,
+				"create trigger t1_bi1 before insert on t1 for each row set new.info = 0",
+			),
+			toQueries: append(
+				createQueries,
+				"create trigger t1_bi1 before insert on t1 for each row set new.info = 1",
+				"create trigger t1_bi2 before insert on t1 for each row follows t1_bi1 set new.info = new.info + 1",
+			),
+			expectDiffs:       3,
+			expectDeps:        3,
+			sequential:        true,
+			entityOrder:       []string{"t1_bi1", "t1_bi1", "t1_bi2"},
+			instantCapability: InstantDDLCapabilityIrrelevant,
+		},
+		{
+			name: "drop table and its trigger",
+			fromQueries: append(
+				createQueries,
+				"create trigger t2_bi before insert on t2 for each row set new.ts = now()",
+			),
+			toQueries: []string{
+				"create table t1 (id int primary key, info int not null);",
+				"create view v1 as select id from t1",
+			},
+			expectDiffs:       2,
+			expectDeps:        1,
+			entityOrder:       []string{"t2_bi", "t2"},
+			instantCapability: InstantDDLCapabilityIrrelevant,
+		},
+		{
+			name: "create procedure and event",
+			toQueries: append(
+				createQueries,
+				"create procedure p1 () begin delete from t2; end",
+				"create event e1 on schedule every 1 day do call p1()",
+			),
+			expectDiffs:       2,
+			entityOrder:       []string{"p1", "e1"},
+			instantCapability: InstantDDLCapabilityIrrelevant,
+		},
 	}
 	baseHints := &DiffHints{
 		RangeRotationStrategy: RangeRotationDistinctStatements,
@@ -208,6 +208,44 @@ func TestCopy(t *testing.T) {
 	assert.False(t, schema == schemaClone)
 }
 
+func TestNewSchemaFromSQLWithRoutines(t *testing.T) {
+	sql := `
+		create event e1 on schedule every 1 day do delete from t1;
+		create trigger t1_bi2 before insert on t1 for each row follows t1_bi1 set new.info = new.info + 1;
+		create trigger t1_bi1 before insert on t1 for each row begin set new.info = f1(new.info); end;
+		create view v1 as select id from t1;
+		create procedure p1 (in a int) begin select a; update t1 set info = a; end;
+		create function f1 (a int) returns int deterministic return a + 1;
+		create table t1 (id int primary key, info int);
+		create procedure t1 () begin select 1; end;
+	`
+	env := NewTestEnv()
+	schema, err := NewSchemaFromSQL(env, sql)
+	require.NoError(t, err)
+
+	// procedures, functions, triggers and events each have their own namespace
+	assert.Equal(t, []string{"t1", "f1", "p1", "t1", "v1", "t1_bi1", "t1_bi2", "e1"}, schema.EntityNames())
+	assert.Equal(t, []string{"t1"}, schema.TableNames())
+	assert.Equal(t, []string{"v1"}, schema.ViewNames())
+	assert.Equal(t, []string{"t1_bi1", "t1_bi2"}, schema.TriggerNames())
+	assert.Len(t, schema.Procedures(), 2)
+	assert.Len(t, schema.Functions(), 1)
+	assert.Len(t, schema.Events(), 1)
+	assert.NotNil(t, schema.Table("t1"))
+	assert.NotNil(t, schema.Procedure("t1"))
+	assert.Nil(t, schema.Function("t1"))
+	assert.NotNil(t, schema.Event("e1"))
+	assert.Equal(t, "t1", schema.Trigger("t1_bi2").TableName())
+
+	// validate the schema can be read back from its own SQL
+	schemaFromSQL, err := NewSchemaFromSQL(env, schema.ToSQL())
+	require.NoError(t, err)
+	assert.Equal(t, schema.ToQueries(), schemaFromSQL.ToQueries())
+
+	schemaClone := schema.copy()
+	assert.Equal(t, schema.ToSQL(), schemaClone.ToSQL())
+}
+
 func TestGetViewDependentTableNames(t *testing.T) {
 	tt := []struct {
 		name   string
@@ -584,6 +622,32 @@ func TestInvalidSchema(t *testing.T) {
 			`,
 			expectErr: &DuplicateForeignKeyConstraintNameError{Table: "t2", Constraint: "const_id"},
 		},
+		{
+			schema: "create table t1 (id int primary key); create trigger t1_bi before insert on t1 for each row set new.id = 1",
+		},
+		{
+			schema:    "create table t1 (id int primary key); create trigger t2_bi before insert on t2 for each row set new.id = 1",
+			expectErr: &TriggerNonexistentTableError{Trigger: "t2_bi", Table: "t2"},
+		},
+		{
+			schema:    "create table t1 (id int primary key); create view v1 as select id from t1; create trigger v1_bi before insert on v1 for each row set new.id = 1",
+			expectErr: &TriggerReferencesViewError{Trigger: "v1_bi", View: "v1"},
+		},
+		{
+			schema:    "create table t1 (id int primary key); create trigger t1_bi before insert on t1 for each row follows t1_bi0 set new.id = 1",
+			expectErr: &TriggerOrderUnresolvedError{Trigger: "t1_bi", ReferencedTrigger: "t1_bi0"},
+		},
+		{
+			schema:    "create table t1 (id int primary key); create trigger t1_bi0 after insert on t1 for each row set @x = 1; create trigger t1_bi before insert on t1 for each row follows t1_bi0 set new.id = 1",
+			expectErr: &TriggerOrderUnresolvedError{Trigger: "t1_bi", ReferencedTrigger: "t1_bi0"},
+		},
+		{
+			schema:    "create table t1 (id int primary key); create trigger t1_bi before insert on t1 for each row set new.id = 1; create trigger t1_bi before update on t1 for each row set new.id = 1",
+			expectErr: &ApplyDuplicateEntityError{Entity: "t1_bi"},
+		},
+		{
+			schema: "create table t1 (id int primary key); create trigger t1 before insert on t1 for each row set new.id = 1; create function t1 () returns int return 1; create event t1 on schedule every 1 day do delete from t1",
+		},
 		{
 			schema: `
 CREATE TABLE users (id INT PRIMARY KEY, name VARCHAR(255));
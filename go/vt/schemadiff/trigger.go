@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+type CreateTriggerEntityDiff struct {
+	createTrigger *sqlparser.CreateTrigger
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *CreateTriggerEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *CreateTriggerEntityDiff) EntityName() string {
+	_, to := d.Entities()
+	return to.Name()
+}
+
+// Entities implements EntityDiff
+func (d *CreateTriggerEntityDiff) Entities() (from Entity, to Entity) {
+	return nil, &CreateTriggerEntity{CreateTrigger: d.createTrigger}
+}
+
+func (d *CreateTriggerEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *CreateTriggerEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.createTrigger
+}
+
+// CreateTrigger returns the underlying sqlparser.CreateTrigger that was generated for the diff.
+func (d *CreateTriggerEntityDiff) CreateTrigger() *sqlparser.CreateTrigger {
+	if d == nil {
+		return nil
+	}
+	return d.createTrigger
+}
+
+// StatementString implements EntityDiff
+func (d *CreateTriggerEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *CreateTriggerEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *CreateTriggerEntityDiff) SubsequentDiff() EntityDiff {
+	return nil
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *CreateTriggerEntityDiff) SetSubsequentDiff(EntityDiff) {
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *CreateTriggerEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *CreateTriggerEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	return &CreateTriggerEntityDiff{
+		createTrigger: sqlparser.Clone(d.createTrigger),
+	}
+}
+
+// DropTriggerEntityDiff drops a trigger. A modified trigger is expressed as a DropTriggerEntityDiff
+// with a subsequent CreateTriggerEntityDiff.
+type DropTriggerEntityDiff struct {
+	from           *CreateTriggerEntity
+	dropTrigger    *sqlparser.DropTrigger
+	subsequentDiff *CreateTriggerEntityDiff
+
+	canonicalStatementString string
+}
+
+// IsEmpty implements EntityDiff
+func (d *DropTriggerEntityDiff) IsEmpty() bool {
+	return d.Statement() == nil
+}
+
+// EntityName implements EntityDiff
+func (d *DropTriggerEntityDiff) EntityName() string {
+	return d.from.Name()
+}
+
+// Entities implements EntityDiff
+func (d *DropTriggerEntityDiff) Entities() (from Entity, to Entity) {
+	return d.from, nil
+}
+
+func (d *DropTriggerEntityDiff) Annotated() (from *TextualAnnotations, to *TextualAnnotations, unified *TextualAnnotations) {
+	return annotatedDiff(d, nil)
+}
+
+// Statement implements EntityDiff
+func (d *DropTriggerEntityDiff) Statement() sqlparser.Statement {
+	if d == nil {
+		return nil
+	}
+	return d.dropTrigger
+}
+
+// DropTrigger returns the underlying sqlparser.DropTrigger that was generated for the diff.
+func (d *DropTriggerEntityDiff) DropTrigger() *sqlparser.DropTrigger {
+	if d == nil {
+		return nil
+	}
+	return d.dropTrigger
+}
+
+// CanonicalStatementString implements EntityDiff
+func (d *DropTriggerEntityDiff) CanonicalStatementString() string {
+	if d == nil {
+		return ""
+	}
+	if d.canonicalStatementString == "" {
+		if stmt := d.Statement(); stmt != nil {
+			d.canonicalStatementString = sqlparser.CanonicalString(stmt)
+		}
+	}
+	return d.canonicalStatementString
+}
+
+// StatementString implements EntityDiff
+func (d *DropTriggerEntityDiff) StatementString() (s string) {
+	if stmt := d.Statement(); stmt != nil {
+		s = sqlparser.String(stmt)
+	}
+	return s
+}
+
+// SubsequentDiff implements EntityDiff
+func (d *DropTriggerEntityDiff) SubsequentDiff() EntityDiff {
+	if d == nil || d.subsequentDiff == nil {
+		return nil
+	}
+	return d.subsequentDiff
+}
+
+// SetSubsequentDiff implements EntityDiff
+func (d *DropTriggerEntityDiff) SetSubsequentDiff(subDiff EntityDiff) {
+	if d == nil {
+		return
+	}
+	if createDiff, ok := subDiff.(*CreateTriggerEntityDiff); ok {
+		d.subsequentDiff = createDiff
+	} else {
+		d.subsequentDiff = nil
+	}
+}
+
+// InstantDDLCapability implements EntityDiff
+func (d *DropTriggerEntityDiff) InstantDDLCapability() InstantDDLCapability {
+	return InstantDDLCapabilityIrrelevant
+}
+
+// Clone implements EntityDiff
+func (d *DropTriggerEntityDiff) Clone() EntityDiff {
+	if d == nil {
+		return nil
+	}
+	clone := &DropTriggerEntityDiff{
+		dropTrigger: sqlparser.Clone(d.dropTrigger),
+	}
+	if d.from != nil {
+		clone.from = d.from.Clone().(*CreateTriggerEntity)
+	}
+	if d.subsequentDiff != nil {
+		clone.subsequentDiff = d.subsequentDiff.Clone().(*CreateTriggerEntityDiff)
+	}
+	return clone
+}
+
+// CreateTriggerEntity stands for a TRIGGER construct. It contains the trigger's CREATE statement.
+type CreateTriggerEntity struct {
+	*sqlparser.CreateTrigger
+	env *Environment
+}
+
+func NewCreateTriggerEntity(env *Environment, c *sqlparser.CreateTrigger) (*CreateTriggerEntity, error) {
+	entity := &CreateTriggerEntity{CreateTrigger: c, env: env}
+	return entity, nil
+}
+
+func NewCreateTriggerEntityFromSQL(env *Environment, sql string) (*CreateTriggerEntity, error) {
+	stmt, err := env.Parser().ParseStrictDDL(sql)
+	if err != nil {
+		return nil, err
+	}
+	createTrigger, ok := stmt.(*sqlparser.CreateTrigger)
+	if !ok {
+		return nil, ErrExpectedCreateTrigger
+	}
+	return NewCreateTriggerEntity(env, createTrigger)
+}
+
+// Name implements Entity interface
+func (c *CreateTriggerEntity) Name() string {
+	return c.CreateTrigger.Name.Name.String()
+}
+
+// TableName returns the name of the table on which the trigger is defined
+func (c *CreateTriggerEntity) TableName() string {
+	return c.CreateTrigger.Table.Name.String()
+}
+
+// Diff implements Entity interface function
+func (c *CreateTriggerEntity) Diff(other Entity, hints *DiffHints) (EntityDiff, error) {
+	otherCreateTrigger, ok := other.(*CreateTriggerEntity)
+	if !ok {
+		return nil, ErrEntityTypeMismatch
+	}
+	return c.TriggerDiff(otherCreateTrigger, hints)
+}
+
+// TriggerDiff compares this trigger statement with another trigger statement, and sees what it takes to
+// change this trigger to look like the other trigger.
+// MySQL has no ALTER TRIGGER, so any change is expressed as a DROP TRIGGER followed by a CREATE TRIGGER.
+// It returns nil if the two are identical. The other trigger may be of different name; its name is ignored.
+func (c *CreateTriggerEntity) TriggerDiff(other *CreateTriggerEntity, _ *DiffHints) (*DropTriggerEntityDiff, error) {
+	if c.identicalOtherThanName(other) {
+		return nil, nil
+	}
+	diff := c.Drop().(*DropTriggerEntityDiff)
+	diff.subsequentDiff = &CreateTriggerEntityDiff{createTrigger: other.CreateTrigger}
+	return diff, nil
+}
+
+// Create implements Entity interface
+func (c *CreateTriggerEntity) Create() EntityDiff {
+	if c == nil {
+		return nil
+	}
+	return &CreateTriggerEntityDiff{createTrigger: c.CreateTrigger}
+}
+
+// Drop implements Entity interface
+func (c *CreateTriggerEntity) Drop() EntityDiff {
+	dropTrigger := &sqlparser.DropTrigger{
+		Name: c.CreateTrigger.Name,
+	}
+	return &DropTriggerEntityDiff{from: c, dropTrigger: dropTrigger}
+}
+
+// Apply attempts to apply given diff onto the trigger defined by this entity. The only supported diff
+// is the one generated by TriggerDiff(): a DROP TRIGGER followed by a CREATE TRIGGER.
+// This entity is unmodified. If successful, a new CREATE TRIGGER entity is returned.
+func (c *CreateTriggerEntity) Apply(diff EntityDiff) (Entity, error) {
+	dropDiff, ok := diff.(*DropTriggerEntityDiff)
+	if !ok || dropDiff.subsequentDiff == nil {
+		return nil, ErrEntityTypeMismatch
+	}
+	return &CreateTriggerEntity{CreateTrigger: sqlparser.Clone(dropDiff.subsequentDiff.createTrigger), env: c.env}, nil
+}
+
+func (c *CreateTriggerEntity) Clone() Entity {
+	return &CreateTriggerEntity{CreateTrigger: sqlparser.Clone(c.CreateTrigger), env: c.env}
+}
+
+func (c *CreateTriggerEntity) identicalOtherThanName(other *CreateTriggerEntity) bool {
+	if other == nil {
+		return false
+	}
+	return c.Time == other.Time &&
+		c.Event == other.Event &&
+		sqlparser.Equals.TableName(c.Table, other.Table) &&
+		sqlparser.Equals.RefOfTriggerOrder(c.Order, other.Order) &&
+		sqlparser.Equals.RefOfDefiner(c.Definer, other.Definer) &&
+		sqlparser.Equals.CompoundStatement(c.Body, other.Body) &&
+		sqlparser.Equals.RefOfParsedComments(c.Comments, other.Comments)
+}
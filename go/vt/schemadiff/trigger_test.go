@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestCreateTriggerDiff(t *testing.T) {
+	tt := []struct {
+		name   string
+		from   string
+		to     string
+		diffs  []string
+		cdiffs []string
+	}{
+		{
+			name: "identical",
+			from: "create trigger t1_bi before insert on t1 for each row set new.id = 1",
+			to:   "create trigger t1_bi before insert on t1 for each row set new.id = 1",
+		},
+		{
+			name: "identical, case and qualifiers",
+			from: "create trigger t1_bi BEFORE INSERT on `t1` for each row SET new.id = 1",
+			to:   "CREATE TRIGGER `t1_bi` before insert ON t1 FOR EACH ROW set NEW.`id` = 1",
+		},
+		{
+			name: "identical other than name",
+			from: "create trigger t1_bi before insert on t1 for each row set new.id = 1",
+			to:   "create trigger t1_bi2 before insert on t1 for each row set new.id = 1",
+		},
+		{
+			name: "change of body",
+			from: "create trigger t1_bi before insert on t1 for each row set new.id = 1",
+			to:   "create trigger t1_bi before insert on t1 for each row set new.id = 2",
+			diffs: []string{
+				"drop trigger t1_bi",
+				"create trigger t1_bi before insert on t1 for each row set new.id = 2;",
+			},
+			cdiffs: []string{
+				"DROP TRIGGER `t1_bi`",
+				"CREATE TRIGGER `t1_bi` BEFORE INSERT ON `t1` FOR EACH ROW SET NEW.`id` = 2;",
+			},
+		},
+		{
+			name: "change of action time",
+			from: "create trigger t1_bi before insert on t1 for each row set new.id = 1",
+			to:   "create trigger t1_bi after insert on t1 for each row set new.id = 1",
+			diffs: []string{
+				"drop trigger t1_bi",
+				"create trigger t1_bi after insert on t1 for each row set new.id = 1;",
+			},
+			cdiffs: []string{
+				"DROP TRIGGER `t1_bi`",
+				"CREATE TRIGGER `t1_bi` AFTER INSERT ON `t1` FOR EACH ROW SET NEW.`id` = 1;",
+			},
+		},
+		{
+			name: "change of order",
+			from: "create trigger t1_bi before insert on t1 for each row set new.id = 1",
+			to:   "create trigger t1_bi before insert on t1 for each row follows t1_bi0 begin set new.id = 1; end",
+			diffs: []string{
+				"drop trigger t1_bi",
+				"create trigger t1_bi before insert on t1 for each row follows t1_bi0 begin set new.id = 1; end;",
+			},
+			cdiffs: []string{
+				"DROP TRIGGER `t1_bi`",
+				"CREATE TRIGGER `t1_bi` BEFORE INSERT ON `t1` FOR EACH ROW FOLLOWS `t1_bi0` BEGIN SET NEW.`id` = 1; END;",
+			},
+		},
+	}
+	hints := EmptyDiffHints()
+	env := NewTestEnv()
+	for _, ts := range tt {
+		t.Run(ts.name, func(t *testing.T) {
+			fromStmt, err := env.Parser().ParseStrictDDL(ts.from)
+			require.NoError(t, err)
+			fromCreateTrigger, ok := fromStmt.(*sqlparser.CreateTrigger)
+			require.True(t, ok)
+
+			c, err := NewCreateTriggerEntity(env, fromCreateTrigger)
+			require.NoError(t, err)
+			other, err := NewCreateTriggerEntityFromSQL(env, ts.to)
+			require.NoError(t, err)
+
+			diff, err := c.Diff(other, hints)
+			require.NoError(t, err)
+			if len(ts.diffs) == 0 {
+				assert.True(t, diff.IsEmpty())
+				return
+			}
+			require.False(t, diff.IsEmpty())
+			var diffs, cdiffs []string
+			for _, d := range AllSubsequent(diff) {
+				diffs = append(diffs, d.StatementString())
+				cdiffs = append(cdiffs, d.CanonicalStatementString())
+			}
+			assert.Equal(t, ts.diffs, diffs)
+			assert.Equal(t, ts.cdiffs, cdiffs)
+			for _, s := range cdiffs {
+				// validate we can parse back the statement
+				_, err := env.Parser().ParseStrictDDL(s)
+				assert.NoError(t, err)
+			}
+
+			// Validate "apply()" on "from" converges with "to"
+			applied, err := c.Apply(diff)
+			require.NoError(t, err)
+			appliedDiff, err := other.Diff(applied, hints)
+			require.NoError(t, err)
+			assert.True(t, appliedDiff.IsEmpty(), "expected empty diff, found changes: %v", appliedDiff.CanonicalStatementString())
+
+			// Validate Clone() works
+			clone := diff.Clone()
+			assert.Equal(t, cdiffs, []string{clone.CanonicalStatementString(), clone.SubsequentDiff().CanonicalStatementString()})
+		})
+	}
+}
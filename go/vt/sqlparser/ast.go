@@ -572,7 +572,67 @@ type (
 		IfExists bool
 	}
 
+	// CreateTrigger represents a CREATE TRIGGER statement.
+	CreateTrigger struct {
+		Name        TableName
+		Comments    *ParsedComments
+		IfNotExists bool
+		Definer     *Definer
+		Time        TriggerTime
+		Event       TriggerEvent
+		Table       TableName
+		Order       *TriggerOrder
+		Body        CompoundStatement
+	}
+
+	// DropTrigger represents a DROP TRIGGER statement.
+	DropTrigger struct {
+		Comments *ParsedComments
+		Name     TableName
+		IfExists bool
+	}
+
+	// CreateFunction represents a CREATE FUNCTION statement for a stored function.
+	CreateFunction struct {
+		Name            TableName
+		Comments        *ParsedComments
+		IfNotExists     bool
+		Definer         *Definer
+		Params          []*ProcParameter
+		Returns         *ColumnType
+		Characteristics []*RoutineCharacteristic
+		Body            CompoundStatement
+	}
+
+	// DropFunction represents a DROP FUNCTION statement.
+	DropFunction struct {
+		Comments *ParsedComments
+		Name     TableName
+		IfExists bool
+	}
+
+	// CreateEvent represents a CREATE EVENT statement.
+	CreateEvent struct {
+		Name         TableName
+		Comments     *ParsedComments
+		IfNotExists  bool
+		Definer      *Definer
+		Schedule     *EventSchedule
+		OnCompletion EventOnCompletion
+		Status       EventStatus
+		EventComment *Literal
+		Body         CompoundStatement
+	}
+
+	// DropEvent represents a DROP EVENT statement.
+	DropEvent struct {
+		Comments *ParsedComments
+		Name     TableName
+		IfExists bool
+	}
+
 	// IgnoreOrReplaceType represents conflict handling mode for CREATE TABLE ... SELECT
+	// and LOAD DATA
 	IgnoreOrReplaceType int8
 
 	// CreateTable represents a CREATE TABLE statement.
@@ -622,8 +682,39 @@ type (
 	// DDLAction is an enum for DDL.Action
 	DDLAction int8
 
-	// Load represents a LOAD statement
+	// Load represents a LOAD DATA statement.
+	// LOAD DATA FROM S3 statements are not parsed and leave Table empty.
 	Load struct {
+		LowPriority     bool
+		Concurrent      bool
+		Local           bool
+		FileName        string
+		IgnoreOrReplace IgnoreOrReplaceType
+		Table           TableName
+		Partitions      Partitions
+		Charset         ColumnCharset
+		Fields          *LoadFields
+		Lines           *LoadLines
+		IgnoreLines     *Literal
+		// Columns contains *ColName and *Variable expressions
+		Columns  []Expr
+		SetExprs UpdateExprs
+	}
+
+	// LoadFields represents the FIELDS clause of a LOAD DATA statement.
+	// Options that are not specified are nil.
+	LoadFields struct {
+		TerminatedBy *Literal
+		EnclosedBy   *Literal
+		Optionally   bool
+		EscapedBy    *Literal
+	}
+
+	// LoadLines represents the LINES clause of a LOAD DATA statement.
+	// Options that are not specified are nil.
+	LoadLines struct {
+		StartingBy   *Literal
+		TerminatedBy *Literal
 	}
 
 	// PurgeBinaryLogs represents a PURGE BINARY LOGS statement
@@ -841,6 +932,11 @@ type (
 		Condition HandlerCondition
 		SetValues []*SignalSet
 	}
+
+	// ReturnStatement represents a RETURN statement in a stored function
+	ReturnStatement struct {
+		Expr Expr
+	}
 )
 
 func (*SingleStatement) iCompoundStatement()   {}
@@ -850,6 +946,7 @@ func (*DeclareVar) iCompoundStatement()        {}
 func (*DeclareHandler) iCompoundStatement()    {}
 func (*DeclareCondition) iCompoundStatement()  {}
 func (*Signal) iCompoundStatement()            {}
+func (*ReturnStatement) iCompoundStatement()   {}
 
 // SignalConditionName is an enum for the name of the condition variable being set in SIGNAL statement
 type SignalConditionName int8
@@ -961,6 +1058,12 @@ func (*DeallocateStmt) iStatement()        {}
 func (*PurgeBinaryLogs) iStatement()       {}
 func (*Kill) iStatement()                  {}
 func (*DropProcedure) iStatement()         {}
+func (*CreateTrigger) iStatement()         {}
+func (*DropTrigger) iStatement()           {}
+func (*CreateFunction) iStatement()        {}
+func (*DropFunction) iStatement()          {}
+func (*CreateEvent) iStatement()           {}
+func (*DropEvent) iStatement()             {}
 
 func (*CreateView) iDDLStatement()      {}
 func (*AlterView) iDDLStatement()       {}
@@ -972,6 +1075,12 @@ func (*TruncateTable) iDDLStatement()   {}
 func (*RenameTable) iDDLStatement()     {}
 func (*CreateProcedure) iDDLStatement() {}
 func (*DropProcedure) iDDLStatement()   {}
+func (*CreateTrigger) iDDLStatement()   {}
+func (*DropTrigger) iDDLStatement()     {}
+func (*CreateFunction) iDDLStatement()  {}
+func (*DropFunction) iDDLStatement()    {}
+func (*CreateEvent) iDDLStatement()     {}
+func (*DropEvent) iDDLStatement()       {}
 
 func (*AddConstraintDefinition) iAlterOption() {}
 func (*AddIndexDefinition) iAlterOption()      {}
@@ -1026,12 +1135,48 @@ func (node *CreateProcedure) IsFullyParsed() bool { return true }
 // IsFullyParsed implements the DDLStatement interface
 func (node *DropProcedure) IsFullyParsed() bool { return true }
 
+// IsFullyParsed implements the DDLStatement interface
+func (node *CreateTrigger) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *DropTrigger) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *CreateFunction) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *DropFunction) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *CreateEvent) IsFullyParsed() bool { return true }
+
+// IsFullyParsed implements the DDLStatement interface
+func (node *DropEvent) IsFullyParsed() bool { return true }
+
 // SetFullyParsed implements the DDL interface
 func (node *DropProcedure) SetFullyParsed(fullyParsed bool) {}
 
 // SetFullyParsed implements the DDLStatement interface
 func (node *CreateProcedure) SetFullyParsed(bool) {}
 
+// SetFullyParsed implements the DDLStatement interface
+func (node *CreateTrigger) SetFullyParsed(bool) {}
+
+// SetFullyParsed implements the DDL interface
+func (node *DropTrigger) SetFullyParsed(fullyParsed bool) {}
+
+// SetFullyParsed implements the DDLStatement interface
+func (node *CreateFunction) SetFullyParsed(bool) {}
+
+// SetFullyParsed implements the DDL interface
+func (node *DropFunction) SetFullyParsed(fullyParsed bool) {}
+
+// SetFullyParsed implements the DDLStatement interface
+func (node *CreateEvent) SetFullyParsed(bool) {}
+
+// SetFullyParsed implements the DDL interface
+func (node *DropEvent) SetFullyParsed(fullyParsed bool) {}
+
 // SetFullyParsed implements the DDLStatement interface
 func (node *RenameTable) SetFullyParsed(fullyParsed bool) {}
 
@@ -1100,6 +1245,30 @@ func (node *DropProcedure) IsTemporary() bool {
 	return false
 }
 
+// IsTemporary implements the DDLStatement interface
+func (node *CreateTrigger) IsTemporary() bool { return false }
+
+// IsTemporary implements the DDL interface
+func (node *DropTrigger) IsTemporary() bool {
+	return false
+}
+
+// IsTemporary implements the DDLStatement interface
+func (node *CreateFunction) IsTemporary() bool { return false }
+
+// IsTemporary implements the DDL interface
+func (node *DropFunction) IsTemporary() bool {
+	return false
+}
+
+// IsTemporary implements the DDLStatement interface
+func (node *CreateEvent) IsTemporary() bool { return false }
+
+// IsTemporary implements the DDL interface
+func (node *DropEvent) IsTemporary() bool {
+	return false
+}
+
 // IsTemporary implements the DDLStatement interface
 func (*RenameTable) IsTemporary() bool {
 	return false
@@ -1183,6 +1352,30 @@ func (node *DropProcedure) GetTable() TableName {
 	return node.Name
 }
 
+// GetTable implements the DDLStatement interface
+func (node *CreateTrigger) GetTable() TableName { return node.Name }
+
+// GetTable implements the DDL interface
+func (node *DropTrigger) GetTable() TableName {
+	return node.Name
+}
+
+// GetTable implements the DDLStatement interface
+func (node *CreateFunction) GetTable() TableName { return node.Name }
+
+// GetTable implements the DDL interface
+func (node *DropFunction) GetTable() TableName {
+	return node.Name
+}
+
+// GetTable implements the DDLStatement interface
+func (node *CreateEvent) GetTable() TableName { return node.Name }
+
+// GetTable implements the DDL interface
+func (node *DropEvent) GetTable() TableName {
+	return node.Name
+}
+
 // GetAction implements the DDLStatement interface
 func (node *TruncateTable) GetAction() DDLAction {
 	return TruncateDDLAction
@@ -1233,6 +1426,36 @@ func (node *CreateProcedure) GetAction() DDLAction {
 	return CreateProcedureAction
 }
 
+// GetAction implements the DDLStatement interface
+func (node *CreateTrigger) GetAction() DDLAction {
+	return CreateTriggerAction
+}
+
+// GetAction implements the DDL interface
+func (node *DropTrigger) GetAction() DDLAction {
+	return DropDDLAction
+}
+
+// GetAction implements the DDLStatement interface
+func (node *CreateFunction) GetAction() DDLAction {
+	return CreateFunctionAction
+}
+
+// GetAction implements the DDL interface
+func (node *DropFunction) GetAction() DDLAction {
+	return DropDDLAction
+}
+
+// GetAction implements the DDLStatement interface
+func (node *CreateEvent) GetAction() DDLAction {
+	return CreateEventAction
+}
+
+// GetAction implements the DDL interface
+func (node *DropEvent) GetAction() DDLAction {
+	return DropDDLAction
+}
+
 // GetOptLike implements the DDLStatement interface
 func (node *CreateTable) GetOptLike() *OptLike {
 	return node.OptLike
@@ -1283,6 +1506,36 @@ func (node *DropProcedure) GetOptLike() *OptLike {
 	return nil
 }
 
+// GetOptLike implements the DDLStatement interface
+func (node *CreateTrigger) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDL interface
+func (node *DropTrigger) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDLStatement interface
+func (node *CreateFunction) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDL interface
+func (node *DropFunction) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDLStatement interface
+func (node *CreateEvent) GetOptLike() *OptLike {
+	return nil
+}
+
+// GetOptLike implements the DDL interface
+func (node *DropEvent) GetOptLike() *OptLike {
+	return nil
+}
+
 // GetIfExists implements the DDLStatement interface
 func (node *RenameTable) GetIfExists() bool {
 	return false
@@ -1333,6 +1586,36 @@ func (node *CreateProcedure) GetIfExists() bool {
 	return false
 }
 
+// GetIfExists implements the DDLStatement interface
+func (node *CreateTrigger) GetIfExists() bool {
+	return false
+}
+
+// GetIfExists implements the DDL interface
+func (node *DropTrigger) GetIfExists() bool {
+	return node.IfExists
+}
+
+// GetIfExists implements the DDLStatement interface
+func (node *CreateFunction) GetIfExists() bool {
+	return false
+}
+
+// GetIfExists implements the DDL interface
+func (node *DropFunction) GetIfExists() bool {
+	return node.IfExists
+}
+
+// GetIfExists implements the DDLStatement interface
+func (node *CreateEvent) GetIfExists() bool {
+	return false
+}
+
+// GetIfExists implements the DDL interface
+func (node *DropEvent) GetIfExists() bool {
+	return node.IfExists
+}
+
 // GetIfNotExists implements the DDLStatement interface
 func (node *RenameTable) GetIfNotExists() bool {
 	return false
@@ -1383,6 +1666,36 @@ func (node *DropProcedure) GetIfNotExists() bool {
 	return false
 }
 
+// GetIfNotExists implements the DDLStatement interface
+func (node *CreateTrigger) GetIfNotExists() bool {
+	return node.IfNotExists
+}
+
+// GetIfNotExists implements the DDL interface
+func (node *DropTrigger) GetIfNotExists() bool {
+	return false
+}
+
+// GetIfNotExists implements the DDLStatement interface
+func (node *CreateFunction) GetIfNotExists() bool {
+	return node.IfNotExists
+}
+
+// GetIfNotExists implements the DDL interface
+func (node *DropFunction) GetIfNotExists() bool {
+	return false
+}
+
+// GetIfNotExists implements the DDLStatement interface
+func (node *CreateEvent) GetIfNotExists() bool {
+	return node.IfNotExists
+}
+
+// GetIfNotExists implements the DDL interface
+func (node *DropEvent) GetIfNotExists() bool {
+	return false
+}
+
 // GetIsReplace implements the DDLStatement interface
 func (node *RenameTable) GetIsReplace() bool {
 	return false
@@ -1433,6 +1746,36 @@ func (node *DropProcedure) GetIsReplace() bool {
 	return false
 }
 
+// GetIsReplace implements the DDLStatement interface
+func (node *CreateTrigger) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDL interface
+func (node *DropTrigger) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDLStatement interface
+func (node *CreateFunction) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDL interface
+func (node *DropFunction) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDLStatement interface
+func (node *CreateEvent) GetIsReplace() bool {
+	return false
+}
+
+// GetIsReplace implements the DDL interface
+func (node *DropEvent) GetIsReplace() bool {
+	return false
+}
+
 // GetTableSpec implements the DDLStatement interface
 func (node *CreateTable) GetTableSpec() *TableSpec {
 	return node.TableSpec
@@ -1483,6 +1826,36 @@ func (node *DropProcedure) GetTableSpec() *TableSpec {
 	return nil
 }
 
+// GetTableSpec implements the DDLStatement interface
+func (node *CreateTrigger) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDL interface
+func (node *DropTrigger) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDLStatement interface
+func (node *CreateFunction) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDL interface
+func (node *DropFunction) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDLStatement interface
+func (node *CreateEvent) GetTableSpec() *TableSpec {
+	return nil
+}
+
+// GetTableSpec implements the DDL interface
+func (node *DropEvent) GetTableSpec() *TableSpec {
+	return nil
+}
+
 // GetFromTables implements the DDLStatement interface
 func (node *RenameTable) GetFromTables() TableNames {
 	var fromTables TableNames
@@ -1537,6 +1910,36 @@ func (node *DropProcedure) GetFromTables() TableNames {
 	return nil
 }
 
+// GetFromTables implements the DDLStatement interface
+func (node *CreateTrigger) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDL interface
+func (node *DropTrigger) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDLStatement interface
+func (node *CreateFunction) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDL interface
+func (node *DropFunction) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDLStatement interface
+func (node *CreateEvent) GetFromTables() TableNames {
+	return nil
+}
+
+// GetFromTables implements the DDL interface
+func (node *DropEvent) GetFromTables() TableNames {
+	return nil
+}
+
 // SetFromTables implements DDLStatement.
 func (node *RenameTable) SetFromTables(tables TableNames) {
 	if len(node.TablePairs) != len(tables) {
@@ -1590,6 +1993,30 @@ func (node *CreateProcedure) SetFromTables(tables TableNames) {
 // SetFromTables implements the DDL interface
 func (node *DropProcedure) SetFromTables(tables TableNames) {}
 
+// SetFromTables implements the DDLStatement interface
+func (node *CreateTrigger) SetFromTables(tables TableNames) {
+	// irrelevant
+}
+
+// SetFromTables implements the DDL interface
+func (node *DropTrigger) SetFromTables(tables TableNames) {}
+
+// SetFromTables implements the DDLStatement interface
+func (node *CreateFunction) SetFromTables(tables TableNames) {
+	// irrelevant
+}
+
+// SetFromTables implements the DDL interface
+func (node *DropFunction) SetFromTables(tables TableNames) {}
+
+// SetFromTables implements the DDLStatement interface
+func (node *CreateEvent) SetFromTables(tables TableNames) {
+	// irrelevant
+}
+
+// SetFromTables implements the DDL interface
+func (node *DropEvent) SetFromTables(tables TableNames) {}
+
 // SetComments implements Commented interface.
 func (node *RenameTable) SetComments(comments Comments) {
 	// irrelevant
@@ -1680,6 +2107,36 @@ func (node *DropProcedure) SetComments(comments Comments) {
 	node.Comments = comments.Parsed()
 }
 
+// SetComments for CreateTrigger
+func (node *CreateTrigger) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments implements the DDL interface
+func (node *DropTrigger) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments for CreateFunction
+func (node *CreateFunction) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments implements the DDL interface
+func (node *DropFunction) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments for CreateEvent
+func (node *CreateEvent) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
+// SetComments implements the DDL interface
+func (node *DropEvent) SetComments(comments Comments) {
+	node.Comments = comments.Parsed()
+}
+
 // GetParsedComments implements Commented interface.
 func (node *RenameTable) GetParsedComments() *ParsedComments {
 	// irrelevant
@@ -1773,6 +2230,30 @@ func (node *DropProcedure) GetParsedComments() *ParsedComments {
 	return node.Comments
 }
 
+// GetParsedComments implements Commented interface.
+func (node *CreateTrigger) GetParsedComments() *ParsedComments { return node.Comments }
+
+// GetParsedComments implements the DDL interface
+func (node *DropTrigger) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
+// GetParsedComments implements Commented interface.
+func (node *CreateFunction) GetParsedComments() *ParsedComments { return node.Comments }
+
+// GetParsedComments implements the DDL interface
+func (node *DropFunction) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
+// GetParsedComments implements Commented interface.
+func (node *CreateEvent) GetParsedComments() *ParsedComments { return node.Comments }
+
+// GetParsedComments implements the DDL interface
+func (node *DropEvent) GetParsedComments() *ParsedComments {
+	return node.Comments
+}
+
 // GetToTables implements the DDLStatement interface
 func (node *RenameTable) GetToTables() TableNames {
 	var toTables TableNames
@@ -1833,6 +2314,36 @@ func (node *DropProcedure) GetToTables() TableNames {
 	return nil
 }
 
+// GetToTables implements the DDLStatement interface
+func (node *CreateTrigger) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDL interface
+func (node *DropTrigger) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDLStatement interface
+func (node *CreateFunction) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDL interface
+func (node *DropFunction) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDLStatement interface
+func (node *CreateEvent) GetToTables() TableNames {
+	return nil
+}
+
+// GetToTables implements the DDL interface
+func (node *DropEvent) GetToTables() TableNames {
+	return nil
+}
+
 // AffectedTables returns the list table names affected by the DDLStatement.
 func (node *RenameTable) AffectedTables() TableNames {
 	list := make(TableNames, 0, 2*len(node.TablePairs))
@@ -1895,6 +2406,36 @@ func (node *DropProcedure) AffectedTables() TableNames {
 	return TableNames{node.GetTable()}
 }
 
+// AffectedTables implements the DDLStatement interface
+func (node *CreateTrigger) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDL interface
+func (node *DropTrigger) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDLStatement interface
+func (node *CreateFunction) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDL interface
+func (node *DropFunction) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDLStatement interface
+func (node *CreateEvent) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
+// AffectedTables implements the DDL interface
+func (node *DropEvent) AffectedTables() TableNames {
+	return TableNames{node.GetTable()}
+}
+
 // SetTable implements DDLStatement.
 func (node *TruncateTable) SetTable(qualifier string, name string) {
 	node.Table.Qualifier = NewIdentifierCS(qualifier)
@@ -1946,6 +2487,42 @@ func (node *DropProcedure) SetTable(qualifier string, name string) {
 	node.Name.Name = NewIdentifierCS(name)
 }
 
+// SetTable implements the DDLStatement interface
+func (node *CreateTrigger) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDL interface
+func (node *DropTrigger) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDLStatement interface
+func (node *CreateFunction) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDL interface
+func (node *DropFunction) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDLStatement interface
+func (node *CreateEvent) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
+// SetTable implements the DDL interface
+func (node *DropEvent) SetTable(qualifier string, name string) {
+	node.Name.Qualifier = NewIdentifierCS(qualifier)
+	node.Name.Name = NewIdentifierCS(name)
+}
+
 func (*DropDatabase) iDBDDLStatement()   {}
 func (*CreateDatabase) iDBDDLStatement() {}
 func (*AlterDatabase) iDBDDLStatement()  {}
@@ -2062,6 +2639,43 @@ type ProcParameter struct {
 // ProcParameterMode is an enum for ProcParameter.Mode
 type ProcParameterMode int8
 
+// TriggerTime is an enum for CreateTrigger.Time
+type TriggerTime int8
+
+// TriggerEvent is an enum for CreateTrigger.Event
+type TriggerEvent int8
+
+// TriggerOrder represents the FOLLOWS/PRECEDES clause of a CREATE TRIGGER statement
+type TriggerOrder struct {
+	Precedes bool
+	Name     IdentifierCS
+}
+
+// RoutineCharacteristicType is an enum for RoutineCharacteristic.Type
+type RoutineCharacteristicType int8
+
+// RoutineCharacteristic represents a characteristic of a stored function, e.g. DETERMINISTIC
+type RoutineCharacteristic struct {
+	Type    RoutineCharacteristicType
+	Comment *Literal
+}
+
+// EventSchedule represents the ON SCHEDULE clause of a CREATE EVENT statement.
+// Either At is set, for a one time event, or Every and Unit are set, for a recurring one.
+type EventSchedule struct {
+	At     Expr
+	Every  Expr
+	Unit   IntervalType
+	Starts Expr
+	Ends   Expr
+}
+
+// EventOnCompletion is an enum for CreateEvent.OnCompletion
+type EventOnCompletion int8
+
+// EventStatus is an enum for CreateEvent.Status
+type EventStatus int8
+
 // PartitionSpec describe partition actions (for alter statements)
 type PartitionSpec struct {
 	Action            PartitionSpecAction
@@ -131,10 +131,16 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfCountStar(in)
 	case *CreateDatabase:
 		return CloneRefOfCreateDatabase(in)
+	case *CreateEvent:
+		return CloneRefOfCreateEvent(in)
+	case *CreateFunction:
+		return CloneRefOfCreateFunction(in)
 	case *CreateProcedure:
 		return CloneRefOfCreateProcedure(in)
 	case *CreateTable:
 		return CloneRefOfCreateTable(in)
+	case *CreateTrigger:
+		return CloneRefOfCreateTrigger(in)
 	case *CreateView:
 		return CloneRefOfCreateView(in)
 	case *CurTimeFuncExpr:
@@ -159,16 +165,24 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfDropColumn(in)
 	case *DropDatabase:
 		return CloneRefOfDropDatabase(in)
+	case *DropEvent:
+		return CloneRefOfDropEvent(in)
+	case *DropFunction:
+		return CloneRefOfDropFunction(in)
 	case *DropKey:
 		return CloneRefOfDropKey(in)
 	case *DropProcedure:
 		return CloneRefOfDropProcedure(in)
 	case *DropTable:
 		return CloneRefOfDropTable(in)
+	case *DropTrigger:
+		return CloneRefOfDropTrigger(in)
 	case *DropView:
 		return CloneRefOfDropView(in)
 	case *ElseIfBlock:
 		return CloneRefOfElseIfBlock(in)
+	case *EventSchedule:
+		return CloneRefOfEventSchedule(in)
 	case *ExecuteStmt:
 		return CloneRefOfExecuteStmt(in)
 	case *ExistsExpr:
@@ -337,6 +351,10 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfLiteral(in)
 	case *Load:
 		return CloneRefOfLoad(in)
+	case *LoadFields:
+		return CloneRefOfLoadFields(in)
+	case *LoadLines:
+		return CloneRefOfLoadLines(in)
 	case *LocateExpr:
 		return CloneRefOfLocateExpr(in)
 	case *LockOption:
@@ -453,12 +471,16 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfRenameTable(in)
 	case *RenameTableName:
 		return CloneRefOfRenameTableName(in)
+	case *ReturnStatement:
+		return CloneRefOfReturnStatement(in)
 	case *RevertMigration:
 		return CloneRefOfRevertMigration(in)
 	case *Rollback:
 		return CloneRefOfRollback(in)
 	case RootNode:
 		return CloneRootNode(in)
+	case *RoutineCharacteristic:
+		return CloneRefOfRoutineCharacteristic(in)
 	case *RowAlias:
 		return CloneRefOfRowAlias(in)
 	case *SRollback:
@@ -541,6 +563,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfTablespaceOperation(in)
 	case *TimestampDiffExpr:
 		return CloneRefOfTimestampDiffExpr(in)
+	case *TriggerOrder:
+		return CloneRefOfTriggerOrder(in)
 	case *TrimFuncExpr:
 		return CloneRefOfTrimFuncExpr(in)
 	case *TruncateTable:
@@ -1176,6 +1200,37 @@ func CloneRefOfCreateDatabase(n *CreateDatabase) *CreateDatabase {
 	return &out
 }
 
+// CloneRefOfCreateEvent creates a deep clone of the input.
+func CloneRefOfCreateEvent(n *CreateEvent) *CreateEvent {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Name = CloneTableName(n.Name)
+	out.Comments = CloneRefOfParsedComments(n.Comments)
+	out.Definer = CloneRefOfDefiner(n.Definer)
+	out.Schedule = CloneRefOfEventSchedule(n.Schedule)
+	out.EventComment = CloneRefOfLiteral(n.EventComment)
+	out.Body = CloneCompoundStatement(n.Body)
+	return &out
+}
+
+// CloneRefOfCreateFunction creates a deep clone of the input.
+func CloneRefOfCreateFunction(n *CreateFunction) *CreateFunction {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Name = CloneTableName(n.Name)
+	out.Comments = CloneRefOfParsedComments(n.Comments)
+	out.Definer = CloneRefOfDefiner(n.Definer)
+	out.Params = CloneSliceOfRefOfProcParameter(n.Params)
+	out.Returns = CloneRefOfColumnType(n.Returns)
+	out.Characteristics = CloneSliceOfRefOfRoutineCharacteristic(n.Characteristics)
+	out.Body = CloneCompoundStatement(n.Body)
+	return &out
+}
+
 // CloneRefOfCreateProcedure creates a deep clone of the input.
 func CloneRefOfCreateProcedure(n *CreateProcedure) *CreateProcedure {
 	if n == nil {
@@ -1204,6 +1259,21 @@ func CloneRefOfCreateTable(n *CreateTable) *CreateTable {
 	return &out
 }
 
+// CloneRefOfCreateTrigger creates a deep clone of the input.
+func CloneRefOfCreateTrigger(n *CreateTrigger) *CreateTrigger {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Name = CloneTableName(n.Name)
+	out.Comments = CloneRefOfParsedComments(n.Comments)
+	out.Definer = CloneRefOfDefiner(n.Definer)
+	out.Table = CloneTableName(n.Table)
+	out.Order = CloneRefOfTriggerOrder(n.Order)
+	out.Body = CloneCompoundStatement(n.Body)
+	return &out
+}
+
 // CloneRefOfCreateView creates a deep clone of the input.
 func CloneRefOfCreateView(n *CreateView) *CreateView {
 	if n == nil {
@@ -1338,6 +1408,28 @@ func CloneRefOfDropDatabase(n *DropDatabase) *DropDatabase {
 	return &out
 }
 
+// CloneRefOfDropEvent creates a deep clone of the input.
+func CloneRefOfDropEvent(n *DropEvent) *DropEvent {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Comments = CloneRefOfParsedComments(n.Comments)
+	out.Name = CloneTableName(n.Name)
+	return &out
+}
+
+// CloneRefOfDropFunction creates a deep clone of the input.
+func CloneRefOfDropFunction(n *DropFunction) *DropFunction {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Comments = CloneRefOfParsedComments(n.Comments)
+	out.Name = CloneTableName(n.Name)
+	return &out
+}
+
 // CloneRefOfDropKey creates a deep clone of the input.
 func CloneRefOfDropKey(n *DropKey) *DropKey {
 	if n == nil {
@@ -1370,6 +1462,17 @@ func CloneRefOfDropTable(n *DropTable) *DropTable {
 	return &out
 }
 
+// CloneRefOfDropTrigger creates a deep clone of the input.
+func CloneRefOfDropTrigger(n *DropTrigger) *DropTrigger {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Comments = CloneRefOfParsedComments(n.Comments)
+	out.Name = CloneTableName(n.Name)
+	return &out
+}
+
 // CloneRefOfDropView creates a deep clone of the input.
 func CloneRefOfDropView(n *DropView) *DropView {
 	if n == nil {
@@ -1392,6 +1495,19 @@ func CloneRefOfElseIfBlock(n *ElseIfBlock) *ElseIfBlock {
 	return &out
 }
 
+// CloneRefOfEventSchedule creates a deep clone of the input.
+func CloneRefOfEventSchedule(n *EventSchedule) *EventSchedule {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.At = CloneExpr(n.At)
+	out.Every = CloneExpr(n.Every)
+	out.Starts = CloneExpr(n.Starts)
+	out.Ends = CloneExpr(n.Ends)
+	return &out
+}
+
 // CloneRefOfExecuteStmt creates a deep clone of the input.
 func CloneRefOfExecuteStmt(n *ExecuteStmt) *ExecuteStmt {
 	if n == nil {
@@ -2288,6 +2404,37 @@ func CloneRefOfLoad(n *Load) *Load {
 		return nil
 	}
 	out := *n
+	out.Table = CloneTableName(n.Table)
+	out.Partitions = ClonePartitions(n.Partitions)
+	out.Charset = CloneColumnCharset(n.Charset)
+	out.Fields = CloneRefOfLoadFields(n.Fields)
+	out.Lines = CloneRefOfLoadLines(n.Lines)
+	out.IgnoreLines = CloneRefOfLiteral(n.IgnoreLines)
+	out.Columns = CloneSliceOfExpr(n.Columns)
+	out.SetExprs = CloneUpdateExprs(n.SetExprs)
+	return &out
+}
+
+// CloneRefOfLoadFields creates a deep clone of the input.
+func CloneRefOfLoadFields(n *LoadFields) *LoadFields {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.TerminatedBy = CloneRefOfLiteral(n.TerminatedBy)
+	out.EnclosedBy = CloneRefOfLiteral(n.EnclosedBy)
+	out.EscapedBy = CloneRefOfLiteral(n.EscapedBy)
+	return &out
+}
+
+// CloneRefOfLoadLines creates a deep clone of the input.
+func CloneRefOfLoadLines(n *LoadLines) *LoadLines {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.StartingBy = CloneRefOfLiteral(n.StartingBy)
+	out.TerminatedBy = CloneRefOfLiteral(n.TerminatedBy)
 	return &out
 }
 
@@ -2907,6 +3054,16 @@ func CloneRefOfRenameTableName(n *RenameTableName) *RenameTableName {
 	return &out
 }
 
+// CloneRefOfReturnStatement creates a deep clone of the input.
+func CloneRefOfReturnStatement(n *ReturnStatement) *ReturnStatement {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Expr = CloneExpr(n.Expr)
+	return &out
+}
+
 // CloneRefOfRevertMigration creates a deep clone of the input.
 func CloneRefOfRevertMigration(n *RevertMigration) *RevertMigration {
 	if n == nil {
@@ -2931,6 +3088,16 @@ func CloneRootNode(n RootNode) RootNode {
 	return *CloneRefOfRootNode(&n)
 }
 
+// CloneRefOfRoutineCharacteristic creates a deep clone of the input.
+func CloneRefOfRoutineCharacteristic(n *RoutineCharacteristic) *RoutineCharacteristic {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Comment = CloneRefOfLiteral(n.Comment)
+	return &out
+}
+
 // CloneRefOfRowAlias creates a deep clone of the input.
 func CloneRefOfRowAlias(n *RowAlias) *RowAlias {
 	if n == nil {
@@ -3382,6 +3549,16 @@ func CloneRefOfTimestampDiffExpr(n *TimestampDiffExpr) *TimestampDiffExpr {
 	return &out
 }
 
+// CloneRefOfTriggerOrder creates a deep clone of the input.
+func CloneRefOfTriggerOrder(n *TriggerOrder) *TriggerOrder {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	out.Name = CloneIdentifierCS(n.Name)
+	return &out
+}
+
 // CloneRefOfTrimFuncExpr creates a deep clone of the input.
 func CloneRefOfTrimFuncExpr(n *TrimFuncExpr) *TrimFuncExpr {
 	if n == nil {
@@ -4039,6 +4216,8 @@ func CloneCompoundStatement(in CompoundStatement) CompoundStatement {
 		return CloneRefOfDeclareVar(in)
 	case *IfStatement:
 		return CloneRefOfIfStatement(in)
+	case *ReturnStatement:
+		return CloneRefOfReturnStatement(in)
 	case *Signal:
 		return CloneRefOfSignal(in)
 	case *SingleStatement:
@@ -4093,16 +4272,28 @@ func CloneDDLStatement(in DDLStatement) DDLStatement {
 		return CloneRefOfAlterTable(in)
 	case *AlterView:
 		return CloneRefOfAlterView(in)
+	case *CreateEvent:
+		return CloneRefOfCreateEvent(in)
+	case *CreateFunction:
+		return CloneRefOfCreateFunction(in)
 	case *CreateProcedure:
 		return CloneRefOfCreateProcedure(in)
 	case *CreateTable:
 		return CloneRefOfCreateTable(in)
+	case *CreateTrigger:
+		return CloneRefOfCreateTrigger(in)
 	case *CreateView:
 		return CloneRefOfCreateView(in)
+	case *DropEvent:
+		return CloneRefOfDropEvent(in)
+	case *DropFunction:
+		return CloneRefOfDropFunction(in)
 	case *DropProcedure:
 		return CloneRefOfDropProcedure(in)
 	case *DropTable:
 		return CloneRefOfDropTable(in)
+	case *DropTrigger:
+		return CloneRefOfDropTrigger(in)
 	case *DropView:
 		return CloneRefOfDropView(in)
 	case *RenameTable:
@@ -4519,10 +4710,16 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfCommit(in)
 	case *CreateDatabase:
 		return CloneRefOfCreateDatabase(in)
+	case *CreateEvent:
+		return CloneRefOfCreateEvent(in)
+	case *CreateFunction:
+		return CloneRefOfCreateFunction(in)
 	case *CreateProcedure:
 		return CloneRefOfCreateProcedure(in)
 	case *CreateTable:
 		return CloneRefOfCreateTable(in)
+	case *CreateTrigger:
+		return CloneRefOfCreateTrigger(in)
 	case *CreateView:
 		return CloneRefOfCreateView(in)
 	case *DeallocateStmt:
@@ -4531,10 +4728,16 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfDelete(in)
 	case *DropDatabase:
 		return CloneRefOfDropDatabase(in)
+	case *DropEvent:
+		return CloneRefOfDropEvent(in)
+	case *DropFunction:
+		return CloneRefOfDropFunction(in)
 	case *DropProcedure:
 		return CloneRefOfDropProcedure(in)
 	case *DropTable:
 		return CloneRefOfDropTable(in)
+	case *DropTrigger:
+		return CloneRefOfDropTrigger(in)
 	case *DropView:
 		return CloneRefOfDropView(in)
 	case *ExecuteStmt:
@@ -4803,6 +5006,18 @@ func CloneSliceOfRefOfProcParameter(n []*ProcParameter) []*ProcParameter {
 	return res
 }
 
+// CloneSliceOfRefOfRoutineCharacteristic creates a deep clone of the input.
+func CloneSliceOfRefOfRoutineCharacteristic(n []*RoutineCharacteristic) []*RoutineCharacteristic {
+	if n == nil {
+		return nil
+	}
+	res := make([]*RoutineCharacteristic, len(n))
+	for i, x := range n {
+		res[i] = CloneRefOfRoutineCharacteristic(x)
+	}
+	return res
+}
+
 // CloneSliceOfHandlerCondition creates a deep clone of the input.
 func CloneSliceOfHandlerCondition(n []HandlerCondition) []HandlerCondition {
 	if n == nil {
@@ -130,10 +130,16 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfCountStar(n, parent)
 	case *CreateDatabase:
 		return c.copyOnRewriteRefOfCreateDatabase(n, parent)
+	case *CreateEvent:
+		return c.copyOnRewriteRefOfCreateEvent(n, parent)
+	case *CreateFunction:
+		return c.copyOnRewriteRefOfCreateFunction(n, parent)
 	case *CreateProcedure:
 		return c.copyOnRewriteRefOfCreateProcedure(n, parent)
 	case *CreateTable:
 		return c.copyOnRewriteRefOfCreateTable(n, parent)
+	case *CreateTrigger:
+		return c.copyOnRewriteRefOfCreateTrigger(n, parent)
 	case *CreateView:
 		return c.copyOnRewriteRefOfCreateView(n, parent)
 	case *CurTimeFuncExpr:
@@ -158,16 +164,24 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfDropColumn(n, parent)
 	case *DropDatabase:
 		return c.copyOnRewriteRefOfDropDatabase(n, parent)
+	case *DropEvent:
+		return c.copyOnRewriteRefOfDropEvent(n, parent)
+	case *DropFunction:
+		return c.copyOnRewriteRefOfDropFunction(n, parent)
 	case *DropKey:
 		return c.copyOnRewriteRefOfDropKey(n, parent)
 	case *DropProcedure:
 		return c.copyOnRewriteRefOfDropProcedure(n, parent)
 	case *DropTable:
 		return c.copyOnRewriteRefOfDropTable(n, parent)
+	case *DropTrigger:
+		return c.copyOnRewriteRefOfDropTrigger(n, parent)
 	case *DropView:
 		return c.copyOnRewriteRefOfDropView(n, parent)
 	case *ElseIfBlock:
 		return c.copyOnRewriteRefOfElseIfBlock(n, parent)
+	case *EventSchedule:
+		return c.copyOnRewriteRefOfEventSchedule(n, parent)
 	case *ExecuteStmt:
 		return c.copyOnRewriteRefOfExecuteStmt(n, parent)
 	case *ExistsExpr:
@@ -336,6 +350,10 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfLiteral(n, parent)
 	case *Load:
 		return c.copyOnRewriteRefOfLoad(n, parent)
+	case *LoadFields:
+		return c.copyOnRewriteRefOfLoadFields(n, parent)
+	case *LoadLines:
+		return c.copyOnRewriteRefOfLoadLines(n, parent)
 	case *LocateExpr:
 		return c.copyOnRewriteRefOfLocateExpr(n, parent)
 	case *LockOption:
@@ -452,12 +470,16 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfRenameTable(n, parent)
 	case *RenameTableName:
 		return c.copyOnRewriteRefOfRenameTableName(n, parent)
+	case *ReturnStatement:
+		return c.copyOnRewriteRefOfReturnStatement(n, parent)
 	case *RevertMigration:
 		return c.copyOnRewriteRefOfRevertMigration(n, parent)
 	case *Rollback:
 		return c.copyOnRewriteRefOfRollback(n, parent)
 	case RootNode:
 		return c.copyOnRewriteRootNode(n, parent)
+	case *RoutineCharacteristic:
+		return c.copyOnRewriteRefOfRoutineCharacteristic(n, parent)
 	case *RowAlias:
 		return c.copyOnRewriteRefOfRowAlias(n, parent)
 	case *SRollback:
@@ -540,6 +562,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfTablespaceOperation(n, parent)
 	case *TimestampDiffExpr:
 		return c.copyOnRewriteRefOfTimestampDiffExpr(n, parent)
+	case *TriggerOrder:
+		return c.copyOnRewriteRefOfTriggerOrder(n, parent)
 	case *TrimFuncExpr:
 		return c.copyOnRewriteRefOfTrimFuncExpr(n, parent)
 	case *TruncateTable:
@@ -1838,6 +1862,88 @@ func (c *cow) copyOnRewriteRefOfCreateDatabase(n *CreateDatabase, parent SQLNode
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfCreateEvent(n *CreateEvent, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Name, changedName := c.copyOnRewriteTableName(n.Name, n)
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
+		_Definer, changedDefiner := c.copyOnRewriteRefOfDefiner(n.Definer, n)
+		_Schedule, changedSchedule := c.copyOnRewriteRefOfEventSchedule(n.Schedule, n)
+		_EventComment, changedEventComment := c.copyOnRewriteRefOfLiteral(n.EventComment, n)
+		_Body, changedBody := c.copyOnRewriteCompoundStatement(n.Body, n)
+		if changedName || changedComments || changedDefiner || changedSchedule || changedEventComment || changedBody {
+			res := *n
+			res.Name, _ = _Name.(TableName)
+			res.Comments, _ = _Comments.(*ParsedComments)
+			res.Definer, _ = _Definer.(*Definer)
+			res.Schedule, _ = _Schedule.(*EventSchedule)
+			res.EventComment, _ = _EventComment.(*Literal)
+			res.Body, _ = _Body.(CompoundStatement)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
+func (c *cow) copyOnRewriteRefOfCreateFunction(n *CreateFunction, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Name, changedName := c.copyOnRewriteTableName(n.Name, n)
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
+		_Definer, changedDefiner := c.copyOnRewriteRefOfDefiner(n.Definer, n)
+		var changedParams bool
+		_Params := make([]*ProcParameter, len(n.Params))
+		for x, el := range n.Params {
+			this, changed := c.copyOnRewriteRefOfProcParameter(el, n)
+			_Params[x] = this.(*ProcParameter)
+			if changed {
+				changedParams = true
+			}
+		}
+		_Returns, changedReturns := c.copyOnRewriteRefOfColumnType(n.Returns, n)
+		var changedCharacteristics bool
+		_Characteristics := make([]*RoutineCharacteristic, len(n.Characteristics))
+		for x, el := range n.Characteristics {
+			this, changed := c.copyOnRewriteRefOfRoutineCharacteristic(el, n)
+			_Characteristics[x] = this.(*RoutineCharacteristic)
+			if changed {
+				changedCharacteristics = true
+			}
+		}
+		_Body, changedBody := c.copyOnRewriteCompoundStatement(n.Body, n)
+		if changedName || changedComments || changedDefiner || changedParams || changedReturns || changedCharacteristics || changedBody {
+			res := *n
+			res.Name, _ = _Name.(TableName)
+			res.Comments, _ = _Comments.(*ParsedComments)
+			res.Definer, _ = _Definer.(*Definer)
+			res.Params = _Params
+			res.Returns, _ = _Returns.(*ColumnType)
+			res.Characteristics = _Characteristics
+			res.Body, _ = _Body.(CompoundStatement)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfCreateProcedure(n *CreateProcedure, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -1906,6 +2012,38 @@ func (c *cow) copyOnRewriteRefOfCreateTable(n *CreateTable, parent SQLNode) (out
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfCreateTrigger(n *CreateTrigger, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Name, changedName := c.copyOnRewriteTableName(n.Name, n)
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
+		_Definer, changedDefiner := c.copyOnRewriteRefOfDefiner(n.Definer, n)
+		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
+		_Order, changedOrder := c.copyOnRewriteRefOfTriggerOrder(n.Order, n)
+		_Body, changedBody := c.copyOnRewriteCompoundStatement(n.Body, n)
+		if changedName || changedComments || changedDefiner || changedTable || changedOrder || changedBody {
+			res := *n
+			res.Name, _ = _Name.(TableName)
+			res.Comments, _ = _Comments.(*ParsedComments)
+			res.Definer, _ = _Definer.(*Definer)
+			res.Table, _ = _Table.(TableName)
+			res.Order, _ = _Order.(*TriggerOrder)
+			res.Body, _ = _Body.(CompoundStatement)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfCreateView(n *CreateView, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -2206,6 +2344,54 @@ func (c *cow) copyOnRewriteRefOfDropDatabase(n *DropDatabase, parent SQLNode) (o
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfDropEvent(n *DropEvent, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
+		_Name, changedName := c.copyOnRewriteTableName(n.Name, n)
+		if changedComments || changedName {
+			res := *n
+			res.Comments, _ = _Comments.(*ParsedComments)
+			res.Name, _ = _Name.(TableName)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
+func (c *cow) copyOnRewriteRefOfDropFunction(n *DropFunction, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
+		_Name, changedName := c.copyOnRewriteTableName(n.Name, n)
+		if changedComments || changedName {
+			res := *n
+			res.Comments, _ = _Comments.(*ParsedComments)
+			res.Name, _ = _Name.(TableName)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfDropKey(n *DropKey, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -2276,6 +2462,30 @@ func (c *cow) copyOnRewriteRefOfDropTable(n *DropTable, parent SQLNode) (out SQL
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfDropTrigger(n *DropTrigger, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Comments, changedComments := c.copyOnRewriteRefOfParsedComments(n.Comments, n)
+		_Name, changedName := c.copyOnRewriteTableName(n.Name, n)
+		if changedComments || changedName {
+			res := *n
+			res.Comments, _ = _Comments.(*ParsedComments)
+			res.Name, _ = _Name.(TableName)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfDropView(n *DropView, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -2324,6 +2534,34 @@ func (c *cow) copyOnRewriteRefOfElseIfBlock(n *ElseIfBlock, parent SQLNode) (out
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfEventSchedule(n *EventSchedule, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_At, changedAt := c.copyOnRewriteExpr(n.At, n)
+		_Every, changedEvery := c.copyOnRewriteExpr(n.Every, n)
+		_Starts, changedStarts := c.copyOnRewriteExpr(n.Starts, n)
+		_Ends, changedEnds := c.copyOnRewriteExpr(n.Ends, n)
+		if changedAt || changedEvery || changedStarts || changedEnds {
+			res := *n
+			res.At, _ = _At.(Expr)
+			res.Every, _ = _Every.(Expr)
+			res.Starts, _ = _Starts.(Expr)
+			res.Ends, _ = _Ends.(Expr)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfExecuteStmt(n *ExecuteStmt, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -4325,6 +4563,86 @@ func (c *cow) copyOnRewriteRefOfLoad(n *Load, parent SQLNode) (out SQLNode, chan
 	}
 	out = n
 	if c.pre == nil || c.pre(n, parent) {
+		_Table, changedTable := c.copyOnRewriteTableName(n.Table, n)
+		_Partitions, changedPartitions := c.copyOnRewritePartitions(n.Partitions, n)
+		_Fields, changedFields := c.copyOnRewriteRefOfLoadFields(n.Fields, n)
+		_Lines, changedLines := c.copyOnRewriteRefOfLoadLines(n.Lines, n)
+		_IgnoreLines, changedIgnoreLines := c.copyOnRewriteRefOfLiteral(n.IgnoreLines, n)
+		var changedColumns bool
+		_Columns := make([]Expr, len(n.Columns))
+		for x, el := range n.Columns {
+			this, changed := c.copyOnRewriteExpr(el, n)
+			_Columns[x] = this.(Expr)
+			if changed {
+				changedColumns = true
+			}
+		}
+		_SetExprs, changedSetExprs := c.copyOnRewriteUpdateExprs(n.SetExprs, n)
+		if changedTable || changedPartitions || changedFields || changedLines || changedIgnoreLines || changedColumns || changedSetExprs {
+			res := *n
+			res.Table, _ = _Table.(TableName)
+			res.Partitions, _ = _Partitions.(Partitions)
+			res.Fields, _ = _Fields.(*LoadFields)
+			res.Lines, _ = _Lines.(*LoadLines)
+			res.IgnoreLines, _ = _IgnoreLines.(*Literal)
+			res.Columns = _Columns
+			res.SetExprs, _ = _SetExprs.(UpdateExprs)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
+func (c *cow) copyOnRewriteRefOfLoadFields(n *LoadFields, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_TerminatedBy, changedTerminatedBy := c.copyOnRewriteRefOfLiteral(n.TerminatedBy, n)
+		_EnclosedBy, changedEnclosedBy := c.copyOnRewriteRefOfLiteral(n.EnclosedBy, n)
+		_EscapedBy, changedEscapedBy := c.copyOnRewriteRefOfLiteral(n.EscapedBy, n)
+		if changedTerminatedBy || changedEnclosedBy || changedEscapedBy {
+			res := *n
+			res.TerminatedBy, _ = _TerminatedBy.(*Literal)
+			res.EnclosedBy, _ = _EnclosedBy.(*Literal)
+			res.EscapedBy, _ = _EscapedBy.(*Literal)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
+func (c *cow) copyOnRewriteRefOfLoadLines(n *LoadLines, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_StartingBy, changedStartingBy := c.copyOnRewriteRefOfLiteral(n.StartingBy, n)
+		_TerminatedBy, changedTerminatedBy := c.copyOnRewriteRefOfLiteral(n.TerminatedBy, n)
+		if changedStartingBy || changedTerminatedBy {
+			res := *n
+			res.StartingBy, _ = _StartingBy.(*Literal)
+			res.TerminatedBy, _ = _TerminatedBy.(*Literal)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
 	}
 	if c.post != nil {
 		out, changed = c.postVisit(out, parent, changed)
@@ -5643,6 +5961,28 @@ func (c *cow) copyOnRewriteRefOfRenameTableName(n *RenameTableName, parent SQLNo
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfReturnStatement(n *ReturnStatement, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Expr, changedExpr := c.copyOnRewriteExpr(n.Expr, n)
+		if changedExpr {
+			res := *n
+			res.Expr, _ = _Expr.(Expr)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfRevertMigration(n *RevertMigration, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -5696,6 +6036,28 @@ func (c *cow) copyOnRewriteRootNode(n RootNode, parent SQLNode) (out SQLNode, ch
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfRoutineCharacteristic(n *RoutineCharacteristic, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Comment, changedComment := c.copyOnRewriteRefOfLiteral(n.Comment, n)
+		if changedComment {
+			res := *n
+			res.Comment, _ = _Comment.(*Literal)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfRowAlias(n *RowAlias, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -6668,6 +7030,28 @@ func (c *cow) copyOnRewriteRefOfTimestampDiffExpr(n *TimestampDiffExpr, parent S
 	}
 	return
 }
+func (c *cow) copyOnRewriteRefOfTriggerOrder(n *TriggerOrder, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+		_Name, changedName := c.copyOnRewriteIdentifierCS(n.Name, n)
+		if changedName {
+			res := *n
+			res.Name, _ = _Name.(IdentifierCS)
+			out = &res
+			if c.cloned != nil {
+				c.cloned(n, out)
+			}
+			changed = true
+		}
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
 func (c *cow) copyOnRewriteRefOfTrimFuncExpr(n *TrimFuncExpr, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -7746,6 +8130,8 @@ func (c *cow) copyOnRewriteCompoundStatement(n CompoundStatement, parent SQLNode
 		return c.copyOnRewriteRefOfDeclareVar(n, parent)
 	case *IfStatement:
 		return c.copyOnRewriteRefOfIfStatement(n, parent)
+	case *ReturnStatement:
+		return c.copyOnRewriteRefOfReturnStatement(n, parent)
 	case *Signal:
 		return c.copyOnRewriteRefOfSignal(n, parent)
 	case *SingleStatement:
@@ -7800,16 +8186,28 @@ func (c *cow) copyOnRewriteDDLStatement(n DDLStatement, parent SQLNode) (out SQL
 		return c.copyOnRewriteRefOfAlterTable(n, parent)
 	case *AlterView:
 		return c.copyOnRewriteRefOfAlterView(n, parent)
+	case *CreateEvent:
+		return c.copyOnRewriteRefOfCreateEvent(n, parent)
+	case *CreateFunction:
+		return c.copyOnRewriteRefOfCreateFunction(n, parent)
 	case *CreateProcedure:
 		return c.copyOnRewriteRefOfCreateProcedure(n, parent)
 	case *CreateTable:
 		return c.copyOnRewriteRefOfCreateTable(n, parent)
+	case *CreateTrigger:
+		return c.copyOnRewriteRefOfCreateTrigger(n, parent)
 	case *CreateView:
 		return c.copyOnRewriteRefOfCreateView(n, parent)
+	case *DropEvent:
+		return c.copyOnRewriteRefOfDropEvent(n, parent)
+	case *DropFunction:
+		return c.copyOnRewriteRefOfDropFunction(n, parent)
 	case *DropProcedure:
 		return c.copyOnRewriteRefOfDropProcedure(n, parent)
 	case *DropTable:
 		return c.copyOnRewriteRefOfDropTable(n, parent)
+	case *DropTrigger:
+		return c.copyOnRewriteRefOfDropTrigger(n, parent)
 	case *DropView:
 		return c.copyOnRewriteRefOfDropView(n, parent)
 	case *RenameTable:
@@ -8226,10 +8624,16 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfCommit(n, parent)
 	case *CreateDatabase:
 		return c.copyOnRewriteRefOfCreateDatabase(n, parent)
+	case *CreateEvent:
+		return c.copyOnRewriteRefOfCreateEvent(n, parent)
+	case *CreateFunction:
+		return c.copyOnRewriteRefOfCreateFunction(n, parent)
 	case *CreateProcedure:
 		return c.copyOnRewriteRefOfCreateProcedure(n, parent)
 	case *CreateTable:
 		return c.copyOnRewriteRefOfCreateTable(n, parent)
+	case *CreateTrigger:
+		return c.copyOnRewriteRefOfCreateTrigger(n, parent)
 	case *CreateView:
 		return c.copyOnRewriteRefOfCreateView(n, parent)
 	case *DeallocateStmt:
@@ -8238,10 +8642,16 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfDelete(n, parent)
 	case *DropDatabase:
 		return c.copyOnRewriteRefOfDropDatabase(n, parent)
+	case *DropEvent:
+		return c.copyOnRewriteRefOfDropEvent(n, parent)
+	case *DropFunction:
+		return c.copyOnRewriteRefOfDropFunction(n, parent)
 	case *DropProcedure:
 		return c.copyOnRewriteRefOfDropProcedure(n, parent)
 	case *DropTable:
 		return c.copyOnRewriteRefOfDropTable(n, parent)
+	case *DropTrigger:
+		return c.copyOnRewriteRefOfDropTrigger(n, parent)
 	case *DropView:
 		return c.copyOnRewriteRefOfDropView(n, parent)
 	case *ExecuteStmt:
@@ -350,6 +350,18 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfCreateDatabase(a, b)
+	case *CreateEvent:
+		b, ok := inB.(*CreateEvent)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateEvent(a, b)
+	case *CreateFunction:
+		b, ok := inB.(*CreateFunction)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateFunction(a, b)
 	case *CreateProcedure:
 		b, ok := inB.(*CreateProcedure)
 		if !ok {
@@ -362,6 +374,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfCreateTable(a, b)
+	case *CreateTrigger:
+		b, ok := inB.(*CreateTrigger)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateTrigger(a, b)
 	case *CreateView:
 		b, ok := inB.(*CreateView)
 		if !ok {
@@ -434,6 +452,18 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfDropDatabase(a, b)
+	case *DropEvent:
+		b, ok := inB.(*DropEvent)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropEvent(a, b)
+	case *DropFunction:
+		b, ok := inB.(*DropFunction)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropFunction(a, b)
 	case *DropKey:
 		b, ok := inB.(*DropKey)
 		if !ok {
@@ -452,6 +482,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfDropTable(a, b)
+	case *DropTrigger:
+		b, ok := inB.(*DropTrigger)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropTrigger(a, b)
 	case *DropView:
 		b, ok := inB.(*DropView)
 		if !ok {
@@ -464,6 +500,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfElseIfBlock(a, b)
+	case *EventSchedule:
+		b, ok := inB.(*EventSchedule)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfEventSchedule(a, b)
 	case *ExecuteStmt:
 		b, ok := inB.(*ExecuteStmt)
 		if !ok {
@@ -968,6 +1010,18 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfLoad(a, b)
+	case *LoadFields:
+		b, ok := inB.(*LoadFields)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfLoadFields(a, b)
+	case *LoadLines:
+		b, ok := inB.(*LoadLines)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfLoadLines(a, b)
 	case *LocateExpr:
 		b, ok := inB.(*LocateExpr)
 		if !ok {
@@ -1316,6 +1370,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfRenameTableName(a, b)
+	case *ReturnStatement:
+		b, ok := inB.(*ReturnStatement)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfReturnStatement(a, b)
 	case *RevertMigration:
 		b, ok := inB.(*RevertMigration)
 		if !ok {
@@ -1334,6 +1394,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RootNode(a, b)
+	case *RoutineCharacteristic:
+		b, ok := inB.(*RoutineCharacteristic)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfRoutineCharacteristic(a, b)
 	case *RowAlias:
 		b, ok := inB.(*RowAlias)
 		if !ok {
@@ -1580,6 +1646,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfTimestampDiffExpr(a, b)
+	case *TriggerOrder:
+		b, ok := inB.(*TriggerOrder)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfTriggerOrder(a, b)
 	case *TrimFuncExpr:
 		b, ok := inB.(*TrimFuncExpr)
 		if !ok {
@@ -2445,6 +2517,43 @@ func (cmp *Comparator) RefOfCreateDatabase(a, b *CreateDatabase) bool {
 		cmp.SliceOfDatabaseOption(a.CreateOptions, b.CreateOptions)
 }
 
+// RefOfCreateEvent does deep equals between the two objects.
+func (cmp *Comparator) RefOfCreateEvent(a, b *CreateEvent) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IfNotExists == b.IfNotExists &&
+		cmp.TableName(a.Name, b.Name) &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
+		cmp.RefOfDefiner(a.Definer, b.Definer) &&
+		cmp.RefOfEventSchedule(a.Schedule, b.Schedule) &&
+		a.OnCompletion == b.OnCompletion &&
+		a.Status == b.Status &&
+		cmp.RefOfLiteral(a.EventComment, b.EventComment) &&
+		cmp.CompoundStatement(a.Body, b.Body)
+}
+
+// RefOfCreateFunction does deep equals between the two objects.
+func (cmp *Comparator) RefOfCreateFunction(a, b *CreateFunction) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IfNotExists == b.IfNotExists &&
+		cmp.TableName(a.Name, b.Name) &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
+		cmp.RefOfDefiner(a.Definer, b.Definer) &&
+		cmp.SliceOfRefOfProcParameter(a.Params, b.Params) &&
+		cmp.RefOfColumnType(a.Returns, b.Returns) &&
+		cmp.SliceOfRefOfRoutineCharacteristic(a.Characteristics, b.Characteristics) &&
+		cmp.CompoundStatement(a.Body, b.Body)
+}
+
 // RefOfCreateProcedure does deep equals between the two objects.
 func (cmp *Comparator) RefOfCreateProcedure(a, b *CreateProcedure) bool {
 	if a == b {
@@ -2480,6 +2589,25 @@ func (cmp *Comparator) RefOfCreateTable(a, b *CreateTable) bool {
 		cmp.TableStatement(a.Select, b.Select)
 }
 
+// RefOfCreateTrigger does deep equals between the two objects.
+func (cmp *Comparator) RefOfCreateTrigger(a, b *CreateTrigger) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IfNotExists == b.IfNotExists &&
+		cmp.TableName(a.Name, b.Name) &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
+		cmp.RefOfDefiner(a.Definer, b.Definer) &&
+		a.Time == b.Time &&
+		a.Event == b.Event &&
+		cmp.TableName(a.Table, b.Table) &&
+		cmp.RefOfTriggerOrder(a.Order, b.Order) &&
+		cmp.CompoundStatement(a.Body, b.Body)
+}
+
 // RefOfCreateView does deep equals between the two objects.
 func (cmp *Comparator) RefOfCreateView(a, b *CreateView) bool {
 	if a == b {
@@ -2638,6 +2766,32 @@ func (cmp *Comparator) RefOfDropDatabase(a, b *DropDatabase) bool {
 		cmp.IdentifierCS(a.DBName, b.DBName)
 }
 
+// RefOfDropEvent does deep equals between the two objects.
+func (cmp *Comparator) RefOfDropEvent(a, b *DropEvent) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IfExists == b.IfExists &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
+		cmp.TableName(a.Name, b.Name)
+}
+
+// RefOfDropFunction does deep equals between the two objects.
+func (cmp *Comparator) RefOfDropFunction(a, b *DropFunction) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IfExists == b.IfExists &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
+		cmp.TableName(a.Name, b.Name)
+}
+
 // RefOfDropKey does deep equals between the two objects.
 func (cmp *Comparator) RefOfDropKey(a, b *DropKey) bool {
 	if a == b {
@@ -2677,6 +2831,19 @@ func (cmp *Comparator) RefOfDropTable(a, b *DropTable) bool {
 		cmp.RefOfParsedComments(a.Comments, b.Comments)
 }
 
+// RefOfDropTrigger does deep equals between the two objects.
+func (cmp *Comparator) RefOfDropTrigger(a, b *DropTrigger) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IfExists == b.IfExists &&
+		cmp.RefOfParsedComments(a.Comments, b.Comments) &&
+		cmp.TableName(a.Name, b.Name)
+}
+
 // RefOfDropView does deep equals between the two objects.
 func (cmp *Comparator) RefOfDropView(a, b *DropView) bool {
 	if a == b {
@@ -2702,6 +2869,21 @@ func (cmp *Comparator) RefOfElseIfBlock(a, b *ElseIfBlock) bool {
 		cmp.RefOfCompoundStatements(a.ThenStatements, b.ThenStatements)
 }
 
+// RefOfEventSchedule does deep equals between the two objects.
+func (cmp *Comparator) RefOfEventSchedule(a, b *EventSchedule) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.Expr(a.At, b.At) &&
+		cmp.Expr(a.Every, b.Every) &&
+		a.Unit == b.Unit &&
+		cmp.Expr(a.Starts, b.Starts) &&
+		cmp.Expr(a.Ends, b.Ends)
+}
+
 // RefOfExecuteStmt does deep equals between the two objects.
 func (cmp *Comparator) RefOfExecuteStmt(a, b *ExecuteStmt) bool {
 	if a == b {
@@ -3728,7 +3910,45 @@ func (cmp *Comparator) RefOfLoad(a, b *Load) bool {
 	if a == nil || b == nil {
 		return false
 	}
-	return true
+	return a.LowPriority == b.LowPriority &&
+		a.Concurrent == b.Concurrent &&
+		a.Local == b.Local &&
+		a.FileName == b.FileName &&
+		a.IgnoreOrReplace == b.IgnoreOrReplace &&
+		cmp.TableName(a.Table, b.Table) &&
+		cmp.Partitions(a.Partitions, b.Partitions) &&
+		cmp.ColumnCharset(a.Charset, b.Charset) &&
+		cmp.RefOfLoadFields(a.Fields, b.Fields) &&
+		cmp.RefOfLoadLines(a.Lines, b.Lines) &&
+		cmp.RefOfLiteral(a.IgnoreLines, b.IgnoreLines) &&
+		cmp.SliceOfExpr(a.Columns, b.Columns) &&
+		cmp.UpdateExprs(a.SetExprs, b.SetExprs)
+}
+
+// RefOfLoadFields does deep equals between the two objects.
+func (cmp *Comparator) RefOfLoadFields(a, b *LoadFields) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Optionally == b.Optionally &&
+		cmp.RefOfLiteral(a.TerminatedBy, b.TerminatedBy) &&
+		cmp.RefOfLiteral(a.EnclosedBy, b.EnclosedBy) &&
+		cmp.RefOfLiteral(a.EscapedBy, b.EscapedBy)
+}
+
+// RefOfLoadLines does deep equals between the two objects.
+func (cmp *Comparator) RefOfLoadLines(a, b *LoadLines) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.RefOfLiteral(a.StartingBy, b.StartingBy) &&
+		cmp.RefOfLiteral(a.TerminatedBy, b.TerminatedBy)
 }
 
 // RefOfLocateExpr does deep equals between the two objects.
@@ -4435,6 +4655,17 @@ func (cmp *Comparator) RefOfRenameTableName(a, b *RenameTableName) bool {
 	return cmp.TableName(a.Table, b.Table)
 }
 
+// RefOfReturnStatement does deep equals between the two objects.
+func (cmp *Comparator) RefOfReturnStatement(a, b *ReturnStatement) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return cmp.Expr(a.Expr, b.Expr)
+}
+
 // RefOfRevertMigration does deep equals between the two objects.
 func (cmp *Comparator) RefOfRevertMigration(a, b *RevertMigration) bool {
 	if a == b {
@@ -4463,6 +4694,18 @@ func (cmp *Comparator) RootNode(a, b RootNode) bool {
 	return cmp.SQLNode(a.SQLNode, b.SQLNode)
 }
 
+// RefOfRoutineCharacteristic does deep equals between the two objects.
+func (cmp *Comparator) RefOfRoutineCharacteristic(a, b *RoutineCharacteristic) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Type == b.Type &&
+		cmp.RefOfLiteral(a.Comment, b.Comment)
+}
+
 // RefOfRowAlias does deep equals between the two objects.
 func (cmp *Comparator) RefOfRowAlias(a, b *RowAlias) bool {
 	if a == b {
@@ -4986,6 +5229,18 @@ func (cmp *Comparator) RefOfTimestampDiffExpr(a, b *TimestampDiffExpr) bool {
 		a.Unit == b.Unit
 }
 
+// RefOfTriggerOrder does deep equals between the two objects.
+func (cmp *Comparator) RefOfTriggerOrder(a, b *TriggerOrder) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Precedes == b.Precedes &&
+		cmp.IdentifierCS(a.Name, b.Name)
+}
+
 // RefOfTrimFuncExpr does deep equals between the two objects.
 func (cmp *Comparator) RefOfTrimFuncExpr(a, b *TrimFuncExpr) bool {
 	if a == b {
@@ -6214,6 +6469,12 @@ func (cmp *Comparator) CompoundStatement(inA, inB CompoundStatement) bool {
 			return false
 		}
 		return cmp.RefOfIfStatement(a, b)
+	case *ReturnStatement:
+		b, ok := inB.(*ReturnStatement)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfReturnStatement(a, b)
 	case *Signal:
 		b, ok := inB.(*Signal)
 		if !ok {
@@ -6313,6 +6574,18 @@ func (cmp *Comparator) DDLStatement(inA, inB DDLStatement) bool {
 			return false
 		}
 		return cmp.RefOfAlterView(a, b)
+	case *CreateEvent:
+		b, ok := inB.(*CreateEvent)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateEvent(a, b)
+	case *CreateFunction:
+		b, ok := inB.(*CreateFunction)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateFunction(a, b)
 	case *CreateProcedure:
 		b, ok := inB.(*CreateProcedure)
 		if !ok {
@@ -6325,12 +6598,30 @@ func (cmp *Comparator) DDLStatement(inA, inB DDLStatement) bool {
 			return false
 		}
 		return cmp.RefOfCreateTable(a, b)
+	case *CreateTrigger:
+		b, ok := inB.(*CreateTrigger)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateTrigger(a, b)
 	case *CreateView:
 		b, ok := inB.(*CreateView)
 		if !ok {
 			return false
 		}
 		return cmp.RefOfCreateView(a, b)
+	case *DropEvent:
+		b, ok := inB.(*DropEvent)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropEvent(a, b)
+	case *DropFunction:
+		b, ok := inB.(*DropFunction)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropFunction(a, b)
 	case *DropProcedure:
 		b, ok := inB.(*DropProcedure)
 		if !ok {
@@ -6343,6 +6634,12 @@ func (cmp *Comparator) DDLStatement(inA, inB DDLStatement) bool {
 			return false
 		}
 		return cmp.RefOfDropTable(a, b)
+	case *DropTrigger:
+		b, ok := inB.(*DropTrigger)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropTrigger(a, b)
 	case *DropView:
 		b, ok := inB.(*DropView)
 		if !ok {
@@ -7402,6 +7699,18 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfCreateDatabase(a, b)
+	case *CreateEvent:
+		b, ok := inB.(*CreateEvent)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateEvent(a, b)
+	case *CreateFunction:
+		b, ok := inB.(*CreateFunction)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateFunction(a, b)
 	case *CreateProcedure:
 		b, ok := inB.(*CreateProcedure)
 		if !ok {
@@ -7414,6 +7723,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfCreateTable(a, b)
+	case *CreateTrigger:
+		b, ok := inB.(*CreateTrigger)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfCreateTrigger(a, b)
 	case *CreateView:
 		b, ok := inB.(*CreateView)
 		if !ok {
@@ -7438,6 +7753,18 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfDropDatabase(a, b)
+	case *DropEvent:
+		b, ok := inB.(*DropEvent)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropEvent(a, b)
+	case *DropFunction:
+		b, ok := inB.(*DropFunction)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropFunction(a, b)
 	case *DropProcedure:
 		b, ok := inB.(*DropProcedure)
 		if !ok {
@@ -7450,6 +7777,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfDropTable(a, b)
+	case *DropTrigger:
+		b, ok := inB.(*DropTrigger)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfDropTrigger(a, b)
 	case *DropView:
 		b, ok := inB.(*DropView)
 		if !ok {
@@ -7910,6 +8243,19 @@ func (cmp *Comparator) SliceOfRefOfProcParameter(a, b []*ProcParameter) bool {
 	return true
 }
 
+// SliceOfRefOfRoutineCharacteristic does deep equals between the two objects.
+func (cmp *Comparator) SliceOfRefOfRoutineCharacteristic(a, b []*RoutineCharacteristic) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if !cmp.RefOfRoutineCharacteristic(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // SliceOfHandlerCondition does deep equals between the two objects.
 func (cmp *Comparator) SliceOfHandlerCondition(a, b []HandlerCondition) bool {
 	if len(a) != len(b) {
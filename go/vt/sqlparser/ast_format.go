@@ -393,6 +393,129 @@ func (node *DropProcedure) Format(buf *TrackedBuffer) {
 	buf.astPrintf(node, "%s %vprocedure %s%v", DropStr, node.Comments, exists, node.Name)
 }
 
+// Format formats the node.
+func (node *CreateTrigger) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "create %v", node.Comments)
+	if node.Definer != nil {
+		buf.astPrintf(node, "definer = %v ", node.Definer)
+	}
+	buf.literal("trigger ")
+	if node.IfNotExists {
+		buf.literal("if not exists ")
+	}
+	buf.astPrintf(node, "%v %s %s on %v for each row ", node.Name, node.Time.ToString(), node.Event.ToString(), node.Table)
+	if node.Order != nil {
+		buf.astPrintf(node, "%v ", node.Order)
+	}
+	buf.astPrintf(node, "%v", node.Body)
+}
+
+// Format formats the node.
+func (node *TriggerOrder) Format(buf *TrackedBuffer) {
+	if node.Precedes {
+		buf.astPrintf(node, "precedes %v", node.Name)
+		return
+	}
+	buf.astPrintf(node, "follows %v", node.Name)
+}
+
+// Format formats the node.
+func (node *DropTrigger) Format(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.astPrintf(node, "%s %vtrigger %s%v", DropStr, node.Comments, exists, node.Name)
+}
+
+// Format formats the node.
+func (node *CreateFunction) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "create %v", node.Comments)
+	if node.Definer != nil {
+		buf.astPrintf(node, "definer = %v ", node.Definer)
+	}
+	buf.literal("function ")
+	if node.IfNotExists {
+		buf.literal("if not exists ")
+	}
+	buf.astPrintf(node, "%v (", node.Name)
+	prefix := ""
+	for _, param := range node.Params {
+		buf.astPrintf(node, "%s%v %v", prefix, param.Name, param.Type)
+		prefix = ", "
+	}
+	buf.astPrintf(node, ") returns %v ", node.Returns)
+	for _, characteristic := range node.Characteristics {
+		buf.astPrintf(node, "%v ", characteristic)
+	}
+	buf.astPrintf(node, "%v", node.Body)
+}
+
+// Format formats the node.
+func (node *RoutineCharacteristic) Format(buf *TrackedBuffer) {
+	if node.Type == CommentCharacteristic {
+		buf.astPrintf(node, "comment %v", node.Comment)
+		return
+	}
+	buf.literal(node.Type.ToString())
+}
+
+// Format formats the node.
+func (node *DropFunction) Format(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.astPrintf(node, "%s %vfunction %s%v", DropStr, node.Comments, exists, node.Name)
+}
+
+// Format formats the node.
+func (node *CreateEvent) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "create %v", node.Comments)
+	if node.Definer != nil {
+		buf.astPrintf(node, "definer = %v ", node.Definer)
+	}
+	buf.literal("event ")
+	if node.IfNotExists {
+		buf.literal("if not exists ")
+	}
+	buf.astPrintf(node, "%v on schedule %v ", node.Name, node.Schedule)
+	if node.OnCompletion != DefaultOnCompletion {
+		buf.astPrintf(node, "%s ", node.OnCompletion.ToString())
+	}
+	if node.Status != DefaultEventStatus {
+		buf.astPrintf(node, "%s ", node.Status.ToString())
+	}
+	if node.EventComment != nil {
+		buf.astPrintf(node, "comment %v ", node.EventComment)
+	}
+	buf.astPrintf(node, "do %v", node.Body)
+}
+
+// Format formats the node.
+func (node *EventSchedule) Format(buf *TrackedBuffer) {
+	if node.At != nil {
+		buf.astPrintf(node, "at %v", node.At)
+		return
+	}
+	buf.astPrintf(node, "every %v %#s", node.Every, node.Unit.ToString())
+	if node.Starts != nil {
+		buf.astPrintf(node, " starts %v", node.Starts)
+	}
+	if node.Ends != nil {
+		buf.astPrintf(node, " ends %v", node.Ends)
+	}
+}
+
+// Format formats the node.
+func (node *DropEvent) Format(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.astPrintf(node, "%s %vevent %s%v", DropStr, node.Comments, exists, node.Name)
+}
+
 // Format formats the node.
 func (pp *ProcParameter) Format(buf *TrackedBuffer) {
 	buf.astPrintf(pp, "%s %v %v", pp.Mode.ToString(), pp.Name, pp.Type)
@@ -474,6 +597,11 @@ func (s *Signal) Format(buf *TrackedBuffer) {
 	buf.literal(";")
 }
 
+// Format formats the node.
+func (rs *ReturnStatement) Format(buf *TrackedBuffer) {
+	buf.astPrintf(rs, "return %v;", rs.Expr)
+}
+
 // Format formats the node.
 func (s *SignalSet) Format(buf *TrackedBuffer) {
 	buf.astPrintf(s, "%s = %v", s.ConditionName.ToString(), s.Value)
@@ -2314,7 +2442,79 @@ func (node IdentifierCS) Format(buf *TrackedBuffer) {
 
 // Format formats the node.
 func (node *Load) Format(buf *TrackedBuffer) {
-	buf.literal("AST node missing for Load type")
+	if node.Table.IsEmpty() {
+		buf.literal("AST node missing for Load type")
+		return
+	}
+	buf.literal("load data ")
+	if node.LowPriority {
+		buf.literal("low_priority ")
+	} else if node.Concurrent {
+		buf.literal("concurrent ")
+	}
+	if node.Local {
+		buf.literal("local ")
+	}
+	buf.astPrintf(node, "infile %#s", encodeSQLString(node.FileName))
+	switch node.IgnoreOrReplace {
+	case IgnoreType:
+		buf.literal(" ignore")
+	case ReplaceType:
+		buf.literal(" replace")
+	}
+	buf.astPrintf(node, " into table %v%v", node.Table, node.Partitions)
+	if node.Charset.Name != "" {
+		buf.astPrintf(node, " character set %#s", node.Charset.Name)
+	}
+	buf.astPrintf(node, "%v%v", node.Fields, node.Lines)
+	if node.IgnoreLines != nil {
+		buf.astPrintf(node, " ignore %v lines", node.IgnoreLines)
+	}
+	if len(node.Columns) > 0 {
+		prefix := " ("
+		for _, col := range node.Columns {
+			buf.astPrintf(node, "%s%v", prefix, col)
+			prefix = ", "
+		}
+		buf.WriteByte(')')
+	}
+	if len(node.SetExprs) > 0 {
+		buf.astPrintf(node, " set %v", node.SetExprs)
+	}
+}
+
+// Format formats the node.
+func (node *LoadFields) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.literal(" fields")
+	if node.TerminatedBy != nil {
+		buf.astPrintf(node, " terminated by %v", node.TerminatedBy)
+	}
+	if node.EnclosedBy != nil {
+		if node.Optionally {
+			buf.literal(" optionally")
+		}
+		buf.astPrintf(node, " enclosed by %v", node.EnclosedBy)
+	}
+	if node.EscapedBy != nil {
+		buf.astPrintf(node, " escaped by %v", node.EscapedBy)
+	}
+}
+
+// Format formats the node.
+func (node *LoadLines) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.literal(" lines")
+	if node.StartingBy != nil {
+		buf.astPrintf(node, " starting by %v", node.StartingBy)
+	}
+	if node.TerminatedBy != nil {
+		buf.astPrintf(node, " terminated by %v", node.TerminatedBy)
+	}
 }
 
 // Format formats the node.
@@ -3197,6 +3397,8 @@ func (node *Variable) Format(buf *TrackedBuffer) {
 		buf.astPrintf(node, "@@%s.", node.Scope.ToString())
 	case NextTxScope:
 		buf.literal("@@")
+	case TriggerNewScope, TriggerOldScope:
+		buf.astPrintf(node, "%s.", node.Scope.ToString())
 	}
 	buf.astPrintf(node, "%v", node.Name)
 }
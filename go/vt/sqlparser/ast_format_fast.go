@@ -541,6 +541,185 @@ func (node *DropProcedure) FormatFast(buf *TrackedBuffer) {
 	node.Name.FormatFast(buf)
 }
 
+// FormatFast formats the node.
+func (node *CreateTrigger) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("create ")
+	node.Comments.FormatFast(buf)
+	if node.Definer != nil {
+		buf.WriteString("definer = ")
+		node.Definer.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("trigger ")
+	if node.IfNotExists {
+		buf.WriteString("if not exists ")
+	}
+	node.Name.FormatFast(buf)
+	buf.WriteByte(' ')
+	buf.WriteString(node.Time.ToString())
+	buf.WriteByte(' ')
+	buf.WriteString(node.Event.ToString())
+	buf.WriteString(" on ")
+	node.Table.FormatFast(buf)
+	buf.WriteString(" for each row ")
+	if node.Order != nil {
+		node.Order.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	node.Body.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *TriggerOrder) FormatFast(buf *TrackedBuffer) {
+	if node.Precedes {
+		buf.WriteString("precedes ")
+		node.Name.FormatFast(buf)
+		return
+	}
+	buf.WriteString("follows ")
+	node.Name.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *DropTrigger) FormatFast(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.WriteString(DropStr)
+	buf.WriteByte(' ')
+	node.Comments.FormatFast(buf)
+	buf.WriteString("trigger ")
+	buf.WriteString(exists)
+	node.Name.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *CreateFunction) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("create ")
+	node.Comments.FormatFast(buf)
+	if node.Definer != nil {
+		buf.WriteString("definer = ")
+		node.Definer.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("function ")
+	if node.IfNotExists {
+		buf.WriteString("if not exists ")
+	}
+	node.Name.FormatFast(buf)
+	buf.WriteString(" (")
+	prefix := ""
+	for _, param := range node.Params {
+		buf.WriteString(prefix)
+		param.Name.FormatFast(buf)
+		buf.WriteByte(' ')
+		param.Type.FormatFast(buf)
+		prefix = ", "
+	}
+	buf.WriteString(") returns ")
+	node.Returns.FormatFast(buf)
+	buf.WriteByte(' ')
+	for _, characteristic := range node.Characteristics {
+		characteristic.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	node.Body.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *RoutineCharacteristic) FormatFast(buf *TrackedBuffer) {
+	if node.Type == CommentCharacteristic {
+		buf.WriteString("comment ")
+		node.Comment.FormatFast(buf)
+		return
+	}
+	buf.WriteString(node.Type.ToString())
+}
+
+// FormatFast formats the node.
+func (node *DropFunction) FormatFast(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.WriteString(DropStr)
+	buf.WriteByte(' ')
+	node.Comments.FormatFast(buf)
+	buf.WriteString("function ")
+	buf.WriteString(exists)
+	node.Name.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *CreateEvent) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("create ")
+	node.Comments.FormatFast(buf)
+	if node.Definer != nil {
+		buf.WriteString("definer = ")
+		node.Definer.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("event ")
+	if node.IfNotExists {
+		buf.WriteString("if not exists ")
+	}
+	node.Name.FormatFast(buf)
+	buf.WriteString(" on schedule ")
+	node.Schedule.FormatFast(buf)
+	buf.WriteByte(' ')
+	if node.OnCompletion != DefaultOnCompletion {
+		buf.WriteString(node.OnCompletion.ToString())
+		buf.WriteByte(' ')
+	}
+	if node.Status != DefaultEventStatus {
+		buf.WriteString(node.Status.ToString())
+		buf.WriteByte(' ')
+	}
+	if node.EventComment != nil {
+		buf.WriteString("comment ")
+		node.EventComment.FormatFast(buf)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("do ")
+	node.Body.FormatFast(buf)
+}
+
+// FormatFast formats the node.
+func (node *EventSchedule) FormatFast(buf *TrackedBuffer) {
+	if node.At != nil {
+		buf.WriteString("at ")
+		node.At.FormatFast(buf)
+		return
+	}
+	buf.WriteString("every ")
+	node.Every.FormatFast(buf)
+	buf.WriteByte(' ')
+	buf.WriteString(node.Unit.ToString())
+	if node.Starts != nil {
+		buf.WriteString(" starts ")
+		node.Starts.FormatFast(buf)
+	}
+	if node.Ends != nil {
+		buf.WriteString(" ends ")
+		node.Ends.FormatFast(buf)
+	}
+}
+
+// FormatFast formats the node.
+func (node *DropEvent) FormatFast(buf *TrackedBuffer) {
+	exists := ""
+	if node.IfExists {
+		exists = "if exists "
+	}
+	buf.WriteString(DropStr)
+	buf.WriteByte(' ')
+	node.Comments.FormatFast(buf)
+	buf.WriteString("event ")
+	buf.WriteString(exists)
+	node.Name.FormatFast(buf)
+}
+
 // FormatFast formats the node.
 func (pp *ProcParameter) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString(pp.Mode.ToString())
@@ -651,6 +830,13 @@ func (s *Signal) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString(";")
 }
 
+// FormatFast formats the node.
+func (rs *ReturnStatement) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("return ")
+	rs.Expr.FormatFast(buf)
+	buf.WriteByte(';')
+}
+
 // FormatFast formats the node.
 func (s *SignalSet) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString(s.ConditionName.ToString())
@@ -3039,7 +3225,93 @@ func (node IdentifierCS) FormatFast(buf *TrackedBuffer) {
 
 // FormatFast formats the node.
 func (node *Load) FormatFast(buf *TrackedBuffer) {
-	buf.WriteString("AST node missing for Load type")
+	if node.Table.IsEmpty() {
+		buf.WriteString("AST node missing for Load type")
+		return
+	}
+	buf.WriteString("load data ")
+	if node.LowPriority {
+		buf.WriteString("low_priority ")
+	} else if node.Concurrent {
+		buf.WriteString("concurrent ")
+	}
+	if node.Local {
+		buf.WriteString("local ")
+	}
+	buf.WriteString("infile ")
+	buf.WriteString(encodeSQLString(node.FileName))
+	switch node.IgnoreOrReplace {
+	case IgnoreType:
+		buf.WriteString(" ignore")
+	case ReplaceType:
+		buf.WriteString(" replace")
+	}
+	buf.WriteString(" into table ")
+	node.Table.FormatFast(buf)
+	node.Partitions.FormatFast(buf)
+	if node.Charset.Name != "" {
+		buf.WriteString(" character set ")
+		buf.WriteString(node.Charset.Name)
+	}
+	node.Fields.FormatFast(buf)
+	node.Lines.FormatFast(buf)
+	if node.IgnoreLines != nil {
+		buf.WriteString(" ignore ")
+		node.IgnoreLines.FormatFast(buf)
+		buf.WriteString(" lines")
+	}
+	if len(node.Columns) > 0 {
+		prefix := " ("
+		for _, col := range node.Columns {
+			buf.WriteString(prefix)
+			col.FormatFast(buf)
+			prefix = ", "
+		}
+		buf.WriteByte(')')
+	}
+	if len(node.SetExprs) > 0 {
+		buf.WriteString(" set ")
+		node.SetExprs.FormatFast(buf)
+	}
+}
+
+// FormatFast formats the node.
+func (node *LoadFields) FormatFast(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString(" fields")
+	if node.TerminatedBy != nil {
+		buf.WriteString(" terminated by ")
+		node.TerminatedBy.FormatFast(buf)
+	}
+	if node.EnclosedBy != nil {
+		if node.Optionally {
+			buf.WriteString(" optionally")
+		}
+		buf.WriteString(" enclosed by ")
+		node.EnclosedBy.FormatFast(buf)
+	}
+	if node.EscapedBy != nil {
+		buf.WriteString(" escaped by ")
+		node.EscapedBy.FormatFast(buf)
+	}
+}
+
+// FormatFast formats the node.
+func (node *LoadLines) FormatFast(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.WriteString(" lines")
+	if node.StartingBy != nil {
+		buf.WriteString(" starting by ")
+		node.StartingBy.FormatFast(buf)
+	}
+	if node.TerminatedBy != nil {
+		buf.WriteString(" terminated by ")
+		node.TerminatedBy.FormatFast(buf)
+	}
 }
 
 // FormatFast formats the node.
@@ -4187,6 +4459,9 @@ func (node *Variable) FormatFast(buf *TrackedBuffer) {
 		buf.WriteByte('.')
 	case NextTxScope:
 		buf.WriteString("@@")
+	case TriggerNewScope, TriggerOldScope:
+		buf.WriteString(node.Scope.ToString())
+		buf.WriteByte('.')
 	}
 	node.Name.FormatFast(buf)
 }
@@ -958,6 +958,18 @@ func NewSetVariable(str string, scope Scope) *Variable {
 	return &Variable{Name: createIdentifierCI(str), Scope: scope}
 }
 
+// NewTriggerRowVariable returns a variable for NEW.col_name or OLD.col_name, as assigned to
+// in the body of a trigger. It returns false if the qualifier is neither NEW nor OLD.
+func NewTriggerRowVariable(qualifier string, name IdentifierCI) (*Variable, bool) {
+	switch strings.ToLower(qualifier) {
+	case TriggerNewStr:
+		return &Variable{Name: name, Scope: TriggerNewScope}, true
+	case TriggerOldStr:
+		return &Variable{Name: name, Scope: TriggerOldScope}, true
+	}
+	return nil, false
+}
+
 // NewSetStatement returns a Set struct
 func NewSetStatement(comments *ParsedComments, exprs SetExprs) *Set {
 	return &Set{Exprs: exprs, Comments: comments}
@@ -1460,6 +1472,12 @@ func (action DDLAction) ToString() string {
 		return TruncateStr
 	case CreateProcedureAction:
 		return CreateProcStr
+	case CreateTriggerAction:
+		return CreateTrStr
+	case CreateFunctionAction:
+		return CreateFStr
+	case CreateEventAction:
+		return CreateEStr
 	case CreateVindexDDLAction:
 		return CreateVindexStr
 	case DropVindexDDLAction:
@@ -1499,6 +1517,84 @@ func (action ProcParameterMode) ToString() string {
 	}
 }
 
+// ToString returns the string associated with the TriggerTime Enum
+func (tt TriggerTime) ToString() string {
+	switch tt {
+	case BeforeTrigger:
+		return BeforeStr
+	case AfterTrigger:
+		return AfterStr
+	default:
+		return "Unknown TriggerTime"
+	}
+}
+
+// ToString returns the string associated with the TriggerEvent Enum
+func (te TriggerEvent) ToString() string {
+	switch te {
+	case InsertTrigger:
+		return InsertStr
+	case UpdateTrigger:
+		return UpdateStr
+	case DeleteTrigger:
+		return DeleteStr
+	default:
+		return "Unknown TriggerEvent"
+	}
+}
+
+// ToString returns the string associated with the RoutineCharacteristicType Enum
+func (rc RoutineCharacteristicType) ToString() string {
+	switch rc {
+	case CommentCharacteristic:
+		return CommentStr
+	case LanguageSQLCharacteristic:
+		return LanguageSQLStr
+	case DeterministicCharacteristic:
+		return DeterministicStr
+	case NotDeterministicCharacteristic:
+		return NotDeterministicStr
+	case ContainsSQLCharacteristic:
+		return ContainsSQLStr
+	case NoSQLCharacteristic:
+		return NoSQLStr
+	case ReadsSQLDataCharacteristic:
+		return ReadsSQLDataStr
+	case ModifiesSQLDataCharacteristic:
+		return ModifiesSQLDataStr
+	case SQLSecurityDefinerCharacteristic:
+		return SQLSecurityDefinerStr
+	case SQLSecurityInvokerCharacteristic:
+		return SQLSecurityInvokerStr
+	default:
+		return "Unknown RoutineCharacteristicType"
+	}
+}
+
+// ToString returns the string associated with the EventOnCompletion Enum
+func (oc EventOnCompletion) ToString() string {
+	switch oc {
+	case PreserveOnCompletion:
+		return PreserveStr
+	case NotPreserveOnCompletion:
+		return NotPreserveStr
+	default:
+		return ""
+	}
+}
+
+// ToString returns the string associated with the EventStatus Enum
+func (es EventStatus) ToString() string {
+	switch es {
+	case EnableEventStatus:
+		return EnableStr
+	case DisableEventStatus:
+		return DisableStr
+	default:
+		return ""
+	}
+}
+
 // ToString returns the type as a string
 func (scn SignalConditionName) ToString() string {
 	switch scn {
@@ -1556,6 +1652,10 @@ func (scope Scope) ToString() string {
 		return VitessMetadataStr
 	case VariableScope:
 		return VariableStr
+	case TriggerNewScope:
+		return TriggerNewStr
+	case TriggerOldScope:
+		return TriggerOldStr
 	case NoScope, NextTxScope:
 		return ""
 	default:
@@ -2419,6 +2519,85 @@ func formatAddress(address string) string {
 	return buf.String()
 }
 
+// GetOverClause returns the OVER clause of a window function, or of an aggregate
+// function that is used as a window function. For any other node it returns nil.
+func GetOverClause(node SQLNode) *OverClause {
+	switch node := node.(type) {
+	case *ArgumentLessWindowExpr:
+		return node.OverClause
+	case *FirstOrLastValueExpr:
+		return node.OverClause
+	case *NtileExpr:
+		return node.OverClause
+	case *NTHValueExpr:
+		return node.OverClause
+	case *LagLeadExpr:
+		return node.OverClause
+	case *Count:
+		return node.OverClause
+	case *CountStar:
+		return node.OverClause
+	case *Avg:
+		return node.OverClause
+	case *Max:
+		return node.OverClause
+	case *Min:
+		return node.OverClause
+	case *Sum:
+		return node.OverClause
+	case *BitAnd:
+		return node.OverClause
+	case *BitOr:
+		return node.OverClause
+	case *BitXor:
+		return node.OverClause
+	case *Std:
+		return node.OverClause
+	case *StdDev:
+		return node.OverClause
+	case *StdPop:
+		return node.OverClause
+	case *StdSamp:
+		return node.OverClause
+	case *VarPop:
+		return node.OverClause
+	case *VarSamp:
+		return node.OverClause
+	case *Variance:
+		return node.OverClause
+	case *JSONArrayAgg:
+		return node.OverClause
+	case *JSONObjectAgg:
+		return node.OverClause
+	}
+	return nil
+}
+
+// IsWindowFunc returns true if the node is a window function, or an aggregate function
+// with an OVER clause. Aggregate functions used this way do not group rows.
+func IsWindowFunc(node SQLNode) bool {
+	return GetOverClause(node) != nil
+}
+
+// ContainsWindowFunc returns true if the expression contains a window function
+func ContainsWindowFunc(e SQLNode) bool {
+	hasWindow := false
+	_ = Walk(func(node SQLNode) (kontinue bool, err error) {
+		switch node.(type) {
+		case *Offset:
+			return false, nil
+		case *Subquery:
+			return false, nil
+		}
+		if IsWindowFunc(node) {
+			hasWindow = true
+			return false, io.EOF
+		}
+		return true, nil
+	}, e)
+	return hasWindow
+}
+
 // ContainsAggregation returns true if the expression contains aggregation
 func ContainsAggregation(e SQLNode) bool {
 	hasAggregates := false
@@ -2429,6 +2608,10 @@ func ContainsAggregation(e SQLNode) bool {
 			// so we don't need to worry about aggregation in the original
 			return false, nil
 		case AggrFunc:
+			if IsWindowFunc(node) {
+				// aggregate functions used as window functions do not aggregate rows
+				return true, nil
+			}
 			hasAggregates = true
 			return false, io.EOF
 		}
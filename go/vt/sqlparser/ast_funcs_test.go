@@ -254,3 +254,28 @@ func TestKeyspaceToNonQualifiedTable(t *testing.T) {
 	AddKeyspace(stmt, "ks2")
 	require.Equal(t, "select col, col + (select 1 from ks2.t4) from ks.t join ks2.t2 join (select 1 from ks2.t3) as x where t.id = t2.id and x.id = t.id", String(stmt))
 }
+
+// TestContainsWindowFunc tests that window functions are told apart from aggregations.
+func TestContainsWindowFunc(t *testing.T) {
+	tcs := []struct {
+		expr   string
+		window bool
+		aggr   bool
+	}{
+		{expr: "row_number() over ()", window: true},
+		{expr: "lag(col, 2) over w + 1", window: true},
+		{expr: "sum(col) over (partition by id)", window: true},
+		{expr: "count(*) over (order by id) + max(col)", window: true, aggr: true},
+		{expr: "sum(col)", aggr: true},
+		{expr: "col + (select row_number() over () from t)"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := NewTestParser().ParseExpr(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.window, ContainsWindowFunc(expr))
+			assert.Equal(t, tc.aggr, ContainsAggregation(expr))
+		})
+	}
+}
@@ -110,6 +110,19 @@ const (
 	RefOfCountStarOverClause
 	RefOfCreateDatabaseComments
 	RefOfCreateDatabaseDBName
+	RefOfCreateEventName
+	RefOfCreateEventComments
+	RefOfCreateEventDefiner
+	RefOfCreateEventSchedule
+	RefOfCreateEventEventComment
+	RefOfCreateEventBody
+	RefOfCreateFunctionName
+	RefOfCreateFunctionComments
+	RefOfCreateFunctionDefiner
+	RefOfCreateFunctionParamsOffset
+	RefOfCreateFunctionReturns
+	RefOfCreateFunctionCharacteristicsOffset
+	RefOfCreateFunctionBody
 	RefOfCreateProcedureName
 	RefOfCreateProcedureComments
 	RefOfCreateProcedureDefiner
@@ -120,6 +133,12 @@ const (
 	RefOfCreateTableOptLike
 	RefOfCreateTableComments
 	RefOfCreateTableSelect
+	RefOfCreateTriggerName
+	RefOfCreateTriggerComments
+	RefOfCreateTriggerDefiner
+	RefOfCreateTriggerTable
+	RefOfCreateTriggerOrder
+	RefOfCreateTriggerBody
 	RefOfCreateViewViewName
 	RefOfCreateViewDefiner
 	RefOfCreateViewColumns
@@ -146,15 +165,25 @@ const (
 	RefOfDropColumnName
 	RefOfDropDatabaseComments
 	RefOfDropDatabaseDBName
+	RefOfDropEventComments
+	RefOfDropEventName
+	RefOfDropFunctionComments
+	RefOfDropFunctionName
 	RefOfDropKeyName
 	RefOfDropProcedureComments
 	RefOfDropProcedureName
 	RefOfDropTableFromTables
 	RefOfDropTableComments
+	RefOfDropTriggerComments
+	RefOfDropTriggerName
 	RefOfDropViewFromTables
 	RefOfDropViewComments
 	RefOfElseIfBlockSearchCondition
 	RefOfElseIfBlockThenStatements
+	RefOfEventScheduleAt
+	RefOfEventScheduleEvery
+	RefOfEventScheduleStarts
+	RefOfEventScheduleEnds
 	RefOfExecuteStmtName
 	RefOfExecuteStmtComments
 	RefOfExecuteStmtArgumentsOffset
@@ -307,6 +336,18 @@ const (
 	RefOfLineStringExprPointParamsOffset
 	RefOfLinestrPropertyFuncExprLinestring
 	RefOfLinestrPropertyFuncExprPropertyDefArg
+	RefOfLoadTable
+	RefOfLoadPartitions
+	RefOfLoadFields
+	RefOfLoadLines
+	RefOfLoadIgnoreLines
+	RefOfLoadColumnsOffset
+	RefOfLoadSetExprs
+	RefOfLoadFieldsTerminatedBy
+	RefOfLoadFieldsEnclosedBy
+	RefOfLoadFieldsEscapedBy
+	RefOfLoadLinesStartingBy
+	RefOfLoadLinesTerminatedBy
 	RefOfLocateExprSubStr
 	RefOfLocateExprStr
 	RefOfLocateExprPos
@@ -409,8 +450,10 @@ const (
 	RefOfRenameIndexOldName
 	RefOfRenameIndexNewName
 	RefOfRenameTableNameTable
+	RefOfReturnStatementExpr
 	RefOfRevertMigrationComments
 	RootNodeSQLNode
+	RefOfRoutineCharacteristicComment
 	RefOfRowAliasTableName
 	RefOfRowAliasColumns
 	RefOfSRollbackName
@@ -482,6 +525,7 @@ const (
 	RefOfTableSpecPartitionOption
 	RefOfTimestampDiffExprExpr1
 	RefOfTimestampDiffExprExpr2
+	RefOfTriggerOrderName
 	RefOfTrimFuncExprTrimArg
 	RefOfTrimFuncExprStringArg
 	RefOfTruncateTableTable
@@ -563,6 +607,7 @@ const (
 	RefOfColumnTypeOptionsSRID
 	SliceOfCompoundStatementOffset
 	SliceOfRefOfProcParameterOffset
+	SliceOfRefOfRoutineCharacteristicOffset
 	SliceOfHandlerConditionOffset
 	SliceOfTableExprOffset
 	SliceOfRefOfVariableOffset
@@ -782,6 +827,32 @@ func (s ASTStep) DebugString() string {
 		return "(*CreateDatabase).Comments"
 	case RefOfCreateDatabaseDBName:
 		return "(*CreateDatabase).DBName"
+	case RefOfCreateEventName:
+		return "(*CreateEvent).Name"
+	case RefOfCreateEventComments:
+		return "(*CreateEvent).Comments"
+	case RefOfCreateEventDefiner:
+		return "(*CreateEvent).Definer"
+	case RefOfCreateEventSchedule:
+		return "(*CreateEvent).Schedule"
+	case RefOfCreateEventEventComment:
+		return "(*CreateEvent).EventComment"
+	case RefOfCreateEventBody:
+		return "(*CreateEvent).Body"
+	case RefOfCreateFunctionName:
+		return "(*CreateFunction).Name"
+	case RefOfCreateFunctionComments:
+		return "(*CreateFunction).Comments"
+	case RefOfCreateFunctionDefiner:
+		return "(*CreateFunction).Definer"
+	case RefOfCreateFunctionParamsOffset:
+		return "(*CreateFunction).ParamsOffset"
+	case RefOfCreateFunctionReturns:
+		return "(*CreateFunction).Returns"
+	case RefOfCreateFunctionCharacteristicsOffset:
+		return "(*CreateFunction).CharacteristicsOffset"
+	case RefOfCreateFunctionBody:
+		return "(*CreateFunction).Body"
 	case RefOfCreateProcedureName:
 		return "(*CreateProcedure).Name"
 	case RefOfCreateProcedureComments:
@@ -802,6 +873,18 @@ func (s ASTStep) DebugString() string {
 		return "(*CreateTable).Comments"
 	case RefOfCreateTableSelect:
 		return "(*CreateTable).Select"
+	case RefOfCreateTriggerName:
+		return "(*CreateTrigger).Name"
+	case RefOfCreateTriggerComments:
+		return "(*CreateTrigger).Comments"
+	case RefOfCreateTriggerDefiner:
+		return "(*CreateTrigger).Definer"
+	case RefOfCreateTriggerTable:
+		return "(*CreateTrigger).Table"
+	case RefOfCreateTriggerOrder:
+		return "(*CreateTrigger).Order"
+	case RefOfCreateTriggerBody:
+		return "(*CreateTrigger).Body"
 	case RefOfCreateViewViewName:
 		return "(*CreateView).ViewName"
 	case RefOfCreateViewDefiner:
@@ -854,6 +937,14 @@ func (s ASTStep) DebugString() string {
 		return "(*DropDatabase).Comments"
 	case RefOfDropDatabaseDBName:
 		return "(*DropDatabase).DBName"
+	case RefOfDropEventComments:
+		return "(*DropEvent).Comments"
+	case RefOfDropEventName:
+		return "(*DropEvent).Name"
+	case RefOfDropFunctionComments:
+		return "(*DropFunction).Comments"
+	case RefOfDropFunctionName:
+		return "(*DropFunction).Name"
 	case RefOfDropKeyName:
 		return "(*DropKey).Name"
 	case RefOfDropProcedureComments:
@@ -864,6 +955,10 @@ func (s ASTStep) DebugString() string {
 		return "(*DropTable).FromTables"
 	case RefOfDropTableComments:
 		return "(*DropTable).Comments"
+	case RefOfDropTriggerComments:
+		return "(*DropTrigger).Comments"
+	case RefOfDropTriggerName:
+		return "(*DropTrigger).Name"
 	case RefOfDropViewFromTables:
 		return "(*DropView).FromTables"
 	case RefOfDropViewComments:
@@ -872,6 +967,14 @@ func (s ASTStep) DebugString() string {
 		return "(*ElseIfBlock).SearchCondition"
 	case RefOfElseIfBlockThenStatements:
 		return "(*ElseIfBlock).ThenStatements"
+	case RefOfEventScheduleAt:
+		return "(*EventSchedule).At"
+	case RefOfEventScheduleEvery:
+		return "(*EventSchedule).Every"
+	case RefOfEventScheduleStarts:
+		return "(*EventSchedule).Starts"
+	case RefOfEventScheduleEnds:
+		return "(*EventSchedule).Ends"
 	case RefOfExecuteStmtName:
 		return "(*ExecuteStmt).Name"
 	case RefOfExecuteStmtComments:
@@ -1176,6 +1279,30 @@ func (s ASTStep) DebugString() string {
 		return "(*LinestrPropertyFuncExpr).Linestring"
 	case RefOfLinestrPropertyFuncExprPropertyDefArg:
 		return "(*LinestrPropertyFuncExpr).PropertyDefArg"
+	case RefOfLoadTable:
+		return "(*Load).Table"
+	case RefOfLoadPartitions:
+		return "(*Load).Partitions"
+	case RefOfLoadFields:
+		return "(*Load).Fields"
+	case RefOfLoadLines:
+		return "(*Load).Lines"
+	case RefOfLoadIgnoreLines:
+		return "(*Load).IgnoreLines"
+	case RefOfLoadColumnsOffset:
+		return "(*Load).ColumnsOffset"
+	case RefOfLoadSetExprs:
+		return "(*Load).SetExprs"
+	case RefOfLoadFieldsTerminatedBy:
+		return "(*LoadFields).TerminatedBy"
+	case RefOfLoadFieldsEnclosedBy:
+		return "(*LoadFields).EnclosedBy"
+	case RefOfLoadFieldsEscapedBy:
+		return "(*LoadFields).EscapedBy"
+	case RefOfLoadLinesStartingBy:
+		return "(*LoadLines).StartingBy"
+	case RefOfLoadLinesTerminatedBy:
+		return "(*LoadLines).TerminatedBy"
 	case RefOfLocateExprSubStr:
 		return "(*LocateExpr).SubStr"
 	case RefOfLocateExprStr:
@@ -1380,10 +1507,14 @@ func (s ASTStep) DebugString() string {
 		return "(*RenameIndex).NewName"
 	case RefOfRenameTableNameTable:
 		return "(*RenameTableName).Table"
+	case RefOfReturnStatementExpr:
+		return "(*ReturnStatement).Expr"
 	case RefOfRevertMigrationComments:
 		return "(*RevertMigration).Comments"
 	case RootNodeSQLNode:
 		return "(RootNode).SQLNode"
+	case RefOfRoutineCharacteristicComment:
+		return "(*RoutineCharacteristic).Comment"
 	case RefOfRowAliasTableName:
 		return "(*RowAlias).TableName"
 	case RefOfRowAliasColumns:
@@ -1526,6 +1657,8 @@ func (s ASTStep) DebugString() string {
 		return "(*TimestampDiffExpr).Expr1"
 	case RefOfTimestampDiffExprExpr2:
 		return "(*TimestampDiffExpr).Expr2"
+	case RefOfTriggerOrderName:
+		return "(*TriggerOrder).Name"
 	case RefOfTrimFuncExprTrimArg:
 		return "(*TrimFuncExpr).TrimArg"
 	case RefOfTrimFuncExprStringArg:
@@ -1688,6 +1821,8 @@ func (s ASTStep) DebugString() string {
 		return "([]CompoundStatement)[]Offset"
 	case SliceOfRefOfProcParameterOffset:
 		return "([]*ProcParameter)[]Offset"
+	case SliceOfRefOfRoutineCharacteristicOffset:
+		return "([]*RoutineCharacteristic)[]Offset"
 	case SliceOfHandlerConditionOffset:
 		return "([]HandlerCondition)[]Offset"
 	case SliceOfTableExprOffset:
@@ -1964,6 +2099,36 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*CreateDatabase).Comments
 		case RefOfCreateDatabaseDBName:
 			node = node.(*CreateDatabase).DBName
+		case RefOfCreateEventName:
+			node = node.(*CreateEvent).Name
+		case RefOfCreateEventComments:
+			node = node.(*CreateEvent).Comments
+		case RefOfCreateEventDefiner:
+			node = node.(*CreateEvent).Definer
+		case RefOfCreateEventSchedule:
+			node = node.(*CreateEvent).Schedule
+		case RefOfCreateEventEventComment:
+			node = node.(*CreateEvent).EventComment
+		case RefOfCreateEventBody:
+			node = node.(*CreateEvent).Body
+		case RefOfCreateFunctionName:
+			node = node.(*CreateFunction).Name
+		case RefOfCreateFunctionComments:
+			node = node.(*CreateFunction).Comments
+		case RefOfCreateFunctionDefiner:
+			node = node.(*CreateFunction).Definer
+		case RefOfCreateFunctionParamsOffset:
+			idx, bytesRead := path.nextPathOffset()
+			path = path[bytesRead:]
+			node = node.(*CreateFunction).Params[idx]
+		case RefOfCreateFunctionReturns:
+			node = node.(*CreateFunction).Returns
+		case RefOfCreateFunctionCharacteristicsOffset:
+			idx, bytesRead := path.nextPathOffset()
+			path = path[bytesRead:]
+			node = node.(*CreateFunction).Characteristics[idx]
+		case RefOfCreateFunctionBody:
+			node = node.(*CreateFunction).Body
 		case RefOfCreateProcedureName:
 			node = node.(*CreateProcedure).Name
 		case RefOfCreateProcedureComments:
@@ -1986,6 +2151,18 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*CreateTable).Comments
 		case RefOfCreateTableSelect:
 			node = node.(*CreateTable).Select
+		case RefOfCreateTriggerName:
+			node = node.(*CreateTrigger).Name
+		case RefOfCreateTriggerComments:
+			node = node.(*CreateTrigger).Comments
+		case RefOfCreateTriggerDefiner:
+			node = node.(*CreateTrigger).Definer
+		case RefOfCreateTriggerTable:
+			node = node.(*CreateTrigger).Table
+		case RefOfCreateTriggerOrder:
+			node = node.(*CreateTrigger).Order
+		case RefOfCreateTriggerBody:
+			node = node.(*CreateTrigger).Body
 		case RefOfCreateViewViewName:
 			node = node.(*CreateView).ViewName
 		case RefOfCreateViewDefiner:
@@ -2044,6 +2221,14 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*DropDatabase).Comments
 		case RefOfDropDatabaseDBName:
 			node = node.(*DropDatabase).DBName
+		case RefOfDropEventComments:
+			node = node.(*DropEvent).Comments
+		case RefOfDropEventName:
+			node = node.(*DropEvent).Name
+		case RefOfDropFunctionComments:
+			node = node.(*DropFunction).Comments
+		case RefOfDropFunctionName:
+			node = node.(*DropFunction).Name
 		case RefOfDropKeyName:
 			node = node.(*DropKey).Name
 		case RefOfDropProcedureComments:
@@ -2054,6 +2239,10 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*DropTable).FromTables
 		case RefOfDropTableComments:
 			node = node.(*DropTable).Comments
+		case RefOfDropTriggerComments:
+			node = node.(*DropTrigger).Comments
+		case RefOfDropTriggerName:
+			node = node.(*DropTrigger).Name
 		case RefOfDropViewFromTables:
 			node = node.(*DropView).FromTables
 		case RefOfDropViewComments:
@@ -2062,6 +2251,14 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*ElseIfBlock).SearchCondition
 		case RefOfElseIfBlockThenStatements:
 			node = node.(*ElseIfBlock).ThenStatements
+		case RefOfEventScheduleAt:
+			node = node.(*EventSchedule).At
+		case RefOfEventScheduleEvery:
+			node = node.(*EventSchedule).Every
+		case RefOfEventScheduleStarts:
+			node = node.(*EventSchedule).Starts
+		case RefOfEventScheduleEnds:
+			node = node.(*EventSchedule).Ends
 		case RefOfExecuteStmtName:
 			node = node.(*ExecuteStmt).Name
 		case RefOfExecuteStmtComments:
@@ -2406,6 +2603,32 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*LinestrPropertyFuncExpr).Linestring
 		case RefOfLinestrPropertyFuncExprPropertyDefArg:
 			node = node.(*LinestrPropertyFuncExpr).PropertyDefArg
+		case RefOfLoadTable:
+			node = node.(*Load).Table
+		case RefOfLoadPartitions:
+			node = node.(*Load).Partitions
+		case RefOfLoadFields:
+			node = node.(*Load).Fields
+		case RefOfLoadLines:
+			node = node.(*Load).Lines
+		case RefOfLoadIgnoreLines:
+			node = node.(*Load).IgnoreLines
+		case RefOfLoadColumnsOffset:
+			idx, bytesRead := path.nextPathOffset()
+			path = path[bytesRead:]
+			node = node.(*Load).Columns[idx]
+		case RefOfLoadSetExprs:
+			node = node.(*Load).SetExprs
+		case RefOfLoadFieldsTerminatedBy:
+			node = node.(*LoadFields).TerminatedBy
+		case RefOfLoadFieldsEnclosedBy:
+			node = node.(*LoadFields).EnclosedBy
+		case RefOfLoadFieldsEscapedBy:
+			node = node.(*LoadFields).EscapedBy
+		case RefOfLoadLinesStartingBy:
+			node = node.(*LoadLines).StartingBy
+		case RefOfLoadLinesTerminatedBy:
+			node = node.(*LoadLines).TerminatedBy
 		case RefOfLocateExprSubStr:
 			node = node.(*LocateExpr).SubStr
 		case RefOfLocateExprStr:
@@ -2632,10 +2855,14 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*RenameIndex).NewName
 		case RefOfRenameTableNameTable:
 			node = node.(*RenameTableName).Table
+		case RefOfReturnStatementExpr:
+			node = node.(*ReturnStatement).Expr
 		case RefOfRevertMigrationComments:
 			node = node.(*RevertMigration).Comments
 		case RootNodeSQLNode:
 			node = node.(RootNode).SQLNode
+		case RefOfRoutineCharacteristicComment:
+			node = node.(*RoutineCharacteristic).Comment
 		case RefOfRowAliasTableName:
 			node = node.(*RowAlias).TableName
 		case RefOfRowAliasColumns:
@@ -2800,6 +3027,8 @@ func GetNodeFromPath(node SQLNode, path ASTPath) SQLNode {
 			node = node.(*TimestampDiffExpr).Expr1
 		case RefOfTimestampDiffExprExpr2:
 			node = node.(*TimestampDiffExpr).Expr2
+		case RefOfTriggerOrderName:
+			node = node.(*TriggerOrder).Name
 		case RefOfTrimFuncExprTrimArg:
 			node = node.(*TrimFuncExpr).TrimArg
 		case RefOfTrimFuncExprStringArg:
@@ -131,10 +131,16 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfCountStar(parent, node, replacer)
 	case *CreateDatabase:
 		return a.rewriteRefOfCreateDatabase(parent, node, replacer)
+	case *CreateEvent:
+		return a.rewriteRefOfCreateEvent(parent, node, replacer)
+	case *CreateFunction:
+		return a.rewriteRefOfCreateFunction(parent, node, replacer)
 	case *CreateProcedure:
 		return a.rewriteRefOfCreateProcedure(parent, node, replacer)
 	case *CreateTable:
 		return a.rewriteRefOfCreateTable(parent, node, replacer)
+	case *CreateTrigger:
+		return a.rewriteRefOfCreateTrigger(parent, node, replacer)
 	case *CreateView:
 		return a.rewriteRefOfCreateView(parent, node, replacer)
 	case *CurTimeFuncExpr:
@@ -159,16 +165,24 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfDropColumn(parent, node, replacer)
 	case *DropDatabase:
 		return a.rewriteRefOfDropDatabase(parent, node, replacer)
+	case *DropEvent:
+		return a.rewriteRefOfDropEvent(parent, node, replacer)
+	case *DropFunction:
+		return a.rewriteRefOfDropFunction(parent, node, replacer)
 	case *DropKey:
 		return a.rewriteRefOfDropKey(parent, node, replacer)
 	case *DropProcedure:
 		return a.rewriteRefOfDropProcedure(parent, node, replacer)
 	case *DropTable:
 		return a.rewriteRefOfDropTable(parent, node, replacer)
+	case *DropTrigger:
+		return a.rewriteRefOfDropTrigger(parent, node, replacer)
 	case *DropView:
 		return a.rewriteRefOfDropView(parent, node, replacer)
 	case *ElseIfBlock:
 		return a.rewriteRefOfElseIfBlock(parent, node, replacer)
+	case *EventSchedule:
+		return a.rewriteRefOfEventSchedule(parent, node, replacer)
 	case *ExecuteStmt:
 		return a.rewriteRefOfExecuteStmt(parent, node, replacer)
 	case *ExistsExpr:
@@ -337,6 +351,10 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfLiteral(parent, node, replacer)
 	case *Load:
 		return a.rewriteRefOfLoad(parent, node, replacer)
+	case *LoadFields:
+		return a.rewriteRefOfLoadFields(parent, node, replacer)
+	case *LoadLines:
+		return a.rewriteRefOfLoadLines(parent, node, replacer)
 	case *LocateExpr:
 		return a.rewriteRefOfLocateExpr(parent, node, replacer)
 	case *LockOption:
@@ -453,12 +471,16 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfRenameTable(parent, node, replacer)
 	case *RenameTableName:
 		return a.rewriteRefOfRenameTableName(parent, node, replacer)
+	case *ReturnStatement:
+		return a.rewriteRefOfReturnStatement(parent, node, replacer)
 	case *RevertMigration:
 		return a.rewriteRefOfRevertMigration(parent, node, replacer)
 	case *Rollback:
 		return a.rewriteRefOfRollback(parent, node, replacer)
 	case RootNode:
 		return a.rewriteRootNode(parent, node, replacer)
+	case *RoutineCharacteristic:
+		return a.rewriteRefOfRoutineCharacteristic(parent, node, replacer)
 	case *RowAlias:
 		return a.rewriteRefOfRowAlias(parent, node, replacer)
 	case *SRollback:
@@ -541,6 +563,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfTablespaceOperation(parent, node, replacer)
 	case *TimestampDiffExpr:
 		return a.rewriteRefOfTimestampDiffExpr(parent, node, replacer)
+	case *TriggerOrder:
+		return a.rewriteRefOfTriggerOrder(parent, node, replacer)
 	case *TrimFuncExpr:
 		return a.rewriteRefOfTrimFuncExpr(parent, node, replacer)
 	case *TruncateTable:
@@ -3113,6 +3137,205 @@ func (a *application) rewriteRefOfCreateDatabase(parent SQLNode, node *CreateDat
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfCreateEvent(parent SQLNode, node *CreateEvent, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfCreateEventName))
+	}
+	if !a.rewriteTableName(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*CreateEvent).Name = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateEventComments))
+	}
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*CreateEvent).Comments = newNode.(*ParsedComments)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateEventDefiner))
+	}
+	if !a.rewriteRefOfDefiner(node, node.Definer, func(newNode, parent SQLNode) {
+		parent.(*CreateEvent).Definer = newNode.(*Definer)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateEventSchedule))
+	}
+	if !a.rewriteRefOfEventSchedule(node, node.Schedule, func(newNode, parent SQLNode) {
+		parent.(*CreateEvent).Schedule = newNode.(*EventSchedule)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateEventEventComment))
+	}
+	if !a.rewriteRefOfLiteral(node, node.EventComment, func(newNode, parent SQLNode) {
+		parent.(*CreateEvent).EventComment = newNode.(*Literal)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateEventBody))
+	}
+	if !a.rewriteCompoundStatement(node, node.Body, func(newNode, parent SQLNode) {
+		parent.(*CreateEvent).Body = newNode.(CompoundStatement)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfCreateFunction(parent SQLNode, node *CreateFunction, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfCreateFunctionName))
+	}
+	if !a.rewriteTableName(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*CreateFunction).Name = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateFunctionComments))
+	}
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*CreateFunction).Comments = newNode.(*ParsedComments)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateFunctionDefiner))
+	}
+	if !a.rewriteRefOfDefiner(node, node.Definer, func(newNode, parent SQLNode) {
+		parent.(*CreateFunction).Definer = newNode.(*Definer)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	for x, el := range node.Params {
+		if a.collectPaths {
+			if x == 0 {
+				a.cur.current.AddStepWithOffset(uint16(RefOfCreateFunctionParamsOffset))
+			} else {
+				a.cur.current.ChangeOffset(x)
+			}
+		}
+		if !a.rewriteRefOfProcParameter(node, el, func(idx int) replacerFunc {
+			return func(newNode, parent SQLNode) {
+				parent.(*CreateFunction).Params[idx] = newNode.(*ProcParameter)
+			}
+		}(x)) {
+			return false
+		}
+	}
+	if a.collectPaths && len(node.Params) > 0 {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateFunctionReturns))
+	}
+	if !a.rewriteRefOfColumnType(node, node.Returns, func(newNode, parent SQLNode) {
+		parent.(*CreateFunction).Returns = newNode.(*ColumnType)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	for x, el := range node.Characteristics {
+		if a.collectPaths {
+			if x == 0 {
+				a.cur.current.AddStepWithOffset(uint16(RefOfCreateFunctionCharacteristicsOffset))
+			} else {
+				a.cur.current.ChangeOffset(x)
+			}
+		}
+		if !a.rewriteRefOfRoutineCharacteristic(node, el, func(idx int) replacerFunc {
+			return func(newNode, parent SQLNode) {
+				parent.(*CreateFunction).Characteristics[idx] = newNode.(*RoutineCharacteristic)
+			}
+		}(x)) {
+			return false
+		}
+	}
+	if a.collectPaths && len(node.Characteristics) > 0 {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateFunctionBody))
+	}
+	if !a.rewriteCompoundStatement(node, node.Body, func(newNode, parent SQLNode) {
+		parent.(*CreateFunction).Body = newNode.(CompoundStatement)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfCreateProcedure(parent SQLNode, node *CreateProcedure, replacer replacerFunc) bool {
 	if node == nil {
@@ -3275,6 +3498,91 @@ func (a *application) rewriteRefOfCreateTable(parent SQLNode, node *CreateTable,
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfCreateTrigger(parent SQLNode, node *CreateTrigger, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfCreateTriggerName))
+	}
+	if !a.rewriteTableName(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*CreateTrigger).Name = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateTriggerComments))
+	}
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*CreateTrigger).Comments = newNode.(*ParsedComments)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateTriggerDefiner))
+	}
+	if !a.rewriteRefOfDefiner(node, node.Definer, func(newNode, parent SQLNode) {
+		parent.(*CreateTrigger).Definer = newNode.(*Definer)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateTriggerTable))
+	}
+	if !a.rewriteTableName(node, node.Table, func(newNode, parent SQLNode) {
+		parent.(*CreateTrigger).Table = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateTriggerOrder))
+	}
+	if !a.rewriteRefOfTriggerOrder(node, node.Order, func(newNode, parent SQLNode) {
+		parent.(*CreateTrigger).Order = newNode.(*TriggerOrder)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfCreateTriggerBody))
+	}
+	if !a.rewriteCompoundStatement(node, node.Body, func(newNode, parent SQLNode) {
+		parent.(*CreateTrigger).Body = newNode.(CompoundStatement)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfCreateView(parent SQLNode, node *CreateView, replacer replacerFunc) bool {
 	if node == nil {
@@ -3747,20 +4055,198 @@ func (a *application) rewriteRefOfDelete(parent SQLNode, node *Delete, replacer
 		return false
 	}
 	if a.collectPaths {
-		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfDeleteOrderBy))
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfDeleteOrderBy))
+	}
+	if !a.rewriteOrderBy(node, node.OrderBy, func(newNode, parent SQLNode) {
+		parent.(*Delete).OrderBy = newNode.(OrderBy)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfDeleteLimit))
+	}
+	if !a.rewriteRefOfLimit(node, node.Limit, func(newNode, parent SQLNode) {
+		parent.(*Delete).Limit = newNode.(*Limit)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfDerivedTable(parent SQLNode, node *DerivedTable, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfDerivedTableSelect))
+	}
+	if !a.rewriteTableStatement(node, node.Select, func(newNode, parent SQLNode) {
+		parent.(*DerivedTable).Select = newNode.(TableStatement)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfDropColumn(parent SQLNode, node *DropColumn, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfDropColumnName))
+	}
+	if !a.rewriteRefOfColName(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*DropColumn).Name = newNode.(*ColName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfDropDatabase(parent SQLNode, node *DropDatabase, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfDropDatabaseComments))
+	}
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*DropDatabase).Comments = newNode.(*ParsedComments)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfDropDatabaseDBName))
+	}
+	if !a.rewriteIdentifierCS(node, node.DBName, func(newNode, parent SQLNode) {
+		parent.(*DropDatabase).DBName = newNode.(IdentifierCS)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfDropEvent(parent SQLNode, node *DropEvent, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfDropEventComments))
 	}
-	if !a.rewriteOrderBy(node, node.OrderBy, func(newNode, parent SQLNode) {
-		parent.(*Delete).OrderBy = newNode.(OrderBy)
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*DropEvent).Comments = newNode.(*ParsedComments)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfDeleteLimit))
+		a.cur.current.AddStep(uint16(RefOfDropEventName))
 	}
-	if !a.rewriteRefOfLimit(node, node.Limit, func(newNode, parent SQLNode) {
-		parent.(*Delete).Limit = newNode.(*Limit)
+	if !a.rewriteTableName(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*DropEvent).Name = newNode.(TableName)
 	}) {
 		return false
 	}
@@ -3779,7 +4265,7 @@ func (a *application) rewriteRefOfDelete(parent SQLNode, node *Delete, replacer
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfDerivedTable(parent SQLNode, node *DerivedTable, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfDropFunction(parent SQLNode, node *DropFunction, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -3797,10 +4283,19 @@ func (a *application) rewriteRefOfDerivedTable(parent SQLNode, node *DerivedTabl
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfDerivedTableSelect))
+		a.cur.current.AddStep(uint16(RefOfDropFunctionComments))
 	}
-	if !a.rewriteTableStatement(node, node.Select, func(newNode, parent SQLNode) {
-		parent.(*DerivedTable).Select = newNode.(TableStatement)
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*DropFunction).Comments = newNode.(*ParsedComments)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfDropFunctionName))
+	}
+	if !a.rewriteTableName(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*DropFunction).Name = newNode.(TableName)
 	}) {
 		return false
 	}
@@ -3819,7 +4314,7 @@ func (a *application) rewriteRefOfDerivedTable(parent SQLNode, node *DerivedTabl
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfDropColumn(parent SQLNode, node *DropColumn, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfDropKey(parent SQLNode, node *DropKey, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -3837,10 +4332,10 @@ func (a *application) rewriteRefOfDropColumn(parent SQLNode, node *DropColumn, r
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfDropColumnName))
+		a.cur.current.AddStep(uint16(RefOfDropKeyName))
 	}
-	if !a.rewriteRefOfColName(node, node.Name, func(newNode, parent SQLNode) {
-		parent.(*DropColumn).Name = newNode.(*ColName)
+	if !a.rewriteIdentifierCI(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*DropKey).Name = newNode.(IdentifierCI)
 	}) {
 		return false
 	}
@@ -3859,7 +4354,7 @@ func (a *application) rewriteRefOfDropColumn(parent SQLNode, node *DropColumn, r
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfDropDatabase(parent SQLNode, node *DropDatabase, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfDropProcedure(parent SQLNode, node *DropProcedure, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -3877,19 +4372,19 @@ func (a *application) rewriteRefOfDropDatabase(parent SQLNode, node *DropDatabas
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfDropDatabaseComments))
+		a.cur.current.AddStep(uint16(RefOfDropProcedureComments))
 	}
 	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
-		parent.(*DropDatabase).Comments = newNode.(*ParsedComments)
+		parent.(*DropProcedure).Comments = newNode.(*ParsedComments)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfDropDatabaseDBName))
+		a.cur.current.AddStep(uint16(RefOfDropProcedureName))
 	}
-	if !a.rewriteIdentifierCS(node, node.DBName, func(newNode, parent SQLNode) {
-		parent.(*DropDatabase).DBName = newNode.(IdentifierCS)
+	if !a.rewriteTableName(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*DropProcedure).Name = newNode.(TableName)
 	}) {
 		return false
 	}
@@ -3908,7 +4403,7 @@ func (a *application) rewriteRefOfDropDatabase(parent SQLNode, node *DropDatabas
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfDropKey(parent SQLNode, node *DropKey, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfDropTable(parent SQLNode, node *DropTable, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -3926,10 +4421,19 @@ func (a *application) rewriteRefOfDropKey(parent SQLNode, node *DropKey, replace
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfDropKeyName))
+		a.cur.current.AddStep(uint16(RefOfDropTableFromTables))
 	}
-	if !a.rewriteIdentifierCI(node, node.Name, func(newNode, parent SQLNode) {
-		parent.(*DropKey).Name = newNode.(IdentifierCI)
+	if !a.rewriteTableNames(node, node.FromTables, func(newNode, parent SQLNode) {
+		parent.(*DropTable).FromTables = newNode.(TableNames)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfDropTableComments))
+	}
+	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
+		parent.(*DropTable).Comments = newNode.(*ParsedComments)
 	}) {
 		return false
 	}
@@ -3948,7 +4452,7 @@ func (a *application) rewriteRefOfDropKey(parent SQLNode, node *DropKey, replace
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfDropProcedure(parent SQLNode, node *DropProcedure, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfDropTrigger(parent SQLNode, node *DropTrigger, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -3966,19 +4470,19 @@ func (a *application) rewriteRefOfDropProcedure(parent SQLNode, node *DropProced
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfDropProcedureComments))
+		a.cur.current.AddStep(uint16(RefOfDropTriggerComments))
 	}
 	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
-		parent.(*DropProcedure).Comments = newNode.(*ParsedComments)
+		parent.(*DropTrigger).Comments = newNode.(*ParsedComments)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfDropProcedureName))
+		a.cur.current.AddStep(uint16(RefOfDropTriggerName))
 	}
 	if !a.rewriteTableName(node, node.Name, func(newNode, parent SQLNode) {
-		parent.(*DropProcedure).Name = newNode.(TableName)
+		parent.(*DropTrigger).Name = newNode.(TableName)
 	}) {
 		return false
 	}
@@ -3997,7 +4501,7 @@ func (a *application) rewriteRefOfDropProcedure(parent SQLNode, node *DropProced
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfDropTable(parent SQLNode, node *DropTable, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfDropView(parent SQLNode, node *DropView, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -4015,19 +4519,19 @@ func (a *application) rewriteRefOfDropTable(parent SQLNode, node *DropTable, rep
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfDropTableFromTables))
+		a.cur.current.AddStep(uint16(RefOfDropViewFromTables))
 	}
 	if !a.rewriteTableNames(node, node.FromTables, func(newNode, parent SQLNode) {
-		parent.(*DropTable).FromTables = newNode.(TableNames)
+		parent.(*DropView).FromTables = newNode.(TableNames)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfDropTableComments))
+		a.cur.current.AddStep(uint16(RefOfDropViewComments))
 	}
 	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
-		parent.(*DropTable).Comments = newNode.(*ParsedComments)
+		parent.(*DropView).Comments = newNode.(*ParsedComments)
 	}) {
 		return false
 	}
@@ -4046,7 +4550,7 @@ func (a *application) rewriteRefOfDropTable(parent SQLNode, node *DropTable, rep
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfDropView(parent SQLNode, node *DropView, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfElseIfBlock(parent SQLNode, node *ElseIfBlock, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -4064,19 +4568,19 @@ func (a *application) rewriteRefOfDropView(parent SQLNode, node *DropView, repla
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfDropViewFromTables))
+		a.cur.current.AddStep(uint16(RefOfElseIfBlockSearchCondition))
 	}
-	if !a.rewriteTableNames(node, node.FromTables, func(newNode, parent SQLNode) {
-		parent.(*DropView).FromTables = newNode.(TableNames)
+	if !a.rewriteExpr(node, node.SearchCondition, func(newNode, parent SQLNode) {
+		parent.(*ElseIfBlock).SearchCondition = newNode.(Expr)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfDropViewComments))
+		a.cur.current.AddStep(uint16(RefOfElseIfBlockThenStatements))
 	}
-	if !a.rewriteRefOfParsedComments(node, node.Comments, func(newNode, parent SQLNode) {
-		parent.(*DropView).Comments = newNode.(*ParsedComments)
+	if !a.rewriteRefOfCompoundStatements(node, node.ThenStatements, func(newNode, parent SQLNode) {
+		parent.(*ElseIfBlock).ThenStatements = newNode.(*CompoundStatements)
 	}) {
 		return false
 	}
@@ -4095,7 +4599,7 @@ func (a *application) rewriteRefOfDropView(parent SQLNode, node *DropView, repla
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfElseIfBlock(parent SQLNode, node *ElseIfBlock, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfEventSchedule(parent SQLNode, node *EventSchedule, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -4113,19 +4617,37 @@ func (a *application) rewriteRefOfElseIfBlock(parent SQLNode, node *ElseIfBlock,
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfElseIfBlockSearchCondition))
+		a.cur.current.AddStep(uint16(RefOfEventScheduleAt))
 	}
-	if !a.rewriteExpr(node, node.SearchCondition, func(newNode, parent SQLNode) {
-		parent.(*ElseIfBlock).SearchCondition = newNode.(Expr)
+	if !a.rewriteExpr(node, node.At, func(newNode, parent SQLNode) {
+		parent.(*EventSchedule).At = newNode.(Expr)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfElseIfBlockThenStatements))
+		a.cur.current.AddStep(uint16(RefOfEventScheduleEvery))
 	}
-	if !a.rewriteRefOfCompoundStatements(node, node.ThenStatements, func(newNode, parent SQLNode) {
-		parent.(*ElseIfBlock).ThenStatements = newNode.(*CompoundStatements)
+	if !a.rewriteExpr(node, node.Every, func(newNode, parent SQLNode) {
+		parent.(*EventSchedule).Every = newNode.(Expr)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfEventScheduleStarts))
+	}
+	if !a.rewriteExpr(node, node.Starts, func(newNode, parent SQLNode) {
+		parent.(*EventSchedule).Starts = newNode.(Expr)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfEventScheduleEnds))
+	}
+	if !a.rewriteExpr(node, node.Ends, func(newNode, parent SQLNode) {
+		parent.(*EventSchedule).Ends = newNode.(Expr)
 	}) {
 		return false
 	}
@@ -8026,17 +8548,163 @@ func (a *application) rewriteRefOfLagLeadExpr(parent SQLNode, node *LagLeadExpr,
 		a.cur.current.Pop()
 		a.cur.current.AddStep(uint16(RefOfLagLeadExprOverClause))
 	}
-	if !a.rewriteRefOfOverClause(node, node.OverClause, func(newNode, parent SQLNode) {
-		parent.(*LagLeadExpr).OverClause = newNode.(*OverClause)
+	if !a.rewriteRefOfOverClause(node, node.OverClause, func(newNode, parent SQLNode) {
+		parent.(*LagLeadExpr).OverClause = newNode.(*OverClause)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLagLeadExprNullTreatmentClause))
+	}
+	if !a.rewriteRefOfNullTreatmentClause(node, node.NullTreatmentClause, func(newNode, parent SQLNode) {
+		parent.(*LagLeadExpr).NullTreatmentClause = newNode.(*NullTreatmentClause)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfLimit(parent SQLNode, node *Limit, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfLimitOffset))
+	}
+	if !a.rewriteExpr(node, node.Offset, func(newNode, parent SQLNode) {
+		parent.(*Limit).Offset = newNode.(Expr)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLimitRowcount))
+	}
+	if !a.rewriteExpr(node, node.Rowcount, func(newNode, parent SQLNode) {
+		parent.(*Limit).Rowcount = newNode.(Expr)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfLineStringExpr(parent SQLNode, node *LineStringExpr, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	for x, el := range node.PointParams {
+		if a.collectPaths {
+			if x == 0 {
+				a.cur.current.AddStepWithOffset(uint16(RefOfLineStringExprPointParamsOffset))
+			} else {
+				a.cur.current.ChangeOffset(x)
+			}
+		}
+		if !a.rewriteExpr(node, el, func(idx int) replacerFunc {
+			return func(newNode, parent SQLNode) {
+				parent.(*LineStringExpr).PointParams[idx] = newNode.(Expr)
+			}
+		}(x)) {
+			return false
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfLinestrPropertyFuncExpr(parent SQLNode, node *LinestrPropertyFuncExpr, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfLinestrPropertyFuncExprLinestring))
+	}
+	if !a.rewriteExpr(node, node.Linestring, func(newNode, parent SQLNode) {
+		parent.(*LinestrPropertyFuncExpr).Linestring = newNode.(Expr)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfLagLeadExprNullTreatmentClause))
+		a.cur.current.AddStep(uint16(RefOfLinestrPropertyFuncExprPropertyDefArg))
 	}
-	if !a.rewriteRefOfNullTreatmentClause(node, node.NullTreatmentClause, func(newNode, parent SQLNode) {
-		parent.(*LagLeadExpr).NullTreatmentClause = newNode.(*NullTreatmentClause)
+	if !a.rewriteExpr(node, node.PropertyDefArg, func(newNode, parent SQLNode) {
+		parent.(*LinestrPropertyFuncExpr).PropertyDefArg = newNode.(Expr)
 	}) {
 		return false
 	}
@@ -8055,7 +8723,7 @@ func (a *application) rewriteRefOfLagLeadExpr(parent SQLNode, node *LagLeadExpr,
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfLimit(parent SQLNode, node *Limit, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfLiteral(parent SQLNode, node *Literal, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -8072,30 +8740,12 @@ func (a *application) rewriteRefOfLimit(parent SQLNode, node *Limit, replacer re
 			return true
 		}
 	}
-	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfLimitOffset))
-	}
-	if !a.rewriteExpr(node, node.Offset, func(newNode, parent SQLNode) {
-		parent.(*Limit).Offset = newNode.(Expr)
-	}) {
-		return false
-	}
-	if a.collectPaths {
-		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfLimitRowcount))
-	}
-	if !a.rewriteExpr(node, node.Rowcount, func(newNode, parent SQLNode) {
-		parent.(*Limit).Rowcount = newNode.(Expr)
-	}) {
-		return false
-	}
-	if a.collectPaths {
-		a.cur.current.Pop()
-	}
 	if a.post != nil {
-		a.cur.replacer = replacer
-		a.cur.parent = parent
-		a.cur.node = node
+		if a.pre == nil {
+			a.cur.replacer = replacer
+			a.cur.parent = parent
+			a.cur.node = node
+		}
 		if !a.post(&a.cur) {
 			return false
 		}
@@ -8104,7 +8754,7 @@ func (a *application) rewriteRefOfLimit(parent SQLNode, node *Limit, replacer re
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfLineStringExpr(parent SQLNode, node *LineStringExpr, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfLoad(parent SQLNode, node *Load, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -8121,22 +8771,78 @@ func (a *application) rewriteRefOfLineStringExpr(parent SQLNode, node *LineStrin
 			return true
 		}
 	}
-	for x, el := range node.PointParams {
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfLoadTable))
+	}
+	if !a.rewriteTableName(node, node.Table, func(newNode, parent SQLNode) {
+		parent.(*Load).Table = newNode.(TableName)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadPartitions))
+	}
+	if !a.rewritePartitions(node, node.Partitions, func(newNode, parent SQLNode) {
+		parent.(*Load).Partitions = newNode.(Partitions)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadFields))
+	}
+	if !a.rewriteRefOfLoadFields(node, node.Fields, func(newNode, parent SQLNode) {
+		parent.(*Load).Fields = newNode.(*LoadFields)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadLines))
+	}
+	if !a.rewriteRefOfLoadLines(node, node.Lines, func(newNode, parent SQLNode) {
+		parent.(*Load).Lines = newNode.(*LoadLines)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadIgnoreLines))
+	}
+	if !a.rewriteRefOfLiteral(node, node.IgnoreLines, func(newNode, parent SQLNode) {
+		parent.(*Load).IgnoreLines = newNode.(*Literal)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	for x, el := range node.Columns {
 		if a.collectPaths {
 			if x == 0 {
-				a.cur.current.AddStepWithOffset(uint16(RefOfLineStringExprPointParamsOffset))
+				a.cur.current.AddStepWithOffset(uint16(RefOfLoadColumnsOffset))
 			} else {
 				a.cur.current.ChangeOffset(x)
 			}
 		}
 		if !a.rewriteExpr(node, el, func(idx int) replacerFunc {
 			return func(newNode, parent SQLNode) {
-				parent.(*LineStringExpr).PointParams[idx] = newNode.(Expr)
+				parent.(*Load).Columns[idx] = newNode.(Expr)
 			}
 		}(x)) {
 			return false
 		}
 	}
+	if a.collectPaths && len(node.Columns) > 0 {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadSetExprs))
+	}
+	if !a.rewriteUpdateExprs(node, node.SetExprs, func(newNode, parent SQLNode) {
+		parent.(*Load).SetExprs = newNode.(UpdateExprs)
+	}) {
+		return false
+	}
 	if a.collectPaths {
 		a.cur.current.Pop()
 	}
@@ -8152,7 +8858,7 @@ func (a *application) rewriteRefOfLineStringExpr(parent SQLNode, node *LineStrin
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfLinestrPropertyFuncExpr(parent SQLNode, node *LinestrPropertyFuncExpr, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfLoadFields(parent SQLNode, node *LoadFields, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -8170,19 +8876,28 @@ func (a *application) rewriteRefOfLinestrPropertyFuncExpr(parent SQLNode, node *
 		}
 	}
 	if a.collectPaths {
-		a.cur.current.AddStep(uint16(RefOfLinestrPropertyFuncExprLinestring))
+		a.cur.current.AddStep(uint16(RefOfLoadFieldsTerminatedBy))
 	}
-	if !a.rewriteExpr(node, node.Linestring, func(newNode, parent SQLNode) {
-		parent.(*LinestrPropertyFuncExpr).Linestring = newNode.(Expr)
+	if !a.rewriteRefOfLiteral(node, node.TerminatedBy, func(newNode, parent SQLNode) {
+		parent.(*LoadFields).TerminatedBy = newNode.(*Literal)
 	}) {
 		return false
 	}
 	if a.collectPaths {
 		a.cur.current.Pop()
-		a.cur.current.AddStep(uint16(RefOfLinestrPropertyFuncExprPropertyDefArg))
+		a.cur.current.AddStep(uint16(RefOfLoadFieldsEnclosedBy))
 	}
-	if !a.rewriteExpr(node, node.PropertyDefArg, func(newNode, parent SQLNode) {
-		parent.(*LinestrPropertyFuncExpr).PropertyDefArg = newNode.(Expr)
+	if !a.rewriteRefOfLiteral(node, node.EnclosedBy, func(newNode, parent SQLNode) {
+		parent.(*LoadFields).EnclosedBy = newNode.(*Literal)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadFieldsEscapedBy))
+	}
+	if !a.rewriteRefOfLiteral(node, node.EscapedBy, func(newNode, parent SQLNode) {
+		parent.(*LoadFields).EscapedBy = newNode.(*Literal)
 	}) {
 		return false
 	}
@@ -8201,7 +8916,7 @@ func (a *application) rewriteRefOfLinestrPropertyFuncExpr(parent SQLNode, node *
 }
 
 // Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfLiteral(parent SQLNode, node *Literal, replacer replacerFunc) bool {
+func (a *application) rewriteRefOfLoadLines(parent SQLNode, node *LoadLines, replacer replacerFunc) bool {
 	if node == nil {
 		return true
 	}
@@ -8218,43 +8933,30 @@ func (a *application) rewriteRefOfLiteral(parent SQLNode, node *Literal, replace
 			return true
 		}
 	}
-	if a.post != nil {
-		if a.pre == nil {
-			a.cur.replacer = replacer
-			a.cur.parent = parent
-			a.cur.node = node
-		}
-		if !a.post(&a.cur) {
-			return false
-		}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfLoadLinesStartingBy))
 	}
-	return true
-}
-
-// Function Generation Source: PtrToStructMethod
-func (a *application) rewriteRefOfLoad(parent SQLNode, node *Load, replacer replacerFunc) bool {
-	if node == nil {
-		return true
+	if !a.rewriteRefOfLiteral(node, node.StartingBy, func(newNode, parent SQLNode) {
+		parent.(*LoadLines).StartingBy = newNode.(*Literal)
+	}) {
+		return false
 	}
-	if a.pre != nil {
+	if a.collectPaths {
+		a.cur.current.Pop()
+		a.cur.current.AddStep(uint16(RefOfLoadLinesTerminatedBy))
+	}
+	if !a.rewriteRefOfLiteral(node, node.TerminatedBy, func(newNode, parent SQLNode) {
+		parent.(*LoadLines).TerminatedBy = newNode.(*Literal)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
 		a.cur.replacer = replacer
 		a.cur.parent = parent
 		a.cur.node = node
-		kontinue := !a.pre(&a.cur)
-		if a.cur.revisit {
-			a.cur.revisit = false
-			return a.rewriteSQLNode(parent, a.cur.node, replacer)
-		}
-		if kontinue {
-			return true
-		}
-	}
-	if a.post != nil {
-		if a.pre == nil {
-			a.cur.replacer = replacer
-			a.cur.parent = parent
-			a.cur.node = node
-		}
 		if !a.post(&a.cur) {
 			return false
 		}
@@ -11008,6 +11710,46 @@ func (a *application) rewriteRefOfRenameTableName(parent SQLNode, node *RenameTa
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfReturnStatement(parent SQLNode, node *ReturnStatement, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfReturnStatementExpr))
+	}
+	if !a.rewriteExpr(node, node.Expr, func(newNode, parent SQLNode) {
+		parent.(*ReturnStatement).Expr = newNode.(Expr)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfRevertMigration(parent SQLNode, node *RevertMigration, replacer replacerFunc) bool {
 	if node == nil {
@@ -11116,6 +11858,46 @@ func (a *application) rewriteRootNode(parent SQLNode, node RootNode, replacer re
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfRoutineCharacteristic(parent SQLNode, node *RoutineCharacteristic, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfRoutineCharacteristicComment))
+	}
+	if !a.rewriteRefOfLiteral(node, node.Comment, func(newNode, parent SQLNode) {
+		parent.(*RoutineCharacteristic).Comment = newNode.(*Literal)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfRowAlias(parent SQLNode, node *RowAlias, replacer replacerFunc) bool {
 	if node == nil {
@@ -13119,6 +13901,46 @@ func (a *application) rewriteRefOfTimestampDiffExpr(parent SQLNode, node *Timest
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfTriggerOrder(parent SQLNode, node *TriggerOrder, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.collectPaths {
+		a.cur.current.AddStep(uint16(RefOfTriggerOrderName))
+	}
+	if !a.rewriteIdentifierCS(node, node.Name, func(newNode, parent SQLNode) {
+		parent.(*TriggerOrder).Name = newNode.(IdentifierCS)
+	}) {
+		return false
+	}
+	if a.collectPaths {
+		a.cur.current.Pop()
+	}
+	if a.post != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfTrimFuncExpr(parent SQLNode, node *TrimFuncExpr, replacer replacerFunc) bool {
 	if node == nil {
@@ -15059,6 +15881,8 @@ func (a *application) rewriteCompoundStatement(parent SQLNode, node CompoundStat
 		return a.rewriteRefOfDeclareVar(parent, node, replacer)
 	case *IfStatement:
 		return a.rewriteRefOfIfStatement(parent, node, replacer)
+	case *ReturnStatement:
+		return a.rewriteRefOfReturnStatement(parent, node, replacer)
 	case *Signal:
 		return a.rewriteRefOfSignal(parent, node, replacer)
 	case *SingleStatement:
@@ -15119,16 +15943,28 @@ func (a *application) rewriteDDLStatement(parent SQLNode, node DDLStatement, rep
 		return a.rewriteRefOfAlterTable(parent, node, replacer)
 	case *AlterView:
 		return a.rewriteRefOfAlterView(parent, node, replacer)
+	case *CreateEvent:
+		return a.rewriteRefOfCreateEvent(parent, node, replacer)
+	case *CreateFunction:
+		return a.rewriteRefOfCreateFunction(parent, node, replacer)
 	case *CreateProcedure:
 		return a.rewriteRefOfCreateProcedure(parent, node, replacer)
 	case *CreateTable:
 		return a.rewriteRefOfCreateTable(parent, node, replacer)
+	case *CreateTrigger:
+		return a.rewriteRefOfCreateTrigger(parent, node, replacer)
 	case *CreateView:
 		return a.rewriteRefOfCreateView(parent, node, replacer)
+	case *DropEvent:
+		return a.rewriteRefOfDropEvent(parent, node, replacer)
+	case *DropFunction:
+		return a.rewriteRefOfDropFunction(parent, node, replacer)
 	case *DropProcedure:
 		return a.rewriteRefOfDropProcedure(parent, node, replacer)
 	case *DropTable:
 		return a.rewriteRefOfDropTable(parent, node, replacer)
+	case *DropTrigger:
+		return a.rewriteRefOfDropTrigger(parent, node, replacer)
 	case *DropView:
 		return a.rewriteRefOfDropView(parent, node, replacer)
 	case *RenameTable:
@@ -15563,10 +16399,16 @@ func (a *application) rewriteStatement(parent SQLNode, node Statement, replacer
 		return a.rewriteRefOfCommit(parent, node, replacer)
 	case *CreateDatabase:
 		return a.rewriteRefOfCreateDatabase(parent, node, replacer)
+	case *CreateEvent:
+		return a.rewriteRefOfCreateEvent(parent, node, replacer)
+	case *CreateFunction:
+		return a.rewriteRefOfCreateFunction(parent, node, replacer)
 	case *CreateProcedure:
 		return a.rewriteRefOfCreateProcedure(parent, node, replacer)
 	case *CreateTable:
 		return a.rewriteRefOfCreateTable(parent, node, replacer)
+	case *CreateTrigger:
+		return a.rewriteRefOfCreateTrigger(parent, node, replacer)
 	case *CreateView:
 		return a.rewriteRefOfCreateView(parent, node, replacer)
 	case *DeallocateStmt:
@@ -15575,10 +16417,16 @@ func (a *application) rewriteStatement(parent SQLNode, node Statement, replacer
 		return a.rewriteRefOfDelete(parent, node, replacer)
 	case *DropDatabase:
 		return a.rewriteRefOfDropDatabase(parent, node, replacer)
+	case *DropEvent:
+		return a.rewriteRefOfDropEvent(parent, node, replacer)
+	case *DropFunction:
+		return a.rewriteRefOfDropFunction(parent, node, replacer)
 	case *DropProcedure:
 		return a.rewriteRefOfDropProcedure(parent, node, replacer)
 	case *DropTable:
 		return a.rewriteRefOfDropTable(parent, node, replacer)
+	case *DropTrigger:
+		return a.rewriteRefOfDropTrigger(parent, node, replacer)
 	case *DropView:
 		return a.rewriteRefOfDropView(parent, node, replacer)
 	case *ExecuteStmt:
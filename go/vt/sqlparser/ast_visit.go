@@ -130,10 +130,16 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfCountStar(in, f)
 	case *CreateDatabase:
 		return VisitRefOfCreateDatabase(in, f)
+	case *CreateEvent:
+		return VisitRefOfCreateEvent(in, f)
+	case *CreateFunction:
+		return VisitRefOfCreateFunction(in, f)
 	case *CreateProcedure:
 		return VisitRefOfCreateProcedure(in, f)
 	case *CreateTable:
 		return VisitRefOfCreateTable(in, f)
+	case *CreateTrigger:
+		return VisitRefOfCreateTrigger(in, f)
 	case *CreateView:
 		return VisitRefOfCreateView(in, f)
 	case *CurTimeFuncExpr:
@@ -158,16 +164,24 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfDropColumn(in, f)
 	case *DropDatabase:
 		return VisitRefOfDropDatabase(in, f)
+	case *DropEvent:
+		return VisitRefOfDropEvent(in, f)
+	case *DropFunction:
+		return VisitRefOfDropFunction(in, f)
 	case *DropKey:
 		return VisitRefOfDropKey(in, f)
 	case *DropProcedure:
 		return VisitRefOfDropProcedure(in, f)
 	case *DropTable:
 		return VisitRefOfDropTable(in, f)
+	case *DropTrigger:
+		return VisitRefOfDropTrigger(in, f)
 	case *DropView:
 		return VisitRefOfDropView(in, f)
 	case *ElseIfBlock:
 		return VisitRefOfElseIfBlock(in, f)
+	case *EventSchedule:
+		return VisitRefOfEventSchedule(in, f)
 	case *ExecuteStmt:
 		return VisitRefOfExecuteStmt(in, f)
 	case *ExistsExpr:
@@ -336,6 +350,10 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfLiteral(in, f)
 	case *Load:
 		return VisitRefOfLoad(in, f)
+	case *LoadFields:
+		return VisitRefOfLoadFields(in, f)
+	case *LoadLines:
+		return VisitRefOfLoadLines(in, f)
 	case *LocateExpr:
 		return VisitRefOfLocateExpr(in, f)
 	case *LockOption:
@@ -452,12 +470,16 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfRenameTable(in, f)
 	case *RenameTableName:
 		return VisitRefOfRenameTableName(in, f)
+	case *ReturnStatement:
+		return VisitRefOfReturnStatement(in, f)
 	case *RevertMigration:
 		return VisitRefOfRevertMigration(in, f)
 	case *Rollback:
 		return VisitRefOfRollback(in, f)
 	case RootNode:
 		return VisitRootNode(in, f)
+	case *RoutineCharacteristic:
+		return VisitRefOfRoutineCharacteristic(in, f)
 	case *RowAlias:
 		return VisitRefOfRowAlias(in, f)
 	case *SRollback:
@@ -540,6 +562,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfTablespaceOperation(in, f)
 	case *TimestampDiffExpr:
 		return VisitRefOfTimestampDiffExpr(in, f)
+	case *TriggerOrder:
+		return VisitRefOfTriggerOrder(in, f)
 	case *TrimFuncExpr:
 		return VisitRefOfTrimFuncExpr(in, f)
 	case *TruncateTable:
@@ -1365,6 +1389,67 @@ func VisitRefOfCreateDatabase(in *CreateDatabase, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfCreateEvent(in *CreateEvent, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableName(in.Name, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfDefiner(in.Definer, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfEventSchedule(in.Schedule, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.EventComment, f); err != nil {
+		return err
+	}
+	if err := VisitCompoundStatement(in.Body, f); err != nil {
+		return err
+	}
+	return nil
+}
+func VisitRefOfCreateFunction(in *CreateFunction, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableName(in.Name, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfDefiner(in.Definer, f); err != nil {
+		return err
+	}
+	for _, el := range in.Params {
+		if err := VisitRefOfProcParameter(el, f); err != nil {
+			return err
+		}
+	}
+	if err := VisitRefOfColumnType(in.Returns, f); err != nil {
+		return err
+	}
+	for _, el := range in.Characteristics {
+		if err := VisitRefOfRoutineCharacteristic(el, f); err != nil {
+			return err
+		}
+	}
+	if err := VisitCompoundStatement(in.Body, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfCreateProcedure(in *CreateProcedure, f Visit) error {
 	if in == nil {
 		return nil
@@ -1415,6 +1500,33 @@ func VisitRefOfCreateTable(in *CreateTable, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfCreateTrigger(in *CreateTrigger, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitTableName(in.Name, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfDefiner(in.Definer, f); err != nil {
+		return err
+	}
+	if err := VisitTableName(in.Table, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfTriggerOrder(in.Order, f); err != nil {
+		return err
+	}
+	if err := VisitCompoundStatement(in.Body, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfCreateView(in *CreateView, f Visit) error {
 	if in == nil {
 		return nil
@@ -1607,6 +1719,36 @@ func VisitRefOfDropDatabase(in *DropDatabase, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfDropEvent(in *DropEvent, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
+	if err := VisitTableName(in.Name, f); err != nil {
+		return err
+	}
+	return nil
+}
+func VisitRefOfDropFunction(in *DropFunction, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
+	if err := VisitTableName(in.Name, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfDropKey(in *DropKey, f Visit) error {
 	if in == nil {
 		return nil
@@ -1649,6 +1791,21 @@ func VisitRefOfDropTable(in *DropTable, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfDropTrigger(in *DropTrigger, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitRefOfParsedComments(in.Comments, f); err != nil {
+		return err
+	}
+	if err := VisitTableName(in.Name, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfDropView(in *DropView, f Visit) error {
 	if in == nil {
 		return nil
@@ -1679,6 +1836,27 @@ func VisitRefOfElseIfBlock(in *ElseIfBlock, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfEventSchedule(in *EventSchedule, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitExpr(in.At, f); err != nil {
+		return err
+	}
+	if err := VisitExpr(in.Every, f); err != nil {
+		return err
+	}
+	if err := VisitExpr(in.Starts, f); err != nil {
+		return err
+	}
+	if err := VisitExpr(in.Ends, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfExecuteStmt(in *ExecuteStmt, f Visit) error {
 	if in == nil {
 		return nil
@@ -2914,6 +3092,62 @@ func VisitRefOfLoad(in *Load, f Visit) error {
 	if cont, err := f(in); err != nil || !cont {
 		return err
 	}
+	if err := VisitTableName(in.Table, f); err != nil {
+		return err
+	}
+	if err := VisitPartitions(in.Partitions, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLoadFields(in.Fields, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLoadLines(in.Lines, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.IgnoreLines, f); err != nil {
+		return err
+	}
+	for _, el := range in.Columns {
+		if err := VisitExpr(el, f); err != nil {
+			return err
+		}
+	}
+	if err := VisitUpdateExprs(in.SetExprs, f); err != nil {
+		return err
+	}
+	return nil
+}
+func VisitRefOfLoadFields(in *LoadFields, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.TerminatedBy, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.EnclosedBy, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.EscapedBy, f); err != nil {
+		return err
+	}
+	return nil
+}
+func VisitRefOfLoadLines(in *LoadLines, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.StartingBy, f); err != nil {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.TerminatedBy, f); err != nil {
+		return err
+	}
 	return nil
 }
 func VisitRefOfLocateExpr(in *LocateExpr, f Visit) error {
@@ -3748,6 +3982,18 @@ func VisitRefOfRenameTableName(in *RenameTableName, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfReturnStatement(in *ReturnStatement, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitExpr(in.Expr, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfRevertMigration(in *RevertMigration, f Visit) error {
 	if in == nil {
 		return nil
@@ -3778,6 +4024,18 @@ func VisitRootNode(in RootNode, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfRoutineCharacteristic(in *RoutineCharacteristic, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitRefOfLiteral(in.Comment, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfRowAlias(in *RowAlias, f Visit) error {
 	if in == nil {
 		return nil
@@ -4374,6 +4632,18 @@ func VisitRefOfTimestampDiffExpr(in *TimestampDiffExpr, f Visit) error {
 	}
 	return nil
 }
+func VisitRefOfTriggerOrder(in *TriggerOrder, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	if err := VisitIdentifierCS(in.Name, f); err != nil {
+		return err
+	}
+	return nil
+}
 func VisitRefOfTrimFuncExpr(in *TrimFuncExpr, f Visit) error {
 	if in == nil {
 		return nil
@@ -5173,6 +5443,8 @@ func VisitCompoundStatement(in CompoundStatement, f Visit) error {
 		return VisitRefOfDeclareVar(in, f)
 	case *IfStatement:
 		return VisitRefOfIfStatement(in, f)
+	case *ReturnStatement:
+		return VisitRefOfReturnStatement(in, f)
 	case *Signal:
 		return VisitRefOfSignal(in, f)
 	case *SingleStatement:
@@ -5227,16 +5499,28 @@ func VisitDDLStatement(in DDLStatement, f Visit) error {
 		return VisitRefOfAlterTable(in, f)
 	case *AlterView:
 		return VisitRefOfAlterView(in, f)
+	case *CreateEvent:
+		return VisitRefOfCreateEvent(in, f)
+	case *CreateFunction:
+		return VisitRefOfCreateFunction(in, f)
 	case *CreateProcedure:
 		return VisitRefOfCreateProcedure(in, f)
 	case *CreateTable:
 		return VisitRefOfCreateTable(in, f)
+	case *CreateTrigger:
+		return VisitRefOfCreateTrigger(in, f)
 	case *CreateView:
 		return VisitRefOfCreateView(in, f)
+	case *DropEvent:
+		return VisitRefOfDropEvent(in, f)
+	case *DropFunction:
+		return VisitRefOfDropFunction(in, f)
 	case *DropProcedure:
 		return VisitRefOfDropProcedure(in, f)
 	case *DropTable:
 		return VisitRefOfDropTable(in, f)
+	case *DropTrigger:
+		return VisitRefOfDropTrigger(in, f)
 	case *DropView:
 		return VisitRefOfDropView(in, f)
 	case *RenameTable:
@@ -5653,10 +5937,16 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfCommit(in, f)
 	case *CreateDatabase:
 		return VisitRefOfCreateDatabase(in, f)
+	case *CreateEvent:
+		return VisitRefOfCreateEvent(in, f)
+	case *CreateFunction:
+		return VisitRefOfCreateFunction(in, f)
 	case *CreateProcedure:
 		return VisitRefOfCreateProcedure(in, f)
 	case *CreateTable:
 		return VisitRefOfCreateTable(in, f)
+	case *CreateTrigger:
+		return VisitRefOfCreateTrigger(in, f)
 	case *CreateView:
 		return VisitRefOfCreateView(in, f)
 	case *DeallocateStmt:
@@ -5665,10 +5955,16 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfDelete(in, f)
 	case *DropDatabase:
 		return VisitRefOfDropDatabase(in, f)
+	case *DropEvent:
+		return VisitRefOfDropEvent(in, f)
+	case *DropFunction:
+		return VisitRefOfDropFunction(in, f)
 	case *DropProcedure:
 		return VisitRefOfDropProcedure(in, f)
 	case *DropTable:
 		return VisitRefOfDropTable(in, f)
+	case *DropTrigger:
+		return VisitRefOfDropTrigger(in, f)
 	case *DropView:
 		return VisitRefOfDropView(in, f)
 	case *ExecuteStmt:
@@ -53,6 +53,8 @@ const (
 	DirectiveVExplainRunDMLQueries = "EXECUTE_DML_QUERIES"
 	// DirectiveConsolidator enables the query consolidator.
 	DirectiveConsolidator = "CONSOLIDATOR"
+	// DirectiveVTGateConsolidator opts a select into the vtgate query consolidator.
+	DirectiveVTGateConsolidator = "VTGATE_CONSOLIDATOR"
 	// DirectiveWorkloadName specifies the name of the client application workload issuing the query.
 	DirectiveWorkloadName = "WORKLOAD_NAME"
 	// DirectivePriority specifies the priority of a workload. It should be an integer between 0 and MaxPriorityValue,
@@ -565,6 +567,7 @@ func checkDirective(stmt Statement, key string) bool {
 type QueryHints struct {
 	IgnoreMaxMemoryRows bool
 	Consolidator        querypb.ExecuteOptions_Consolidator
+	VTGateConsolidator  bool
 	Workload            string
 	ForeignKeyChecks    *bool
 	Priority            string
@@ -587,6 +590,7 @@ func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
 	}
 	qh.IgnoreMaxMemoryRows = directives.IsSet(DirectiveIgnoreMaxMemoryRows)
 	qh.Consolidator = getConsolidator(stmt, directives)
+	qh.VTGateConsolidator = getVTGateConsolidator(stmt, directives)
 	qh.Workload = getWorkload(directives)
 	qh.ForeignKeyChecks = getForeignKeyChecksState(comment)
 	qh.Timeout = getQueryTimeout(directives)
@@ -609,6 +613,14 @@ func getConsolidator(stmt Statement, directives *CommentDirectives) querypb.Exec
 	return querypb.ExecuteOptions_CONSOLIDATOR_UNSPECIFIED
 }
 
+// getVTGateConsolidator returns whether a select opted into the vtgate query consolidator.
+func getVTGateConsolidator(stmt Statement, directives *CommentDirectives) bool {
+	if _, isSelect := stmt.(SelectStatement); !isSelect {
+		return false
+	}
+	return directives.IsSet(DirectiveVTGateConsolidator)
+}
+
 // getWorkload gets the workload name from the provided Statement, using workloadLabel as the name of
 // the query directive that specifies it.
 func getWorkload(directives *CommentDirectives) string {
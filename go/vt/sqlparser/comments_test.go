@@ -482,6 +482,31 @@ func TestConsolidator(t *testing.T) {
 	}
 }
 
+func TestVTGateConsolidator(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected bool
+	}{
+		{"insert /*vt+ VTGATE_CONSOLIDATOR */ into user(id) values (1), (2)", false},
+		{"update /*vt+ VTGATE_CONSOLIDATOR */ users set name=1", false},
+		{"select * from users", false},
+		{"select /*vt+ CONSOLIDATOR=enabled */ * from users", false},
+		{"select /*vt+ VTGATE_CONSOLIDATOR=false */ * from users", false},
+		{"select /*vt+ VTGATE_CONSOLIDATOR */ * from users", true},
+		{"select /*vt+ VTGATE_CONSOLIDATOR */ * from users union select * from admins", true},
+	}
+
+	parser := NewTestParser()
+	for _, test := range testCases {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, _ := parser.Parse(test.query)
+			qh, err := BuildQueryHints(stmt)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, qh.VTGateConsolidator)
+		})
+	}
+}
+
 func TestGetPriorityFromStatement(t *testing.T) {
 	testCases := []struct {
 		query            string
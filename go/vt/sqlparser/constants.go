@@ -58,6 +58,8 @@ const (
 	GlobalStr         = "global"
 	VitessMetadataStr = "vitess_metadata"
 	VariableStr       = "variable"
+	TriggerNewStr     = "new"
+	TriggerOldStr     = "old"
 
 	// DDL strings.
 	CreateStr           = "create"
@@ -183,6 +185,34 @@ const (
 	ExitStr     = "exit"
 	UndoStr     = "undo"
 
+	// TriggerTime
+	BeforeStr = "before"
+	AfterStr  = "after"
+
+	// TriggerEvent (insert shares InsertStr)
+	UpdateStr = "update"
+	DeleteStr = "delete"
+
+	// RoutineCharacteristicType
+	CommentStr            = "comment"
+	LanguageSQLStr        = "language sql"
+	DeterministicStr      = "deterministic"
+	NotDeterministicStr   = "not deterministic"
+	ContainsSQLStr        = "contains sql"
+	NoSQLStr              = "no sql"
+	ReadsSQLDataStr       = "reads sql data"
+	ModifiesSQLDataStr    = "modifies sql data"
+	SQLSecurityDefinerStr = "sql security definer"
+	SQLSecurityInvokerStr = "sql security invoker"
+
+	// EventOnCompletion
+	PreserveStr    = "on completion preserve"
+	NotPreserveStr = "on completion not preserve"
+
+	// EventStatus
+	EnableStr  = "enable"
+	DisableStr = "disable"
+
 	// IsExpr.Operator
 	IsNullStr     = "is null"
 	IsNotNullStr  = "is not null"
@@ -535,6 +565,9 @@ const (
 	DropAutoIncDDLAction
 	RevertDDLAction
 	CreateProcedureAction
+	CreateTriggerAction
+	CreateFunctionAction
+	CreateEventAction
 )
 
 // Constants for scope of variables
@@ -548,6 +581,8 @@ const (
 	PersistOnlySysScope       // {PERSIST_ONLY | @@PERSIST_ONLY.} system_var_name
 	VariableScope             // @var_name   This is used for user defined variables.
 	NextTxScope               // This is used for transaction related variables like transaction_isolation, transaction_read_write and set transaction statement.
+	TriggerNewScope           // NEW.col_name   This is used for columns of the new row in the body of a trigger.
+	TriggerOldScope           // OLD.col_name   This is used for columns of the old row in the body of a trigger.
 )
 
 // Constants for Enum Type - Lock
@@ -813,6 +848,47 @@ const (
 	InoutMode
 )
 
+// Constant for Enum Type - TriggerTime
+const (
+	BeforeTrigger TriggerTime = iota
+	AfterTrigger
+)
+
+// Constant for Enum Type - TriggerEvent
+const (
+	InsertTrigger TriggerEvent = iota
+	UpdateTrigger
+	DeleteTrigger
+)
+
+// Constant for Enum Type - RoutineCharacteristicType
+const (
+	CommentCharacteristic RoutineCharacteristicType = iota
+	LanguageSQLCharacteristic
+	DeterministicCharacteristic
+	NotDeterministicCharacteristic
+	ContainsSQLCharacteristic
+	NoSQLCharacteristic
+	ReadsSQLDataCharacteristic
+	ModifiesSQLDataCharacteristic
+	SQLSecurityDefinerCharacteristic
+	SQLSecurityInvokerCharacteristic
+)
+
+// Constant for Enum Type - EventOnCompletion
+const (
+	DefaultOnCompletion EventOnCompletion = iota
+	PreserveOnCompletion
+	NotPreserveOnCompletion
+)
+
+// Constant for Enum Type - EventStatus
+const (
+	DefaultEventStatus EventStatus = iota
+	EnableEventStatus
+	DisableEventStatus
+)
+
 // Constant for Enum Type - SignalConditionName
 const (
 	ClassOriginType SignalConditionName = iota
@@ -133,6 +133,7 @@ var keywords = []keyword{
 	{"asc", ASC},
 	{"ascii", ASCII},
 	{"asensitive", UNUSED},
+	{"at", AT},
 	{"auto_increment", AUTO_INCREMENT},
 	{"autoextend_size", AUTOEXTEND_SIZE},
 	{"avg", AVG},
@@ -181,8 +182,10 @@ var keywords = []keyword{
 	{"commit", COMMIT},
 	{"compact", COMPACT},
 	{"complete", COMPLETE},
+	{"completion", COMPLETION},
 	{"compressed", COMPRESSED},
 	{"compression", COMPRESSION},
+	{"concurrent", CONCURRENT},
 	{"condition", CONDITION},
 	{"connection", CONNECTION},
 	{"consistent", CONSISTENT},
@@ -190,11 +193,20 @@ var keywords = []keyword{
 	{"constraint_catalog", CONSTRAINT_CATALOG},
 	{"constraint_name", CONSTRAINT_NAME},
 	{"constraint_schema", CONSTRAINT_SCHEMA},
+	{"contains", CONTAINS},
 	{"continue", CONTINUE},
 	{"convert", CONVERT},
 	{"copy", COPY},
 	{"count", COUNT},
 	{"cume_dist", CUME_DIST},
+	{"ends", ENDS},
+	{"every", EVERY},
+	{"follows", FOLLOWS},
+	{"precedes", PRECEDES},
+	{"preserve", PRESERVE},
+	{"returns", RETURNS},
+	{"schedule", SCHEDULE},
+	{"starts", STARTS},
 	{"substr", SUBSTRING},
 	{"subpartition", SUBPARTITION},
 	{"subpartitions", SUBPARTITIONS},
@@ -237,7 +249,7 @@ var keywords = []keyword{
 	{"dense_rank", DENSE_RANK},
 	{"desc", DESC},
 	{"describe", DESCRIBE},
-	{"deterministic", UNUSED},
+	{"deterministic", DETERMINISTIC},
 	{"directory", DIRECTORY},
 	{"disable", DISABLE},
 	{"discard", DISCARD},
@@ -251,7 +263,7 @@ var keywords = []keyword{
 	{"dumpfile", DUMPFILE},
 	{"duplicate", DUPLICATE},
 	{"dynamic", DYNAMIC},
-	{"each", UNUSED},
+	{"each", EACH},
 	{"else", ELSE},
 	{"elseif", ELSEIF},
 	{"empty", EMPTY},
@@ -337,7 +349,7 @@ var keywords = []keyword{
 	{"in", IN},
 	{"index", INDEX},
 	{"indexes", INDEXES},
-	{"infile", UNUSED},
+	{"infile", INFILE},
 	{"inout", INOUT},
 	{"inner", INNER},
 	{"inplace", INPLACE},
@@ -460,7 +472,7 @@ var keywords = []keyword{
 	{"mod", MOD},
 	{"mode", MODE},
 	{"modify", MODIFY},
-	{"modifies", UNUSED},
+	{"modifies", MODIFIES},
 	{"multilinestring", MULTILINESTRING},
 	{"multipoint", MULTIPOINT},
 	{"multipolygon", MULTIPOLYGON},
@@ -537,7 +549,7 @@ var keywords = []keyword{
 	{"rank", RANK},
 	{"ratio", RATIO},
 	{"read", READ},
-	{"reads", UNUSED},
+	{"reads", READS},
 	{"read_write", UNUSED},
 	{"real", REAL},
 	{"rebuild", REBUILD},
@@ -564,7 +576,7 @@ var keywords = []keyword{
 	{"resignal", UNUSED},
 	{"respect", RESPECT},
 	{"restrict", RESTRICT},
-	{"return", UNUSED},
+	{"return", RETURN},
 	{"returning", RETURNING},
 	{"retry", RETRY},
 	{"revert", REVERT},
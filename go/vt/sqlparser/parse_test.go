@@ -2228,6 +2228,48 @@ var (
 		output: "create procedure ConditionWithSignalAndHandler () begin declare custom_error condition for sqlstate '45000'; declare exit handler for custom_error begin select 'Handled with custom condition and signal' from dual; end; signal sqlstate '45000' set message_text = 'Custom signal triggered'; end;",
 	}, {
 		input: "create procedure t1 (in x BIGINT) begin start transaction; insert into unsharded_a values (1, 'a', 'a'); commit; end;",
+	}, {
+		input:  "create trigger t1_bi before insert on t1 for each row set new.x = 1;",
+		output: "create trigger t1_bi before insert on t1 for each row set new.x = 1;",
+	}, {
+		input:  "CREATE DEFINER=`root`@`localhost` TRIGGER `t1_bi` BEFORE INSERT ON `t1` FOR EACH ROW BEGIN IF NEW.x < 0 THEN SET NEW.x = 0, OLD.y = 1; END IF; END;",
+		output: "create definer = root@localhost trigger t1_bi before insert on t1 for each row begin if NEW.x < 0 then set new.x = 0, old.y = 1; end if; end;",
+	}, {
+		input: "create trigger if not exists db.t1_au after update on t1 for each row follows t1_bi insert into log values (old.id, new.id);",
+	}, {
+		input: "create trigger t1_ad after delete on t1 for each row precedes t1_ad2 delete from t2 where id = old.id;",
+	}, {
+		input: "drop trigger t1_bi",
+	}, {
+		input: "drop trigger if exists db.t1_bi",
+	}, {
+		input:  "create function f1(a int, b varchar(10)) returns int deterministic return a + 1;",
+		output: "create function f1 (a int, b varchar(10)) returns int deterministic return a + 1;",
+	}, {
+		input:  "CREATE DEFINER=`root`@`%` FUNCTION `hello`(s CHAR(20)) RETURNS char(50) CHARSET utf8mb4 DETERMINISTIC READS SQL DATA COMMENT 'hi' RETURN CONCAT('Hello, ',s,'!');",
+		output: "create definer = root@`%` function hello (s CHAR(20)) returns char(50) character set utf8mb4 deterministic reads sql data comment 'hi' return CONCAT('Hello, ', s, '!');",
+	}, {
+		input: "create function f2 () returns int language sql no sql begin declare x int default 3; return x * 2; end;",
+	}, {
+		input: "create function if not exists f3 (x int) returns decimal(10,2) not deterministic contains sql modifies sql data sql security invoker sql security definer return x;",
+	}, {
+		input: "drop function f1",
+	}, {
+		input: "drop function if exists db.f1",
+	}, {
+		input: "create event e1 on schedule every 1 day starts '2024-01-01 00:00:00' on completion not preserve enable do delete from t1 where ts < now() - interval 1 day;",
+	}, {
+		input:  "CREATE DEFINER=`root`@`localhost` EVENT `e2` ON SCHEDULE AT '2024-05-01 10:00:00' + INTERVAL 1 HOUR ON COMPLETION PRESERVE DISABLE COMMENT 'once' DO BEGIN insert into t1 values (1); insert into t1 values (2); END;",
+		output: "create definer = root@localhost event e2 on schedule at '2024-05-01 10:00:00' + interval 1 hour on completion preserve disable comment 'once' do begin insert into t1 values (1); insert into t1 values (2); end;",
+	}, {
+		input: "create event if not exists e3 on schedule every '1:30' hour_minute starts now() ends '2030-01-01' do call p1();",
+	}, {
+		input: "drop event e1",
+	}, {
+		input: "drop event if exists db.e1",
+	}, {
+		input:  "select at, every, starts, ends, schedule, returns, follows, precedes, completion, preserve, contains from t",
+		output: "select `at`, `every`, `starts`, `ends`, `schedule`, `returns`, `follows`, `precedes`, `completion`, `preserve`, `contains` from t",
 	}, {
 		input: "create /*vt+ strategy=online */ or replace view v as select a, b, c from t",
 	}, {
@@ -4901,7 +4943,6 @@ func TestLoadData(t *testing.T) {
 		"load data from s3 'x.txt'",
 		"load data from s3 manifest 'x.txt'",
 		"load data from s3 file 'x.txt'",
-		"load data infile 'x.txt' into table 'c'",
 		"load data from s3 'x.txt' into table x"}
 
 	parser := NewTestParser()
@@ -4909,6 +4950,50 @@ func TestLoadData(t *testing.T) {
 		_, err := parser.Parse(tcase)
 		require.NoError(t, err)
 	}
+
+	testCases := []struct {
+		input  string
+		output string
+	}{{
+		input: "load data infile 'x.txt' into table c",
+	}, {
+		input: "load data low_priority local infile '/tmp/x.txt' replace into table ks.c partition (p0, p1) character set utf8mb4",
+	}, {
+		input: "load data concurrent local infile 'x.txt' ignore into table c",
+	}, {
+		input:  "LOAD DATA LOCAL INFILE 'x.csv' INTO TABLE c COLUMNS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '\\\\' LINES STARTING BY 'x' TERMINATED BY '\\r\\n' IGNORE 1 ROWS",
+		output: "load data local infile 'x.csv' into table c fields terminated by ',' optionally enclosed by '\"' escaped by '\\\\' lines starting by 'x' terminated by '\\r\\n' ignore 1 lines",
+	}, {
+		input:  "load data local infile 'x.csv' into table c fields escaped by '' enclosed by '\"' terminated by '\\t' lines terminated by '\\n' starting by ''",
+		output: "load data local infile 'x.csv' into table c fields terminated by '\\t' enclosed by '\"' escaped by '' lines starting by '' terminated by '\\n'",
+	}, {
+		input: "load data local infile 'x.csv' into table c ignore 2 lines (a, @b, c) set d = @b + 1, e = now()",
+	}, {
+		input:  "load data local infile 'x.csv' into table c ()",
+		output: "load data local infile 'x.csv' into table c",
+	}}
+	for _, tcase := range testCases {
+		t.Run(tcase.input, func(t *testing.T) {
+			if tcase.output == "" {
+				tcase.output = tcase.input
+			}
+			stmt, err := parser.Parse(tcase.input)
+			require.NoError(t, err)
+			require.IsType(t, &Load{}, stmt)
+			assert.Equal(t, tcase.output, String(stmt))
+		})
+	}
+
+	invalidSQL := []string{
+		"load data infile 'x.txt' into table 'c'",
+		"load data low_priority concurrent infile 'x.txt' into table c",
+		"load data local infile 'x.txt' into table c fields",
+		"load data local infile 'x.txt' into table c (a + 1)",
+	}
+	for _, tcase := range invalidSQL {
+		_, err := parser.Parse(tcase)
+		require.Error(t, err, tcase)
+	}
 }
 
 func TestCreateTable(t *testing.T) {
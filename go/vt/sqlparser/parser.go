@@ -19,6 +19,7 @@ package sqlparser
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -243,22 +244,27 @@ func (p *Parser) SplitStatement(blob string) (string, string, error) {
 }
 
 var validCreatePrefixes = [][]int{
-	// These are the tokens (in order) for valid "create procedure" forms.
-	{CREATE, PROCEDURE},
-	{CREATE, DEFINER, '=', CURRENT_USER, PROCEDURE},
-	{CREATE, DEFINER, '=', CURRENT_USER, '(', ')', PROCEDURE},
-	{CREATE, DEFINER, '=', STRING, PROCEDURE},
-	{CREATE, DEFINER, '=', STRING, AT_ID, PROCEDURE},
-	{CREATE, DEFINER, '=', ID, PROCEDURE},
-	{CREATE, DEFINER, '=', ID, AT_ID, PROCEDURE},
+	// These are the tokens (in order) that can precede the object type in
+	// "create procedure", "create function", "create trigger" and "create event" forms.
+	{CREATE},
+	{CREATE, DEFINER, '=', CURRENT_USER},
+	{CREATE, DEFINER, '=', CURRENT_USER, '(', ')'},
+	{CREATE, DEFINER, '=', STRING},
+	{CREATE, DEFINER, '=', STRING, AT_ID},
+	{CREATE, DEFINER, '=', ID},
+	{CREATE, DEFINER, '=', ID, AT_ID},
 }
 
-// matchesCreateProcedurePrefix checks if the given token sequence
-// is a create procedure statement or not.
-func matchesCreateProcedurePrefix(tokens []int) bool {
+// compoundStatementObjects are the object types whose body is a compound statement,
+// which may contain semicolons.
+var compoundStatementObjects = []int{PROCEDURE, FUNCTION, TRIGGER, EVENT}
+
+// matchesCreateRoutinePrefix checks if the given token sequence
+// is a create procedure, function, trigger or event statement or not.
+func matchesCreateRoutinePrefix(tokens []int) bool {
 	// Check each candidate sequence.
 	for _, pattern := range validCreatePrefixes {
-		if len(tokens) >= len(pattern) {
+		if len(tokens) > len(pattern) {
 			match := true
 			for i, tok := range pattern {
 				if tokens[i] != tok {
@@ -266,7 +272,7 @@ func matchesCreateProcedurePrefix(tokens []int) bool {
 					break
 				}
 			}
-			if match {
+			if match && slices.Contains(compoundStatementObjects, tokens[len(pattern)]) {
 				return true
 			}
 		}
@@ -304,8 +310,8 @@ loop:
 		case ';':
 			// Potential end of the statement.
 			stmt = blob[stmtBegin : tokenizer.Pos-1]
-			// If it's a create procedure statement and is incomplete, skip appending.
-			if matchesCreateProcedurePrefix(startTokens) && p.IsStatementIncomplete(stmt) {
+			// If it's a create procedure, function, trigger or event statement and is incomplete, skip appending.
+			if matchesCreateRoutinePrefix(startTokens) && p.IsStatementIncomplete(stmt) {
 				continue
 			}
 			if !emptyStatement {
@@ -165,6 +165,18 @@ func TestSplitStatementToPieces(t *testing.T) {
 		// Create procedure with definer id at_id.
 		input:     "create DEFINER=`root`@`localhost` procedure p1 (in country CHAR(3))  begin declare abc DECIMAL(14,2); DECLARE def DECIMAL(14,2); end",
 		lenWanted: 1,
+	}, {
+		// Test that we don't split on semicolons inside create trigger calls.
+		input:     "select * from t1;create DEFINER=`root`@`localhost` trigger t1_bi before insert on t1 for each row begin set new.a = 1; set new.b = 2; end;select * from t2",
+		lenWanted: 3,
+	}, {
+		// Test that we don't split on semicolons inside create function calls.
+		input:     "create function f1 (x int) returns int deterministic begin declare y int; set y = x * 2; return y; end;select * from t2",
+		lenWanted: 2,
+	}, {
+		// Test that we don't split on semicolons inside create event calls.
+		input:     "create DEFINER=CURRENT_USER event e1 on schedule every 1 day do begin delete from t1; delete from t2; end",
+		lenWanted: 1,
 	},
 	}
 
@@ -117,6 +117,10 @@ func markBindVariable(yylex yyLexer, bvar string) {
   createTable      *CreateTable
   createView      *CreateView
   createProcedure  *CreateProcedure
+  triggerOrder     *TriggerOrder
+  eventSchedule    *EventSchedule
+  routineCharacteristic *RoutineCharacteristic
+  routineCharacteristics []*RoutineCharacteristic
   tableAndLockType *TableAndLockType
   alterTable       *AlterTable
   tableOption      *TableOption
@@ -190,6 +194,10 @@ func markBindVariable(yylex yyLexer, bvar string) {
   colKeyOpt     ColumnKeyOption
   referenceAction ReferenceAction
   procParamMode ProcParameterMode
+  triggerTime TriggerTime
+  triggerEvent TriggerEvent
+  eventOnCompletion EventOnCompletion
+  eventStatus EventStatus
   matchAction MatchAction
   insertAction InsertAction
   scope 	Scope
@@ -208,6 +216,8 @@ func markBindVariable(yylex yyLexer, bvar string) {
   txAccessMode TxAccessMode
   killType KillType
   ignoreOrReplaceType IgnoreOrReplaceType
+  loadFields *LoadFields
+  loadLines *LoadLines
 
   columnStorage ColumnStorage
   columnFormat ColumnFormat
@@ -280,6 +290,10 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // When we see '(', we can either reduce ignore_or_replace_opt to empty or shift '(' to parse table_spec.
 // We want shifting to take precedence, so we add lower precedence to the reduce rule.
 %nonassoc EMPTY_IGNORE_OR_REPLACE
+// EMPTY_LENGTH is used to resolve shift-reduce conflicts occurring due to '(' after the RETURNS type in CREATE FUNCTION statements.
+// When we see '(', we can either reduce the length of the type to empty or shift '(' to parse the length.
+// We want shifting to take precedence, so we add lower precedence to the reduce rule.
+%nonassoc EMPTY_LENGTH
 
 %token LEX_ERROR
 %left <str> UNION
@@ -289,6 +303,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> DISTINCTROW PARSER GENERATED ALWAYS
 %token <str> OUTFILE S3 DATA LOAD LINES TERMINATED ESCAPED ENCLOSED
 %token <str> DUMPFILE CSV HEADER MANIFEST OVERWRITE STARTING OPTIONALLY
+%token <str> INFILE CONCURRENT
 %token <str> VALUES LAST_INSERT_ID
 %token <str> NEXT VALUE SHARE MODE
 %token <str> SQL_NO_CACHE SQL_CACHE SQL_CALC_FOUND_ROWS SQL_SMALL_RESULT SQL_BIG_RESULT HIGH_PRIORITY
@@ -356,7 +371,9 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> SCHEMA TABLE INDEX VIEW TO IGNORE IF PRIMARY COLUMN SPATIAL FULLTEXT KEY_BLOCK_SIZE CHECK INDEXES
 %token <str> ACTION CASCADE CONSTRAINT FOREIGN NO REFERENCES RESTRICT SIGNAL
 %token <str> SHOW DESCRIBE EXPLAIN DATE ESCAPE REPAIR OPTIMIZE TRUNCATE COALESCE EXCHANGE REBUILD PARTITIONING REMOVE PREPARE EXECUTE
-%token <str> MAXVALUE PARTITION REORGANIZE LESS THAN PROCEDURE TRIGGER
+%token <str> MAXVALUE PARTITION REORGANIZE LESS THAN PROCEDURE TRIGGER EACH FOLLOWS PRECEDES
+%token <str> RETURN RETURNS DETERMINISTIC CONTAINS READS MODIFIES
+%token <str> SCHEDULE AT EVERY STARTS ENDS COMPLETION PRESERVE
 %token <str> VINDEX VINDEXES DIRECTORY NAME UPGRADE
 %token <str> STATUS VARIABLES WARNINGS CASCADED DEFINER OPTION SQL UNDEFINED
 %token <str> SEQUENCE MERGE TEMPORARY TEMPTABLE INVOKER SECURITY FIRST AFTER LAST
@@ -479,6 +496,17 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <renameTablePairs> rename_list
 %type <createTable> create_table_prefix
 %type <createProcedure> create_procedure
+%type <statement> create_trigger create_function create_event
+%type <triggerTime> trigger_time
+%type <triggerEvent> trigger_event
+%type <triggerOrder> trigger_order_opt
+%type <routineCharacteristic> routine_characteristic
+%type <routineCharacteristics> routine_characteristic_list_opt routine_characteristic_list
+%type <eventSchedule> event_schedule
+%type <expr> event_starts_opt event_ends_opt
+%type <eventOnCompletion> event_on_completion_opt
+%type <eventStatus> event_status_opt
+%type <literal> event_comment_opt
 %type <createView> create_view_prefix
 %type <alterTable> alter_table_prefix
 %type <alterOption> alter_option alter_commands_modifier lock_index algorithm_index
@@ -602,6 +630,12 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <str> default_opt value_or_values
 %type <ignore> ignore_opt
 %type <ignoreOrReplaceType> ignore_or_replace_opt
+%type <loadFields> load_fields_opt load_fields_opt_list
+%type <loadLines> load_lines_opt load_lines_opt_list
+%type <literal> load_ignore_lines_opt
+%type <exprs> load_columns_opt load_column_list
+%type <expr> load_column
+%type <updateExprs> load_set_opt
 %type <str> columns_or_fields extended_opt storage_opt
 %type <showFilter> like_or_where_opt like_opt
 %type <boolean> exists_opt not_exists_opt enforced enforced_opt temp_opt full_opt
@@ -616,12 +650,12 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %type <str> charset
 %type <scope> set_session_or_global
 %type <convertType> convert_type returning_type_opt convert_type_weight_string
-%type <boolean> array_opt rollup_opt
+%type <boolean> array_opt rollup_opt load_local_opt
 %type <columnType> column_type
 %type <columnType> int_type decimal_type numeric_type time_type char_type spatial_type
 %type <literal> partition_comment partition_data_directory partition_index_directory
 %type <intPtr> length_opt
-%type <integer> func_datetime_precision
+%type <integer> func_datetime_precision load_priority_opt
 %type <columnCharset> charset_opt
 %type <str> collate_opt
 %type <boolean> binary_opt
@@ -792,6 +826,10 @@ compound_statement_without_semicolon:
   {
     $$ = &Signal{Condition: $2, SetValues: $3}
   }
+| RETURN expression
+  {
+    $$ = &ReturnStatement{Expr: $2}
+  }
 
 signal_set_list_opt:
   {
@@ -1047,10 +1085,168 @@ do_statement:
   }
 
 load_statement:
-  LOAD DATA skip_to_end
+  LOAD DATA FROM skip_to_end
   {
     $$ = &Load{}
   }
+| LOAD DATA load_priority_opt load_local_opt INFILE STRING ignore_or_replace_opt INTO TABLE table_name opt_partition_clause charset_opt load_fields_opt load_lines_opt load_ignore_lines_opt load_columns_opt load_set_opt
+  {
+    $$ = &Load{LowPriority: $3 == LOW_PRIORITY, Concurrent: $3 == CONCURRENT, Local: $4, FileName: $6, IgnoreOrReplace: $7, Table: $10, Partitions: $11, Charset: $12, Fields: $13, Lines: $14, IgnoreLines: $15, Columns: $16, SetExprs: $17}
+  }
+
+load_priority_opt:
+  {
+    $$ = 0
+  }
+| LOW_PRIORITY
+  {
+    $$ = LOW_PRIORITY
+  }
+| CONCURRENT
+  {
+    $$ = CONCURRENT
+  }
+
+load_local_opt:
+  {
+    $$ = false
+  }
+| LOCAL
+  {
+    $$ = true
+  }
+
+load_fields_opt:
+  {
+    $$ = nil
+  }
+| columns_or_fields load_fields_opt_list
+  {
+    $$ = $2
+  }
+
+load_fields_opt_list:
+  TERMINATED BY STRING
+  {
+    $$ = &LoadFields{TerminatedBy: NewStrLiteral($3)}
+  }
+| ENCLOSED BY STRING
+  {
+    $$ = &LoadFields{EnclosedBy: NewStrLiteral($3)}
+  }
+| OPTIONALLY ENCLOSED BY STRING
+  {
+    $$ = &LoadFields{EnclosedBy: NewStrLiteral($4), Optionally: true}
+  }
+| ESCAPED BY STRING
+  {
+    $$ = &LoadFields{EscapedBy: NewStrLiteral($3)}
+  }
+| load_fields_opt_list TERMINATED BY STRING
+  {
+    $1.TerminatedBy = NewStrLiteral($4)
+    $$ = $1
+  }
+| load_fields_opt_list ENCLOSED BY STRING
+  {
+    $1.EnclosedBy = NewStrLiteral($4)
+    $$ = $1
+  }
+| load_fields_opt_list OPTIONALLY ENCLOSED BY STRING
+  {
+    $1.EnclosedBy = NewStrLiteral($5)
+    $1.Optionally = true
+    $$ = $1
+  }
+| load_fields_opt_list ESCAPED BY STRING
+  {
+    $1.EscapedBy = NewStrLiteral($4)
+    $$ = $1
+  }
+
+load_lines_opt:
+  {
+    $$ = nil
+  }
+| LINES load_lines_opt_list
+  {
+    $$ = $2
+  }
+
+load_lines_opt_list:
+  STARTING BY STRING
+  {
+    $$ = &LoadLines{StartingBy: NewStrLiteral($3)}
+  }
+| TERMINATED BY STRING
+  {
+    $$ = &LoadLines{TerminatedBy: NewStrLiteral($3)}
+  }
+| load_lines_opt_list STARTING BY STRING
+  {
+    $1.StartingBy = NewStrLiteral($4)
+    $$ = $1
+  }
+| load_lines_opt_list TERMINATED BY STRING
+  {
+    $1.TerminatedBy = NewStrLiteral($4)
+    $$ = $1
+  }
+
+load_ignore_lines_opt:
+  {
+    $$ = nil
+  }
+| IGNORE INTEGRAL LINES
+  {
+    $$ = NewIntLiteral($2)
+  }
+| IGNORE INTEGRAL ROWS
+  {
+    $$ = NewIntLiteral($2)
+  }
+
+load_columns_opt:
+  {
+    $$ = nil
+  }
+| openb closeb
+  {
+    $$ = nil
+  }
+| openb load_column_list closeb
+  {
+    $$ = $2
+  }
+
+load_column_list:
+  load_column
+  {
+    $$ = []Expr{$1}
+  }
+| load_column_list ',' load_column
+  {
+    $$ = append($1, $3)
+  }
+
+load_column:
+  column_name
+  {
+    $$ = $1
+  }
+| user_defined_variable
+  {
+    $$ = $1
+  }
+
+load_set_opt:
+  {
+    $$ = nil
+  }
+| SET update_list
+  {
+    $$ = $2
+  }
 
 with_clause:
   WITH with_list
@@ -1420,6 +1616,15 @@ set_variable:
   {
     $$ = NewSetVariable(string($2), $1)
   }
+| ID '.' sql_id
+  {
+    v, ok := NewTriggerRowVariable(string($1), $3)
+    if !ok {
+      yylex.Error("expecting new or old before column name")
+      return 1
+    }
+    $$ = v
+  }
 
 set_transaction_statement:
   SET comment_opt set_session_or_global TRANSACTION transaction_chars
@@ -1520,6 +1725,9 @@ create_statement:
   {
     $$ = $1
   }
+| create_trigger
+| create_function
+| create_event
 | create_index_prefix '(' index_column_list ')' index_option_list_opt algorithm_lock_opt
   {
     indexDef := $1.AlterOptions[0].(*AddIndexDefinition).IndexDefinition
@@ -1622,6 +1830,185 @@ create_procedure:
     $$ = &CreateProcedure{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Params: $8, Body: $10}
   }
 
+create_trigger:
+  CREATE comment_opt definer_opt TRIGGER not_exists_opt table_name trigger_time trigger_event ON table_name FOR EACH ROW trigger_order_opt compound_statement
+  {
+    $$ = &CreateTrigger{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Time: $7, Event: $8, Table: $10, Order: $14, Body: $15}
+  }
+
+trigger_time:
+  BEFORE
+  {
+    $$ = BeforeTrigger
+  }
+| AFTER
+  {
+    $$ = AfterTrigger
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$ = InsertTrigger
+  }
+| UPDATE
+  {
+    $$ = UpdateTrigger
+  }
+| DELETE
+  {
+    $$ = DeleteTrigger
+  }
+
+trigger_order_opt:
+  {
+    $$ = nil
+  }
+| FOLLOWS table_id
+  {
+    $$ = &TriggerOrder{Name: $2}
+  }
+| PRECEDES table_id
+  {
+    $$ = &TriggerOrder{Precedes: true, Name: $2}
+  }
+
+create_function:
+  CREATE comment_opt definer_opt FUNCTION not_exists_opt table_name openb proc_params_list_opt closeb RETURNS column_type routine_characteristic_list_opt compound_statement
+  {
+    $$ = &CreateFunction{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Params: $8, Returns: $11, Characteristics: $12, Body: $13}
+  }
+
+routine_characteristic_list_opt:
+  {
+    $$ = nil
+  }
+| routine_characteristic_list
+  {
+    $$ = $1
+  }
+
+routine_characteristic_list:
+  routine_characteristic
+  {
+    $$ = []*RoutineCharacteristic{$1}
+  }
+| routine_characteristic_list routine_characteristic
+  {
+    $$ = append($1, $2)
+  }
+
+routine_characteristic:
+  COMMENT_KEYWORD STRING
+  {
+    $$ = &RoutineCharacteristic{Type: CommentCharacteristic, Comment: NewStrLiteral($2)}
+  }
+| LANGUAGE SQL
+  {
+    $$ = &RoutineCharacteristic{Type: LanguageSQLCharacteristic}
+  }
+| DETERMINISTIC
+  {
+    $$ = &RoutineCharacteristic{Type: DeterministicCharacteristic}
+  }
+| NOT DETERMINISTIC
+  {
+    $$ = &RoutineCharacteristic{Type: NotDeterministicCharacteristic}
+  }
+| CONTAINS SQL
+  {
+    $$ = &RoutineCharacteristic{Type: ContainsSQLCharacteristic}
+  }
+| NO SQL
+  {
+    $$ = &RoutineCharacteristic{Type: NoSQLCharacteristic}
+  }
+| READS SQL DATA
+  {
+    $$ = &RoutineCharacteristic{Type: ReadsSQLDataCharacteristic}
+  }
+| MODIFIES SQL DATA
+  {
+    $$ = &RoutineCharacteristic{Type: ModifiesSQLDataCharacteristic}
+  }
+| SQL SECURITY DEFINER
+  {
+    $$ = &RoutineCharacteristic{Type: SQLSecurityDefinerCharacteristic}
+  }
+| SQL SECURITY INVOKER
+  {
+    $$ = &RoutineCharacteristic{Type: SQLSecurityInvokerCharacteristic}
+  }
+
+create_event:
+  CREATE comment_opt definer_opt EVENT not_exists_opt table_name ON SCHEDULE event_schedule event_on_completion_opt event_status_opt event_comment_opt DO compound_statement
+  {
+    $$ = &CreateEvent{Comments: Comments($2).Parsed(), Name: $6, IfNotExists: $5, Definer: $3, Schedule: $9, OnCompletion: $10, Status: $11, EventComment: $12, Body: $14}
+  }
+
+event_schedule:
+  AT expression
+  {
+    $$ = &EventSchedule{At: $2}
+  }
+| EVERY expression interval event_starts_opt event_ends_opt
+  {
+    $$ = &EventSchedule{Every: $2, Unit: $3, Starts: $4, Ends: $5}
+  }
+
+event_starts_opt:
+  {
+    $$ = nil
+  }
+| STARTS expression
+  {
+    $$ = $2
+  }
+
+event_ends_opt:
+  {
+    $$ = nil
+  }
+| ENDS expression
+  {
+    $$ = $2
+  }
+
+event_on_completion_opt:
+  {
+    $$ = DefaultOnCompletion
+  }
+| ON COMPLETION PRESERVE
+  {
+    $$ = PreserveOnCompletion
+  }
+| ON COMPLETION NOT PRESERVE
+  {
+    $$ = NotPreserveOnCompletion
+  }
+
+event_status_opt:
+  {
+    $$ = DefaultEventStatus
+  }
+| ENABLE
+  {
+    $$ = EnableEventStatus
+  }
+| DISABLE
+  {
+    $$ = DisableEventStatus
+  }
+
+event_comment_opt:
+  {
+    $$ = nil
+  }
+| COMMENT_KEYWORD STRING
+  {
+    $$ = NewStrLiteral($2)
+  }
+
 create_table_prefix:
   CREATE comment_opt temp_opt TABLE not_exists_opt table_name
   {
@@ -2634,6 +3021,7 @@ enum_values:
   }
 
 length_opt:
+  %prec EMPTY_LENGTH
   {
     $$ = nil
   }
@@ -2643,6 +3031,7 @@ length_opt:
   }
 
 double_length_opt:
+  %prec EMPTY_LENGTH
   {
     $$ = LengthScaleOption{}
   }
@@ -2667,6 +3056,7 @@ double_length_opt
   }
 
 decimal_length_opt:
+  %prec EMPTY_LENGTH
   {
     $$ = LengthScaleOption{}
   }
@@ -4425,6 +4815,18 @@ drop_statement:
   {
     $$ = &DropProcedure{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
   }
+| DROP comment_opt FUNCTION exists_opt table_name
+  {
+    $$ = &DropFunction{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
+  }
+| DROP comment_opt TRIGGER exists_opt table_name
+  {
+    $$ = &DropTrigger{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
+  }
+| DROP comment_opt EVENT exists_opt table_name
+  {
+    $$ = &DropEvent{Comments: Comments($2).Parsed(), Name: $5, IfExists: $4}
+  }
 
 truncate_statement:
   TRUNCATE TABLE table_name
@@ -8697,6 +9099,7 @@ reserved_keyword:
 | IGNORE
 | IN
 | INDEX
+| INFILE
 | INNER
 | INOUT
 | INSERT
@@ -8817,6 +9220,7 @@ non_reserved_keyword:
 | ANY_VALUE %prec FUNCTION_CALL_NON_KEYWORD
 | ARRAY
 | ASCII
+| AT
 | AUTO_INCREMENT
 | AUTOEXTEND_SIZE
 | AVG %prec FUNCTION_CALL_NON_KEYWORD
@@ -8855,14 +9259,17 @@ non_reserved_keyword:
 | COMMITTED
 | COMPACT
 | COMPLETE
+| COMPLETION
 | COMPONENT
 | COMPRESSED
 | COMPRESSION
+| CONCURRENT
 | CONNECTION
 | CONSISTENT
 | CONSTRAINT_CATALOG
 | CONSTRAINT_NAME
 | CONSTRAINT_SCHEMA
+| CONTAINS
 | COPY
 | COUNT %prec FUNCTION_CALL_NON_KEYWORD
 | CSV
@@ -8893,6 +9300,7 @@ non_reserved_keyword:
 | ENCLOSED
 | ENCRYPTION
 | END
+| ENDS
 | ENFORCED
 | ENGINE
 | ENGINE_ATTRIBUTE
@@ -8901,6 +9309,7 @@ non_reserved_keyword:
 | ERROR
 | ESCAPED
 | EVENT
+| EVERY
 | EXCHANGE
 | EXCLUDE
 | EXCLUSIVE
@@ -8916,6 +9325,7 @@ non_reserved_keyword:
 | FIXED
 | FLUSH
 | FOLLOWING
+| FOLLOWS
 | FORCE_CUTOVER
 | FORMAT
 | FORMAT_BYTES %prec FUNCTION_CALL_NON_KEYWORD
@@ -9063,8 +9473,10 @@ non_reserved_keyword:
 | PERSIST
 | PERSIST_ONLY
 | PLAN
+| PRECEDES
 | PRECEDING
 | PREPARE
+| PRESERVE
 | PRIVILEGE_CHECKS_USER
 | PRIVILEGES
 | PROCESS
@@ -9106,6 +9518,7 @@ non_reserved_keyword:
 | RETAIN
 | RETRY
 | RETURNING
+| RETURNS
 | REUSE
 | ROLE
 | ROLLBACK
@@ -9113,6 +9526,7 @@ non_reserved_keyword:
 | ROW_FORMAT
 | RTRIM %prec FUNCTION_CALL_NON_KEYWORD
 | S3
+| SCHEDULE
 | SCHEMA_NAME
 | SECONDARY
 | SECONDARY_ENGINE
@@ -9149,6 +9563,7 @@ non_reserved_keyword:
 | SRID
 | START
 | STARTING
+| STARTS
 | STATS_AUTO_RECALC
 | STATS_PERSISTENT
 | STATS_SAMPLE_PAGES
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+const (
+	// MaxAuditLogRequestSize is the size over which the requests of the
+	// entries of the audit log are truncated.
+	MaxAuditLogRequestSize = 4 * 1024
+
+	// MaxAuditLogSize is the size under which the audit log is kept by
+	// dropping its oldest entries, as the topo servers limit the size of
+	// their values.
+	MaxAuditLogSize = 512 * 1024
+)
+
+// GetAuditLog fetches the audit log ring buffer from the global topo.
+func (ts *Server) GetAuditLog(ctx context.Context) (*vtctldatapb.AuditLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	auditLog := &vtctldatapb.AuditLog{}
+	data, _, err := ts.globalCell.Get(ctx, AuditLogFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return auditLog, nil
+		}
+		return nil, err
+	}
+	if err := auditLog.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrapf(err, "bad audit log data: %q", data)
+	}
+	return auditLog, nil
+}
+
+// AppendAuditLogEntry appends an entry to the audit log ring buffer in the
+// global topo, and drops its oldest entries to keep at most maxEntries, and
+// to keep the log under MaxAuditLogSize. The request of the entry is
+// truncated to MaxAuditLogRequestSize.
+// Concurrent appends are serialized with the version of the topo file.
+func (ts *Server) AppendAuditLogEntry(ctx context.Context, entry *vtctldatapb.AuditLogEntry, maxEntries int) error {
+	if len(entry.Request) > MaxAuditLogRequestSize {
+		// The entry may be logged elsewhere in full, so truncate a copy.
+		entry = entry.CloneVT()
+		entry.Request = truncateAuditLogRequest(entry.Request)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		auditLog := &vtctldatapb.AuditLog{}
+		data, version, err := ts.globalCell.Get(ctx, AuditLogFile)
+		switch {
+		case err == nil:
+			if err := auditLog.UnmarshalVT(data); err != nil {
+				return vterrors.Wrapf(err, "bad audit log data: %q", data)
+			}
+		case IsErrType(err, NoNode):
+		default:
+			return err
+		}
+
+		auditLog.Entries = append(auditLog.Entries, entry)
+		if len(auditLog.Entries) > maxEntries {
+			auditLog.Entries = auditLog.Entries[len(auditLog.Entries)-maxEntries:]
+		}
+		for len(auditLog.Entries) > 1 && auditLog.SizeVT() > MaxAuditLogSize {
+			auditLog.Entries = auditLog.Entries[1:]
+		}
+		data, err = auditLog.MarshalVT()
+		if err != nil {
+			return err
+		}
+
+		if version == nil {
+			_, err = ts.globalCell.Create(ctx, AuditLogFile, data)
+		} else {
+			_, err = ts.globalCell.Update(ctx, AuditLogFile, data, version)
+		}
+		if IsErrType(err, BadVersion) || IsErrType(err, NodeExists) {
+			// Another entry was appended concurrently, retry.
+			continue
+		}
+		return err
+	}
+}
+
+// truncateAuditLogRequest truncates the request to MaxAuditLogRequestSize, on
+// a character boundary, and marks it as truncated.
+func truncateAuditLogRequest(request string) string {
+	n := MaxAuditLogRequestSize
+	for n > 0 && !utf8.RuneStart(request[n]) {
+		n--
+	}
+	return request[:n] + "...[TRUNCATED]"
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestAuditLog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	auditLog, err := ts.GetAuditLog(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, auditLog.Entries)
+
+	for i := range 5 {
+		err := ts.AppendAuditLogEntry(ctx, &vtctldatapb.AuditLogEntry{Method: fmt.Sprintf("Method%d", i)}, 3)
+		require.NoError(t, err)
+	}
+
+	// Only the 3 most recent entries are kept.
+	auditLog, err = ts.GetAuditLog(ctx)
+	require.NoError(t, err)
+	var methods []string
+	for _, entry := range auditLog.Entries {
+		methods = append(methods, entry.Method)
+	}
+	assert.Equal(t, []string{"Method2", "Method3", "Method4"}, methods)
+}
+
+func TestAuditLogSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	request := strings.Repeat("é", topo.MaxAuditLogRequestSize)
+	for i := range 200 {
+		entry := &vtctldatapb.AuditLogEntry{Method: fmt.Sprintf("Method%d", i), Request: request}
+		err := ts.AppendAuditLogEntry(ctx, entry, 1000)
+		require.NoError(t, err)
+		// The entry of the caller is left untouched.
+		assert.Equal(t, request, entry.Request)
+	}
+
+	auditLog, err := ts.GetAuditLog(ctx)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, auditLog.SizeVT(), topo.MaxAuditLogSize)
+	// The oldest entries are dropped to keep the log under its maximum size.
+	require.Less(t, len(auditLog.Entries), 200)
+	assert.Equal(t, "Method199", auditLog.Entries[len(auditLog.Entries)-1].Method)
+	for _, entry := range auditLog.Entries {
+		assert.True(t, utf8.ValidString(entry.Request))
+		assert.LessOrEqual(t, len(entry.Request), topo.MaxAuditLogRequestSize+len("...[TRUNCATED]"))
+		assert.True(t, strings.HasSuffix(entry.Request, "...[TRUNCATED]"))
+	}
+}
+
+func TestAuditLogConcurrentAppends(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := ts.AppendAuditLogEntry(ctx, &vtctldatapb.AuditLogEntry{Method: fmt.Sprintf("Method%d", i)}, 100)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	auditLog, err := ts.GetAuditLog(ctx)
+	require.NoError(t, err)
+	assert.Len(t, auditLog.Entries, 10)
+}
@@ -18,6 +18,7 @@ package topo
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"time"
 )
@@ -249,6 +250,78 @@ type Conn interface {
 	Close()
 }
 
+// TransactionalConn is an optional interface that can be implemented
+// by Conn plug-ins that are able to apply several file operations
+// atomically. Callers should not type-assert a Conn to this interface
+// directly, but use ApplyTxn instead, which falls back to applying the
+// operations one at a time on plug-ins that do not implement it.
+type TransactionalConn interface {
+	// Txn applies the provided operations atomically: either all of
+	// them are applied, or none of them is. The preconditions of
+	// each operation are the same as for the matching single-file
+	// call (Create, Update, Delete), so for instance an operation
+	// with a non-nil version only succeeds if that version is still
+	// current. A TxnCheck operation only asserts its precondition.
+	// A given path can only appear once in ops.
+	// It returns the new Version of each file, in the order of ops,
+	// with nil entries for TxnDelete and TxnCheck operations.
+	// Returns ErrNodeExists, ErrNoNode or ErrBadVersion if the
+	// precondition of one of the operations does not hold.
+	Txn(ctx context.Context, ops []TxnOp) ([]Version, error)
+}
+
+// TxnOpType is the type of an operation in a transaction.
+type TxnOpType int
+
+const (
+	// TxnCreate creates a file, and fails if it already exists.
+	TxnCreate TxnOpType = iota
+
+	// TxnUpdate updates a file. If Version is nil, it is an
+	// unconditional update that creates the file if needed.
+	TxnUpdate
+
+	// TxnDelete deletes a file, and fails if it doesn't exist.
+	// If Version is nil, it is an unconditional delete.
+	TxnDelete
+
+	// TxnCheck does not modify anything, and fails if the file
+	// doesn't exist or, when Version is set, if the file changed.
+	TxnCheck
+)
+
+// String returns a text representation of the operation type.
+func (t TxnOpType) String() string {
+	switch t {
+	case TxnCreate:
+		return "Create"
+	case TxnUpdate:
+		return "Update"
+	case TxnDelete:
+		return "Delete"
+	case TxnCheck:
+		return "Check"
+	default:
+		return fmt.Sprintf("TxnOpType(%d)", int(t))
+	}
+}
+
+// TxnOp is a single operation in a transaction, see TransactionalConn.
+type TxnOp struct {
+	// Type is the type of the operation.
+	Type TxnOpType
+
+	// Path is the file path, relative to the root directory of the cell.
+	Path string
+
+	// Contents is the new file contents for TxnCreate and TxnUpdate.
+	Contents []byte
+
+	// Version is the expected current version of the file, or nil.
+	// It must be nil for TxnCreate.
+	Version Version
+}
+
 // DirEntryType is the type of an entry in a directory.
 type DirEntryType int
 
@@ -102,6 +102,8 @@ func getClientCreds() (creds map[string]*ClientAuthCred, err error) {
 }
 
 // Server is the implementation of topo.Server for consul.
+// It does not implement topo.TransactionalConn, so topo.ApplyTxn
+// falls back to applying the operations of a transaction one at a time.
 type Server struct {
 	// client is the consul api client.
 	client *api.Client
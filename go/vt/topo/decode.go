@@ -26,6 +26,7 @@ import (
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
 
 // DecodeContent uses the filename to imply a type, and proto-decodes
@@ -53,6 +54,8 @@ func DecodeContent(filename string, data []byte, json bool) (string, error) {
 		p = new(topodatapb.SrvKeyspace)
 	case RoutingRulesFile:
 		p = new(vschemapb.RoutingRules)
+	case AuditLogFile:
+		p = new(vtctldatapb.AuditLog)
 	case CommonRoutingRulesFile:
 		switch path.Base(dir) {
 		case "keyspace":
@@ -21,6 +21,7 @@ import (
 
 	"context"
 
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	"vitess.io/vitess/go/vt/topo"
@@ -183,3 +184,100 @@ func (s *Server) Delete(ctx context.Context, filePath string, version topo.Versi
 	}
 	return nil
 }
+
+var _ topo.TransactionalConn = (*Server)(nil)
+
+// Txn is part of the topo.TransactionalConn interface. All the
+// operations are applied in a single etcd transaction, so the number
+// of operations is limited by the --max-txn-ops setting of the etcd
+// server (128 by default).
+func (s *Server) Txn(ctx context.Context, ops []topo.TxnOp) ([]topo.Version, error) {
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	if err := s.checkClosed(); err != nil {
+		return nil, convertError(err, ops[0].Path)
+	}
+
+	nodePaths := make([]string, len(ops))
+	var cmps []clientv3.Cmp
+	var thenOps, elseOps []clientv3.Op
+	for i, op := range ops {
+		nodePath := path.Join(s.root, op.Path)
+		nodePaths[i] = nodePath
+
+		switch op.Type {
+		case topo.TxnCreate:
+			cmps = append(cmps, clientv3.Compare(clientv3.Version(nodePath), "=", 0))
+			thenOps = append(thenOps, clientv3.OpPut(nodePath, string(op.Contents)))
+		case topo.TxnUpdate:
+			if op.Version != nil {
+				cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(nodePath), "=", int64(op.Version.(EtcdVersion))))
+			}
+			thenOps = append(thenOps, clientv3.OpPut(nodePath, string(op.Contents)))
+		case topo.TxnDelete:
+			cmps = append(cmps, txnExistsCompare(nodePath, op.Version))
+			thenOps = append(thenOps, clientv3.OpDelete(nodePath))
+		case topo.TxnCheck:
+			cmps = append(cmps, txnExistsCompare(nodePath, op.Version))
+		}
+
+		// If the transaction doesn't succeed, we read all the nodes
+		// in the same revision, to find out which operation failed.
+		elseOps = append(elseOps, clientv3.OpGet(nodePath))
+	}
+
+	txnresp, err := s.cli.Txn(ctx).
+		If(cmps...).
+		Then(thenOps...).
+		Else(elseOps...).
+		Commit()
+	if err != nil {
+		return nil, convertError(err, nodePaths[0])
+	}
+	if !txnresp.Succeeded {
+		return nil, txnFailure(ops, nodePaths, txnresp.Responses)
+	}
+
+	versions := make([]topo.Version, len(ops))
+	for i, op := range ops {
+		if op.Type == topo.TxnCreate || op.Type == topo.TxnUpdate {
+			versions[i] = EtcdVersion(txnresp.Header.Revision)
+		}
+	}
+	return versions, nil
+}
+
+// txnExistsCompare returns the comparison that checks a node exists,
+// and has the provided version if it is not nil.
+func txnExistsCompare(nodePath string, version topo.Version) clientv3.Cmp {
+	if version != nil {
+		return clientv3.Compare(clientv3.ModRevision(nodePath), "=", int64(version.(EtcdVersion)))
+	}
+	return clientv3.Compare(clientv3.Version(nodePath), ">", 0)
+}
+
+// txnFailure returns the error for the first operation whose condition
+// failed in a transaction, based on the node values read by its Else
+// branch.
+func txnFailure(ops []topo.TxnOp, nodePaths []string, responses []*etcdserverpb.ResponseOp) error {
+	if len(responses) != len(ops) {
+		return ErrBadResponse
+	}
+	for i, op := range ops {
+		kvs := responses[i].GetResponseRange().GetKvs()
+		switch {
+		case op.Type == topo.TxnCreate:
+			if len(kvs) > 0 {
+				return topo.NewError(topo.NodeExists, nodePaths[i])
+			}
+		case op.Type == topo.TxnUpdate && op.Version == nil:
+			// Unconditional update, it cannot fail.
+		case len(kvs) == 0:
+			return topo.NewError(topo.NoNode, nodePaths[i])
+		case op.Version != nil && kvs[0].ModRevision != int64(op.Version.(EtcdVersion)):
+			return topo.NewError(topo.BadVersion, nodePaths[i])
+		}
+	}
+	return topo.NewError(topo.BadVersion, nodePaths[0])
+}
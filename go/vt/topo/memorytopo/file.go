@@ -50,6 +50,11 @@ func (c *Conn) Create(ctx context.Context, filePath string, contents []byte) (to
 		return nil, err
 	}
 
+	return c.createLocked(filePath, contents)
+}
+
+// createLocked creates a file. The factory mutex must be held.
+func (c *Conn) createLocked(filePath string, contents []byte) (topo.Version, error) {
 	// Get the parent dir.
 	dir, file := path.Split(filePath)
 	p := c.factory.getOrCreatePath(c.cell, dir)
@@ -99,6 +104,11 @@ func (c *Conn) Update(ctx context.Context, filePath string, contents []byte, ver
 		return nil, err
 	}
 
+	return c.updateLocked(filePath, contents, version)
+}
+
+// updateLocked updates a file. The factory mutex must be held.
+func (c *Conn) updateLocked(filePath string, contents []byte, version topo.Version) (topo.Version, error) {
 	// Get the parent dir, we'll need it in case of creation.
 	dir, file := path.Split(filePath)
 	p := c.factory.nodeByPath(c.cell, dir)
@@ -275,6 +285,11 @@ func (c *Conn) Delete(ctx context.Context, filePath string, version topo.Version
 		return err
 	}
 
+	return c.deleteLocked(filePath, version)
+}
+
+// deleteLocked deletes a file. The factory mutex must be held.
+func (c *Conn) deleteLocked(filePath string, version topo.Version) error {
 	// Get the parent dir.
 	dir, file := path.Split(filePath)
 	p := c.factory.nodeByPath(c.cell, dir)
@@ -323,3 +338,101 @@ func (c *Conn) Delete(ctx context.Context, filePath string, version topo.Version
 
 	return nil
 }
+
+var _ topo.TransactionalConn = (*Conn)(nil)
+
+// Txn is part of the topo.TransactionalConn interface.
+func (c *Conn) Txn(ctx context.Context, ops []topo.TxnOp) ([]topo.Version, error) {
+	c.factory.callstats.Add([]string{"Txn"}, 1)
+
+	if err := c.dial(ctx); err != nil {
+		return nil, err
+	}
+
+	c.factory.mu.Lock()
+	defer c.factory.mu.Unlock()
+
+	if c.factory.err != nil {
+		return nil, c.factory.err
+	}
+
+	// Check all the operations first, so we either apply all of
+	// them or none of them. Errors added for the matching single-file
+	// operations also apply here.
+	for _, op := range ops {
+		if err := c.factory.getOperationError(Txn, op.Path); err != nil {
+			return nil, err
+		}
+		if err := c.factory.getOperationError(txnOpOperation(op.Type), op.Path); err != nil {
+			return nil, err
+		}
+		if err := c.checkTxnOpLocked(op); err != nil {
+			return nil, err
+		}
+	}
+
+	versions := make([]topo.Version, len(ops))
+	for i, op := range ops {
+		if op.Contents == nil {
+			op.Contents = []byte{}
+		}
+		var err error
+		switch op.Type {
+		case topo.TxnCreate:
+			versions[i], err = c.createLocked(op.Path, op.Contents)
+		case topo.TxnUpdate:
+			versions[i], err = c.updateLocked(op.Path, op.Contents, op.Version)
+		case topo.TxnDelete:
+			err = c.deleteLocked(op.Path, op.Version)
+		}
+		if err != nil {
+			// This cannot happen as we checked all the operations above.
+			return nil, vterrors.Wrapf(err, "memorytopo transaction failed after its checks succeeded")
+		}
+	}
+	return versions, nil
+}
+
+// txnOpOperation returns the Operation matching a transaction operation.
+func txnOpOperation(opType topo.TxnOpType) Operation {
+	switch opType {
+	case topo.TxnCreate:
+		return Create
+	case topo.TxnUpdate:
+		return Update
+	case topo.TxnDelete:
+		return Delete
+	default:
+		return Get
+	}
+}
+
+// checkTxnOpLocked returns an error if the precondition of op does not
+// hold. The factory mutex must be held.
+func (c *Conn) checkTxnOpLocked(op topo.TxnOp) error {
+	n := c.factory.nodeByPath(c.cell, op.Path)
+	if n != nil && n.isDirectory() {
+		return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "%v(%v, %v) failed: it's a directory", op.Type, c.cell, op.Path)
+	}
+
+	if n == nil {
+		switch {
+		case op.Type == topo.TxnCreate, op.Type == topo.TxnUpdate && op.Version == nil:
+			dir, _ := path.Split(op.Path)
+			if c.factory.pathContainsFile(c.cell, dir) {
+				return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "trying to create file %v in cell %v in a path that contains files", op.Path, c.cell)
+			}
+			return nil
+		default:
+			return topo.NewError(topo.NoNode, op.Path)
+		}
+	}
+
+	if op.Type == topo.TxnCreate {
+		return topo.NewError(topo.NodeExists, op.Path)
+	}
+	if op.Version != nil && n.version != uint64(op.Version.(NodeVersion)) {
+		return topo.NewError(topo.BadVersion, op.Path)
+	}
+	return nil
+}
@@ -68,6 +68,7 @@ const (
 	WatchRecursive
 	NewLeaderParticipation
 	Close
+	Txn
 )
 
 // Factory is a memory-based implementation of topo.Factory.  It
@@ -378,6 +379,34 @@ func (f *Factory) getOrCreatePath(cell, filePath string) *node {
 	return n
 }
 
+// pathContainsFile returns true if one of the components of dirPath is
+// an existing file, in which case no file can be created under it.
+func (f *Factory) pathContainsFile(cell, dirPath string) bool {
+	n, ok := f.cells[cell]
+	if !ok {
+		return true
+	}
+
+	parts := strings.Split(dirPath, "/")
+	for _, part := range parts {
+		if part == "" {
+			// Skip empty parts, usually happens at the end.
+			continue
+		}
+		if n.children == nil {
+			// This is a file.
+			return true
+		}
+		child, ok := n.children[part]
+		if !ok {
+			// Path doesn't exist, it will be created.
+			return false
+		}
+		n = child
+	}
+	return n.children == nil
+}
+
 // recursiveDelete deletes a node and its parent directory if empty.
 func (f *Factory) recursiveDelete(n *node) {
 	parent := n.parent
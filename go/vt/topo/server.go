@@ -84,6 +84,7 @@ const (
 	ShardRoutingRulesFile  = "ShardRoutingRules"
 	CommonRoutingRulesFile = "Rules"
 	MirrorRulesFile        = "MirrorRules"
+	AuditLogFile           = "AuditLog"
 )
 
 // Path for all object types.
@@ -21,6 +21,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"path"
+	"sort"
 	"sync"
 
 	"vitess.io/vitess/go/vt/vterrors"
@@ -676,21 +677,58 @@ func (ts *Server) GetSrvKeyspaceAllCells(ctx context.Context, keyspace string) (
 
 // GetSrvKeyspace returns the SrvKeyspace for a cell/keyspace.
 func (ts *Server) GetSrvKeyspace(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, error) {
+	srvKeyspace, _, err := ts.GetSrvKeyspaceWithVersion(ctx, cell, keyspace)
+	return srvKeyspace, err
+}
+
+// GetSrvKeyspaceWithVersion returns the SrvKeyspace for a cell/keyspace,
+// along with its version, to be used with UpdateSrvKeyspaces.
+func (ts *Server) GetSrvKeyspaceWithVersion(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, Version, error) {
 	conn, err := ts.ConnForCell(ctx, cell)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	nodePath := srvKeyspaceFileName(keyspace)
-	data, _, err := conn.Get(ctx, nodePath)
+	data, version, err := conn.Get(ctx, nodePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	srvKeyspace := &topodatapb.SrvKeyspace{}
 	if err := srvKeyspace.UnmarshalVT(data); err != nil {
-		return nil, vterrors.Wrapf(err, "SrvKeyspace unmarshal failed: %v", data)
+		return nil, nil, vterrors.Wrapf(err, "SrvKeyspace unmarshal failed: %v", data)
 	}
-	return srvKeyspace, nil
+	return srvKeyspace, version, nil
+}
+
+// UpdateSrvKeyspaces saves the provided SrvKeyspace objects of a cell,
+// indexed by keyspace name, in a single topo transaction. versions has
+// the version each SrvKeyspace was read at, as returned by
+// GetSrvKeyspaceWithVersion: the transaction fails with a BadVersion
+// error if any of them changed since. A SrvKeyspace without a version
+// is written unconditionally, as UpdateSrvKeyspace does.
+func (ts *Server) UpdateSrvKeyspaces(ctx context.Context, cell string, srvKeyspaces map[string]*topodatapb.SrvKeyspace, versions map[string]Version) error {
+	keyspaces := make([]string, 0, len(srvKeyspaces))
+	for keyspace := range srvKeyspaces {
+		keyspaces = append(keyspaces, keyspace)
+	}
+	sort.Strings(keyspaces)
+
+	ops := make([]TxnOp, 0, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		data, err := srvKeyspaces[keyspace].MarshalVT()
+		if err != nil {
+			return err
+		}
+		ops = append(ops, TxnOp{
+			Type:     TxnUpdate,
+			Path:     srvKeyspaceFileName(keyspace),
+			Contents: data,
+			Version:  versions[keyspace],
+		})
+	}
+	_, err := ts.Txn(ctx, cell, ops)
+	return err
 }
 
 // OrderAndCheckPartitions will re-order the partition list, and check
@@ -27,7 +27,10 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 )
 
-var _ Conn = (*StatsConn)(nil)
+var (
+	_ Conn              = (*StatsConn)(nil)
+	_ TransactionalConn = (*StatsConn)(nil)
+)
 
 var (
 	topoStatsConnTimings = stats.NewMultiTimings(
@@ -190,6 +193,28 @@ func (st *StatsConn) Delete(ctx context.Context, filePath string, version Versio
 	return err
 }
 
+// Txn is part of the TransactionalConn interface. It uses ApplyTxn on
+// the underlying Conn, so it falls back to applying the operations one
+// at a time if that Conn is not transactional.
+func (st *StatsConn) Txn(ctx context.Context, ops []TxnOp) ([]Version, error) {
+	statsKey := []string{"Txn", st.cell}
+	if st.readOnly {
+		var filePath string
+		if len(ops) > 0 {
+			filePath = ops[0].Path
+		}
+		return nil, vterrors.Errorf(vtrpc.Code_READ_ONLY, readOnlyErrorStrFormat, statsKey[0], filePath)
+	}
+	startTime := time.Now()
+	defer topoStatsConnTimings.Record(statsKey, startTime)
+	res, err := ApplyTxn(ctx, st.conn, ops)
+	if err != nil {
+		topoStatsConnErrors.Add(statsKey, int64(1))
+		return res, err
+	}
+	return res, err
+}
+
 // Lock is part of the Conn interface
 func (st *StatsConn) Lock(ctx context.Context, dirPath, contents string) (LockDescriptor, error) {
 	return st.internalLock(ctx, dirPath, contents, Blocking, 0)
@@ -329,6 +329,35 @@ func TestStatsConnTopoDelete(t *testing.T) {
 	require.Equal(t, int64(1), topoStatsConnErrors.Counts()["Delete.global"])
 }
 
+// TestStatsConnTopoTxn emits stats on Txn
+func TestStatsConnTopoTxn(t *testing.T) {
+	testStatsConnStatsReset()
+	defer testStatsConnStatsReset()
+
+	conn := &fakeConn{}
+	statsConn := NewStatsConn("global", conn, testStatsConnReadSem)
+	ctx := context.Background()
+
+	_, err := statsConn.Txn(ctx, []TxnOp{{Type: TxnUpdate, Path: "", Contents: []byte("a")}})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), topoStatsConnTimings.Counts()["Txn.global"])
+	require.NotZero(t, topoStatsConnTimings.Time())
+
+	// error is zero before getting an error
+	require.Zero(t, topoStatsConnErrors.Counts()["Txn.global"])
+
+	_, err = statsConn.Txn(ctx, []TxnOp{{Type: TxnUpdate, Path: "error", Contents: []byte("a")}})
+	require.Error(t, err)
+
+	// error stats gets emitted
+	require.Equal(t, int64(1), topoStatsConnErrors.Counts()["Txn.global"])
+
+	// read-only connections refuse transactions
+	statsConn.SetReadOnly(true)
+	_, err = statsConn.Txn(ctx, []TxnOp{{Type: TxnUpdate, Path: "RoutingRules", Contents: []byte("a")}})
+	require.ErrorContains(t, err, "cannot perform Txn on RoutingRules as the topology server connection is read-only")
+}
+
 // TestStatsConnTopoLock emits stats on Lock
 func TestStatsConnTopoLock(t *testing.T) {
 	testStatsConnStatsReset()
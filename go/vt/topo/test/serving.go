@@ -79,6 +79,39 @@ func checkSrvKeyspace(t *testing.T, ctx context.Context, ts *topo.Server) {
 	if _, err := ts.GetSrvKeyspace(ctx, LocalCellName, "unknown_keyspace_so_far"); !topo.IsErrType(err, topo.NoNode) {
 		t.Errorf("GetSrvKeyspace(deleted) got %v, want ErrNoNode", err)
 	}
+
+	// Update several SrvKeyspace objects at once, based on the versions
+	// they were read at.
+	_, version, err := ts.GetSrvKeyspaceWithVersion(ctx, LocalCellName, "test_keyspace")
+	if err != nil {
+		t.Fatalf("GetSrvKeyspaceWithVersion: %v", err)
+	}
+	srvKeyspace2 := proto.Clone(srvKeyspace).(*topodatapb.SrvKeyspace)
+	srvKeyspace2.Partitions[0].ShardReferences[0].Name = "-40"
+	srvKeyspaces := map[string]*topodatapb.SrvKeyspace{
+		"test_keyspace":  srvKeyspace2,
+		"test_keyspace2": srvKeyspace2,
+	}
+	versions := map[string]topo.Version{
+		"test_keyspace": version,
+	}
+	if err := ts.UpdateSrvKeyspaces(ctx, LocalCellName, srvKeyspaces, versions); err != nil {
+		t.Fatalf("UpdateSrvKeyspaces: %v", err)
+	}
+	for keyspace := range srvKeyspaces {
+		if k, err := ts.GetSrvKeyspace(ctx, LocalCellName, keyspace); err != nil || !proto.Equal(srvKeyspace2, k) {
+			t.Errorf("GetSrvKeyspace(%v) after UpdateSrvKeyspaces: %v %v", keyspace, err, k)
+		}
+	}
+
+	// Stale versions fail the whole update.
+	srvKeyspaces["test_keyspace3"] = srvKeyspace
+	if err := ts.UpdateSrvKeyspaces(ctx, LocalCellName, srvKeyspaces, versions); !topo.IsErrType(err, topo.BadVersion) {
+		t.Errorf("UpdateSrvKeyspaces(stale) got %v, want ErrBadVersion", err)
+	}
+	if _, err := ts.GetSrvKeyspace(ctx, LocalCellName, "test_keyspace3"); !topo.IsErrType(err, topo.NoNode) {
+		t.Errorf("GetSrvKeyspace(failed update) got %v, want ErrNoNode", err)
+	}
 }
 
 // checkSrvVSchema tests the SrvVSchema methods (other than watch).
@@ -155,4 +155,9 @@ func TopoServerTestSuite(t *testing.T, ctx context.Context, factory func() *topo
 	t.Log("=== checkWatchRecursive")
 	executeTestSuite(checkWatchRecursive, t, ctx, ts, ignoreList, "checkWatchRecursive")
 	ts.Close()
+
+	ts = factory()
+	t.Log("=== checkTxn")
+	executeTestSuite(checkTxn, t, ctx, ts, ignoreList, "checkTxn")
+	ts.Close()
 }
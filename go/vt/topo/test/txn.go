@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// checkTxn tests the transaction part of the Conn API.
+func checkTxn(t *testing.T, ctx context.Context, ts *topo.Server) {
+	t.Log("===   checkTxnInCell global")
+	checkTxnInCell(t, ctx, ts, topo.GlobalCell)
+
+	t.Log("===   checkTxnInCell test")
+	checkTxnInCell(t, ctx, ts, LocalCellName)
+}
+
+func checkTxnInCell(t *testing.T, ctx context.Context, ts *topo.Server, cell string) {
+	conn, err := ts.ConnForCell(ctx, cell)
+	require.NoError(t, err)
+
+	versionA, err := conn.Create(ctx, "/txn/a", []byte("a1"))
+	require.NoError(t, err)
+
+	// Update a file and create another one.
+	versions, err := ts.Txn(ctx, cell, []topo.TxnOp{
+		{Type: topo.TxnUpdate, Path: "/txn/a", Contents: []byte("a2"), Version: versionA},
+		{Type: topo.TxnCreate, Path: "/txn/dir/b", Contents: []byte("b1")},
+	})
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	checkTxnFile(t, ctx, conn, "/txn/a", "a2", versions[0])
+	checkTxnFile(t, ctx, conn, "/txn/dir/b", "b1", versions[1])
+	versionA, versionB := versions[0], versions[1]
+
+	// A stale version fails the whole transaction.
+	staleVersionA := versionA
+	versionA, err = conn.Update(ctx, "/txn/a", []byte("a3"), versionA)
+	require.NoError(t, err)
+	_, err = ts.Txn(ctx, cell, []topo.TxnOp{
+		{Type: topo.TxnCreate, Path: "/txn/c", Contents: []byte("c1")},
+		{Type: topo.TxnUpdate, Path: "/txn/dir/b", Contents: []byte("b2"), Version: versionB},
+		{Type: topo.TxnUpdate, Path: "/txn/a", Contents: []byte("a4"), Version: staleVersionA},
+	})
+	require.Truef(t, topo.IsErrType(err, topo.BadVersion), "expected BadVersion, got %v", err)
+	_, _, err = conn.Get(ctx, "/txn/c")
+	require.Truef(t, topo.IsErrType(err, topo.NoNode), "expected NoNode, got %v", err)
+	checkTxnFile(t, ctx, conn, "/txn/dir/b", "b1", versionB)
+	checkTxnFile(t, ctx, conn, "/txn/a", "a3", versionA)
+
+	// Creating an existing file fails the whole transaction.
+	_, err = ts.Txn(ctx, cell, []topo.TxnOp{
+		{Type: topo.TxnUpdate, Path: "/txn/a", Contents: []byte("a4"), Version: versionA},
+		{Type: topo.TxnCreate, Path: "/txn/dir/b", Contents: []byte("b2")},
+	})
+	require.Truef(t, topo.IsErrType(err, topo.NodeExists), "expected NodeExists, got %v", err)
+	checkTxnFile(t, ctx, conn, "/txn/a", "a3", versionA)
+
+	// Checking or deleting a missing file fails the whole transaction.
+	for _, opType := range []topo.TxnOpType{topo.TxnCheck, topo.TxnDelete} {
+		_, err = ts.Txn(ctx, cell, []topo.TxnOp{
+			{Type: topo.TxnUpdate, Path: "/txn/a", Contents: []byte("a4"), Version: versionA},
+			{Type: opType, Path: "/txn/missing"},
+		})
+		require.Truef(t, topo.IsErrType(err, topo.NoNode), "expected NoNode for %v, got %v", opType, err)
+		checkTxnFile(t, ctx, conn, "/txn/a", "a3", versionA)
+	}
+
+	// Unconditional update of a missing file creates it, and files
+	// can be deleted conditionally, after checking another one.
+	versions, err = ts.Txn(ctx, cell, []topo.TxnOp{
+		{Type: topo.TxnUpdate, Path: "/txn/c", Contents: []byte("c1")},
+		{Type: topo.TxnCheck, Path: "/txn/a", Version: versionA},
+		{Type: topo.TxnDelete, Path: "/txn/dir/b", Version: versionB},
+	})
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	checkTxnFile(t, ctx, conn, "/txn/c", "c1", versions[0])
+	require.Nil(t, versions[1])
+	require.Nil(t, versions[2])
+	_, _, err = conn.Get(ctx, "/txn/dir/b")
+	require.Truef(t, topo.IsErrType(err, topo.NoNode), "expected NoNode, got %v", err)
+
+	// Invalid transactions are rejected.
+	_, err = ts.Txn(ctx, cell, nil)
+	require.ErrorContains(t, err, "topo transaction has no operations")
+	_, err = ts.Txn(ctx, cell, []topo.TxnOp{
+		{Type: topo.TxnDelete, Path: "/txn/a"},
+		{Type: topo.TxnCreate, Path: "/txn/a"},
+	})
+	require.ErrorContains(t, err, "topo transaction has several operations on /txn/a")
+
+	// Clean up.
+	for _, p := range []string{"/txn/a", "/txn/c"} {
+		require.NoError(t, conn.Delete(ctx, p, nil))
+	}
+}
+
+// checkTxnFile checks the contents and version of a file.
+func checkTxnFile(t *testing.T, ctx context.Context, conn topo.Conn, filePath, contents string, version topo.Version) {
+	data, v, err := conn.Get(ctx, filePath)
+	require.NoError(t, err)
+	require.Equal(t, contents, string(data))
+	require.Equal(t, version.String(), v.String())
+}
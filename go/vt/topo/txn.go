@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"fmt"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// ApplyTxn applies the provided operations on conn. If conn implements
+// TransactionalConn, the operations are applied atomically. Otherwise,
+// the preconditions of all the operations are checked first, and the
+// operations are then applied one at a time: a concurrent change or a
+// failure half-way through can then leave some of them applied, in
+// which case the returned error is a PartialResult error wrapping the
+// original one.
+// See TransactionalConn for the semantics of the operations.
+func ApplyTxn(ctx context.Context, conn Conn, ops []TxnOp) ([]Version, error) {
+	if err := validateTxnOps(ops); err != nil {
+		return nil, err
+	}
+	if tc, ok := conn.(TransactionalConn); ok {
+		return tc.Txn(ctx, ops)
+	}
+	return applyTxnSequentially(ctx, conn, ops)
+}
+
+// Txn applies the provided operations in the given cell, which can be
+// GlobalCell, with ApplyTxn.
+func (ts *Server) Txn(ctx context.Context, cell string, ops []TxnOp) ([]Version, error) {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyTxn(ctx, conn, ops)
+}
+
+// validateTxnOps makes sure the operations of a transaction are well formed.
+func validateTxnOps(ops []TxnOp) error {
+	if len(ops) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "topo transaction has no operations")
+	}
+	paths := make(map[string]bool, len(ops))
+	for _, op := range ops {
+		switch op.Type {
+		case TxnCreate:
+			if op.Version != nil {
+				return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "topo transaction cannot create %s with a version", op.Path)
+			}
+		case TxnUpdate, TxnDelete, TxnCheck:
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid topo transaction operation %v on %s", op.Type, op.Path)
+		}
+		if paths[op.Path] {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "topo transaction has several operations on %s", op.Path)
+		}
+		paths[op.Path] = true
+	}
+	return nil
+}
+
+// applyTxnSequentially is the ApplyTxn fallback for Conn plug-ins that
+// do not implement TransactionalConn.
+func applyTxnSequentially(ctx context.Context, conn Conn, ops []TxnOp) ([]Version, error) {
+	// Check all the preconditions before changing anything, so the
+	// common failures (a stale version, an existing file) do not leave
+	// a partially applied transaction behind.
+	for _, op := range ops {
+		if err := checkTxnOp(ctx, conn, op); err != nil {
+			return nil, err
+		}
+	}
+
+	versions := make([]Version, len(ops))
+	applied := 0
+	for i, op := range ops {
+		var err error
+		switch op.Type {
+		case TxnCreate:
+			versions[i], err = conn.Create(ctx, op.Path, op.Contents)
+		case TxnUpdate:
+			versions[i], err = conn.Update(ctx, op.Path, op.Contents, op.Version)
+		case TxnDelete:
+			err = conn.Delete(ctx, op.Path, op.Version)
+		case TxnCheck:
+			// Already checked above.
+			continue
+		}
+		if err != nil {
+			if applied == 0 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %d of %d topo transaction operations were applied before %v on %s failed: %w",
+				NewError(PartialResult, op.Path), applied, len(ops), op.Type, op.Path, err)
+		}
+		applied++
+	}
+	return versions, nil
+}
+
+// checkTxnOp returns an error if the precondition of op does not hold.
+func checkTxnOp(ctx context.Context, conn Conn, op TxnOp) error {
+	_, version, err := conn.Get(ctx, op.Path)
+	switch {
+	case err == nil:
+	case IsErrType(err, NoNode):
+		if op.Type == TxnCreate || (op.Type == TxnUpdate && op.Version == nil) {
+			return nil
+		}
+		return err
+	default:
+		return err
+	}
+
+	switch op.Type {
+	case TxnCreate:
+		return NewError(NodeExists, op.Path)
+	default:
+		if op.Version != nil && op.Version.String() != version.String() {
+			return NewError(BadVersion, op.Path)
+		}
+	}
+	return nil
+}
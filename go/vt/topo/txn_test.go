@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+// nonTransactionalConn hides the Txn method of the wrapped Conn, so
+// ApplyTxn has to use its fallback.
+type nonTransactionalConn struct {
+	topo.Conn
+}
+
+func TestApplyTxnFallback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts, factory := memorytopo.NewServerAndFactory(ctx, "zone1")
+	defer ts.Close()
+
+	globalConn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	conn := &nonTransactionalConn{globalConn}
+	_, ok := topo.Conn(conn).(topo.TransactionalConn)
+	require.False(t, ok)
+
+	versionA, err := conn.Create(ctx, "a", []byte("a1"))
+	require.NoError(t, err)
+
+	versions, err := topo.ApplyTxn(ctx, conn, []topo.TxnOp{
+		{Type: topo.TxnUpdate, Path: "a", Contents: []byte("a2"), Version: versionA},
+		{Type: topo.TxnCreate, Path: "b", Contents: []byte("b1")},
+	})
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	versionA, versionB := versions[0], versions[1]
+
+	// Preconditions are all checked before anything is applied.
+	_, err = topo.ApplyTxn(ctx, conn, []topo.TxnOp{
+		{Type: topo.TxnUpdate, Path: "a", Contents: []byte("a3"), Version: versionA},
+		{Type: topo.TxnCreate, Path: "c", Contents: []byte("c1")},
+		{Type: topo.TxnCheck, Path: "b", Version: versionA},
+	})
+	require.True(t, topo.IsErrType(err, topo.BadVersion), "expected BadVersion, got %v", err)
+	data, _, err := conn.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "a2", string(data))
+	_, _, err = conn.Get(ctx, "c")
+	require.True(t, topo.IsErrType(err, topo.NoNode), "expected NoNode, got %v", err)
+
+	// A failure after some operations were applied is reported as a
+	// partial result.
+	factory.AddOperationError(memorytopo.Delete, "b", errors.New("delete failed"))
+	_, err = topo.ApplyTxn(ctx, conn, []topo.TxnOp{
+		{Type: topo.TxnUpdate, Path: "a", Contents: []byte("a3"), Version: versionA},
+		{Type: topo.TxnDelete, Path: "b", Version: versionB},
+	})
+	require.True(t, topo.IsErrType(err, topo.PartialResult), "expected PartialResult, got %v", err)
+	require.ErrorContains(t, err, "1 of 2 topo transaction operations were applied before Delete on b failed: delete failed")
+	data, _, err = conn.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "a3", string(data))
+}
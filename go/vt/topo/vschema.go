@@ -17,6 +17,7 @@ limitations under the License.
 package topo
 
 import (
+	"bytes"
 	"context"
 	"path"
 
@@ -284,6 +285,143 @@ func (ts *Server) GetKeyspaceRoutingRules(ctx context.Context) (*vschemapb.Keysp
 	return rules, nil
 }
 
+// AllRoutingRules holds the table, shard and keyspace routing rules,
+// along with the versions they were read at. It is returned by
+// GetAllRoutingRules, and can be saved back atomically with
+// SaveAllRoutingRules.
+type AllRoutingRules struct {
+	RoutingRules         *vschemapb.RoutingRules
+	ShardRoutingRules    *vschemapb.ShardRoutingRules
+	KeyspaceRoutingRules *vschemapb.KeyspaceRoutingRules
+
+	// read has the data and version of each file when it was last
+	// read or saved, indexed by path. Missing files have no entry.
+	read map[string]routingRulesFile
+}
+
+type routingRulesFile struct {
+	data    []byte
+	version Version
+}
+
+// routingRulesMessage is implemented by the routing rules protos.
+type routingRulesMessage interface {
+	MarshalVT() ([]byte, error)
+	UnmarshalVT([]byte) error
+}
+
+// routingRulesEntry describes one kind of routing rules in AllRoutingRules.
+type routingRulesEntry struct {
+	path    string
+	message routingRulesMessage
+	// deleteIfEmpty is set if the file is deleted when it is empty.
+	deleteIfEmpty bool
+}
+
+// entries returns the path and current message of each kind of
+// routing rules.
+func (rules *AllRoutingRules) entries(ts *Server) []routingRulesEntry {
+	return []routingRulesEntry{
+		{RoutingRulesFile, rules.RoutingRules, true},
+		{ShardRoutingRulesFile, rules.ShardRoutingRules, true},
+		// See SaveKeyspaceRoutingRules for why this one is never deleted.
+		{ts.GetKeyspaceRoutingRulesPath(), rules.KeyspaceRoutingRules, false},
+	}
+}
+
+// GetAllRoutingRules fetches the table, shard and keyspace routing rules
+// from the topo. Missing rules are returned as empty messages.
+func (ts *Server) GetAllRoutingRules(ctx context.Context) (*AllRoutingRules, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rules := &AllRoutingRules{
+		RoutingRules:         &vschemapb.RoutingRules{},
+		ShardRoutingRules:    &vschemapb.ShardRoutingRules{},
+		KeyspaceRoutingRules: &vschemapb.KeyspaceRoutingRules{},
+		read:                 make(map[string]routingRulesFile),
+	}
+	for _, file := range rules.entries(ts) {
+		data, version, err := ts.globalCell.Get(ctx, file.path)
+		if err != nil {
+			if IsErrType(err, NoNode) {
+				continue
+			}
+			return nil, err
+		}
+		if err := file.message.UnmarshalVT(data); err != nil {
+			return nil, vterrors.Wrapf(err, "bad %s data: %q", file.path, data)
+		}
+		rules.read[file.path] = routingRulesFile{data: data, version: version}
+	}
+	return rules, nil
+}
+
+// SaveAllRoutingRules saves the routing rules returned by
+// GetAllRoutingRules in a single topo transaction. The transaction fails
+// with a BadVersion, NoNode or NodeExists error if any of the routing
+// rules were changed since they were read, including the ones that were
+// not modified, so the saved rules are always consistent with each other.
+// Routing rules that did not exist when they were read and are still
+// empty are not part of the transaction, so their creation in the
+// meantime is not detected.
+// As with SaveRoutingRules and SaveShardRoutingRules, empty table and
+// shard routing rules are deleted.
+func (ts *Server) SaveAllRoutingRules(ctx context.Context, rules *AllRoutingRules) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var ops []TxnOp
+	changed := false
+	for _, file := range rules.entries(ts) {
+		data, err := file.message.MarshalVT()
+		if err != nil {
+			return err
+		}
+		read, exists := rules.read[file.path]
+		if bytes.Equal(data, read.data) {
+			if exists {
+				ops = append(ops, TxnOp{Type: TxnCheck, Path: file.path, Version: read.version})
+			}
+			continue
+		}
+
+		switch {
+		case len(data) == 0 && file.deleteIfEmpty:
+			ops = append(ops, TxnOp{Type: TxnDelete, Path: file.path, Version: read.version})
+		case !exists:
+			ops = append(ops, TxnOp{Type: TxnCreate, Path: file.path, Contents: data})
+		default:
+			ops = append(ops, TxnOp{Type: TxnUpdate, Path: file.path, Contents: data, Version: read.version})
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	versions, err := ts.Txn(ctx, GlobalCell, ops)
+	if err != nil {
+		return err
+	}
+
+	// Remember what we saved, so the rules can be modified and
+	// saved again.
+	for i, op := range ops {
+		if op.Type == TxnCheck {
+			continue
+		}
+		if op.Type == TxnDelete {
+			delete(rules.read, op.Path)
+			continue
+		}
+		rules.read[op.Path] = routingRulesFile{data: op.Contents, version: versions[i]}
+	}
+	return nil
+}
+
 // GetMirrorRules fetches the mirror rules from the topo.
 func (ts *Server) GetMirrorRules(ctx context.Context) (*vschemapb.MirrorRules, error) {
 	if err := ctx.Err(); err != nil {
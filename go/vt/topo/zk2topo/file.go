@@ -135,3 +135,97 @@ func (zs *Server) recursiveDeleteParentIfEmpty(ctx context.Context, filePath str
 		return err
 	}
 }
+
+var _ topo.TransactionalConn = (*Server)(nil)
+
+// Txn is part of the topo.TransactionalConn interface. It uses a single
+// zookeeper multi request. As multi requests cannot create missing
+// parent directories, these are created beforehand, outside of the
+// transaction, and an unconditional update is turned into a create or
+// a set based on whether the file exists when the request is built.
+func (zs *Server) Txn(ctx context.Context, ops []topo.TxnOp) ([]topo.Version, error) {
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	zkPaths := make([]string, len(ops))
+	requests := make([]any, len(ops))
+	for i, op := range ops {
+		zkPath := path.Join(zs.root, op.Path)
+		zkPaths[i] = zkPath
+
+		// Interpret the version
+		var zkVersion int32
+		if op.Version != nil {
+			zkVersion = int32(op.Version.(ZKVersion))
+		} else {
+			zkVersion = -1
+		}
+
+		create := op.Type == topo.TxnCreate
+		if op.Type == topo.TxnUpdate && op.Version == nil {
+			exists, _, err := zs.conn.Exists(ctx, zkPath)
+			if err != nil {
+				return nil, convertError(err, zkPath)
+			}
+			create = !exists
+		}
+
+		switch {
+		case create:
+			if err := zs.createParentDirectories(ctx, zkPath); err != nil {
+				return nil, convertError(err, zkPath)
+			}
+			requests[i] = &zk.CreateRequest{Path: zkPath, Data: op.Contents, Acl: zk.WorldACL(PermFile)}
+		case op.Type == topo.TxnUpdate:
+			requests[i] = &zk.SetDataRequest{Path: zkPath, Data: op.Contents, Version: zkVersion}
+		case op.Type == topo.TxnDelete:
+			requests[i] = &zk.DeleteRequest{Path: zkPath, Version: zkVersion}
+		case op.Type == topo.TxnCheck:
+			requests[i] = &zk.CheckVersionRequest{Path: zkPath, Version: zkVersion}
+		}
+	}
+
+	responses, err := zs.conn.Multi(ctx, requests...)
+	if err != nil {
+		// Report the error on the path of the operation that failed.
+		for i, resp := range responses {
+			if i < len(zkPaths) && resp.Error == err {
+				return nil, convertError(err, zkPaths[i])
+			}
+		}
+		return nil, convertError(err, zkPaths[0])
+	}
+	if len(responses) != len(ops) {
+		return nil, fmt.Errorf("zk multi request returned %d responses for %d operations", len(responses), len(ops))
+	}
+
+	versions := make([]topo.Version, len(ops))
+	for i, op := range ops {
+		switch req := requests[i].(type) {
+		case *zk.CreateRequest:
+			// A newly created node always has version 0.
+			versions[i] = ZKVersion(0)
+		case *zk.SetDataRequest:
+			if responses[i].Stat == nil {
+				return nil, fmt.Errorf("zk multi request returned no stat for %s", req.Path)
+			}
+			versions[i] = ZKVersion(responses[i].Stat.Version)
+		case *zk.DeleteRequest:
+			if err := zs.recursiveDeleteParentIfEmpty(ctx, op.Path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return versions, nil
+}
+
+// createParentDirectories creates the missing parent directories of
+// zkPath, like CreateRecursive does for the file itself.
+func (zs *Server) createParentDirectories(ctx context.Context, zkPath string) error {
+	_, err := CreateRecursive(ctx, zs.conn, path.Dir(zkPath), nil, 0, zk.WorldACL(PermDirectory), -1)
+	if err != nil && err != zk.ErrNodeExists {
+		return err
+	}
+	return nil
+}
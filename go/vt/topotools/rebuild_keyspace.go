@@ -104,17 +104,18 @@ func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Ser
 	// srvKeyspaceMap is a map:
 	//   key: cell
 	//   value: topo.SrvKeyspace object being built
+	//
+	// srvKeyspaceVersions has the version of the existing SrvKeyspace
+	// objects, so we do not overwrite them if they changed since.
 	srvKeyspaceMap := make(map[string]*topodatapb.SrvKeyspace)
+	srvKeyspaceVersions := make(map[string]topo.Version)
 	for _, cell := range cells {
-		srvKeyspace, err := ts.GetSrvKeyspace(ctx, cell, keyspace)
+		srvKeyspace, version, err := ts.GetSrvKeyspaceWithVersion(ctx, cell, keyspace)
 		switch {
 		case err == nil:
-			for _, partition := range srvKeyspace.GetPartitions() {
-				for _, shardTabletControl := range partition.GetShardTabletControls() {
-					if shardTabletControl.QueryServiceDisabled {
-						return fmt.Errorf("can't rebuild serving keyspace while a migration is on going. TabletControls is set for partition %v", partition)
-					}
-				}
+			srvKeyspaceVersions[cell] = version
+			if err := checkNoMigration(srvKeyspace); err != nil {
+				return err
 			}
 		case topo.IsErrType(err, topo.NoNode):
 			// NOOP
@@ -171,7 +172,7 @@ func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Ser
 		wg.Add(1)
 		go func(cell string, srvKeyspace *topodatapb.SrvKeyspace) {
 			defer wg.Done()
-			if err := ts.UpdateSrvKeyspace(ctx, cell, keyspace, srvKeyspace); err != nil {
+			if err := saveRebuiltSrvKeyspace(ctx, ts, cell, keyspace, srvKeyspace, srvKeyspaceVersions[cell]); err != nil {
 				rec.RecordError(fmt.Errorf("writing serving data failed: %v", err))
 			}
 		}(cell, srvKeyspace)
@@ -179,3 +180,48 @@ func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Ser
 	wg.Wait()
 	return rec.Error()
 }
+
+// rebuildKeyspaceRetries is the number of times the rebuilt SrvKeyspace of
+// a cell is saved again after it was changed concurrently.
+const rebuildKeyspaceRetries = 3
+
+// checkNoMigration returns an error if a migration is on going in the
+// SrvKeyspace, as its tablet controls would be lost by the rebuild.
+func checkNoMigration(srvKeyspace *topodatapb.SrvKeyspace) error {
+	for _, partition := range srvKeyspace.GetPartitions() {
+		for _, shardTabletControl := range partition.GetShardTabletControls() {
+			if shardTabletControl.QueryServiceDisabled {
+				return fmt.Errorf("can't rebuild serving keyspace while a migration is on going. TabletControls is set for partition %v", partition)
+			}
+		}
+	}
+	return nil
+}
+
+// saveRebuiltSrvKeyspace saves the rebuilt SrvKeyspace of a cell, unless
+// it changed since it was read at version. If it did, e.g. because traffic
+// was switched meanwhile, it is read again, and saved as long as it can
+// still be rebuilt.
+func saveRebuiltSrvKeyspace(ctx context.Context, ts *topo.Server, cell, keyspace string, srvKeyspace *topodatapb.SrvKeyspace, version topo.Version) error {
+	for attempt := 0; ; attempt++ {
+		srvKeyspaces := map[string]*topodatapb.SrvKeyspace{keyspace: srvKeyspace}
+		versions := map[string]topo.Version{keyspace: version}
+		err := ts.UpdateSrvKeyspaces(ctx, cell, srvKeyspaces, versions)
+		if !topo.IsErrType(err, topo.BadVersion) || attempt == rebuildKeyspaceRetries {
+			return err
+		}
+
+		current, currentVersion, err := ts.GetSrvKeyspaceWithVersion(ctx, cell, keyspace)
+		switch {
+		case err == nil:
+			if err := checkNoMigration(current); err != nil {
+				return err
+			}
+			version = currentVersion
+		case topo.IsErrType(err, topo.NoNode):
+			version = nil
+		default:
+			return err
+		}
+	}
+}
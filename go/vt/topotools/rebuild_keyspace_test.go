@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// concurrentChangeFactory creates the connections of a memorytopo Factory
+// which, before each transaction in a cell, save the next of the changes as
+// the SrvKeyspace of ks, as if it was changed concurrently.
+type concurrentChangeFactory struct {
+	*memorytopo.Factory
+	changes []*topodatapb.SrvKeyspace
+	txns    int
+}
+
+func (f *concurrentChangeFactory) Create(cell, serverAddr, root string) (topo.Conn, error) {
+	conn, err := f.Factory.Create(cell, serverAddr, root)
+	if err != nil || cell == topo.GlobalCell {
+		return conn, err
+	}
+	return &concurrentChangeConn{Conn: conn, factory: f}, nil
+}
+
+type concurrentChangeConn struct {
+	topo.Conn
+	factory *concurrentChangeFactory
+}
+
+func (c *concurrentChangeConn) Txn(ctx context.Context, ops []topo.TxnOp) ([]topo.Version, error) {
+	c.factory.txns++
+	if len(c.factory.changes) > 0 {
+		data, err := c.factory.changes[0].MarshalVT()
+		if err != nil {
+			return nil, err
+		}
+		c.factory.changes = c.factory.changes[1:]
+		if _, err := c.Conn.Update(ctx, path.Join(topo.KeyspacesPath, "ks", topo.SrvKeyspaceFile), data, nil); err != nil {
+			return nil, err
+		}
+	}
+	return topo.ApplyTxn(ctx, c.Conn, ops)
+}
+
+func TestRebuildKeyspaceConcurrentChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, factory := memorytopo.NewServerAndFactory(ctx, "cell1")
+	f := &concurrentChangeFactory{Factory: factory}
+	ts, err := topo.NewWithFactory(f, "", "")
+	require.NoError(t, err)
+	defer ts.Close()
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "0"))
+	require.NoError(t, RebuildKeyspace(ctx, logutil.NewMemoryLogger(), ts, "ks", []string{"cell1"}, false))
+
+	// The SrvKeyspace changed since it was read, so it is read again and
+	// the rebuild is saved.
+	f.changes = []*topodatapb.SrvKeyspace{{}, {}}
+	f.txns = 0
+	require.NoError(t, RebuildKeyspace(ctx, logutil.NewMemoryLogger(), ts, "ks", []string{"cell1"}, false))
+	assert.Equal(t, 3, f.txns)
+	srvKeyspace, err := ts.GetSrvKeyspace(ctx, "cell1", "ks")
+	require.NoError(t, err)
+	assert.Len(t, srvKeyspace.Partitions, 3)
+
+	// A migration started meanwhile, so the SrvKeyspace must not be
+	// rebuilt.
+	migrating := srvKeyspace.CloneVT()
+	migrating.Partitions[0].ShardTabletControls = []*topodatapb.ShardTabletControl{{Name: "0", QueryServiceDisabled: true}}
+	f.changes = []*topodatapb.SrvKeyspace{migrating}
+	err = RebuildKeyspace(ctx, logutil.NewMemoryLogger(), ts, "ks", []string{"cell1"}, false)
+	assert.ErrorContains(t, err, "can't rebuild serving keyspace while a migration is on going")
+	srvKeyspace, err = ts.GetSrvKeyspace(ctx, "cell1", "ks")
+	require.NoError(t, err)
+	assert.True(t, srvKeyspace.Partitions[0].ShardTabletControls[0].QueryServiceDisabled)
+}
@@ -19,6 +19,8 @@ package topotools
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"vitess.io/vitess/go/vt/log"
@@ -58,6 +60,12 @@ func GetRoutingRules(ctx context.Context, ts *topo.Server) (map[string][]string,
 func SaveRoutingRules(ctx context.Context, ts *topo.Server, rules map[string][]string) error {
 	log.Infof("Saving routing rules %v\n", rules)
 
+	return ts.SaveRoutingRules(ctx, buildRoutingRules(rules))
+}
+
+// buildRoutingRules builds a vschemapb.RoutingRules struct from a mapping of
+// fromTable=>[]toTables.
+func buildRoutingRules(rules map[string][]string) *vschemapb.RoutingRules {
 	rrs := &vschemapb.RoutingRules{Rules: make([]*vschemapb.RoutingRule, 0, len(rules))}
 	for from, to := range rules {
 		rrs.Rules = append(rrs.Rules, &vschemapb.RoutingRule{
@@ -65,8 +73,7 @@ func SaveRoutingRules(ctx context.Context, ts *topo.Server, rules map[string][]s
 			ToTables:  to,
 		})
 	}
-
-	return ts.SaveRoutingRules(ctx, rrs)
+	return rrs
 }
 
 // endregion
@@ -110,6 +117,12 @@ func GetShardRoutingRules(ctx context.Context, ts *topo.Server) (map[string]stri
 func SaveShardRoutingRules(ctx context.Context, ts *topo.Server, srr map[string]string) error {
 	log.Infof("Saving shard routing rules %v\n", srr)
 
+	return ts.SaveShardRoutingRules(ctx, buildShardRoutingRules(srr))
+}
+
+// buildShardRoutingRules builds a vschemapb.ShardRoutingRules struct from a
+// mapping of fromKeyspace.Shard=>toKeyspace.
+func buildShardRoutingRules(srr map[string]string) *vschemapb.ShardRoutingRules {
 	srs := &vschemapb.ShardRoutingRules{Rules: make([]*vschemapb.ShardRoutingRule, 0, len(srr))}
 	for from, to := range srr {
 		fromKeyspace, shard := ParseShardRoutingRuleKey(from)
@@ -119,8 +132,7 @@ func SaveShardRoutingRules(ctx context.Context, ts *topo.Server, srr map[string]
 			Shard:        shard,
 		})
 	}
-
-	return ts.SaveShardRoutingRules(ctx, srs)
+	return srs
 }
 
 // endregion
@@ -213,3 +225,72 @@ func UpdateKeyspaceRoutingRules(ctx context.Context, ts *topo.Server, reason str
 }
 
 // endregion
+
+// region all routing rules
+
+// RoutingRulesMaps holds the table, shard and keyspace routing rules, in
+// the mapping forms used by the functions above.
+type RoutingRulesMaps struct {
+	// Rules is a mapping of fromTable=>[]toTables.
+	Rules map[string][]string
+	// ShardRules is a mapping of fromKeyspace.Shard=>toKeyspace.
+	ShardRules map[string]string
+	// KeyspaceRules is a mapping of fromKeyspace=>toKeyspace.
+	KeyspaceRules map[string]string
+}
+
+// UpdateAllRoutingRules reads the table, shard and keyspace routing rules,
+// lets update modify them, and saves the ones that changed in a single topo
+// transaction. The transaction only succeeds if none of the routing rules
+// were changed in the meantime, so a traffic switch that updates several
+// kinds of rules cannot leave them half updated: a topo.BadVersion,
+// topo.NoNode or topo.NodeExists error means a concurrent change was
+// detected, and nothing was saved. On topo servers that do not support
+// transactions, the rules are checked first and then saved one at a time.
+// As with UpdateKeyspaceRoutingRules, a RoutingRulesLock is held while doing
+// so, if the routing rules lock path exists.
+func UpdateAllRoutingRules(ctx context.Context, ts *topo.Server, reason string,
+	update func(ctx context.Context, rules *RoutingRulesMaps) error) (err error) {
+	lockCtx, unlock, lockErr := ts.LockRoutingRules(ctx, reason)
+	switch {
+	case lockErr == nil:
+		defer unlock(&err)
+		ctx = lockCtx
+	case topo.IsErrType(lockErr, topo.NoNode):
+		// There are no keyspace routing rules yet, so there is nothing
+		// to lock: we only rely on the checks of the transaction.
+	default:
+		return lockErr
+	}
+
+	all, err := ts.GetAllRoutingRules(ctx)
+	if err != nil {
+		return err
+	}
+	rules := &RoutingRulesMaps{
+		Rules:         GetRoutingRulesMap(all.RoutingRules),
+		ShardRules:    GetShardRoutingRulesMap(all.ShardRoutingRules),
+		KeyspaceRules: GetKeyspaceRoutingRulesMap(all.KeyspaceRoutingRules),
+	}
+	if err := update(ctx, rules); err != nil {
+		return err
+	}
+
+	// Only rebuild the rules that changed, as rebuilding them from the
+	// maps does not preserve their order, which would then be saved as
+	// a change.
+	if !maps.EqualFunc(rules.Rules, GetRoutingRulesMap(all.RoutingRules), slices.Equal) {
+		log.Infof("Saving routing rules %v\n", rules.Rules)
+		all.RoutingRules = buildRoutingRules(rules.Rules)
+	}
+	if !maps.Equal(rules.ShardRules, GetShardRoutingRulesMap(all.ShardRoutingRules)) {
+		log.Infof("Saving shard routing rules %v\n", rules.ShardRules)
+		all.ShardRoutingRules = buildShardRoutingRules(rules.ShardRules)
+	}
+	if !maps.Equal(rules.KeyspaceRules, GetKeyspaceRoutingRulesMap(all.KeyspaceRoutingRules)) {
+		all.KeyspaceRoutingRules = buildKeyspaceRoutingRules(&rules.KeyspaceRules)
+	}
+	return ts.SaveAllRoutingRules(ctx, all)
+}
+
+// endregion
@@ -163,3 +163,91 @@ func TestSaveKeyspaceRoutingRulesLocked(t *testing.T) {
 		require.Errorf(t, err, "routing_rules is not locked (no locksInfo)")
 	})
 }
+
+func TestUpdateAllRoutingRules(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	rules := map[string][]string{
+		"t1": {"ks1.t1"},
+	}
+	require.NoError(t, SaveRoutingRules(ctx, ts, rules))
+	shardRules := map[string]string{
+		"ks2.-80": "ks1",
+		"ks2.80-": "ks1",
+	}
+	require.NoError(t, SaveShardRoutingRules(ctx, ts, shardRules))
+
+	// All the kinds of rules are updated together, and the keyspace
+	// routing rules are created.
+	err := UpdateAllRoutingRules(ctx, ts, "test", func(ctx context.Context, rules *RoutingRulesMaps) error {
+		assert.Equal(t, map[string][]string{"t1": {"ks1.t1"}}, rules.Rules)
+		assert.Equal(t, shardRules, rules.ShardRules)
+		assert.Empty(t, rules.KeyspaceRules)
+
+		rules.Rules["t1"] = []string{"ks2.t1"}
+		delete(rules.ShardRules, "ks2.-80")
+		rules.KeyspaceRules["ks3"] = "ks4"
+		return nil
+	})
+	require.NoError(t, err)
+
+	gotRules, err := GetRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"t1": {"ks2.t1"}}, gotRules)
+	gotShardRules, err := GetShardRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ks2.80-": "ks1"}, gotShardRules)
+	gotKeyspaceRules, err := GetKeyspaceRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ks3": "ks4"}, gotKeyspaceRules)
+
+	// Emptied table and shard routing rules are deleted, and unchanged
+	// rules are not written.
+	conn, err := ts.ConnForCell(ctx, topo.GlobalCell)
+	require.NoError(t, err)
+	_, keyspaceRulesVersion, err := conn.Get(ctx, ts.GetKeyspaceRoutingRulesPath())
+	require.NoError(t, err)
+	err = UpdateAllRoutingRules(ctx, ts, "test", func(ctx context.Context, rules *RoutingRulesMaps) error {
+		clear(rules.Rules)
+		clear(rules.ShardRules)
+		return nil
+	})
+	require.NoError(t, err)
+	_, _, err = conn.Get(ctx, topo.RoutingRulesFile)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "expected NoNode, got %v", err)
+	_, _, err = conn.Get(ctx, topo.ShardRoutingRulesFile)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "expected NoNode, got %v", err)
+	_, version, err := conn.Get(ctx, ts.GetKeyspaceRoutingRulesPath())
+	require.NoError(t, err)
+	assert.Equal(t, keyspaceRulesVersion, version)
+
+	// A concurrent change to any of the existing rules fails the
+	// update, and nothing is saved.
+	require.NoError(t, SaveShardRoutingRules(ctx, ts, map[string]string{"ks2.-80": "ks1"}))
+	err = UpdateAllRoutingRules(ctx, ts, "test", func(ctx context.Context, rules *RoutingRulesMaps) error {
+		rules.Rules["t1"] = []string{"ks1.t1"}
+		return SaveShardRoutingRules(ctx, ts, map[string]string{"ks2.-80": "ks3"})
+	})
+	assert.True(t, topo.IsErrType(err, topo.BadVersion), "expected BadVersion, got %v", err)
+	gotRules, err = GetRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Empty(t, gotRules)
+
+	err = UpdateAllRoutingRules(ctx, ts, "test", func(ctx context.Context, rules *RoutingRulesMaps) error {
+		rules.Rules["t1"] = []string{"ks1.t1"}
+		return ts.SaveKeyspaceRoutingRules(ctx, buildKeyspaceRoutingRules(&map[string]string{"ks3": "ks5"}))
+	})
+	assert.True(t, topo.IsErrType(err, topo.BadVersion), "expected BadVersion, got %v", err)
+	gotRules, err = GetRoutingRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Empty(t, gotRules)
+
+	// Errors from update are returned as is.
+	err = UpdateAllRoutingRules(ctx, ts, "test", func(ctx context.Context, rules *RoutingRulesMaps) error {
+		return errors.New("update failed")
+	})
+	assert.EqualError(t, err, "update failed")
+}
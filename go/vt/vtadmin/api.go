@@ -59,6 +59,7 @@ import (
 	"vitess.io/vitess/go/vt/vtadmin/rbac"
 	"vitess.io/vitess/go/vt/vtadmin/sort"
 	"vitess.io/vitess/go/vt/vtadmin/vtadminproto"
+	"vitess.io/vitess/go/vt/vtctl/audit"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
 	"vitess.io/vitess/go/vt/vtctl/workflow"
 	"vitess.io/vitess/go/vt/vtenv"
@@ -103,6 +104,8 @@ type Options struct {
 	GRPCOpts grpcserver.Options
 	HTTPOpts vtadminhttp.Options
 	RBAC     *rbac.Config
+	// AuditLogger, if set, audits the mutating operations of the API.
+	AuditLogger *audit.Logger
 	// EnableDynamicClusters makes it so that clients can pass clusters dynamically
 	// in a session-like way, either via HTTP cookies or gRPC metadata.
 	EnableDynamicClusters bool
@@ -134,6 +137,13 @@ func NewAPI(env *vtenv.Environment, clusters []*cluster.Cluster, opts Options) *
 		}
 	}
 
+	if opts.AuditLogger != nil {
+		// The audit interceptors come after the authentication ones, so that
+		// the actor is known.
+		opts.GRPCOpts.StreamInterceptors = append(opts.GRPCOpts.StreamInterceptors, opts.AuditLogger.StreamServerInterceptor(rbac.ActorNameFromContext))
+		opts.GRPCOpts.UnaryInterceptors = append(opts.GRPCOpts.UnaryInterceptors, opts.AuditLogger.UnaryServerInterceptor(rbac.ActorNameFromContext))
+	}
+
 	if authz == nil {
 		authz, _ = rbac.NewAuthorizer(&rbac.Config{
 			Rules: []*struct {
@@ -368,6 +378,12 @@ func (api *API) Handler() http.Handler {
 	router.Use(handlers.CORS(
 		handlers.AllowCredentials(), handlers.AllowedOrigins(api.options.HTTPOpts.CORSOrigins), handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"})))
 
+	// The audit middleware is added to this router rather than to the one of
+	// NewAPI, as the names and variables of the routes are only known here.
+	if api.options.AuditLogger != nil {
+		router.Use(vthandlers.NewAuditHandler(api.options.AuditLogger))
+	}
+
 	httpAPI := vtadminhttp.NewAPI(api, api.options.HTTPOpts)
 
 	router.HandleFunc("/backups", httpAPI.Adapt(vtadminhttp.GetBackups)).Name("API.GetBackups")
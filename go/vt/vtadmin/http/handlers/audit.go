@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"vitess.io/vitess/go/vt/vtadmin/rbac"
+	"vitess.io/vitess/go/vt/vtctl/audit"
+)
+
+// NewAuditHandler returns an http middleware that writes the mutating requests
+// of the API to the given audit log, once they are served.
+//
+// The method of an entry is the name of the route, and its request is the
+// redacted JSON of the route variables, the query parameters and the body of
+// the http request. Responses with an error code are audited as failures.
+func NewAuditHandler(logger *audit.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method := strings.TrimPrefix(mux.CurrentRoute(r).GetName(), "API.")
+			switch r.Method {
+			case http.MethodPost, http.MethodPut, http.MethodDelete:
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !audit.IsMutating(method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			request, err := auditRequest(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			start := time.Now()
+			sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+
+			if sw.status >= http.StatusBadRequest {
+				err = fmt.Errorf("%d %s", sw.status, http.StatusText(sw.status))
+			}
+			ctx := r.Context()
+			logger.Log(ctx, audit.NewEntry(rbac.ActorNameFromContext(ctx), method, request, start, err))
+		})
+	}
+}
+
+// auditRequest returns the redacted JSON of the arguments of the request. The
+// body is read, and then restored for the next handlers.
+func auditRequest(r *http.Request) (string, error) {
+	args := map[string]any{}
+	if vars := mux.Vars(r); len(vars) > 0 {
+		args["vars"] = vars
+	}
+	if query := r.URL.Query(); len(query) > 0 {
+		args["query"] = query
+	}
+
+	if r.Body != nil {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read request body: %w", err)
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		switch {
+		case len(bytes.TrimSpace(body)) == 0:
+		case json.Valid(body):
+			args["body"] = json.RawMessage(body)
+		default:
+			args["body"] = fmt.Sprintf("[NON-JSON: %d bytes]", len(body))
+		}
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	return audit.RedactJSON(data), nil
+}
+
+// statusResponseWriter records the status code of the response.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader is part of the http.ResponseWriter interface.
+func (w *statusResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtadmin/rbac"
+	"vitess.io/vitess/go/vt/vtctl/audit"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+type fakeAuditSink struct {
+	entries []*vtctldatapb.AuditLogEntry
+}
+
+func (s *fakeAuditSink) Write(ctx context.Context, entry *vtctldatapb.AuditLogEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *fakeAuditSink) Close() error {
+	return nil
+}
+
+func TestAuditHandler(t *testing.T) {
+	sink := &fakeAuditSink{}
+	audit.RegisterSink("handlers-test", func(component string, ts *topo.Server) (audit.Sink, error) {
+		return sink, nil
+	})
+	logger, err := audit.New("vtadmin", nil, "handlers-test")
+	require.NoError(t, err)
+
+	m := mux.NewRouter()
+	m.HandleFunc("/keyspace/{cluster_id}", func(w http.ResponseWriter, r *http.Request) {
+		// The body is still readable by the handler.
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"name"`) {
+			http.Error(w, "missing name", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("ok\n"))
+	}).Name("API.CreateKeyspace").Methods("POST")
+	m.HandleFunc("/keyspace/{cluster_id}/validate", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok\n"))
+	}).Name("API.ValidateKeyspace").Methods("PUT")
+	m.HandleFunc("/keyspaces", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok\n"))
+	}).Name("API.GetKeyspaces")
+	m.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(rbac.NewContext(r.Context(), &rbac.Actor{Name: "alice"})))
+		})
+	})
+	m.Use(NewAuditHandler(logger))
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("POST", "/keyspace/local?force=true", strings.NewReader(`{"name": "ks", "db_password": "hunter2"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("POST", "/keyspace/local", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// The read-only requests aren't audited.
+	m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("PUT", "/keyspace/local/validate", nil))
+	m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/keyspaces", nil))
+
+	require.Len(t, sink.entries, 2)
+	assert.Equal(t, "alice", sink.entries[0].Actor)
+	assert.Equal(t, "vtadmin", sink.entries[0].Component)
+	assert.Equal(t, "CreateKeyspace", sink.entries[0].Method)
+	assert.Equal(t, `{"body":{"db_password":"[REDACTED]","name":"ks"},"query":{"force":["true"]},"vars":{"cluster_id":"local"}}`, sink.entries[0].Request)
+	assert.Empty(t, sink.entries[0].Error)
+	assert.Equal(t, `{"vars":{"cluster_id":"local"}}`, sink.entries[1].Request)
+	assert.Equal(t, "400 Bad Request", sink.entries[1].Error)
+}
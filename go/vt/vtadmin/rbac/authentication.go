@@ -119,6 +119,17 @@ func FromContext(ctx context.Context) (*Actor, bool) {
 	return actor, true
 }
 
+// ActorNameFromContext returns the name of the actor in the context, or
+// "unauthenticated" if there is none. It is used to audit the operations.
+func ActorNameFromContext(ctx context.Context) string {
+	actor, ok := FromContext(ctx)
+	if !ok || actor == nil {
+		return "unauthenticated"
+	}
+
+	return actor.Name
+}
+
 var (
 	// ErrUnregisteredAuthenticationImpl is returned when an RBAC config
 	// specifies an authenticator name that was not registered.
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records an audit trail of the mutating operations of vtctld and
+// VTAdmin. Each entry captures who called what, with which (redacted)
+// arguments, whether it failed, and how long it took, and is written to the
+// sinks enabled with --audit-log-sinks.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/utils"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// Sink is where audit log entries are written to.
+type Sink interface {
+	// Write writes an entry to the sink.
+	Write(ctx context.Context, entry *vtctldatapb.AuditLogEntry) error
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// SinkFactory creates a Sink for the given component. The topo server is nil
+// in the components without one, like VTAdmin.
+type SinkFactory func(component string, ts *topo.Server) (Sink, error)
+
+var (
+	sinkFactoriesMu sync.Mutex
+	sinkFactories   = map[string]SinkFactory{}
+)
+
+// RegisterSink registers a SinkFactory under the given name, which can then be
+// enabled with --audit-log-sinks.
+func RegisterSink(name string, factory SinkFactory) {
+	sinkFactoriesMu.Lock()
+	defer sinkFactoriesMu.Unlock()
+
+	if _, ok := sinkFactories[name]; ok {
+		log.Fatalf("audit log sink %s is already registered", name)
+	}
+	sinkFactories[name] = factory
+}
+
+var (
+	sinkNames      []string
+	filePath       string
+	topoMaxEntries = 1000
+
+	entriesCount     = stats.NewCountersWithSingleLabel("AuditLogEntries", "Number of audit log entries written, by sink", "Sink")
+	writeErrorsCount = stats.NewCountersWithSingleLabel("AuditLogWriteErrors", "Number of audit log entries that failed to be written, by sink", "Sink")
+)
+
+func init() {
+	servenv.OnParseFor("vtctld", RegisterFlags)
+	servenv.OnParseFor("vtcombo", RegisterFlags)
+}
+
+// RegisterFlags registers the audit log flags on the given FlagSet.
+func RegisterFlags(fs *pflag.FlagSet) {
+	utils.SetFlagStringSliceVar(fs, &sinkNames, "audit-log-sinks", sinkNames, "Comma-separated list of the sinks the audit log of the mutating operations is written to. Supported sinks are file, syslog and topo (vtctld only). If empty, there is no audit log.")
+	utils.SetFlagStringVar(fs, &filePath, "audit-log-file", filePath, "Path of the file the file audit log sink appends the entries to, as JSON lines.")
+	utils.SetFlagIntVar(fs, &topoMaxEntries, "audit-log-topo-max-entries", topoMaxEntries, "Maximum number of the most recent entries the topo audit log sink keeps in the global topo.")
+}
+
+// Logger writes audit log entries to its sinks.
+type Logger struct {
+	component string
+	names     []string
+	sinks     []Sink
+}
+
+// NewFromFlags returns a Logger for the component writing to the sinks of
+// --audit-log-sinks, or nil if there are none.
+func NewFromFlags(component string, ts *topo.Server) (*Logger, error) {
+	return New(component, ts, sinkNames...)
+}
+
+// New returns a Logger for the component writing to the named sinks, or nil if
+// there are none.
+func New(component string, ts *topo.Server, names ...string) (*Logger, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	sinkFactoriesMu.Lock()
+	defer sinkFactoriesMu.Unlock()
+
+	l := &Logger{component: component}
+	for _, name := range names {
+		factory, ok := sinkFactories[name]
+		if !ok {
+			l.Close()
+			return nil, fmt.Errorf("unknown audit log sink %q, supported sinks are: %s", name, strings.Join(registeredSinks(), ", "))
+		}
+		sink, err := factory(component, ts)
+		if err != nil {
+			l.Close()
+			return nil, fmt.Errorf("failed to create the %s audit log sink: %w", name, err)
+		}
+		l.names = append(l.names, name)
+		l.sinks = append(l.sinks, sink)
+	}
+	return l, nil
+}
+
+func registeredSinks() []string {
+	names := make([]string, 0, len(sinkFactories))
+	for name := range sinkFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Log writes the entry to all the sinks. The failures to write are logged,
+// they don't fail the audited operation.
+func (l *Logger) Log(ctx context.Context, entry *vtctldatapb.AuditLogEntry) {
+	if entry.Component == "" {
+		entry.Component = l.component
+	}
+	for i, sink := range l.sinks {
+		if err := sink.Write(ctx, entry); err != nil {
+			writeErrorsCount.Add(l.names[i], 1)
+			log.Errorf("failed to write %s of %s to the %s audit log sink: %v", entry.Method, entry.Actor, l.names[i], err)
+			continue
+		}
+		entriesCount.Add(l.names[i], 1)
+	}
+}
+
+// Close closes all the sinks.
+func (l *Logger) Close() error {
+	var errs []error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// readOnlyPrefixes are the prefixes of the names of the operations that don't
+// mutate anything, and so aren't audited.
+var readOnlyPrefixes = []string{"Get", "Find", "Show", "Validate", "Check"}
+
+// readOnlyMethods are the other operations that don't mutate anything.
+var readOnlyMethods = map[string]bool{
+	"ShardReplicationPositions": true,
+	"VDiffShow":                 true,
+	"VExplain":                  true,
+	"VTExplain":                 true,
+	"WorkflowStatus":            true,
+}
+
+// IsMutating returns whether the operation of the given name mutates the
+// cluster, and so must be audited.
+func IsMutating(method string) bool {
+	if readOnlyMethods[method] {
+		return false
+	}
+	for _, prefix := range readOnlyPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// fakeSink records the entries written to it.
+type fakeSink struct {
+	mu      sync.Mutex
+	entries []*vtctldatapb.AuditLogEntry
+}
+
+func (s *fakeSink) Write(ctx context.Context, entry *vtctldatapb.AuditLogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	return nil
+}
+
+func init() {
+	RegisterSink("test", func(component string, ts *topo.Server) (Sink, error) {
+		return &fakeSink{}, nil
+	})
+}
+
+// newTestLogger returns a logger writing to a new fake sink.
+func newTestLogger(t *testing.T) (*Logger, *fakeSink) {
+	l, err := New("test-component", nil, "test")
+	require.NoError(t, err)
+	return l, l.sinks[0].(*fakeSink)
+}
+
+func TestNew(t *testing.T) {
+	l, err := New("vtctld", nil)
+	require.NoError(t, err)
+	assert.Nil(t, l)
+
+	_, err = New("vtctld", nil, "test", "kafka")
+	assert.EqualError(t, err, `unknown audit log sink "kafka", supported sinks are: file, syslog, test, topo`)
+
+	_, err = New("vtadmin", nil, "topo")
+	assert.EqualError(t, err, "failed to create the topo audit log sink: the topo sink is not supported by vtadmin")
+}
+
+func TestIsMutating(t *testing.T) {
+	for _, method := range []string{"PlannedReparentShard", "CreateKeyspace", "DeleteTablets", "ApplySchema", "MoveTablesCreate", "SetKeyspaceDurabilityPolicy"} {
+		assert.True(t, IsMutating(method), method)
+	}
+	for _, method := range []string{"GetKeyspace", "FindAllShardsInKeyspace", "ShowSchema", "ValidateShard", "CheckThrottler", "ShardReplicationPositions", "VDiffShow", "WorkflowStatus"} {
+		assert.False(t, IsMutating(method), method)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	oldFilePath := filePath
+	defer func() { filePath = oldFilePath }()
+	filePath = filepath.Join(t.TempDir(), "audit.log")
+
+	l, err := New("vtctld", nil, "file")
+	require.NoError(t, err)
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	l.Log(context.Background(), &vtctldatapb.AuditLogEntry{Time: protoutil.TimeToProto(start), Actor: "alice", Method: "CreateKeyspace", Request: `{"name":"ks"}`})
+	l.Log(context.Background(), &vtctldatapb.AuditLogEntry{Actor: "bob", Method: "DeleteKeyspace", Error: "keyspace not found"})
+	require.NoError(t, l.Close())
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"time":{"seconds":"1767323045"},"actor":"alice","component":"vtctld","method":"CreateKeyspace","request":"{\"name\":\"ks\"}"}`, lines[0])
+	assert.Equal(t, `{"actor":"bob","component":"vtctld","method":"DeleteKeyspace","error":"keyspace not found"}`, lines[1])
+
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	l, sink := newTestLogger(t)
+	interceptor := l.UnaryServerInterceptor(func(ctx context.Context) string { return "alice" })
+
+	handlerErr := errors.New("keyspace ks already exists")
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, handlerErr
+	}
+	req := &vtctldatapb.CreateKeyspaceRequest{Name: "ks"}
+
+	_, err := interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/vtctlservice.Vtctld/CreateKeyspace"}, handler)
+	assert.Equal(t, handlerErr, err)
+	_, _ = interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/vtctlservice.Vtctld/GetKeyspace"}, handler)
+	_, _ = interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}, handler)
+
+	require.Len(t, sink.entries, 1)
+	entry := sink.entries[0]
+	assert.Equal(t, "alice", entry.Actor)
+	assert.Equal(t, "test-component", entry.Component)
+	assert.Equal(t, "CreateKeyspace", entry.Method)
+	assert.Equal(t, `{"name":"ks"}`, entry.Request)
+	assert.Equal(t, "keyspace ks already exists", entry.Error)
+	assert.NotNil(t, entry.Time)
+	assert.NotNil(t, entry.Duration)
+}
+
+// fakeServerStream is a grpc.ServerStream receiving a single request.
+type fakeServerStream struct {
+	grpc.ServerStream
+	req *vtctldatapb.EmergencyReparentShardRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeServerStream) RecvMsg(m any) error {
+	m.(*vtctldatapb.EmergencyReparentShardRequest).Keyspace = s.req.Keyspace
+	return nil
+}
+
+func TestWrapServiceDesc(t *testing.T) {
+	l, sink := newTestLogger(t)
+
+	// The handlers are written like the generated ones.
+	desc := &grpc.ServiceDesc{
+		ServiceName: "test.Service",
+		Methods: []grpc.MethodDesc{{
+			MethodName: "CreateKeyspace",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := new(vtctldatapb.CreateKeyspaceRequest)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req any) (any, error) {
+					return &vtctldatapb.CreateKeyspaceResponse{}, nil
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Service/CreateKeyspace"}, handler)
+			},
+		}},
+		Streams: []grpc.StreamDesc{{
+			StreamName: "EmergencyReparentShard",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				return stream.RecvMsg(new(vtctldatapb.EmergencyReparentShardRequest))
+			},
+			ServerStreams: true,
+		}},
+	}
+	wrapped := l.WrapServiceDesc(desc, func(ctx context.Context) string { return "alice" })
+
+	dec := func(m any) error {
+		m.(*vtctldatapb.CreateKeyspaceRequest).Name = "ks"
+		return nil
+	}
+	// Without server interceptor.
+	_, err := wrapped.Methods[0].Handler(nil, context.Background(), dec, nil)
+	require.NoError(t, err)
+
+	// The server interceptors are called before the audit, and an RPC they
+	// reject isn't audited.
+	var calls []string
+	serverInterceptor := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		calls = append(calls, "server")
+		if len(calls) > 1 {
+			return nil, errors.New("unauthenticated")
+		}
+		return handler(ctx, req)
+	}
+	_, err = wrapped.Methods[0].Handler(nil, context.Background(), dec, serverInterceptor)
+	require.NoError(t, err)
+	_, err = wrapped.Methods[0].Handler(nil, context.Background(), dec, serverInterceptor)
+	require.EqualError(t, err, "unauthenticated")
+	assert.Equal(t, []string{"server", "server"}, calls)
+
+	err = wrapped.Streams[0].Handler(nil, &fakeServerStream{req: &vtctldatapb.EmergencyReparentShardRequest{Keyspace: "ks"}})
+	require.NoError(t, err)
+
+	// The original description is left untouched.
+	assert.Equal(t, "CreateKeyspace", desc.Methods[0].MethodName)
+
+	require.Len(t, sink.entries, 3)
+	assert.Equal(t, "CreateKeyspace", sink.entries[0].Method)
+	assert.Equal(t, `{"name":"ks"}`, sink.entries[0].Request)
+	assert.Equal(t, "CreateKeyspace", sink.entries[1].Method)
+	assert.Equal(t, "EmergencyReparentShard", sink.entries[2].Method)
+	assert.Equal(t, `{"keyspace":"ks"}`, sink.entries[2].Request)
+	assert.Equal(t, "alice", sink.entries[2].Actor)
+}
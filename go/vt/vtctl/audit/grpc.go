@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/servenv"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// ActorFunc returns the name of the caller of an operation.
+type ActorFunc func(ctx context.Context) string
+
+// GRPCActor returns the name of the caller of a gRPC request: the username
+// authenticated by the static auth plugin, or the common name of the TLS client
+// certificate, or else the address of the caller.
+func GRPCActor(ctx context.Context) string {
+	if username := servenv.StaticAuthUsernameFromContext(ctx); username != "" {
+		return username
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unauthenticated"
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+		return tlsInfo.State.PeerCertificates[0].Subject.CommonName
+	}
+	return "unauthenticated@" + p.Addr.String()
+}
+
+// auditedMethod returns the name of the method of the gRPC full method name,
+// and whether it is audited. The methods of the gRPC services themselves, like
+// health checks and reflection, are never audited.
+func auditedMethod(fullMethod string) (string, bool) {
+	if strings.HasPrefix(fullMethod, "/grpc.") {
+		return "", false
+	}
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return method, IsMutating(method)
+}
+
+// NewEntry returns the audit log entry of an operation that started at start
+// and returned err.
+func NewEntry(actor, method, request string, start time.Time, err error) *vtctldatapb.AuditLogEntry {
+	entry := &vtctldatapb.AuditLogEntry{
+		Time:     protoutil.TimeToProto(start),
+		Actor:    actor,
+		Method:   method,
+		Request:  request,
+		Duration: protoutil.DurationToProto(time.Since(start)),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// redactRequest returns the redacted JSON of a gRPC request.
+func redactRequest(req any) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	return Redact(msg)
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that audits the mutating
+// unary RPCs.
+func (l *Logger) UnaryServerInterceptor(actor ActorFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		method, ok := auditedMethod(info.FullMethod)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		l.Log(ctx, NewEntry(actor(ctx), method, redactRequest(req), start, err))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that audits the mutating
+// streaming RPCs. The request of an entry is the first message received from
+// the client.
+func (l *Logger) StreamServerInterceptor(actor ActorFunc) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		method, ok := auditedMethod(info.FullMethod)
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		start := time.Now()
+		stream := &auditedServerStream{ServerStream: ss}
+		err := handler(srv, stream)
+		ctx := ss.Context()
+		l.Log(ctx, NewEntry(actor(ctx), method, redactRequest(stream.req), start, err))
+		return err
+	}
+}
+
+// auditedServerStream records the first message received on a stream.
+type auditedServerStream struct {
+	grpc.ServerStream
+	req any
+}
+
+// RecvMsg is part of the grpc.ServerStream interface.
+func (s *auditedServerStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+	return err
+}
+
+// WrapServiceDesc returns a copy of the service description whose handlers
+// audit the mutating RPCs of the service. Unlike the server options, this only
+// audits the RPCs of this service, and the audit happens after the server
+// interceptors, so once the caller is authenticated.
+func (l *Logger) WrapServiceDesc(desc *grpc.ServiceDesc, actor ActorFunc) *grpc.ServiceDesc {
+	unaryInterceptor := l.UnaryServerInterceptor(actor)
+	streamInterceptor := l.StreamServerInterceptor(actor)
+
+	wrapped := *desc
+	wrapped.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, md := range desc.Methods {
+		handler := md.Handler
+		wrapped.Methods[i] = grpc.MethodDesc{
+			MethodName: md.MethodName,
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				return handler(srv, ctx, dec, chainUnaryInterceptors(interceptor, unaryInterceptor))
+			},
+		}
+	}
+
+	wrapped.Streams = make([]grpc.StreamDesc, len(desc.Streams))
+	for i, sd := range desc.Streams {
+		handler := sd.Handler
+		info := &grpc.StreamServerInfo{
+			FullMethod:     "/" + desc.ServiceName + "/" + sd.StreamName,
+			IsClientStream: sd.ClientStreams,
+			IsServerStream: sd.ServerStreams,
+		}
+		wrapped.Streams[i] = sd
+		wrapped.Streams[i].Handler = func(srv any, stream grpc.ServerStream) error {
+			return streamInterceptor(srv, stream, info, handler)
+		}
+	}
+	return &wrapped
+}
+
+// chainUnaryInterceptors returns an interceptor calling outer, then inner. The
+// outer interceptor is nil if the server has none.
+func chainUnaryInterceptors(outer, inner grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if outer == nil {
+		return inner
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return outer(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return inner(ctx, req, info, handler)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// redacted replaces the values of the sensitive fields.
+const redacted = "[REDACTED]"
+
+// sensitiveNames are the substrings of the names of the fields whose values
+// must not be written to the audit log, once lowercased and stripped of their
+// separators.
+var sensitiveNames = []string{"password", "passwd", "secret", "token", "credential", "privatekey", "apikey"}
+
+// sqlFields are the fields holding SQL statements, whose literals are
+// replaced by bind variables as they may hold sensitive values.
+var sqlFields = map[protoreflect.FullName]bool{
+	"vtctldata.ApplySchemaRequest.sql":            true,
+	"vtctldata.ExecuteFetchAsAppRequest.query":    true,
+	"vtctldata.ExecuteFetchAsDBARequest.query":    true,
+	"vtctldata.ExecuteMultiFetchAsDBARequest.sql": true,
+}
+
+var sqlParser = sync.OnceValues(func() (*sqlparser.Parser, error) {
+	return sqlparser.New(sqlparser.Options{})
+})
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("_", "", "-", "").Replace(name)
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns the message as JSON, with the values of its sensitive fields
+// replaced. The message itself is left untouched.
+func Redact(msg proto.Message) string {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return ""
+	}
+	clone := proto.Clone(msg)
+	redactMessage(clone.ProtoReflect())
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(clone)
+	if err != nil {
+		return fmt.Sprintf("[UNMARSHALABLE: %v]", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return fmt.Sprintf("[UNMARSHALABLE: %v]", err)
+	}
+	return buf.String()
+}
+
+func redactMessage(m protoreflect.Message) {
+	// The fields are only set once the message is ranged over, as setting
+	// other fields while ranging is not allowed.
+	var sensitiveFields, sqlFieldsSet []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case sqlFields[fd.FullName()]:
+			sqlFieldsSet = append(sqlFieldsSet, fd)
+		case fd.IsMap():
+			sensitive := isSensitive(string(fd.Name()))
+			var sensitiveKeys []protoreflect.MapKey
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				switch fd.MapValue().Kind() {
+				case protoreflect.MessageKind:
+					redactMessage(mv.Message())
+				case protoreflect.StringKind, protoreflect.BytesKind:
+					if sensitive || isSensitive(k.String()) {
+						sensitiveKeys = append(sensitiveKeys, k)
+					}
+				}
+				return true
+			})
+			for _, k := range sensitiveKeys {
+				if fd.MapValue().Kind() == protoreflect.StringKind {
+					v.Map().Set(k, protoreflect.ValueOfString(redacted))
+				} else {
+					v.Map().Set(k, protoreflect.ValueOfBytes(nil))
+				}
+			}
+		case fd.IsList():
+			list := v.List()
+			switch {
+			case fd.Kind() == protoreflect.MessageKind:
+				for i := 0; i < list.Len(); i++ {
+					redactMessage(list.Get(i).Message())
+				}
+			case isSensitive(string(fd.Name())):
+				sensitiveFields = append(sensitiveFields, fd)
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			redactMessage(v.Message())
+		case isSensitive(string(fd.Name())):
+			sensitiveFields = append(sensitiveFields, fd)
+		}
+		return true
+	})
+
+	for _, fd := range sensitiveFields {
+		switch {
+		case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+			list := m.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				list.Set(i, protoreflect.ValueOfString(redacted))
+			}
+		case fd.Kind() == protoreflect.StringKind:
+			m.Set(fd, protoreflect.ValueOfString(redacted))
+		default:
+			m.Clear(fd)
+		}
+	}
+
+	for _, fd := range sqlFieldsSet {
+		if fd.IsList() {
+			list := m.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				list.Set(i, protoreflect.ValueOfString(RedactSQL(list.Get(i).String())))
+			}
+			continue
+		}
+		m.Set(fd, protoreflect.ValueOfString(RedactSQL(m.Get(fd).String())))
+	}
+}
+
+// RedactSQL returns the SQL statements with their literals replaced by bind
+// variables. SQL that can't be parsed is not returned at all, as it can't be
+// redacted.
+func RedactSQL(sql string) string {
+	parser, err := sqlParser()
+	if err != nil {
+		return fmt.Sprintf("[UNPARSABLE SQL: %d bytes]", len(sql))
+	}
+	pieces, err := parser.SplitStatementToPieces(sql)
+	if err != nil {
+		return fmt.Sprintf("[UNPARSABLE SQL: %d bytes]", len(sql))
+	}
+	for i, piece := range pieces {
+		if pieces[i], err = parser.RedactSQLQuery(piece); err != nil {
+			return fmt.Sprintf("[UNPARSABLE SQL: %d bytes]", len(sql))
+		}
+	}
+	return strings.Join(pieces, ";")
+}
+
+// RedactJSON returns the JSON document with the values of its sensitive keys
+// replaced, compacted on a single line. Documents that aren't valid JSON are
+// not returned at all, as they can't be redacted.
+func RedactJSON(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Sprintf("[INVALID JSON: %d bytes]", len(data))
+	}
+	out, err := json.Marshal(redactJSONValue(doc))
+	if err != nil {
+		return fmt.Sprintf("[UNMARSHALABLE: %v]", err)
+	}
+	return string(out)
+}
+
+func redactJSONValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactJSONValue(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactJSONValue(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestRedact(t *testing.T) {
+	req := &vtctldatapb.ExecuteHookRequest{
+		TabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		TabletHookRequest: &tabletmanagerdatapb.ExecuteHookRequest{
+			Name:       "test.sh",
+			Parameters: []string{"--verbose"},
+			ExtraEnv: map[string]string{
+				"MYSQL_PASSWORD": "hunter2",
+				"API_TOKEN":      "abc",
+				"MYSQL_USER":     "vt_app",
+			},
+		},
+	}
+
+	assert.Equal(t, `{"tablet_alias":{"cell":"zone1","uid":100},"tablet_hook_request":{"name":"test.sh","parameters":["--verbose"],"extra_env":{"API_TOKEN":"[REDACTED]","MYSQL_PASSWORD":"[REDACTED]","MYSQL_USER":"vt_app"}}}`, Redact(req))
+	// The request itself is left untouched.
+	assert.Equal(t, "hunter2", req.TabletHookRequest.ExtraEnv["MYSQL_PASSWORD"])
+
+	assert.Equal(t, "", Redact(nil))
+	assert.Equal(t, "", Redact((*vtctldatapb.ExecuteHookRequest)(nil)))
+}
+
+func TestRedactSQL(t *testing.T) {
+	alias := &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}
+	testcases := []struct {
+		name string
+		req  proto.Message
+		want string
+	}{{
+		name: "ExecuteFetchAsDBA",
+		req:  &vtctldatapb.ExecuteFetchAsDBARequest{TabletAlias: alias, Query: "update mysql.user set authentication_string = 'hunter2' where user = 'app'", MaxRows: 10},
+		want: `{"tablet_alias":{"cell":"zone1","uid":100},"query":"update mysql.`+"`user`"+` set authentication_string = :authentication_string /* VARCHAR */ where `+"`user`"+` = :user /* VARCHAR */","max_rows":"10"}`,
+	}, {
+		name: "ExecuteMultiFetchAsDBA",
+		req:  &vtctldatapb.ExecuteMultiFetchAsDBARequest{TabletAlias: alias, Sql: "update t set ssn = '123-45-6789' where id = 1; select 1 from dual"},
+		want: `{"tablet_alias":{"cell":"zone1","uid":100},"sql":"update t set ssn = :ssn /* VARCHAR */ where id = :id /* INT64 */;select :redacted1 /* INT64 */ from dual"}`,
+	}, {
+		name: "ExecuteFetchAsApp",
+		req:  &vtctldatapb.ExecuteFetchAsAppRequest{TabletAlias: alias, Query: "select * from t where email = 'a@b.c'"},
+		want: `{"tablet_alias":{"cell":"zone1","uid":100},"query":"select * from t where email = :email /* VARCHAR */"}`,
+	}, {
+		name: "ApplySchema",
+		req:  &vtctldatapb.ApplySchemaRequest{Keyspace: "ks", Sql: []string{"insert into t values ('secret')", "not sql"}},
+		want: `{"keyspace":"ks","sql":["insert into t values (:redacted1 /* VARCHAR */)","[UNPARSABLE SQL: 7 bytes]"]}`,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Redact(tc.req))
+		})
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	testcases := []struct {
+		name string
+		data string
+		want string
+	}{{
+		name: "empty",
+		data: "",
+		want: "",
+	}, {
+		name: "nested",
+		data: `{"keyspace": "ks", "options": {"db_password": "hunter2", "clientSecret": "abc", "tokens": ["a", "b"]}, "shards": [{"name": "-80", "private-key": "k"}]}`,
+		want: `{"keyspace":"ks","options":{"clientSecret":"[REDACTED]","db_password":"[REDACTED]","tokens":"[REDACTED]"},"shards":[{"name":"-80","private-key":"[REDACTED]"}]}`,
+	}, {
+		name: "not an object",
+		data: `["password"]`,
+		want: `["password"]`,
+	}, {
+		name: "invalid",
+		data: `{"password": `,
+		want: "[INVALID JSON: 13 bytes]",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, RedactJSON([]byte(tc.data)))
+		})
+	}
+}
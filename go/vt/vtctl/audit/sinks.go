@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func init() {
+	RegisterSink("file", newFileSink)
+	RegisterSink("topo", newTopoSink)
+}
+
+// marshalEntry marshals the entry as a single line of JSON.
+func marshalEntry(entry *vtctldatapb.AuditLogEntry) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	// protojson randomly adds whitespace, compact it to get one line.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fileSink appends the entries to a file, as JSON lines.
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileSink(component string, ts *topo.Server) (Sink, error) {
+	if filePath == "" {
+		return nil, errors.New("--audit-log-file must be set")
+	}
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{file: file}, nil
+}
+
+// Write is part of the Sink interface.
+func (s *fileSink) Write(ctx context.Context, entry *vtctldatapb.AuditLogEntry) error {
+	data, err := marshalEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+// Close is part of the Sink interface.
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// topoSink appends the entries to the ring buffer of the global topo, which is
+// read with the GetAuditLog RPC.
+type topoSink struct {
+	ts         *topo.Server
+	maxEntries int
+}
+
+func newTopoSink(component string, ts *topo.Server) (Sink, error) {
+	if ts == nil {
+		return nil, errors.New("the topo sink is not supported by " + component)
+	}
+	if topoMaxEntries <= 0 {
+		return nil, errors.New("--audit-log-topo-max-entries must be positive")
+	}
+	return &topoSink{ts: ts, maxEntries: topoMaxEntries}, nil
+}
+
+// Write is part of the Sink interface.
+func (s *topoSink) Write(ctx context.Context, entry *vtctldatapb.AuditLogEntry) error {
+	// The entry is written even if the audited operation was canceled.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), topo.RemoteOperationTimeout)
+	defer cancel()
+
+	return s.ts.AppendAuditLogEntry(ctx, entry, s.maxEntries)
+}
+
+// Close is part of the Sink interface.
+func (s *topoSink) Close() error {
+	return nil
+}
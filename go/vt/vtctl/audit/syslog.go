@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"log/syslog"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func init() {
+	RegisterSink("syslog", newSyslogSink)
+}
+
+// syslogSink writes the entries to the local syslog, as JSON.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink(component string, ts *topo.Server) (Sink, error) {
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, component)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{writer: writer}, nil
+}
+
+// Write is part of the Sink interface.
+func (s *syslogSink) Write(ctx context.Context, entry *vtctldatapb.AuditLogEntry) error {
+	data, err := marshalEntry(entry)
+	if err != nil {
+		return err
+	}
+	return s.writer.Info(string(data))
+}
+
+// Close is part of the Sink interface.
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
+	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/schematools"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func (s *VtctldServer) schemadiffEnv() *schemadiff.Environment {
+	env := s.ws.Environment()
+	return schemadiff.NewEnv(env, env.CollationEnv().DefaultConnectionCharset())
+}
+
+// schemaLintOptions returns the options to lint the schema of the given keyspace with, according to
+// the given policy.
+func (s *VtctldServer) schemaLintOptions(ctx context.Context, keyspace string, lintPolicy *topodatapb.SchemaLintPolicy) (*schemadiff.LintOptions, error) {
+	severities, err := schemadiff.ParseLintSeverities(lintPolicy.GetRuleSeverities())
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid schema lint policy: %v", err)
+	}
+
+	opts := &schemadiff.LintOptions{
+		Keyspace:          keyspace,
+		Severities:        severities,
+		MaxIndexKeyLength: int(lintPolicy.GetMaxIndexKeyLength()),
+	}
+	vschema, err := s.ts.GetVSchema(ctx, keyspace)
+	switch {
+	case err == nil:
+		opts.VSchema = vschema.Keyspace
+	case !topo.IsErrType(err, topo.NoNode):
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// lintChangedTables evaluates the schema lint rules of the keyspace on the tables that the given
+// statements create or alter, as they will be once the statements are applied. If any table is
+// altered, the current schema is read from the primary tablet of the first shard of the keyspace.
+func (s *VtctldServer) lintChangedTables(ctx context.Context, keyspace string, sql []string) ([]*schemadiff.LintFinding, error) {
+	env := s.schemadiffEnv()
+	var (
+		statements []sqlparser.Statement
+		altered    bool
+	)
+	for _, query := range sql {
+		stmt, err := env.Parser().Parse(query)
+		if err != nil {
+			// Invalid statements fail the schema change itself.
+			continue
+		}
+		switch stmt.(type) {
+		case *sqlparser.AlterTable:
+			altered = true
+			statements = append(statements, stmt)
+		case *sqlparser.CreateTable, *sqlparser.DropTable:
+			statements = append(statements, stmt)
+		}
+	}
+
+	// current is the schema of the keyspace before the change. It is only needed to evaluate
+	// ALTER TABLE statements.
+	var current *schemadiff.Schema
+	if altered {
+		currentSQL, err := s.primaryTablesSQL(ctx, keyspace)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot read the schema of keyspace %s to lint the altered tables", keyspace)
+		}
+		current, err = schemadiff.NewSchemaFromSQL(env, currentSQL)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot read the schema of keyspace %s to lint the altered tables", keyspace)
+		}
+	}
+
+	// tables has the definition of each table that the statements changed so far, in the order in
+	// which the tables are first changed. A nil definition means the table was dropped.
+	var names []string
+	tables := make(map[string]*schemadiff.CreateTableEntity)
+	setTable := func(name string, table *schemadiff.CreateTableEntity) {
+		if _, ok := tables[name]; !ok {
+			names = append(names, name)
+		}
+		tables[name] = table
+	}
+	for _, stmt := range statements {
+		switch stmt := stmt.(type) {
+		case *sqlparser.CreateTable:
+			table, err := schemadiff.NewCreateTableEntity(env, stmt)
+			if err != nil {
+				continue
+			}
+			setTable(table.Name(), table)
+		case *sqlparser.AlterTable:
+			name := stmt.Table.Name.String()
+			table, ok := tables[name]
+			if !ok {
+				table = current.Table(name)
+			}
+			if table == nil {
+				// The table does not exist, which fails the schema change itself.
+				continue
+			}
+			to, err := table.Apply(schemadiff.EntityDiffByStatement(stmt))
+			if err != nil {
+				continue
+			}
+			if to.Name() != name {
+				setTable(name, nil)
+			}
+			setTable(to.Name(), to.(*schemadiff.CreateTableEntity))
+		case *sqlparser.DropTable:
+			for _, table := range stmt.FromTables {
+				setTable(table.Name.String(), nil)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ki, err := s.ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := s.schemaLintOptions(ctx, keyspace, ki.SchemaLintPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []*schemadiff.LintFinding
+	for _, name := range names {
+		if table := tables[name]; table != nil {
+			findings = append(findings, schemadiff.LintTable(table, opts)...)
+		}
+	}
+	return findings, nil
+}
+
+// primaryTablesSQL returns the CREATE TABLE statements of the tables on the primary tablet of the
+// first shard of the given keyspace.
+func (s *VtctldServer) primaryTablesSQL(ctx context.Context, keyspace string) (string, error) {
+	shards, err := s.ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return "", err
+	}
+	if len(shards) == 0 {
+		return "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %s has no shards", keyspace)
+	}
+	sort.Strings(shards)
+
+	si, err := s.ts.GetShard(ctx, keyspace, shards[0])
+	if err != nil {
+		return "", err
+	}
+	if !si.HasPrimary() {
+		return "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary", keyspace, shards[0])
+	}
+
+	sd, err := schematools.GetSchema(ctx, s.ts, s.tmc, si.PrimaryAlias, &tabletmanagerdatapb.GetSchemaRequest{TableSchemaOnly: true})
+	if err != nil {
+		return "", err
+	}
+
+	statements := make([]string, 0, len(sd.TableDefinitions))
+	for _, td := range sd.TableDefinitions {
+		if td.Type != tmutils.TableBaseTable || schema.IsInternalOperationTableName(td.Name) {
+			continue
+		}
+		statements = append(statements, td.Schema)
+	}
+	return strings.Join(statements, ";\n"), nil
+}
+
+func schemaLintFindingsToProto(findings []*schemadiff.LintFinding) []*vtctldatapb.SchemaLintFinding {
+	if len(findings) == 0 {
+		return nil
+	}
+	result := make([]*vtctldatapb.SchemaLintFinding, 0, len(findings))
+	for _, f := range findings {
+		result = append(result, &vtctldatapb.SchemaLintFinding{
+			Rule:     f.Rule,
+			Severity: f.Severity.String(),
+			Entity:   f.Entity,
+			Message:  f.Message,
+		})
+	}
+	return result
+}
@@ -65,12 +65,15 @@ import (
 	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/schemamanager"
+	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/topotools/events"
+	"vitess.io/vitess/go/vt/vtctl/audit"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil"
 	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
 	"vitess.io/vitess/go/vt/vtctl/schematools"
@@ -248,6 +251,32 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 		ctx = callerid.NewContext(ctx, req.CallerId, &querypb.VTGateCallerID{Username: req.CallerId.Principal})
 	}
 
+	span.Annotate("skip_lint", req.SkipLint)
+
+	lintFindings, err := s.lintChangedTables(ctx, req.Keyspace, req.Sql)
+	if err != nil {
+		if !req.SkipLint {
+			return nil, err
+		}
+		log.Warningf("ApplySchema: keyspace %s: ignoring schema lint failure: %v", req.Keyspace, err)
+		err = nil
+	}
+	var lintErrors []string
+	for _, finding := range lintFindings {
+		if finding.Severity == schemadiff.LintSeverityError {
+			lintErrors = append(lintErrors, finding.String())
+		} else {
+			log.Warningf("ApplySchema: keyspace %s: schema lint: %s", req.Keyspace, finding)
+		}
+	}
+	if len(lintErrors) > 0 {
+		if !req.SkipLint {
+			err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "schema lint found errors in keyspace %s:\n%s", req.Keyspace, strings.Join(lintErrors, "\n"))
+			return nil, err
+		}
+		log.Warningf("ApplySchema: keyspace %s: ignoring schema lint errors:\n%s", req.Keyspace, strings.Join(lintErrors, "\n"))
+	}
+
 	executionUUID, err := schema.CreateUUID()
 	if err != nil {
 		err = vterrors.Wrapf(err, "unable to create execution UUID")
@@ -302,6 +331,7 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 	resp = &vtctldatapb.ApplySchemaResponse{
 		UuidList:            execResult.UUIDs,
 		RowsAffectedByShard: make(map[string]uint64, len(execResult.SuccessShards)),
+		LintFindings:        schemaLintFindingsToProto(lintFindings),
 	}
 
 	for _, shard := range execResult.SuccessShards {
@@ -1498,6 +1528,41 @@ func (s *VtctldServer) FindAllShardsInKeyspace(ctx context.Context, req *vtctlda
 	}, nil
 }
 
+// GetAuditLog is part of the vtctldservicepb.VtctldServer interface.
+func (s *VtctldServer) GetAuditLog(ctx context.Context, req *vtctldatapb.GetAuditLogRequest) (resp *vtctldatapb.GetAuditLogResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetAuditLog")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("limit", req.Limit)
+	span.Annotate("actor", req.Actor)
+	span.Annotate("method", req.Method)
+
+	auditLog, err := s.ts.GetAuditLog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.GetAuditLogResponse{}
+	// The entries are stored oldest first, and returned newest first.
+	for i := len(auditLog.Entries) - 1; i >= 0; i-- {
+		if req.Limit > 0 && len(resp.Entries) >= int(req.Limit) {
+			break
+		}
+		entry := auditLog.Entries[i]
+		if req.Actor != "" && entry.Actor != req.Actor {
+			continue
+		}
+		if req.Method != "" && entry.Method != req.Method {
+			continue
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+
+	return resp, nil
+}
+
 // GetBackups is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) GetBackups(ctx context.Context, req *vtctldatapb.GetBackupsRequest) (resp *vtctldatapb.GetBackupsResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetBackups")
@@ -3042,6 +3107,51 @@ func (s *VtctldServer) LaunchSchemaMigration(ctx context.Context, req *vtctldata
 	return resp, nil
 }
 
+// LintSchema is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) LintSchema(ctx context.Context, req *vtctldatapb.LintSchemaRequest) (resp *vtctldatapb.LintSchemaResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.LintSchema")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	lintPolicy := ki.SchemaLintPolicy
+	if req.SchemaLintPolicy != nil {
+		lintPolicy = req.SchemaLintPolicy
+	}
+	opts, err := s.schemaLintOptions(ctx, req.Keyspace, lintPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := req.Sql
+	if sql == "" {
+		sql, err = s.primaryTablesSQL(ctx, req.Keyspace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Foreign keys may reference tables in other keyspaces, which the cross-keyspace-foreign-key
+	// rule reports.
+	hints := &schemadiff.DiffHints{ForeignKeyCheckStrategy: schemadiff.ForeignKeyCheckStrategyIgnore}
+	keyspaceSchema, err := schemadiff.NewSchemaFromSQLWithHints(s.schemadiffEnv(), sql, hints)
+	if err != nil {
+		err = vterrors.Wrapf(err, "invalid schema of keyspace %s", req.Keyspace)
+		return nil, err
+	}
+
+	return &vtctldatapb.LintSchemaResponse{
+		Findings: schemaLintFindingsToProto(schemadiff.LintSchema(keyspaceSchema, opts)),
+	}, nil
+}
+
 // LookupVindexComplete is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) LookupVindexComplete(ctx context.Context, req *vtctldatapb.LookupVindexCompleteRequest) (resp *vtctldatapb.LookupVindexCompleteResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.LookupVindexComplete")
@@ -3342,6 +3452,70 @@ func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatap
 	return resp, err
 }
 
+// PruneBackups is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PruneBackups(ctx context.Context, req *vtctldatapb.PruneBackupsRequest) (resp *vtctldatapb.PruneBackupsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PruneBackups")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("dry_run", req.DryRun)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := ki.BackupRetentionPolicy
+	if policy == nil {
+		err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %s has no backup retention policy", req.Keyspace)
+		return nil, err
+	}
+
+	shards := []string{req.Shard}
+	if req.Shard == "" {
+		shards, err = s.ts.GetShardNames(ctx, req.Keyspace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bs, err := backupstorage.GetBackupStorage()
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Close()
+
+	now := time.Now()
+	logger := logutil.NewConsoleLogger()
+	resp = &vtctldatapb.PruneBackupsResponse{}
+	for _, shard := range shards {
+		bucket := filepath.Join(req.Keyspace, shard)
+		bhs, err := bs.ListBackups(ctx, bucket)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bh := range mysqlctl.FindBackupsToPrune(ctx, policy, bhs, now, logger) {
+			if !req.DryRun {
+				if err := bs.RemoveBackup(ctx, bucket, bh.Name()); err != nil {
+					return nil, vterrors.Wrapf(err, "failed to remove backup %s of %s", bh.Name(), bucket)
+				}
+				log.Infof("Pruned backup %s of %s", bh.Name(), bucket)
+			}
+
+			bi := mysqlctlproto.BackupHandleToProto(bh)
+			bi.Keyspace = req.Keyspace
+			bi.Shard = shard
+			resp.RemovedBackups = append(resp.RemovedBackups, bi)
+		}
+	}
+
+	return resp, nil
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RebuildKeyspaceGraph(ctx context.Context, req *vtctldatapb.RebuildKeyspaceGraphRequest) (resp *vtctldatapb.RebuildKeyspaceGraphResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RebuildKeyspaceGraph")
@@ -3862,6 +4036,52 @@ func (s *VtctldServer) RunHealthCheck(ctx context.Context, req *vtctldatapb.RunH
 	return &vtctldatapb.RunHealthCheckResponse{}, nil
 }
 
+// SetKeyspaceBackupRetentionPolicy is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetKeyspaceBackupRetentionPolicy(ctx context.Context, req *vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest) (resp *vtctldatapb.SetKeyspaceBackupRetentionPolicyResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetKeyspaceBackupRetentionPolicy")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("backup_retention_policy", req.BackupRetentionPolicy.String())
+
+	policy := req.BackupRetentionPolicy
+	if policy.GetKeepLatest() < 0 || policy.GetKeepDailyDays() < 0 || policy.GetKeepWeeklyWeeks() < 0 {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "backup retention policy %v cannot have negative values", policy)
+		return nil, err
+	}
+	if policy.GetKeepLatest() == 0 && policy.GetKeepDailyDays() == 0 && policy.GetKeepWeeklyWeeks() == 0 {
+		// An empty policy would remove all the backups but the newest full
+		// backup, so it removes the policy instead.
+		policy = nil
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetKeyspaceBackupRetentionPolicy")
+	if lockErr != nil {
+		err = lockErr
+		return nil, err
+	}
+
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ki.BackupRetentionPolicy = policy
+
+	err = s.ts.UpdateKeyspace(ctx, ki)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetKeyspaceBackupRetentionPolicyResponse{
+		Keyspace: ki.Keyspace,
+	}, nil
+}
+
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtctldatapb.SetKeyspaceDurabilityPolicyRequest) (resp *vtctldatapb.SetKeyspaceDurabilityPolicyResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetKeyspaceDurabilityPolicy")
@@ -3885,6 +4105,12 @@ func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtc
 		return nil, err
 	}
 
+	if policy.IsDeclarativeDurability(req.DurabilityPolicy) {
+		if _, err = policy.ParseDeclarativeDurability(req.DurabilityPolicy); err != nil {
+			err = vterrors.Wrapf(err, "durability policy <%v> is not a valid policy", req.DurabilityPolicy)
+			return nil, err
+		}
+	}
 	policyValid := policy.CheckDurabilityPolicyExists(req.DurabilityPolicy)
 	if !policyValid {
 		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "durability policy <%v> is not a valid policy. Please register it as a policy first", req.DurabilityPolicy)
@@ -3903,6 +4129,54 @@ func (s *VtctldServer) SetKeyspaceDurabilityPolicy(ctx context.Context, req *vtc
 	}, nil
 }
 
+// SetKeyspaceSchemaLintPolicy is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) SetKeyspaceSchemaLintPolicy(ctx context.Context, req *vtctldatapb.SetKeyspaceSchemaLintPolicyRequest) (resp *vtctldatapb.SetKeyspaceSchemaLintPolicyResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetKeyspaceSchemaLintPolicy")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("schema_lint_policy", req.SchemaLintPolicy.String())
+
+	lintPolicy := req.SchemaLintPolicy
+	if _, err = schemadiff.ParseLintSeverities(lintPolicy.GetRuleSeverities()); err != nil {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid schema lint policy: %v", err)
+		return nil, err
+	}
+	if lintPolicy.GetMaxIndexKeyLength() < 0 {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "schema lint policy %v cannot have a negative max index key length", lintPolicy)
+		return nil, err
+	}
+	if len(lintPolicy.GetRuleSeverities()) == 0 && lintPolicy.GetMaxIndexKeyLength() == 0 {
+		lintPolicy = nil
+	}
+
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, req.Keyspace, "SetKeyspaceSchemaLintPolicy")
+	if lockErr != nil {
+		err = lockErr
+		return nil, err
+	}
+
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ki.SchemaLintPolicy = lintPolicy
+
+	err = s.ts.UpdateKeyspace(ctx, ki)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.SetKeyspaceSchemaLintPolicyResponse{
+		Keyspace: ki.Keyspace,
+	}, nil
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) SetShardIsPrimaryServing(ctx context.Context, req *vtctldatapb.SetShardIsPrimaryServingRequest) (resp *vtctldatapb.SetShardIsPrimaryServingResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.SetShardIsPrimaryServing")
@@ -5642,9 +5916,19 @@ func (s *VtctldServer) WorkflowMirrorTraffic(ctx context.Context, req *vtctldata
 	return resp, err
 }
 
-// StartServer registers a VtctldServer for RPCs on the given gRPC server.
+// StartServer registers a VtctldServer for RPCs on the given gRPC server. The
+// mutating RPCs are audited if --audit-log-sinks is set.
 func StartServer(s *grpc.Server, env *vtenv.Environment, ts *topo.Server) {
-	vtctlservicepb.RegisterVtctldServer(s, NewVtctldServer(env, ts))
+	auditLogger, err := audit.NewFromFlags("vtctld", ts)
+	if err != nil {
+		log.Fatalf("failed to create the audit log: %v", err)
+	}
+	if auditLogger == nil {
+		vtctlservicepb.RegisterVtctldServer(s, NewVtctldServer(env, ts))
+		return
+	}
+	s.RegisterService(auditLogger.WrapServiceDesc(&vtctlservicepb.Vtctld_ServiceDesc, audit.GRPCActor), NewVtctldServer(env, ts))
+	servenv.OnClose(func() { auditLogger.Close() })
 }
 
 // getTopologyCell is a helper method that returns a topology cell given its path.
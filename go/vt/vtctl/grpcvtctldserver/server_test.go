@@ -46,8 +46,10 @@ import (
 	"vitess.io/vitess/go/vt/callerid"
 	hk "vitess.io/vitess/go/vt/hook"
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/mysqlctl/tmutils"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/proto/vttime"
+	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
@@ -353,6 +355,93 @@ func TestApplyRoutingRules(t *testing.T) {
 	}
 }
 
+func TestApplySchemaLint(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddKeyspaces(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name: "ks1",
+		Keyspace: &topodatapb.Keyspace{
+			SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+				RuleSeverities: map[string]string{
+					schemadiff.LintRuleMissingPrimaryKey: "error",
+				},
+			},
+		},
+	})
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	_, err := vtctld.ApplySchema(ctx, &vtctldatapb.ApplySchemaRequest{
+		Keyspace: "ks1",
+		Sql: []string{
+			"create table t1 (id int primary key, name varchar(32) charset utf8mb3)",
+			"create table t2 (id int)",
+		},
+	})
+	assert.EqualError(t, err, "schema lint found errors in keyspace ks1:\nerror: missing-primary-key: table `t2`: table has no PRIMARY KEY")
+}
+
+func TestApplySchemaLintAlter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "zone1")
+	testutil.AddKeyspaces(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name: "ks1",
+		Keyspace: &topodatapb.Keyspace{
+			SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+				RuleSeverities: map[string]string{
+					schemadiff.LintRuleMissingPrimaryKey: "error",
+				},
+			},
+		},
+	})
+	testutil.AddTablet(ctx, t, ts, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "ks1",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_PRIMARY,
+	}, &testutil.AddTabletOptions{AlsoSetShardPrimary: true})
+	tmc := &testutil.TabletManagerClient{
+		GetSchemaResults: map[string]struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{
+			"zone1-0000000100": {
+				Schema: &tabletmanagerdatapb.SchemaDefinition{
+					TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+						{
+							Name:   "t1",
+							Schema: "CREATE TABLE `t1` (\n  `id` int NOT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB",
+							Type:   tmutils.TableBaseTable,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	_, err := vtctld.ApplySchema(ctx, &vtctldatapb.ApplySchemaRequest{
+		Keyspace: "ks1",
+		Sql: []string{
+			"create table t2 (id int)",
+			"alter table t2 add primary key (id)",
+			"alter table t1 drop primary key",
+		},
+	})
+	assert.EqualError(t, err, "schema lint found errors in keyspace ks1:\nerror: missing-primary-key: table `t1`: table has no PRIMARY KEY")
+}
+
 func TestApplyVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -5778,6 +5867,71 @@ func TestFindAllShardsInKeyspace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	resp, err := vtctld.GetAuditLog(ctx, &vtctldatapb.GetAuditLogRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Entries)
+
+	entries := []*vtctldatapb.AuditLogEntry{
+		{Actor: "alice", Method: "CreateKeyspace"},
+		{Actor: "bob", Method: "PlannedReparentShard"},
+		{Actor: "alice", Method: "PlannedReparentShard"},
+		{Actor: "alice", Method: "DeleteKeyspace"},
+	}
+	for _, entry := range entries {
+		err := ts.AppendAuditLogEntry(ctx, entry, 10)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		req  *vtctldatapb.GetAuditLogRequest
+		want []*vtctldatapb.AuditLogEntry
+	}{
+		{
+			name: "all entries, newest first",
+			req:  &vtctldatapb.GetAuditLogRequest{},
+			want: []*vtctldatapb.AuditLogEntry{entries[3], entries[2], entries[1], entries[0]},
+		},
+		{
+			name: "limit",
+			req:  &vtctldatapb.GetAuditLogRequest{Limit: 2},
+			want: []*vtctldatapb.AuditLogEntry{entries[3], entries[2]},
+		},
+		{
+			name: "actor",
+			req:  &vtctldatapb.GetAuditLogRequest{Actor: "alice", Limit: 2},
+			want: []*vtctldatapb.AuditLogEntry{entries[3], entries[2]},
+		},
+		{
+			name: "method",
+			req:  &vtctldatapb.GetAuditLogRequest{Method: "PlannedReparentShard"},
+			want: []*vtctldatapb.AuditLogEntry{entries[2], entries[1]},
+		},
+		{
+			name: "actor and method",
+			req:  &vtctldatapb.GetAuditLogRequest{Actor: "bob", Method: "CreateKeyspace"},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := vtctld.GetAuditLog(ctx, tt.req)
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.want, resp.Entries)
+		})
+	}
+}
+
 func TestGetBackups(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -8714,6 +8868,210 @@ func TestLaunchSchemaMigration(t *testing.T) {
 	}
 }
 
+func TestLintSchema(t *testing.T) {
+	t.Parallel()
+
+	primarySchema := &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+			{
+				Name:   "t1",
+				Schema: "CREATE TABLE `t1` (\n  `id` int NOT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB",
+				Type:   tmutils.TableBaseTable,
+			},
+			{
+				Name:   "t2",
+				Schema: "CREATE TABLE `t2` (\n  `id` int DEFAULT NULL,\n  `t1_id` int DEFAULT NULL,\n  KEY `t1_id` (`t1_id`),\n  CONSTRAINT `t2_fk` FOREIGN KEY (`t1_id`) REFERENCES `other`.`t1` (`id`)\n) ENGINE=InnoDB",
+				Type:   tmutils.TableBaseTable,
+			},
+			{
+				Name:   "v1",
+				Schema: "CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `v1` AS select `t9`.`id` AS `id` from `t9`",
+				Type:   tmutils.TableView,
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		keyspace    *topodatapb.Keyspace
+		vschema     *vschemapb.Keyspace
+		noPrimary   bool
+		req         *vtctldatapb.LintSchemaRequest
+		expected    *vtctldatapb.LintSchemaResponse
+		expectedErr string
+	}{
+		{
+			name:     "primary schema",
+			keyspace: &topodatapb.Keyspace{},
+			vschema: &vschemapb.Keyspace{
+				Sharded: true,
+				Tables: map[string]*vschemapb.Table{
+					"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "id"}}},
+				},
+			},
+			req: &vtctldatapb.LintSchemaRequest{
+				Keyspace: "ks1",
+			},
+			expected: &vtctldatapb.LintSchemaResponse{
+				Findings: []*vtctldatapb.SchemaLintFinding{
+					{
+						Rule:     schemadiff.LintRuleMissingPrimaryKey,
+						Severity: "warning",
+						Entity:   "t2",
+						Message:  "table has no PRIMARY KEY",
+					},
+					{
+						Rule:     schemadiff.LintRuleMissingVindexColumn,
+						Severity: "warning",
+						Entity:   "t2",
+						Message:  "table is not in the VSchema of sharded keyspace ks1",
+					},
+					{
+						Rule:     schemadiff.LintRuleCrossKeyspaceForeignKey,
+						Severity: "warning",
+						Entity:   "t2",
+						Message:  "foreign key `t2_fk` references table `t1` in keyspace `other`",
+					},
+				},
+			},
+		},
+		{
+			name: "keyspace policy",
+			keyspace: &topodatapb.Keyspace{
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+					RuleSeverities: map[string]string{
+						schemadiff.LintRuleMissingPrimaryKey:       "error",
+						schemadiff.LintRuleCrossKeyspaceForeignKey: "off",
+					},
+				},
+			},
+			req: &vtctldatapb.LintSchemaRequest{
+				Keyspace: "ks1",
+			},
+			expected: &vtctldatapb.LintSchemaResponse{
+				Findings: []*vtctldatapb.SchemaLintFinding{
+					{
+						Rule:     schemadiff.LintRuleMissingPrimaryKey,
+						Severity: "error",
+						Entity:   "t2",
+						Message:  "table has no PRIMARY KEY",
+					},
+				},
+			},
+		},
+		{
+			name: "sql and request policy",
+			keyspace: &topodatapb.Keyspace{
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+					RuleSeverities: map[string]string{
+						schemadiff.LintRuleMissingPrimaryKey: "error",
+					},
+				},
+			},
+			noPrimary: true,
+			req: &vtctldatapb.LintSchemaRequest{
+				Keyspace: "ks1",
+				Sql:      "create table t3 (id int); create table t4 (id int primary key, a varchar(100), key a_idx (a))",
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+					MaxIndexKeyLength: 100,
+				},
+			},
+			expected: &vtctldatapb.LintSchemaResponse{
+				Findings: []*vtctldatapb.SchemaLintFinding{
+					{
+						Rule:     schemadiff.LintRuleMissingPrimaryKey,
+						Severity: "warning",
+						Entity:   "t3",
+						Message:  "table has no PRIMARY KEY",
+					},
+					{
+						Rule:     schemadiff.LintRuleWideIndex,
+						Severity: "warning",
+						Entity:   "t4",
+						Message:  "key `a_idx` is 400 bytes wide, more than 100 bytes",
+					},
+				},
+			},
+		},
+		{
+			name:     "invalid policy",
+			keyspace: &topodatapb.Keyspace{},
+			req: &vtctldatapb.LintSchemaRequest{
+				Keyspace: "ks1",
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+					RuleSeverities: map[string]string{"no-such-rule": "error"},
+				},
+			},
+			expectedErr: "invalid schema lint policy: unknown lint rule: no-such-rule",
+		},
+		{
+			name:      "no primary",
+			keyspace:  &topodatapb.Keyspace{},
+			noPrimary: true,
+			req: &vtctldatapb.LintSchemaRequest{
+				Keyspace: "ks1",
+			},
+			expectedErr: "shard ks1/-80 has no primary",
+		},
+		{
+			name: "keyspace not found",
+			req: &vtctldatapb.LintSchemaRequest{
+				Keyspace: "ks1",
+			},
+			expectedErr: "node doesn't exist: keyspaces/ks1/Keyspace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ts := memorytopo.NewServer(ctx, "zone1")
+			tmc := &testutil.TabletManagerClient{
+				GetSchemaResults: map[string]struct {
+					Schema *tabletmanagerdatapb.SchemaDefinition
+					Error  error
+				}{
+					"zone1-0000000100": {Schema: primarySchema},
+				},
+			}
+			if tt.keyspace != nil {
+				testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{Name: "ks1", Keyspace: tt.keyspace})
+				for i, shard := range []string{"80-", "-80"} {
+					tabletType := topodatapb.TabletType_PRIMARY
+					if tt.noPrimary {
+						tabletType = topodatapb.TabletType_REPLICA
+					}
+					testutil.AddTablet(ctx, t, ts, &topodatapb.Tablet{
+						Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: uint32(101 - i)},
+						Keyspace: "ks1",
+						Shard:    shard,
+						Type:     tabletType,
+					}, &testutil.AddTabletOptions{AlsoSetShardPrimary: true})
+				}
+			}
+			if tt.vschema != nil {
+				require.NoError(t, ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{Name: "ks1", Keyspace: tt.vschema}))
+			}
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			resp, err := vtctld.LintSchema(ctx, tt.req)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestPingTablet(t *testing.T) {
 	t.Parallel()
 
@@ -9071,6 +9429,128 @@ func TestPlannedReparentShard(t *testing.T) {
 	}
 }
 
+func TestPruneBackups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memorytopo.NewServer(ctx)
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddKeyspaces(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name: "testkeyspace",
+		Keyspace: &topodatapb.Keyspace{
+			BackupRetentionPolicy: &topodatapb.BackupRetentionPolicy{KeepLatest: 1},
+		},
+	}, &vtctldatapb.Keyspace{
+		Name:     "nopolicy",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	testutil.AddShards(ctx, t, ts, &vtctldatapb.Shard{
+		Keyspace: "testkeyspace",
+		Name:     "-80",
+	}, &vtctldatapb.Shard{
+		Keyspace: "testkeyspace",
+		Name:     "80-",
+	})
+
+	manifest := func(name string, backupTime string, position string) string {
+		return fmt.Sprintf(`{"BackupName": %q, "BackupTime": %q, "Position": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:%s"}`, name, backupTime, position)
+	}
+	incrementalManifest := func(name string, backupTime string, position string, fromPosition string) string {
+		return fmt.Sprintf(`{"BackupName": %q, "BackupTime": %q, "Incremental": true, "Position": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:%s", "FromPosition": "MySQL56/16b1039f-22b6-11ed-b765-0a43f95f28a3:%s"}`, name, backupTime, position, fromPosition)
+	}
+	setup := func() {
+		testutil.BackupStorage.Backups = map[string][]string{
+			"testkeyspace/-80": {"backup1", "backup2", "backup3", "backup4"},
+			"testkeyspace/80-": {"backup1", "backup2"},
+		}
+		testutil.BackupStorage.Files = map[string]string{
+			"testkeyspace/-80/backup1/MANIFEST": manifest("backup1", "2026-01-01T00:00:00Z", "1-10"),
+			"testkeyspace/-80/backup2/MANIFEST": manifest("backup2", "2026-01-02T00:00:00Z", "1-20"),
+			"testkeyspace/-80/backup3/MANIFEST": incrementalManifest("backup3", "2026-01-03T00:00:00Z", "1-30", "1-20"),
+			// backup4 is in progress, and has no MANIFEST yet.
+			"testkeyspace/80-/backup1/MANIFEST": manifest("backup1", "2026-01-01T00:00:00Z", "1-10"),
+			"testkeyspace/80-/backup2/MANIFEST": manifest("backup2", "2026-01-02T00:00:00Z", "1-20"),
+		}
+	}
+	defer func() { testutil.BackupStorage.Files = nil }()
+
+	backupNames := func(t *testing.T, shard string) []string {
+		resp, err := vtctld.GetBackups(ctx, &vtctldatapb.GetBackupsRequest{
+			Keyspace: "testkeyspace",
+			Shard:    shard,
+		})
+		require.NoError(t, err)
+
+		var names []string
+		for _, bi := range resp.Backups {
+			names = append(names, bi.Name)
+		}
+		return names
+	}
+
+	t.Run("all shards", func(t *testing.T) {
+		setup()
+		resp, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+			Keyspace: "testkeyspace",
+		})
+		require.NoError(t, err)
+		expected := &vtctldatapb.PruneBackupsResponse{
+			RemovedBackups: []*mysqlctlpb.BackupInfo{
+				{
+					Directory: "testkeyspace/-80",
+					Name:      "backup1",
+					Keyspace:  "testkeyspace",
+					Shard:     "-80",
+				},
+				{
+					Directory: "testkeyspace/80-",
+					Name:      "backup1",
+					Keyspace:  "testkeyspace",
+					Shard:     "80-",
+				},
+			},
+		}
+		utils.MustMatch(t, expected, resp)
+		// The full backup of the latest incremental backup is kept.
+		utils.MustMatch(t, []string{"backup2", "backup3", "backup4"}, backupNames(t, "-80"))
+		utils.MustMatch(t, []string{"backup2"}, backupNames(t, "80-"))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		setup()
+		resp, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+			Keyspace: "testkeyspace",
+			Shard:    "80-",
+			DryRun:   true,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.RemovedBackups, 1)
+		assert.Equal(t, "backup1", resp.RemovedBackups[0].Name)
+		utils.MustMatch(t, []string{"backup1", "backup2"}, backupNames(t, "80-"))
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		setup()
+		_, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+			Keyspace: "nopolicy",
+		})
+		assert.EqualError(t, err, "keyspace nopolicy has no backup retention policy")
+	})
+
+	t.Run("listbackups error", func(t *testing.T) {
+		setup()
+		testutil.BackupStorage.ListBackupsError = assert.AnError
+		defer func() { testutil.BackupStorage.ListBackupsError = nil }()
+
+		_, err := vtctld.PruneBackups(ctx, &vtctldatapb.PruneBackupsRequest{
+			Keyspace: "testkeyspace",
+		})
+		assert.Error(t, err)
+	})
+}
+
 func TestRebuildKeyspaceGraph(t *testing.T) {
 	t.Parallel()
 
@@ -11405,6 +11885,110 @@ func TestRunHealthCheck(t *testing.T) {
 	}
 }
 
+func TestSetKeyspaceBackupRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		keyspaces   []*vtctldatapb.Keyspace
+		req         *vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest
+		expected    *vtctldatapb.SetKeyspaceBackupRetentionPolicyResponse
+		expectedErr string
+	}{
+		{
+			name: "ok",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest{
+				Keyspace: "ks1",
+				BackupRetentionPolicy: &topodatapb.BackupRetentionPolicy{
+					KeepLatest:    3,
+					KeepDailyDays: 7,
+				},
+			},
+			expected: &vtctldatapb.SetKeyspaceBackupRetentionPolicyResponse{
+				Keyspace: &topodatapb.Keyspace{
+					BackupRetentionPolicy: &topodatapb.BackupRetentionPolicy{
+						KeepLatest:    3,
+						KeepDailyDays: 7,
+					},
+				},
+			},
+		},
+		{
+			name: "empty policy",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name: "ks1",
+					Keyspace: &topodatapb.Keyspace{
+						BackupRetentionPolicy: &topodatapb.BackupRetentionPolicy{
+							KeepWeeklyWeeks: 4,
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest{
+				Keyspace:              "ks1",
+				BackupRetentionPolicy: &topodatapb.BackupRetentionPolicy{},
+			},
+			expected: &vtctldatapb.SetKeyspaceBackupRetentionPolicyResponse{
+				Keyspace: &topodatapb.Keyspace{},
+			},
+		},
+		{
+			name: "negative values",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest{
+				Keyspace: "ks1",
+				BackupRetentionPolicy: &topodatapb.BackupRetentionPolicy{
+					KeepLatest: -1,
+				},
+			},
+			expectedErr: "backup retention policy keep_latest:-1 cannot have negative values",
+		},
+		{
+			name: "keyspace not found",
+			req: &vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest{
+				Keyspace: "ks1",
+			},
+			expectedErr: "node doesn't exist: keyspaces/ks1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ts := memorytopo.NewServer(ctx, "zone1")
+			testutil.AddKeyspaces(ctx, t, ts, tt.keyspaces...)
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			resp, err := vtctld.SetKeyspaceBackupRetentionPolicy(ctx, tt.req)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestSetKeyspaceDurabilityPolicy(t *testing.T) {
 	t.Parallel()
 
@@ -11458,6 +12042,38 @@ func TestSetKeyspaceDurabilityPolicy(t *testing.T) {
 			},
 			expectedErr: "durability policy <non-existent> is not a valid policy. Please register it as a policy first",
 		},
+		{
+			name: "declarative durability policy",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceDurabilityPolicyRequest{
+				Keyspace:         "ks1",
+				DurabilityPolicy: `{"semi_sync_rules": [{"ackers": 2, "min_cells": 2}]}`,
+			},
+			expected: &vtctldatapb.SetKeyspaceDurabilityPolicyResponse{
+				Keyspace: &topodatapb.Keyspace{
+					DurabilityPolicy: `{"semi_sync_rules": [{"ackers": 2, "min_cells": 2}]}`,
+				},
+			},
+		},
+		{
+			name: "invalid declarative durability policy",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceDurabilityPolicyRequest{
+				Keyspace:         "ks1",
+				DurabilityPolicy: `{"semi_sync_rules": [{"ackers": 2, "min_cells": 3}]}`,
+			},
+			expectedErr: `durability policy <{"semi_sync_rules": [{"ackers": 2, "min_cells": 3}]}> is not a valid policy: invalid declarative durability policy: min_cells 3 is not supported, it must be 1 or 2`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -11485,6 +12101,134 @@ func TestSetKeyspaceDurabilityPolicy(t *testing.T) {
 	}
 }
 
+func TestSetKeyspaceSchemaLintPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		keyspaces   []*vtctldatapb.Keyspace
+		req         *vtctldatapb.SetKeyspaceSchemaLintPolicyRequest
+		expected    *vtctldatapb.SetKeyspaceSchemaLintPolicyResponse
+		expectedErr string
+	}{
+		{
+			name: "ok",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceSchemaLintPolicyRequest{
+				Keyspace: "ks1",
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+					RuleSeverities: map[string]string{
+						schemadiff.LintRuleMissingPrimaryKey: "error",
+						schemadiff.LintRuleUtf8mb3:           "off",
+					},
+					MaxIndexKeyLength: 3072,
+				},
+			},
+			expected: &vtctldatapb.SetKeyspaceSchemaLintPolicyResponse{
+				Keyspace: &topodatapb.Keyspace{
+					SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+						RuleSeverities: map[string]string{
+							schemadiff.LintRuleMissingPrimaryKey: "error",
+							schemadiff.LintRuleUtf8mb3:           "off",
+						},
+						MaxIndexKeyLength: 3072,
+					},
+				},
+			},
+		},
+		{
+			name: "empty policy",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name: "ks1",
+					Keyspace: &topodatapb.Keyspace{
+						SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+							MaxIndexKeyLength: 3072,
+						},
+					},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceSchemaLintPolicyRequest{
+				Keyspace:         "ks1",
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{},
+			},
+			expected: &vtctldatapb.SetKeyspaceSchemaLintPolicyResponse{
+				Keyspace: &topodatapb.Keyspace{},
+			},
+		},
+		{
+			name: "invalid severity",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceSchemaLintPolicyRequest{
+				Keyspace: "ks1",
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+					RuleSeverities: map[string]string{
+						schemadiff.LintRuleWideIndex: "fatal",
+					},
+				},
+			},
+			expectedErr: `invalid schema lint policy: invalid severity "fatal" for lint rule wide-index, expected one of off, warning, error`,
+		},
+		{
+			name: "negative max index key length",
+			keyspaces: []*vtctldatapb.Keyspace{
+				{
+					Name:     "ks1",
+					Keyspace: &topodatapb.Keyspace{},
+				},
+			},
+			req: &vtctldatapb.SetKeyspaceSchemaLintPolicyRequest{
+				Keyspace: "ks1",
+				SchemaLintPolicy: &topodatapb.SchemaLintPolicy{
+					MaxIndexKeyLength: -1,
+				},
+			},
+			expectedErr: "schema lint policy max_index_key_length:-1 cannot have a negative max index key length",
+		},
+		{
+			name: "keyspace not found",
+			req: &vtctldatapb.SetKeyspaceSchemaLintPolicyRequest{
+				Keyspace: "ks1",
+			},
+			expectedErr: "node doesn't exist: keyspaces/ks1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ts := memorytopo.NewServer(ctx, "zone1")
+			testutil.AddKeyspaces(ctx, t, ts, tt.keyspaces...)
+
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+			resp, err := vtctld.SetKeyspaceSchemaLintPolicy(ctx, tt.req)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			utils.MustMatch(t, tt.expected, resp)
+		})
+	}
+}
+
 func TestSetShardIsPrimaryServing(t *testing.T) {
 	t.Parallel()
 
@@ -19,7 +19,10 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"io"
+	"path"
 	"sort"
+	"strings"
 
 	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
 )
@@ -30,6 +33,9 @@ type backupStorage struct {
 	// Backups is a mapping of directory to list of backup names stored in that
 	// directory.
 	Backups map[string][]string
+	// Files is a mapping of directory/name/filename to the contents of the
+	// files of the backups, for the backups whose files are read.
+	Files map[string]string
 	// ListBackupsError is returned from ListBackups when it is non-nil.
 	ListBackupsError error
 }
@@ -45,7 +51,7 @@ func (bs *backupStorage) ListBackups(ctx context.Context, dir string) ([]backups
 	for k, v := range bs.Backups {
 		if k == dir {
 			for _, name := range v {
-				handles = append(handles, &backupHandle{directory: k, name: name})
+				handles = append(handles, &backupHandle{directory: k, name: name, files: bs.Files})
 			}
 		}
 	}
@@ -87,11 +93,24 @@ type backupHandle struct {
 
 	directory string
 	name      string
+	files     map[string]string
 }
 
 func (bh *backupHandle) Directory() string { return bh.directory }
 func (bh *backupHandle) Name() string      { return bh.name }
 
+// ReadFile is part of the backupstorage.BackupHandle interface.
+func (bh *backupHandle) ReadFile(ctx context.Context, filename string) (io.ReadCloser, error) {
+	data, ok := bh.files[path.Join(bh.directory, bh.name, filename)]
+	if !ok {
+		return nil, fmt.Errorf("no file %s in backup %s/%s", filename, bh.directory, bh.name)
+	}
+	return io.NopCloser(strings.NewReader(data)), nil
+}
+
+// Error is part of the backupstorage.BackupHandle interface.
+func (bh *backupHandle) Error() error { return nil }
+
 // handlesByName implements the sort interface for backup handles by Name().
 type handlesByName []backupstorage.BackupHandle
 
@@ -281,6 +281,11 @@ func (client *localVtctldClient) ForceCutOverSchemaMigration(ctx context.Context
 	return client.s.ForceCutOverSchemaMigration(ctx, in)
 }
 
+// GetAuditLog is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetAuditLog(ctx context.Context, in *vtctldatapb.GetAuditLogRequest, opts ...grpc.CallOption) (*vtctldatapb.GetAuditLogResponse, error) {
+	return client.s.GetAuditLog(ctx, in)
+}
+
 // GetBackups is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetBackups(ctx context.Context, in *vtctldatapb.GetBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetBackupsResponse, error) {
 	return client.s.GetBackups(ctx, in)
@@ -436,6 +441,11 @@ func (client *localVtctldClient) LaunchSchemaMigration(ctx context.Context, in *
 	return client.s.LaunchSchemaMigration(ctx, in)
 }
 
+// LintSchema is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) LintSchema(ctx context.Context, in *vtctldatapb.LintSchemaRequest, opts ...grpc.CallOption) (*vtctldatapb.LintSchemaResponse, error) {
+	return client.s.LintSchema(ctx, in)
+}
+
 // LookupVindexComplete is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) LookupVindexComplete(ctx context.Context, in *vtctldatapb.LookupVindexCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexCompleteResponse, error) {
 	return client.s.LookupVindexComplete(ctx, in)
@@ -506,6 +516,11 @@ func (client *localVtctldClient) PlannedReparentShard(ctx context.Context, in *v
 	return client.s.PlannedReparentShard(ctx, in)
 }
 
+// PruneBackups is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) PruneBackups(ctx context.Context, in *vtctldatapb.PruneBackupsRequest, opts ...grpc.CallOption) (*vtctldatapb.PruneBackupsResponse, error) {
+	return client.s.PruneBackups(ctx, in)
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
 	return client.s.RebuildKeyspaceGraph(ctx, in)
@@ -627,11 +642,21 @@ func (client *localVtctldClient) RunHealthCheck(ctx context.Context, in *vtctlda
 	return client.s.RunHealthCheck(ctx, in)
 }
 
+// SetKeyspaceBackupRetentionPolicy is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetKeyspaceBackupRetentionPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceBackupRetentionPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceBackupRetentionPolicyResponse, error) {
+	return client.s.SetKeyspaceBackupRetentionPolicy(ctx, in)
+}
+
 // SetKeyspaceDurabilityPolicy is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetKeyspaceDurabilityPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceDurabilityPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceDurabilityPolicyResponse, error) {
 	return client.s.SetKeyspaceDurabilityPolicy(ctx, in)
 }
 
+// SetKeyspaceSchemaLintPolicy is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) SetKeyspaceSchemaLintPolicy(ctx context.Context, in *vtctldatapb.SetKeyspaceSchemaLintPolicyRequest, opts ...grpc.CallOption) (*vtctldatapb.SetKeyspaceSchemaLintPolicyResponse, error) {
+	return client.s.SetKeyspaceSchemaLintPolicy(ctx, in)
+}
+
 // SetShardIsPrimaryServing is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) SetShardIsPrimaryServing(ctx context.Context, in *vtctldatapb.SetShardIsPrimaryServingRequest, opts ...grpc.CallOption) (*vtctldatapb.SetShardIsPrimaryServingResponse, error) {
 	return client.s.SetShardIsPrimaryServing(ctx, in)
@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/promotionrule"
+)
+
+// DeclarativeDurability is a durability policy declared as rules, rather than
+// registered in the binaries. It is stored as JSON in the durability policy of
+// the keyspace, for example, to require 2 semi-sync acks from replicas of a
+// different cell than the primary, and to prefer promoting the replicas of
+// zone1:
+//
+//	{
+//	  "promotion_rules": [
+//	    {"cells": ["zone1"], "tablet_types": ["PRIMARY", "REPLICA"], "rule": "prefer"},
+//	    {"tablet_types": ["PRIMARY", "REPLICA"], "rule": "neutral"}
+//	  ],
+//	  "semi_sync_rules": [
+//	    {"ackers": 2, "min_cells": 2}
+//	  ]
+//	}
+type DeclarativeDurability struct {
+	// PromotionRules are the promotion rules of the tablets. The first rule
+	// that matches a tablet applies, and the tablets that match no rule must
+	// not be promoted. If there are no rules, the PRIMARY and REPLICA tablets
+	// are neutral, like in the none durability policy.
+	PromotionRules []DeclarativePromotionRule `json:"promotion_rules,omitempty"`
+	// SemiSyncRules are the semi-sync setups of the tablets if they were to
+	// become the primary. The first rule that matches the primary applies,
+	// and there is no semi-sync if no rule matches.
+	SemiSyncRules []DeclarativeSemiSyncRule `json:"semi_sync_rules,omitempty"`
+}
+
+// TabletMatcher matches the tablets of any of the Cells, of any of the
+// TabletTypes and with any of the TabletAliases. An empty list matches all
+// the tablets.
+type TabletMatcher struct {
+	Cells         []string `json:"cells,omitempty"`
+	TabletTypes   []string `json:"tablet_types,omitempty"`
+	TabletAliases []string `json:"tablet_aliases,omitempty"`
+}
+
+// DeclarativePromotionRule is the promotion rule of the tablets it matches.
+type DeclarativePromotionRule struct {
+	TabletMatcher
+	Rule promotionrule.CandidatePromotionRule `json:"rule"`
+}
+
+// DeclarativeSemiSyncRule is the semi-sync setup of the primaries it matches.
+type DeclarativeSemiSyncRule struct {
+	TabletMatcher
+	// Ackers is the number of semi-sync acks the primary waits for.
+	Ackers int `json:"ackers"`
+	// MinCells is the number of distinct cells, including the cell of the
+	// primary, a transaction must be in to be acknowledged. MySQL counts all
+	// the acks alike, so only 1 or 2 can be enforced: 2 only lets the
+	// replicas of the other cells send acks.
+	MinCells int `json:"min_cells,omitempty"`
+	// EligibleAckers are the replicas that send semi-sync acks. If empty,
+	// the PRIMARY and REPLICA tablets send acks.
+	EligibleAckers []TabletMatcher `json:"eligible_ackers,omitempty"`
+}
+
+// IsDeclarativeDurability returns whether the durability policy is a
+// declarative one, rather than the name of a registered one.
+func IsDeclarativeDurability(name string) bool {
+	return strings.HasPrefix(strings.TrimSpace(name), "{")
+}
+
+// ParseDeclarativeDurability parses and validates a declarative durability
+// policy.
+func ParseDeclarativeDurability(data string) (*DeclarativeDurability, error) {
+	dd := &DeclarativeDurability{}
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dd); err != nil {
+		return nil, fmt.Errorf("invalid declarative durability policy: %v", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid declarative durability policy: unexpected data after the policy")
+	}
+	for _, rule := range dd.PromotionRules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+		if _, err := promotionrule.Parse(string(rule.Rule)); err != nil {
+			return nil, fmt.Errorf("invalid declarative durability policy: %v", err)
+		}
+	}
+	for _, rule := range dd.SemiSyncRules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+		if rule.Ackers < 0 {
+			return nil, fmt.Errorf("invalid declarative durability policy: negative ackers %d", rule.Ackers)
+		}
+		if rule.MinCells < 0 || rule.MinCells > 2 {
+			return nil, fmt.Errorf("invalid declarative durability policy: min_cells %d is not supported, it must be 1 or 2", rule.MinCells)
+		}
+		for _, acker := range rule.EligibleAckers {
+			if err := acker.validate(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dd, nil
+}
+
+func (tm *TabletMatcher) validate() error {
+	for _, tabletType := range tm.TabletTypes {
+		if _, err := topoproto.ParseTabletType(tabletType); err != nil {
+			return fmt.Errorf("invalid declarative durability policy: %v", err)
+		}
+	}
+	for _, alias := range tm.TabletAliases {
+		if _, err := topoproto.ParseTabletAlias(alias); err != nil {
+			return fmt.Errorf("invalid declarative durability policy: %v", err)
+		}
+	}
+	return nil
+}
+
+// matches returns whether the matcher matches the tablet. A nil tablet only
+// matches the matchers without any conditions.
+func (tm *TabletMatcher) matches(tablet *topodatapb.Tablet) bool {
+	if tablet == nil || tablet.Alias == nil {
+		return len(tm.Cells) == 0 && len(tm.TabletTypes) == 0 && len(tm.TabletAliases) == 0
+	}
+	if len(tm.Cells) > 0 && !slices.Contains(tm.Cells, tablet.Alias.Cell) {
+		return false
+	}
+	if len(tm.TabletTypes) > 0 && !slices.ContainsFunc(tm.TabletTypes, func(tabletType string) bool {
+		tt, _ := topoproto.ParseTabletType(tabletType)
+		return tt == tablet.Type
+	}) {
+		return false
+	}
+	if len(tm.TabletAliases) > 0 && !slices.Contains(tm.TabletAliases, topoproto.TabletAliasString(tablet.Alias)) {
+		return false
+	}
+	return true
+}
+
+//=======================================================================
+
+// durabilityDeclarative is the Durabler of a DeclarativeDurability.
+type durabilityDeclarative struct {
+	dd *DeclarativeDurability
+}
+
+// PromotionRule implements the Durabler interface
+func (d *durabilityDeclarative) PromotionRule(tablet *topodatapb.Tablet) promotionrule.CandidatePromotionRule {
+	if len(d.dd.PromotionRules) == 0 {
+		switch tablet.Type {
+		case topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA:
+			return promotionrule.Neutral
+		}
+		return promotionrule.MustNot
+	}
+	for _, rule := range d.dd.PromotionRules {
+		if rule.matches(tablet) {
+			return rule.Rule
+		}
+	}
+	return promotionrule.MustNot
+}
+
+// SemiSyncAckers implements the Durabler interface
+func (d *durabilityDeclarative) SemiSyncAckers(tablet *topodatapb.Tablet) int {
+	if rule := d.semiSyncRule(tablet); rule != nil {
+		return rule.Ackers
+	}
+	return 0
+}
+
+// IsReplicaSemiSync implements the Durabler interface
+func (d *durabilityDeclarative) IsReplicaSemiSync(primary, replica *topodatapb.Tablet) bool {
+	rule := d.semiSyncRule(primary)
+	if rule == nil || rule.Ackers == 0 {
+		return false
+	}
+	if rule.MinCells == 2 && primary.Alias.Cell == replica.Alias.Cell {
+		return false
+	}
+	if len(rule.EligibleAckers) == 0 {
+		switch replica.Type {
+		case topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA:
+			return true
+		}
+		return false
+	}
+	for _, acker := range rule.EligibleAckers {
+		if acker.matches(replica) {
+			return true
+		}
+	}
+	return false
+}
+
+// semiSyncRule returns the semi-sync rule of the primary, if any.
+func (d *durabilityDeclarative) semiSyncRule(primary *topodatapb.Tablet) *DeclarativeSemiSyncRule {
+	for i, rule := range d.dd.SemiSyncRules {
+		if rule.matches(primary) {
+			return &d.dd.SemiSyncRules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/vtctl/reparentutil/promotionrule"
+)
+
+func TestDurabilityDeclarative(t *testing.T) {
+	durability, err := GetDurabilityPolicy(`{
+		"promotion_rules": [
+			{"tablet_aliases": ["zone1-0000000101"], "rule": "must_not"},
+			{"cells": ["zone1"], "tablet_types": ["PRIMARY", "REPLICA"], "rule": "prefer"},
+			{"tablet_types": ["PRIMARY", "REPLICA"], "rule": "neutral"}
+		],
+		"semi_sync_rules": [
+			{"cells": ["zone3"], "ackers": 0},
+			{"ackers": 2, "min_cells": 2, "eligible_ackers": [{"tablet_types": ["REPLICA", "RDONLY"]}]}
+		]
+	}`)
+	require.NoError(t, err)
+
+	newTablet := func(cell string, uid uint32, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+		return &topodatapb.Tablet{
+			Alias: &topodatapb.TabletAlias{
+				Cell: cell,
+				Uid:  uid,
+			},
+			Type: tabletType,
+		}
+	}
+	primary := newTablet("zone1", 100, topodatapb.TabletType_PRIMARY)
+
+	assert.Equal(t, promotionrule.Prefer, PromotionRule(durability, primary))
+	assert.Equal(t, promotionrule.MustNot, PromotionRule(durability, newTablet("zone1", 101, topodatapb.TabletType_REPLICA)))
+	assert.Equal(t, promotionrule.Neutral, PromotionRule(durability, newTablet("zone2", 200, topodatapb.TabletType_REPLICA)))
+	assert.Equal(t, promotionrule.MustNot, PromotionRule(durability, newTablet("zone2", 201, topodatapb.TabletType_RDONLY)))
+	assert.Equal(t, promotionrule.MustNot, PromotionRule(durability, nil))
+
+	assert.Equal(t, 2, SemiSyncAckers(durability, primary))
+	assert.Equal(t, 2, SemiSyncAckers(durability, nil))
+	assert.Equal(t, 0, SemiSyncAckers(durability, newTablet("zone3", 300, topodatapb.TabletType_REPLICA)))
+
+	// Only the replicas of the other cells send acks.
+	assert.False(t, IsReplicaSemiSync(durability, primary, newTablet("zone1", 102, topodatapb.TabletType_REPLICA)))
+	assert.True(t, IsReplicaSemiSync(durability, primary, newTablet("zone2", 200, topodatapb.TabletType_REPLICA)))
+	assert.True(t, IsReplicaSemiSync(durability, primary, newTablet("zone2", 201, topodatapb.TabletType_RDONLY)))
+	assert.False(t, IsReplicaSemiSync(durability, primary, newTablet("zone2", 202, topodatapb.TabletType_SPARE)))
+	// There's no semi-sync for the primaries of zone3.
+	assert.False(t, IsReplicaSemiSync(durability, newTablet("zone3", 300, topodatapb.TabletType_PRIMARY), newTablet("zone2", 200, topodatapb.TabletType_REPLICA)))
+}
+
+func TestDurabilityDeclarativeDefaults(t *testing.T) {
+	durability, err := GetDurabilityPolicy(`{"semi_sync_rules": [{"ackers": 1}]}`)
+	require.NoError(t, err)
+
+	primary := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Type:  topodatapb.TabletType_PRIMARY,
+	}
+	replica := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+		Type:  topodatapb.TabletType_REPLICA,
+	}
+	rdonly := &topodatapb.Tablet{
+		Alias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 102},
+		Type:  topodatapb.TabletType_RDONLY,
+	}
+	// Like the semi_sync durability policy.
+	assert.Equal(t, promotionrule.Neutral, PromotionRule(durability, primary))
+	assert.Equal(t, promotionrule.Neutral, PromotionRule(durability, replica))
+	assert.Equal(t, promotionrule.MustNot, PromotionRule(durability, rdonly))
+	assert.Equal(t, 1, SemiSyncAckers(durability, primary))
+	assert.True(t, IsReplicaSemiSync(durability, primary, replica))
+	assert.False(t, IsReplicaSemiSync(durability, primary, rdonly))
+
+	durability, err = GetDurabilityPolicy(`{}`)
+	require.NoError(t, err)
+	assert.Equal(t, 0, SemiSyncAckers(durability, primary))
+	assert.False(t, IsReplicaSemiSync(durability, primary, replica))
+}
+
+func TestParseDeclarativeDurability(t *testing.T) {
+	testcases := []struct {
+		policy  string
+		wantErr string
+	}{{
+		policy:  `{"promotion_rules": [{"rule": "prefer"}], "semi_sync_rules": [{"ackers": 1, "min_cells": 1}]}`,
+		wantErr: "",
+	}, {
+		policy:  `{"promotion_rules": [{"rule": "must"}]}`,
+		wantErr: "invalid declarative durability policy: CandidatePromotionRule: must not supported yet",
+	}, {
+		policy:  `{"promotion_rules": [{"tablet_types": ["LEADER"], "rule": "prefer"}]}`,
+		wantErr: "invalid declarative durability policy: unknown TabletType LEADER",
+	}, {
+		policy:  `{"promotion_rules": [{"tablet_aliases": ["zone1"], "rule": "prefer"}]}`,
+		wantErr: "invalid declarative durability policy: invalid tablet alias: 'zone1', expecting format: '^(?P<cell>[-_.a-zA-Z0-9]+)-(?P<uid>[0-9]+)$'",
+	}, {
+		policy:  `{"semi_sync_rules": [{"ackers": 2, "min_cells": 3}]}`,
+		wantErr: "invalid declarative durability policy: min_cells 3 is not supported, it must be 1 or 2",
+	}, {
+		policy:  `{"semi_sync_rules": [{"ackers": -1}]}`,
+		wantErr: "invalid declarative durability policy: negative ackers -1",
+	}, {
+		policy:  `{"semi_sync": {"ackers": 1}}`,
+		wantErr: `invalid declarative durability policy: json: unknown field "semi_sync"`,
+	}, {
+		policy:  `{"semi_sync_rules": [{"ackers": 1}]} {"semi_sync_rules": []}`,
+		wantErr: "invalid declarative durability policy: unexpected data after the policy",
+	}, {
+		policy:  `{"semi_sync_rules": [{"ackers": 1}]} garbage`,
+		wantErr: "invalid declarative durability policy: unexpected data after the policy",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.policy, func(t *testing.T) {
+			_, err := ParseDeclarativeDurability(tc.policy)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				assert.True(t, CheckDurabilityPolicyExists(tc.policy))
+				return
+			}
+			require.EqualError(t, err, tc.wantErr)
+			assert.False(t, CheckDurabilityPolicyExists(tc.policy))
+		})
+	}
+}
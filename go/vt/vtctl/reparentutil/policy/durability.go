@@ -102,8 +102,16 @@ func RegisterDurability(name string, newDurablerFunc NewDurabler) {
 
 //=======================================================================
 
-// GetDurabilityPolicy is used to get a new durability policy from the registered policies
+// GetDurabilityPolicy is used to get a new durability policy from the registered policies,
+// or from the rules of a declarative durability policy.
 func GetDurabilityPolicy(name string) (Durabler, error) {
+	if IsDeclarativeDurability(name) {
+		dd, err := ParseDeclarativeDurability(name)
+		if err != nil {
+			return nil, err
+		}
+		return &durabilityDeclarative{dd: dd}, nil
+	}
 	newDurabilityCreationFunc, found := durabilityPolicies[name]
 	if !found {
 		return nil, fmt.Errorf("durability policy %v not found", name)
@@ -111,8 +119,13 @@ func GetDurabilityPolicy(name string) (Durabler, error) {
 	return newDurabilityCreationFunc(), nil
 }
 
-// CheckDurabilityPolicyExists is used to check if the durability policy is part of the registered policies
+// CheckDurabilityPolicyExists is used to check if the durability policy is part of the registered policies,
+// or is a valid declarative durability policy.
 func CheckDurabilityPolicyExists(name string) bool {
+	if IsDeclarativeDurability(name) {
+		_, err := ParseDeclarativeDurability(name)
+		return err == nil
+	}
 	_, found := durabilityPolicies[name]
 	return found
 }
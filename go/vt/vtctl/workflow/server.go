@@ -160,6 +160,11 @@ func (s *Server) SQLParser() *sqlparser.Parser {
 	return s.env.Parser()
 }
 
+// Environment returns the environment of the server.
+func (s *Server) Environment() *vtenv.Environment {
+	return s.env
+}
+
 // CheckReshardingJournalExistsOnTablet returns the journal (or an empty
 // journal) and a boolean to indicate if the resharding_journal table exists on
 // the given tablet.
@@ -137,28 +137,30 @@ func validateNewWorkflow(ctx context.Context, ts *topo.Server, tmc tmclient.Tabl
 // each shard in a new partial keyspace migration workflow that does
 // not already have an existing routing rule in place.
 func createDefaultShardRoutingRules(ctx context.Context, ms *vtctldatapb.MaterializeSettings, ts *topo.Server) error {
-	srr, err := topotools.GetShardRoutingRules(ctx, ts)
-	if err != nil {
-		return err
-	}
 	allShards, err := ts.GetServingShards(ctx, ms.SourceKeyspace)
 	if err != nil {
 		return err
 	}
 	changed := false
-	for _, si := range allShards {
-		fromSource := fmt.Sprintf("%s.%s", ms.SourceKeyspace, si.ShardName())
-		fromTarget := fmt.Sprintf("%s.%s", ms.TargetKeyspace, si.ShardName())
-		if srr[fromSource] == "" && srr[fromTarget] == "" {
-			srr[fromTarget] = ms.SourceKeyspace
-			changed = true
-			log.Infof("Added default shard routing rule from %q to %q", fromTarget, fromSource)
-		}
+	reason := fmt.Sprintf("Creating default shard routing rules for workflow %s.%s", ms.TargetKeyspace, ms.Workflow)
+	err = topotools.UpdateAllRoutingRules(ctx, ts, reason,
+		func(ctx context.Context, rules *topotools.RoutingRulesMaps) error {
+			srr := rules.ShardRules
+			for _, si := range allShards {
+				fromSource := fmt.Sprintf("%s.%s", ms.SourceKeyspace, si.ShardName())
+				fromTarget := fmt.Sprintf("%s.%s", ms.TargetKeyspace, si.ShardName())
+				if srr[fromSource] == "" && srr[fromTarget] == "" {
+					srr[fromTarget] = ms.SourceKeyspace
+					changed = true
+					log.Infof("Added default shard routing rule from %q to %q", fromTarget, fromSource)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return err
 	}
 	if changed {
-		if err := topotools.SaveShardRoutingRules(ctx, ts, srr); err != nil {
-			return err
-		}
 		if err := ts.RebuildSrvVSchema(ctx, nil); err != nil {
 			return err
 		}
@@ -877,10 +879,10 @@ func changeKeyspaceRouting(ctx context.Context, ts *topo.Server, tabletTypes []t
 // keyspace to the target keyspace.
 func updateKeyspaceRoutingRules(ctx context.Context, ts *topo.Server, reason string, routes map[string]string) error {
 	update := func() error {
-		return topotools.UpdateKeyspaceRoutingRules(ctx, ts, reason,
-			func(ctx context.Context, rules *map[string]string) error {
+		return topotools.UpdateAllRoutingRules(ctx, ts, reason,
+			func(ctx context.Context, rules *topotools.RoutingRulesMaps) error {
 				for fromKeyspace, toKeyspace := range routes {
-					(*rules)[fromKeyspace] = toKeyspace
+					rules.KeyspaceRules[fromKeyspace] = toKeyspace
 				}
 				return nil
 			})
@@ -0,0 +1,641 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/cache"
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/binlog"
+	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// VTGate serves the MySQL binlog replication protocol from a VStream of the
+// keyspace of the session, so CDC tools can replicate from it as if it were
+// a single MySQL primary, across resharding. The row events are synthesized
+// from the events of the VStream.
+//
+// The binlog positions are synthesized too: each transaction is sent in its
+// own binlog file, which name encodes the VGTID before the transaction, with
+// the shard of the transaction first. A client resuming after the start of
+// the transaction gets it from its shard alone, skipping the events before
+// its position, before the whole keyspace is streamed again.
+//
+// The names of the binlog files are bounded, as the clients keep them in
+// columns like VARCHAR(255). If the VGTID doesn't fit in the name, e.g. for
+// a keyspace with many shards, the name has a token instead, which maps to
+// the VGTID on the VTGate that streamed the file while it remembers it.
+
+const (
+	// binlogFilePrefix starts the names of the binlog files, followed by a
+	// sequence number, so the names sort in the order of the stream, and
+	// by the VGTID.
+	binlogFilePrefix = "vtgate-bin."
+
+	// maxBinlogFileNameLen is the maximum length of the names of the
+	// binlog files.
+	maxBinlogFileNameLen = 255
+
+	// binlogFileTokens is the number of tokens of binlog file names that
+	// are remembered.
+	binlogFileTokens = 10000
+
+	// binlogServerID is the server ID in the headers of the events.
+	binlogServerID = 1
+
+	// rowsEventStmtEndFlag marks the last rows event of a statement.
+	rowsEventStmtEndFlag = 0x0001
+)
+
+// errBinlogFileNotFound is returned, like MySQL does, for a binlog file
+// that is not one of VTGate.
+var errBinlogFileNotFound = sqlerror.NewSQLError(sqlerror.ERMasterFatalReadingBinlog, sqlerror.SSUnknownSQLState,
+	"Could not find first log file name in binary log index file")
+
+// binlogFileVGTIDs maps the tokens of the binlog file names to their VGTID.
+var binlogFileVGTIDs = cache.NewLRUCache[*binlogdatapb.VGtid](binlogFileTokens)
+
+// binlogFileName returns the name of the binlog file with the given
+// sequence number, that starts at vgtid. The VGTID follows the sequence
+// number after a dot, or its token after a dash if it is too long.
+func binlogFileName(seq uint64, vgtid *binlogdatapb.VGtid) (string, error) {
+	data, err := vgtid.MarshalVT()
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s%010d.%s", binlogFilePrefix, seq, base64.RawURLEncoding.EncodeToString(data))
+	if len(name) <= maxBinlogFileNameLen {
+		return name, nil
+	}
+	sum := sha256.Sum256(data)
+	token := hex.EncodeToString(sum[:16])
+	binlogFileVGTIDs.Set(token, vgtid.CloneVT())
+	return fmt.Sprintf("%s%010d-%s", binlogFilePrefix, seq, token), nil
+}
+
+// parseBinlogFileName is the reverse of binlogFileName.
+func parseBinlogFileName(name string) (uint64, *binlogdatapb.VGtid, error) {
+	rest, ok := strings.CutPrefix(name, binlogFilePrefix)
+	i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+	if !ok || i <= 0 {
+		return 0, nil, errBinlogFileNotFound
+	}
+	n, err := strconv.ParseUint(rest[:i], 10, 64)
+	if err != nil {
+		return 0, nil, errBinlogFileNotFound
+	}
+	switch rest[i] {
+	case '.':
+		data, err := base64.RawURLEncoding.DecodeString(rest[i+1:])
+		if err != nil {
+			return 0, nil, errBinlogFileNotFound
+		}
+		vgtid := &binlogdatapb.VGtid{}
+		if err := vgtid.UnmarshalVT(data); err != nil || len(vgtid.ShardGtids) == 0 {
+			return 0, nil, errBinlogFileNotFound
+		}
+		return n, vgtid, nil
+	case '-':
+		vgtid, ok := binlogFileVGTIDs.Get(rest[i+1:])
+		if !ok {
+			return 0, nil, errBinlogFileNotFound
+		}
+		return n, vgtid.CloneVT(), nil
+	}
+	return 0, nil, errBinlogFileNotFound
+}
+
+// binlogDump translates a VStream into the events of a binlog dump.
+type binlogDump struct {
+	keyspace string
+	format   mysql.BinlogFormat
+	stream   mysql.FakeBinlogStream
+
+	// heartbeatInterval is the interval of the heartbeats the client asked
+	// for, in seconds, or zero.
+	heartbeatInterval uint32
+
+	// vstream streams the events from a VGTID, and send sends an event to
+	// the client.
+	vstream func(ctx context.Context, vgtid *binlogdatapb.VGtid, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error
+	send    func(ev mysql.BinlogEvent) error
+
+	// seq and file are the current binlog file, and pos is the position
+	// of the next event in it. The events before skip were already sent
+	// to the client.
+	seq       uint64
+	file      string
+	fileVGTID *binlogdatapb.VGtid
+	pos       uint32
+	skip      uint32
+
+	// empty is set while the current file has no transaction.
+	empty bool
+
+	// vgtid is the position after the last transaction, and next the
+	// position after the current one, once known.
+	vgtid *binlogdatapb.VGtid
+	next  *binlogdatapb.VGtid
+
+	// resuming is set while the transaction the client resumes in is
+	// streamed, and done once it was.
+	resuming bool
+	done     bool
+
+	tables      map[string]*binlogTable
+	nextTableID uint64
+}
+
+// binlogTable is a table of the VStream, with its TableMap.
+type binlogTable struct {
+	id       uint64
+	fields   []*querypb.Field
+	tableMap *mysql.TableMap
+	internal bool
+
+	// enumSetValues maps the values of the ENUM and SET columns to their
+	// index, if the VStream sends their values as strings.
+	enumSetValues []map[string]uint64
+}
+
+func newBinlogDump(keyspace string, userVariables map[string]*querypb.BindVariable) *binlogDump {
+	d := &binlogDump{
+		keyspace: keyspace,
+		format:   mysql.NewMySQL56BinlogFormat(),
+		stream: mysql.FakeBinlogStream{
+			ServerID: binlogServerID,
+		},
+		tables:      make(map[string]*binlogTable),
+		nextTableID: 1,
+	}
+	d.format.ServerVersion = servenv.MySQLServerVersion()
+
+	// Like MySQL, the events only have a checksum if the client says it
+	// can check it, and heartbeats are only sent if it asks for them.
+	d.format.ChecksumAlgorithm = mysql.BinlogChecksumAlgOff
+	for name, bv := range userVariables {
+		switch strings.ToLower(name) {
+		case "master_binlog_checksum", "source_binlog_checksum":
+			if !strings.EqualFold(string(bv.Value), "NONE") {
+				d.format.ChecksumAlgorithm = mysql.BinlogChecksumAlgCRC32
+			}
+		case "master_heartbeat_period", "source_heartbeat_period":
+			// The period is in nanoseconds.
+			if period, err := strconv.ParseUint(string(bv.Value), 10, 64); err == nil && period > 0 {
+				d.heartbeatInterval = uint32((time.Duration(period) + time.Second - 1) / time.Second)
+			}
+		}
+	}
+	return d
+}
+
+// run streams the binlog from the given file and position. An empty file
+// starts from the current position of the keyspace.
+func (d *binlogDump) run(ctx context.Context, file string, pos uint32) error {
+	var err error
+	if file == "" {
+		d.vgtid = &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{
+			Keyspace: d.keyspace,
+			Gtid:     "current",
+		}}}
+		if file, err = binlogFileName(0, d.vgtid); err != nil {
+			return err
+		}
+		pos = 4
+	} else if d.seq, d.vgtid, err = parseBinlogFileName(file); err != nil {
+		return err
+	}
+	if pos < 4 {
+		pos = 4
+	}
+	d.file, d.fileVGTID, d.skip = file, d.vgtid, pos
+
+	if err := d.send(mysql.NewFakeRotateEventWithPosition(d.format, &d.stream, uint64(pos), file)); err != nil {
+		return err
+	}
+	if err := d.writeFormatDescription(); err != nil {
+		return err
+	}
+
+	flags := &vtgatepb.VStreamFlags{
+		HeartbeatInterval:            d.heartbeatInterval,
+		ExcludeKeyspaceFromTableName: true,
+	}
+	if first := d.vgtid.ShardGtids[0]; d.skip > d.pos && first.Shard == "" {
+		// The file started at the current position of the keyspace, so
+		// its transaction is not known, and the stream continues in a
+		// new file.
+		d.empty = false
+	} else if d.skip > d.pos {
+		// The client has seen a part of the transaction of the file, so
+		// the rest of it is streamed from its shard alone.
+		ctx, cancel := context.WithCancel(ctx)
+		d.resuming = true
+		err := d.vstream(ctx, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{first}}, flags, d.handleEvents)
+		cancel()
+		if !d.done {
+			return err
+		}
+		d.resuming = false
+	}
+	return d.vstream(ctx, d.vgtid, flags, d.handleEvents)
+}
+
+// handleEvents translates a batch of events of the VStream.
+func (d *binlogDump) handleEvents(events []*binlogdatapb.VEvent) error {
+	for _, ev := range events {
+		if d.done {
+			// Stop the VStream of the resumed transaction.
+			return context.Canceled
+		}
+		if ev.Timestamp != 0 {
+			d.stream.Timestamp = uint32(ev.Timestamp)
+		}
+		if err := d.handleEvent(ev); err != nil {
+			return err
+		}
+	}
+	if d.done {
+		return context.Canceled
+	}
+	return nil
+}
+
+func (d *binlogDump) handleEvent(ev *binlogdatapb.VEvent) error {
+	switch ev.Type {
+	case binlogdatapb.VEventType_VGTID:
+		d.next = ev.Vgtid
+	case binlogdatapb.VEventType_BEGIN:
+		if err := d.rotate(ev.Keyspace, ev.Shard); err != nil {
+			return err
+		}
+		return d.write(mysql.NewQueryEvent(d.format, &d.stream, mysql.Query{Database: ev.Keyspace, SQL: "BEGIN"}))
+	case binlogdatapb.VEventType_FIELD:
+		return d.addTable(ev.FieldEvent)
+	case binlogdatapb.VEventType_ROW:
+		return d.writeRows(ev.RowEvent)
+	case binlogdatapb.VEventType_COMMIT:
+		if err := d.write(mysql.NewXIDEvent(d.format, &d.stream)); err != nil {
+			return err
+		}
+		d.endTransaction()
+	case binlogdatapb.VEventType_DDL:
+		if err := d.rotate(ev.Keyspace, ev.Shard); err != nil {
+			return err
+		}
+		if err := d.write(mysql.NewQueryEvent(d.format, &d.stream, mysql.Query{Database: ev.Keyspace, SQL: ev.Statement})); err != nil {
+			return err
+		}
+		d.endTransaction()
+	case binlogdatapb.VEventType_OTHER:
+		// The statement is not replicated, but the position moves on.
+		d.endTransaction()
+	case binlogdatapb.VEventType_HEARTBEAT:
+		if d.heartbeatInterval == 0 {
+			return nil
+		}
+		// Heartbeats are not part of the binlog file, so they have the
+		// position of the next event.
+		ev := mysql.NewHeartbeatEventWithLogFile(d.format, &d.stream, d.file)
+		d.setNextPosition(ev.Bytes(), d.pos)
+		return d.send(ev)
+	}
+	return nil
+}
+
+// endTransaction moves the position after the current transaction.
+func (d *binlogDump) endTransaction() {
+	next := d.next
+	d.next = nil
+	if !d.resuming {
+		if next != nil {
+			d.vgtid = next
+		}
+		return
+	}
+	// The VStream of the resumed transaction only has its shard, which
+	// goes back in the VGTID of the keyspace.
+	d.vgtid = d.vgtid.CloneVT()
+	for _, sgtid := range next.GetShardGtids() {
+		for i, cur := range d.vgtid.ShardGtids {
+			if cur.Keyspace == sgtid.Keyspace && cur.Shard == sgtid.Shard {
+				d.vgtid.ShardGtids[i] = sgtid
+			}
+		}
+	}
+	d.done = true
+}
+
+// rotate starts the binlog file of a new transaction of the given shard.
+func (d *binlogDump) rotate(keyspace, shard string) error {
+	if d.resuming {
+		// The resumed transaction is in the file of the client.
+		return nil
+	}
+	vgtid := d.vgtid.CloneVT()
+	if i := slices.IndexFunc(vgtid.ShardGtids, func(sgtid *binlogdatapb.ShardGtid) bool {
+		return sgtid.Keyspace == keyspace && sgtid.Shard == shard
+	}); i > 0 {
+		sgtid := vgtid.ShardGtids[i]
+		copy(vgtid.ShardGtids[1:i+1], vgtid.ShardGtids[:i])
+		vgtid.ShardGtids[0] = sgtid
+	}
+	if first := vgtid.ShardGtids[0]; d.empty && first.Keyspace == keyspace && first.Shard == shard && proto.Equal(vgtid, d.fileVGTID) {
+		// The transaction is the one of the current file.
+		return nil
+	}
+	file, err := binlogFileName(d.seq+1, vgtid)
+	if err != nil {
+		return err
+	}
+	if err := d.write(mysql.NewRotateEvent(d.format, &d.stream, 4, file)); err != nil {
+		return err
+	}
+	d.seq, d.file, d.fileVGTID, d.skip = d.seq+1, file, vgtid, 0
+	return d.writeFormatDescription()
+}
+
+// writeFormatDescription sends the FormatDescription event that starts the
+// current file.
+func (d *binlogDump) writeFormatDescription() error {
+	ev := mysql.NewFormatDescriptionEvent(d.format, &d.stream)
+	d.pos = 4 + uint32(len(ev.Bytes()))
+	next := d.pos
+	if d.skip > 4 {
+		// Like MySQL, the position of the client is kept when it resumes
+		// after the start of the file.
+		next = 0
+	}
+	d.setNextPosition(ev.Bytes(), next)
+	d.empty = true
+	return d.send(ev)
+}
+
+// write sends an event of the current file, unless the client already has
+// it.
+func (d *binlogDump) write(ev mysql.BinlogEvent) error {
+	start := d.pos
+	d.pos += uint32(len(ev.Bytes()))
+	d.empty = false
+	if start < d.skip {
+		return nil
+	}
+	d.setNextPosition(ev.Bytes(), d.pos)
+	return d.send(ev)
+}
+
+// setNextPosition sets the position of the next event in the header of an
+// event, which the event builders leave to the stream.
+func (d *binlogDump) setNextPosition(data []byte, pos uint32) {
+	binary.LittleEndian.PutUint32(data[13:17], pos)
+	if d.format.ChecksumAlgorithm == mysql.BinlogChecksumAlgCRC32 {
+		binary.LittleEndian.PutUint32(data[len(data)-4:], crc32.ChecksumIEEE(data[:len(data)-4]))
+	}
+}
+
+// addTable remembers the fields of a table. A table keeps its table id
+// while its fields do not change.
+func (d *binlogDump) addTable(fe *binlogdatapb.FieldEvent) error {
+	name := fe.Keyspace + "." + fe.TableName
+	if table := d.tables[name]; table != nil && slices.EqualFunc(table.fields, fe.Fields, func(a, b *querypb.Field) bool { return proto.Equal(a, b) }) {
+		return nil
+	}
+
+	table := &binlogTable{
+		id:     d.nextTableID,
+		fields: fe.Fields,
+		tableMap: &mysql.TableMap{
+			Database:  fe.Keyspace,
+			Name:      fe.TableName,
+			Types:     make([]byte, len(fe.Fields)),
+			CanBeNull: mysql.NewServerBitmap(len(fe.Fields)),
+			Metadata:  make([]uint16, len(fe.Fields)),
+		},
+		internal:      fe.IsInternalTable,
+		enumSetValues: make([]map[string]uint64, len(fe.Fields)),
+	}
+	d.nextTableID++
+	for i, field := range fe.Fields {
+		typ, metadata, err := binlog.FieldType(field)
+		if err != nil {
+			return err
+		}
+		table.tableMap.Types[i] = typ
+		table.tableMap.Metadata[i] = metadata
+		table.tableMap.CanBeNull.Set(i, field.Flags&uint32(querypb.MySqlFlag_NOT_NULL_FLAG) == 0)
+
+		if fe.EnumSetStringValues && (field.Type == querypb.Type_ENUM || field.Type == querypb.Type_SET) {
+			values := make(map[string]uint64)
+			for j, value := range binlog.EnumOrSetValues(field.ColumnType) {
+				if field.Type == querypb.Type_ENUM {
+					values[value] = uint64(j + 1)
+				} else {
+					values[value] = 1 << j
+				}
+			}
+			table.enumSetValues[i] = values
+		}
+	}
+	d.tables[name] = table
+	return nil
+}
+
+// writeRows sends the TableMap event of a table, followed by the rows
+// events of its row changes.
+func (d *binlogDump) writeRows(re *binlogdatapb.RowEvent) error {
+	table := d.tables[re.Keyspace+"."+re.TableName]
+	if table == nil {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "no fields for table %s.%s", re.Keyspace, re.TableName)
+	}
+	if table.internal {
+		return nil
+	}
+	if err := d.write(mysql.NewTableMapEvent(d.format, &d.stream, table.id, table.tableMap)); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(re.RowChanges); {
+		// Consecutive changes of the same kind with all their columns go
+		// in the same rows event.
+		j := i + 1
+		if re.RowChanges[i].DataColumns == nil {
+			for j < len(re.RowChanges) && re.RowChanges[j].DataColumns == nil && rowChangeKind(re.RowChanges[j]) == rowChangeKind(re.RowChanges[i]) {
+				j++
+			}
+		}
+		ev, err := d.rowsEvent(table, re.RowChanges[i:j], j == len(re.RowChanges))
+		if err != nil {
+			return err
+		}
+		if err := d.write(ev); err != nil {
+			return err
+		}
+		i = j
+	}
+	return nil
+}
+
+// rowChangeKind returns whether a row change is an insert, an update or a
+// delete.
+func rowChangeKind(rc *binlogdatapb.RowChange) int {
+	switch {
+	case rc.Before == nil:
+		return 0
+	case rc.After == nil:
+		return 1
+	}
+	return 2
+}
+
+// rowsEvent returns the rows event of row changes of the same kind.
+func (d *binlogDump) rowsEvent(table *binlogTable, changes []*binlogdatapb.RowChange, last bool) (mysql.BinlogEvent, error) {
+	allColumns := mysql.NewServerBitmap(len(table.fields))
+	for i := range table.fields {
+		allColumns.Set(i, true)
+	}
+	rows := mysql.Rows{}
+	if last {
+		rows.Flags = rowsEventStmtEndFlag
+	}
+	if changes[0].Before != nil {
+		rows.IdentifyColumns = allColumns
+	}
+	if changes[0].After != nil {
+		rows.DataColumns = allColumns
+		if dc := changes[0].DataColumns; dc != nil {
+			// Only some columns are in the after image.
+			rows.DataColumns = mysql.NewServerBitmap(len(table.fields))
+			for i := range table.fields {
+				rows.DataColumns.Set(i, i/8 < len(dc.Cols) && dc.Cols[i/8]&(1<<(i%8)) != 0)
+			}
+		}
+	}
+
+	for _, rc := range changes {
+		var row mysql.Row
+		var err error
+		if rc.Before != nil {
+			if row.NullIdentifyColumns, row.Identify, err = d.rowImage(table, rc.Before, rows.IdentifyColumns); err != nil {
+				return nil, err
+			}
+		}
+		if rc.After != nil {
+			if row.NullColumns, row.Data, err = d.rowImage(table, rc.After, rows.DataColumns); err != nil {
+				return nil, err
+			}
+		}
+		rows.Rows = append(rows.Rows, row)
+	}
+
+	switch {
+	case changes[0].Before == nil:
+		return mysql.NewWriteRowsEvent(d.format, &d.stream, table.id, rows), nil
+	case changes[0].After == nil:
+		return mysql.NewDeleteRowsEvent(d.format, &d.stream, table.id, rows), nil
+	}
+	return mysql.NewUpdateRowsEvent(d.format, &d.stream, table.id, rows), nil
+}
+
+// rowImage returns the NULL bitmap and the data of the given columns of a
+// row.
+func (d *binlogDump) rowImage(table *binlogTable, row *querypb.Row, columns mysql.Bitmap) (mysql.Bitmap, []byte, error) {
+	values := sqltypes.MakeRowTrusted(table.fields, row)
+	nulls := mysql.NewServerBitmap(columns.BitCount())
+	var data []byte
+	n := 0
+	for i, value := range values {
+		if !columns.Bit(i) {
+			continue
+		}
+		if value.IsNull() {
+			nulls.Set(n, true)
+			n++
+			continue
+		}
+		n++
+		if enumSetValues := table.enumSetValues[i]; enumSetValues != nil {
+			var index uint64
+			if table.fields[i].Type == querypb.Type_SET {
+				for _, v := range strings.Split(value.ToString(), ",") {
+					index |= enumSetValues[v]
+				}
+			} else {
+				index = enumSetValues[value.ToString()]
+			}
+			value = sqltypes.NewUint64(index)
+		}
+		var err error
+		if data, err = binlog.AppendCellValue(data, value, table.tableMap.Types[i], table.tableMap.Metadata[i]); err != nil {
+			return mysql.Bitmap{}, nil, err
+		}
+	}
+	return nulls, data, nil
+}
+
+// binlogDump serves a COM_BINLOG_DUMP of the keyspace of the session.
+func (vh *vtgateHandler) binlogDump(c *mysql.Conn, file string, pos uint32) error {
+	session := vh.session(c)
+	keyspace, tabletType, _, err := vh.vtg.executor.ParseDestinationTarget(session.TargetString)
+	if err != nil {
+		return err
+	}
+	if keyspace == "" {
+		return sqlerror.NewSQLError(sqlerror.ERNoDb, sqlerror.SSNoDB, "No database selected: the keyspace to replicate must be selected")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.UpdateCancelCtx(cancel)
+	ctx = callinfo.MysqlCallInfo(ctx, c)
+	ef := callerid.NewEffectiveCallerID(
+		c.User,                  /* principal: who */
+		c.RemoteAddr().String(), /* component: running client process */
+		"VTGate MySQL Connector" /* subcomponent: part of the client */)
+	ctx = callerid.NewContext(ctx, ef, c.UserData.Get())
+
+	d := newBinlogDump(keyspace, session.UserDefinedVariables)
+	d.vstream = func(ctx context.Context, vgtid *binlogdatapb.VGtid, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
+		return vh.vtg.VStream(ctx, tabletType, vgtid, nil, flags, send)
+	}
+	d.send = func(ev mysql.BinlogEvent) error {
+		return c.WriteBinlogEvent(ev, false)
+	}
+	return d.run(ctx, file, pos)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestBinlogFileName(t *testing.T) {
+	vgtid := &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "ks", Shard: "-80", Gtid: "MySQL56/00010203-0405-0607-0809-0a0b0c0d0e0f:1-10"},
+		{Keyspace: "ks", Shard: "80-", Gtid: "current"},
+	}}
+	name, err := binlogFileName(12, vgtid)
+	require.NoError(t, err)
+	assert.Regexp(t, `^vtgate-bin\.0000000012\.[A-Za-z0-9_-]+$`, name)
+
+	seq, got, err := parseBinlogFileName(name)
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, seq)
+	utils.MustMatch(t, vgtid, got)
+
+	for _, name := range []string{"", "mysql-bin.000001", "vtgate-bin.x.AA", "vtgate-bin.0000000001.!", "vtgate-bin.0000000001", "vtgate-bin.0000000001-00112233445566778899aabbccddeeff"} {
+		_, _, err := parseBinlogFileName(name)
+		assert.ErrorIs(t, err, errBinlogFileNotFound, name)
+	}
+
+	// The VGTID of many shards doesn't fit in the name, which has a token
+	// of it instead.
+	vgtid = &binlogdatapb.VGtid{}
+	for i := range 16 {
+		vgtid.ShardGtids = append(vgtid.ShardGtids, &binlogdatapb.ShardGtid{
+			Keyspace: "ks",
+			Shard:    fmt.Sprintf("%02x-%02x", i*16, (i+1)*16),
+			Gtid:     "MySQL56/00010203-0405-0607-0809-0a0b0c0d0e0f:1-10",
+		})
+	}
+	name, err = binlogFileName(13, vgtid)
+	require.NoError(t, err)
+	assert.Regexp(t, `^vtgate-bin\.0000000013-[0-9a-f]{32}$`, name)
+	assert.LessOrEqual(t, len(name), maxBinlogFileNameLen)
+
+	seq, got, err = parseBinlogFileName(name)
+	require.NoError(t, err)
+	assert.EqualValues(t, 13, seq)
+	utils.MustMatch(t, vgtid, got)
+}
+
+// binlogDumpEvents are the events of a VStream of the keyspace ks: a
+// transaction on -80, then a DDL on 80-.
+func binlogDumpEvents() []*binlogdatapb.VEvent {
+	fields := []*querypb.Field{
+		{Name: "id", Type: querypb.Type_INT64, Flags: uint32(querypb.MySqlFlag_NOT_NULL_FLAG)},
+		{Name: "name", Type: querypb.Type_VARCHAR, ColumnLength: 128},
+		{Name: "color", Type: querypb.Type_ENUM, ColumnType: "enum('red','blue')"},
+	}
+	row := func(values ...sqltypes.Value) *querypb.Row {
+		return sqltypes.RowToProto3(values)
+	}
+	return []*binlogdatapb.VEvent{
+		{Type: binlogdatapb.VEventType_BEGIN, Keyspace: "ks", Shard: "-80", Timestamp: 1000},
+		{Type: binlogdatapb.VEventType_FIELD, Keyspace: "ks", Shard: "-80", FieldEvent: &binlogdatapb.FieldEvent{
+			TableName: "t", Keyspace: "ks", Shard: "-80", Fields: fields, EnumSetStringValues: true,
+		}},
+		{Type: binlogdatapb.VEventType_ROW, Keyspace: "ks", Shard: "-80", RowEvent: &binlogdatapb.RowEvent{
+			TableName: "t", Keyspace: "ks", Shard: "-80",
+			RowChanges: []*binlogdatapb.RowChange{
+				{After: row(sqltypes.NewInt64(1), sqltypes.NewVarChar("a"), sqltypes.MakeTrusted(querypb.Type_ENUM, []byte("blue")))},
+				{After: row(sqltypes.NewInt64(2), sqltypes.NULL, sqltypes.MakeTrusted(querypb.Type_ENUM, []byte("red")))},
+			},
+		}},
+		{Type: binlogdatapb.VEventType_VGTID, Keyspace: "ks", Shard: "-80", Vgtid: &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+			{Keyspace: "ks", Shard: "-80", Gtid: "pos1"},
+			{Keyspace: "ks", Shard: "80-", Gtid: "pos0"},
+		}}},
+		{Type: binlogdatapb.VEventType_COMMIT, Keyspace: "ks", Shard: "-80"},
+		{Type: binlogdatapb.VEventType_VGTID, Keyspace: "ks", Shard: "80-", Vgtid: &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+			{Keyspace: "ks", Shard: "-80", Gtid: "pos1"},
+			{Keyspace: "ks", Shard: "80-", Gtid: "pos2"},
+		}}},
+		{Type: binlogdatapb.VEventType_DDL, Keyspace: "ks", Shard: "80-", Statement: "alter table t add column c int"},
+	}
+}
+
+// binlogDumpEvent is a decoded event of a binlog dump.
+type binlogDumpEvent struct {
+	kind string
+	file string
+	// start and next are the position of the event in its file, and the
+	// position of the next event, as sent to the client.
+	start, next uint64
+	text        string
+}
+
+// runBinlogDump runs a binlog dump from the given file and position, and
+// decodes the events it sends.
+func runBinlogDump(t *testing.T, file string, pos uint32, vstream func(vgtid *binlogdatapb.VGtid, send func([]*binlogdatapb.VEvent) error) error) []binlogDumpEvent {
+	d := newBinlogDump("ks", map[string]*querypb.BindVariable{
+		"master_binlog_checksum": sqltypes.StringBindVariable("CRC32"),
+	})
+	d.vstream = func(ctx context.Context, vgtid *binlogdatapb.VGtid, flags *vtgatepb.VStreamFlags, send func([]*binlogdatapb.VEvent) error) error {
+		assert.True(t, flags.ExcludeKeyspaceFromTableName)
+		return vstream(vgtid, send)
+	}
+
+	var events []binlogDumpEvent
+	format := mysql.NewMySQL56BinlogFormat()
+	var tableMap *mysql.TableMap
+	start := uint64(pos)
+	d.send = func(ev mysql.BinlogEvent) error {
+		// The events are copied, like they would be sent.
+		ev = mysql.NewMysql56BinlogEvent(append([]byte(nil), ev.Bytes()...))
+		require.True(t, ev.IsValid())
+		e := binlogDumpEvent{file: d.file, start: start, next: ev.NextPosition()}
+		if e.next != 0 {
+			start = e.next
+		}
+		if ev.IsFormatDescription() {
+			var err error
+			format, err = ev.Format()
+			require.NoError(t, err)
+			events = append(events, binlogDumpEvent{kind: "fde", file: e.file, start: e.start, next: e.next})
+			return nil
+		}
+		if ev.IsRotate() {
+			// NextLogFile expects the checksum.
+			name, _, err := ev.NextLogFile(format)
+			require.NoError(t, err)
+			if e.next != 0 {
+				start = 4
+			}
+			events = append(events, binlogDumpEvent{kind: "rotate", file: e.file, start: e.start, next: e.next, text: name})
+			return nil
+		}
+		ev, _, err := ev.StripChecksum(format)
+		require.NoError(t, err)
+		switch {
+		case ev.IsQuery():
+			e.kind = "query"
+			q, err := ev.Query(format)
+			require.NoError(t, err)
+			e.text = q.Database + ":" + q.SQL
+		case ev.IsTableMap():
+			e.kind = "tablemap"
+			tableMap, err = ev.TableMap(format)
+			require.NoError(t, err)
+			e.text = tableMap.Database + "." + tableMap.Name
+		case ev.IsWriteRows():
+			e.kind = "write"
+			rows, err := ev.Rows(format, tableMap)
+			require.NoError(t, err)
+			for i := range rows.Rows {
+				values, err := rows.StringValuesForTests(tableMap, i)
+				require.NoError(t, err)
+				e.text += "|" + values[0] + "," + values[1] + "," + values[2]
+			}
+		case ev.IsXID():
+			e.kind = "xid"
+		default:
+			e.kind = "other"
+		}
+		events = append(events, e)
+		return nil
+	}
+	require.NoError(t, d.run(context.Background(), file, pos))
+	return events
+}
+
+func TestBinlogDump(t *testing.T) {
+	var vgtids []*binlogdatapb.VGtid
+	events := runBinlogDump(t, "", 4, func(vgtid *binlogdatapb.VGtid, send func([]*binlogdatapb.VEvent) error) error {
+		vgtids = append(vgtids, vgtid)
+		return send(binlogDumpEvents())
+	})
+	utils.MustMatch(t, []*binlogdatapb.VGtid{{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Gtid: "current"}}}}, vgtids)
+
+	var kinds []string
+	for _, e := range events {
+		kinds = append(kinds, e.kind)
+	}
+	assert.Equal(t, []string{
+		"rotate", "fde", "rotate",
+		"fde", "query", "tablemap", "write", "xid", "rotate",
+		"fde", "query",
+	}, kinds)
+
+	// The events are contiguous in their file.
+	for i, e := range events[1:] {
+		if e.kind != "fde" && events[i].kind != "rotate" {
+			assert.Equal(t, events[i].next, e.start, "event %d", i+1)
+		}
+	}
+	assert.Equal(t, "ks:BEGIN", events[4].text)
+	assert.Equal(t, "ks.t", events[5].text)
+	assert.Equal(t, "|1,a,2|2,NULL,1", events[6].text)
+	assert.Equal(t, "ks:alter table t add column c int", events[10].text)
+
+	// Each transaction has its own file, which starts at the position
+	// before it, with its shard first.
+	_, vgtid, err := parseBinlogFileName(events[2].text)
+	require.NoError(t, err)
+	utils.MustMatch(t, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Gtid: "current"}}}, vgtid)
+	seq, vgtid, err := parseBinlogFileName(events[8].text)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, seq)
+	utils.MustMatch(t, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "ks", Shard: "80-", Gtid: "pos0"},
+		{Keyspace: "ks", Shard: "-80", Gtid: "pos1"},
+	}}, vgtid)
+}
+
+func TestBinlogDumpResume(t *testing.T) {
+	file, err := binlogFileName(1, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{
+		{Keyspace: "ks", Shard: "-80", Gtid: "pos0"},
+		{Keyspace: "ks", Shard: "80-", Gtid: "pos0"},
+	}})
+	require.NoError(t, err)
+
+	// The transaction on -80 is the one of the file, so it stays in it.
+	events := runBinlogDump(t, file, 4, func(vgtid *binlogdatapb.VGtid, send func([]*binlogdatapb.VEvent) error) error {
+		return send(binlogDumpEvents())
+	})
+	var kinds []string
+	for _, e := range events {
+		kinds = append(kinds, e.kind)
+	}
+	assert.Equal(t, []string{"rotate", "fde", "query", "tablemap", "write", "xid", "rotate", "fde", "query"}, kinds)
+	assert.Equal(t, file, events[4].file)
+	tableMap := events[3]
+
+	// The client resumes at the TableMap event: the rest of the transaction
+	// is streamed from its shard, then the keyspace from after it.
+	var vgtids []*binlogdatapb.VGtid
+	resumed := runBinlogDump(t, file, uint32(tableMap.start), func(vgtid *binlogdatapb.VGtid, send func([]*binlogdatapb.VEvent) error) error {
+		vgtids = append(vgtids, vgtid)
+		if len(vgtids) == 2 {
+			return nil
+		}
+		events := binlogDumpEvents()
+		events[3].Vgtid = &binlogdatapb.VGtid{ShardGtids: events[3].Vgtid.ShardGtids[:1]}
+		return send(events)
+	})
+	utils.MustMatch(t, []*binlogdatapb.VGtid{
+		{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "-80", Gtid: "pos0"}}},
+		{ShardGtids: []*binlogdatapb.ShardGtid{
+			{Keyspace: "ks", Shard: "-80", Gtid: "pos1"},
+			{Keyspace: "ks", Shard: "80-", Gtid: "pos0"},
+		}},
+	}, vgtids)
+
+	require.Len(t, resumed, 5)
+	assert.Equal(t, "rotate", resumed[0].kind)
+	assert.Equal(t, file, resumed[0].text)
+	assert.Equal(t, "fde", resumed[1].kind)
+	assert.EqualValues(t, 0, resumed[1].next)
+	assert.Equal(t, events[3:6], resumed[2:])
+}
+
+func TestComBinlogDumpGTIDSet(t *testing.T) {
+	vh := newVtgateHandler(&VTGate{})
+	gtidSet, err := replication.ParseMysql56GTIDSet("00010203-0405-0607-0809-0a0b0c0d0e0f:1-10")
+	require.NoError(t, err)
+
+	// A GTID set is rejected, even with a binlog file to start from.
+	file, err := binlogFileName(1, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "ks", Shard: "-80", Gtid: "current"}}})
+	require.NoError(t, err)
+	for _, file := range []string{"", file} {
+		err := vh.ComBinlogDumpGTID(nil, file, 4, gtidSet)
+		assert.ErrorContains(t, err, "GTID positions are not supported by VTGate", file)
+	}
+}
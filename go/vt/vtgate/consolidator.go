@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+)
+
+// consolidations counts the executions which shared the result of an
+// identical query in flight instead of executing it again.
+var consolidations = stats.NewCounter("VtgateConsolidations", "Vtgate query executions which shared the result of an identical query in flight")
+
+// shouldConsolidate returns true if the execution of the plan can share the
+// result of an identical query in flight. Only reads which opted in with the
+// VTGATE_CONSOLIDATOR directive are consolidated, and only when their result
+// does not depend on the state of the session.
+func (e *Executor) shouldConsolidate(safeSession *econtext.SafeSession, plan *engine.Plan) bool {
+	if !e.config.EnableConsolidator || !plan.QueryHints.VTGateConsolidator || plan.QueryType != sqlparser.StmtSelect {
+		return false
+	}
+	if safeSession.InTransaction() || safeSession.InReservedConn() || safeSession.HasSystemVariables() {
+		return false
+	}
+	// Locking functions need a connection of their own.
+	if _, isLock := plan.Instructions.(*engine.Lock); isLock {
+		return false
+	}
+	return true
+}
+
+// executeConsolidated executes the plan unless an identical query is already
+// in flight, in which case it waits for it and shares its result.
+func (e *Executor) executeConsolidated(
+	ctx context.Context,
+	plan *engine.Plan,
+	vcursor *econtext.VCursorImpl,
+	bindVars map[string]*querypb.BindVariable,
+) (*sqltypes.Result, error) {
+	q, original := e.consolidator.Create(consolidatorKey(ctx, plan, vcursor, bindVars))
+	if original {
+		defer q.Broadcast()
+		qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+		q.SetResult(qr)
+		q.SetErr(err)
+		return qr, err
+	}
+
+	q.Wait()
+	q.AddWaiterCounter(-1)
+	plan.AddConsolidation()
+	consolidations.Add(1)
+	if q.Err() != nil {
+		return nil, q.Err()
+	}
+	// The result is shared with the other executions, which must not see
+	// the changes made to it on the way to the client.
+	return q.Result().ShallowCopy(), nil
+}
+
+// consolidatorKey identifies the executions of a plan which can share their
+// results: same plan, same bind variables, same target and same caller, so
+// that the tablets see the same ACLs. The plan stands for the query as it was
+// planned for the state of the session, e.g. with its sql_select_limit,
+// since identical plans are shared through the plan cache.
+func consolidatorKey(ctx context.Context, plan *engine.Plan, vcursor *econtext.VCursorImpl, bindVars map[string]*querypb.BindVariable) string {
+	var key strings.Builder
+	key.WriteString(vcursor.GetKeyspace())
+	if dest := vcursor.ShardDestination(); dest != nil {
+		key.WriteByte(':')
+		key.WriteString(dest.String())
+	}
+	key.WriteByte('@')
+	key.WriteString(vcursor.TabletType().String())
+	key.WriteByte(' ')
+	key.WriteString(callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)))
+	key.WriteByte('/')
+	key.WriteString(callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx)))
+	_, _ = fmt.Fprintf(&key, " %p ", plan)
+	key.WriteString(plan.Original)
+	for _, name := range slices.Sorted(maps.Keys(bindVars)) {
+		bv := bindVars[name]
+		key.WriteString(" :")
+		key.WriteString(name)
+		key.WriteByte('=')
+		key.WriteString(bv.Type.String())
+		key.WriteString(strconv.Quote(string(bv.Value)))
+		for _, v := range bv.Values {
+			key.WriteByte(',')
+			key.WriteString(v.Type.String())
+			key.WriteString(strconv.Quote(string(v.Value)))
+		}
+	}
+	return key.String()
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/sync2"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/logstats"
+)
+
+// recordingConsolidator records the keys of the queries it is asked to consolidate.
+type recordingConsolidator struct {
+	sync2.Consolidator
+
+	mu   sync.Mutex
+	keys []string
+}
+
+func (rc *recordingConsolidator) Create(key string) (sync2.PendingResult, bool) {
+	rc.mu.Lock()
+	rc.keys = append(rc.keys, key)
+	rc.mu.Unlock()
+	return rc.Consolidator.Create(key)
+}
+
+func (rc *recordingConsolidator) Keys() []string {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return append([]string(nil), rc.keys...)
+}
+
+func TestConsolidator(t *testing.T) {
+	eConfig := createExecutorConfigWithNormalizer()
+	eConfig.EnableConsolidator = true
+	executor, sbc1, _, _, ctx := createExecutorEnvWithConfig(t, eConfig)
+	consolidator := &recordingConsolidator{Consolidator: sync2.NewConsolidator()}
+	executor.consolidator = consolidator
+
+	exec := func(session *vtgatepb.Session, sql string) (*sqltypes.Result, error) {
+		return executorExecSession(ctx, executor, econtext.NewSafeSession(session), sql, nil)
+	}
+	autocommit := func() *vtgatepb.Session {
+		return &vtgatepb.Session{TargetString: "@primary", Autocommit: true}
+	}
+
+	query := "select /*vt+ VTGATE_CONSOLIDATOR */ id from user where id = 1"
+	_, err := exec(autocommit(), query)
+	require.NoError(t, err)
+	require.Len(t, consolidator.Keys(), 1)
+	key := consolidator.Keys()[0]
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+
+	// Hold an identical query in flight: the next execution waits for it
+	// and shares its result instead of going to the tablet.
+	q, original := consolidator.Consolidator.Create(key)
+	require.True(t, original)
+	done := make(chan *sqltypes.Result)
+	go func() {
+		qr, err := exec(autocommit(), query)
+		assert.NoError(t, err)
+		done <- qr
+	}()
+	assert.Eventually(t, func() bool {
+		return *q.AddWaiterCounter(0) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	q.SetResult(sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "42"))
+	q.Broadcast()
+	qr := <-done
+	assert.Equal(t, `[[INT64(42)]]`, fmt.Sprintf("%v", qr.Rows))
+	assert.EqualValues(t, 1, sbc1.ExecCount.Load())
+	plan := assertCacheContains(t, executor, nil, "select /*vt+ VTGATE_CONSOLIDATOR */ id from `user` where id = :id /* INT64 */")
+	_, _, _, _, _, _, consolidations := plan.Stats()
+	assert.EqualValues(t, 1, consolidations)
+
+	// Other bind variables make another query.
+	_, err = exec(autocommit(), "select /*vt+ VTGATE_CONSOLIDATOR */ id from user where id = 2")
+	require.NoError(t, err)
+	keys := consolidator.Keys()
+	require.Len(t, keys, 3)
+	assert.Equal(t, key, keys[1])
+	assert.NotEqual(t, key, keys[2])
+
+	// Queries without the directive, in a transaction or with session
+	// settings are not consolidated.
+	_, err = exec(autocommit(), "select id from user where id = 1")
+	require.NoError(t, err)
+	_, err = exec(&vtgatepb.Session{TargetString: "@primary", InTransaction: true}, query)
+	require.NoError(t, err)
+	_, err = exec(&vtgatepb.Session{TargetString: "@primary", Autocommit: true, SystemVariables: map[string]string{"sql_mode": "''"}}, query)
+	require.NoError(t, err)
+	assert.Len(t, consolidator.Keys(), 3)
+
+	// Without the flag, nothing is consolidated.
+	executor.config.EnableConsolidator = false
+	_, err = exec(autocommit(), query)
+	require.NoError(t, err)
+	assert.Len(t, consolidator.Keys(), 3)
+}
+
+func TestConsolidatorKey(t *testing.T) {
+	bindVars := map[string]*querypb.BindVariable{
+		"a": sqltypes.Int64BindVariable(1),
+		"b": sqltypes.TestBindVariable([]any{1, "x"}),
+	}
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	safeSession := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "TestExecutor@replica"})
+	logStats := logstats.NewLogStats(ctx, "Test", "", "", nil, streamlog.NewQueryLogConfigForTest())
+	plan, vcursor, _, err := executor.fetchOrCreatePlan(ctx, safeSession, "select id from user where id in ::b and col = :a", bindVars, false, false, logStats, false)
+	require.NoError(t, err)
+	key := consolidatorKey(ctx, plan, vcursor, bindVars)
+	assert.Equal(t, fmt.Sprintf("TestExecutor@REPLICA / %p select id from `user` where id in ::b and col = :a :a=INT64\"1\" :b=TUPLE\"\",INT64\"1\",VARCHAR\"x\"", plan), key)
+}
+
+// TestConsolidatorKeySessions verifies that the executions of sessions which
+// target different shards, or whose state changes the plan, are not
+// consolidated.
+func TestConsolidatorKeySessions(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	query := "select /*vt+ VTGATE_CONSOLIDATOR */ id from user"
+	key := func(session *vtgatepb.Session) string {
+		safeSession := econtext.NewSafeSession(session)
+		logStats := logstats.NewLogStats(ctx, "Test", "", "", nil, streamlog.NewQueryLogConfigForTest())
+		plan, vcursor, _, err := executor.fetchOrCreatePlan(ctx, safeSession, query, nil, false, false, logStats, false)
+		require.NoError(t, err)
+		return consolidatorKey(ctx, plan, vcursor, nil)
+	}
+
+	shard1 := key(&vtgatepb.Session{TargetString: "TestExecutor:-20@primary"})
+	shard2 := key(&vtgatepb.Session{TargetString: "TestExecutor:20-40@primary"})
+	assert.NotEqual(t, shard1, shard2)
+	assert.Equal(t, shard1, key(&vtgatepb.Session{TargetString: "TestExecutor:-20@primary"}))
+
+	noLimit := key(&vtgatepb.Session{TargetString: "@primary"})
+	limit := key(&vtgatepb.Session{TargetString: "@primary", Options: &querypb.ExecuteOptions{SqlSelectLimit: 10}})
+	assert.NotEqual(t, noLimit, limit)
+	assert.Equal(t, noLimit, key(&vtgatepb.Session{TargetString: "@primary"}))
+}
@@ -47,6 +47,11 @@ type (
 		// VindexValueOffset stores the offset for each column in the ColumnVindex
 		// that will appear in the result set of the select query.
 		VindexValueOffset [][]int
+
+		// ForceStreaming is true when the input must be consumed in chunks,
+		// also for non-streaming execution, because it can be too large
+		// to be buffered. This is the case for LOAD DATA.
+		ForceStreaming bool
 	}
 )
 
@@ -88,6 +93,9 @@ func (ins *InsertSelect) Inputs() ([]Primitive, []map[string]any) {
 
 // TryExecute performs a non-streaming exec.
 func (ins *InsertSelect) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool) (*sqltypes.Result, error) {
+	if ins.ForceStreaming {
+		return ins.execInsertStreaming(ctx, vcursor, bindVars)
+	}
 	if ins.Keyspace.Sharded {
 		return ins.execInsertSharded(ctx, vcursor, bindVars)
 	}
@@ -103,6 +111,15 @@ func (ins *InsertSelect) TryStreamExecute(ctx context.Context, vcursor VCursor,
 		}
 		return callback(res)
 	}
+	res, err := ins.execInsertStreaming(ctx, vcursor, bindVars)
+	if err != nil {
+		return err
+	}
+	return callback(res)
+}
+
+// execInsertStreaming streams the input and inserts it one chunk at a time.
+func (ins *InsertSelect) execInsertStreaming(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
 	if ins.QueryTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, time.Duration(ins.QueryTimeout)*time.Millisecond)
@@ -135,9 +152,9 @@ func (ins *InsertSelect) TryStreamExecute(ctx context.Context, vcursor VCursor,
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return callback(output)
+	return output, nil
 }
 
 func (ins *InsertSelect) execInsertUnsharded(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
@@ -196,7 +213,7 @@ func (ins *InsertSelect) executeInsertQueries(
 	queries []*querypb.BoundQuery,
 	insertID uint64,
 ) (*sqltypes.Result, error) {
-	autocommit := (len(rss) == 1 || ins.MultiShardAutocommit) && vcursor.AutocommitApproval()
+	autocommit := !ins.PreventAutoCommit && (len(rss) == 1 || ins.MultiShardAutocommit) && vcursor.AutocommitApproval()
 	err := allowOnlyPrimary(rss...)
 	if err != nil {
 		return nil, err
@@ -327,6 +344,9 @@ func (ins *InsertSelect) description() PrimitiveDescription {
 		}
 		other["VindexOffsetFromSelect"] = valuesOffsets
 	}
+	if ins.ForceStreaming {
+		other["InputAsStreaming"] = true
+	}
 
 	return PrimitiveDescription{
 		OperatorType: "Insert",
@@ -19,6 +19,7 @@ package engine
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -1739,6 +1740,59 @@ func TestInsertSelectSimple(t *testing.T) {
 			` {_c1_0: type:VARCHAR value:"a" _c1_1: type:INT64 value:"3"} true false`})
 }
 
+func TestInsertSelectPreventAutoCommit(t *testing.T) {
+	invschema := &vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"sharded": {
+				Sharded: true,
+				Vindexes: map[string]*vschemapb.Vindex{
+					"hash": {Type: "hash"}},
+				Tables: map[string]*vschemapb.Table{
+					"t1": {
+						ColumnVindexes: []*vschemapb.ColumnVindex{{
+							Name:    "hash",
+							Columns: []string{"id"}}}}}}}}
+
+	vs := vindexes.BuildVSchema(invschema, sqlparser.NewTestParser())
+	ks := vs.Keyspaces["sharded"]
+
+	rb := &Route{
+		Query:      "dummy_select",
+		FieldQuery: "dummy_field_query",
+		RoutingParameters: &RoutingParameters{
+			Opcode:   Scatter,
+			Keyspace: ks.Keyspace}}
+
+	for _, preventAutoCommit := range []bool{false, true} {
+		t.Run(fmt.Sprintf("PreventAutoCommit=%v", preventAutoCommit), func(t *testing.T) {
+			ins := newInsertSelect(false, ks.Keyspace, ks.Tables["t1"], "prefix ", nil, [][]int{{1}}, rb)
+			ins.PreventAutoCommit = preventAutoCommit
+
+			vc := newTestVCursor("-20")
+			vc.shardForKsid = []string{"-20"}
+			vc.results = []*sqltypes.Result{
+				sqltypes.MakeTestResult(
+					sqltypes.MakeTestFields(
+						"name|id",
+						"varchar|int64"),
+					"a|1")}
+
+			_, err := ins.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+			require.NoError(t, err)
+
+			// the rows are only autocommitted when they all go to one shard
+			// and autocommit is not prevented
+			vc.ExpectLog(t, []string{
+				`ResolveDestinations sharded [] Destinations:DestinationAllShards()`,
+				`ExecuteMultiShard sharded.-20: dummy_select {} false false`,
+				`ResolveDestinations sharded [value:"0"] Destinations:DestinationKeyspaceID(166b40b44aba4bd6)`,
+				`ExecuteMultiShard sharded.-20: prefix values (:_c0_0, :_c0_1) ` +
+					`{_c0_0: type:VARCHAR value:"a" _c0_1: type:INT64 value:"1"} ` +
+					fmt.Sprintf("true %v", !preventAutoCommit)})
+		})
+	}
+}
+
 func TestInsertSelectOwned(t *testing.T) {
 	invschema := &vschemapb.SrvVSchema{
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -0,0 +1,418 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+var _ Primitive = (*LoadData)(nil)
+
+// loadDataBatchSize is the maximum number of rows LoadData returns in a
+// single result, and therefore the number of rows inserted per batch.
+const loadDataBatchSize = 1000
+
+// LoadData is a primitive that requests the file of a LOAD DATA LOCAL
+// INFILE statement from the client, and returns its lines as rows of
+// VARCHAR values, in batches. It is used as the input of an InsertSelect.
+type LoadData struct {
+	noInputs
+	noTxNeeded
+
+	// FileName is the name of the file on the client side.
+	FileName string
+
+	// Columns is the number of fields read from every line.
+	// Additional fields are ignored.
+	Columns int
+
+	// The options below follow the semantics of the FIELDS and LINES
+	// clauses of LOAD DATA. EnclosedBy and EscapedBy are empty or a
+	// single character.
+	FieldsTerminatedBy string
+	FieldsEnclosedBy   string
+	FieldsEscapedBy    string
+	LinesStartingBy    string
+	LinesTerminatedBy  string
+
+	// IgnoreLines is the number of lines skipped at the start of the file.
+	IgnoreLines int
+}
+
+// NewLoadData creates a LoadData primitive with the default options of
+// LOAD DATA.
+func NewLoadData(fileName string, columns int) *LoadData {
+	return &LoadData{
+		FileName:           fileName,
+		Columns:            columns,
+		FieldsTerminatedBy: "\t",
+		FieldsEscapedBy:    "\\",
+		LinesTerminatedBy:  "\n",
+	}
+}
+
+// RouteType implements the Primitive interface
+func (ld *LoadData) RouteType() string {
+	return "LoadData"
+}
+
+// GetKeyspaceName implements the Primitive interface
+func (ld *LoadData) GetKeyspaceName() string {
+	return ""
+}
+
+// GetTableName implements the Primitive interface
+func (ld *LoadData) GetTableName() string {
+	return ""
+}
+
+// GetFields implements the Primitive interface
+func (ld *LoadData) GetFields(context.Context, VCursor, map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{}, nil
+}
+
+// TryExecute implements the Primitive interface
+func (ld *LoadData) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	result := &sqltypes.Result{}
+	err := ld.TryStreamExecute(ctx, vcursor, bindVars, wantfields, func(qr *sqltypes.Result) error {
+		result.Rows = append(result.Rows, qr.Rows...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TryStreamExecute implements the Primitive interface
+func (ld *LoadData) TryStreamExecute(ctx context.Context, _ VCursor, _ map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	requester, ok := mysql.LocalInfileRequesterFromContext(ctx)
+	if !ok {
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "LOAD DATA LOCAL INFILE is only supported over the MySQL protocol")
+	}
+	r, err := requester.RequestLocalInfile(ld.FileName)
+	if err != nil {
+		return err
+	}
+
+	p := ld.newParser(r)
+	for range ld.IgnoreLines {
+		if err := p.skipLine(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	var rows []sqltypes.Row
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		row, err := p.nextRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		p.line++
+		if len(row) < ld.Columns {
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueCountOnRow, "Row %d doesn't contain data for all columns", p.line)
+		}
+		rows = append(rows, row[:ld.Columns])
+		if len(rows) == loadDataBatchSize {
+			if err := callback(&sqltypes.Result{Rows: rows}); err != nil {
+				return err
+			}
+			rows = nil
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return callback(&sqltypes.Result{Rows: rows})
+}
+
+func (ld *LoadData) description() PrimitiveDescription {
+	other := map[string]any{
+		"FileName": ld.FileName,
+		"Columns":  ld.Columns,
+	}
+	if ld.FieldsTerminatedBy != "\t" {
+		other["FieldsTerminatedBy"] = ld.FieldsTerminatedBy
+	}
+	if ld.FieldsEnclosedBy != "" {
+		other["FieldsEnclosedBy"] = ld.FieldsEnclosedBy
+	}
+	if ld.FieldsEscapedBy != "\\" {
+		other["FieldsEscapedBy"] = ld.FieldsEscapedBy
+	}
+	if ld.LinesStartingBy != "" {
+		other["LinesStartingBy"] = ld.LinesStartingBy
+	}
+	if ld.LinesTerminatedBy != "\n" {
+		other["LinesTerminatedBy"] = ld.LinesTerminatedBy
+	}
+	if ld.IgnoreLines > 0 {
+		other["IgnoreLines"] = ld.IgnoreLines
+	}
+	return PrimitiveDescription{
+		OperatorType: "LoadData",
+		Other:        other,
+	}
+}
+
+// loadDataParser splits the content of a file into rows according to
+// the FIELDS and LINES options of a LoadData.
+type loadDataParser struct {
+	r *bufio.Reader
+
+	fieldTerm []byte
+	lineStart []byte
+	lineTerm  []byte
+
+	enclosed    byte
+	hasEnclosed bool
+	escaped     byte
+	hasEscaped  bool
+
+	// line is the number of rows read so far.
+	line int
+	buf  []byte
+}
+
+func (ld *LoadData) newParser(r io.Reader) *loadDataParser {
+	p := &loadDataParser{
+		r:         bufio.NewReader(r),
+		fieldTerm: []byte(ld.FieldsTerminatedBy),
+		lineStart: []byte(ld.LinesStartingBy),
+		lineTerm:  []byte(ld.LinesTerminatedBy),
+	}
+	if ld.FieldsEnclosedBy != "" {
+		p.enclosed, p.hasEnclosed = ld.FieldsEnclosedBy[0], true
+	}
+	if ld.FieldsEscapedBy != "" {
+		p.escaped, p.hasEscaped = ld.FieldsEscapedBy[0], true
+	}
+	return p
+}
+
+// hasPrefix returns true if the unread input starts with prefix.
+func (p *loadDataParser) hasPrefix(prefix []byte) bool {
+	if len(prefix) == 0 {
+		return false
+	}
+	next, _ := p.r.Peek(len(prefix))
+	return bytes.Equal(next, prefix)
+}
+
+// atEOF returns true if the input has been read completely.
+func (p *loadDataParser) atEOF() (bool, error) {
+	_, err := p.r.Peek(1)
+	if err == io.EOF {
+		return true, nil
+	}
+	return false, err
+}
+
+// atFieldEnd returns true if the next bytes terminate a field.
+func (p *loadDataParser) atFieldEnd() (bool, error) {
+	if p.hasPrefix(p.fieldTerm) || p.hasPrefix(p.lineTerm) {
+		return true, nil
+	}
+	return p.atEOF()
+}
+
+// skipLine discards the input up to and including the next line terminator.
+func (p *loadDataParser) skipLine() error {
+	if eof, err := p.atEOF(); eof {
+		return io.EOF
+	} else if err != nil {
+		return err
+	}
+	for !p.hasPrefix(p.lineTerm) {
+		if _, err := p.r.ReadByte(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	_, err := p.r.Discard(len(p.lineTerm))
+	return err
+}
+
+// skipToLineStart discards the input up to and including the next
+// LINES STARTING BY prefix. Lines without the prefix are skipped.
+func (p *loadDataParser) skipToLineStart() error {
+	for !p.hasPrefix(p.lineStart) {
+		if _, err := p.r.ReadByte(); err != nil {
+			return err
+		}
+	}
+	_, err := p.r.Discard(len(p.lineStart))
+	return err
+}
+
+// nextRow returns the values of the next line, or io.EOF at the end of
+// the input.
+func (p *loadDataParser) nextRow() (sqltypes.Row, error) {
+	if eof, err := p.atEOF(); eof {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, err
+	}
+	if len(p.lineStart) > 0 {
+		if err := p.skipToLineStart(); err != nil {
+			return nil, err
+		}
+	}
+
+	var row sqltypes.Row
+	for {
+		value, err := p.nextField()
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, value)
+
+		switch {
+		case p.hasPrefix(p.fieldTerm):
+			if _, err := p.r.Discard(len(p.fieldTerm)); err != nil {
+				return nil, err
+			}
+		case p.hasPrefix(p.lineTerm):
+			_, err := p.r.Discard(len(p.lineTerm))
+			return row, err
+		default:
+			// nextField only returns at a terminator or at the end of the input.
+			return row, nil
+		}
+	}
+}
+
+// nextField reads the next field of the current line and leaves the
+// terminator that follows it unread.
+func (p *loadDataParser) nextField() (sqltypes.Value, error) {
+	p.buf = p.buf[:0]
+	enclosed := p.hasEnclosed && p.hasPrefix([]byte{p.enclosed})
+	if enclosed {
+		if _, err := p.r.Discard(1); err != nil {
+			return sqltypes.Value{}, err
+		}
+	}
+	escapes := 0
+	for {
+		if !enclosed {
+			end, err := p.atFieldEnd()
+			if err != nil {
+				return sqltypes.Value{}, err
+			}
+			if end {
+				break
+			}
+		}
+		b, err := p.r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return sqltypes.Value{}, err
+		}
+
+		if enclosed && b == p.enclosed {
+			// A doubled enclosing character stands for itself.
+			if p.hasPrefix([]byte{p.enclosed}) {
+				if _, err := p.r.Discard(1); err != nil {
+					return sqltypes.Value{}, err
+				}
+				p.buf = append(p.buf, b)
+				continue
+			}
+			end, err := p.atFieldEnd()
+			if err != nil {
+				return sqltypes.Value{}, err
+			}
+			if end {
+				break
+			}
+			p.buf = append(p.buf, b)
+			continue
+		}
+
+		if p.hasEscaped && b == p.escaped {
+			next, err := p.r.ReadByte()
+			if err == io.EOF {
+				p.buf = append(p.buf, b)
+				break
+			}
+			if err != nil {
+				return sqltypes.Value{}, err
+			}
+			escapes++
+			if next == 'N' && !enclosed && len(p.buf) == 0 {
+				end, err := p.atFieldEnd()
+				if err != nil {
+					return sqltypes.Value{}, err
+				}
+				if end {
+					return sqltypes.NULL, nil
+				}
+			}
+			p.buf = append(p.buf, unescapeLoadData(next))
+			continue
+		}
+
+		p.buf = append(p.buf, b)
+	}
+
+	// With FIELDS ENCLOSED BY, an unenclosed NULL word is a NULL value.
+	if p.hasEnclosed && !enclosed && escapes == 0 && string(p.buf) == "NULL" {
+		return sqltypes.NULL, nil
+	}
+	return sqltypes.MakeTrusted(sqltypes.VarChar, bytes.Clone(p.buf)), nil
+}
+
+// unescapeLoadData returns the character represented by the escape
+// sequence made of the escape character followed by b.
+func unescapeLoadData(b byte) byte {
+	switch b {
+	case '0':
+		return 0
+	case 'b':
+		return '\b'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 0x1a
+	default:
+		return b
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+type fakeLocalInfileRequester struct {
+	content   string
+	requested string
+}
+
+func (f *fakeLocalInfileRequester) RequestLocalInfile(fileName string) (io.Reader, error) {
+	f.requested = fileName
+	return strings.NewReader(f.content), nil
+}
+
+func TestLoadDataParse(t *testing.T) {
+	tcases := []struct {
+		name     string
+		setup    func(ld *LoadData)
+		content  string
+		expected []sqltypes.Row
+		err      string
+	}{{
+		name:    "defaults",
+		content: "1\ta\n2\tb\\tc\n3\t\\N\n",
+		expected: []sqltypes.Row{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar("a")},
+			{sqltypes.NewVarChar("2"), sqltypes.NewVarChar("b\tc")},
+			{sqltypes.NewVarChar("3"), sqltypes.NULL},
+		},
+	}, {
+		name:    "no trailing line terminator and extra fields",
+		content: "1\ta\textra\n2\tb",
+		expected: []sqltypes.Row{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar("a")},
+			{sqltypes.NewVarChar("2"), sqltypes.NewVarChar("b")},
+		},
+	}, {
+		name: "csv",
+		setup: func(ld *LoadData) {
+			ld.FieldsTerminatedBy = ","
+			ld.FieldsEnclosedBy = `"`
+			ld.LinesTerminatedBy = "\r\n"
+			ld.IgnoreLines = 1
+		},
+		content: "id,name\r\n1,\"a,b\"\r\n2,\"say \"\"hi\"\"\"\r\n3,NULL\r\n4,\"NULL\"\r\n",
+		expected: []sqltypes.Row{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar("a,b")},
+			{sqltypes.NewVarChar("2"), sqltypes.NewVarChar(`say "hi"`)},
+			{sqltypes.NewVarChar("3"), sqltypes.NULL},
+			{sqltypes.NewVarChar("4"), sqltypes.NewVarChar("NULL")},
+		},
+	}, {
+		name: "lines starting by",
+		setup: func(ld *LoadData) {
+			ld.LinesStartingBy = "xxx"
+		},
+		content: "xxx1\ta\nskipped\nyyyxxx2\tb\n",
+		expected: []sqltypes.Row{
+			{sqltypes.NewVarChar("1"), sqltypes.NewVarChar("a")},
+			{sqltypes.NewVarChar("2"), sqltypes.NewVarChar("b")},
+		},
+	}, {
+		name:    "ignore more lines than the file has",
+		setup:   func(ld *LoadData) { ld.IgnoreLines = 5 },
+		content: "1\ta\n",
+	}, {
+		name:    "missing field",
+		content: "1\ta\n2\n",
+		err:     "Row 2 doesn't contain data for all columns",
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			ld := NewLoadData("/tmp/x.txt", 2)
+			if tcase.setup != nil {
+				tcase.setup(ld)
+			}
+			requester := &fakeLocalInfileRequester{content: tcase.content}
+			ctx := mysql.NewContextWithLocalInfileRequester(context.Background(), requester)
+
+			qr, err := ld.TryExecute(ctx, &noopVCursor{}, nil, false)
+			if tcase.err != "" {
+				require.ErrorContains(t, err, tcase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "/tmp/x.txt", requester.requested)
+			assert.Equal(t, tcase.expected, qr.Rows)
+		})
+	}
+}
+
+func TestLoadDataBatches(t *testing.T) {
+	var content strings.Builder
+	for i := range loadDataBatchSize + 10 {
+		fmt.Fprintf(&content, "%d\n", i)
+	}
+	ctx := mysql.NewContextWithLocalInfileRequester(context.Background(), &fakeLocalInfileRequester{content: content.String()})
+
+	var batches []int
+	err := NewLoadData("x", 1).TryStreamExecute(ctx, &noopVCursor{}, nil, false, func(qr *sqltypes.Result) error {
+		batches = append(batches, len(qr.Rows))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{loadDataBatchSize, 10}, batches)
+}
+
+func TestLoadDataWithoutRequester(t *testing.T) {
+	_, err := NewLoadData("x", 1).TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.ErrorContains(t, err, "LOAD DATA LOCAL INFILE is only supported over the MySQL protocol")
+}
@@ -49,6 +49,11 @@ type OrderedAggregate struct {
 	// from the result received. If 0, no truncation happens.
 	TruncateColumnCount int
 
+	// WithRollup adds the super-aggregate rows of GROUP BY ... WITH ROLLUP
+	// to the result. After the rows of a group, one row is produced for every
+	// grouping key that changes, with that key and the ones after it set to NULL.
+	WithRollup bool
+
 	// Input is the primitive that will feed into this Primitive.
 	Input Primitive
 }
@@ -129,6 +134,9 @@ func (oa *OrderedAggregate) execute(ctx context.Context, vcursor VCursor, bindVa
 	if err != nil {
 		return nil, err
 	}
+	if oa.WithRollup {
+		return oa.executeRollup(result, env, vcursor.ConnCollation())
+	}
 	if len(oa.Aggregates) == 0 {
 		return oa.executeGroupBy(result)
 	}
@@ -230,6 +238,9 @@ func (oa *OrderedAggregate) executeStreamGroupBy(ctx context.Context, vcursor VC
 
 // TryStreamExecute is a Primitive function.
 func (oa *OrderedAggregate) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	if oa.WithRollup {
+		return oa.executeStreamRollup(ctx, vcursor, bindVars, callback)
+	}
 	if len(oa.Aggregates) == 0 {
 		return oa.executeStreamGroupBy(ctx, vcursor, bindVars, callback)
 	}
@@ -303,6 +314,160 @@ func (oa *OrderedAggregate) TryStreamExecute(ctx context.Context, vcursor VCurso
 	return nil
 }
 
+func (oa *OrderedAggregate) executeRollup(result *sqltypes.Result, env *evalengine.ExpressionEnv, collation collations.ID) (*sqltypes.Result, error) {
+	r, fields, err := oa.newRollup(result.Fields, env, collation)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sqltypes.Result{
+		Fields: fields,
+		Rows:   make([][]sqltypes.Value, 0, len(result.Rows)),
+	}
+	for _, row := range result.Rows {
+		done, err := r.add(row)
+		if err != nil {
+			return nil, err
+		}
+		out.Rows = append(out.Rows, done...)
+	}
+
+	done, err := r.finish(0)
+	if err != nil {
+		return nil, err
+	}
+	out.Rows = append(out.Rows, done...)
+	return out, nil
+}
+
+func (oa *OrderedAggregate) executeStreamRollup(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, callback func(*sqltypes.Result) error) error {
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+
+	cb := func(qr *sqltypes.Result) error {
+		return callback(qr.Truncate(oa.TruncateColumnCount))
+	}
+
+	var r *rollup
+	visitor := func(qr *sqltypes.Result) error {
+		if r == nil && len(qr.Fields) != 0 {
+			var fields []*querypb.Field
+			var err error
+			r, fields, err = oa.newRollup(qr.Fields, env, vcursor.ConnCollation())
+			if err != nil {
+				return err
+			}
+			if err = cb(&sqltypes.Result{Fields: fields}); err != nil {
+				return err
+			}
+		}
+
+		var rows [][]sqltypes.Value
+		for _, row := range qr.Rows {
+			done, err := r.add(row)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, done...)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return cb(&sqltypes.Result{Rows: rows})
+	}
+
+	/* we need the input fields types to correctly calculate the output types */
+	err := vcursor.StreamExecutePrimitive(ctx, oa.Input, bindVars, true, visitor)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return nil
+	}
+
+	done, err := r.finish(0)
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		return nil
+	}
+	return cb(&sqltypes.Result{Rows: done})
+}
+
+// rollup aggregates the rows once per level of GROUP BY ... WITH ROLLUP.
+// Level i groups by the first i grouping keys, so level 0 aggregates all the rows
+// and the last level holds the regular groups.
+type rollup struct {
+	oa         *OrderedAggregate
+	levels     []*aggregationState
+	currentKey []sqltypes.Value
+}
+
+func (oa *OrderedAggregate) newRollup(fields []*querypb.Field, env *evalengine.ExpressionEnv, collation collations.ID) (*rollup, []*querypb.Field, error) {
+	r := &rollup{oa: oa}
+	var outFields []*querypb.Field
+	for range len(oa.GroupByKeys) + 1 {
+		agg, aggFields, err := newAggregation(fields, oa.Aggregates, env, collation)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.levels = append(r.levels, agg)
+		outFields = aggFields
+	}
+	return r, outFields, nil
+}
+
+// add aggregates the row on every level, and returns the rows of the groups that the row closed
+func (r *rollup) add(row []sqltypes.Value) ([][]sqltypes.Value, error) {
+	var done [][]sqltypes.Value
+	if r.currentKey != nil {
+		changed, err := firstChangedKey(r.oa.GroupByKeys, r.currentKey, row)
+		if err != nil {
+			return nil, err
+		}
+		if changed >= 0 {
+			// the groups of all the levels that include the changed key are complete
+			done, err = r.finish(changed + 1)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	r.currentKey = row
+
+	for _, agg := range r.levels {
+		if err := agg.add(row); err != nil {
+			return nil, err
+		}
+	}
+	return done, nil
+}
+
+// finish returns the rows of the levels from the innermost one down to the given level,
+// and resets them so they can aggregate the next group
+func (r *rollup) finish(level int) ([][]sqltypes.Value, error) {
+	if r.currentKey == nil {
+		return nil, nil
+	}
+
+	var rows [][]sqltypes.Value
+	for l := len(r.levels) - 1; l >= level; l-- {
+		row, err := r.levels[l].finish()
+		if err != nil {
+			return nil, err
+		}
+		for _, gb := range r.oa.GroupByKeys[l:] {
+			row[gb.KeyCol] = sqltypes.NULL
+			if gb.WeightStringCol >= 0 {
+				row[gb.WeightStringCol] = sqltypes.NULL
+			}
+		}
+		rows = append(rows, row)
+		r.levels[l].reset()
+	}
+	return rows, nil
+}
+
 // GetFields is a Primitive function.
 func (oa *OrderedAggregate) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
 	qr, err := oa.Input.GetFields(ctx, vcursor, bindVars)
@@ -334,31 +499,44 @@ func (oa *OrderedAggregate) nextGroupBy(currentKey, nextRow []sqltypes.Value) (n
 		return nextRow, false, nil
 	}
 
-	for _, gb := range oa.GroupByKeys {
+	changed, err := firstChangedKey(oa.GroupByKeys, currentKey, nextRow)
+	if err != nil {
+		return nil, false, err
+	}
+	if changed >= 0 {
+		return nextRow, true, nil
+	}
+	return currentKey, false, nil
+}
+
+// firstChangedKey returns the index of the first of the keys that differs
+// between the two rows, or -1 if both rows belong to the same group
+func firstChangedKey(keys []*GroupByParams, currentKey, nextRow []sqltypes.Value) (int, error) {
+	for idx, gb := range keys {
 		v1 := currentKey[gb.KeyCol]
 		v2 := nextRow[gb.KeyCol]
 		if v1.TinyWeightCmp(v2) != 0 {
-			return nextRow, true, nil
+			return idx, nil
 		}
 
 		cmp, err := evalengine.NullsafeCompare(v1, v2, gb.CollationEnv, gb.Type.Collation(), gb.Type.Values())
 		if err != nil {
 			_, isCollationErr := err.(evalengine.UnsupportedCollationError)
 			if !isCollationErr || gb.WeightStringCol == -1 {
-				return nil, false, err
+				return 0, err
 			}
-			gb.KeyCol = gb.WeightStringCol
 			cmp, err = evalengine.NullsafeCompare(currentKey[gb.WeightStringCol], nextRow[gb.WeightStringCol], gb.CollationEnv, gb.Type.Collation(), gb.Type.Values())
 			if err != nil {
-				return nil, false, err
+				return 0, err
 			}
 		}
 		if cmp != 0 {
-			return nextRow, true, nil
+			return idx, nil
 		}
 	}
-	return currentKey, false, nil
+	return -1, nil
 }
+
 func aggregateParamsToString(in any) string {
 	return in.(*AggregateParams).String()
 }
@@ -377,6 +555,9 @@ func (oa *OrderedAggregate) description() PrimitiveDescription {
 	if oa.TruncateColumnCount > 0 {
 		other["ResultColumns"] = oa.TruncateColumnCount
 	}
+	if oa.WithRollup {
+		other["WithRollup"] = true
+	}
 	return PrimitiveDescription{
 		OperatorType: "Aggregate",
 		Variant:      "Ordered",
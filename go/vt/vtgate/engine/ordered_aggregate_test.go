@@ -1169,3 +1169,109 @@ func TestGroupConcat(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderedAggregateRollup(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"a|b|sum(c)",
+				"varbinary|varbinary|decimal",
+			),
+			"x|1|1",
+			"x|1|2",
+			"x|2|3",
+			"y|1|4",
+		)},
+	}
+
+	oa := &OrderedAggregate{
+		Aggregates: []*AggregateParams{NewAggregateParam(AggregateSum, 2, nil, "", collations.MySQL8())},
+		GroupByKeys: []*GroupByParams{
+			{KeyCol: 0, WeightStringCol: -1},
+			{KeyCol: 1, WeightStringCol: -1},
+		},
+		WithRollup: true,
+		Input:      fp,
+	}
+
+	result, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"a|b|sum(c)",
+			"varbinary|varbinary|decimal",
+		),
+		"x|1|3",
+		"x|2|3",
+		"x|null|6",
+		"y|1|4",
+		"y|null|4",
+		"null|null|10",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestOrderedAggregateRollupStreamExecute(t *testing.T) {
+	fp := &fakePrimitive{
+		// the fake primitive streams two rows at a time
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"a|weight_string(a)",
+				"varchar|varbinary",
+			),
+			"a|A",
+			"A|A",
+			"b|B",
+		)},
+	}
+
+	oa := &OrderedAggregate{
+		GroupByKeys: []*GroupByParams{{
+			KeyCol:          0,
+			WeightStringCol: 1,
+			Type:            evalengine.NewType(sqltypes.VarChar, collations.Unknown),
+			CollationEnv:    collations.MySQL8(),
+		}},
+		WithRollup:          true,
+		TruncateColumnCount: 1,
+		Input:               fp,
+	}
+
+	var results []*sqltypes.Result
+	err := oa.TryStreamExecute(context.Background(), &noopVCursor{}, nil, true, func(qr *sqltypes.Result) error {
+		results = append(results, qr)
+		return nil
+	})
+	require.NoError(t, err)
+
+	wantResults := sqltypes.MakeTestStreamingResults(
+		sqltypes.MakeTestFields("a", "varchar"),
+		"a",
+		"---",
+		"b",
+		"null",
+	)
+	utils.MustMatch(t, wantResults, results)
+}
+
+func TestOrderedAggregateRollupNoRows(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"a|count(*)",
+		"varbinary|int64",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(fields)},
+	}
+
+	oa := &OrderedAggregate{
+		Aggregates:  []*AggregateParams{NewAggregateParam(AggregateSum, 1, nil, "", collations.MySQL8())},
+		GroupByKeys: []*GroupByParams{{KeyCol: 0, WeightStringCol: -1}},
+		WithRollup:  true,
+		Input:       fp,
+	}
+
+	result, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Rows)
+}
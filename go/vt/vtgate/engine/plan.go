@@ -51,12 +51,13 @@ type (
 		ParamsCount  uint16                  // ParamsCount is the total number of bind parameters (?) in the query.
 		Optimized    atomic.Bool             // Prepared queries need to be optimized before the first execution
 
-		ExecCount    uint64 // ExecCount is how many times this plan has been executed.
-		ExecTime     uint64 // ExecTime is the total accumulated execution time in nanoseconds.
-		ShardQueries uint64 // ShardQueries is the total count of shard-level queries performed.
-		RowsReturned uint64 // RowsReturned is the total number of rows returned to clients.
-		RowsAffected uint64 // RowsAffected is the total number of rows affected by DML operations.
-		Errors       uint64 // Errors is the total count of errors encountered during execution.
+		ExecCount      uint64 // ExecCount is how many times this plan has been executed.
+		ExecTime       uint64 // ExecTime is the total accumulated execution time in nanoseconds.
+		ShardQueries   uint64 // ShardQueries is the total count of shard-level queries performed.
+		RowsReturned   uint64 // RowsReturned is the total number of rows returned to clients.
+		RowsAffected   uint64 // RowsAffected is the total number of rows affected by DML operations.
+		Errors         uint64 // Errors is the total count of errors encountered during execution.
+		Consolidations uint64 // Consolidations is how many executions shared the result of an identical query in flight.
 	}
 
 	// PlanKey identifies a plan uniquely based on keyspace, destination, query,
@@ -292,29 +293,31 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 	}
 
 	marshalPlan := struct {
-		Type         string
-		QueryType    string
-		Original     string                `json:",omitempty"`
-		Instructions *PrimitiveDescription `json:",omitempty"`
-		ExecCount    uint64                `json:",omitempty"`
-		ExecTime     time.Duration         `json:",omitempty"`
-		ShardQueries uint64                `json:",omitempty"`
-		RowsAffected uint64                `json:",omitempty"`
-		RowsReturned uint64                `json:",omitempty"`
-		Errors       uint64                `json:",omitempty"`
-		TablesUsed   []string              `json:",omitempty"`
+		Type           string
+		QueryType      string
+		Original       string                `json:",omitempty"`
+		Instructions   *PrimitiveDescription `json:",omitempty"`
+		ExecCount      uint64                `json:",omitempty"`
+		ExecTime       time.Duration         `json:",omitempty"`
+		ShardQueries   uint64                `json:",omitempty"`
+		RowsAffected   uint64                `json:",omitempty"`
+		RowsReturned   uint64                `json:",omitempty"`
+		Errors         uint64                `json:",omitempty"`
+		Consolidations uint64                `json:",omitempty"`
+		TablesUsed     []string              `json:",omitempty"`
 	}{
-		Type:         p.Type.String(),
-		QueryType:    p.QueryType.String(),
-		Original:     p.Original,
-		Instructions: instructions,
-		ExecCount:    atomic.LoadUint64(&p.ExecCount),
-		ExecTime:     time.Duration(atomic.LoadUint64(&p.ExecTime)),
-		ShardQueries: atomic.LoadUint64(&p.ShardQueries),
-		RowsAffected: atomic.LoadUint64(&p.RowsAffected),
-		RowsReturned: atomic.LoadUint64(&p.RowsReturned),
-		Errors:       atomic.LoadUint64(&p.Errors),
-		TablesUsed:   p.TablesUsed,
+		Type:           p.Type.String(),
+		QueryType:      p.QueryType.String(),
+		Original:       p.Original,
+		Instructions:   instructions,
+		ExecCount:      atomic.LoadUint64(&p.ExecCount),
+		ExecTime:       time.Duration(atomic.LoadUint64(&p.ExecTime)),
+		ShardQueries:   atomic.LoadUint64(&p.ShardQueries),
+		RowsAffected:   atomic.LoadUint64(&p.RowsAffected),
+		RowsReturned:   atomic.LoadUint64(&p.RowsReturned),
+		Errors:         atomic.LoadUint64(&p.Errors),
+		Consolidations: atomic.LoadUint64(&p.Consolidations),
+		TablesUsed:     p.TablesUsed,
 	}
 
 	b := new(bytes.Buffer)
@@ -338,13 +341,20 @@ func (p *Plan) AddStats(execCount uint64, execTime time.Duration, shardQueries,
 	atomic.AddUint64(&p.Errors, errors)
 }
 
+// AddConsolidation counts an execution of the plan which shared the result
+// of an identical query in flight.
+func (p *Plan) AddConsolidation() {
+	atomic.AddUint64(&p.Consolidations, 1)
+}
+
 // Stats returns a copy of the plan execution statistics
-func (p *Plan) Stats() (execCount uint64, execTime time.Duration, shardQueries, rowsAffected, rowsReturned, errors uint64) {
+func (p *Plan) Stats() (execCount uint64, execTime time.Duration, shardQueries, rowsAffected, rowsReturned, errors, consolidations uint64) {
 	execCount = atomic.LoadUint64(&p.ExecCount)
 	execTime = time.Duration(atomic.LoadUint64(&p.ExecTime))
 	shardQueries = atomic.LoadUint64(&p.ShardQueries)
 	rowsAffected = atomic.LoadUint64(&p.RowsAffected)
 	rowsReturned = atomic.LoadUint64(&p.RowsReturned)
 	errors = atomic.LoadUint64(&p.Errors)
+	consolidations = atomic.LoadUint64(&p.Consolidations)
 	return
 }
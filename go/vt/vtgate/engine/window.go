@@ -0,0 +1,496 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*Window)(nil)
+
+// Window is a primitive that evaluates window functions at the vtgate level.
+// It expects the underlying primitive to feed rows sorted by the PartitionBy
+// keys followed by the OrderBy keys. Every row is returned, with the value of
+// each window function written to the column it was planned on.
+// Only the default window frame is supported: the whole partition when there is
+// no ordering, and from the start of the partition to the last peer of the
+// current row otherwise.
+type Window struct {
+	// Functions specifies the window functions to evaluate
+	Functions []*WindowParams
+
+	// PartitionBy specifies the input values that split the rows into partitions
+	PartitionBy []*GroupByParams
+
+	// OrderBy specifies the input values of the window ordering.
+	// Rows that are equal on all of them are peers.
+	OrderBy []*GroupByParams
+
+	// TruncateColumnCount specifies the number of columns to return
+	// in the final result. Rest of the columns are truncated
+	// from the result received. If 0, no truncation happens.
+	TruncateColumnCount int
+
+	// Input is the primitive that will feed into this Primitive.
+	Input Primitive
+}
+
+// WindowParams specifies the parameters of a single window function.
+type WindowParams struct {
+	Opcode opcode.WindowOpcode
+
+	// Col is the input column holding the argument of the function.
+	// The result of the function is written to the same column.
+	Col int
+
+	// N is the row offset for LAG and LEAD, the number of buckets for NTILE,
+	// and the row number for NTH_VALUE. LAG and LEAD use an offset of 1 when it is nil.
+	N evalengine.Expr
+
+	// Default is the value used by LAG and LEAD when there is no row at the requested offset
+	Default evalengine.Expr
+
+	Type         evalengine.Type
+	CollationEnv *collations.Environment
+}
+
+func (wp *WindowParams) String() string {
+	var args []string
+	if wp.Opcode.NeedsArgument() {
+		args = append(args, strconv.Itoa(wp.Col))
+	}
+	if wp.N != nil {
+		args = append(args, sqlparser.String(wp.N))
+	}
+	if wp.Default != nil {
+		args = append(args, sqlparser.String(wp.Default))
+	}
+	return fmt.Sprintf("%s(%s)", wp.Opcode.String(), strings.Join(args, ", "))
+}
+
+// TryExecute is a Primitive function.
+func (w *Window) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool) (*sqltypes.Result, error) {
+	result, err := vcursor.ExecutePrimitive(
+		ctx,
+		w.Input,
+		bindVars,
+		true, /*wantFields - we need the input fields types to correctly calculate the output types*/
+	)
+	if err != nil {
+		return nil, err
+	}
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	state, fields := w.newWindowState(result.Fields, env, vcursor.ConnCollation())
+
+	out := &sqltypes.Result{
+		Fields: fields,
+		Rows:   make([]sqltypes.Row, 0, len(result.Rows)),
+	}
+
+	start := 0
+	for idx := 1; idx <= len(result.Rows); idx++ {
+		if idx < len(result.Rows) {
+			changed, err := firstChangedKey(w.PartitionBy, result.Rows[start], result.Rows[idx])
+			if err != nil {
+				return nil, err
+			}
+			if changed < 0 {
+				continue
+			}
+		}
+		rows, err := state.evaluate(result.Rows[start:idx])
+		if err != nil {
+			return nil, err
+		}
+		out.Rows = append(out.Rows, rows...)
+		start = idx
+	}
+
+	return out.Truncate(w.TruncateColumnCount), nil
+}
+
+// TryStreamExecute is a Primitive function.
+func (w *Window) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+
+	cb := func(qr *sqltypes.Result) error {
+		return callback(qr.Truncate(w.TruncateColumnCount))
+	}
+
+	var state *windowState
+	var partition []sqltypes.Row
+
+	flush := func() error {
+		if len(partition) == 0 {
+			return nil
+		}
+		rows, err := state.evaluate(partition)
+		if err != nil {
+			return err
+		}
+		partition = nil
+		return cb(&sqltypes.Result{Rows: rows})
+	}
+
+	visitor := func(qr *sqltypes.Result) error {
+		if state == nil && len(qr.Fields) != 0 {
+			var fields []*querypb.Field
+			state, fields = w.newWindowState(qr.Fields, env, vcursor.ConnCollation())
+			if err := cb(&sqltypes.Result{Fields: fields}); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range qr.Rows {
+			if len(partition) > 0 {
+				changed, err := firstChangedKey(w.PartitionBy, partition[0], row)
+				if err != nil {
+					return err
+				}
+				if changed >= 0 {
+					// this is a new partition. let's yield the old one, and start a new
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+			}
+			partition = append(partition, row)
+		}
+		return nil
+	}
+
+	/* we need the input fields types to correctly calculate the output types */
+	err := vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, true, visitor)
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+// GetFields is a Primitive function.
+func (w *Window) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := w.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	_, fields := w.newWindowState(qr.Fields, env, vcursor.ConnCollation())
+
+	qr = &sqltypes.Result{Fields: fields}
+	return qr.Truncate(w.TruncateColumnCount), nil
+}
+
+// Inputs returns the Primitive input for this window
+func (w *Window) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Input}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (w *Window) NeedsTransaction() bool {
+	return w.Input.NeedsTransaction()
+}
+
+func windowParamsToString(in any) string {
+	return in.(*WindowParams).String()
+}
+
+func (w *Window) description() PrimitiveDescription {
+	other := map[string]any{
+		"Functions": GenericJoin(w.Functions, windowParamsToString),
+	}
+	if len(w.PartitionBy) > 0 {
+		other["PartitionBy"] = GenericJoin(w.PartitionBy, groupByParamsToString)
+	}
+	if len(w.OrderBy) > 0 {
+		other["OrderBy"] = GenericJoin(w.OrderBy, groupByParamsToString)
+	}
+	if w.TruncateColumnCount > 0 {
+		other["ResultColumns"] = w.TruncateColumnCount
+	}
+	return PrimitiveDescription{
+		OperatorType: "Window",
+		Other:        other,
+	}
+}
+
+// windowState holds what is needed to evaluate the window functions of a single partition
+type windowState struct {
+	window *Window
+	fields []*querypb.Field
+	env    *evalengine.ExpressionEnv
+	coll   collations.ID
+}
+
+func (w *Window) newWindowState(fields []*querypb.Field, env *evalengine.ExpressionEnv, collation collations.ID) (*windowState, []*querypb.Field) {
+	state := &windowState{
+		window: w,
+		fields: fields,
+		env:    env,
+		coll:   collation,
+	}
+
+	fields = slice.Map(fields, func(from *querypb.Field) *querypb.Field { return from.CloneVT() })
+	for _, fn := range w.Functions {
+		if fn.Col >= len(fields) {
+			continue
+		}
+		fields[fn.Col].Type = fn.Opcode.SQLType(fields[fn.Col].Type)
+	}
+	return state, fields
+}
+
+// peers describes, for every row of a partition, the peer group it belongs to
+type peers struct {
+	// start and end are the bounds of the peer group of each row; end is exclusive
+	start, end []int
+	// group is the index of the peer group of each row
+	group []int
+}
+
+func (ws *windowState) findPeers(rows []sqltypes.Row) (*peers, error) {
+	p := &peers{
+		start: make([]int, len(rows)),
+		end:   make([]int, len(rows)),
+		group: make([]int, len(rows)),
+	}
+	start, group := 0, 0
+	for idx := 1; idx <= len(rows); idx++ {
+		if idx < len(rows) {
+			changed, err := firstChangedKey(ws.window.OrderBy, rows[start], rows[idx])
+			if err != nil {
+				return nil, err
+			}
+			if changed < 0 {
+				continue
+			}
+		}
+		for i := start; i < idx; i++ {
+			p.start[i] = start
+			p.end[i] = idx
+			p.group[i] = group
+		}
+		start = idx
+		group++
+	}
+	return p, nil
+}
+
+// evaluate calculates all the window functions for the rows of a single partition
+func (ws *windowState) evaluate(rows []sqltypes.Row) ([]sqltypes.Row, error) {
+	p, err := ws.findPeers(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]sqltypes.Row, len(rows))
+	for i, row := range rows {
+		out[i] = slices.Clone(row)
+	}
+
+	for _, fn := range ws.window.Functions {
+		if err := ws.evaluateFunction(fn, rows, p, out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (ws *windowState) evaluateFunction(fn *WindowParams, rows []sqltypes.Row, p *peers, out []sqltypes.Row) error {
+	n := len(rows)
+	switch fn.Opcode {
+	case opcode.WindowRowNumber:
+		for i := range rows {
+			out[i][fn.Col] = sqltypes.NewUint64(uint64(i + 1))
+		}
+	case opcode.WindowRank:
+		for i := range rows {
+			out[i][fn.Col] = sqltypes.NewUint64(uint64(p.start[i] + 1))
+		}
+	case opcode.WindowDenseRank:
+		for i := range rows {
+			out[i][fn.Col] = sqltypes.NewUint64(uint64(p.group[i] + 1))
+		}
+	case opcode.WindowPercentRank:
+		for i := range rows {
+			var rank float64
+			if n > 1 {
+				rank = float64(p.start[i]) / float64(n-1)
+			}
+			out[i][fn.Col] = sqltypes.NewFloat64(rank)
+		}
+	case opcode.WindowCumeDist:
+		for i := range rows {
+			out[i][fn.Col] = sqltypes.NewFloat64(float64(p.end[i]) / float64(n))
+		}
+	case opcode.WindowNtile:
+		buckets, err := ws.argument(fn)
+		if err != nil {
+			return err
+		}
+		for i := range rows {
+			out[i][fn.Col] = sqltypes.NewUint64(uint64(ntileBucket(i, n, buckets)))
+		}
+	case opcode.WindowLag, opcode.WindowLead:
+		def := sqltypes.NULL
+		if fn.Default != nil {
+			var err error
+			def, err = eval(ws.env, fn.Default, ws.coll)
+			if err != nil {
+				return err
+			}
+		}
+		offset, err := ws.argument(fn)
+		if err != nil {
+			return err
+		}
+		if fn.Opcode == opcode.WindowLag {
+			offset = -offset
+		}
+		for i := range rows {
+			if j := i + offset; j >= 0 && j < n {
+				out[i][fn.Col] = rows[j][fn.Col]
+			} else {
+				out[i][fn.Col] = def
+			}
+		}
+	case opcode.WindowFirstValue:
+		for i := range rows {
+			out[i][fn.Col] = rows[0][fn.Col]
+		}
+	case opcode.WindowLastValue:
+		for i := range rows {
+			out[i][fn.Col] = rows[p.end[i]-1][fn.Col]
+		}
+	case opcode.WindowNthValue:
+		nth, err := ws.argument(fn)
+		if err != nil {
+			return err
+		}
+		nth--
+		for i := range rows {
+			if nth >= 0 && nth < p.end[i] {
+				out[i][fn.Col] = rows[nth][fn.Col]
+			} else {
+				out[i][fn.Col] = sqltypes.NULL
+			}
+		}
+	case opcode.WindowCount, opcode.WindowCountStar, opcode.WindowSum, opcode.WindowMin, opcode.WindowMax:
+		agg, err := ws.newAggregator(fn)
+		if err != nil {
+			return err
+		}
+		// the frame grows one peer group at a time, so every row of the group sees the same value
+		for start := 0; start < n; start = p.end[start] {
+			end := p.end[start]
+			for i := start; i < end; i++ {
+				if err := agg.add(rows[i]); err != nil {
+					return err
+				}
+			}
+			v, err := agg.finish(ws.env, ws.coll)
+			if err != nil {
+				return err
+			}
+			for i := start; i < end; i++ {
+				out[i][fn.Col] = v
+			}
+		}
+	default:
+		return vterrors.VT12001(fmt.Sprintf("window function '%s'", fn.Opcode.String()))
+	}
+	return nil
+}
+
+// argument evaluates the N argument of the window function, which must be a positive integer,
+// or a non-negative one for LAG and LEAD
+func (ws *windowState) argument(fn *WindowParams) (int, error) {
+	if fn.N == nil {
+		return 1, nil
+	}
+	v, err := eval(ws.env, fn.N, ws.coll)
+	if err != nil {
+		return 0, err
+	}
+	n, err := v.ToInt64()
+	minimum := int64(1)
+	if fn.Opcode == opcode.WindowLag || fn.Opcode == opcode.WindowLead {
+		minimum = 0
+	}
+	if err != nil || n < minimum {
+		return 0, vterrors.VT03025(fn.Opcode.String())
+	}
+	return int(n), nil
+}
+
+func (ws *windowState) newAggregator(fn *WindowParams) (aggregator, error) {
+	var sourceType querypb.Type
+	if fn.Col < len(ws.fields) {
+		sourceType = ws.fields[fn.Col].Type
+	}
+	switch fn.Opcode {
+	case opcode.WindowCountStar:
+		return &aggregatorCountStar{}, nil
+	case opcode.WindowCount:
+		return &aggregatorCount{from: fn.Col, distinct: aggregatorDistinct{column: -1}}, nil
+	case opcode.WindowSum:
+		return &aggregatorSum{
+			from:     fn.Col,
+			sum:      evalengine.NewAggregationSum(sourceType),
+			distinct: aggregatorDistinct{column: -1},
+		}, nil
+	case opcode.WindowMin:
+		return &aggregatorMin{aggregatorMinMax{
+			from:   fn.Col,
+			minmax: evalengine.NewAggregationMinMax(sourceType, fn.CollationEnv, fn.Type.Collation(), fn.Type.Values()),
+		}}, nil
+	case opcode.WindowMax:
+		return &aggregatorMax{aggregatorMinMax{
+			from:   fn.Col,
+			minmax: evalengine.NewAggregationMinMax(sourceType, fn.CollationEnv, fn.Type.Collation(), fn.Type.Values()),
+		}}, nil
+	}
+	return nil, vterrors.VT13001(fmt.Sprintf("unexpected window aggregation: %s", fn.Opcode.String()))
+}
+
+// ntileBucket returns the bucket, starting from 1, of the row at position idx
+// in a partition of n rows divided into the given number of buckets.
+// Just like MySQL, the first n % buckets buckets get one extra row.
+func ntileBucket(idx, n, buckets int) int {
+	size := n / buckets
+	extra := n % buckets
+	if size == 0 {
+		return idx + 1
+	}
+	bigRows := extra * (size + 1)
+	if idx < bigRows {
+		return idx/(size+1) + 1
+	}
+	return extra + (idx-bigRows)/size + 1
+}
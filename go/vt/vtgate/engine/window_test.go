@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+)
+
+func TestWindowRanking(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"rn|rk|dr|p|o",
+				"int64|int64|int64|varbinary|int64",
+			),
+			"1|1|1|a|1",
+			"1|1|1|a|1",
+			"1|1|1|a|2",
+			"1|1|1|b|5",
+		)},
+	}
+
+	w := &Window{
+		Functions: []*WindowParams{
+			{Opcode: WindowRowNumber, Col: 0},
+			{Opcode: WindowRank, Col: 1},
+			{Opcode: WindowDenseRank, Col: 2},
+		},
+		PartitionBy: []*GroupByParams{{KeyCol: 3, WeightStringCol: -1, CollationEnv: collations.MySQL8()}},
+		OrderBy:     []*GroupByParams{{KeyCol: 4, WeightStringCol: -1, CollationEnv: collations.MySQL8()}},
+		Input:       fp,
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"rn|rk|dr|p|o",
+			"uint64|uint64|uint64|varbinary|int64",
+		),
+		"1|1|1|a|1",
+		"2|1|1|a|1",
+		"3|3|2|a|2",
+		"1|1|1|b|5",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowAggregationsUsePeers(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"s|c|p|o",
+				"int64|int64|varbinary|int64",
+			),
+			"1|1|a|1",
+			"2|1|a|1",
+			"3|1|a|2",
+			"4|1|b|1",
+		)},
+	}
+
+	w := &Window{
+		Functions: []*WindowParams{
+			{Opcode: WindowSum, Col: 0},
+			{Opcode: WindowCountStar, Col: 1},
+		},
+		PartitionBy: []*GroupByParams{{KeyCol: 2, WeightStringCol: -1, CollationEnv: collations.MySQL8()}},
+		OrderBy:     []*GroupByParams{{KeyCol: 3, WeightStringCol: -1, CollationEnv: collations.MySQL8()}},
+		Input:       fp,
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"s|c|p|o",
+			"decimal|int64|varbinary|int64",
+		),
+		"3|2|a|1",
+		"3|2|a|1",
+		"6|3|a|2",
+		"4|1|b|1",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowValueFunctions(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields(
+				"lag|lead|nt|fv|lv|nv|o",
+				"int64|int64|int64|int64|int64|int64|int64",
+			),
+			"10|10|1|10|10|10|1",
+			"20|20|1|20|20|20|2",
+			"30|30|1|30|30|30|2",
+			"40|40|1|40|40|40|3",
+		)},
+	}
+
+	w := &Window{
+		Functions: []*WindowParams{
+			{Opcode: WindowLag, Col: 0, Default: evalengine.NewLiteralInt(-1)},
+			{Opcode: WindowLead, Col: 1, N: evalengine.NewLiteralInt(2)},
+			{Opcode: WindowNtile, Col: 2, N: evalengine.NewLiteralInt(3)},
+			{Opcode: WindowFirstValue, Col: 3},
+			{Opcode: WindowLastValue, Col: 4},
+			{Opcode: WindowNthValue, Col: 5, N: evalengine.NewLiteralInt(2)},
+		},
+		OrderBy: []*GroupByParams{{KeyCol: 6, WeightStringCol: -1, CollationEnv: collations.MySQL8()}},
+		Input:   fp,
+	}
+
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+
+	wantResult := sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields(
+			"lag|lead|nt|fv|lv|nv|o",
+			"int64|int64|uint64|int64|int64|int64|int64",
+		),
+		"-1|30|1|10|10|null|1",
+		"10|40|1|10|30|20|2",
+		"20|null|2|10|30|20|2",
+		"30|null|3|10|40|20|3",
+	)
+	utils.MustMatch(t, wantResult, result)
+}
+
+func TestWindowStreamExecute(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"rn|p|weight_string(p)",
+		"int64|varchar|varbinary",
+	)
+	fp := &fakePrimitive{
+		// the fake primitive streams two rows at a time, so the first partition spans two results
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"1|a|A",
+			"1|a|A",
+			"1|a|A",
+			"1|b|B",
+			"1|c|C",
+		)},
+	}
+
+	w := &Window{
+		Functions:           []*WindowParams{{Opcode: WindowRowNumber, Col: 0}},
+		PartitionBy:         []*GroupByParams{{KeyCol: 1, WeightStringCol: 2, Type: evalengine.NewType(sqltypes.VarChar, collations.Unknown), CollationEnv: collations.MySQL8()}},
+		TruncateColumnCount: 2,
+		Input:               fp,
+	}
+
+	var results []*sqltypes.Result
+	err := w.TryStreamExecute(context.Background(), &noopVCursor{}, nil, true, func(qr *sqltypes.Result) error {
+		results = append(results, qr)
+		return nil
+	})
+	require.NoError(t, err)
+
+	wantResults := sqltypes.MakeTestStreamingResults(
+		sqltypes.MakeTestFields(
+			"rn|p",
+			"uint64|varchar",
+		),
+		"1|a",
+		"2|a",
+		"3|a",
+		"---",
+		"1|b",
+		"---",
+		"1|c",
+	)
+	utils.MustMatch(t, wantResults, results)
+}
+
+func TestWindowInvalidArgument(t *testing.T) {
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("nt", "int64"),
+			"1",
+		)},
+	}
+
+	w := &Window{
+		Functions: []*WindowParams{{Opcode: WindowNtile, Col: 0, N: evalengine.NewLiteralInt(0)}},
+		Input:     fp,
+	}
+
+	_, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	assert.EqualError(t, err, "VT03025: Incorrect arguments to ntile")
+}
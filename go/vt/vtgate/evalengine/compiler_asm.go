@@ -2294,6 +2294,33 @@ func (asm *assembler) Fn_JSON_KEYS(jp *json.Path) {
 	}
 }
 
+func (asm *assembler) Fn_JSON_MODIFY(fname string, t json.Transformation, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-args], env.vm.err = builtin_JSON_MODIFY(fname, t, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", fname, args)
+}
+
+func (asm *assembler) Fn_JSON_MERGE_PRESERVE(fname string, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-args], env.vm.err = builtin_JSON_MERGE_PRESERVE(fname, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN %s (SP-%d)...(SP-1)", fname, args)
+}
+
+func (asm *assembler) Fn_JSON_MERGE_PATCH(args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-args], env.vm.err = builtin_JSON_MERGE_PATCH(env.vm.stack[env.vm.sp-args : env.vm.sp])
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_MERGE_PATCH (SP-%d)...(SP-1)", args)
+}
+
 func (asm *assembler) Fn_JSON_OBJECT(args int) {
 	asm.adjustStack(-(args - 1))
 	asm.emit(func(env *ExpressionEnv) int {
@@ -2315,6 +2342,24 @@ func (asm *assembler) Fn_JSON_OBJECT(args int) {
 	}, "FN JSON_ARRAY (SP-%d)...(SP-1)", args)
 }
 
+func (asm *assembler) Fn_JSON_OVERLAPS() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-2], env.vm.err = builtin_JSON_OVERLAPS(env.vm.stack[env.vm.sp-2], env.vm.stack[env.vm.sp-1])
+		env.vm.sp--
+		return 1
+	}, "FN JSON_OVERLAPS (SP-2), (SP-1)")
+}
+
+func (asm *assembler) Fn_JSON_SEARCH(collate collations.ID, args int) {
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-args], env.vm.err = builtin_JSON_SEARCH(collate, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_SEARCH (SP-%d)...(SP-1)", args)
+}
+
 func (asm *assembler) Fn_JSON_UNQUOTE() {
 	asm.emit(func(env *ExpressionEnv) int {
 		j := env.vm.stack[env.vm.sp-1].(*evalJSON)
@@ -2331,6 +2376,25 @@ func (asm *assembler) Fn_JSON_UNQUOTE() {
 	}, "FN JSON_UNQUOTE (SP-1)")
 }
 
+func (asm *assembler) Fn_JSON_VALUE(call *builtinJSONValue) {
+	args := len(call.Arguments)
+	asm.adjustStack(-(args - 1))
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-args], env.vm.err = call.value(env, env.vm.stack[env.vm.sp-args:env.vm.sp])
+		env.vm.sp -= args - 1
+		return 1
+	}, "FN JSON_VALUE (SP-%d)...(SP-1)", args)
+}
+
+func (asm *assembler) Fn_MEMBER_OF() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-2], env.vm.err = builtin_MEMBER_OF(env.vm.stack[env.vm.sp-2], env.vm.stack[env.vm.sp-1])
+		env.vm.sp--
+		return 1
+	}, "FN MEMBER OF (SP-2), (SP-1)")
+}
+
 func (asm *assembler) Fn_CHAR_LENGTH() {
 	asm.emit(func(env *ExpressionEnv) int {
 		arg := env.vm.stack[env.vm.sp-1].(*evalBytes)
@@ -3796,6 +3860,71 @@ func (asm *assembler) Fn_DATE_FORMAT(col collations.TypedCollation) {
 	}, "FN DATE_FORMAT DATETIME(SP-2), VARBINARY(SP-1)")
 }
 
+func (asm *assembler) Fn_STR_TO_DATE(t sqltypes.Type, prec uint8) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		str := env.vm.stack[env.vm.sp-2].(*evalBytes)
+		format := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		env.vm.stack[env.vm.sp-2] = strToDate(env, str.string(), format.string(), t, prec)
+		env.vm.sp--
+		return 1
+	}, "FN STR_TO_DATE VARBINARY(SP-2), VARBINARY(SP-1)")
+}
+
+func (asm *assembler) Fn_TIMESTAMPDIFF(unit datetime.IntervalType) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		if env.vm.stack[env.vm.sp-2] == nil || env.vm.stack[env.vm.sp-1] == nil {
+			env.vm.stack[env.vm.sp-2] = nil
+			env.vm.sp--
+			return 1
+		}
+		from := env.vm.stack[env.vm.sp-2].(*evalTemporal)
+		to := env.vm.stack[env.vm.sp-1].(*evalTemporal)
+		env.vm.stack[env.vm.sp-2] = timestampDiff(unit, from, to)
+		env.vm.sp--
+		return 1
+	}, "FN TIMESTAMPDIFF DATETIME(SP-2), DATETIME(SP-1)")
+}
+
+func (asm *assembler) Fn_ADDTIME(sub bool, col collations.TypedCollation) {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		env.vm.stack[env.vm.sp-2] = addTime(env, env.vm.stack[env.vm.sp-2], env.vm.stack[env.vm.sp-1], sub, col)
+		env.vm.sp--
+		return 1
+	}, "FN ADDTIME (SP-2), (SP-1)")
+}
+
+func (asm *assembler) Fn_DATEDIFF() {
+	asm.adjustStack(-1)
+	asm.emit(func(env *ExpressionEnv) int {
+		if env.vm.stack[env.vm.sp-2] == nil || env.vm.stack[env.vm.sp-1] == nil {
+			env.vm.stack[env.vm.sp-2] = nil
+			env.vm.sp--
+			return 1
+		}
+		left := env.vm.stack[env.vm.sp-2].(*evalTemporal)
+		right := env.vm.stack[env.vm.sp-1].(*evalTemporal)
+		env.vm.stack[env.vm.sp-2] = dateDiff(left, right)
+		env.vm.sp--
+		return 1
+	}, "FN DATEDIFF DATE(SP-2), DATE(SP-1)")
+}
+
+func (asm *assembler) Fn_GET_FORMAT(typ string, col collations.TypedCollation) {
+	asm.emit(func(env *ExpressionEnv) int {
+		locale := env.vm.stack[env.vm.sp-1].(*evalBytes)
+		format, ok := datetime.GetFormat(typ, locale.string())
+		if !ok {
+			env.vm.stack[env.vm.sp-1] = nil
+			return 1
+		}
+		env.vm.stack[env.vm.sp-1] = env.vm.arena.newEvalText([]byte(format), col)
+		return 1
+	}, "FN GET_FORMAT VARBINARY(SP-1)")
+}
+
 func (asm *assembler) Fn_CONVERT_TZ() {
 	asm.adjustStack(-2)
 	asm.emit(func(env *ExpressionEnv) int {
@@ -801,6 +801,187 @@ func TestCompilerSingle(t *testing.T) {
 			expression: `GREATEST(JSON_OBJECT(), JSON_ARRAY())`,
 			result:     `VARCHAR("{}")`,
 		},
+		{
+			expression: `JSON_SET('{"a": 1, "b": [2, 3]}', '$.a[1]', 10, '$.b[5]', 'x', '$.c', column0)`,
+			values:     []sqltypes.Value{sqltypes.NewInt64(4)},
+			result:     `JSON("{\"a\": [1, 10], \"b\": [2, 3, \"x\"], \"c\": 4}")`,
+		},
+		{
+			expression: `JSON_INSERT(column0, '$.a', 10, '$.c', '[true]')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1}`)},
+			result:     `JSON("{\"a\": 1, \"c\": \"[true]\"}")`,
+		},
+		{
+			expression: `JSON_REPLACE(column0, '$.a', JSON_ARRAY(1, 2), '$.c', 3)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1}`)},
+			result:     `JSON("{\"a\": [1, 2]}")`,
+		},
+		{
+			expression: `JSON_REMOVE(column0, '$[1]', '$[0].b')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`[{"a": 1, "b": 2}, 3, 4]`)},
+			result:     `JSON("[{\"a\": 1}, 4]")`,
+		},
+		{
+			expression: `JSON_REMOVE(column0, '$')`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `NULL`,
+		},
+		{
+			expression: `JSON_MERGE_PRESERVE(column0, '{"a": 2}', '[3]')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1}`)},
+			result:     `JSON("[{\"a\": [1, 2]}, 3]")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH(column0, '{"a": null, "b": {"c": 2}}')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1, "b": {"d": 3}}`)},
+			result:     `JSON("{\"b\": {\"c\": 2, \"d\": 3}}")`,
+		},
+		{
+			expression: `JSON_MERGE_PATCH(column0, '{"a": 1}', '[2]')`,
+			values:     []sqltypes.Value{sqltypes.NULL},
+			result:     `JSON("[2]")`,
+		},
+		{
+			expression: `JSON_SEARCH(column0, 'all', 'ab%')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "abc", "b": ["xyz", "abd"], "c key": "abe"}`)},
+			result:     `JSON("[\"$.a\", \"$.b[1]\", \"$.\\\"c key\\\"\"]")`,
+		},
+		{
+			expression: `JSON_SEARCH(column0, 'one', 'ab%', NULL, '$.b')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "abc", "b": ["xyz", "abd"]}`)},
+			result:     `JSON("\"$.b[1]\"")`,
+		},
+		{
+			expression: `JSON_SEARCH(column0, 'one', 'a|%', '|')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`["abc", "a%"]`)},
+			result:     `JSON("\"$[1]\"")`,
+		},
+		{
+			expression: `JSON_OVERLAPS(column0, '[3, {"a": 1}]')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1}`)},
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_OVERLAPS(column0, '{"a": 2, "b": 1}')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1}`)},
+			result:     `INT64(0)`,
+		},
+		{
+			expression: `column0 MEMBER OF ('[1, "ab", [2]]')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`ab`)},
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `2.0 MEMBER OF (column0)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`[1, 2]`)},
+			result:     `INT64(1)`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "foo"}`)},
+			result:     `VARCHAR("foo")`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' RETURNING DECIMAL(4,2))`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1.5}`)},
+			result:     `DECIMAL(1.50)`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.b' RETURNING SIGNED DEFAULT '7' ON EMPTY)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": 1}`)},
+			result:     `INT64(7)`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' DEFAULT 'x' ON ERROR)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": [1]}`)},
+			result:     `VARCHAR("x")`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' RETURNING SIGNED)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "abc"}`)},
+			result:     `NULL`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' RETURNING SIGNED DEFAULT '-1' ON ERROR)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "abc"}`)},
+			result:     `INT64(-1)`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' RETURNING UNSIGNED DEFAULT '0' ON ERROR)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": -3}`)},
+			result:     `UINT64(0)`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' RETURNING DATE DEFAULT '2000-01-01' ON ERROR)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "foo"}`)},
+			result:     `DATE("2000-01-01")`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' RETURNING DATE)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "2023-10-24"}`)},
+			result:     `DATE("2023-10-24")`,
+		},
+		{
+			expression: `JSON_VALUE(column0, '$.a' RETURNING CHAR(2) DEFAULT 'x' ON ERROR)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`{"a": "abc"}`)},
+			result:     `VARCHAR("x")`,
+		},
+		{
+			expression: `STR_TO_DATE('01,5,2013', '%d,%m,%Y')`,
+			result:     `DATE("2013-05-01")`,
+		},
+		{
+			expression: `STR_TO_DATE(column0, '%h:%i:%s.%f %p')`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`09:30:17.5 PM`)},
+			result:     `TIME("21:30:17.500000")`,
+		},
+		{
+			expression: `STR_TO_DATE(column0, column1)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`May 1, 2013`), sqltypes.NewVarChar(`%M %d,%Y`)},
+			result:     `DATETIME("2013-05-01 00:00:00.000000")`,
+		},
+		{
+			expression: `STR_TO_DATE('04/31/2004', '%m/%d/%Y')`,
+			result:     `NULL`,
+		},
+		{
+			expression: `TIMESTAMPDIFF(MONTH, '2003-02-01', column0)`,
+			values:     []sqltypes.Value{sqltypes.NewDatetime(`2003-05-01 00:00:00`)},
+			result:     `INT64(3)`,
+		},
+		{
+			expression: `TIMESTAMPDIFF(MINUTE, '2003-02-01', '2003-05-01 12:05:55')`,
+			result:     `INT64(128885)`,
+		},
+		{
+			expression: `TIMESTAMPADD(MINUTE, 1, column0)`,
+			values:     []sqltypes.Value{sqltypes.NewDate(`2003-01-02`)},
+			result:     `DATETIME("2003-01-02 00:01:00")`,
+		},
+		{
+			expression: `ADDTIME('2007-12-31 23:59:59.999999', '1 1:1:1.000002')`,
+			result:     `VARCHAR("2008-01-02 01:01:01.000001")`,
+		},
+		{
+			expression: `SUBTIME(column0, '02:00:00.999998')`,
+			values:     []sqltypes.Value{sqltypes.NewTime(`01:00:00.999999`)},
+			result:     `TIME("-00:59:59.999999")`,
+		},
+		{
+			expression: `ADDTIME(column0, '2020-01-01 00:00:00')`,
+			values:     []sqltypes.Value{sqltypes.NewDatetime(`2020-01-01 00:00:00`)},
+			result:     `NULL`,
+		},
+		{
+			expression: `DATEDIFF('2010-11-30 23:59:59', column0)`,
+			values:     []sqltypes.Value{sqltypes.NewDate(`2010-12-31`)},
+			result:     `INT64(-31)`,
+		},
+		{
+			expression: `GET_FORMAT(DATE, column0)`,
+			values:     []sqltypes.Value{sqltypes.NewVarChar(`USA`)},
+			result:     `VARCHAR("%m.%d.%Y")`,
+		},
 	}
 
 	tz, _ := time.LoadLocation("Europe/Madrid")
@@ -80,7 +80,11 @@ func (c *ConvertExpr) eval(env *ExpressionEnv) (eval, error) {
 	if e == nil {
 		return nil, nil
 	}
+	return c.convert(env, e)
+}
 
+// convert converts the non-NULL value "e" to the type of the conversion.
+func (c *ConvertExpr) convert(env *ExpressionEnv, e eval) (eval, error) {
 	switch c.Type {
 	case "BINARY":
 		b := evalToBinary(e)
@@ -175,6 +179,37 @@ func (c *ConvertExpr) convertToCharType(tt sqltypes.Type) sqltypes.Type {
 	return sqltypes.VarChar
 }
 
+// convertedType returns the type of a value of type "arg" after the
+// conversion. It matches the types of compile.
+func (c *ConvertExpr) convertedType(arg ctype) ctype {
+	var convt ctype
+	switch c.Type {
+	case "BINARY":
+		convt = ctype{Type: c.convertToBinaryType(arg.Type), Col: collationBinary}
+	case "CHAR", "NCHAR":
+		convt = ctype{Type: c.convertToCharType(arg.Type), Col: collations.TypedCollation{Collation: c.Collation}}
+	case "DECIMAL":
+		m, d := c.decimalPrecision()
+		convt = ctype{Type: sqltypes.Decimal, Col: collationNumeric, Size: m, Scale: d}
+	case "DOUBLE", "REAL":
+		convt = ctype{Type: sqltypes.Float64, Col: collationNumeric}
+	case "SIGNED", "SIGNED INTEGER":
+		convt = ctype{Type: sqltypes.Int64, Col: collationNumeric}
+	case "UNSIGNED", "UNSIGNED INTEGER":
+		convt = ctype{Type: sqltypes.Uint64, Col: collationNumeric}
+	case "JSON":
+		convt = ctype{Type: sqltypes.TypeJSON, Col: collationJSON}
+	case "DATE":
+		convt = ctype{Type: sqltypes.Date, Col: collationBinary}
+	case "DATETIME":
+		convt = ctype{Type: sqltypes.Datetime, Size: int32(ptr.Unwrap(c.Length, 0)), Col: collationBinary}
+	case "TIME":
+		convt = ctype{Type: sqltypes.Time, Size: int32(ptr.Unwrap(c.Length, 0)), Col: collationBinary}
+	}
+	convt.Flag = arg.Flag | flagNullable
+	return convt
+}
+
 func (conv *ConvertExpr) compile(c *compiler) (ctype, error) {
 	arg, err := conv.Inner.compile(c)
 	if err != nil {
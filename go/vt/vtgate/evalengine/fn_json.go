@@ -17,7 +17,13 @@ limitations under the License.
 package evalengine
 
 import (
+	"unicode/utf8"
+
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/charset"
+	"vitess.io/vitess/go/mysql/collations/colldata"
+	"vitess.io/vitess/go/mysql/decimal"
+	"vitess.io/vitess/go/mysql/fastparse"
 	"vitess.io/vitess/go/mysql/json"
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/sqltypes"
@@ -57,6 +63,41 @@ type (
 	builtinJSONKeys struct {
 		CallExpr
 	}
+
+	builtinJSONModify struct {
+		CallExpr
+		Transform json.Transformation
+	}
+
+	builtinJSONMergePreserve struct {
+		CallExpr
+	}
+
+	builtinJSONMergePatch struct {
+		CallExpr
+	}
+
+	builtinJSONSearch struct {
+		CallExpr
+		collate collations.ID
+	}
+
+	builtinJSONOverlaps struct {
+		CallExpr
+	}
+
+	builtinMemberOf struct {
+		CallExpr
+	}
+
+	builtinJSONValue struct {
+		CallExpr
+		OnEmpty jsonOnResponse
+		OnError jsonOnResponse
+		// Returning is the conversion of the RETURNING clause, if any. Its
+		// Inner expression is not used.
+		Returning *ConvertExpr
+	}
 )
 
 var _ IR = (*builtinJSONExtract)(nil)
@@ -67,6 +108,13 @@ var _ IR = (*builtinJSONDepth)(nil)
 var _ IR = (*builtinJSONLength)(nil)
 var _ IR = (*builtinJSONContainsPath)(nil)
 var _ IR = (*builtinJSONKeys)(nil)
+var _ IR = (*builtinJSONModify)(nil)
+var _ IR = (*builtinJSONMergePreserve)(nil)
+var _ IR = (*builtinJSONMergePatch)(nil)
+var _ IR = (*builtinJSONSearch)(nil)
+var _ IR = (*builtinJSONOverlaps)(nil)
+var _ IR = (*builtinMemberOf)(nil)
+var _ IR = (*builtinJSONValue)(nil)
 
 var errInvalidPathForTransform = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
 
@@ -511,3 +559,573 @@ func (call *builtinJSONKeys) compile(c *compiler) (ctype, error) {
 	c.asm.Fn_JSON_KEYS(jp)
 	return ctype{Type: sqltypes.TypeJSON, Flag: flagNullable, Col: collationJSON}, nil
 }
+
+func (call *builtinJSONModify) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MODIFY(call.Method, call.Transform, args)
+}
+
+// builtin_JSON_MODIFY implements JSON_SET, JSON_INSERT, JSON_REPLACE and JSON_REMOVE.
+// The arguments are the document followed by pairs of path and value, or
+// by paths alone when removing.
+func builtin_JSON_MODIFY(fname string, t json.Transformation, args []eval) (eval, error) {
+	if args[0] == nil {
+		return nil, nil
+	}
+	doc, err := intoJSON(fname, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	step := 2
+	if t == json.Remove {
+		step = 1
+	}
+
+	paths := make([]*json.Path, 0, len(args)/step)
+	var values []*json.Value
+	for i := 1; i < len(args); i += step {
+		if args[i] == nil {
+			return nil, nil
+		}
+		jp, err := intoJSONPath(args[i])
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, jp)
+
+		if t != json.Remove {
+			value, err := argToJSON(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+	}
+
+	// The document may be a constant shared between evaluations, so
+	// it must never be transformed in place.
+	doc, err = json.ApplyTransform(t, doc.Clone(), paths, values)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// compileJSONValueArg compiles an argument that is converted into a JSON value
+// when the function is evaluated. Booleans must be converted right away,
+// because they cannot be told apart from integers on the stack.
+func (c *compiler) compileJSONValueArg(arg IR) error {
+	ct, err := arg.compile(c)
+	if err != nil {
+		return err
+	}
+	if ct.Flag&flagIsBoolean != 0 {
+		skip := c.compileNullCheckOffset(ct, 1)
+		if _, err := c.compileArgToJSON(ct, 1); err != nil {
+			return err
+		}
+		c.asm.jumpDestination(skip)
+	}
+	return nil
+}
+
+func (call *builtinJSONModify) compile(c *compiler) (ctype, error) {
+	for i, arg := range call.Arguments {
+		if call.Transform != json.Remove && i > 0 && i%2 == 0 {
+			if err := c.compileJSONValueArg(arg); err != nil {
+				return ctype{}, err
+			}
+			continue
+		}
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_MODIFY(call.Method, call.Transform, len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Col: collationJSON, Flag: flagNullable}, nil
+}
+
+func (call *builtinJSONMergePreserve) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PRESERVE(call.Method, args)
+}
+
+func builtin_JSON_MERGE_PRESERVE(fname string, args []eval) (eval, error) {
+	var merged *json.Value
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+		doc, err := intoJSON(fname, arg)
+		if err != nil {
+			return nil, err
+		}
+		if merged == nil {
+			merged = doc
+		} else {
+			merged = json.MergePreserve(merged, doc)
+		}
+	}
+	return merged, nil
+}
+
+func (call *builtinJSONMergePreserve) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_MERGE_PRESERVE(call.Method, len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Col: collationJSON, Flag: flagNullable}, nil
+}
+
+func (call *builtinJSONMergePatch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_MERGE_PATCH(args)
+}
+
+func builtin_JSON_MERGE_PATCH(args []eval) (eval, error) {
+	// A NULL argument makes the result NULL, unless a later
+	// argument that is not an object replaces it completely.
+	var merged *json.Value
+	for i, arg := range args {
+		if arg == nil {
+			merged = nil
+			continue
+		}
+		doc, err := intoJSON("JSON_MERGE_PATCH", arg)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := doc.Object(); i == 0 || !ok {
+			merged = doc
+		} else if merged != nil {
+			merged = json.MergePatch(merged, doc)
+		}
+	}
+	if merged == nil {
+		return nil, nil
+	}
+	return merged, nil
+}
+
+func (call *builtinJSONMergePatch) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_MERGE_PATCH(len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Col: collationJSON, Flag: flagNullable}, nil
+}
+
+var errJSONSearchEscape = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Incorrect arguments to ESCAPE")
+
+func (call *builtinJSONSearch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_SEARCH(call.collate, args)
+}
+
+// builtin_JSON_SEARCH implements JSON_SEARCH. The arguments are the document,
+// 'one' or 'all', the search string, the escape character (which may be NULL)
+// and the paths the search is restricted to, if any.
+func builtin_JSON_SEARCH(collate collations.ID, args []eval) (eval, error) {
+	for i, arg := range args {
+		if arg == nil && i != 3 {
+			return nil, nil
+		}
+	}
+
+	doc, err := intoJSON("JSON_SEARCH", args[0])
+	if err != nil {
+		return nil, err
+	}
+	match, err := intoOneOrAll("JSON_SEARCH", evalToBinary(args[1]).string())
+	if err != nil {
+		return nil, err
+	}
+
+	var escape rune
+	if args[3] != nil {
+		esc := evalToBinary(args[3]).bytes
+		if len(esc) > 0 {
+			var size int
+			escape, size = utf8.DecodeRune(esc)
+			if size != len(esc) {
+				return nil, errJSONSearchEscape
+			}
+		}
+	}
+
+	// JSON strings are always utf8mb4, so the search string is matched
+	// with its own collation only when it is compatible with them.
+	col := collate
+	if b, ok := args[2].(*evalBytes); ok && sqltypes.IsText(b.SQLType()) {
+		col = b.col.Collation
+	}
+	if !isEncodingJSONSafe(col) {
+		col = collations.CollationUtf8mb4ID
+	}
+	search, err := evalToVarchar(args[2], col, true)
+	if err != nil {
+		return nil, err
+	}
+	wc := colldata.Lookup(col).Wildcard(search.bytes, 0, 0, escape)
+
+	var found []*json.Value
+	find := func(path string, value *json.Value) bool {
+		if match == jsonMatchOne && len(found) > 0 {
+			return false
+		}
+		if s, ok := value.StringBytes(); ok && wc.Match(s) {
+			found = append(found, json.NewString(path))
+		}
+		return true
+	}
+
+	if len(args) == 4 {
+		doc.Walk("$", find)
+	} else {
+		roots := make(map[*json.Value]struct{})
+		for _, p := range args[4:] {
+			jp, err := intoJSONPath(p)
+			if err != nil {
+				return nil, err
+			}
+			jp.Match(doc, true, func(value *json.Value) {
+				roots[value] = struct{}{}
+			})
+		}
+		doc.Walk("$", func(path string, value *json.Value) bool {
+			if _, ok := roots[value]; ok {
+				value.Walk(path, find)
+				return false
+			}
+			return true
+		})
+	}
+
+	switch {
+	case len(found) == 0:
+		return nil, nil
+	case len(found) == 1:
+		return found[0], nil
+	default:
+		return json.NewArray(found), nil
+	}
+}
+
+func (call *builtinJSONSearch) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_SEARCH(call.collate, len(call.Arguments))
+	return ctype{Type: sqltypes.TypeJSON, Col: collationJSON, Flag: flagNullable}, nil
+}
+
+func (call *builtinJSONOverlaps) eval(env *ExpressionEnv) (eval, error) {
+	left, right, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_JSON_OVERLAPS(left, right)
+}
+
+func builtin_JSON_OVERLAPS(left, right eval) (eval, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	l, err := intoJSON("JSON_OVERLAPS", left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := intoJSON("JSON_OVERLAPS", right)
+	if err != nil {
+		return nil, err
+	}
+	overlaps, err := jsonOverlaps(l, r)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(overlaps), nil
+}
+
+// jsonOverlaps returns whether two documents have any array element or
+// object key-value pair in common. A scalar or an object compared with an
+// array is handled as if it was an array with a single element.
+func jsonOverlaps(l, r *json.Value) (bool, error) {
+	la, lok := l.Array()
+	ra, rok := r.Array()
+	if lok || rok {
+		if !lok {
+			la = []*json.Value{l}
+		}
+		if !rok {
+			ra = []*json.Value{r}
+		}
+		for _, a := range la {
+			for _, b := range ra {
+				if eq, err := jsonEqual(a, b); eq || err != nil {
+					return eq, err
+				}
+			}
+		}
+		return false, nil
+	}
+
+	lo, lok := l.Object()
+	ro, rok := r.Object()
+	if lok && rok {
+		var overlaps bool
+		var err error
+		lo.Visit(func(key string, value *json.Value) {
+			if overlaps || err != nil {
+				return
+			}
+			if other := ro.Get(key); other != nil {
+				overlaps, err = jsonEqual(value, other)
+			}
+		})
+		return overlaps, err
+	}
+	return jsonEqual(l, r)
+}
+
+func jsonEqual(l, r *json.Value) (bool, error) {
+	cmp, err := compareJSONValue(l, r)
+	return cmp == 0, err
+}
+
+func (call *builtinJSONOverlaps) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_OVERLAPS()
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | flagNullable}, nil
+}
+
+func (call *builtinMemberOf) eval(env *ExpressionEnv) (eval, error) {
+	value, ary, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	return builtin_MEMBER_OF(value, ary)
+}
+
+func builtin_MEMBER_OF(value, ary eval) (eval, error) {
+	if value == nil || ary == nil {
+		return nil, nil
+	}
+	v, err := argToJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := intoJSON("MEMBER OF", ary)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, ok := doc.Array()
+	if !ok {
+		elements = []*json.Value{doc}
+	}
+	for _, e := range elements {
+		if eq, err := jsonEqual(v, e); eq || err != nil {
+			return newEvalBool(eq), err
+		}
+	}
+	return newEvalBool(false), nil
+}
+
+func (call *builtinMemberOf) compile(c *compiler) (ctype, error) {
+	if err := c.compileJSONValueArg(call.Arguments[0]); err != nil {
+		return ctype{}, err
+	}
+	if _, err := call.Arguments[1].compile(c); err != nil {
+		return ctype{}, err
+	}
+	c.asm.Fn_MEMBER_OF()
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagIsBoolean | flagNullable}, nil
+}
+
+// jsonOnResponse is the behavior of JSON_VALUE when the path does not match
+// any value (ON EMPTY) or when it cannot match a single scalar (ON ERROR).
+type jsonOnResponse int8
+
+const (
+	jsonOnResponseNull jsonOnResponse = iota
+	jsonOnResponseError
+	jsonOnResponseDefault
+)
+
+var (
+	errJSONValueMissing   = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "No value was found by 'json_value' on the specified path.")
+	errJSONValueMultiple  = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "More than one value was found by 'json_value' on the specified path.")
+	errJSONValueNotScalar = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Can't store an array or an object in the scalar column 'json_value'.")
+)
+
+func (call *builtinJSONValue) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	return call.value(env, args)
+}
+
+// value extracts the scalar at the given path and converts it to the
+// RETURNING type, if any. Without RETURNING, it is an utf8mb4 string.
+// The arguments are the document and the path, followed by the DEFAULT
+// values of the ON EMPTY and ON ERROR clauses, when present.
+func (call *builtinJSONValue) value(env *ExpressionEnv, args []eval) (eval, error) {
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	jp, err := intoJSONPath(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var onEmpty, onError eval
+	defaults := args[2:]
+	if call.OnEmpty == jsonOnResponseDefault {
+		onEmpty, defaults = defaults[0], defaults[1:]
+	}
+	if call.OnError == jsonOnResponseDefault {
+		onError = defaults[0]
+	}
+
+	var matches []*json.Value
+	jp.Match(doc, true, func(value *json.Value) {
+		matches = append(matches, value)
+	})
+
+	switch {
+	case len(matches) == 0:
+		return call.respond(env, call.OnEmpty, onEmpty, errJSONValueMissing)
+	case len(matches) > 1:
+		return call.respond(env, call.OnError, onError, errJSONValueMultiple)
+	}
+
+	match := matches[0]
+	switch match.Type() {
+	case json.TypeObject, json.TypeArray:
+		return call.respond(env, call.OnError, onError, errJSONValueNotScalar)
+	case json.TypeNull:
+		return nil, nil
+	}
+	var value *evalBytes
+	if b, ok := match.StringBytes(); ok {
+		value = newEvalRaw(sqltypes.VarChar, b, collationJSON)
+	} else {
+		value = newEvalRaw(sqltypes.VarChar, match.MarshalTo(nil), collationJSON)
+	}
+	if call.Returning == nil {
+		return value, nil
+	}
+	converted, err := call.convert(env, value)
+	if err != nil {
+		return call.respond(env, call.OnError, onError, err)
+	}
+	return converted, nil
+}
+
+// convert converts the extracted value to the RETURNING type. Unlike CAST(),
+// it fails if the value is not valid for the type or would be truncated, so
+// that the ON ERROR clause applies.
+func (call *builtinJSONValue) convert(env *ExpressionEnv, value *evalBytes) (eval, error) {
+	ret := call.Returning
+	valid := true
+	switch ret.Type {
+	case "SIGNED", "SIGNED INTEGER", "DOUBLE", "REAL":
+		_, err := fastparse.ParseFloat64(value.string())
+		valid = err == nil
+	case "UNSIGNED", "UNSIGNED INTEGER":
+		f, err := fastparse.ParseFloat64(value.string())
+		valid = err == nil && f >= 0
+	case "DECIMAL":
+		_, err := decimal.NewFromMySQL(value.bytes)
+		valid = err == nil
+	case "CHAR", "NCHAR":
+		valid = ret.Length == nil || charset.Length(colldata.Lookup(value.col.Collation).Charset(), value.bytes) <= *ret.Length
+	}
+	if !valid {
+		return nil, errJSONValueCast(ret)
+	}
+	converted, err := ret.convert(env, value)
+	if err != nil {
+		return nil, err
+	}
+	if converted == nil {
+		// The value is not a valid date or time, or not valid in the charset.
+		return nil, errJSONValueCast(ret)
+	}
+	return converted, nil
+}
+
+// respond returns the result of an ON EMPTY or ON ERROR clause. A DEFAULT
+// value is converted to the RETURNING type, if any.
+func (call *builtinJSONValue) respond(env *ExpressionEnv, response jsonOnResponse, def eval, err error) (eval, error) {
+	if call.Returning == nil || response != jsonOnResponseDefault || def == nil {
+		return jsonRespond(response, def, err)
+	}
+	return call.Returning.convert(env, def)
+}
+
+func errJSONValueCast(ret *ConvertExpr) error {
+	typ := ret.Type
+	switch typ {
+	case "SIGNED", "SIGNED INTEGER", "UNSIGNED", "UNSIGNED INTEGER":
+		typ = "INTEGER"
+	case "NCHAR":
+		typ = "CHAR"
+	}
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid JSON value for CAST to %s from column json_value at row 1", typ)
+}
+
+func jsonRespond(response jsonOnResponse, def eval, err error) (eval, error) {
+	switch response {
+	case jsonOnResponseError:
+		return nil, err
+	case jsonOnResponseDefault:
+		if def == nil {
+			return nil, nil
+		}
+		return evalToVarchar(def, collationJSON.Collation, true)
+	default:
+		return nil, nil
+	}
+}
+
+func (call *builtinJSONValue) compile(c *compiler) (ctype, error) {
+	for _, arg := range call.Arguments {
+		if _, err := arg.compile(c); err != nil {
+			return ctype{}, err
+		}
+	}
+	c.asm.Fn_JSON_VALUE(call)
+	if call.Returning == nil {
+		return ctype{Type: sqltypes.VarChar, Col: collationJSON, Flag: flagNullable}, nil
+	}
+	return call.Returning.convertedType(ctype{Type: sqltypes.VarChar, Col: collationJSON, Flag: flagNullable}), nil
+}
@@ -191,6 +191,33 @@ type (
 		unit    datetime.IntervalType
 		collate collations.ID
 	}
+
+	builtinStrToDate struct {
+		CallExpr
+		typ  sqltypes.Type
+		prec uint8
+	}
+
+	builtinTimestampDiff struct {
+		CallExpr
+		unit datetime.IntervalType
+	}
+
+	builtinAddTime struct {
+		CallExpr
+		sub     bool
+		collate collations.ID
+	}
+
+	builtinDateDiff struct {
+		CallExpr
+	}
+
+	builtinGetFormat struct {
+		CallExpr
+		typ     string
+		collate collations.ID
+	}
 )
 
 var _ IR = (*builtinNow)(nil)
@@ -227,6 +254,12 @@ var _ IR = (*builtinYear)(nil)
 var _ IR = (*builtinYearWeek)(nil)
 var _ IR = (*builtinPeriodAdd)(nil)
 var _ IR = (*builtinPeriodDiff)(nil)
+var _ IR = (*builtinDateMath)(nil)
+var _ IR = (*builtinStrToDate)(nil)
+var _ IR = (*builtinTimestampDiff)(nil)
+var _ IR = (*builtinAddTime)(nil)
+var _ IR = (*builtinDateDiff)(nil)
+var _ IR = (*builtinGetFormat)(nil)
 
 func (call *builtinNow) eval(env *ExpressionEnv) (eval, error) {
 	now := env.time(call.utc)
@@ -2149,3 +2182,332 @@ func (call *builtinDateMath) compile(c *compiler) (ctype, error) {
 	}
 	return ret, nil
 }
+
+func newBuiltinStrToDate(call CallExpr) *builtinStrToDate {
+	// When the format is known ahead of time, the type of the result depends
+	// on the parts it contains; otherwise it is always a DATETIME(6).
+	b := &builtinStrToDate{CallExpr: call, typ: sqltypes.Datetime, prec: datetime.DefaultPrecision}
+	lit, ok := call.Arguments[1].(*Literal)
+	if !ok || lit.inner == nil {
+		return b
+	}
+
+	parts := datetime.ParseStrToDateParts(evalToBinary(lit.inner).string())
+	switch {
+	case parts&datetime.StrToDateDate != 0 && parts&datetime.StrToDateTime != 0:
+		b.typ = sqltypes.Datetime
+	case parts&datetime.StrToDateTime != 0:
+		b.typ = sqltypes.Time
+	default:
+		b.typ = sqltypes.Date
+	}
+	if parts&datetime.StrToDateFrac == 0 {
+		b.prec = 0
+	}
+	return b
+}
+
+func strToDate(env *ExpressionEnv, str, format string, t sqltypes.Type, prec uint8) eval {
+	dt, ok := datetime.StrToDate(str, format)
+	if !ok {
+		return nil
+	}
+
+	if t == sqltypes.Time {
+		return newEvalTime(dt.Time, int(prec))
+	}
+
+	switch {
+	case dt.Date.IsZero():
+		if !env.sqlmode.AllowZeroDate() {
+			return nil
+		}
+	case dt.Date.Month() == 0 || dt.Date.Day() == 0:
+		if !env.sqlmode.AllowZeroInDate() {
+			return nil
+		}
+	}
+
+	if t == sqltypes.Date {
+		return newEvalDate(dt.Date, true)
+	}
+	return newEvalDateTime(dt, int(prec), true)
+}
+
+func (call *builtinStrToDate) eval(env *ExpressionEnv) (eval, error) {
+	str, format, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if str == nil || format == nil {
+		return nil, nil
+	}
+	return strToDate(env, evalToBinary(str).string(), evalToBinary(format).string(), call.typ, call.prec), nil
+}
+
+func (call *builtinStrToDate) compile(c *compiler) (ctype, error) {
+	str, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(str)
+
+	switch str.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	format, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(format)
+
+	switch format.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	c.asm.Fn_STR_TO_DATE(call.typ, call.prec)
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: call.typ, Col: collationBinary, Flag: flagNullable}, nil
+}
+
+func timestampDiff(unit datetime.IntervalType, from, to *evalTemporal) eval {
+	if from.dt.Date.IsZero() || to.dt.Date.IsZero() {
+		return nil
+	}
+	return newEvalInt64(datetime.TimestampDiff(unit, from.dt, to.dt))
+}
+
+func (call *builtinTimestampDiff) eval(env *ExpressionEnv) (eval, error) {
+	from, to, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil || to == nil {
+		return nil, nil
+	}
+
+	f := evalToDateTime(from, datetime.DefaultPrecision, env.now, false)
+	t := evalToDateTime(to, datetime.DefaultPrecision, env.now, false)
+	if f == nil || t == nil {
+		return nil, nil
+	}
+	return timestampDiff(call.unit, f, t), nil
+}
+
+func (call *builtinTimestampDiff) compile(c *compiler) (ctype, error) {
+	from, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(from)
+	c.asm.Convert_xDT(1, datetime.DefaultPrecision, false)
+
+	to, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(to)
+	c.asm.Convert_xDT(1, datetime.DefaultPrecision, false)
+
+	c.asm.Fn_TIMESTAMPDIFF(call.unit)
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagNullable}, nil
+}
+
+// addTimeArg converts the second argument of ADDTIME and SUBTIME into a TIME.
+// Arguments that contain a date are not valid times and yield NULL.
+func addTimeArg(e eval) *evalTemporal {
+	switch e := e.(type) {
+	case *evalTemporal:
+		if e.t != sqltypes.Time {
+			return nil
+		}
+		return e
+	case *evalBytes:
+		if _, _, ok := datetime.ParseDateTime(e.string(), -1); ok {
+			return nil
+		}
+	}
+	return evalToTime(e, -1)
+}
+
+func addTime(env *ExpressionEnv, left, right eval, sub bool, col collations.TypedCollation) eval {
+	t2 := addTimeArg(right)
+	if t2 == nil {
+		return nil
+	}
+
+	t1, isTemporal := left.(*evalTemporal)
+	if !isTemporal {
+		if t1 = evalToTemporal(left, true); t1 == nil {
+			return nil
+		}
+	}
+	prec := max(t1.prec, t2.prec)
+
+	var r *evalTemporal
+	if t1.t == sqltypes.Time {
+		r = newEvalTime(t1.dt.Time.AddTime(t2.dt.Time, sub), int(prec))
+	} else {
+		dt, ok := t1.toDateTime(int(prec), env.now).dt.AddTime(t2.dt.Time, sub)
+		if !ok {
+			return nil
+		}
+		r = newEvalDateTime(dt, int(prec), true)
+	}
+
+	if isTemporal {
+		return r
+	}
+
+	// Non-temporal arguments produce a string, which only has fractional
+	// seconds when they are not zero.
+	if r.dt.Time.Nanosecond() == 0 {
+		r.prec = 0
+	} else {
+		r.prec = datetime.DefaultPrecision
+	}
+	return newEvalText(r.ToRawBytes(), col)
+}
+
+func (call *builtinAddTime) eval(env *ExpressionEnv) (eval, error) {
+	left, right, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	return addTime(env, left, right, call.sub, typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinAddTime) compile(c *compiler) (ctype, error) {
+	left, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(left)
+
+	right, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(right)
+
+	ret := ctype{Col: collationBinary, Flag: flagNullable}
+	switch left.Type {
+	case sqltypes.Time:
+		ret.Type = sqltypes.Time
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+		ret.Type = sqltypes.Datetime
+	default:
+		ret.Type = sqltypes.VarChar
+		ret.Col = typedCoercionCollation(sqltypes.VarChar, c.collation)
+	}
+
+	c.asm.Fn_ADDTIME(call.sub, ret.Col)
+	c.asm.jumpDestination(skip1, skip2)
+	return ret, nil
+}
+
+func dateDiff(left, right *evalTemporal) eval {
+	if left.dt.Date.IsZero() || right.dt.Date.IsZero() {
+		return nil
+	}
+	l := datetime.MysqlDayNumber(left.dt.Date.Year(), left.dt.Date.Month(), left.dt.Date.Day())
+	r := datetime.MysqlDayNumber(right.dt.Date.Year(), right.dt.Date.Month(), right.dt.Date.Day())
+	return newEvalInt64(int64(l - r))
+}
+
+func (call *builtinDateDiff) eval(env *ExpressionEnv) (eval, error) {
+	left, right, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	l := evalToDate(left, env.now, false)
+	r := evalToDate(right, env.now, false)
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	return dateDiff(l, r), nil
+}
+
+func (call *builtinDateDiff) compile(c *compiler) (ctype, error) {
+	left, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip1 := c.compileNullCheck1(left)
+
+	switch left.Type {
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+	default:
+		c.asm.Convert_xD(1, false)
+	}
+
+	right, err := call.Arguments[1].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip2 := c.compileNullCheck1r(right)
+
+	switch right.Type {
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+	default:
+		c.asm.Convert_xD(1, false)
+	}
+
+	c.asm.Fn_DATEDIFF()
+	c.asm.jumpDestination(skip1, skip2)
+	return ctype{Type: sqltypes.Int64, Col: collationNumeric, Flag: flagNullable}, nil
+}
+
+func (call *builtinGetFormat) eval(env *ExpressionEnv) (eval, error) {
+	locale, err := call.arg1(env)
+	if err != nil || locale == nil {
+		return nil, err
+	}
+
+	format, ok := datetime.GetFormat(call.typ, evalToBinary(locale).string())
+	if !ok {
+		return nil, nil
+	}
+	return newEvalText([]byte(format), typedCoercionCollation(sqltypes.VarChar, call.collate)), nil
+}
+
+func (call *builtinGetFormat) compile(c *compiler) (ctype, error) {
+	locale, err := call.Arguments[0].compile(c)
+	if err != nil {
+		return ctype{}, err
+	}
+
+	skip := c.compileNullCheck1(locale)
+
+	switch locale.Type {
+	case sqltypes.VarChar, sqltypes.VarBinary:
+	default:
+		c.asm.Convert_xb(1, sqltypes.VarBinary, nil)
+	}
+
+	col := typedCoercionCollation(sqltypes.VarChar, c.collation)
+	c.asm.Fn_GET_FORMAT(call.typ, col)
+	c.asm.jumpDestination(skip)
+	return ctype{Type: sqltypes.VarChar, Col: col, Flag: flagNullable}, nil
+}
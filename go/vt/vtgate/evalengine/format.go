@@ -41,6 +41,8 @@ func precedenceFor(in IR) sqlparser.Precendence {
 		return sqlparser.P11
 	case *IsExpr:
 		return sqlparser.P11
+	case *builtinMemberOf:
+		return sqlparser.P11
 	case *BitwiseExpr:
 		switch node.Op.(type) {
 		case opBitOr:
@@ -278,6 +280,71 @@ func (c *builtinWeightString) format(buf *sqlparser.TrackedBuffer) {
 	buf.WriteByte(')')
 }
 
+func (c *builtinTimestampDiff) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteLiteral("timestampdiff(")
+	buf.WriteLiteral(c.unit.ToString())
+	buf.WriteString(", ")
+	formatExpr(buf, c, c.Arguments[0], true)
+	buf.WriteString(", ")
+	formatExpr(buf, c, c.Arguments[1], true)
+	buf.WriteByte(')')
+}
+
+func (c *builtinGetFormat) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteLiteral("get_format(")
+	buf.WriteLiteral(c.typ)
+	buf.WriteString(", ")
+	formatExpr(buf, c, c.Arguments[0], true)
+	buf.WriteByte(')')
+}
+
+func (c *builtinMemberOf) format(buf *sqlparser.TrackedBuffer) {
+	formatExpr(buf, c, c.Arguments[0], true)
+	buf.WriteLiteral(" member of (")
+	formatExpr(buf, c, c.Arguments[1], false)
+	buf.WriteByte(')')
+}
+
+func (c *builtinJSONValue) format(buf *sqlparser.TrackedBuffer) {
+	buf.WriteLiteral("json_value(")
+	formatExpr(buf, c, c.Arguments[0], true)
+	buf.WriteString(", ")
+	formatExpr(buf, c, c.Arguments[1], true)
+	if r := c.Returning; r != nil {
+		switch {
+		case r.Length != nil && r.Scale != nil:
+			_, _ = fmt.Fprintf(buf, " returning %s(%d,%d)", r.Type, *r.Length, *r.Scale)
+		case r.Length != nil:
+			_, _ = fmt.Fprintf(buf, " returning %s(%d)", r.Type, *r.Length)
+		default:
+			_, _ = fmt.Fprintf(buf, " returning %s", r.Type)
+		}
+		if r.Collation != collations.Unknown {
+			buf.WriteLiteral(" character set ")
+			buf.WriteString(r.CollationEnv.LookupName(r.Collation))
+		}
+	}
+
+	defaults := c.Arguments[2:]
+	for _, clause := range []struct {
+		response jsonOnResponse
+		on       string
+	}{{c.OnEmpty, " on empty"}, {c.OnError, " on error"}} {
+		switch clause.response {
+		case jsonOnResponseNull:
+			continue
+		case jsonOnResponseError:
+			buf.WriteLiteral(" error")
+		case jsonOnResponseDefault:
+			buf.WriteLiteral(" default ")
+			formatExpr(buf, c, defaults[0], true)
+			defaults = defaults[1:]
+		}
+		buf.WriteLiteral(clause.on)
+	}
+	buf.WriteByte(')')
+}
+
 func (n *NegateExpr) format(buf *sqlparser.TrackedBuffer) {
 	buf.WriteByte('-')
 	formatExpr(buf, n, n.Inner, true)
@@ -33,6 +33,11 @@ var Cases = []TestCase{
 	{Run: JSONPathOperations},
 	{Run: JSONArray},
 	{Run: JSONObject},
+	{Run: JSONModification},
+	{Run: JSONMerge},
+	{Run: JSONSearch},
+	{Run: JSONOverlaps},
+	{Run: JSONValue},
 	{Run: CharsetConversionOperators},
 	{Run: CaseExprWithPredicate},
 	{Run: CaseExprWithValue},
@@ -170,6 +175,11 @@ var Cases = []TestCase{
 	{Run: FnUUID},
 	{Run: FnUUIDToBin},
 	{Run: DateMath},
+	{Run: FnStrToDate},
+	{Run: FnTimestampDiff},
+	{Run: FnAddTime},
+	{Run: FnDateDiff},
+	{Run: FnGetFormat},
 	{Run: RegexpLike},
 	{Run: RegexpInstr},
 	{Run: RegexpSubstr},
@@ -195,6 +205,76 @@ func JSONPathOperations(yield Query) {
 	}
 }
 
+func JSONModification(yield Query) {
+	for _, obj := range inputJSONObjects {
+		for _, path1 := range inputJSONPaths {
+			yield(fmt.Sprintf("JSON_REMOVE('%s', '%s')", obj, path1), nil, false)
+			for _, val := range inputJSONPrimitives {
+				yield(fmt.Sprintf("JSON_SET('%s', '%s', %s)", obj, path1, val), nil, false)
+				yield(fmt.Sprintf("JSON_INSERT('%s', '%s', %s)", obj, path1, val), nil, false)
+				yield(fmt.Sprintf("JSON_REPLACE('%s', '%s', %s)", obj, path1, val), nil, false)
+			}
+			for _, path2 := range inputJSONPaths {
+				yield(fmt.Sprintf("JSON_SET('%s', '%s', 1, '%s', 2)", obj, path1, path2), nil, false)
+				yield(fmt.Sprintf("JSON_REMOVE('%s', '%s', '%s')", obj, path1, path2), nil, false)
+			}
+		}
+	}
+}
+
+func JSONMerge(yield Query) {
+	docs := append([]string{`NULL`}, inputJSONObjects...)
+	for _, fn := range []string{"JSON_MERGE", "JSON_MERGE_PRESERVE", "JSON_MERGE_PATCH"} {
+		for _, a := range docs {
+			for _, b := range docs {
+				yield(fmt.Sprintf("%s('%s', '%s')", fn, a, b), nil, false)
+				yield(fmt.Sprintf("%s('%s', '%s', '%s')", fn, a, b, a), nil, false)
+			}
+		}
+	}
+}
+
+func JSONSearch(yield Query) {
+	for _, obj := range inputJSONObjects {
+		for _, search := range []string{`'foo'`, `'f%'`, `'%2%'`, `'1_3'`, `'%'`, `NULL`} {
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'one', %s)", obj, search), nil, false)
+			yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s)", obj, search), nil, false)
+			for _, path := range inputJSONPaths {
+				yield(fmt.Sprintf("JSON_SEARCH('%s', 'all', %s, NULL, '%s')", obj, search, path), nil, false)
+			}
+		}
+	}
+}
+
+func JSONOverlaps(yield Query) {
+	for _, a := range inputJSONObjects {
+		for _, b := range inputJSONObjects {
+			yield(fmt.Sprintf("JSON_OVERLAPS('%s', '%s')", a, b), nil, false)
+		}
+		for _, val := range inputJSONPrimitives {
+			yield(fmt.Sprintf("JSON_OVERLAPS('%s', CAST(%s AS JSON))", a, val), nil, false)
+			yield(fmt.Sprintf("%s MEMBER OF ('%s')", val, a), nil, false)
+		}
+	}
+}
+
+func JSONValue(yield Query) {
+	for _, obj := range inputJSONObjects {
+		for _, path := range inputJSONPaths {
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s')", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' RETURNING SIGNED)", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' RETURNING CHAR(2))", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' DEFAULT 'empty' ON EMPTY DEFAULT 'error' ON ERROR)", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' ERROR ON EMPTY ERROR ON ERROR)", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' RETURNING SIGNED DEFAULT -1 ON ERROR)", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' RETURNING UNSIGNED ERROR ON ERROR)", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' RETURNING DECIMAL(4,2) DEFAULT 0 ON EMPTY DEFAULT 1 ON ERROR)", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' RETURNING DATE NULL ON ERROR)", obj, path), nil, false)
+			yield(fmt.Sprintf("JSON_VALUE('%s', '%s' RETURNING CHAR(2) DEFAULT 'error' ON ERROR)", obj, path), nil, false)
+		}
+	}
+}
+
 func JSONArray(yield Query) {
 	for _, a := range inputJSONPrimitives {
 		yield(fmt.Sprintf("JSON_ARRAY(%s)", a), nil, false)
@@ -2452,6 +2532,163 @@ func DateMath(yield Query) {
 	}
 }
 
+func FnStrToDate(yield Query) {
+	mysqlDocSamples := []string{
+		`STR_TO_DATE('01,5,2013','%d,%m,%Y')`,
+		`STR_TO_DATE('May 1, 2013','%M %d,%Y')`,
+		`STR_TO_DATE('a09:30:17','a%h:%i:%s')`,
+		`STR_TO_DATE('a09:30:17','%h:%i:%s')`,
+		`STR_TO_DATE('09:30:17a','%h:%i:%s')`,
+		`STR_TO_DATE('abc','abc')`,
+		`STR_TO_DATE('9','%m')`,
+		`STR_TO_DATE('9','%s')`,
+		`STR_TO_DATE('00/00/0000', '%m/%d/%Y')`,
+		`STR_TO_DATE('04/31/2004', '%m/%d/%Y')`,
+		`STR_TO_DATE('200442 Monday', '%X%V %W')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+
+	values := []string{
+		`'2023-09-03 07:08:09.123456'`,
+		`'03/09/23 7:08:09 PM'`,
+		`'Sep 3rd, 2023'`,
+		`'20230903'`,
+		`20230903`,
+		`'15:30'`,
+		`''`,
+		`NULL`,
+	}
+	formats := []string{
+		`'%Y-%m-%d %H:%i:%s.%f'`,
+		`'%Y-%m-%d'`,
+		`'%d/%m/%y %r'`,
+		`'%d/%m/%y %h:%i:%s %p'`,
+		`'%b %D, %Y'`,
+		`'%Y%m%d'`,
+		`'%H:%i'`,
+		`'%T'`,
+		`'%Y-%j'`,
+		`NULL`,
+	}
+
+	for _, v := range values {
+		for _, f := range formats {
+			yield(fmt.Sprintf("STR_TO_DATE(%s, %s)", v, f), nil, false)
+		}
+	}
+}
+
+func FnTimestampDiff(yield Query) {
+	mysqlDocSamples := []string{
+		`TIMESTAMPDIFF(MONTH,'2003-02-01','2003-05-01')`,
+		`TIMESTAMPDIFF(YEAR,'2002-05-01','2001-01-01')`,
+		`TIMESTAMPDIFF(MINUTE,'2003-02-01','2003-05-01 12:05:55')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+
+	dates := []string{
+		`DATE'2018-05-01'`,
+		`TIMESTAMP'2020-12-31 23:59:59'`,
+		`TIMESTAMP'2025-01-01 00:00:00.123456'`,
+		`'2018-02-28'`,
+		`'2020-02-29 12:00:00'`,
+		`20250101`,
+		`'0000-00-00'`,
+		`'pokemon trainers'`,
+		`NULL`,
+	}
+	units := []string{"MICROSECOND", "SECOND", "MINUTE", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR"}
+
+	for _, u := range units {
+		for _, d1 := range dates {
+			for _, d2 := range dates {
+				yield(fmt.Sprintf("TIMESTAMPDIFF(%s, %s, %s)", u, d1, d2), nil, false)
+			}
+		}
+	}
+}
+
+func FnAddTime(yield Query) {
+	mysqlDocSamples := []string{
+		`ADDTIME('2007-12-31 23:59:59.999999', '1 1:1:1.000002')`,
+		`ADDTIME('01:00:00.999999', '02:00:00.999998')`,
+		`SUBTIME('2007-12-31 23:59:59.999999','1 1:1:1.000002')`,
+		`SUBTIME('01:00:00.999999', '02:00:00.999998')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+
+	lefts := []string{
+		`TIMESTAMP'2020-12-31 23:59:59'`,
+		`TIME'10:00:00'`,
+		`TIME'-10:00:00.5'`,
+		`DATE'2018-05-01'`,
+		`'2020-12-31 23:59:59'`,
+		`'10:00:00'`,
+		`NULL`,
+	}
+	rights := []string{
+		`TIME'01:00:01'`,
+		`'1:1:1.000002'`,
+		`'1 1:1:1'`,
+		`'-25:00:00'`,
+		`10000`,
+		`TIMESTAMP'2020-12-31 23:59:59'`,
+		`'2020-12-31 23:59:59'`,
+		`NULL`,
+	}
+
+	for _, l := range lefts {
+		for _, r := range rights {
+			yield(fmt.Sprintf("ADDTIME(%s, %s)", l, r), nil, false)
+			yield(fmt.Sprintf("SUBTIME(%s, %s)", l, r), nil, false)
+		}
+	}
+}
+
+func FnDateDiff(yield Query) {
+	mysqlDocSamples := []string{
+		`DATEDIFF('2007-12-31 23:59:59','2007-12-30')`,
+		`DATEDIFF('2010-11-30 23:59:59','2010-12-31')`,
+	}
+
+	for _, q := range mysqlDocSamples {
+		yield(q, nil, false)
+	}
+
+	dates := []string{
+		`DATE'2018-05-01'`,
+		`TIMESTAMP'2020-12-31 23:59:59'`,
+		`'2018-02-28'`,
+		`20250101`,
+		`'0000-00-00'`,
+		`'pokemon trainers'`,
+		`NULL`,
+	}
+
+	for _, d1 := range dates {
+		for _, d2 := range dates {
+			yield(fmt.Sprintf("DATEDIFF(%s, %s)", d1, d2), nil, false)
+		}
+	}
+}
+
+func FnGetFormat(yield Query) {
+	for _, t := range []string{"DATE", "TIME", "DATETIME", "TIMESTAMP"} {
+		for _, l := range []string{`'EUR'`, `'USA'`, `'JIS'`, `'ISO'`, `'INTERNAL'`, `'usa'`, `'foo'`, `NULL`} {
+			yield(fmt.Sprintf("GET_FORMAT(%s, %s)", t, l), nil, false)
+		}
+	}
+}
+
 func RegexpLike(yield Query) {
 	mysqlDocSamples := []string{
 		`'Michael!' REGEXP '.*'`,
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/json"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -45,6 +46,12 @@ func (ast *astCompiler) translateFuncArgs(fnargs []sqlparser.Expr) ([]IR, error)
 }
 
 func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
+	if fn.Name.Lowered() == "get_format" {
+		// The first argument to GET_FORMAT is a type name, which the parser
+		// reads as a column, so it cannot be translated like other arguments.
+		return ast.translateGetFormat(fn)
+	}
+
 	var args TupleExpr
 	for _, expr := range fn.Exprs {
 		convertedExpr, err := ast.translateExpr(expr)
@@ -417,6 +424,21 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (IR, error) {
 			return nil, argError(method)
 		}
 		return &builtinHour{CallExpr: call}, nil
+	case "str_to_date":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return newBuiltinStrToDate(call), nil
+	case "addtime", "subtime":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinAddTime{CallExpr: call, sub: method == "subtime", collate: ast.cfg.Collation}, nil
+	case "datediff":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinDateDiff{CallExpr: call}, nil
 	case "makedate":
 		if len(args) != 2 {
 			return nil, argError(method)
@@ -787,6 +809,130 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			Method:    "JSON_KEYS",
 		}}, nil
 
+	case *sqlparser.JSONValueModifierExpr:
+		var t json.Transformation
+		switch call.Type {
+		case sqlparser.JSONSetType:
+			t = json.Set
+		case sqlparser.JSONInsertType:
+			t = json.Insert
+		case sqlparser.JSONReplaceType:
+			t = json.Replace
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+		exprs := []sqlparser.Expr{call.JSONDoc}
+		for _, param := range call.Params {
+			exprs = append(exprs, param.Key, param.Value)
+		}
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONModify{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    strings.ToUpper(call.Type.ToString()),
+			},
+			Transform: t,
+		}, nil
+
+	case *sqlparser.JSONRemoveExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.PathList...))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONModify{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_REMOVE",
+			},
+			Transform: json.Remove,
+		}, nil
+
+	case *sqlparser.JSONValueMergeExpr:
+		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.JSONDocList...))
+		if err != nil {
+			return nil, err
+		}
+		cexpr := CallExpr{
+			Arguments: args,
+			Method:    strings.ToUpper(call.Type.ToString()),
+		}
+		if call.Type == sqlparser.JSONMergePatchType {
+			return &builtinJSONMergePatch{CallExpr: cexpr}, nil
+		}
+		return &builtinJSONMergePreserve{CallExpr: cexpr}, nil
+
+	case *sqlparser.JSONSearchExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.JSONDoc, call.OneOrAll, call.SearchStr})
+		if err != nil {
+			return nil, err
+		}
+		escape := IR(NullExpr)
+		if call.EscapeChar != nil {
+			escape, err = ast.translateExpr(call.EscapeChar)
+			if err != nil {
+				return nil, err
+			}
+		}
+		paths, err := ast.translateFuncArgs(call.PathList)
+		if err != nil {
+			return nil, err
+		}
+		args = append(append(args, escape), paths...)
+		return &builtinJSONSearch{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_SEARCH",
+			},
+			collate: ast.cfg.Collation,
+		}, nil
+
+	case *sqlparser.JSONOverlapsExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.JSONDoc1, call.JSONDoc2})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONOverlaps{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_OVERLAPS",
+		}}, nil
+
+	case *sqlparser.MemberOfExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.Value, call.JSONArr})
+		if err != nil {
+			return nil, err
+		}
+		return &builtinMemberOf{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "MEMBER OF",
+		}}, nil
+
+	case *sqlparser.JSONValueExpr:
+		args, err := ast.translateFuncArgs([]sqlparser.Expr{call.JSONDoc, call.Path})
+		if err != nil {
+			return nil, err
+		}
+		jv := &builtinJSONValue{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_VALUE",
+		}}
+		if jv.OnEmpty, err = ast.translateJSONOnResponse(jv, call.EmptyOnResponse); err != nil {
+			return nil, err
+		}
+		if jv.OnError, err = ast.translateJSONOnResponse(jv, call.ErrorOnResponse); err != nil {
+			return nil, err
+		}
+		if call.ReturningType != nil {
+			returning, err := ast.translateConvertType(nil, call, call.ReturningType)
+			if err != nil {
+				return nil, err
+			}
+			jv.Returning = returning.(*ConvertExpr)
+		}
+		return jv, nil
+
 	case *sqlparser.CurTimeFuncExpr:
 		if call.Fsp > 6 {
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Too-big precision %d specified for '%s'. Maximum is 6.", call.Fsp, call.Name.String())
@@ -908,6 +1054,24 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (IR, error) {
 			collate:  ast.cfg.Collation,
 		}, nil
 
+	case *sqlparser.TimestampDiffExpr:
+		var err error
+		args := make([]IR, 2)
+
+		args[0], err = ast.translateExpr(call.Expr1)
+		if err != nil {
+			return nil, err
+		}
+		args[1], err = ast.translateExpr(call.Expr2)
+		if err != nil {
+			return nil, err
+		}
+
+		return &builtinTimestampDiff{
+			CallExpr: CallExpr{Arguments: args, Method: "TIMESTAMPDIFF"},
+			unit:     call.Unit,
+		}, nil
+
 	case *sqlparser.RegexpLikeExpr:
 		input, err := ast.translateExpr(call.Expr)
 		if err != nil {
@@ -1221,3 +1385,52 @@ func builtinIfRewrite(args []IR) (IR, error) {
 		Else: args[2],
 	}, nil
 }
+
+// translateJSONOnResponse translates an ON EMPTY or ON ERROR clause of JSON_VALUE,
+// appending the DEFAULT value of the clause, if any, to the arguments of jv.
+func (ast *astCompiler) translateJSONOnResponse(jv *builtinJSONValue, response *sqlparser.JtOnResponse) (jsonOnResponse, error) {
+	if response == nil {
+		return jsonOnResponseNull, nil
+	}
+	switch response.ResponseType {
+	case sqlparser.ErrorJSONType:
+		return jsonOnResponseError, nil
+	case sqlparser.DefaultJSONType:
+		def, err := ast.translateExpr(response.Expr)
+		if err != nil {
+			return jsonOnResponseNull, err
+		}
+		jv.Arguments = append(jv.Arguments, def)
+		return jsonOnResponseDefault, nil
+	default:
+		return jsonOnResponseNull, nil
+	}
+}
+
+// translateGetFormat translates GET_FORMAT({DATE|TIME|DATETIME|TIMESTAMP}, locale).
+func (ast *astCompiler) translateGetFormat(fn *sqlparser.FuncExpr) (IR, error) {
+	if len(fn.Exprs) != 2 {
+		return nil, argError("get_format")
+	}
+
+	col, ok := fn.Exprs[0].(*sqlparser.ColName)
+	if !ok || !col.Qualifier.IsEmpty() {
+		return nil, translateExprNotSupported(fn)
+	}
+	typ := col.Name.Lowered()
+	switch typ {
+	case "date", "time", "datetime", "timestamp":
+	default:
+		return nil, translateExprNotSupported(fn)
+	}
+
+	locale, err := ast.translateExpr(fn.Exprs[1])
+	if err != nil {
+		return nil, err
+	}
+	return &builtinGetFormat{
+		CallExpr: CallExpr{Arguments: []IR{locale}, Method: "GET_FORMAT"},
+		typ:      typ,
+		collate:  ast.cfg.Collation,
+	}, nil
+}
@@ -61,17 +61,23 @@ func (ast *astCompiler) translateConvertCharset(charset string, binary bool) (co
 }
 
 func (ast *astCompiler) translateConvertExpr(expr sqlparser.Expr, convertType *sqlparser.ConvertType) (IR, error) {
+	inner, err := ast.translateExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	return ast.translateConvertType(inner, expr, convertType)
+}
+
+// translateConvertType converts the already translated inner expression, which
+// was translated from expr, into the given type.
+func (ast *astCompiler) translateConvertType(inner IR, expr sqlparser.Expr, convertType *sqlparser.ConvertType) (IR, error) {
 	var (
 		convert ConvertExpr
 		err     error
 	)
 
 	convert.CollationEnv = ast.cfg.Environment.CollationEnv()
-	convert.Inner, err = ast.translateExpr(expr)
-	if err != nil {
-		return nil, err
-	}
-
+	convert.Inner = inner
 	convert.Length = convertType.Length
 	convert.Scale = convertType.Scale
 	convert.Type = strings.ToUpper(convertType.Type)
@@ -39,6 +39,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/sync2"
 	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/key"
@@ -67,6 +68,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 	"vitess.io/vitess/go/vt/vtgate/vtgateservice"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 )
 
 var (
@@ -113,6 +115,8 @@ type (
 		AllowScatter        bool
 		WarmingReadsPercent int
 		QueryLogToFile      string
+		// EnableConsolidator lets identical reads which opted in share their results
+		EnableConsolidator bool
 	}
 
 	Executor struct {
@@ -144,6 +148,13 @@ type (
 
 		vConfig   econtext.VCursorConfig
 		ddlConfig dynamicconfig.DDL
+
+		// queryRules are the query rules applied to the queries before
+		// they are executed.
+		queryRules atomic.Pointer[rules.Rules]
+
+		// consolidator shares the results of identical reads in flight.
+		consolidator sync2.Consolidator
 	}
 
 	Metrics struct {
@@ -194,6 +205,7 @@ func NewExecutor(
 		plans:               plans,
 		warmingReadsChannel: make(chan bool, warmingReadsConcurrency),
 		ddlConfig:           ddlConfig,
+		consolidator:        sync2.NewConsolidator(),
 	}
 	// setting the vcursor config.
 	e.initVConfig(warnOnShardedOnly, pv)
@@ -1116,6 +1128,15 @@ func (e *Executor) fetchOrCreatePlan(
 		return nil, nil, nil, vterrors.VT13001("vschema not initialized")
 	}
 
+	span, ctx := trace.NewSpan(ctx, "executor.fetchOrCreatePlan")
+	defer span.Finish()
+	defer func() {
+		span.Annotate("cached_plan", logStats.CachedPlan)
+		if plan != nil {
+			span.Annotate("plan_type", plan.Type.String())
+		}
+	}()
+
 	query, comments := sqlparser.SplitMarginComments(queryString)
 	vcursor, _ = e.newVCursor(safeSession, comments, logStats)
 
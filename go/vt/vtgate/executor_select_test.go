@@ -3294,7 +3294,8 @@ func TestOnlyOptimizedPlan(t *testing.T) {
 func TestPrepareWithUnsupportedQuery(t *testing.T) {
 	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
 
-	sql := "select a, b, c, row_number() over (partition by x) from user where c1 = ? and c2 = ?"
+	// window functions with different window specifications can't be evaluated on a sharded keyspace
+	sql := "select a, b, c, row_number() over (partition by x), rank() over (order by y) from user where c1 = ? and c2 = ?"
 	session := econtext.NewAutocommitSession(&vtgatepb.Session{})
 	fields, paramsCount, err := executorPrepare(ctx, executor, session.Session, sql)
 	require.NoError(t, err)
@@ -3304,6 +3305,7 @@ func TestPrepareWithUnsupportedQuery(t *testing.T) {
 		{Name: "b", Type: querypb.Type_NULL_TYPE},
 		{Name: "c", Type: querypb.Type_NULL_TYPE},
 		{Name: "row_number() over ( partition by x)", Type: querypb.Type_NULL_TYPE},
+		{Name: "rank() over ( order by y asc)", Type: querypb.Type_NULL_TYPE},
 	}
 	require.Equal(t, wantFields, fields)
 
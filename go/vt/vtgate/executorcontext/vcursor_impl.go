@@ -1106,6 +1106,12 @@ func (vc *VCursorImpl) TabletType() topodatapb.TabletType {
 	return vc.tabletType
 }
 
+// SetTabletType changes the tablet type the queries of this cursor are sent
+// to. Unlike SetTarget, it leaves the target of the session alone.
+func (vc *VCursorImpl) SetTabletType(tabletType topodatapb.TabletType) {
+	vc.tabletType = tabletType
+}
+
 func commentedShardQueries(shardQueries []*querypb.BoundQuery, marginComments sqlparser.MarginComments) []*querypb.BoundQuery {
 	if marginComments.Leading == "" && marginComments.Trailing == "" {
 		return shardQueries
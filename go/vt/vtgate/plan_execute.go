@@ -143,6 +143,12 @@ func (e *Executor) newExecute(
 		ctx, cancel = vcursor.GetContextWithTimeOut(ctx)
 		defer cancel()
 
+		ctx, cancel, err = e.applyQueryRules(ctx, safeSession, vcursor, plan, sql, bindVars)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
 		// If we have previously issued a VT15001 error, we block any new queries on this session until we receive a ROLLBACK or "show warnings".
 		if shouldBlockQueries(plan, safeSession) {
 			return vterrors.VT09032()
@@ -329,7 +335,13 @@ func (e *Executor) executePlan(
 ) (*sqltypes.Result, error) {
 
 	// 4: Execute!
-	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	var qr *sqltypes.Result
+	var err error
+	if e.shouldConsolidate(safeSession, plan) {
+		qr, err = e.executeConsolidated(ctx, plan, vcursor, bindVars)
+	} else {
+		qr, err = vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
+	}
 
 	// 5: Log and add statistics
 	e.setLogStats(logStats, plan, vcursor, execStart, err, qr)
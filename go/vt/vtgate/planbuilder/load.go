@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	"strconv"
+
+	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/operators"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// buildLoadPlan builds the plan for a LOAD statement.
+// LOAD DATA LOCAL INFILE is executed by vtgate, which reads the file from
+// the client and inserts its rows like an INSERT ... SELECT would.
+// Other LOAD statements are sent to a single shard of an unsharded keyspace.
+func buildLoadPlan(query string, stmt *sqlparser.Load, vschema plancontext.VSchema) (*planResult, error) {
+	if stmt.Local {
+		return buildLoadLocalPlan(stmt, vschema)
+	}
+
+	keyspace, err := vschema.SelectedKeyspace()
+	if err != nil {
+		return nil, err
+	}
+
+	destination := vschema.ShardDestination()
+	if destination == nil {
+		if err := vschema.ErrorIfShardedF(keyspace, "LOAD", "LOAD is not supported on sharded keyspace"); err != nil {
+			return nil, err
+		}
+		destination = key.DestinationAnyShard{}
+	}
+
+	return newPlanResult(&engine.Send{
+		Keyspace:          keyspace,
+		TargetDestination: destination,
+		Query:             query,
+		IsDML:             true,
+		SingleShardOnly:   true,
+	}), nil
+}
+
+func buildLoadLocalPlan(stmt *sqlparser.Load, vschema plancontext.VSchema) (*planResult, error) {
+	vTbl, _, tabletType, dest, err := vschema.FindTable(stmt.Table)
+	if err != nil {
+		return nil, err
+	}
+	if dest != nil {
+		return nil, vterrors.VT09017("LOAD DATA LOCAL INFILE with a target destination is not allowed")
+	}
+	if tabletType != topodatapb.TabletType_PRIMARY {
+		return nil, vterrors.VT09002("load")
+	}
+	if vTbl.Type == vindexes.TypeReference && vTbl.Source != nil {
+		vTbl, _, _, _, err = vschema.FindTable(vTbl.Source.TableName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := checkLoadLocalSupported(stmt, vTbl, vschema); err != nil {
+		return nil, err
+	}
+
+	columns, err := loadColumnList(stmt, vTbl)
+	if err != nil {
+		return nil, err
+	}
+	input, err := newLoadDataInput(stmt, len(columns))
+	if err != nil {
+		return nil, err
+	}
+
+	var gen *operators.Generate
+	if vTbl.AutoIncrement != nil {
+		gen = &operators.Generate{
+			Keyspace:  vTbl.AutoIncrement.Sequence.Keyspace,
+			TableName: sqlparser.TableName{Name: vTbl.AutoIncrement.Sequence.Name},
+			Offset:    loadColumnOffset(columns, vTbl.AutoIncrement.Column),
+		}
+		if gen.Offset == -1 {
+			// The generated values are appended to the rows read from the file.
+			gen.Offset = len(columns)
+			columns = append(columns, vTbl.AutoIncrement.Column)
+		}
+	}
+
+	eins := &engine.InsertSelect{
+		InsertCommon: engine.InsertCommon{
+			Keyspace: vTbl.Keyspace,
+			// LOCAL implies IGNORE, as the client keeps sending the file after an error.
+			Ignore:    stmt.IgnoreOrReplace != sqlparser.ReplaceType,
+			TableName: vTbl.Name.String(),
+			Generate:  autoIncGenerate(gen),
+			// The batches of rows are inserted in the transaction of the
+			// statement, which is committed once all the rows are inserted
+			// under autocommit, so the file is loaded atomically.
+			PreventAutoCommit: true,
+		},
+		Input:          input,
+		ForceStreaming: true,
+	}
+	if vTbl.Keyspace.Sharded {
+		for _, colVindex := range vTbl.ColumnVindexes {
+			if colVindex.IsPartialVindex() {
+				continue
+			}
+			eins.ColVindexes = append(eins.ColVindexes, colVindex)
+		}
+		eins.VindexValueOffset = make([][]int, len(eins.ColVindexes))
+		for idx, colVindex := range eins.ColVindexes {
+			for _, col := range colVindex.Columns {
+				colNum := loadColumnOffset(columns, col)
+				// sharding column values should be provided in the file.
+				if colNum == -1 && idx == 0 {
+					return nil, vterrors.VT09003(col)
+				}
+				eins.VindexValueOffset[idx] = append(eins.VindexValueOffset[idx], colNum)
+			}
+		}
+	}
+
+	action := sqlparser.InsertStr
+	if stmt.IgnoreOrReplace == sqlparser.ReplaceType {
+		action = sqlparser.ReplaceStr
+	}
+	prefixBuf := sqlparser.NewTrackedBuffer(dmlFormatter)
+	prefixBuf.Myprintf("%s %sinto %v%v%v ",
+		action, sqlparser.Ignore(eins.Ignore).ToString(),
+		vTbl.GetTableName(), stmt.Partitions, columns)
+	eins.Prefix = prefixBuf.String()
+
+	return newPlanResult(eins, singleTable(vTbl.Keyspace.Name, vTbl.Name.String())), nil
+}
+
+// checkLoadLocalSupported returns an error for the LOAD DATA LOCAL INFILE
+// statements that cannot be turned into inserts.
+func checkLoadLocalSupported(stmt *sqlparser.Load, vTbl *vindexes.BaseTable, vschema plancontext.VSchema) error {
+	for _, col := range stmt.Columns {
+		// user variables are rewritten into arguments by the normalizer.
+		if _, isCol := col.(*sqlparser.ColName); !isCol {
+			return vterrors.VT12001("user variables in the column list of LOAD DATA")
+		}
+	}
+	if len(stmt.SetExprs) > 0 {
+		return vterrors.VT12001("SET clause in LOAD DATA")
+	}
+	if stmt.Charset.Name != "" {
+		return vterrors.VT12001("CHARACTER SET clause in LOAD DATA")
+	}
+	if stmt.IgnoreOrReplace == sqlparser.ReplaceType && vTbl.Keyspace.Sharded {
+		return vterrors.VT12001("LOAD DATA with REPLACE on a sharded keyspace")
+	}
+
+	fkMode, err := vschema.ForeignKeyMode(vTbl.Keyspace.Name)
+	if err != nil {
+		return err
+	}
+	if fkMode == vschemapb.Keyspace_managed && (len(vTbl.ParentForeignKeys) > 0 || len(vTbl.ChildForeignKeys) > 0) {
+		return vterrors.VT12001("LOAD DATA with foreign keys")
+	}
+	return nil
+}
+
+// loadColumnList returns the columns the fields of each line are inserted into.
+func loadColumnList(stmt *sqlparser.Load, vTbl *vindexes.BaseTable) (sqlparser.Columns, error) {
+	if len(stmt.Columns) == 0 {
+		if !vTbl.ColumnListAuthoritative {
+			return nil, vterrors.VT09004()
+		}
+		columns := make(sqlparser.Columns, 0, len(vTbl.Columns))
+		for _, col := range vTbl.Columns {
+			columns = append(columns, col.Name)
+		}
+		return columns, nil
+	}
+
+	columns := make(sqlparser.Columns, 0, len(stmt.Columns))
+	for _, col := range stmt.Columns {
+		columns = append(columns, col.(*sqlparser.ColName).Name)
+	}
+	return columns, nil
+}
+
+// loadColumnOffset returns the offset of col in columns, or -1.
+func loadColumnOffset(columns sqlparser.Columns, col sqlparser.IdentifierCI) int {
+	for i, column := range columns {
+		if col.Equal(column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// newLoadDataInput creates the primitive reading the file of the
+// statement, using the default of every option that is not specified.
+func newLoadDataInput(stmt *sqlparser.Load, columns int) (*engine.LoadData, error) {
+	ld := engine.NewLoadData(stmt.FileName, columns)
+	if fields := stmt.Fields; fields != nil {
+		if fields.TerminatedBy != nil {
+			ld.FieldsTerminatedBy = fields.TerminatedBy.Val
+		}
+		if fields.EnclosedBy != nil {
+			ld.FieldsEnclosedBy = fields.EnclosedBy.Val
+		}
+		if fields.EscapedBy != nil {
+			ld.FieldsEscapedBy = fields.EscapedBy.Val
+		}
+	}
+	if lines := stmt.Lines; lines != nil {
+		if lines.StartingBy != nil {
+			ld.LinesStartingBy = lines.StartingBy.Val
+		}
+		if lines.TerminatedBy != nil {
+			ld.LinesTerminatedBy = lines.TerminatedBy.Val
+		}
+	}
+	if stmt.IgnoreLines != nil {
+		ignoreLines, err := strconv.Atoi(stmt.IgnoreLines.Val)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid number of lines to ignore: %s", stmt.IgnoreLines.Val)
+		}
+		ld.IgnoreLines = ignoreLines
+	}
+
+	if len(ld.FieldsEnclosedBy) > 1 || len(ld.FieldsEscapedBy) > 1 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Field separator argument is not what is expected; check the manual")
+	}
+	if ld.FieldsTerminatedBy == "" || ld.LinesTerminatedBy == "" {
+		return nil, vterrors.VT12001("fixed-row format in LOAD DATA")
+	}
+	return ld, nil
+}
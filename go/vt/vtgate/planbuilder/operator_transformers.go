@@ -75,6 +75,8 @@ func recursiveTransform(ctx *plancontext.PlanningContext, op operators.Operator)
 		return transformAggregator(ctx, op)
 	case *operators.Distinct:
 		return transformDistinct(ctx, op)
+	case *operators.Window:
+		return transformWindow(ctx, op)
 	case *operators.FkCascade:
 		return transformFkCascade(ctx, op)
 	case *operators.FkVerify:
@@ -324,9 +326,6 @@ func transformFkVerify(ctx *plancontext.PlanningContext, fkv *operators.FkVerify
 }
 
 func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggregator) (engine.Primitive, error) {
-	if op.WithRollup {
-		return nil, vterrors.VT12001("GROUP BY WITH ROLLUP not supported for sharded queries")
-	}
 	src, err := transformToPrimitive(ctx, op.Source)
 	if err != nil {
 		return nil, err
@@ -393,10 +392,72 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 		Aggregates:          aggregates,
 		GroupByKeys:         groupByKeys,
 		TruncateColumnCount: op.ResultColumns,
+		WithRollup:          op.WithRollup,
 		Input:               src,
 	}, nil
 }
 
+func transformWindow(ctx *plancontext.PlanningContext, op *operators.Window) (engine.Primitive, error) {
+	src, err := transformToPrimitive(ctx, op.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &evalengine.Config{
+		Collation:   ctx.SemTable.Collation,
+		ResolveType: ctx.TypeForExpr,
+		Environment: ctx.VSchema.Environment(),
+	}
+	translate := func(expr sqlparser.Expr) (evalengine.Expr, error) {
+		if expr == nil {
+			return nil, nil
+		}
+		return evalengine.Translate(expr, cfg)
+	}
+
+	var functions []*engine.WindowParams
+	for _, fn := range op.Functions {
+		param := &engine.WindowParams{
+			Opcode:       fn.OpCode,
+			Col:          fn.ColOffset,
+			CollationEnv: ctx.VSchema.Environment().CollationEnv(),
+		}
+		if fn.Arg != nil {
+			param.Type, _ = ctx.TypeForExpr(fn.Arg)
+		}
+		if param.N, err = translate(fn.N); err != nil {
+			return nil, err
+		}
+		if param.Default, err = translate(fn.Default); err != nil {
+			return nil, err
+		}
+		functions = append(functions, param)
+	}
+
+	return &engine.Window{
+		Functions:           functions,
+		PartitionBy:         windowKeys(ctx, op.Partition),
+		OrderBy:             windowKeys(ctx, op.Order),
+		TruncateColumnCount: op.ResultColumns,
+		Input:               src,
+	}, nil
+}
+
+func windowKeys(ctx *plancontext.PlanningContext, keys []operators.GroupBy) []*engine.GroupByParams {
+	var params []*engine.GroupByParams
+	for _, key := range keys {
+		typ, _ := ctx.TypeForExpr(key.Inner)
+		params = append(params, &engine.GroupByParams{
+			KeyCol:          key.ColOffset,
+			WeightStringCol: key.WSOffset,
+			Expr:            key.Inner,
+			Type:            typ,
+			CollationEnv:    ctx.VSchema.Environment().CollationEnv(),
+		})
+	}
+	return params
+}
+
 func transformDistinct(ctx *plancontext.PlanningContext, op *operators.Distinct) (engine.Primitive, error) {
 	src, err := transformToPrimitive(ctx, op.Source)
 	if err != nil {
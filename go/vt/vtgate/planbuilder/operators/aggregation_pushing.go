@@ -39,8 +39,9 @@ func tryPushAggregator(ctx *plancontext.PlanningContext, aggregator *Aggregator)
 		return aggregator, NoRewrite
 	}
 
-	// this rewrite is always valid, and we should do it whenever possible
-	if route, ok := aggregator.Source.(*Route); ok && (route.IsSingleShard() || overlappingUniqueVindex(ctx, aggregator.Grouping)) {
+	// this rewrite is always valid, and we should do it whenever possible.
+	// The super-aggregate rows of WITH ROLLUP span all the groups, so they can't be computed on each shard
+	if route, ok := aggregator.Source.(*Route); ok && (route.IsSingleShard() || (!aggregator.WithRollup && overlappingUniqueVindex(ctx, aggregator.Grouping))) {
 		return Swap(aggregator, route, "push down aggregation under route - remove original")
 	}
 
@@ -202,6 +203,10 @@ func pushAggregations(ctx *plancontext.PlanningContext, aggregator *Aggregator,
 	}
 
 	if !canPushDistinctAggr {
+		if aggregator.WithRollup {
+			// the distinct values are only sorted within the innermost groups, so the super-aggregates can't be computed
+			panic(vterrors.VT12001("distinct aggregation with GROUP BY WITH ROLLUP in a cross-shard query"))
+		}
 		aggregator.DistinctExpr = distinctExprs[0]
 	}
 }
@@ -193,11 +193,18 @@ func createProjectionFromSelect(ctx *plancontext.PlanningContext, horizon *Horiz
 		}
 	}
 
-	if qp.NeedsAggregation() {
+	src := horizon.src()
+	if sel, isSel := horizon.Query.(*sqlparser.Select); isSel && !canPushWindowFunctions(ctx, sel, src) {
+		if qp.NeedsAggregation() {
+			panic(vterrors.VT12001("window functions together with aggregation in a cross-shard query"))
+		}
+		// the window functions need to be evaluated at the vtgate level, before the projection
+		src = createWindow(ctx, sel, src)
+	} else if qp.NeedsAggregation() {
 		return createProjectionWithAggr(ctx, qp, dt, horizon)
 	}
 
-	projX := createProjectionWithoutAggr(ctx, qp, horizon.src())
+	projX := createProjectionWithoutAggr(ctx, qp, src)
 	projX.DT = dt
 	return projX
 }
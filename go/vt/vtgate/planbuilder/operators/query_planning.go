@@ -288,12 +288,15 @@ func pushOrExpandHorizon(ctx *plancontext.PlanningContext, in *Horizon) (Operato
 	needsOrdering := len(qp.OrderExprs) > 0
 	hasHaving := isSel && sel.Having != nil
 
+	pushWindows := !isSel || canPushWindowFunctions(ctx, sel, in.src())
+
 	canPush := isRoute &&
 		!hasHaving &&
 		!needsOrdering &&
 		!qp.NeedsAggregation() &&
 		!isDistinctAST(in.selectStatement()) &&
-		in.selectStatement().GetLimit() == nil
+		in.selectStatement().GetLimit() == nil &&
+		pushWindows
 
 	if canPush {
 		return Swap(in, rb, "push horizon into route")
@@ -312,6 +315,8 @@ func pushOrExpandHorizon(ctx *plancontext.PlanningContext, in *Horizon) (Operato
 		debugNoRewrite("horizon push blocked: query has DISTINCT")
 	} else if in.selectStatement().GetLimit() != nil {
 		debugNoRewrite("horizon push blocked: query has LIMIT")
+	} else if !pushWindows {
+		debugNoRewrite("horizon push blocked: window functions are not partitioned by a unique vindex")
 	}
 
 	return expandHorizon(ctx, in)
@@ -590,6 +595,9 @@ func setUpperLimit(in *Limit) (Operator, *ApplyResult) {
 		case *Join, *ApplyJoin, *SubQueryContainer, *SubQuery:
 			// we can't push limits down on either side
 			return SkipChildren
+		case *Window:
+			// the window functions need to see all the rows of their partitions
+			return SkipChildren
 		case *Aggregator:
 			if len(op.Grouping) > 0 {
 				// we can't push limits down if we have a group by
@@ -627,6 +635,12 @@ func tryPushOrdering(ctx *plancontext.PlanningContext, in *Ordering) (Operator,
 	case *Projection:
 		return pushOrderingUnderProjection(ctx, in, src)
 	case *Aggregator:
+		if src.WithRollup {
+			// the super-aggregate rows are produced after the rows of their group,
+			// so the ordering has to be done on the output of the aggregation
+			debugNoRewrite("ordering push blocked: aggregation is using WITH ROLLUP")
+			return in, NoRewrite
+		}
 		if !src.QP.AlignGroupByAndOrderBy(ctx) && !overlaps(ctx, in.Order, src.Grouping) {
 			debugNoRewrite("ordering push blocked: GROUP BY and ORDER BY cannot be aligned and don't overlap")
 			return in, NoRewrite
@@ -833,6 +847,12 @@ func tryPushFilter(ctx *plancontext.PlanningContext, in *Filter) (Operator, *App
 
 func pushFilterUnderProjection(ctx *plancontext.PlanningContext, filter *Filter, projection *Projection) (Operator, *ApplyResult) {
 	for _, p := range filter.Predicates {
+		if sqlparser.ContainsWindowFunc(projection.DT.RewriteExpression(ctx, p)) {
+			// window functions have to be evaluated before we can filter on them
+			debugNoRewrite("filter push blocked: predicate uses a window function")
+			return filter, NoRewrite
+		}
+
 		cantPush := false
 		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
 			if !mustFetchFromInput(ctx, node) {
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/slice"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+)
+
+type (
+	// Window evaluates window functions at the vtgate level.
+	// All the window functions share the same window specification, and the
+	// input is expected to be sorted by the partition and the order expressions.
+	// The first columns of the operator are the window functions, in the same
+	// order as Functions. Any other column is passed through from the input.
+	Window struct {
+		unaryOperator
+		Columns []*sqlparser.AliasedExpr
+
+		Functions []WindowFunction
+
+		// Partition and Order are the expressions of the window specification.
+		// Only equality matters for the order expressions - they decide which rows are peers.
+		Partition []GroupBy
+		Order     []GroupBy
+
+		offsetPlanned bool
+
+		// ResultColumns signals how many columns will be produced by this operator
+		// This is used to truncate the columns in the final result
+		ResultColumns int
+	}
+
+	// WindowFunction contains the information needed to evaluate a single window function
+	WindowFunction struct {
+		Original sqlparser.Expr
+		OpCode   opcode.WindowOpcode
+
+		// Arg is the expression the function reads from each row. It is nil for functions without arguments
+		Arg sqlparser.Expr
+
+		// N is the offset of LAG/LEAD, the number of buckets of NTILE and the row of NTH_VALUE
+		N sqlparser.Expr
+
+		// Default is the value LAG/LEAD return when the offset is outside the partition
+		Default sqlparser.Expr
+
+		// ColOffset is the column of the argument on the input, and of the result on the output
+		ColOffset int
+	}
+)
+
+func (w *Window) Clone(inputs []Operator) Operator {
+	kopy := *w
+	kopy.Source = inputs[0]
+	kopy.Columns = slices.Clone(w.Columns)
+	kopy.Functions = slices.Clone(w.Functions)
+	kopy.Partition = slices.Clone(w.Partition)
+	kopy.Order = slices.Clone(w.Order)
+	return &kopy
+}
+
+func (w *Window) AddPredicate(_ *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
+	// predicates can't be pushed under the window - they would change the rows of the partitions
+	return newFilter(w, expr)
+}
+
+func (w *Window) AddColumn(ctx *plancontext.PlanningContext, reuse bool, addToGroupBy bool, ae *sqlparser.AliasedExpr) int {
+	w.planOffsets(ctx)
+
+	if reuse {
+		if offset := w.FindCol(ctx, ae.Expr, false); offset >= 0 {
+			return offset
+		}
+	}
+
+	if sqlparser.ContainsWindowFunc(ae.Expr) {
+		panic(vterrors.VT13001(fmt.Sprintf("window function not planned on the Window operator: %s", sqlparser.String(ae))))
+	}
+
+	offset := len(w.Columns)
+	w.Columns = append(w.Columns, ae)
+	incomingOffset := w.Source.AddColumn(ctx, false, addToGroupBy, ae)
+	if offset != incomingOffset {
+		panic(errFailedToPlanWindow(ae))
+	}
+	return offset
+}
+
+func (w *Window) AddWSColumn(ctx *plancontext.PlanningContext, offset int, underRoute bool) int {
+	w.planOffsets(ctx)
+
+	if len(w.Columns) <= offset {
+		panic(vterrors.VT13001("offset out of range"))
+	}
+	if offset < len(w.Functions) {
+		panic(vterrors.VT12001(fmt.Sprintf("comparing the result of the window function: %s", sqlparser.String(w.Columns[offset].Expr))))
+	}
+
+	wsAe := aeWrap(weightStringFor(w.Columns[offset].Expr))
+	if found := w.FindCol(ctx, wsAe.Expr, underRoute); found >= 0 {
+		return found
+	}
+
+	wsOffset := len(w.Columns)
+	w.Columns = append(w.Columns, wsAe)
+	incomingOffset := w.Source.AddWSColumn(ctx, offset, false)
+	if wsOffset != incomingOffset {
+		panic(errFailedToPlanWindow(wsAe))
+	}
+	return wsOffset
+}
+
+func errFailedToPlanWindow(original *sqlparser.AliasedExpr) *vterrors.VitessError {
+	return vterrors.VT12001(fmt.Sprintf("failed to plan window function on: %s", sqlparser.String(original)))
+}
+
+func (w *Window) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr, _ bool) int {
+	if offset, found := canReuseColumn(ctx, w.Columns, expr, extractExpr); found {
+		return offset
+	}
+	return -1
+}
+
+func (w *Window) GetColumns(*plancontext.PlanningContext) []*sqlparser.AliasedExpr {
+	return truncate(w, w.Columns)
+}
+
+func (w *Window) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
+	return transformColumnsToSelectExprs(ctx, w)
+}
+
+func (w *Window) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
+	return w.Source.GetOrdering(ctx)
+}
+
+func (w *Window) ShortDescription() string {
+	columns := slice.Map(w.Columns, func(from *sqlparser.AliasedExpr) string {
+		return sqlparser.String(from)
+	})
+	desc := strings.Join(columns, ", ")
+	if w.ResultColumns > 0 {
+		desc = fmt.Sprintf(":%d %s", w.ResultColumns, desc)
+	}
+	return desc
+}
+
+// planOffsets puts a projection under the window, so the argument of each window function
+// can be placed on the column where the result of the function is returned
+func (w *Window) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	if w.offsetPlanned {
+		return nil
+	}
+	w.offsetPlanned = true
+
+	w.Source = newAliasedProjection(w.Source)
+	for idx, fn := range w.Functions {
+		offset := w.Source.AddColumn(ctx, false, false, aeWrap(fn.getPushColumn()))
+		if offset != idx {
+			panic(errFailedToPlanWindow(w.Columns[idx]))
+		}
+		w.Functions[idx].ColOffset = offset
+	}
+
+	w.planKeyOffsets(ctx, w.Partition)
+	w.planKeyOffsets(ctx, w.Order)
+	return nil
+}
+
+func (w *Window) planKeyOffsets(ctx *plancontext.PlanningContext, keys []GroupBy) {
+	for idx, key := range keys {
+		keys[idx].ColOffset = w.internalAddColumn(ctx, aeWrap(key.Inner))
+		if !ctx.NeedsWeightString(key.Inner) {
+			continue
+		}
+		offset := w.Source.AddWSColumn(ctx, keys[idx].ColOffset, false)
+		if offset == len(w.Columns) {
+			w.Columns = append(w.Columns, aeWrap(weightStringFor(key.Inner)))
+		}
+		keys[idx].WSOffset = offset
+	}
+}
+
+func (w *Window) internalAddColumn(ctx *plancontext.PlanningContext, ae *sqlparser.AliasedExpr) int {
+	offset := w.Source.AddColumn(ctx, true, false, ae)
+	if offset == len(w.Columns) {
+		// if we get an offset at the end of our current column list, it means we added a new column
+		w.Columns = append(w.Columns, ae)
+	}
+	return offset
+}
+
+func (w *Window) setTruncateColumnCount(offset int) {
+	w.ResultColumns = offset
+}
+
+func (w *Window) getTruncateColumnCount() int {
+	return w.ResultColumns
+}
+
+// getPushColumn returns the expression the window function needs from its input.
+// Functions that don't read from the rows still need a column to write their result to.
+func (fn WindowFunction) getPushColumn() sqlparser.Expr {
+	if fn.Arg == nil {
+		return sqlparser.NewIntLiteral("1")
+	}
+	return fn.Arg
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+)
+
+// canPushWindowFunctions returns true if all window functions in the query can be evaluated by MySQL.
+// This is the case when the query goes to a single shard, or when every window is partitioned
+// by a unique vindex column, which means that all the rows of a partition live on the same shard.
+func canPushWindowFunctions(ctx *plancontext.PlanningContext, sel *sqlparser.Select, src Operator) bool {
+	if !ctx.SemTable.QuerySignature.WindowFunctions {
+		return true
+	}
+	rb, isRoute := src.(*Route)
+	if !isRoute {
+		return findWindowFunctions(ctx, sel) == nil
+	}
+	if rb.IsSingleShard() {
+		return true
+	}
+	return windowsPartitionedByVindex(ctx, sel, rb)
+}
+
+// windowsPartitionedByVindex checks that every window used in the query is partitioned by a unique vindex column
+func windowsPartitionedByVindex(ctx *plancontext.PlanningContext, sel *sqlparser.Select, op Operator) bool {
+	for _, fn := range findWindowFunctions(ctx, sel) {
+		spec := resolveWindowSpec(sel, sqlparser.GetOverClause(fn))
+		if spec == nil {
+			return false
+		}
+		partitioned := false
+		for _, expr := range spec.PartitionClause {
+			sc := findColumnVindex(ctx, op, expr)
+			if sc != nil && sc.IsUnique() {
+				partitioned = true
+				break
+			}
+		}
+		if !partitioned {
+			return false
+		}
+	}
+	return true
+}
+
+// findWindowFunctions returns the window functions used in the SELECT expressions and the ORDER BY of the query
+func findWindowFunctions(ctx *plancontext.PlanningContext, sel *sqlparser.Select) []sqlparser.Expr {
+	var found []sqlparser.Expr
+	visit := func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.Subquery:
+			return false, nil
+		case sqlparser.Expr:
+			if !sqlparser.IsWindowFunc(node) {
+				return true, nil
+			}
+			for _, fn := range found {
+				if ctx.SemTable.EqualsExprWithDeps(fn, node) {
+					return false, nil
+				}
+			}
+			found = append(found, node)
+			return false, nil
+		}
+		return true, nil
+	}
+	for _, expr := range sel.GetColumns() {
+		_ = sqlparser.Walk(visit, expr)
+	}
+	for _, order := range sel.OrderBy {
+		_ = sqlparser.Walk(visit, order.Expr)
+	}
+	return found
+}
+
+// resolveWindowSpec returns the window specification used by an OVER clause, following references to named windows.
+// It returns nil if a window name can't be found.
+func resolveWindowSpec(sel *sqlparser.Select, over *sqlparser.OverClause) *sqlparser.WindowSpecification {
+	if over.WindowName.NotEmpty() {
+		return findNamedWindow(sel, over.WindowName, 0)
+	}
+	return inheritWindowSpec(sel, over.WindowSpec, 0)
+}
+
+func inheritWindowSpec(sel *sqlparser.Select, spec *sqlparser.WindowSpecification, depth int) *sqlparser.WindowSpecification {
+	if spec == nil || spec.Name.IsEmpty() {
+		return spec
+	}
+
+	// the window specification extends a named window, which can't define a partition of its own
+	base := findNamedWindow(sel, spec.Name, depth+1)
+	if base == nil {
+		return nil
+	}
+	merged := &sqlparser.WindowSpecification{
+		PartitionClause: base.PartitionClause,
+		OrderClause:     base.OrderClause,
+		FrameClause:     base.FrameClause,
+	}
+	if len(spec.OrderClause) > 0 {
+		merged.OrderClause = spec.OrderClause
+	}
+	if spec.FrameClause != nil {
+		merged.FrameClause = spec.FrameClause
+	}
+	return merged
+}
+
+func findNamedWindow(sel *sqlparser.Select, name sqlparser.IdentifierCI, depth int) *sqlparser.WindowSpecification {
+	// named windows can reference each other, but can't be circular
+	if depth > countNamedWindows(sel) {
+		return nil
+	}
+	for _, named := range sel.Windows {
+		for _, def := range named.Windows {
+			if def.Name.Equal(name) {
+				return inheritWindowSpec(sel, def.WindowSpec, depth)
+			}
+		}
+	}
+	return nil
+}
+
+func countNamedWindows(sel *sqlparser.Select) (count int) {
+	for _, named := range sel.Windows {
+		count += len(named.Windows)
+	}
+	return
+}
+
+// createWindow creates a Window operator for the window functions in the query,
+// on top of an Ordering that sorts the rows by partition and by the window order
+func createWindow(ctx *plancontext.PlanningContext, sel *sqlparser.Select, src Operator) *Window {
+	funcs := findWindowFunctions(ctx, sel)
+	window := &Window{unaryOperator: newUnaryOp(src)}
+
+	var spec *sqlparser.WindowSpecification
+	for idx, fn := range funcs {
+		fnSpec := resolveWindowSpec(sel, sqlparser.GetOverClause(fn))
+		if fnSpec == nil {
+			panic(vterrors.VT12001(fmt.Sprintf("window function with an undefined window: %s", sqlparser.String(fn))))
+		}
+		if idx == 0 {
+			spec = fnSpec
+		} else if !sameWindowSpec(ctx, spec, fnSpec) {
+			panic(vterrors.VT12001("window functions with different window specifications in a cross-shard query"))
+		}
+
+		window.Functions = append(window.Functions, newWindowFunction(fn))
+		window.Columns = append(window.Columns, aeWrap(fn))
+	}
+
+	if spec.FrameClause != nil {
+		panic(vterrors.VT12001("window frame clause in a cross-shard query"))
+	}
+
+	var order []OrderBy
+	for _, expr := range spec.PartitionClause {
+		window.Partition = append(window.Partition, NewGroupBy(expr))
+		order = append(order, OrderBy{
+			Inner:          &sqlparser.Order{Expr: expr, Direction: sqlparser.AscOrder},
+			SimplifiedExpr: expr,
+		})
+	}
+	for _, by := range spec.OrderClause {
+		window.Order = append(window.Order, NewGroupBy(by.Expr))
+		order = append(order, OrderBy{
+			Inner:          by,
+			SimplifiedExpr: by.Expr,
+		})
+	}
+	if len(order) > 0 {
+		window.Source = newOrdering(src, order)
+	}
+
+	return window
+}
+
+func sameWindowSpec(ctx *plancontext.PlanningContext, a, b *sqlparser.WindowSpecification) bool {
+	if len(a.PartitionClause) != len(b.PartitionClause) || len(a.OrderClause) != len(b.OrderClause) {
+		return false
+	}
+	for i, expr := range a.PartitionClause {
+		if !ctx.SemTable.EqualsExprWithDeps(expr, b.PartitionClause[i]) {
+			return false
+		}
+	}
+	for i, by := range a.OrderClause {
+		other := b.OrderClause[i]
+		if by.Direction != other.Direction || !ctx.SemTable.EqualsExprWithDeps(by.Expr, other.Expr) {
+			return false
+		}
+	}
+	return sqlparser.Equals.RefOfFrameClause(a.FrameClause, b.FrameClause)
+}
+
+// newWindowFunction translates a window function to the WindowFunction the vtgate can evaluate
+func newWindowFunction(expr sqlparser.Expr) WindowFunction {
+	fn := WindowFunction{Original: expr, ColOffset: -1}
+	unsupported := func() {
+		panic(vterrors.VT12001(fmt.Sprintf("in scatter query: window function '%s'", sqlparser.String(expr))))
+	}
+
+	switch e := expr.(type) {
+	case *sqlparser.ArgumentLessWindowExpr:
+		switch e.Type {
+		case sqlparser.RowNumberExprType:
+			fn.OpCode = opcode.WindowRowNumber
+		case sqlparser.RankExprType:
+			fn.OpCode = opcode.WindowRank
+		case sqlparser.DenseRankExprType:
+			fn.OpCode = opcode.WindowDenseRank
+		case sqlparser.PercentRankExprType:
+			fn.OpCode = opcode.WindowPercentRank
+		case sqlparser.CumeDistExprType:
+			fn.OpCode = opcode.WindowCumeDist
+		}
+	case *sqlparser.NtileExpr:
+		fn.OpCode = opcode.WindowNtile
+		fn.N = e.N
+	case *sqlparser.FirstOrLastValueExpr:
+		if ignoresNulls(e.NullTreatmentClause) {
+			unsupported()
+		}
+		fn.OpCode = opcode.WindowFirstValue
+		if e.Type == sqlparser.LastValueExprType {
+			fn.OpCode = opcode.WindowLastValue
+		}
+		fn.Arg = e.Expr
+	case *sqlparser.NTHValueExpr:
+		if ignoresNulls(e.NullTreatmentClause) || (e.FromFirstLastClause != nil && e.FromFirstLastClause.Type == sqlparser.FromLastType) {
+			unsupported()
+		}
+		fn.OpCode = opcode.WindowNthValue
+		fn.Arg = e.Expr
+		fn.N = e.N
+	case *sqlparser.LagLeadExpr:
+		if ignoresNulls(e.NullTreatmentClause) {
+			unsupported()
+		}
+		fn.OpCode = opcode.WindowLag
+		if e.Type == sqlparser.LeadExprType {
+			fn.OpCode = opcode.WindowLead
+		}
+		fn.Arg = e.Expr
+		fn.N = e.N
+		fn.Default = e.Default
+	case *sqlparser.CountStar:
+		fn.OpCode = opcode.WindowCountStar
+	case *sqlparser.Count:
+		if e.Distinct || len(e.Args) != 1 {
+			unsupported()
+		}
+		fn.OpCode = opcode.WindowCount
+		fn.Arg = e.Args[0]
+	case *sqlparser.Sum:
+		if e.Distinct {
+			unsupported()
+		}
+		fn.OpCode = opcode.WindowSum
+		fn.Arg = e.Arg
+	case *sqlparser.Min:
+		fn.OpCode = opcode.WindowMin
+		fn.Arg = e.Arg
+	case *sqlparser.Max:
+		fn.OpCode = opcode.WindowMax
+		fn.Arg = e.Arg
+	default:
+		unsupported()
+	}
+
+	for _, arg := range []sqlparser.Expr{fn.N, fn.Default} {
+		if arg != nil && !sqlparser.IsConstant(arg) {
+			unsupported()
+		}
+	}
+	return fn
+}
+
+func ignoresNulls(clause *sqlparser.NullTreatmentClause) bool {
+	return clause != nil && clause.Type == sqlparser.IgnoreNullsType
+}
@@ -78,8 +78,12 @@ var (
 	mysqlDefaultWorkload     int32
 	mysqlDrainOnTerm         bool
 
-	mysqlServerFlushDelay = 100 * time.Millisecond
-	mysqlServerMultiQuery = false
+	mysqlServerFlushDelay  = 100 * time.Millisecond
+	mysqlServerMultiQuery  = false
+	mysqlServerLocalInfile = false
+
+	mysqlServerCompressionAlgorithmNames string
+	mysqlServerCompressionAlgorithms     []mysql.CompressionAlgorithm
 )
 
 func registerPluginFlags(fs *pflag.FlagSet) {
@@ -107,6 +111,8 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	utils.SetFlagStringVar(fs, &mysqlDefaultWorkloadName, "mysql-default-workload", mysqlDefaultWorkloadName, "Default session workload (OLTP, OLAP, DBA)")
 	fs.BoolVar(&mysqlDrainOnTerm, "mysql-server-drain-onterm", mysqlDrainOnTerm, "If set, the server waits for --onterm-timeout for already connected clients to complete their in flight work")
 	utils.SetFlagBoolVar(fs, &mysqlServerMultiQuery, "mysql-server-multi-query-protocol", mysqlServerMultiQuery, "If set, the server will use the new implementation of handling queries where-in multiple queries are sent together.")
+	utils.SetFlagBoolVar(fs, &mysqlServerLocalInfile, "mysql-server-local-infile", mysqlServerLocalInfile, "If set, the server will accept LOAD DATA LOCAL INFILE statements from clients that enable it.")
+	utils.SetFlagStringVar(fs, &mysqlServerCompressionAlgorithmNames, "mysql-server-compression-algorithms", mysqlServerCompressionAlgorithmNames, "Comma separated list of the algorithms of the compressed protocol the server accepts from clients that ask for it. Options: zlib, zstd. The protocol is never compressed if empty.")
 }
 
 // vtgateHandler implements the Listener interface.
@@ -152,6 +158,15 @@ func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
 	}
 }
 
+// ComChangeUser drops the session of the previous user, which was already
+// released by ComResetConnection, so the next query starts a fresh session.
+// The caller id is built from c.User and c.UserData for every query, so it
+// follows the new user.
+func (vh *vtgateHandler) ComChangeUser(c *mysql.Conn) {
+	c.ClientData = nil
+	vh.session(c)
+}
+
 func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 	// Rollback if there is an ongoing transaction. Ignore error.
 	defer func() {
@@ -178,12 +193,24 @@ func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
 // Regexp to extract parent span id over the sql query
 var r = regexp.MustCompile(`/\*VT_SPAN_CONTEXT=(.*)\*/`)
 
+// Regexp to extract the W3C traceparent of the parent span, as added to the
+// sql query by sqlcommenter, e.g. /*traceparent='00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01'*/
+var traceParentRegexp = regexp.MustCompile(`traceparent='([0-9a-f]{2}-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2})'`)
+
+// acceptsTraceParent returns whether the tracer can create a span from a
+// traceparent. The opentracing tracers cannot, so the traceparent of the
+// queries is only used with the opentelemetry tracer.
+var acceptsTraceParent = trace.AcceptsTraceParent
+
 // this function is here to make this logic easy to test by decoupling the logic from the `trace.NewSpan` and `trace.NewFromString` functions
 func startSpanTestable(ctx context.Context, query, label string,
 	newSpan func(context.Context, string) (trace.Span, context.Context),
 	newSpanFromString func(context.Context, string, string) (trace.Span, context.Context, error)) (trace.Span, context.Context, error) {
 	_, comments := sqlparser.SplitMarginComments(query)
 	match := r.FindStringSubmatch(comments.Leading)
+	if len(match) == 0 && acceptsTraceParent() {
+		match = traceParentRegexp.FindStringSubmatch(comments.Leading + comments.Trailing)
+	}
 	span, ctx := getSpan(ctx, match, newSpan, label, newSpanFromString)
 
 	trace.AnnotateSQL(span, sqlparser.Preview(query))
@@ -231,6 +258,7 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 	defer span.Finish()
 
 	ctx = callinfo.MysqlCallInfo(ctx, c)
+	ctx = mysql.NewContextWithLocalInfileRequester(ctx, c)
 
 	// Fill in the ImmediateCallerID with the UserData returned by
 	// the AuthServer plugin for that user. If nothing was
@@ -288,6 +316,7 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 	defer span.Finish()
 
 	ctx = callinfo.MysqlCallInfo(ctx, c)
+	ctx = mysql.NewContextWithLocalInfileRequester(ctx, c)
 
 	// Fill in the ImmediateCallerID with the UserData returned by
 	// the AuthServer plugin for that user. If nothing was
@@ -410,12 +439,24 @@ func (vh *vtgateHandler) ComPrepare(c *mysql.Conn, query string) ([]*querypb.Fie
 }
 
 func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
-	ctx, cancel := context.WithCancel(context.Background())
-	c.UpdateCancelCtx(cancel)
+	session := vh.session(c)
+	streamCursor := prepare.CursorType&mysql.CursorTypeReadOnly != 0 && canStreamCursor(session, prepare)
 
-	if mysqlQueryTimeout != 0 {
-		ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
-		defer cancel()
+	var ctx context.Context
+	if streamCursor {
+		// The rows of the cursor are fetched by later commands of the
+		// connection. The execution is canceled when the cursor is closed,
+		// not by the connection, and has no query timeout.
+		ctx = prepare.CursorContext()
+	} else {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		c.UpdateCancelCtx(cancel)
+
+		if mysqlQueryTimeout != 0 {
+			ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
+			defer cancel()
+		}
 	}
 
 	ctx = callinfo.MysqlCallInfo(ctx, c)
@@ -432,7 +473,34 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 		"VTGate MySQL Connector" /* subcomponent: part of the client */)
 	ctx = callerid.NewContext(ctx, ef, im)
 
-	session := vh.session(c)
+	if streamCursor {
+		return vh.streamCursor(ctx, session, prepare, callback)
+	}
+	if prepare.CursorType&mysql.CursorTypeReadOnly != 0 {
+		// The rows of the cursor are fetched while the connection keeps
+		// using the session, so the statement is executed in full before
+		// the cursor hands out its results.
+		var results []*sqltypes.Result
+		err := vh.executePrepared(ctx, c, session, prepare, func(qr *sqltypes.Result) error {
+			results = append(results, qr.Copy())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, qr := range results {
+			if err := callback(qr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return vh.executePrepared(ctx, c, session, prepare, callback)
+}
+
+// executePrepared executes a prepared statement on the session of the
+// connection.
+func (vh *vtgateHandler) executePrepared(ctx context.Context, c *mysql.Conn, session *vtgatepb.Session, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -459,6 +527,35 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 	return callback(qr)
 }
 
+// canStreamCursor returns true if the rows of a read-only cursor can be
+// streamed. The cursor is fetched while the connection runs other
+// statements, so it is only streamed for a select outside of a transaction
+// or reserved connection. Otherwise the statement is executed in full, and
+// the cursor hands out the rows of its result.
+func canStreamCursor(session *vtgatepb.Session, prepare *mysql.PrepareData) bool {
+	return !session.InTransaction && !session.InReservedConn && sqlparser.Preview(prepare.PrepareStmt) == sqlparser.StmtSelect
+}
+
+// streamCursor streams the rows of a read-only cursor on a copy of the
+// session, as the session keeps being used by the connection while the
+// cursor is fetched.
+func (vh *vtgateHandler) streamCursor(ctx context.Context, session *vtgatepb.Session, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	vh.busyConnections.Add(1)
+	defer vh.busyConnections.Add(-1)
+
+	cursorSession := session.CloneVT()
+	_, err := vh.vtg.StreamExecute(ctx, vh, cursorSession, prepare.PrepareStmt, prepare.BindVars, callback)
+	// The copy of the session is dropped, so release the connections
+	// the execution may have reserved.
+	if len(cursorSession.ShardSessions) > 0 {
+		_ = vh.vtg.CloseSession(context.Background(), cursorSession)
+	}
+	if err != nil {
+		return sqlerror.NewSQLErrorFromError(err)
+	}
+	return nil
+}
+
 func (vh *vtgateHandler) WarningCount(c *mysql.Conn) uint16 {
 	return uint16(len(vh.session(c).GetWarnings()))
 }
@@ -470,12 +567,18 @@ func (vh *vtgateHandler) ComRegisterReplica(c *mysql.Conn, replicaHost string, r
 
 // ComBinlogDump is part of the mysql.Handler interface.
 func (vh *vtgateHandler) ComBinlogDump(c *mysql.Conn, logFile string, binlogPos uint32) error {
-	return vterrors.VT12001("ComBinlogDump for the VTGate handler")
+	return vh.binlogDump(c, logFile, binlogPos)
 }
 
 // ComBinlogDumpGTID is part of the mysql.Handler interface.
+// The positions are binlog file positions of VTGate, so the GTID set is
+// only accepted when empty, with or without a binlog file to start from.
 func (vh *vtgateHandler) ComBinlogDumpGTID(c *mysql.Conn, logFile string, logPos uint64, gtidSet replication.GTIDSet) error {
-	return vterrors.VT12001("ComBinlogDumpGTID for the VTGate handler")
+	if gtidSet != nil && gtidSet.String() != "" {
+		return sqlerror.NewSQLError(sqlerror.ERMasterFatalReadingBinlog, sqlerror.SSUnknownSQLState,
+			"GTID positions are not supported by VTGate, binlog file positions must be used")
+	}
+	return vh.binlogDump(c, logFile, uint32(logPos))
 }
 
 // KillConnection closes an open connection by connection ID.
@@ -607,8 +710,14 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 		log.Exitf("-mysql-tcp-version must be one of [tcp, tcp4, tcp6]")
 	}
 
+	// Check mysql-server-compression-algorithms
+	algorithms, err := mysql.ParseCompressionAlgorithms(mysqlServerCompressionAlgorithmNames)
+	if err != nil {
+		log.Exitf("-mysql-server-compression-algorithms: %v", err)
+	}
+	mysqlServerCompressionAlgorithms = algorithms
+
 	// Create a Listener.
-	var err error
 	srv := &mysqlServer{}
 	srv.vtgateHandle = newVtgateHandler(vtgate)
 	if mysqlServerPort >= 0 {
@@ -637,6 +746,8 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 			_ = initTLSConfig(context.Background(), srv, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlServerRequireSecureTransport, tlsVersion)
 		}
 		srv.tcpListener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+		srv.tcpListener.AllowLocalInfile.Store(mysqlServerLocalInfile)
+		srv.tcpListener.CompressionAlgorithms = mysqlServerCompressionAlgorithms
 		// Check for the connection threshold
 		if mysqlSlowConnectWarnThreshold != 0 {
 			log.Infof("setting mysql slow connection threshold to %v", mysqlSlowConnectWarnThreshold)
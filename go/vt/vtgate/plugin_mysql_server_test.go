@@ -255,6 +255,37 @@ func TestSpanContextPassedInEvenAroundOtherComments(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSpanContextFromTraceParent(t *testing.T) {
+	traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	// The traceparent is ignored when the tracer cannot use it.
+	_, _, err := startSpanTestable(context.Background(), "SELECT col1 FROM TABLE /*traceparent='"+traceParent+"'*/", "someLabel", newSpanOK, newFromStringFail(t))
+	assert.NoError(t, err)
+
+	defer func(f func() bool) { acceptsTraceParent = f }(acceptsTraceParent)
+	acceptsTraceParent = func() bool { return true }
+
+	_, _, err = startSpanTestable(context.Background(), "SELECT col1 FROM TABLE /*traceparent='"+traceParent+"'*/", "someLabel",
+		newSpanFail(t),
+		newFromStringExpect(t, traceParent))
+	assert.NoError(t, err)
+
+	_, _, err = startSpanTestable(context.Background(), "/*application='app',traceparent='"+traceParent+"'*/SELECT col1 FROM TABLE", "someLabel",
+		newSpanFail(t),
+		newFromStringExpect(t, traceParent))
+	assert.NoError(t, err)
+
+	// VT_SPAN_CONTEXT takes precedence.
+	_, _, err = startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SELECT col1 FROM TABLE /*traceparent='"+traceParent+"'*/", "someLabel",
+		newSpanFail(t),
+		newFromStringExpect(t, "123"))
+	assert.NoError(t, err)
+
+	// A malformed traceparent is ignored.
+	_, _, err = startSpanTestable(context.Background(), "SELECT col1 FROM TABLE /*traceparent='00-123'*/", "someLabel", newSpanOK, newFromStringFail(t))
+	assert.NoError(t, err)
+}
+
 func TestSpanContextNotParsable(t *testing.T) {
 	hasRun := false
 	_, _, err := startSpanTestable(context.Background(), "/*VT_SPAN_CONTEXT=123*/SQL QUERY", "someLabel",
@@ -290,6 +321,21 @@ func TestDefaultWorkloadOLAP(t *testing.T) {
 	}
 }
 
+func TestComChangeUserNewSession(t *testing.T) {
+	vh := &vtgateHandler{}
+	c := &mysql.Conn{}
+	sess := vh.session(c)
+	sess.TargetString = "ks@replica"
+	sess.Autocommit = false
+
+	vh.ComChangeUser(c)
+	newSess := vh.session(c)
+	assert.NotSame(t, sess, newSess)
+	assert.NotEqual(t, sess.SessionUUID, newSess.SessionUUID)
+	assert.Empty(t, newSess.TargetString)
+	assert.True(t, newSess.Autocommit)
+}
+
 func TestInitTLSConfigWithoutServerCA(t *testing.T) {
 	testInitTLSConfig(t, false)
 }
@@ -820,6 +866,73 @@ func TestComQueryMulti(t *testing.T) {
 	}
 }
 
+func TestComStmtExecuteCursor(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+	vh := newVtgateHandler(&VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed})
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), &testHandler{}, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.UserData = &mysql.StaticUserData{}
+	session := vh.session(mysqlConn)
+	session.Options.Workload = querypb.ExecuteOptions_OLTP
+
+	execute := func(query string) int {
+		prepare := &mysql.PrepareData{
+			PrepareStmt: query,
+			BindVars:    map[string]*querypb.BindVariable{},
+			CursorType:  mysql.CursorTypeReadOnly,
+		}
+		callbacks := 0
+		err := vh.ComStmtExecute(mysqlConn, prepare, func(result *sqltypes.Result) error {
+			callbacks++
+			return nil
+		})
+		require.NoError(t, err)
+		return callbacks
+	}
+
+	// A select is streamed on a copy of the session.
+	assert.Greater(t, execute("select id from user"), 1)
+	assert.Same(t, session, vh.session(mysqlConn))
+	assert.Empty(t, session.ShardSessions)
+
+	// The streamed cursor doesn't replace the cancel function of the
+	// current command of the connection, as its rows are fetched by later
+	// commands.
+	canceled := false
+	mysqlConn.UpdateCancelCtx(func() { canceled = true })
+	assert.Greater(t, execute("select id from user"), 1)
+	mysqlConn.CancelCtx()
+	assert.True(t, canceled)
+
+	// Other statements are executed as usual.
+	assert.Equal(t, 1, execute("update user set a = 1 where id = 1"))
+
+	// So are the selects in a transaction.
+	session.InTransaction = true
+	assert.Equal(t, 1, execute("select id from user"))
+
+	// In a transaction in OLAP mode, the results of the select are only
+	// handed out once the execution on the session is done.
+	session.Options.Workload = querypb.ExecuteOptions_OLAP
+	defer func() {
+		session.Options.Workload = querypb.ExecuteOptions_OLTP
+	}()
+	assert.Greater(t, execute("select id from user"), 1)
+	prepare := &mysql.PrepareData{
+		PrepareStmt: "select id from user",
+		BindVars:    map[string]*querypb.BindVariable{},
+		CursorType:  mysql.CursorTypeReadOnly,
+	}
+	errClosed := errors.New("cursor closed")
+	err = vh.ComStmtExecute(mysqlConn, prepare, func(result *sqltypes.Result) error {
+		return errClosed
+	})
+	assert.Equal(t, errClosed, err)
+}
+
 func TestGracefulShutdown(t *testing.T) {
 	executor, _, _, _, _ := createExecutorEnv(t)
 
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
+)
+
+// queryRuleActions counts the queries matched by a query rule, by rule and action.
+var queryRuleActions = stats.NewCountersWithMultiLabels("VtgateQueryRuleActions", "Counts queries matched by a VTGate query rule by rule and action.", []string{"Rule", "Action"})
+
+// planTypes maps the statement types of VTGate to the plan types the rules
+// are written against. Statement types without a counterpart only match
+// rules without a plan condition.
+var planTypes = map[sqlparser.StatementType]planbuilder.PlanType{
+	sqlparser.StmtSelect:   planbuilder.PlanSelect,
+	sqlparser.StmtStream:   planbuilder.PlanSelectStream,
+	sqlparser.StmtInsert:   planbuilder.PlanInsert,
+	sqlparser.StmtReplace:  planbuilder.PlanInsert,
+	sqlparser.StmtUpdate:   planbuilder.PlanUpdate,
+	sqlparser.StmtDelete:   planbuilder.PlanDelete,
+	sqlparser.StmtDDL:      planbuilder.PlanDDL,
+	sqlparser.StmtSet:      planbuilder.PlanSet,
+	sqlparser.StmtShow:     planbuilder.PlanShow,
+	sqlparser.StmtFlush:    planbuilder.PlanFlush,
+	sqlparser.StmtCallProc: planbuilder.PlanCallProc,
+}
+
+// SetQueryRules replaces the query rules applied by the executor. A nil set
+// of rules disables them.
+func (e *Executor) SetQueryRules(qrs *rules.Rules) {
+	e.queryRules.Store(qrs)
+}
+
+// loadQueryRules loads the query rules of the executor from a file in the
+// format of the vttablet query rules.
+func (e *Executor) loadQueryRules(rulesPath string) error {
+	data, err := os.ReadFile(rulesPath)
+	if err != nil {
+		return err
+	}
+	qrs := rules.New()
+	if err := qrs.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	e.SetQueryRules(qrs)
+	return nil
+}
+
+// watchQueryRules loads the query rules from rulesPath and reloads them
+// whenever the file changes, so that a bad query pattern can be mitigated
+// without restarting VTGate.
+func (e *Executor) watchQueryRules(rulesPath string) {
+	if err := e.loadQueryRules(rulesPath); err != nil {
+		log.Fatalf("Unable to load query rules from %q: %v", rulesPath, err)
+	}
+	log.Infof("Loaded query rules from %q", rulesPath)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Fatalf("Unable create new fsnotify watcher: %v", err)
+	}
+	servenv.OnTerm(func() { watcher.Close() })
+
+	ruleFileName := path.Base(rulesPath)
+	go func() {
+		for {
+			select {
+			case evt, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if path.Base(evt.Name) != ruleFileName {
+					continue
+				}
+				// Keep the current rules if the new ones cannot be loaded.
+				if err := e.loadQueryRules(rulesPath); err != nil {
+					log.Errorf("Failed to load query rules from %q: %v", rulesPath, err)
+				} else {
+					log.Infof("Loaded query rules from %q", rulesPath)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Errorf("Error watching %v: %v", rulesPath, err)
+			}
+		}
+	}()
+
+	baseDir := path.Dir(rulesPath)
+	if err = watcher.Add(baseDir); err != nil {
+		log.Fatalf("Unable to set up watcher for %v + %v: %v", baseDir, ruleFileName, err)
+	}
+}
+
+// applyQueryRules applies the query rules matching the query to its
+// execution, up to the first one with a terminal action. It returns an
+// error if the query must not be executed, and otherwise a context to
+// execute it with.
+func (e *Executor) applyQueryRules(
+	ctx context.Context,
+	safeSession *econtext.SafeSession,
+	vcursor *econtext.VCursorImpl,
+	plan *engine.Plan,
+	sql string,
+	bindVars map[string]*querypb.BindVariable,
+) (context.Context, context.CancelFunc, error) {
+	qrs := e.queryRules.Load()
+	if qrs == nil {
+		return ctx, func() {}, nil
+	}
+
+	planType, ok := planTypes[plan.QueryType]
+	if !ok {
+		planType = planbuilder.NumPlans
+	}
+	// Rules can name tables with or without their keyspace.
+	tableNames := make([]string, 0, 2*len(plan.TablesUsed))
+	for _, table := range plan.TablesUsed {
+		tableNames = append(tableNames, table)
+		if _, name, ok := strings.Cut(table, "."); ok {
+			tableNames = append(tableNames, name)
+		}
+	}
+	query, marginComments := sqlparser.SplitMarginComments(sql)
+
+	var remoteAddr, username string
+	if ci, ok := callinfo.FromContext(ctx); ok {
+		remoteAddr = ci.RemoteAddr()
+		username = ci.Username()
+	}
+
+	matches := qrs.FilterByPlan(query, planType, tableNames...).FindMatches(remoteAddr, username, bindVars, marginComments)
+	var timeout time.Duration
+	rerouted := false
+	for _, qr := range matches {
+		queryRuleActions.Add([]string{qr.Description, qr.Action().String()}, 1)
+
+		switch qr.Action() {
+		case rules.QRFail:
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "disallowed due to rule: %s", qr.Description)
+		case rules.QRFailRetry:
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: %s", qr.Description)
+		case rules.QRThrottle:
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "throttled due to rule: %s", qr.Description)
+		case rules.QRMaxExecutionTime:
+			// The smallest max execution time of the matching rules applies.
+			if timeout == 0 || qr.Timeout() < timeout {
+				timeout = qr.Timeout()
+			}
+		case rules.QRWarn:
+			safeSession.RecordWarning(&querypb.QueryWarning{Message: "query matched rule: " + qr.Description})
+		case rules.QRReroute:
+			// Only reads can go to another tablet type, and only outside of a
+			// transaction, which is bound to the primary. The first matching
+			// rule decides the tablet type.
+			if !rerouted && plan.QueryType.IsReadStatement() && !safeSession.InTransaction() && qr.TabletType() != topodatapb.TabletType_PRIMARY {
+				vcursor.SetTabletType(qr.TabletType())
+				rerouted = true
+			}
+		}
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return ctx, cancel, nil
+	}
+	return ctx, func() {}, nil
+}
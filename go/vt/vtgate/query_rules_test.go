@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+)
+
+func TestQueryRules(t *testing.T) {
+	ctx := context.Background()
+	executor, primary, replica := createExecutorEnvWithPrimaryReplicaConn(t, ctx, 0)
+
+	rulesPath := path.Join(t.TempDir(), "rules.json")
+	setRules := func(rules string) {
+		require.NoError(t, os.WriteFile(rulesPath, []byte(rules), 0o644))
+		require.NoError(t, executor.loadQueryRules(rulesPath))
+	}
+	exec := func(session *econtext.SafeSession, sql string) error {
+		_, err := executor.Execute(ctx, nil, "TestQueryRules", session, sql, map[string]*querypb.BindVariable{}, false)
+		return err
+	}
+	newSession := func() *econtext.SafeSession {
+		return econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true})
+	}
+
+	setRules(`[{
+		"Description": "no music",
+		"TableNames": ["music"],
+		"Action": "FAIL"
+	}, {
+		"Description": "rate limit user_extra",
+		"Query": "select .* from user_extra",
+		"Action": "THROTTLE",
+		"MaxQPS": 1
+	}, {
+		"Description": "slow user_metadata",
+		"Plans": ["Select"],
+		"TableNames": ["TestUnsharded.user_metadata"],
+		"Action": "WARN"
+	}, {
+		"Description": "reads of user to replicas",
+		"Query": "select .* from user",
+		"Action": "REROUTE",
+		"TabletType": "replica"
+	}]`)
+
+	err := exec(newSession(), "select id from music")
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+	assert.ErrorContains(t, err, "disallowed due to rule: no music")
+
+	require.NoError(t, exec(newSession(), "select id from user_extra"))
+	err = exec(newSession(), "select id from user_extra")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "throttled due to rule: rate limit user_extra")
+
+	session := newSession()
+	require.NoError(t, exec(session, "select id from user_metadata"))
+	require.Len(t, session.GetWarnings(), 1)
+	assert.Equal(t, "query matched rule: slow user_metadata", session.GetWarnings()[0].Message)
+
+	primary.ClearQueries()
+	require.NoError(t, exec(newSession(), "select id from user"))
+	assert.Empty(t, primary.GetQueries())
+	assert.Len(t, replica.GetQueries(), 1)
+
+	// Reads in a transaction stay on the primary.
+	replica.ClearQueries()
+	session = newSession()
+	require.NoError(t, exec(session, "begin"))
+	require.NoError(t, exec(session, "select id from user"))
+	require.NoError(t, exec(session, "commit"))
+	assert.Len(t, primary.GetQueries(), 1)
+	assert.Empty(t, replica.GetQueries())
+
+	// A warning does not hide the failure of a later rule.
+	setRules(`[{
+		"Description": "slow music",
+		"TableNames": ["music"],
+		"Action": "WARN"
+	}, {
+		"Description": "no music",
+		"TableNames": ["music"],
+		"Action": "FAIL"
+	}]`)
+	err = exec(newSession(), "select id from music")
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+	assert.ErrorContains(t, err, "disallowed due to rule: no music")
+
+	// A rule file that cannot be parsed keeps the current rules.
+	require.NoError(t, os.WriteFile(rulesPath, []byte(`[{"Action": "REROUTE"}]`), 0o644))
+	assert.ErrorContains(t, executor.loadQueryRules(rulesPath), "TabletType missing for Action REROUTE")
+	err = exec(newSession(), "select id from music")
+	assert.ErrorContains(t, err, "disallowed due to rule: no music")
+
+	// Without rules, queries go through.
+	executor.SetQueryRules(nil)
+	require.NoError(t, exec(newSession(), "select id from music"))
+}
@@ -45,6 +45,7 @@ var (
 			<th>RowsAffected per query</th>
 			<th>RowsReturned per query</th>
 			<th>Errors per query</th>
+			<th>Consolidations</th>
 		</tr>
         </thead>
 	`)
@@ -62,6 +63,7 @@ var (
 			<td>{{.RowsAffectedPQ}}</td>
 			<td>{{.RowsReturnedPQ}}</td>
 			<td>{{.ErrorsPQ}}</td>
+			<td>{{.Consolidations}}</td>
 		</tr>
 	`))
 )
@@ -69,15 +71,16 @@ var (
 // queryzRow is used for rendering query stats
 // using go's template.
 type queryzRow struct {
-	Query        string
-	Table        string
-	Count        uint64
-	tm           time.Duration
-	ShardQueries uint64
-	RowsAffected uint64
-	RowsReturned uint64
-	Errors       uint64
-	Color        string
+	Query          string
+	Table          string
+	Count          uint64
+	tm             time.Duration
+	ShardQueries   uint64
+	RowsAffected   uint64
+	RowsReturned   uint64
+	Errors         uint64
+	Consolidations uint64
+	Color          string
 }
 
 // Time returns the total time as a string.
@@ -146,7 +149,7 @@ func queryzHandler(e *Executor, w http.ResponseWriter, r *http.Request) {
 		Value := &queryzRow{
 			Query: logz.Wrappable(e.env.Parser().TruncateForUI(plan.Original)),
 		}
-		Value.Count, Value.tm, Value.ShardQueries, Value.RowsAffected, Value.RowsReturned, Value.Errors = plan.Stats()
+		Value.Count, Value.tm, Value.ShardQueries, Value.RowsAffected, Value.RowsReturned, Value.Errors, Value.Consolidations = plan.Stats()
 		var timepq time.Duration
 		if Value.Count != 0 {
 			timepq = time.Duration(uint64(Value.tm) / Value.Count)
@@ -98,6 +98,7 @@ func TestQueryzHandler(t *testing.T) {
 		`<td>0.000000</td>`,
 		`<td>1.000000</td>`,
 		`<td>0.000000</td>`,
+		`<td>0</td>`,
 		`</tr>`,
 	}
 	checkQueryzHasPlan(t, planPattern1, plan1, body)
@@ -115,6 +116,7 @@ func TestQueryzHandler(t *testing.T) {
 		`<td>0.000000</td>`,
 		`<td>8.000000</td>`,
 		`<td>0.000000</td>`,
+		`<td>0</td>`,
 		`</tr>`,
 	}
 	checkQueryzHasPlan(t, planPattern2, plan2, body)
@@ -132,6 +134,7 @@ func TestQueryzHandler(t *testing.T) {
 		`<td>1.000000</td>`,
 		`<td>0.000000</td>`,
 		`<td>0.000000</td>`,
+		`<td>0</td>`,
 		`</tr>`,
 	}
 	checkQueryzHasPlan(t, planPattern3, plan3, body)
@@ -149,6 +152,7 @@ func TestQueryzHandler(t *testing.T) {
 		`<td>1.000000</td>`,
 		`<td>0.000000</td>`,
 		`<td>0.000000</td>`,
+		`<td>0</td>`,
 		`</tr>`,
 	}
 	checkQueryzHasPlan(t, planPattern4, plan4, body)
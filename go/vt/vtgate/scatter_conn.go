@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/trace"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/log"
@@ -69,7 +70,7 @@ type shardActionFunc func(rs *srvtopo.ResolvedShard, i int) error
 // multiGoTransaction is capable of executing multiple
 // shardActionTransactionFunc actions in parallel and consolidating
 // the results and errors for the caller.
-type shardActionTransactionFunc func(rs *srvtopo.ResolvedShard, i int, shardActionInfo *shardActionInfo) (*shardActionInfo, error)
+type shardActionTransactionFunc func(ctx context.Context, rs *srvtopo.ResolvedShard, i int, shardActionInfo *shardActionInfo) (*shardActionInfo, error)
 
 type (
 	nullResultsObserver struct{}
@@ -177,7 +178,7 @@ func (stc *ScatterConn) ExecuteMultiShard(
 		rss,
 		session,
 		autocommit,
-		func(rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
+		func(ctx context.Context, rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
 			var (
 				innerqr *sqltypes.Result
 				err     error
@@ -409,7 +410,7 @@ func (stc *ScatterConn) StreamExecuteMulti(
 		rss,
 		session,
 		autocommit,
-		func(rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
+		func(ctx context.Context, rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
 			var (
 				err   error
 				opts  *querypb.ExecuteOptions
@@ -689,11 +690,18 @@ func (stc *ScatterConn) multiGoTransaction(
 		startTime, statsKey := stc.startAction(name, rs.Target)
 		defer stc.endAction(startTime, allErrors, statsKey, &err, session)
 
+		// Each shard gets its own span, the parent of the span of the RPC.
+		span, ctx := trace.NewSpan(ctx, "ScatterConn."+name)
+		defer span.Finish()
+		span.Annotate("keyspace", rs.Target.Keyspace)
+		span.Annotate("shard", rs.Target.Shard)
+		span.Annotate("tablet_type", rs.Target.TabletType.String())
+
 		info, shardSession, err := actionInfo(ctx, rs.Target, session, autocommit, stc.txConn.txMode.TransactionMode())
 		if err != nil {
 			return
 		}
-		info, err = action(rs, i, info)
+		info, err = action(ctx, rs, i, info)
 		if info == nil {
 			return
 		}
@@ -479,7 +479,13 @@ func (a *analyzer) noteQuerySignature(node sqlparser.SQLNode) {
 			a.sig.RecursiveCTE = true
 		}
 	case sqlparser.AggrFunc:
+		if sqlparser.IsWindowFunc(node) {
+			a.sig.WindowFunctions = true
+			break
+		}
 		a.sig.Aggregation = true
+	case *sqlparser.ArgumentLessWindowExpr, *sqlparser.FirstOrLastValueExpr, *sqlparser.NtileExpr, *sqlparser.NTHValueExpr, *sqlparser.LagLeadExpr:
+		a.sig.WindowFunctions = true
 	case *sqlparser.Delete, *sqlparser.Update, *sqlparser.Insert:
 		a.sig.DML = true
 	}
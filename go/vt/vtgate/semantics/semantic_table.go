@@ -87,6 +87,7 @@ type (
 		SubQueries      bool
 		Union           bool
 		RecursiveCTE    bool
+		WindowFunctions bool
 		LastInsertIDArg bool // LastInsertIDArg is true if the query has a LAST_INSERT_ID(x) with an argument
 	}
 
@@ -412,6 +412,34 @@ func (cached *NumericStaticMap) CachedSize(alloc bool) int64 {
 	}
 	return size
 }
+func (cached *Range) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(80)
+	}
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field keyType string
+	size += hack.RuntimeAllocSize(int64(len(cached.keyType)))
+	// field splits []vitess.io/vitess/go/vt/vtgate/vindexes.rangeSplit
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.splits)) * int64(48))
+		for _, elem := range cached.splits {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field unknownParams []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownParams)) * int64(16))
+		for _, elem := range cached.unknownParams {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
 func (cached *RegionExperimental) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -645,3 +673,21 @@ func (cached *prefixCFC) CachedSize(alloc bool) int64 {
 	size += cached.cfcCommon.CachedSize(true)
 	return size
 }
+func (cached *rangeSplit) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field key []byte
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.key)))
+	}
+	// field ksid []byte
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.ksid)))
+	}
+	return size
+}
@@ -91,6 +91,7 @@ var availableVindexes = []string{"binary",
 	"unicode_loose_xxhash",
 	"reverse_bits",
 	"region_json",
+	"range",
 	"null"}
 
 // FuzzVindex implements the vindexes fuzzer
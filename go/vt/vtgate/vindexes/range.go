@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"sort"
+
+	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	rangeParamKeyType     = "key_type"
+	rangeParamSplitPoints = "split_points"
+
+	rangeKeyTypeInt      = "int"
+	rangeKeyTypeString   = "string"
+	rangeKeyTypeDatetime = "datetime"
+)
+
+var (
+	_ SingleColumn    = (*Range)(nil)
+	_ Sequential      = (*Range)(nil)
+	_ ParamValidating = (*Range)(nil)
+
+	rangeParams = []string{
+		rangeParamKeyType,
+		rangeParamSplitPoints,
+	}
+)
+
+func init() {
+	Register("range", newRange)
+}
+
+// Range is a unique vindex that maps ordered keys to the keyspace id ranges
+// declared in its split_points param, a JSON object of the first key of each
+// range to the keyspace id, in hex, where the range starts, for example:
+//
+//	{"2024-01-01": "40", "2025-01-01": "80", "2026-01-01": "c0"}
+//
+// A key maps to the keyspace id of the range of the greatest split point that
+// is lower than or equal to it, and the keys lower than the first split point
+// are not mapped. Since the ranges are in the same order as their keys, the
+// rows are stored in the order of their keys, and a range of keys maps to a
+// range of keyspace ids, which lets BETWEEN predicates be routed to a subset
+// of the shards. The split points are meant to match the boundaries of the
+// shards, as all the rows of a range are stored in the shard of its keyspace
+// id.
+//
+// The keys are compared as integers, binary strings or datetimes, depending on
+// the key_type param: int (the default), string or datetime.
+type Range struct {
+	name          string
+	keyType       string
+	splits        []rangeSplit
+	unknownParams []string
+}
+
+// rangeSplit is a split point of a Range vindex.
+type rangeSplit struct {
+	// key is the comparable form of the split point, see Range.comparableKey.
+	key  []byte
+	ksid []byte
+}
+
+// newRange creates a Range vindex.
+func newRange(name string, m map[string]string) (Vindex, error) {
+	vind := &Range{
+		name:          name,
+		keyType:       rangeKeyTypeInt,
+		unknownParams: FindUnknownParams(m, rangeParams),
+	}
+	if keyType, ok := m[rangeParamKeyType]; ok {
+		switch keyType {
+		case rangeKeyTypeInt, rangeKeyTypeString, rangeKeyTypeDatetime:
+			vind.keyType = keyType
+		default:
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "range: invalid key_type %q, must be one of int, string or datetime", keyType)
+		}
+	}
+
+	splitPoints, ok := m[rangeParamSplitPoints]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "range: missing split_points param")
+	}
+	var points map[string]string
+	if err := json.Unmarshal([]byte(splitPoints), &points); err != nil {
+		return nil, vterrors.Wrapf(err, "range: cannot parse split_points")
+	}
+	if len(points) == 0 {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "range: split_points is empty")
+	}
+	for point, ksidHex := range points {
+		k, err := vind.comparableKey(sqltypes.NewVarChar(point))
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "range: invalid split point %q", point)
+		}
+		ksid, err := hex.DecodeString(ksidHex)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "range: invalid keyspace id %q of split point %q", ksidHex, point)
+		}
+		vind.splits = append(vind.splits, rangeSplit{key: k, ksid: ksid})
+	}
+	sort.Slice(vind.splits, func(i, j int) bool {
+		return bytes.Compare(vind.splits[i].key, vind.splits[j].key) < 0
+	})
+	for i := 1; i < len(vind.splits); i++ {
+		if bytes.Equal(vind.splits[i-1].key, vind.splits[i].key) {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "range: duplicate split points")
+		}
+		if bytes.Compare(vind.splits[i-1].ksid, vind.splits[i].ksid) >= 0 {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "range: the keyspace ids of the split points must increase with the split points")
+		}
+	}
+	return vind, nil
+}
+
+// String returns the name of the vindex.
+func (vind *Range) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of this vindex as 1.
+func (*Range) Cost() int {
+	return 1
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (*Range) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (*Range) NeedsVCursor() bool {
+	return false
+}
+
+// Map can map ids to key.ShardDestination objects.
+func (vind *Range) Map(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]key.ShardDestination, error) {
+	out := make([]key.ShardDestination, 0, len(ids))
+	for _, id := range ids {
+		i, err := vind.find(id)
+		if err != nil || i < 0 {
+			out = append(out, key.DestinationNone{})
+			continue
+		}
+		out = append(out, key.DestinationKeyspaceID(vind.splits[i].ksid))
+	}
+	return out, nil
+}
+
+// Verify returns true if ids maps to ksids.
+func (vind *Range) Verify(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, 0, len(ids))
+	for i, id := range ids {
+		s, err := vind.find(id)
+		out = append(out, err == nil && s >= 0 && bytes.Equal(vind.splits[s].ksid, ksids[i]))
+	}
+	return out, nil
+}
+
+// RangeMap maps the range of keys from startId to endId, inclusive, to the
+// range of keyspace ids of their ranges.
+func (vind *Range) RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error) {
+	start, err := vind.find(startId)
+	if err != nil {
+		return nil, err
+	}
+	end, err := vind.find(endId)
+	if err != nil {
+		return nil, err
+	}
+	if end < 0 || end < start {
+		return []key.ShardDestination{key.DestinationNone{}}, nil
+	}
+	kr := key.NewKeyRange(vind.splits[max(start, 0)].ksid, nil)
+	if end+1 < len(vind.splits) {
+		kr.End = vind.splits[end+1].ksid
+	}
+	return []key.ShardDestination{&key.DestinationKeyRange{KeyRange: kr}}, nil
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (vind *Range) UnknownParams() []string {
+	return vind.unknownParams
+}
+
+// find returns the index of the split point of the range of the id, or -1 if
+// the id is lower than the first split point.
+func (vind *Range) find(id sqltypes.Value) (int, error) {
+	k, err := vind.comparableKey(id)
+	if err != nil {
+		return 0, err
+	}
+	return sort.Search(len(vind.splits), func(i int) bool {
+		return bytes.Compare(vind.splits[i].key, k) > 0
+	}) - 1, nil
+}
+
+// comparableKey returns a form of the key that compares with bytes.Compare
+// like the keys compare.
+func (vind *Range) comparableKey(id sqltypes.Value) ([]byte, error) {
+	if id.IsNull() {
+		return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "range: cannot map NULL")
+	}
+	switch vind.keyType {
+	case rangeKeyTypeString:
+		return id.Raw(), nil
+	case rangeKeyTypeDatetime:
+		var dt datetime.DateTime
+		var ok bool
+		if id.IsIntegral() {
+			i, err := id.ToCastInt64()
+			if err != nil {
+				return nil, err
+			}
+			dt, ok = datetime.ParseDateTimeInt64(i)
+		} else {
+			dt, _, ok = datetime.ParseDateTime(id.ToString(), -1)
+			if !ok {
+				var d datetime.Date
+				d, ok = datetime.ParseDate(id.ToString())
+				dt = datetime.DateTime{Date: d}
+			}
+		}
+		if !ok {
+			return nil, vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "range: cannot parse %s as a datetime", id.String())
+		}
+		// The datetimes are formatted with all their digits, so they compare
+		// like strings.
+		return dt.Format(6), nil
+	default:
+		i, err := id.ToCastInt64()
+		if err != nil {
+			return nil, err
+		}
+		// Flipping the sign bit orders the negative numbers first.
+		var k [8]byte
+		binary.BigEndian.PutUint64(k[:], uint64(i)^(math.MaxInt64+1))
+		return k[:], nil
+	}
+}
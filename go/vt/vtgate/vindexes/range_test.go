@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+)
+
+func createRange(t *testing.T, keyType string, splitPoints string) SingleColumn {
+	t.Helper()
+	vindex, err := CreateVindex("range", "range", map[string]string{
+		"key_type":     keyType,
+		"split_points": splitPoints,
+	})
+	require.NoError(t, err)
+	return vindex.(SingleColumn)
+}
+
+func rangeCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectErr error,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "range",
+		vindexName:   "range",
+		vindexParams: vindexParams,
+
+		expectCost:          1,
+		expectErr:           expectErr,
+		expectIsUnique:      true,
+		expectNeedsVCursor:  false,
+		expectString:        "range",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestRangeCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		rangeCreateVindexTestCase(
+			"int keys",
+			map[string]string{"split_points": `{"0": "", "100": "80"}`},
+			nil,
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"string keys",
+			map[string]string{"key_type": "string", "split_points": `{"a": "", "m": "80"}`},
+			nil,
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"datetime keys",
+			map[string]string{"key_type": "datetime", "split_points": `{"2024-01-01": "", "2025-01-01 12:00:00": "80"}`},
+			nil,
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"unknown params",
+			map[string]string{"split_points": `{"0": ""}`, "hello": "world"},
+			nil,
+			[]string{"hello"},
+		),
+		rangeCreateVindexTestCase(
+			"invalid key_type",
+			map[string]string{"key_type": "float", "split_points": `{"0": ""}`},
+			errors.New(`range: invalid key_type "float", must be one of int, string or datetime`),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"missing split_points",
+			nil,
+			errors.New("range: missing split_points param"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"empty split_points",
+			map[string]string{"split_points": `{}`},
+			errors.New("range: split_points is empty"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"invalid split point",
+			map[string]string{"key_type": "datetime", "split_points": `{"yesterday": ""}`},
+			errors.New(`range: invalid split point "yesterday": range: cannot parse VARCHAR("yesterday") as a datetime`),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"invalid keyspace id",
+			map[string]string{"split_points": `{"0": "zz"}`},
+			errors.New(`range: invalid keyspace id "zz" of split point "0": encoding/hex: invalid byte: U+007A 'z'`),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"duplicate split points",
+			map[string]string{"split_points": `{"1": "", "01": "80"}`},
+			errors.New("range: duplicate split points"),
+			nil,
+		),
+		rangeCreateVindexTestCase(
+			"decreasing keyspace ids",
+			map[string]string{"split_points": `{"0": "80", "100": "40"}`},
+			errors.New("range: the keyspace ids of the split points must increase with the split points"),
+			nil,
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func TestRangeMap(t *testing.T) {
+	ctx := context.Background()
+
+	intRange := createRange(t, "int", `{"-100": "", "0": "40", "100": "80", "1000": "c0"}`)
+	got, err := intRange.Map(ctx, nil, []sqltypes.Value{
+		sqltypes.NewInt64(-1000),
+		sqltypes.NewInt64(-100),
+		sqltypes.NewInt64(-1),
+		sqltypes.NewInt64(0),
+		sqltypes.NewVarChar("99"),
+		sqltypes.NewUint64(100),
+		sqltypes.NewInt64(1_000_000),
+		sqltypes.NewVarChar("abc"),
+		sqltypes.NULL,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationNone{},
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte("\x40")),
+		key.DestinationKeyspaceID([]byte("\x40")),
+		key.DestinationKeyspaceID([]byte("\x80")),
+		key.DestinationKeyspaceID([]byte("\xc0")),
+		key.DestinationNone{},
+		key.DestinationNone{},
+	}, got)
+
+	stringRange := createRange(t, "string", `{"b": "", "m": "80"}`)
+	got, err = stringRange.Map(ctx, nil, []sqltypes.Value{
+		sqltypes.NewVarChar("a"),
+		sqltypes.NewVarChar("b"),
+		sqltypes.NewVarChar("lzz"),
+		sqltypes.NewVarChar("m"),
+		sqltypes.NewVarBinary("zebra"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationNone{},
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte("\x80")),
+		key.DestinationKeyspaceID([]byte("\x80")),
+	}, got)
+
+	datetimeRange := createRange(t, "datetime", `{"2024-01-01": "", "2025-01-01": "80", "2025-07-01 12:00:00": "c0"}`)
+	got, err = datetimeRange.Map(ctx, nil, []sqltypes.Value{
+		sqltypes.NewVarChar("2023-12-31 23:59:59.999999"),
+		sqltypes.NewDate("2024-01-01"),
+		sqltypes.NewDatetime("2024-12-31 23:59:59"),
+		sqltypes.NewVarChar("2025-01-01"),
+		sqltypes.NewInt64(20250701115959),
+		sqltypes.NewTimestamp("2025-07-01 12:00:00"),
+		sqltypes.NewVarChar("soon"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationNone{},
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte{}),
+		key.DestinationKeyspaceID([]byte("\x80")),
+		key.DestinationKeyspaceID([]byte("\x80")),
+		key.DestinationKeyspaceID([]byte("\xc0")),
+		key.DestinationNone{},
+	}, got)
+}
+
+func TestRangeVerify(t *testing.T) {
+	vindex := createRange(t, "int", `{"0": "", "100": "80"}`)
+	got, err := vindex.Verify(context.Background(), nil,
+		[]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(1), sqltypes.NewInt64(100), sqltypes.NewInt64(-1), sqltypes.NULL},
+		[][]byte{{}, []byte("\x80"), []byte("\x80"), {}, {}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true, false, false}, got)
+}
+
+func TestRangeRangeMap(t *testing.T) {
+	vindex := createRange(t, "datetime", `{"2024-01-01": "", "2025-01-01": "40", "2026-01-01": "80", "2027-01-01": "c0"}`)
+	cases := []struct {
+		start, end string
+		want       string
+	}{{
+		start: "2025-03-01",
+		end:   "2025-04-01",
+		want:  "DestinationKeyRange(40-80)",
+	}, {
+		start: "2025-03-01",
+		end:   "2026-01-01",
+		want:  "DestinationKeyRange(40-c0)",
+	}, {
+		start: "2020-01-01",
+		end:   "2024-06-01",
+		want:  "DestinationKeyRange(-40)",
+	}, {
+		start: "2026-06-01",
+		end:   "2030-01-01",
+		want:  "DestinationKeyRange(80-)",
+	}, {
+		start: "2020-01-01",
+		end:   "2023-01-01",
+		want:  "DestinationNone()",
+	}, {
+		start: "2026-01-01",
+		end:   "2025-01-01",
+		want:  "DestinationNone()",
+	}}
+	for _, tcase := range cases {
+		t.Run(tcase.start+"/"+tcase.end, func(t *testing.T) {
+			got, err := vindex.(Sequential).RangeMap(context.Background(), nil, sqltypes.NewVarChar(tcase.start), sqltypes.NewVarChar(tcase.end))
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, tcase.want, got[0].String())
+		})
+	}
+
+	_, err := vindex.(Sequential).RangeMap(context.Background(), nil, sqltypes.NULL, sqltypes.NewVarChar("2025-01-01"))
+	assert.EqualError(t, err, "range: cannot map NULL")
+}
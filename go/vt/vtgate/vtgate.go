@@ -168,6 +168,11 @@ var (
 	warmingReadsPercent      = 0
 	warmingReadsQueryTimeout = 5 * time.Second
 	warmingReadsConcurrency  = 500
+
+	// queryRulesFile is the file with the query rules applied by vtgate
+	queryRulesFile string
+
+	enableConsolidator bool
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -205,6 +210,8 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
 	fs.DurationVar(&warmingReadsQueryTimeout, "warming-reads-query-timeout", 5*time.Second, "Timeout of warming read queries")
+	utils.SetFlagBoolVar(fs, &enableConsolidator, "enable-vtgate-consolidator", enableConsolidator, "Let identical read-only queries with identical bind variables and target share the result of the one in flight instead of executing it again. Queries opt in with the VTGATE_CONSOLIDATOR comment directive.")
+	utils.SetFlagStringVar(fs, &queryRulesFile, "query-rules-file", queryRulesFile, "JSON file with query rules, in the format of the vttablet query rules, to apply to the queries before they are executed. The file is reloaded when it changes.")
 
 	viperutil.BindFlags(fs,
 		enableOnlineDDL,
@@ -381,6 +388,7 @@ func Init(
 		AllowScatter:        !noScatter,
 		WarmingReadsPercent: warmingReadsPercent,
 		QueryLogToFile:      queryLogToFile,
+		EnableConsolidator:  enableConsolidator,
 	}
 
 	executor := NewExecutor(ctx, env, serv, cell, resolver, eConfig, warnShardedOnly, plans, si, pv, dynamicConfig)
@@ -389,6 +397,10 @@ func Init(
 		log.Fatalf("error initializing query logger: %v", err)
 	}
 
+	if queryRulesFile != "" {
+		executor.watchQueryRules(queryRulesFile)
+	}
+
 	// connect the schema tracker with the vschema manager
 	if enableSchemaChangeSignal {
 		st.RegisterSignalReceiver(executor.vm.Rebuild)
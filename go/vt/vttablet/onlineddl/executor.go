@@ -443,6 +443,23 @@ func (e *Executor) tableExists(ctx context.Context, tableName string) (bool, err
 	return (row != nil), nil
 }
 
+// declarativeEntityExists checks if the table, view, stored procedure, stored function, trigger or event
+// of a declarative migration exists.
+func (e *Executor) declarativeEntityExists(ctx context.Context, onlineDDL *schema.OnlineDDL) (bool, error) {
+	if !onlineDDL.IsStoredProgram(e.env.Environment().Parser()) {
+		return e.tableExists(ctx, onlineDDL.Table)
+	}
+	ddlStmt, _, err := schema.ParseOnlineDDLStatement(onlineDDL.SQL, e.env.Environment().Parser())
+	if err != nil {
+		return false, err
+	}
+	showCreate, err := e.showCreateStoredProgram(ctx, ddlStmt)
+	if err != nil {
+		return false, err
+	}
+	return showCreate != "", nil
+}
+
 // showCreateTable returns the SHOW CREATE statement for a table or a view
 func (e *Executor) showCreateTable(ctx context.Context, tableName string) (string, error) {
 	parsed := sqlparser.BuildParsedQuery(sqlShowCreateTable, tableName)
@@ -457,6 +474,49 @@ func (e *Executor) showCreateTable(ctx context.Context, tableName string) (strin
 	return row[1].ToString(), nil
 }
 
+// showCreateStoredProgram returns the SHOW CREATE statement for the stored procedure, stored function,
+// trigger or event created or dropped by the given statement, or an empty string if it does not exist.
+func (e *Executor) showCreateStoredProgram(ctx context.Context, ddlStmt sqlparser.DDLStatement) (string, error) {
+	name := ddlStmt.GetTable().Name.String()
+	var existsQuery, showCreateQuery, column string
+	var err error
+	switch ddlStmt.(type) {
+	case *sqlparser.CreateProcedure, *sqlparser.DropProcedure:
+		existsQuery, err = sqlparser.ParseAndBind(sqlSelectRoutineExists, sqltypes.StringBindVariable("PROCEDURE"), sqltypes.StringBindVariable(name))
+		showCreateQuery, column = sqlShowCreateProcedure, "Create Procedure"
+	case *sqlparser.CreateFunction, *sqlparser.DropFunction:
+		existsQuery, err = sqlparser.ParseAndBind(sqlSelectRoutineExists, sqltypes.StringBindVariable("FUNCTION"), sqltypes.StringBindVariable(name))
+		showCreateQuery, column = sqlShowCreateFunction, "Create Function"
+	case *sqlparser.CreateTrigger, *sqlparser.DropTrigger:
+		existsQuery, err = sqlparser.ParseAndBind(sqlSelectTriggerExists, sqltypes.StringBindVariable(name))
+		showCreateQuery, column = sqlShowCreateTrigger, "SQL Original Statement"
+	case *sqlparser.CreateEvent, *sqlparser.DropEvent:
+		existsQuery, err = sqlparser.ParseAndBind(sqlSelectEventExists, sqltypes.StringBindVariable(name))
+		showCreateQuery, column = sqlShowCreateEvent, "Create Event"
+	default:
+		return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "expected a stored procedure, function, trigger or event statement: %v", sqlparser.String(ddlStmt))
+	}
+	if err != nil {
+		return "", err
+	}
+	rs, err := e.execQuery(ctx, existsQuery)
+	if err != nil {
+		return "", err
+	}
+	if len(rs.Rows) == 0 {
+		return "", nil
+	}
+	rs, err = e.execQuery(ctx, sqlparser.BuildParsedQuery(showCreateQuery, name).Query)
+	if err != nil {
+		return "", err
+	}
+	row := rs.Named().Row()
+	if row == nil {
+		return "", nil
+	}
+	return row.AsString(column, ""), nil
+}
+
 // getCreateTableStatement gets a formal AlterTable representation of the given table
 func (e *Executor) getCreateTableStatement(ctx context.Context, tableName string) (*sqlparser.CreateTable, error) {
 	showCreateTable, err := e.showCreateTable(ctx, tableName)
@@ -1958,6 +2018,9 @@ func (e *Executor) validateMigrationRevertible(ctx context.Context, revertMigrat
 	if err != nil {
 		return err
 	}
+	if revertMigration.IsStoredProgram(e.env.Environment().Parser()) {
+		return fmt.Errorf("cannot revert migration %s: migrations of stored procedures, functions, triggers and events are not revertible", revertMigration.UUID)
+	}
 	switch action {
 	case sqlparser.AlterDDLAction:
 		if revertMigration.Strategy != schema.DDLStrategyOnline && revertMigration.Strategy != schema.DDLStrategyVitess {
@@ -2208,6 +2271,164 @@ func (e *Executor) evaluateDeclarativeDiff(ctx context.Context, onlineDDL *schem
 	return diff, nil
 }
 
+// evaluateDeclarativeStoredProgramDiff is called for -declarative CREATE statements of stored procedures,
+// stored functions, triggers and events which already exist. The function generates a diff, which can be:
+// - empty, in which case the migration is noop and implicitly successful, or
+// - a DROP followed by a CREATE, as MySQL cannot modify the definition of these in place
+// It also returns the existing definition, as shown by SHOW CREATE.
+func (e *Executor) evaluateDeclarativeStoredProgramDiff(ctx context.Context, ddlStmt sqlparser.DDLStatement) (diff schemadiff.EntityDiff, existingShowCreate string, err error) {
+	existingShowCreate, err = e.showCreateStoredProgram(ctx, ddlStmt)
+	if err != nil {
+		return nil, "", vterrors.Wrapf(err, "in evaluateDeclarativeStoredProgramDiff()")
+	}
+	if existingShowCreate == "" {
+		return nil, "", vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "unexpected: cannot find %v", ddlStmt.GetTable().Name.String())
+	}
+	existingStmt, err := e.env.Environment().Parser().ParseStrictDDL(existingShowCreate)
+	if err != nil {
+		return nil, "", err
+	}
+	existing, ok := existingStmt.(sqlparser.DDLStatement)
+	if !ok {
+		return nil, "", schemadiff.ErrEntityTypeMismatch
+	}
+	senv := schemadiff.NewEnv(e.env.Environment(), e.env.Environment().CollationEnv().DefaultConnectionCharset())
+	diff, err = diffStoredPrograms(senv, existing, ddlStmt)
+	return diff, existingShowCreate, err
+}
+
+// diffStoredPrograms returns the diff from the existing definition of a stored procedure, stored function,
+// trigger or event, as shown by SHOW CREATE, to its declared definition. MySQL fills in the DEFINER, and the
+// STARTS, ON COMPLETION and status clauses of events, when they are not given: these are only compared when
+// they are declared.
+func diffStoredPrograms(env *schemadiff.Environment, existing sqlparser.DDLStatement, declared sqlparser.DDLStatement) (schemadiff.EntityDiff, error) {
+	existing = sqlparser.Clone(existing)
+	declared = sqlparser.Clone(declared)
+	existing.SetComments(nil)
+	declared.SetComments(nil)
+
+	hints := &schemadiff.DiffHints{}
+	switch declared := declared.(type) {
+	case *sqlparser.CreateProcedure:
+		existing, ok := existing.(*sqlparser.CreateProcedure)
+		if !ok {
+			return nil, schemadiff.ErrEntityTypeMismatch
+		}
+		if declared.Definer == nil {
+			existing.Definer = nil
+		}
+		from, err := schemadiff.NewCreateProcedureEntity(env, existing)
+		if err != nil {
+			return nil, err
+		}
+		to, err := schemadiff.NewCreateProcedureEntity(env, declared)
+		if err != nil {
+			return nil, err
+		}
+		return from.Diff(to, hints)
+	case *sqlparser.CreateFunction:
+		existing, ok := existing.(*sqlparser.CreateFunction)
+		if !ok {
+			return nil, schemadiff.ErrEntityTypeMismatch
+		}
+		if declared.Definer == nil {
+			existing.Definer = nil
+		}
+		from, err := schemadiff.NewCreateFunctionEntity(env, existing)
+		if err != nil {
+			return nil, err
+		}
+		to, err := schemadiff.NewCreateFunctionEntity(env, declared)
+		if err != nil {
+			return nil, err
+		}
+		return from.Diff(to, hints)
+	case *sqlparser.CreateTrigger:
+		existing, ok := existing.(*sqlparser.CreateTrigger)
+		if !ok {
+			return nil, schemadiff.ErrEntityTypeMismatch
+		}
+		if declared.Definer == nil {
+			existing.Definer = nil
+		}
+		from, err := schemadiff.NewCreateTriggerEntity(env, existing)
+		if err != nil {
+			return nil, err
+		}
+		to, err := schemadiff.NewCreateTriggerEntity(env, declared)
+		if err != nil {
+			return nil, err
+		}
+		return from.Diff(to, hints)
+	case *sqlparser.CreateEvent:
+		existing, ok := existing.(*sqlparser.CreateEvent)
+		if !ok {
+			return nil, schemadiff.ErrEntityTypeMismatch
+		}
+		if declared.Definer == nil {
+			existing.Definer = nil
+		}
+		if declared.Schedule != nil && existing.Schedule != nil && declared.Schedule.Starts == nil {
+			// MySQL starts recurring events at the time they are created.
+			existing.Schedule.Starts = nil
+		}
+		if declared.OnCompletion == sqlparser.DefaultOnCompletion && existing.OnCompletion == sqlparser.NotPreserveOnCompletion {
+			existing.OnCompletion = sqlparser.DefaultOnCompletion
+		}
+		if declared.Status == sqlparser.DefaultEventStatus && existing.Status == sqlparser.EnableEventStatus {
+			existing.Status = sqlparser.DefaultEventStatus
+		}
+		from, err := schemadiff.NewCreateEventEntity(env, existing)
+		if err != nil {
+			return nil, err
+		}
+		to, err := schemadiff.NewCreateEventEntity(env, declared)
+		if err != nil {
+			return nil, err
+		}
+		return from.Diff(to, hints)
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "expected CREATE PROCEDURE, FUNCTION, TRIGGER or EVENT: %v", sqlparser.String(declared))
+}
+
+// executeReplaceStoredProgramMigration replaces the definition of a stored procedure, stored function, trigger
+// or event in a declarative migration. MySQL cannot modify these in place, so the existing definition is dropped
+// and the declared one is created right after, on the same connection. Should the CREATE fail, the existing
+// definition, as shown by SHOW CREATE, is created again.
+func (e *Executor) executeReplaceStoredProgramMigration(ctx context.Context, onlineDDL *schema.OnlineDDL, diff schemadiff.EntityDiff, existingShowCreate string) error {
+	failMigration := func(err error) error {
+		return e.failMigration(ctx, onlineDDL, err)
+	}
+	e.migrationMutex.Lock()
+	defer e.migrationMutex.Unlock()
+
+	conn, err := dbconnpool.NewDBConnection(ctx, e.env.Config().DB.DbaWithDB())
+	if err != nil {
+		return failMigration(err)
+	}
+	defer conn.Close()
+
+	restoreSQLModeFunc, err := e.initMigrationSQLMode(ctx, onlineDDL, conn)
+	defer restoreSQLModeFunc()
+	if err != nil {
+		return failMigration(err)
+	}
+
+	_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusRunning, false, progressPctStarted, etaSecondsUnknown, rowsCopiedUnknown, emptyHint)
+	if _, err := conn.ExecuteFetch(diff.CanonicalStatementString(), 0, false); err != nil {
+		return failMigration(err)
+	}
+	defer e.reloadSchema(ctx)
+	if _, err := conn.ExecuteFetch(onlineDDL.SQL, 0, false); err != nil {
+		if _, restoreErr := conn.ExecuteFetch(existingShowCreate, 0, false); restoreErr != nil {
+			return failMigration(vterrors.Errorf(vtrpcpb.Code_UNKNOWN, "%v; the existing definition of %v could not be restored: %v", err, onlineDDL.Table, restoreErr))
+		}
+		return failMigration(err)
+	}
+	_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusComplete, false, progressPctFull, etaSecondsNow, rowsCopiedUnknown, emptyHint)
+	return nil
+}
+
 // getCompletedMigrationByContextAndSQL checks if there exists a completed migration with exact same
 // context and SQL as given migration. If so, it returns its UUID.
 func (e *Executor) getCompletedMigrationByContextAndSQL(ctx context.Context, onlineDDL *schema.OnlineDDL) (completedUUID string, err error) {
@@ -2334,6 +2555,14 @@ func (e *Executor) executeDropDDLActionMigration(ctx context.Context, onlineDDL
 	e.migrationMutex.Lock()
 	defer e.migrationMutex.Unlock()
 
+	if onlineDDL.IsStoredProgram(e.env.Environment().Parser()) {
+		// Stored procedures, functions, triggers and events hold no data. They are dropped directly.
+		if _, err := e.executeDirectly(ctx, onlineDDL); err != nil {
+			return failMigration(err)
+		}
+		return nil
+	}
+
 	// Drop statement.
 	// Normally, we're going to modify DROP to RENAME (see later on). But if table name is
 	// already a GC-lifecycle table, then we don't put it through yet another GC lifecycle,
@@ -2390,6 +2619,14 @@ func (e *Executor) executeCreateDDLActionMigration(ctx context.Context, onlineDD
 	e.migrationMutex.Lock()
 	defer e.migrationMutex.Unlock()
 
+	if onlineDDL.IsStoredProgram(e.env.Environment().Parser()) {
+		// Stored procedures, functions, triggers and events are created directly.
+		if _, err := e.executeDirectly(ctx, onlineDDL); err != nil {
+			return failMigration(err)
+		}
+		return nil
+	}
+
 	ddlStmt, _, err := schema.ParseOnlineDDLStatement(onlineDDL.SQL, e.env.Environment().Parser())
 	if err != nil {
 		return failMigration(err)
@@ -2753,7 +2990,7 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 					return failMigration(vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "strategy is declarative. IF EXISTS does not work in declarative mode for migration %v", onlineDDL.UUID))
 				}
 			}
-			exists, err := e.tableExists(ctx, onlineDDL.Table)
+			exists, err := e.declarativeEntityExists(ctx, onlineDDL)
 			if err != nil {
 				return failMigration(err)
 			}
@@ -2783,11 +3020,28 @@ func (e *Executor) executeMigration(ctx context.Context, onlineDDL *schema.Onlin
 				return failMigration(vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "strategy is declarative. OR REPLACE does not work in declarative mode for migration %v", onlineDDL.UUID))
 			}
 
-			exists, err := e.tableExists(ctx, onlineDDL.Table)
+			exists, err := e.declarativeEntityExists(ctx, onlineDDL)
 			if err != nil {
 				return failMigration(err)
 			}
-			if exists {
+			if exists && onlineDDL.IsStoredProgram(e.env.Environment().Parser()) {
+				diff, existingShowCreate, err := e.evaluateDeclarativeStoredProgramDiff(ctx, ddlStmt)
+				if err != nil {
+					return failMigration(err)
+				}
+				if diff == nil || diff.IsEmpty() {
+					// No diff! We mark this CREATE as implicitly successful
+					_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusComplete, false, progressPctFull, etaSecondsNow, rowsCopiedUnknown, emptyHint)
+					_ = e.updateMigrationMessage(ctx, onlineDDL.UUID, "no change")
+					return nil
+				}
+				// The definition is different. We drop it and create it anew.
+				_ = e.updateMigrationMessage(ctx, onlineDDL.UUID, diff.CanonicalStatementString()+"; "+diff.SubsequentDiff().CanonicalStatementString())
+				go func() error {
+					return e.executeReplaceStoredProgramMigration(ctx, onlineDDL, diff, existingShowCreate)
+				}()
+				return nil
+			} else if exists {
 				diff, err := e.evaluateDeclarativeDiff(ctx, onlineDDL)
 				if err != nil {
 					return failMigration(err)
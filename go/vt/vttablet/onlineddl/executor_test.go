@@ -26,6 +26,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
 )
 
 func TestShouldCutOverAccordingToBackoff(t *testing.T) {
@@ -229,3 +232,80 @@ func TestSafeMigrationCutOverThreshold(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffStoredPrograms(t *testing.T) {
+	tcases := []struct {
+		name     string
+		existing string
+		declared string
+		expect   string
+	}{
+		{
+			name:     "identical trigger with implicit definer",
+			existing: "CREATE DEFINER=`root`@`localhost` TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW set new.i = 1",
+			declared: "create trigger tr before insert on t for each row set new.i = 1",
+		},
+		{
+			name:     "modified trigger",
+			existing: "CREATE DEFINER=`root`@`localhost` TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW set new.i = 1",
+			declared: "create trigger tr before insert on t for each row set new.i = 2",
+			expect:   "DROP TRIGGER `tr`; CREATE TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW SET NEW.`i` = 2;",
+		},
+		{
+			name:     "modified definer",
+			existing: "CREATE DEFINER=`root`@`localhost` PROCEDURE `p`()\nselect 1",
+			declared: "create definer=`app`@`%` procedure p() select 1",
+			expect:   "DROP PROCEDURE `p`; CREATE DEFINER = app@`%` PROCEDURE `p` () SELECT 1 FROM `dual`;",
+		},
+		{
+			name:     "identical function",
+			existing: "CREATE DEFINER=`root`@`localhost` FUNCTION `f`() RETURNS int\n    DETERMINISTIC\nreturn 1",
+			declared: "create function f() returns int deterministic return 1",
+		},
+		{
+			name:     "identical event with implicit clauses",
+			existing: "CREATE DEFINER=`root`@`localhost` EVENT `e` ON SCHEDULE EVERY 1 HOUR STARTS '2024-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO delete from t",
+			declared: "create event e on schedule every 1 hour do delete from t",
+		},
+		{
+			name:     "modified event schedule",
+			existing: "CREATE DEFINER=`root`@`localhost` EVENT `e` ON SCHEDULE EVERY 1 HOUR STARTS '2024-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO delete from t",
+			declared: "create event e on schedule every 2 hour do delete from t",
+			expect:   "DROP EVENT `e`; CREATE EVENT `e` ON SCHEDULE EVERY 2 hour DO DELETE FROM `t`;",
+		},
+		{
+			name:     "modified event status",
+			existing: "CREATE DEFINER=`root`@`localhost` EVENT `e` ON SCHEDULE EVERY 1 HOUR STARTS '2024-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO delete from t",
+			declared: "create event e on schedule every 1 hour disable do delete from t",
+			expect:   "DROP EVENT `e`; CREATE EVENT `e` ON SCHEDULE EVERY 1 hour DISABLE DO DELETE FROM `t`;",
+		},
+	}
+	env := schemadiff.NewTestEnv()
+	parser := sqlparser.NewTestParser()
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			existing, err := parser.ParseStrictDDL(tcase.existing)
+			require.NoError(t, err)
+			declared, err := parser.ParseStrictDDL(tcase.declared)
+			require.NoError(t, err)
+
+			diff, err := diffStoredPrograms(env, existing.(sqlparser.DDLStatement), declared.(sqlparser.DDLStatement))
+			require.NoError(t, err)
+			if tcase.expect == "" {
+				assert.True(t, diff == nil || diff.IsEmpty())
+				return
+			}
+			require.False(t, diff.IsEmpty())
+			assert.Equal(t, tcase.expect, diff.CanonicalStatementString()+"; "+diff.SubsequentDiff().CanonicalStatementString())
+		})
+	}
+
+	t.Run("mismatching types", func(t *testing.T) {
+		existing, err := parser.ParseStrictDDL("create procedure p() select 1")
+		require.NoError(t, err)
+		declared, err := parser.ParseStrictDDL("create function p() returns int return 1")
+		require.NoError(t, err)
+		_, err = diffStoredPrograms(env, existing.(sqlparser.DDLStatement), declared.(sqlparser.DDLStatement))
+		assert.ErrorIs(t, err, schemadiff.ErrEntityTypeMismatch)
+	})
+}
@@ -498,6 +498,13 @@ const (
 	sqlAnalyzeTableLocal                   = "ANALYZE NO_WRITE_TO_BINLOG TABLE `%a`"
 	sqlAnalyzeTable                        = "ANALYZE TABLE `%a`"
 	sqlShowCreateTable                     = "SHOW CREATE TABLE `%a`"
+	sqlShowCreateProcedure                 = "SHOW CREATE PROCEDURE `%a`"
+	sqlShowCreateFunction                  = "SHOW CREATE FUNCTION `%a`"
+	sqlShowCreateTrigger                   = "SHOW CREATE TRIGGER `%a`"
+	sqlShowCreateEvent                     = "SHOW CREATE EVENT `%a`"
+	sqlSelectRoutineExists                 = "SELECT 1 FROM information_schema.ROUTINES WHERE ROUTINE_SCHEMA=DATABASE() AND ROUTINE_TYPE=%a AND ROUTINE_NAME=%a"
+	sqlSelectTriggerExists                 = "SELECT 1 FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA=DATABASE() AND TRIGGER_NAME=%a"
+	sqlSelectEventExists                   = "SELECT 1 FROM information_schema.EVENTS WHERE EVENT_SCHEMA=DATABASE() AND EVENT_NAME=%a"
 	sqlShowVariablesLikePreserveForeignKey = "show global variables like 'rename_table_preserve_foreign_key'"
 	sqlShowVariablesLikeFastAnalyzeTable   = "show global variables like 'fast_analyze_table'"
 	sqlEnableFastAnalyzeTable              = "set @@fast_analyze_table = 1"
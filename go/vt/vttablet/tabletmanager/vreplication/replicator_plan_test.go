@@ -322,6 +322,58 @@ func TestBuildPlayerPlan(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		// sum of an expression
+		input: &binlogdatapb.Filter{
+			Rules: []*binlogdatapb.Rule{{
+				Match:  "t1",
+				Filter: "select c1, sum(c2 * c3) as c4 from t2 group by c1",
+			}},
+		},
+		plan: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t2",
+					Filter: "select c1, c2 * c3 as c4 from t2",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t2": {
+					TargetName:   "t1",
+					SendRule:     "t2",
+					PKReferences: []string{"c1"},
+					InsertFront:  "insert into t1(c1,c4)",
+					InsertValues: "(:a_c1,ifnull(:a_c4, 0))",
+					InsertOnDup:  " on duplicate key update c4=c4+ifnull(values(c4), 0)",
+					Insert:       "insert into t1(c1,c4) values (:a_c1,ifnull(:a_c4, 0)) on duplicate key update c4=c4+ifnull(values(c4), 0)",
+					Update:       "update t1 set c4=c4-ifnull(:b_c4, 0)+ifnull(:a_c4, 0) where c1=:b_c1",
+					Delete:       "update t1 set c4=c4-ifnull(:b_c4, 0) where c1=:b_c1",
+				},
+			},
+		},
+		planpk: &TestReplicatorPlan{
+			VStreamFilter: &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{
+					Match:  "t2",
+					Filter: "select c1, c2 * c3 as c4, pk1, pk2 from t2",
+				}},
+			},
+			TargetTables: []string{"t1"},
+			TablePlans: map[string]*TestTablePlan{
+				"t2": {
+					TargetName:   "t1",
+					SendRule:     "t2",
+					PKReferences: []string{"c1", "pk1", "pk2"},
+					InsertFront:  "insert into t1(c1,c4)",
+					InsertValues: "(:a_c1,ifnull(:a_c4, 0))",
+					InsertOnDup:  " on duplicate key update c4=c4+ifnull(values(c4), 0)",
+					Insert:       "insert into t1(c1,c4) select :a_c1, ifnull(:a_c4, 0) from dual where (:a_pk1,:a_pk2) <= (1,'aaa') on duplicate key update c4=c4+ifnull(values(c4), 0)",
+					Update:       "update t1 set c4=c4-ifnull(:b_c4, 0)+ifnull(:a_c4, 0) where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+					Delete:       "update t1 set c4=c4-ifnull(:b_c4, 0) where c1=:b_c1 and (:b_pk1,:b_pk2) <= (1,'aaa')",
+				},
+			},
+		},
 	}, {
 		input: &binlogdatapb.Filter{
 			Rules: []*binlogdatapb.Rule{{
@@ -687,14 +739,14 @@ func TestBuildPlayerPlan(t *testing.T) {
 		},
 		err: "failed to build table replication plan for t1 table: syntax error at position 14 in query: select sum(a, b) as c from t1",
 	}, {
-		// no complex expr in sum
+		// no subquery in sum
 		input: &binlogdatapb.Filter{
 			Rules: []*binlogdatapb.Rule{{
 				Match:  "t1",
-				Filter: "select sum(a + b) as c from t1",
+				Filter: "select sum(a + (select b from t2)) as c from t1",
 			}},
 		},
-		err: "failed to build table replication plan for t1 table: unsupported non-column name in sum clause: sum(a + b) in query: select sum(a + b) as c from t1",
+		err: "failed to build table replication plan for t1 table: unsupported subquery: (select b from t2) in query: select sum(a + (select b from t2)) as c from t1",
 	}, {
 		// no complex expr in group by
 		input: &binlogdatapb.Filter{
@@ -500,7 +500,25 @@ func (tpb *tablePlanBuilder) analyzeExpr(selExpr sqlparser.SelectExpr) (*colExpr
 			}
 			innerCol, ok := expr.GetArg().(*sqlparser.ColName)
 			if !ok {
-				return nil, fmt.Errorf("unsupported non-column name in sum clause: %v", sqlparser.String(expr))
+				// The vstreamer evaluates the expression and sends its
+				// value as a column named after the target column.
+				err := sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+					switch node := node.(type) {
+					case *sqlparser.Subquery:
+						return false, fmt.Errorf("unsupported subquery: %v", sqlparser.String(node))
+					case sqlparser.AggrFunc:
+						return false, fmt.Errorf("unsupported aggregation function in sum clause: %v", sqlparser.String(node))
+					}
+					return true, nil
+				}, expr.GetArg())
+				if err != nil {
+					return nil, err
+				}
+				tpb.sendSelect.AddSelectExpr(&sqlparser.AliasedExpr{Expr: expr.GetArg(), As: as})
+				cexpr.operation = opSum
+				cexpr.expr = &sqlparser.ColName{Name: as}
+				cexpr.references[as.String()] = true
+				return cexpr, nil
 			}
 			if !innerCol.Qualifier.IsEmpty() {
 				return nil, fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(innerCol))
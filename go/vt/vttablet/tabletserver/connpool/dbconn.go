@@ -122,6 +122,7 @@ func (dbc *Conn) Err() error {
 // and retry. A failed reconnect will trigger a CheckMySQL.
 func (dbc *Conn) Exec(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
 	span, ctx := trace.NewSpan(ctx, "DBConn.Exec")
+	trace.AnnotateSQL(span, sqlparser.Preview(query))
 	defer span.Finish()
 
 	for attempt := 1; attempt <= 2; attempt++ {
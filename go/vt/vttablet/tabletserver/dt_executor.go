@@ -27,7 +27,6 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
-	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 )
@@ -96,12 +95,9 @@ func (dte *DTExecutor) Prepare(transactionID int64, dtid string) error {
 	// regarding OnlineDDL or MoveTables.
 	for _, query := range queries {
 		qr := dte.qe.queryRuleSources.FilterByPlan(query.Sql, 0, query.Tables...)
-		if qr != nil {
-			act, _, _, _ := qr.GetAction("", "", nil, sqlparser.MarginComments{})
-			if act != rules.QRContinue {
-				dte.te.txPool.RollbackAndRelease(dte.ctx, conn)
-				return vterrors.VT10002("cannot prepare the transaction due to query rule")
-			}
+		if qr != nil && qr.Rejects("", "", nil, sqlparser.MarginComments{}) {
+			dte.te.txPool.RollbackAndRelease(dte.ctx, conn)
+			return vterrors.VT10002("cannot prepare the transaction due to query rule")
 		}
 	}
 
@@ -116,13 +112,10 @@ func (dte *DTExecutor) Prepare(transactionID int64, dtid string) error {
 	// This check helps reject the prepare that came later.
 	for _, query := range queries {
 		qr := dte.qe.queryRuleSources.FilterByPlan(query.Sql, 0, query.Tables...)
-		if qr != nil {
-			act, _, _, _ := qr.GetAction("", "", nil, sqlparser.MarginComments{})
-			if act != rules.QRContinue {
-				dte.te.txPool.RollbackAndRelease(dte.ctx, conn)
-				dte.te.preparedPool.FetchForRollback(dtid)
-				return vterrors.VT10002("cannot prepare the transaction due to query rule")
-			}
+		if qr != nil && qr.Rejects("", "", nil, sqlparser.MarginComments{}) {
+			dte.te.txPool.RollbackAndRelease(dte.ctx, conn)
+			dte.te.preparedPool.FetchForRollback(dtid)
+			return vterrors.VT10002("cannot prepare the transaction due to query rule")
 		}
 	}
 
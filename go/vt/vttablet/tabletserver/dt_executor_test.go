@@ -214,6 +214,39 @@ func TestExecutorPrepareRuleFailure(t *testing.T) {
 	require.EqualError(t, err, "VT10002: atomic distributed transaction not allowed: cannot prepare the transaction due to query rule")
 }
 
+func TestExecutorPrepareRuleNotRejecting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	txe, tsv, _, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+
+	// The rules which let the queries run don't fail the prepare.
+	warnRule := rules.NewQueryRule("warn update", "warn update", rules.QRWarn)
+	warnRule.AddTableCond("test_table")
+	rerouteRule := rules.NewQueryRule("reroute update", "reroute update", rules.QRReroute)
+	rerouteRule.AddTableCond("test_table")
+
+	r := rules.New()
+	r.Add(warnRule)
+	r.Add(rerouteRule)
+	txe.qe.queryRuleSources.RegisterSource("warnQuery")
+	err := txe.qe.queryRuleSources.SetRules("warnQuery", r)
+	require.NoError(t, err)
+
+	txid := newTxForPrep(ctx, tsv)
+	sc, err := tsv.te.txPool.GetAndLock(txid, "adding query property")
+	require.NoError(t, err)
+	sc.txProps.Queries = append(sc.txProps.Queries, tx.Query{
+		Sql:    "update test_table set col = 5",
+		Tables: []string{"test_table"},
+	})
+	sc.Unlock()
+
+	err = txe.Prepare(txid, "aa")
+	defer txe.RollbackPrepared("aa", 0)
+	require.NoError(t, err)
+}
+
 func TestExecutorPrepareConnFailure(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -53,6 +53,7 @@ type QueryGenerator interface {
 	GenerateAckQuery(ids []string) (string, map[string]*querypb.BindVariable)
 	GeneratePostponeQuery(ids []string) (string, map[string]*querypb.BindVariable)
 	GeneratePurgeQuery(timeCutoff int64) (string, map[string]*querypb.BindVariable)
+	GenerateDeadLetterQueries(ids []string) []*querypb.BoundQuery
 }
 
 type messageReceiver struct {
@@ -166,6 +167,12 @@ type receiverWithStatus struct {
 // The Purge thread
 // This thread is mostly independent. It wakes up periodically
 // to delete old rows that were successfully acked.
+//
+// Dead-lettering
+// If the table has a max number of attempts, the send loop does not
+// send the messages that were already sent that many times. Instead,
+// they are asynchronously moved to the dead-letter table, marked as
+// failed with the dead-letter column, or, if neither are set, acked.
 type messageManager struct {
 	tsv TabletService
 	vs  VStreamer
@@ -176,6 +183,7 @@ type messageManager struct {
 	purgeAfter   time.Duration
 	minBackoff   time.Duration
 	maxBackoff   time.Duration
+	maxAttempts  int64
 	batchSize    int
 	pollerTicks  *timer.Timer
 	purgeTicks   *timer.Timer
@@ -236,6 +244,10 @@ type messageManager struct {
 	ackQuery                  *sqlparser.ParsedQuery
 	postponeQuery             *sqlparser.ParsedQuery
 	purgeQuery                *sqlparser.ParsedQuery
+	deadLetterQuery           *sqlparser.ParsedQuery
+	// ackDeadLetters is set if the dead-lettered messages are
+	// acked, which is the case unless they're marked as failed.
+	ackDeadLetters bool
 
 	// idType is the type of the id column in the message table.
 	idType sqltypes.Type
@@ -256,6 +268,7 @@ func newMessageManager(tsv TabletService, vs VStreamer, table *schema.Table, pos
 		purgeAfter:      table.MessageInfo.PurgeAfterDuration,
 		minBackoff:      table.MessageInfo.MinBackoff,
 		maxBackoff:      table.MessageInfo.MaxBackoff,
+		maxAttempts:     int64(table.MessageInfo.MaxAttempts),
 		batchSize:       table.MessageInfo.BatchSize,
 		cache:           newCache(table.MessageInfo.CacheSize),
 		pollerTicks:     timer.NewTimer(table.MessageInfo.PollInterval),
@@ -287,6 +300,19 @@ func newMessageManager(tsv TabletService, vs VStreamer, table *schema.Table, pos
 
 	mm.postponeQuery = buildPostponeQuery(mm.name, mm.minBackoff, mm.maxBackoff)
 
+	mm.ackDeadLetters = table.MessageInfo.DeadLetterColumn == ""
+	switch {
+	case table.MessageInfo.DeadLetterTable != "":
+		// The messages are sent by the dead-letter table as new messages.
+		mm.deadLetterQuery = sqlparser.BuildParsedQuery(
+			"insert into %v(priority, time_next, %s) select priority, %a, %s from %v where id in %a and time_acked is null",
+			sqlparser.NewIdentifierCS(table.MessageInfo.DeadLetterTable), columnList, ":time_now", columnList, mm.name, "::ids")
+	case table.MessageInfo.DeadLetterColumn != "":
+		mm.deadLetterQuery = sqlparser.BuildParsedQuery(
+			"update %v set %v = 1, time_next = null where id in %a and time_acked is null",
+			mm.name, sqlparser.NewIdentifierCI(table.MessageInfo.DeadLetterColumn), "::ids")
+	}
+
 	return mm
 }
 
@@ -553,17 +579,26 @@ func (mm *messageManager) runSend() {
 
 			// Fetch rows from cache.
 			lateCount := int64(0)
+			var deadIDs []string
 			for i := 0; i < mm.batchSize; i++ {
 				mr := mm.cache.Pop()
 				if mr == nil {
 					break
 				}
+				if mm.maxAttempts > 0 && mr.Epoch >= mm.maxAttempts {
+					deadIDs = append(deadIDs, mr.Row[0].ToString())
+					continue
+				}
 				if mr.Epoch >= 1 {
 					lateCount++
 				}
 				rows = append(rows, mr.Row)
 			}
 			MessageStats.Add([]string{mm.name.String(), "Delayed"}, lateCount)
+			if deadIDs != nil {
+				mm.wg.Add(1)
+				go mm.deadLetter(context.Background(), deadIDs) // calls the offsetting mm.wg.Done()
+			}
 
 			// If we have rows to send, break out of this loop.
 			if rows != nil {
@@ -646,6 +681,39 @@ func (mm *messageManager) postpone(ctx context.Context, tsv TabletService, ackWa
 	return nil
 }
 
+// deadLetter dead-letters the messages that were sent mm.maxAttempts times.
+func (mm *messageManager) deadLetter(ctx context.Context, ids []string) {
+	defer func() {
+		mm.tsv.LogError()
+		mm.wg.Done()
+	}()
+
+	defer func() {
+		// Like in send, hold cacheManagementMu to prevent the poller
+		// from requeuing a snapshot of the messages.
+		mm.cacheManagementMu.Lock()
+		defer mm.cacheManagementMu.Unlock()
+		mm.cache.Discard(ids)
+	}()
+
+	// Use the semaphore to limit parallelism.
+	if err := mm.postponeSema.Acquire(ctx, 1); err != nil {
+		// Only happens if context is cancelled.
+		return
+	}
+	defer mm.postponeSema.Release(1)
+	ctx, cancel := context.WithTimeout(tabletenv.LocalContext(), mm.ackWaitTime)
+	defer cancel()
+	count, err := mm.tsv.DeadLetterMessages(ctx, nil, mm, ids)
+	if err != nil {
+		// The messages will be dead-lettered again the next time they're due.
+		MessageStats.Add([]string{mm.name.String(), "DeadLetterFailed"}, 1)
+		log.Errorf("messageManager (%v) - Unable to dead-letter messages: %v", mm.name, err)
+		return
+	}
+	MessageStats.Add([]string{mm.name.String(), "DeadLettered"}, count)
+}
+
 func (mm *messageManager) startVStream() {
 	if mm.streamCancel != nil {
 		return
@@ -769,6 +837,11 @@ func (mm *messageManager) processRowEvent(fields []*querypb.Field, rowEvent *bin
 		if mr.TimeAcked != 0 || mr.TimeNext > now {
 			continue
 		}
+		// The messages marked with the dead-letter column have no time_next.
+		// Like the poller, skip them.
+		if mm.maxAttempts > 0 && mr.Epoch >= mm.maxAttempts && row[1].IsNull() {
+			continue
+		}
 		mm.Add(mr)
 	}
 	return nil
@@ -905,6 +978,26 @@ func (mm *messageManager) GeneratePurgeQuery(timeCutoff int64) (string, map[stri
 	}
 }
 
+// GenerateDeadLetterQueries returns the queries and bind vars for
+// dead-lettering messages. They must be executed in one transaction.
+func (mm *messageManager) GenerateDeadLetterQueries(ids []string) []*querypb.BoundQuery {
+	ackQuery, ackBindVars := mm.GenerateAckQuery(ids)
+	var queries []*querypb.BoundQuery
+	if mm.deadLetterQuery != nil {
+		queries = append(queries, &querypb.BoundQuery{
+			Sql: mm.deadLetterQuery.Query,
+			BindVariables: map[string]*querypb.BindVariable{
+				"time_now": ackBindVars["time_acked"],
+				"ids":      ackBindVars["ids"],
+			},
+		})
+	}
+	if mm.ackDeadLetters {
+		queries = append(queries, &querypb.BoundQuery{Sql: ackQuery, BindVariables: ackBindVars})
+	}
+	return queries
+}
+
 // BuildMessageRow builds a MessageRow from a db row.
 func BuildMessageRow(row []sqltypes.Value) (*MessageRow, error) {
 	mr := &MessageRow{Row: row[4:]}
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/semaphore"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -789,6 +790,72 @@ func TestMMGenerate(t *testing.T) {
 	}
 }
 
+func TestMessageManagerDeadLetter(t *testing.T) {
+	tsv := newFakeTabletServer()
+	ti := newMMTable()
+	ti.MessageInfo.MaxAttempts = 2
+	mm := newMessageManager(tsv, newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	mm.Open()
+	defer mm.Close()
+
+	r1 := newTestReceiver(1)
+	mm.Subscribe(context.Background(), r1.rcv)
+	<-r1.ch
+
+	ch := make(chan string, 20)
+	tsv.SetChannel(ch)
+	// The message was already sent twice, so it's dead-lettered instead.
+	mm.Add(&MessageRow{Epoch: 2, Row: []sqltypes.Value{sqltypes.NewVarBinary("1"), sqltypes.NULL}})
+	if got, want := <-ch, "deadletter"; got != want {
+		t.Errorf("DeadLetter: %s, want %v", got, want)
+	}
+	mm.Add(&MessageRow{Epoch: 1, Row: []sqltypes.Value{sqltypes.NewVarBinary("2"), sqltypes.NULL}})
+	want := &sqltypes.Result{
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewVarBinary("2"),
+			sqltypes.NULL,
+		}},
+	}
+	if got := <-r1.ch; !got.Equal(want) {
+		t.Errorf("Received: %v, want %v", got, want)
+	}
+	if got, want := <-ch, "postpone"; got != want {
+		t.Errorf("Postpone: %s, want %v", got, want)
+	}
+	assert.EqualValues(t, 1, tsv.deadLetterCount.Load())
+}
+
+func TestMMGenerateDeadLetterQueries(t *testing.T) {
+	wantids := sqltypes.TestBindVariable([]any{[]byte{'1'}, []byte{'2'}})
+	wantAckQuery := "update foo set time_acked = :time_acked, time_next = null where id in ::ids and time_acked is null"
+
+	// Without a dead-letter target, the messages are acked.
+	ti := newMMTable()
+	ti.MessageInfo.MaxAttempts = 3
+	mm := newMessageManager(newFakeTabletServer(), newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	queries := mm.GenerateDeadLetterQueries([]string{"1", "2"})
+	require.Len(t, queries, 1)
+	assert.Equal(t, wantAckQuery, queries[0].Sql)
+	utils.MustMatch(t, wantids, queries[0].BindVariables["ids"])
+
+	ti.MessageInfo.DeadLetterTable = "foo_dlt"
+	mm = newMessageManager(newFakeTabletServer(), newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	queries = mm.GenerateDeadLetterQueries([]string{"1", "2"})
+	require.Len(t, queries, 2)
+	assert.Equal(t, "insert into foo_dlt(priority, time_next, id, message) select priority, :time_now, id, message from foo where id in ::ids and time_acked is null", queries[0].Sql)
+	utils.MustMatch(t, wantids, queries[0].BindVariables["ids"])
+	assert.Equal(t, queries[1].BindVariables["time_acked"], queries[0].BindVariables["time_now"])
+	assert.Equal(t, wantAckQuery, queries[1].Sql)
+
+	ti.MessageInfo.DeadLetterTable = ""
+	ti.MessageInfo.DeadLetterColumn = "failed"
+	mm = newMessageManager(newFakeTabletServer(), newFakeVStreamer(), ti, semaphore.NewWeighted(1))
+	queries = mm.GenerateDeadLetterQueries([]string{"1", "2"})
+	require.Len(t, queries, 1)
+	assert.Equal(t, "update foo set failed = 1, time_next = null where id in ::ids and time_acked is null", queries[0].Sql)
+	utils.MustMatch(t, wantids, queries[0].BindVariables["ids"])
+}
+
 func TestMMGenerateWithBackoff(t *testing.T) {
 	mm := newMessageManager(newFakeTabletServer(), newFakeVStreamer(), newMMTableWithBackoff(), semaphore.NewWeighted(1))
 	mm.Open()
@@ -826,8 +893,9 @@ func TestMMGenerateWithBackoff(t *testing.T) {
 
 type fakeTabletServer struct {
 	tabletenv.Env
-	postponeCount atomic.Int64
-	purgeCount    atomic.Int64
+	postponeCount   atomic.Int64
+	purgeCount      atomic.Int64
+	deadLetterCount atomic.Int64
 
 	mu sync.Mutex
 	ch chan string
@@ -870,6 +938,17 @@ func (fts *fakeTabletServer) PurgeMessages(ctx context.Context, target *querypb.
 	return 0, nil
 }
 
+func (fts *fakeTabletServer) DeadLetterMessages(ctx context.Context, target *querypb.Target, gen QueryGenerator, ids []string) (count int64, err error) {
+	fts.deadLetterCount.Add(1)
+	fts.mu.Lock()
+	ch := fts.ch
+	fts.mu.Unlock()
+	if ch != nil {
+		ch <- "deadletter"
+	}
+	return int64(len(ids)), nil
+}
+
 type fakeVStreamer struct {
 	streamInvocations atomic.Int64
 	mu                sync.Mutex
@@ -30,9 +30,9 @@ import (
 
 func analyzeUnion(stmt *sqlparser.Union, noRowslimit bool) *Plan {
 	if noRowslimit {
-		return &Plan{PlanID: PlanSelect, FullQuery: GenerateFullQuery(stmt)}
+		return &Plan{PlanID: PlanSelect, FullQuery: GenerateFullQuery(stmt), SelectStmt: stmt}
 	}
-	return &Plan{PlanID: PlanSelect, FullQuery: GenerateLimitQuery(stmt)}
+	return &Plan{PlanID: PlanSelect, FullQuery: GenerateLimitQuery(stmt), SelectStmt: withExecLimit(stmt)}
 }
 
 func analyzeSelect(env *vtenv.Environment, sel *sqlparser.Select, tables map[string]*schema.Table, noRowsLimit bool) (plan *Plan, err error) {
@@ -41,9 +41,11 @@ func analyzeSelect(env *vtenv.Environment, sel *sqlparser.Select, tables map[str
 	if noRowsLimit {
 		plan.PlanID = PlanSelectNoLimit
 		plan.FullQuery = GenerateFullQuery(sel)
+		plan.SelectStmt = sel
 	} else {
 		plan.PlanID = PlanSelect
 		plan.FullQuery = GenerateLimitQuery(sel)
+		plan.SelectStmt = withExecLimit(sel)
 	}
 
 	plan.Table = lookupTables(sel.From, tables)
@@ -71,6 +73,7 @@ func analyzeSelect(env *vtenv.Environment, sel *sqlparser.Select, tables map[str
 		}
 		plan.NextCount = v
 		plan.FullQuery = nil
+		plan.SelectStmt = nil
 	}
 
 	if hasLockFunc(sel) {
@@ -94,18 +97,26 @@ func analyzeUpdate(upd *sqlparser.Update, tables map[string]*schema.Table) (plan
 		plan.WhereClause = buf.ParsedQuery()
 	}
 
+	if plan.Table != nil {
+		plan.PKWhereClause = analyzePKWhereClause(plan.Table, upd.Where)
+	}
+
 	// Situations when we pass-through:
 	// PassthroughDMLs flag is set.
 	// plan.Table==nil: it's likely a multi-table statement. MySQL doesn't allow limit clauses for multi-table dmls.
 	// If there's an explicit Limit.
 	if PassthroughDMLs || plan.Table == nil || upd.Limit != nil {
 		plan.FullQuery = GenerateFullQuery(upd)
+		if plan.Table != nil {
+			plan.Increment = analyzeIncrement(upd)
+		}
 		return plan, nil
 	}
 
 	plan.PlanID = PlanUpdateLimit
 	upd.Limit = execLimit
 	plan.FullQuery = GenerateFullQuery(upd)
+	plan.Increment = analyzeIncrement(upd)
 	upd.Limit = nil
 	return plan, nil
 }
@@ -123,6 +134,10 @@ func analyzeDelete(del *sqlparser.Delete, tables map[string]*schema.Table) (plan
 		plan.WhereClause = buf.ParsedQuery()
 	}
 
+	if plan.Table != nil {
+		plan.PKWhereClause = analyzePKWhereClause(plan.Table, del.Where)
+	}
+
 	if PassthroughDMLs || plan.Table == nil || del.Limit != nil {
 		plan.FullQuery = GenerateFullQuery(del)
 		return plan, nil
@@ -134,6 +149,111 @@ func analyzeDelete(del *sqlparser.Delete, tables map[string]*schema.Table) (plan
 	return plan, nil
 }
 
+// analyzePKWhereClause returns the equalities of the WHERE clause which pin
+// the primary key of table, in primary key order. It returns nil if the
+// WHERE clause does not pin every primary key column to a value.
+func analyzePKWhereClause(table *schema.Table, where *sqlparser.Where) *sqlparser.ParsedQuery {
+	if where == nil || !table.HasPrimary() {
+		return nil
+	}
+	values := make([]sqlparser.Expr, len(table.PKColumns))
+	for _, expr := range sqlparser.SplitAndExpression(nil, where.Expr) {
+		cmp, ok := expr.(*sqlparser.ComparisonExpr)
+		if !ok || cmp.Operator != sqlparser.EqualOp {
+			continue
+		}
+		col, ok := cmp.Left.(*sqlparser.ColName)
+		val := cmp.Right
+		if !ok {
+			col, ok = cmp.Right.(*sqlparser.ColName)
+			val = cmp.Left
+		}
+		if !ok || !isValue(val) {
+			continue
+		}
+		for i, pkCol := range table.PKColumns {
+			if col.Name.EqualString(table.Fields[pkCol].Name) {
+				values[i] = val
+			}
+		}
+	}
+
+	buf := sqlparser.NewTrackedBuffer(nil)
+	for i, val := range values {
+		if val == nil {
+			return nil
+		}
+		if i == 0 {
+			buf.WriteString(" where ")
+		} else {
+			buf.WriteString(" and ")
+		}
+		buf.Myprintf("%v = %v", sqlparser.NewColName(table.Fields[table.PKColumns[i]].Name), val)
+	}
+	return buf.ParsedQuery()
+}
+
+// analyzeIncrement returns the IncrementPlan for an UPDATE which only adds an
+// integer to or subtracts an integer from columns e.g.
+// "update t set a = a + 1, b = b - :v where id = 5". It returns nil for any
+// other UPDATE. The limit of upd must already be set up as for the full query.
+func analyzeIncrement(upd *sqlparser.Update) *IncrementPlan {
+	incr := &IncrementPlan{}
+	exprs := make(sqlparser.UpdateExprs, 0, len(upd.Exprs))
+	for i, expr := range upd.Exprs {
+		op, ok := expr.Expr.(*sqlparser.BinaryExpr)
+		if !ok {
+			return nil
+		}
+		var delta sqlparser.Expr
+		negate := false
+		switch {
+		case op.Operator == sqlparser.PlusOp && isSameColumn(expr.Name, op.Left):
+			delta = op.Right
+		case op.Operator == sqlparser.PlusOp && isSameColumn(expr.Name, op.Right):
+			delta = op.Left
+		case op.Operator == sqlparser.MinusOp && isSameColumn(expr.Name, op.Left):
+			delta = op.Right
+			negate = true
+		default:
+			return nil
+		}
+		if lit, ok := delta.(*sqlparser.Literal); ok && lit.Type != sqlparser.IntVal {
+			return nil
+		}
+		if !isValue(delta) {
+			return nil
+		}
+		incr.Deltas = append(incr.Deltas, delta)
+		incr.Negate = append(incr.Negate, negate)
+		exprs = append(exprs, &sqlparser.UpdateExpr{
+			Name: expr.Name,
+			Expr: &sqlparser.BinaryExpr{Operator: sqlparser.PlusOp, Left: expr.Name, Right: sqlparser.NewArgument(IncrementBindVar(i))},
+		})
+	}
+
+	orig := upd.Exprs
+	upd.Exprs = exprs
+	incr.Query = GenerateFullQuery(upd)
+	upd.Exprs = orig
+	return incr
+}
+
+// isValue returns true if expr is a literal or a bind variable.
+func isValue(expr sqlparser.Expr) bool {
+	switch expr.(type) {
+	case *sqlparser.Literal, *sqlparser.Argument:
+		return true
+	}
+	return false
+}
+
+// isSameColumn returns true if expr refers to the column col.
+func isSameColumn(col *sqlparser.ColName, expr sqlparser.Expr) bool {
+	other, ok := expr.(*sqlparser.ColName)
+	return ok && col.Name.Equal(other.Name) && col.Qualifier == other.Qualifier
+}
+
 func analyzeInsert(ins *sqlparser.Insert, tables map[string]*schema.Table) (plan *Plan, err error) {
 	plan = &Plan{
 		PlanID:    PlanInsert,
@@ -23,6 +23,31 @@ type cachedObject interface {
 	CachedSize(alloc bool) int64
 }
 
+func (cached *IncrementPlan) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Deltas []vitess.io/vitess/go/vt/sqlparser.Expr
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Deltas)) * int64(16))
+		for _, elem := range cached.Deltas {
+			if cc, ok := elem.(cachedObject); ok {
+				size += cc.CachedSize(true)
+			}
+		}
+	}
+	// field Negate []bool
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Negate)))
+	}
+	// field Query *vitess.io/vitess/go/vt/sqlparser.ParsedQuery
+	size += cached.Query.CachedSize(true)
+	return size
+}
 func (cached *Permission) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -41,7 +66,7 @@ func (cached *Plan) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(128)
+		size += int64(160)
 	}
 	// field Table *vitess.io/vitess/go/vt/vttablet/tabletserver/schema.Table
 	size += cached.Table.CachedSize(true)
@@ -61,12 +86,20 @@ func (cached *Plan) CachedSize(alloc bool) int64 {
 	}
 	// field FullQuery *vitess.io/vitess/go/vt/sqlparser.ParsedQuery
 	size += cached.FullQuery.CachedSize(true)
+	// field SelectStmt vitess.io/vitess/go/vt/sqlparser.SelectStatement
+	if cc, ok := cached.SelectStmt.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
 	// field NextCount vitess.io/vitess/go/vt/vtgate/evalengine.Expr
 	if cc, ok := cached.NextCount.(cachedObject); ok {
 		size += cc.CachedSize(true)
 	}
 	// field WhereClause *vitess.io/vitess/go/vt/sqlparser.ParsedQuery
 	size += cached.WhereClause.CachedSize(true)
+	// field PKWhereClause *vitess.io/vitess/go/vt/sqlparser.ParsedQuery
+	size += cached.PKWhereClause.CachedSize(true)
+	// field Increment *vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder.IncrementPlan
+	size += cached.Increment.CachedSize(true)
 	// field FullStmt vitess.io/vitess/go/vt/sqlparser.Statement
 	if cc, ok := cached.FullStmt.(cachedObject); ok {
 		size += cc.CachedSize(true)
@@ -18,6 +18,7 @@ package planbuilder
 
 import (
 	"encoding/json"
+	"strconv"
 	"strings"
 
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -166,6 +167,11 @@ type Plan struct {
 	// FullQuery will be set for all plans.
 	FullQuery *sqlparser.ParsedQuery
 
+	// SelectStmt is set for the selects. It is the statement of FullQuery,
+	// which is formatted again to add the MAX_EXECUTION_TIME optimizer hint
+	// of the query rules.
+	SelectStmt sqlparser.SelectStatement
+
 	// NextCount stores the count for "select next".
 	NextCount evalengine.Expr
 
@@ -173,6 +179,17 @@ type Plan struct {
 	// to serialize e.g. UPDATEs going to the same row.
 	WhereClause *sqlparser.ParsedQuery
 
+	// PKWhereClause is set for single table DMLs whose WHERE clause pins every
+	// primary key column with an equality. It only contains these equalities,
+	// in primary key order, and lets the hot row protection identify the row
+	// independently of the other conditions of the WHERE clause.
+	PKWhereClause *sqlparser.ParsedQuery
+
+	// Increment is set for single table UPDATEs which only add to or subtract
+	// from columns. It is used by the hot row protection to merge such UPDATEs
+	// to the same row into one statement.
+	Increment *IncrementPlan
+
 	// FullStmt can be used when the query does not operate on tables
 	FullStmt sqlparser.Statement
 
@@ -180,6 +197,26 @@ type Plan struct {
 	NeedsReservedConn bool
 }
 
+// IncrementPlan describes an UPDATE like
+// "update t set a = a + 1, b = b - :v where id = 5".
+type IncrementPlan struct {
+	// Deltas holds the value added to each of the updated columns. It is
+	// either an integer literal or a bind variable.
+	Deltas []sqlparser.Expr
+	// Negate is true for the columns the delta is subtracted from.
+	Negate []bool
+	// Query is the full query of the plan with the deltas replaced by the
+	// bind variables IncrementBindVar(i). All deltas are added in Query,
+	// i.e. the bind variables carry the sign.
+	Query *sqlparser.ParsedQuery
+}
+
+// IncrementBindVar returns the name of the bind variable which holds the
+// i-th delta of IncrementPlan.Query.
+func IncrementBindVar(i int) string {
+	return "__hrp_delta" + strconv.Itoa(i)
+}
+
 // TableName returns the table name for the plan.
 func (plan *Plan) TableName() sqlparser.IdentifierCS {
 	var tableName sqlparser.IdentifierCS
@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -47,13 +48,19 @@ func (p *Plan) MarshalJSON() ([]byte, error) {
 		FullQuery         *sqlparser.ParsedQuery `json:",omitempty"`
 		NextCount         string                 `json:",omitempty"`
 		WhereClause       *sqlparser.ParsedQuery `json:",omitempty"`
+		PKWhereClause     *sqlparser.ParsedQuery `json:",omitempty"`
+		IncrementQuery    *sqlparser.ParsedQuery `json:",omitempty"`
 		NeedsReservedConn bool                   `json:",omitempty"`
 	}{
-		PlanID:      p.PlanID,
-		TableName:   p.TableName(),
-		Permissions: p.Permissions,
-		FullQuery:   p.FullQuery,
-		WhereClause: p.WhereClause,
+		PlanID:        p.PlanID,
+		TableName:     p.TableName(),
+		Permissions:   p.Permissions,
+		FullQuery:     p.FullQuery,
+		WhereClause:   p.WhereClause,
+		PKWhereClause: p.PKWhereClause,
+	}
+	if p.Increment != nil {
+		mplan.IncrementQuery = p.Increment.Query
 	}
 	if p.NextCount != nil {
 		mplan.NextCount = sqlparser.String(p.NextCount)
@@ -387,3 +394,37 @@ func iterateExecFile(name string) (testCaseIterator chan testCase) {
 func locateFile(name string) string {
 	return "testdata/" + name
 }
+
+func TestGenerateMaxExecutionTimeQuery(t *testing.T) {
+	testSchema := loadSchema("schema_test.json")
+	parser := sqlparser.NewTestParser()
+	testcases := []struct {
+		input, want string
+	}{{
+		input: "select * from a",
+		want:  "select /*+ MAX_EXECUTION_TIME(1500) */ * from a limit :#maxLimit",
+	}, {
+		input: "SELECT * FROM a LIMIT 10",
+		want:  "select /*+ MAX_EXECUTION_TIME(1500) */ * from a limit 10",
+	}, {
+		input: "select /*+ SET_VAR(sort_buffer_size = 16M) */ /* app */ * from a",
+		want:  "select /*+ SET_VAR(sort_buffer_size = 16M) MAX_EXECUTION_TIME(1500) */ /* app */ * from a limit :#maxLimit",
+	}, {
+		input: "select eid from a union select eid from b",
+		want:  "select /*+ MAX_EXECUTION_TIME(1500) */ eid from a union select eid from b limit :#maxLimit",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			statement, err := parser.Parse(tc.input)
+			require.NoError(t, err)
+			plan, err := Build(vtenv.NewTestEnv(), statement, testSchema, "dbName", false)
+			require.NoError(t, err)
+			query, err := GenerateMaxExecutionTimeQuery(plan.SelectStmt, 1500*time.Millisecond)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, query.Query)
+
+			// The plan is left unchanged.
+			require.NotContains(t, plan.FullQuery.Query, "MAX_EXECUTION_TIME")
+		})
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package planbuilder
 
 import (
+	"fmt"
+	"time"
+
 	"vitess.io/vitess/go/vt/sqlparser"
 )
 
@@ -27,6 +30,39 @@ func GenerateFullQuery(statement sqlparser.Statement) *sqlparser.ParsedQuery {
 	return buf.ParsedQuery()
 }
 
+// withExecLimit returns the select statement with the limit clause of
+// GenerateLimitQuery, as a copy if the statement has no limit.
+func withExecLimit(selStmt sqlparser.SelectStatement) sqlparser.SelectStatement {
+	switch sel := selStmt.(type) {
+	case *sqlparser.Select:
+		if sel.Limit == nil {
+			sel = sqlparser.Clone(sel)
+			sel.Limit = execLimit
+			return sel
+		}
+	case *sqlparser.Union:
+		if sel.Limit == nil {
+			sel = sqlparser.Clone(sel)
+			sel.Limit = execLimit
+			return sel
+		}
+	}
+	return selStmt
+}
+
+// GenerateMaxExecutionTimeQuery generates a select query with the
+// MAX_EXECUTION_TIME optimizer hint, merged into the optimizer hints of its
+// first select, where MySQL reads the hints of the statement.
+func GenerateMaxExecutionTimeQuery(selStmt sqlparser.SelectStatement, maxExecutionTime time.Duration) (*sqlparser.ParsedQuery, error) {
+	selStmt = sqlparser.Clone(selStmt)
+	comments, err := selStmt.GetParsedComments().AddQueryHint(fmt.Sprintf("MAX_EXECUTION_TIME(%d)", maxExecutionTime.Milliseconds()))
+	if err != nil {
+		return nil, err
+	}
+	selStmt.SetComments(comments)
+	return GenerateFullQuery(selStmt), nil
+}
+
 // GenerateLimitQuery generates a select query with a limit clause.
 func GenerateLimitQuery(selStmt sqlparser.SelectStatement) *sqlparser.ParsedQuery {
 	buf := sqlparser.NewTrackedBuffer(nil)
@@ -51,6 +51,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txserializer"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/userquota"
 )
 
 // _______________________________________________
@@ -159,6 +160,10 @@ type QueryEngine struct {
 	conns       *connpool.Pool
 	streamConns *connpool.Pool
 
+	// Per-user quotas of the pools
+	connQuotas       userquota.Limiter
+	streamConnQuotas userquota.Limiter
+
 	// Services
 	consolidator       sync2.Consolidator
 	streamConsolidator *StreamConsolidator
@@ -189,12 +194,14 @@ type QueryEngine struct {
 	// Note: queryErrorCountsWithCode is similar to queryErrorCounts except it contains error code as an additional dimension
 	queryCounts, queryCountsWithTabletType, queryTimes, queryErrorCounts, queryErrorCountsWithCode, queryRowsAffected, queryRowsReturned, queryTextCharsProcessed *stats.CountersWithMultiLabels
 	queryEnginePlanCacheHits, queryEnginePlanCacheMisses                                                                                                          *stats.CounterFunc
+	queryRuleWarnings                                                                                                                                             *stats.CountersWithSingleLabel
 
 	// stats flags
 	enablePerWorkloadTableMetrics bool
 
 	// Loggers
 	accessCheckerLogger *logutil.ThrottledLogger
+	queryRuleLogger     *logutil.ThrottledLogger
 
 	redactUIQuery bool
 }
@@ -230,6 +237,8 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 
 	qe.conns = connpool.NewPool(env, "ConnPool", config.OltpReadPool)
 	qe.streamConns = connpool.NewPool(env, "StreamConnPool", config.OlapReadPool)
+	qe.connQuotas = userquota.New(env, userquota.OltpPool, qe.conns.Capacity, config.OltpReadPool.Timeout)
+	qe.streamConnQuotas = userquota.New(env, userquota.OlapPool, qe.streamConns.Capacity, config.OlapReadPool.Timeout)
 	qe.consolidatorMode.Store(config.Consolidator)
 	qe.consolidator = sync2.NewConsolidator()
 	if config.ConsolidatorStreamTotalSize > 0 && config.ConsolidatorStreamQuerySize > 0 {
@@ -266,6 +275,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	planbuilder.PassthroughDMLs = config.PassthroughDML
 
 	qe.accessCheckerLogger = logutil.NewThrottledLogger("accessChecker", 1*time.Second)
+	qe.queryRuleLogger = logutil.NewThrottledLogger("queryRule", 1*time.Second)
 
 	env.Exporter().NewGaugeFunc("MaxResultSize", "Query engine max result size", qe.maxResultSize.Load)
 	env.Exporter().NewGaugeFunc("WarnResultSize", "Query engine warn result size", qe.warnResultSize.Load)
@@ -309,6 +319,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	qe.queryTextCharsProcessed = env.Exporter().NewCountersWithMultiLabels("QueryTextCharactersProcessed", "query text characters processed", labels)
 	qe.queryErrorCounts = env.Exporter().NewCountersWithMultiLabels("QueryErrorCounts", "query error counts", labels)
 	qe.queryErrorCountsWithCode = env.Exporter().NewCountersWithMultiLabels("QueryErrorCountsWithCode", "query error counts with error code", []string{"Table", "Plan", "Code"})
+	qe.queryRuleWarnings = env.Exporter().NewCountersWithSingleLabel("QueryRuleWarnings", "queries matched by a query rule with the WARN action", "Rule")
 
 	env.Exporter().HandleFunc("/debug/hotrows", qe.txSerializer.ServeHTTP)
 	env.Exporter().HandleFunc("/debug/tablet_plans", qe.handleHTTPQueryPlans)
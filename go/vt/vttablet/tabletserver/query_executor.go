@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"strings"
 	"sync"
 	"time"
@@ -49,6 +50,7 @@ import (
 	eschema "vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/userquota"
 )
 
 // QueryExecutor is used for executing a query request.
@@ -67,6 +69,11 @@ type QueryExecutor struct {
 	// The target type we requested might be different from tsv's tablet type, if we had a change to the tablet type recently.
 	targetTabletType topodatapb.TabletType
 	setting          *smartconnpool.Setting
+	// maxExecutionTime is set by a query rule with the MAX_EXECUTION_TIME action.
+	maxExecutionTime time.Duration
+	// permissionsChecked is set if checkPermissions already ran for this
+	// query, e.g. before it joined a batch of coalesced UPDATEs.
+	permissionsChecked bool
 }
 
 const (
@@ -154,8 +161,15 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		qre.tsv.Stats().ResultHistogram.Add(int64(len(reply.Rows)))
 	}(time.Now())
 
-	if err = qre.checkPermissions(); err != nil {
-		return nil, err
+	if !qre.permissionsChecked {
+		if err = qre.checkPermissions(); err != nil {
+			return nil, err
+		}
+	}
+	if qre.maxExecutionTime > 0 {
+		var cancel context.CancelFunc
+		qre.ctx, cancel = context.WithTimeout(qre.ctx, qre.maxExecutionTime)
+		defer cancel()
 	}
 
 	if qre.plan.PlanID == p.PlanNextval {
@@ -233,6 +247,28 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] %s unexpected plan type", qre.plan.PlanID.String())
 }
 
+// executeIncrement executes the IncrementPlan of an UPDATE with the given
+// deltas instead of the original query. The hot row protection uses it to
+// execute the UPDATEs which were coalesced with this one.
+func (qre *QueryExecutor) executeIncrement(deltas []int64) (*sqltypes.Result, error) {
+	bindVars := maps.Clone(qre.bindVars)
+	for i, delta := range deltas {
+		bindVars[p.IncrementBindVar(i)] = sqltypes.Int64BindVariable(delta)
+	}
+	plan := *qre.plan.Plan
+	plan.FullQuery = plan.Increment.Query
+
+	incr := *qre
+	incr.bindVars = bindVars
+	incr.plan = &TabletPlan{
+		Plan:       &plan,
+		Original:   qre.plan.Original,
+		Rules:      qre.plan.Rules,
+		Authorized: qre.plan.Authorized,
+	}
+	return incr.Execute()
+}
+
 func (qre *QueryExecutor) execAutocommit(f func(conn *StatefulConnection) (*sqltypes.Result, error)) (reply *sqltypes.Result, err error) {
 	if qre.options == nil {
 		qre.options = &querypb.ExecuteOptions{}
@@ -349,6 +385,11 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 	if err := qre.checkPermissions(); err != nil {
 		return err
 	}
+	if qre.maxExecutionTime > 0 {
+		var cancel context.CancelFunc
+		qre.ctx, cancel = context.WithTimeout(qre.ctx, qre.maxExecutionTime)
+		defer cancel()
+	}
 
 	switch qre.plan.PlanID {
 	case p.PlanSelectStream:
@@ -371,11 +412,11 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		if qre.connID == 0 && qre.plan.PlanID == p.PlanSelectStream && qre.shouldConsolidate() {
 			return consolidator.Consolidate(qre.tsv.stats.WaitTimings, qre.logStats, sqlWithoutComments, callback,
 				func(callback StreamCallback) error {
-					dbConn, err := qre.getStreamConn()
+					dbConn, recycle, err := qre.getStreamConn()
 					if err != nil {
 						return err
 					}
-					defer dbConn.Recycle()
+					defer recycle()
 					return qre.execStreamSQL(dbConn, qre.connID != 0, sql, func(result *sqltypes.Result) error {
 						// this stream result is potentially used by more than one client, so
 						// the consolidator will return it to the pool once it knows it's no longer
@@ -405,11 +446,11 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		}
 		conn = txConn.UnderlyingDBConn()
 	} else {
-		dbConn, err := qre.getStreamConn()
+		dbConn, recycle, err := qre.getStreamConn()
 		if err != nil {
 			return err
 		}
-		defer dbConn.Recycle()
+		defer recycle()
 		conn = dbConn
 	}
 
@@ -472,30 +513,10 @@ func (qre *QueryExecutor) checkPermissions() error {
 		username = ci.Username()
 	}
 
-	action, ruleCancelCtx, timeout, desc := qre.plan.Rules.GetAction(remoteAddr, username, qre.bindVars, qre.marginComments)
-
-	bufferingTimeoutCtx, cancel := context.WithTimeout(qre.ctx, timeout) // aborts buffering at given timeout
-	defer cancel()
-
-	switch action {
-	case rules.QRFail:
-		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "disallowed due to rule: %s", desc)
-	case rules.QRFailRetry:
-		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: %s", desc)
-	case rules.QRBuffer:
-		if ruleCancelCtx != nil {
-			// We buffer up to some timeout. The timeout is determined by ctx.Done().
-			// If we're not at timeout yet, we fail the query
-			select {
-			case <-ruleCancelCtx.Done():
-				// good! We have buffered the query, and buffering is completed
-			case <-bufferingTimeoutCtx.Done():
-				// Sorry, timeout while waiting for buffering to complete
-				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "buffer timeout after %v in rule: %s", timeout, desc)
-			}
+	for _, qr := range qre.plan.Rules.FindMatches(remoteAddr, username, qre.bindVars, qre.marginComments) {
+		if err := qre.applyRule(qr); err != nil {
+			return err
 		}
-	default:
-		// no rules against this query. Good to proceed
 	}
 	// Skip ACL check for queries against the dummy dual table
 	if qre.plan.TableName().String() == "dual" {
@@ -531,6 +552,43 @@ func (qre *QueryExecutor) checkPermissions() error {
 	return nil
 }
 
+// applyRule applies the action of a query rule which matches the query. It
+// returns an error if the query must not run.
+func (qre *QueryExecutor) applyRule(qr *rules.Rule) error {
+	desc := qr.Description
+	switch qr.Action() {
+	case rules.QRFail:
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "disallowed due to rule: %s", desc)
+	case rules.QRFailRetry:
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "disallowed due to rule: %s", desc)
+	case rules.QRThrottle:
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "throttled due to rule: %s", desc)
+	case rules.QRMaxExecutionTime:
+		// The smallest max execution time of the matching rules applies.
+		if qre.maxExecutionTime == 0 || qr.Timeout() < qre.maxExecutionTime {
+			qre.maxExecutionTime = qr.Timeout()
+		}
+	case rules.QRWarn:
+		qre.tsv.qe.queryRuleWarnings.Add(desc, 1)
+		qre.tsv.qe.queryRuleLogger.Warningf("query matched rule %q: %s", desc, qre.tsv.env.Parser().TruncateForLog(qre.query))
+	case rules.QRBuffer:
+		if ruleCancelCtx := qr.CancelCtx(); ruleCancelCtx != nil {
+			bufferingTimeoutCtx, cancel := context.WithTimeout(qre.ctx, qr.Timeout()) // aborts buffering at given timeout
+			defer cancel()
+			// We buffer up to some timeout. The timeout is determined by ctx.Done().
+			// If we're not at timeout yet, we fail the query
+			select {
+			case <-ruleCancelCtx.Done():
+				// good! We have buffered the query, and buffering is completed
+			case <-bufferingTimeoutCtx.Done():
+				// Sorry, timeout while waiting for buffering to complete
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "buffer timeout after %v in rule: %s", qr.Timeout(), desc)
+			}
+		}
+	}
+	return nil
+}
+
 func (qre *QueryExecutor) checkAccess(authorized *tableacl.ACLResult, tableName string, callerID *querypb.VTGateCallerID) error {
 	var aclState acl.ACLState
 	defer func() {
@@ -738,12 +796,12 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 		q, original := qre.tsv.qe.consolidator.Create(sqlWithoutComments)
 		if original {
 			defer q.Broadcast()
-			conn, err := qre.getConn()
+			conn, recycle, err := qre.getConn()
 
 			if err != nil {
 				q.SetErr(err)
 			} else {
-				defer conn.Recycle()
+				defer recycle()
 				res, err := qre.execDBConn(conn.Conn, sql, true)
 				q.SetResult(res)
 				q.SetErr(err)
@@ -763,11 +821,11 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 		}
 		return q.Result(), nil
 	}
-	conn, err := qre.getConn()
+	conn, recycle, err := qre.getConn()
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Recycle()
+	defer recycle()
 	res, err := qre.execDBConn(conn.Conn, sql, true)
 	if err != nil {
 		return nil, err
@@ -805,32 +863,52 @@ func (qre *QueryExecutor) verifyRowCount(count, maxrows int64) error {
 }
 
 func (qre *QueryExecutor) execOther() (*sqltypes.Result, error) {
-	conn, err := qre.getConn()
+	conn, recycle, err := qre.getConn()
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Recycle()
+	defer recycle()
 	return qre.execDBConn(conn.Conn, qre.query, true)
 }
 
-func (qre *QueryExecutor) getConn() (*connpool.PooledConn, error) {
+// getConn gets a connection from the OLTP pool, within the quotas of the
+// caller. The returned function recycles the connection and releases its
+// quota slot.
+func (qre *QueryExecutor) getConn() (*connpool.PooledConn, func(), error) {
 	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.getConn")
 	defer span.Finish()
 
 	defer func(start time.Time) {
 		qre.logStats.WaitingForConnection += time.Since(start)
 	}(time.Now())
-	return qre.tsv.qe.conns.Get(ctx, qre.setting)
+	return getPooledConn(ctx, qre.tsv.qe.conns, qre.tsv.qe.connQuotas, qre.options, qre.setting)
 }
 
-func (qre *QueryExecutor) getStreamConn() (*connpool.PooledConn, error) {
+// getStreamConn is like getConn, for the OLAP pool.
+func (qre *QueryExecutor) getStreamConn() (*connpool.PooledConn, func(), error) {
 	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.getStreamConn")
 	defer span.Finish()
 
 	defer func(start time.Time) {
 		qre.logStats.WaitingForConnection += time.Since(start)
 	}(time.Now())
-	return qre.tsv.qe.streamConns.Get(ctx, qre.setting)
+	return getPooledConn(ctx, qre.tsv.qe.streamConns, qre.tsv.qe.streamConnQuotas, qre.options, qre.setting)
+}
+
+func getPooledConn(ctx context.Context, pool *connpool.Pool, quotas userquota.Limiter, options *querypb.ExecuteOptions, setting *smartconnpool.Setting) (*connpool.PooledConn, func(), error) {
+	slot, err := quotas.Acquire(ctx, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	conn, err := pool.Get(ctx, setting)
+	if err != nil {
+		slot.Release()
+		return nil, nil, err
+	}
+	return conn, func() {
+		conn.Recycle()
+		slot.Release()
+	}, nil
 }
 
 // txFetch fetches from a TxConnection.
@@ -890,6 +968,15 @@ func (qre *QueryExecutor) execRollbackToSavepoint(conn *StatefulConnection, sql
 }
 
 func (qre *QueryExecutor) generateFinalSQL(parsedQuery *sqlparser.ParsedQuery, bindVars map[string]*querypb.BindVariable) (string, string, error) {
+	if qre.maxExecutionTime > 0 && qre.plan.SelectStmt != nil && parsedQuery == qre.plan.FullQuery {
+		// Let MySQL abort the query too, so that it does not keep running
+		// until the tablet kills it.
+		var err error
+		parsedQuery, err = p.GenerateMaxExecutionTimeQuery(qre.plan.SelectStmt, qre.maxExecutionTime)
+		if err != nil {
+			return "", "", err
+		}
+	}
 	query, err := parsedQuery.GenerateQuery(bindVars, nil)
 	if err != nil {
 		return "", "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s", err)
@@ -935,11 +1022,11 @@ func rewriteOUTParamError(err error) error {
 }
 
 func (qre *QueryExecutor) execCallProc() (*sqltypes.Result, error) {
-	conn, err := qre.getConn()
+	conn, recycle, err := qre.getConn()
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Recycle()
+	defer recycle()
 	sql, _, err := qre.generateFinalSQL(qre.plan.FullQuery, qre.bindVars)
 	if err != nil {
 		return nil, err
@@ -1367,11 +1454,11 @@ func (qre *QueryExecutor) getAllDefinitions(tableNames []string, callback func(s
 }
 
 func (qre *QueryExecutor) executeGetSchemaQuery(query string, callback func(schemaRes *querypb.GetSchemaResponse) error) error {
-	conn, err := qre.getStreamConn()
+	conn, recycle, err := qre.getStreamConn()
 	if err != nil {
 		return err
 	}
-	defer conn.Recycle()
+	defer recycle()
 
 	return qre.execStreamSQL(conn, false /* isTransaction */, query, func(result *sqltypes.Result) error {
 		schemaDef := make(map[string]string)
@@ -1393,11 +1480,11 @@ func (qre *QueryExecutor) getUDFs(callback func(schemaRes *querypb.GetSchemaResp
 		return err
 	}
 
-	conn, err := qre.getStreamConn()
+	conn, recycle, err := qre.getStreamConn()
 	if err != nil {
 		return err
 	}
-	defer conn.Recycle()
+	defer recycle()
 
 	return qre.execStreamSQL(conn, false /* isTransaction */, query, func(result *sqltypes.Result) error {
 		var udfs []*querypb.UDFInfo
@@ -1270,6 +1270,72 @@ func TestQueryExecutorDenyListQRRetry(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorQueryRuleActions(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1 limit 1000"
+	expected := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	db.AddQuery("select * from test_table where `name` = 1 limit 1000", expected)
+	db.AddQuery("select /*+ MAX_EXECUTION_TIME(1500) */ * from test_table where `name` = 1 limit 1000", expected)
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	rulesName := "queryRuleActions"
+	tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	setRule := func(qr *rules.Rule) {
+		qr.SetQueryCond("select.*")
+		qrs := rules.New()
+		qrs.Add(qr)
+		require.NoError(t, tsv.SetQueryRules(rulesName, qrs))
+	}
+
+	// The first query is within the rate of the rule, the second one isn't.
+	setRule(rules.NewThrottleQueryRule("throttle selects", "throttle", 1))
+	_, err := newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	require.NoError(t, err)
+	_, err = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "throttled due to rule: throttle selects")
+
+	// Selects are sent with the MAX_EXECUTION_TIME hint.
+	setRule(rules.NewMaxExecutionTimeQueryRule("limit selects", "met", 1500*time.Millisecond))
+	db.ResetQueryLog()
+	_, err = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	require.NoError(t, err)
+	assert.Contains(t, db.QueryLog(), "/*+ max_execution_time(1500) */")
+
+	// Warnings are counted per rule.
+	setRule(rules.NewQueryRule("warn selects", "warn", rules.QRWarn))
+	before := tsv.qe.queryRuleWarnings.Counts()["warn selects"]
+	_, err = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	require.NoError(t, err)
+	assert.EqualValues(t, before+1, tsv.qe.queryRuleWarnings.Counts()["warn selects"])
+
+	// A warning does not hide the failure of a later rule.
+	warn := rules.NewQueryRule("warn selects", "warn", rules.QRWarn)
+	warn.SetQueryCond("select.*")
+	fail := rules.NewQueryRule("no selects", "fail", rules.QRFail)
+	fail.SetQueryCond("select.*")
+	qrs := rules.New()
+	qrs.Add(warn)
+	qrs.Add(fail)
+	require.NoError(t, tsv.SetQueryRules(rulesName, qrs))
+	before = tsv.qe.queryRuleWarnings.Counts()["warn selects"]
+	_, err = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+	assert.ErrorContains(t, err, "disallowed due to rule: no selects")
+	assert.EqualValues(t, before+1, tsv.qe.queryRuleWarnings.Counts()["warn selects"])
+}
+
 func TestReplaceSchemaName(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -1476,14 +1542,14 @@ func TestGetConnectionLogStats(t *testing.T) {
 
 	// getConn() happy path
 	qre := newTestQueryExecutor(ctx, tsv, input, 0)
-	conn, err := qre.getConn()
+	conn, _, err := qre.getConn()
 	assert.NoError(t, err)
 	assert.NotNil(t, conn)
 	assert.True(t, qre.logStats.WaitingForConnection > 0)
 
 	// getStreamConn() happy path
 	qre = newTestQueryExecutor(ctx, tsv, input, 0)
-	conn, err = qre.getStreamConn()
+	conn, _, err = qre.getStreamConn()
 	assert.NoError(t, err)
 	assert.NotNil(t, conn)
 	assert.True(t, qre.logStats.WaitingForConnection > 0)
@@ -1493,13 +1559,13 @@ func TestGetConnectionLogStats(t *testing.T) {
 
 	// getConn() error path
 	qre = newTestQueryExecutor(ctx, tsv, input, 0)
-	_, err = qre.getConn()
+	_, _, err = qre.getConn()
 	assert.Error(t, err)
 	assert.True(t, qre.logStats.WaitingForConnection > 0)
 
 	// getStreamConn() error path
 	qre = newTestQueryExecutor(ctx, tsv, input, 0)
-	_, err = qre.getStreamConn()
+	_, _, err = qre.getStreamConn()
 	assert.Error(t, err)
 	assert.True(t, qre.logStats.WaitingForConnection > 0)
 }
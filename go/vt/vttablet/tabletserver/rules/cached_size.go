@@ -45,7 +45,7 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(256)
+		size += int64(288)
 	}
 	// field Description string
 	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
@@ -79,6 +79,11 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field limiter *golang.org/x/time/rate.Limiter
+	if cached.limiter != nil {
+		// WARNING: size of external type golang.org/x/time/rate.Limiter cannot be fully calculated
+		size += hack.RuntimeAllocSize(int64(80))
+	}
 	return size
 }
 func (cached *Rules) CachedSize(alloc bool) int64 {
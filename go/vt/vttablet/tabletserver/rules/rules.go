@@ -26,10 +26,14 @@ import (
 	"strconv"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 )
@@ -170,6 +174,8 @@ func (qrs *Rules) FilterByPlan(query string, planid planbuilder.PlanType, tableN
 }
 
 // GetAction runs the input against the rules engine and returns the action to be performed.
+// It is the action of the last matching rule returned by FindMatches, so
+// a terminal action takes precedence over the other actions.
 func (qrs *Rules) GetAction(
 	ip,
 	user string,
@@ -180,12 +186,64 @@ func (qrs *Rules) GetAction(
 	cancelCtx context.Context,
 	timeout time.Duration,
 	desc string) {
+	if matches := qrs.FindMatches(ip, user, bindVars, marginComments); len(matches) > 0 {
+		qr := matches[len(matches)-1]
+		return qr.act, qr.cancelCtx, qr.timeout, qr.Description
+	}
+	return QRContinue, nil, 0, ""
+}
+
+// FindMatches runs the input against the rules engine and returns the
+// rules whose action is to be performed, in order. The actions which let
+// the query run, QRMaxExecutionTime, QRWarn and QRReroute, all apply, so
+// the rules after them are still evaluated, up to the first rule with a
+// terminal action, which is the last one returned. It returns nil if the
+// query can continue unaffected.
+func (qrs *Rules) FindMatches(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+) []*Rule {
+	var matches []*Rule
 	for _, qr := range qrs.rules {
-		if act := qr.GetAction(ip, user, bindVars, marginComments); act != QRContinue {
-			return act, qr.cancelCtx, qr.timeout, qr.Description
+		act := qr.GetAction(ip, user, bindVars, marginComments)
+		if act == QRContinue {
+			continue
+		}
+		matches = append(matches, qr)
+		if act.IsTerminal() {
+			break
 		}
 	}
-	return QRContinue, nil, 0, ""
+	return matches
+}
+
+// Rejects returns true if a rule rejects the input: a rule with the
+// QRFail, QRFailRetry or QRBuffer action, or a QRThrottle rule whose rate
+// is exceeded. Unlike GetAction, it doesn't take from the rate of the
+// QRThrottle rules, so it can check queries which already ran, like those
+// of a transaction to prepare.
+func (qrs *Rules) Rejects(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+) bool {
+	for _, qr := range qrs.rules {
+		if !qr.matches(ip, user, bindVars, marginComments) {
+			continue
+		}
+		switch qr.act {
+		case QRFail, QRFailRetry, QRBuffer:
+			return true
+		case QRThrottle:
+			if qr.limiter.Tokens() < 1 {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // -----------------------------------------------
@@ -222,8 +280,18 @@ type Rule struct {
 	// a rule can be dynamically cancelled.
 	cancelCtx context.Context
 
-	// a rule can timeout.
+	// a rule can timeout. For QRMaxExecutionTime, this is the max execution
+	// time of the query.
 	timeout time.Duration
+
+	// maxQPS is the rate QRThrottle lets matching queries through at.
+	// limiter is shared by all copies of the rule, e.g. the copies in the
+	// rules of the query plans, so that the rate applies to them as a whole.
+	maxQPS  float64
+	limiter *rate.Limiter
+
+	// tabletType is the tablet type QRReroute sends matching queries to.
+	tabletType topodatapb.TabletType
 }
 
 type namedRegexp struct {
@@ -250,6 +318,26 @@ func NewQueryRule(description, name string, act Action) (qr *Rule) {
 	return &Rule{Description: description, Name: name, act: act}
 }
 
+// NewThrottleQueryRule creates a new Rule which lets matching queries through
+// at up to maxQPS queries per second and rejects the others.
+func NewThrottleQueryRule(description, name string, maxQPS float64) (qr *Rule) {
+	qr = NewQueryRule(description, name, QRThrottle)
+	qr.setMaxQPS(maxQPS)
+	return qr
+}
+
+// NewMaxExecutionTimeQueryRule creates a new Rule which limits the execution
+// time of matching queries.
+func NewMaxExecutionTimeQueryRule(description, name string, maxExecutionTime time.Duration) (qr *Rule) {
+	return &Rule{Description: description, Name: name, timeout: maxExecutionTime, act: QRMaxExecutionTime}
+}
+
+// NewRerouteQueryRule creates a new Rule which sends matching reads to
+// tablets of the given type. It is only honored by VTGate.
+func NewRerouteQueryRule(description, name string, tabletType topodatapb.TabletType) (qr *Rule) {
+	return &Rule{Description: description, Name: name, tabletType: tabletType, act: QRReroute}
+}
+
 // NewBufferedTableQueryRule creates a new buffer Rule.
 func NewBufferedTableQueryRule(cancelCtx context.Context, tableName string, bufferTimeout time.Duration, description string) (qr *Rule) {
 	// We ignore act because there's only one action right now
@@ -269,6 +357,8 @@ func (qr *Rule) Equal(other *Rule) bool {
 		qr.leadingComment.Equal(other.leadingComment) &&
 		qr.trailingComment.Equal(other.trailingComment) &&
 		qr.timeout == other.timeout &&
+		qr.maxQPS == other.maxQPS &&
+		qr.tabletType == other.tabletType &&
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
@@ -288,6 +378,9 @@ func (qr *Rule) Copy() (newqr *Rule) {
 		act:             qr.act,
 		cancelCtx:       qr.cancelCtx,
 		timeout:         qr.timeout,
+		maxQPS:          qr.maxQPS,
+		limiter:         qr.limiter,
+		tabletType:      qr.tabletType,
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -339,10 +432,44 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.timeout != 0 {
 		safeEncode(b, `,"Timeout":`, qr.timeout)
 	}
+	if qr.maxQPS != 0 {
+		safeEncode(b, `,"MaxQPS":`, qr.maxQPS)
+	}
+	if qr.act == QRReroute {
+		safeEncode(b, `,"TabletType":`, qr.tabletType.String())
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
 
+// Action returns the action of the rule.
+func (qr *Rule) Action() Action {
+	return qr.act
+}
+
+// CancelCtx returns the context whose cancellation ends the buffering of
+// the queries matching a QRBuffer rule.
+func (qr *Rule) CancelCtx() context.Context {
+	return qr.cancelCtx
+}
+
+// Timeout returns the timeout of the rule, i.e. the buffering timeout for
+// QRBuffer and the max execution time for QRMaxExecutionTime.
+func (qr *Rule) Timeout() time.Duration {
+	return qr.timeout
+}
+
+// TabletType returns the tablet type QRReroute sends matching queries to.
+func (qr *Rule) TabletType() topodatapb.TabletType {
+	return qr.tabletType
+}
+
+func (qr *Rule) setMaxQPS(maxQPS float64) {
+	qr.maxQPS = maxQPS
+	// Allow bursts of up to one second worth of queries, but at least one.
+	qr.limiter = rate.NewLimiter(rate.Limit(maxQPS), max(1, int(maxQPS)))
+}
+
 // SetIPCond adds a regular expression condition for the client IP.
 // It has to be a full match (not substring).
 func (qr *Rule) SetIPCond(pattern string) (err error) {
@@ -487,34 +614,52 @@ func (qr *Rule) GetAction(
 	bindVars map[string]*querypb.BindVariable,
 	marginComments sqlparser.MarginComments,
 ) Action {
+	if !qr.matches(ip, user, bindVars, marginComments) {
+		return QRContinue
+	}
+	if qr.act == QRThrottle && qr.limiter.Allow() {
+		// The query is within the rate of the rule.
+		return QRContinue
+	}
+	return qr.act
+}
+
+// matches returns whether all the conditions of the rule which are not
+// resolved by FilterByPlan match the input.
+func (qr *Rule) matches(
+	ip,
+	user string,
+	bindVars map[string]*querypb.BindVariable,
+	marginComments sqlparser.MarginComments,
+) bool {
 	if qr.cancelCtx != nil {
 		select {
 		case <-qr.cancelCtx.Done():
 			// rule was cancelled. Nothing else to check
-			return QRContinue
+			return false
 		default:
 			// rule will be cancelled in the future. Until then, it applies!
 			// proceed to evaluate rules
 		}
 	}
 	if !reMatch(qr.leadingComment.Regexp, marginComments.Leading) {
-		return QRContinue
+		return false
 	}
 	if !reMatch(qr.trailingComment.Regexp, marginComments.Trailing) {
-		return QRContinue
+		return false
 	}
 	if !reMatch(qr.requestIP.Regexp, ip) {
-		return QRContinue
+		return false
 	}
 	if !reMatch(qr.user.Regexp, user) {
-		return QRContinue
+		return false
 	}
 	for _, bvcond := range qr.bindVarConds {
 		if !bvMatch(bvcond, bindVars) {
-			return QRContinue
+			return false
 		}
 	}
-	return qr.act
+	return true
 }
 
 func reMatch(re *regexp.Regexp, val string) bool {
@@ -568,25 +713,59 @@ func bvMatch(bvcond BindVarCond, bindVars map[string]*querypb.BindVariable) bool
 type Action int
 
 // These are actions.
+//
+// QRThrottle rejects matching queries which exceed the rate of the rule.
+// QRMaxExecutionTime limits the execution time of matching queries.
+// QRWarn lets matching queries through but reports them.
+// QRReroute sends matching reads to a different tablet type. It is only
+// honored by VTGate.
 const (
 	QRContinue = Action(iota)
 	QRFail
 	QRFailRetry
 	QRBuffer
+	QRThrottle
+	QRMaxExecutionTime
+	QRWarn
+	QRReroute
 )
 
+var actionNames = map[Action]string{
+	QRFail:             "FAIL",
+	QRFailRetry:        "FAIL_RETRY",
+	QRBuffer:           "BUFFER",
+	QRThrottle:         "THROTTLE",
+	QRMaxExecutionTime: "MAX_EXECUTION_TIME",
+	QRWarn:             "WARN",
+	QRReroute:          "REROUTE",
+}
+
+// IsTerminal returns whether the action decides the outcome of the query,
+// so the rules after it are not evaluated. The other actions only change
+// how the query runs, and combine with the actions of the next rules.
+func (act Action) IsTerminal() bool {
+	switch act {
+	case QRFail, QRFailRetry, QRBuffer, QRThrottle:
+		return true
+	}
+	return false
+}
+
+// String returns the name of the action as used in the JSON rules.
+func (act Action) String() string {
+	if str, ok := actionNames[act]; ok {
+		return str
+	}
+	if act == QRContinue {
+		return "CONTINUE"
+	}
+	return "INVALID"
+}
+
 // MarshalJSON marshals to JSON.
 func (act Action) MarshalJSON() ([]byte, error) {
-	// If we add more actions, we'll need to use a map.
-	var str string
-	switch act {
-	case QRFail:
-		str = "FAIL"
-	case QRFailRetry:
-		str = "FAIL_RETRY"
-	case QRBuffer:
-		str = "BUFFER"
-	default:
+	str, ok := actionNames[act]
+	if !ok {
 		str = "INVALID"
 	}
 	return json.Marshal(str)
@@ -876,7 +1055,7 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 		var lv []any
 		var ok bool
 		switch k {
-		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment":
+		case "Name", "Description", "RequestIP", "User", "Query", "Action", "LeadingComment", "TrailingComment", "TabletType":
 			sv, ok = v.(string)
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want string for %s", k)
@@ -886,6 +1065,7 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "MaxQPS", "Timeout":
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -951,21 +1131,80 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 				}
 			}
 		case "Action":
-			switch sv {
-			case "FAIL":
-				qr.act = QRFail
-			case "FAIL_RETRY":
-				qr.act = QRFailRetry
-			case "BUFFER":
-				qr.act = QRBuffer
-			default:
+			act, ok := actionByName(sv)
+			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Action %s", sv)
 			}
+			qr.act = act
+		case "MaxQPS":
+			maxQPS, err := buildFloat(v)
+			if err != nil || maxQPS <= 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive number for MaxQPS: %v", v)
+			}
+			qr.setMaxQPS(maxQPS)
+		case "Timeout":
+			qr.timeout, err = buildDuration(v)
+			if err != nil || qr.timeout <= 0 {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want positive duration for Timeout: %v", v)
+			}
+		case "TabletType":
+			tt, err := topoproto.ParseTabletType(sv)
+			if err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid TabletType %s", sv)
+			}
+			qr.tabletType = tt
+		}
+	}
+
+	// The parameters of an action are only known once all tags are processed.
+	switch qr.act {
+	case QRThrottle:
+		if qr.limiter == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "MaxQPS missing for Action THROTTLE")
+		}
+	case QRMaxExecutionTime:
+		if qr.timeout == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Timeout missing for Action MAX_EXECUTION_TIME")
+		}
+	case QRReroute:
+		if qr.tabletType == topodatapb.TabletType_UNKNOWN {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "TabletType missing for Action REROUTE")
 		}
 	}
 	return qr, nil
 }
 
+func actionByName(name string) (Action, bool) {
+	for act, actName := range actionNames {
+		if actName == name {
+			return act, true
+		}
+	}
+	return QRContinue, false
+}
+
+// buildFloat accepts a JSON number.
+func buildFloat(v any) (float64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("want number: %v", v)
+	}
+	return n.Float64()
+}
+
+// buildDuration accepts a duration string like "1.5s" or a JSON number of
+// nanoseconds, which is how MarshalJSON encodes it.
+func buildDuration(v any) (time.Duration, error) {
+	switch v := v.(type) {
+	case string:
+		return time.ParseDuration(v)
+	case json.Number:
+		ns, err := v.Int64()
+		return time.Duration(ns), err
+	}
+	return 0, fmt.Errorf("want duration: %v", v)
+}
+
 func buildBindVarCondition(bvc any) (name string, onAbsent, onMismatch bool, op Operator, value any, err error) {
 	bvcinfo, ok := bvc.(map[string]any)
 	if !ok {
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -33,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -734,6 +736,13 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"Action": "THROTTLE" }]`, "MaxQPS missing for Action THROTTLE"},
+	{`[{"Action": "THROTTLE", "MaxQPS": "1" }]`, "want positive number for MaxQPS: 1"},
+	{`[{"Action": "THROTTLE", "MaxQPS": 0 }]`, "want positive number for MaxQPS: 0"},
+	{`[{"Action": "MAX_EXECUTION_TIME" }]`, "Timeout missing for Action MAX_EXECUTION_TIME"},
+	{`[{"Action": "MAX_EXECUTION_TIME", "Timeout": "1x" }]`, "want positive duration for Timeout: 1x"},
+	{`[{"Action": "REROUTE" }]`, "TabletType missing for Action REROUTE"},
+	{`[{"Action": "REROUTE", "TabletType": "foo" }]`, "invalid TabletType foo"},
 }
 
 func TestInvalidJSON(t *testing.T) {
@@ -771,6 +780,152 @@ func TestBuildQueryRuleActionFail(t *testing.T) {
 	}
 }
 
+func TestBuildQueryRuleNewActions(t *testing.T) {
+	qrs := New()
+	err := qrs.UnmarshalJSON([]byte(`[{
+		"Name": "throttle",
+		"Query": "select .* from a",
+		"Action": "THROTTLE",
+		"MaxQPS": 2
+	}, {
+		"Name": "met",
+		"Query": "select .* from b",
+		"Action": "MAX_EXECUTION_TIME",
+		"Timeout": "1.5s"
+	}, {
+		"Name": "met_ns",
+		"Query": "select .* from c",
+		"Action": "MAX_EXECUTION_TIME",
+		"Timeout": 1000000
+	}, {
+		"Name": "warn",
+		"Query": "select .* from d",
+		"Action": "WARN"
+	}, {
+		"Name": "reroute",
+		"Query": "select .* from e",
+		"Action": "REROUTE",
+		"TabletType": "rdonly"
+	}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	throttle := qrs.Find("throttle")
+	assert.Equal(t, QRThrottle, throttle.Action())
+	assert.Equal(t, 1500*time.Millisecond, qrs.Find("met").Timeout())
+	assert.Equal(t, time.Millisecond, qrs.Find("met_ns").Timeout())
+	assert.Equal(t, QRWarn, qrs.Find("warn").Action())
+	assert.Equal(t, topodatapb.TabletType_RDONLY, qrs.Find("reroute").TabletType())
+
+	// The rules survive a round trip through JSON.
+	qrs2 := New()
+	err = qrs2.UnmarshalJSON([]byte(marshalled(qrs)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !qrs.Equal(qrs2) {
+		t.Errorf("qrs: %s, want %s", marshalled(qrs2), marshalled(qrs))
+	}
+
+	// The burst of the throttle rule is shared by its copies.
+	qr := throttle.Copy()
+	plan := qrs.FilterByPlan("select * from a", planbuilder.PlanSelect, "a")
+	assert.Equal(t, QRContinue, qr.GetAction("", "", nil, sqlparser.MarginComments{}))
+	assert.Equal(t, QRContinue, throttle.GetAction("", "", nil, sqlparser.MarginComments{}))
+	act, _, _, desc := plan.GetAction("", "", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRThrottle, act)
+	assert.Equal(t, "", desc)
+	matches := plan.FindMatches("", "", nil, sqlparser.MarginComments{})
+	require.Len(t, matches, 1)
+	assert.Equal(t, "throttle", matches[0].Name)
+
+	// Queries which do not match a rule are not affected.
+	plan = qrs.FilterByPlan("select * from x", planbuilder.PlanSelect, "x")
+	assert.Nil(t, plan.FindMatches("", "", nil, sqlparser.MarginComments{}))
+}
+
+func TestFindMatches(t *testing.T) {
+	qrs := New()
+	err := qrs.UnmarshalJSON([]byte(`[{
+		"Name": "warn",
+		"Action": "WARN"
+	}, {
+		"Name": "met",
+		"Action": "MAX_EXECUTION_TIME",
+		"Timeout": "1s"
+	}, {
+		"Name": "fail",
+		"Query": "select .* from a",
+		"Action": "FAIL"
+	}, {
+		"Name": "reroute",
+		"Action": "REROUTE",
+		"TabletType": "replica"
+	}]`))
+	require.NoError(t, err)
+
+	names := func(matches []*Rule) []string {
+		var names []string
+		for _, qr := range matches {
+			names = append(names, qr.Name)
+		}
+		return names
+	}
+
+	// A warning does not hide the failure of a later rule, and the rules
+	// after the failure are not evaluated.
+	plan := qrs.FilterByPlan("select * from a", planbuilder.PlanSelect, "a")
+	assert.Equal(t, []string{"warn", "met", "fail"}, names(plan.FindMatches("", "", nil, sqlparser.MarginComments{})))
+	act, _, _, _ := plan.GetAction("", "", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRFail, act)
+
+	// Without a terminal action, all the matching rules apply.
+	plan = qrs.FilterByPlan("select * from b", planbuilder.PlanSelect, "b")
+	assert.Equal(t, []string{"warn", "met", "reroute"}, names(plan.FindMatches("", "", nil, sqlparser.MarginComments{})))
+}
+
+func TestRejects(t *testing.T) {
+	qrs := New()
+	err := qrs.UnmarshalJSON([]byte(`[{
+		"Name": "warn",
+		"Action": "WARN"
+	}, {
+		"Name": "met",
+		"Action": "MAX_EXECUTION_TIME",
+		"Timeout": "1s"
+	}, {
+		"Name": "reroute",
+		"Action": "REROUTE",
+		"TabletType": "replica"
+	}, {
+		"Name": "throttle",
+		"Query": "select .* from a",
+		"Action": "THROTTLE",
+		"MaxQPS": 1
+	}, {
+		"Name": "buffer",
+		"Query": "select .* from b",
+		"Action": "BUFFER"
+	}]`))
+	require.NoError(t, err)
+
+	// The actions which let the query run don't reject it.
+	plan := qrs.FilterByPlan("select * from c", planbuilder.PlanSelect, "c")
+	assert.False(t, plan.Rejects("", "", nil, sqlparser.MarginComments{}))
+	plan = qrs.FilterByPlan("select * from b", planbuilder.PlanSelect, "b")
+	assert.True(t, plan.Rejects("", "", nil, sqlparser.MarginComments{}))
+
+	// The rate of a throttling rule is checked without taking from it.
+	plan = qrs.FilterByPlan("select * from a", planbuilder.PlanSelect, "a")
+	for range 3 {
+		assert.False(t, plan.Rejects("", "", nil, sqlparser.MarginComments{}))
+	}
+	act, _, _, _ := plan.GetAction("", "", nil, sqlparser.MarginComments{})
+	assert.Equal(t, QRReroute, act)
+	assert.True(t, plan.Rejects("", "", nil, sqlparser.MarginComments{}))
+}
+
 func TestBadAddBindVarCond(t *testing.T) {
 	qr1 := NewQueryRule("rule 1", "r1", QRFail)
 	err := qr1.AddBindVarCond("a", true, false, QRMatch, uint64(1))
@@ -25,7 +25,7 @@ func (cached *MessageInfo) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(96)
+		size += int64(128)
 	}
 	// field Fields []*vitess.io/vitess/go/vt/proto/query.Field
 	{
@@ -34,6 +34,10 @@ func (cached *MessageInfo) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(true)
 		}
 	}
+	// field DeadLetterTable string
+	size += hack.RuntimeAllocSize(int64(len(cached.DeadLetterTable)))
+	// field DeadLetterColumn string
+	size += hack.RuntimeAllocSize(int64(len(cached.DeadLetterColumn)))
 	return size
 }
 func (cached *Table) CachedSize(alloc bool) int64 {
@@ -52,6 +52,9 @@ func LoadTable(conn *connpool.PooledConn, databaseName, tableName, tableType str
 		if err := loadMessageInfo(ta, comment, collationEnv); err != nil {
 			return nil, err
 		}
+		if err := checkDeadLetterTable(ta, conn, databaseName); err != nil {
+			return nil, err
+		}
 		ta.Type = Message
 	}
 	return ta, nil
@@ -70,6 +73,28 @@ func fetchColumns(ta *Table, conn *connpool.PooledConn, databaseName, sqlTableNa
 	return nil
 }
 
+// checkDeadLetterTable makes sure that the dead-letter table of a message
+// table exists, with the columns that the failed messages are copied to.
+func checkDeadLetterTable(ta *Table, conn *connpool.PooledConn, databaseName string) error {
+	if ta.MessageInfo.DeadLetterTable == "" {
+		return nil
+	}
+	dlt := NewTable(ta.MessageInfo.DeadLetterTable, NoType)
+	if err := fetchColumns(dlt, conn, databaseName, sqlparser.String(dlt.Name)); err != nil {
+		return fmt.Errorf("vt_dead_letter_table %s cannot be loaded for message table: %s: %v", dlt.Name.String(), ta.Name.String(), err)
+	}
+	cols := []string{"priority", "time_next"}
+	for _, field := range ta.MessageInfo.Fields {
+		cols = append(cols, field.Name)
+	}
+	for _, col := range cols {
+		if dlt.FindColumn(sqlparser.NewIdentifierCI(col)) == -1 {
+			return fmt.Errorf("%s missing from dead-letter table %s of message table: %s", col, dlt.Name.String(), ta.Name.String())
+		}
+	}
+	return nil
+}
+
 func loadMessageInfo(ta *Table, comment string, collationEnv *collations.Environment) error {
 	ta.MessageInfo = &MessageInfo{}
 	// Extract keyvalues.
@@ -109,6 +134,25 @@ func loadMessageInfo(ta *Table, comment string, collationEnv *collations.Environ
 
 	ta.MessageInfo.MaxBackoff, _ = getDuration(keyvals, "vt_max_backoff")
 
+	if keyvals["vt_max_attempts"] != "" {
+		if ta.MessageInfo.MaxAttempts, err = getNum(keyvals, "vt_max_attempts"); err != nil {
+			return err
+		}
+		if ta.MessageInfo.MaxAttempts < 0 {
+			return fmt.Errorf("vt_max_attempts must not be negative for message table: %s", ta.Name.String())
+		}
+	}
+	ta.MessageInfo.DeadLetterTable = keyvals["vt_dead_letter_table"]
+	ta.MessageInfo.DeadLetterColumn = keyvals["vt_dead_letter_column"]
+	if ta.MessageInfo.DeadLetterTable != "" || ta.MessageInfo.DeadLetterColumn != "" {
+		if ta.MessageInfo.MaxAttempts == 0 {
+			return fmt.Errorf("a dead-letter target requires vt_max_attempts for message table: %s", ta.Name.String())
+		}
+		if ta.MessageInfo.DeadLetterTable != "" && ta.MessageInfo.DeadLetterColumn != "" {
+			return fmt.Errorf("vt_dead_letter_table and vt_dead_letter_column are mutually exclusive for message table: %s", ta.Name.String())
+		}
+	}
+
 	// these columns are required for message manager to function properly, but only
 	// id is required to be streamed to subscribers
 	requiredCols := []string{
@@ -136,6 +180,19 @@ func loadMessageInfo(ta *Table, comment string, collationEnv *collations.Environ
 		}
 	}
 
+	if col := ta.MessageInfo.DeadLetterColumn; col != "" {
+		if ta.FindColumn(sqlparser.NewIdentifierCI(col)) == -1 {
+			return fmt.Errorf("%s missing from message table: %s", col, ta.Name.String())
+		}
+		for _, requiredCol := range requiredCols {
+			if strings.EqualFold(col, requiredCol) {
+				return fmt.Errorf("vt_dead_letter_column cannot be %s for message table: %s", col, ta.Name.String())
+			}
+		}
+		// The marker column is not sent to the subscribers by default.
+		hiddenCols[strings.ToLower(col)] = struct{}{}
+	}
+
 	// check to see if the user has specified columns to stream to subscribers
 	specifiedCols := parseMessageCols(keyvals, "vt_message_cols")
 
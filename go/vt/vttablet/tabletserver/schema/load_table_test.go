@@ -205,6 +205,76 @@ func TestLoadTableMessage(t *testing.T) {
 	// end vt_message_cols tests
 	//
 
+	// Test loading max attempts and a dead-letter table
+	db.MockQueriesForTable("test_table_dlt", &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "id",
+			Type: sqltypes.Int64,
+		}, {
+			Name: "priority",
+			Type: sqltypes.Int64,
+		}, {
+			Name: "time_next",
+			Type: sqltypes.Int64,
+		}, {
+			Name: "message",
+			Type: sqltypes.VarBinary,
+		}},
+	})
+	table, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_min_backoff=10,vt_max_backoff=100,vt_max_attempts=5,vt_dead_letter_table=test_table_dlt", db)
+	require.NoError(t, err)
+	want.MessageInfo.MaxAttempts = 5
+	want.MessageInfo.DeadLetterTable = "test_table_dlt"
+	assert.Equal(t, want, table)
+
+	// Test loading a dead-letter column, which is not sent to the subscribers
+	table, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_min_backoff=10,vt_max_backoff=100,vt_max_attempts=5,vt_dead_letter_column=message", db)
+	require.NoError(t, err)
+	want.MessageInfo.DeadLetterTable = ""
+	want.MessageInfo.DeadLetterColumn = "message"
+	want.MessageInfo.Fields = []*querypb.Field{{
+		Name: "id",
+		Type: sqltypes.Int64,
+	}}
+	assert.Equal(t, want, table)
+	want.MessageInfo.Fields = origFields
+
+	// The dead-letter table must exist, with the columns of the messages.
+	db.MockQueriesForTable("test_table_dlt2", &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "id",
+			Type: sqltypes.Int64,
+		}, {
+			Name: "priority",
+			Type: sqltypes.Int64,
+		}, {
+			Name: "time_next",
+			Type: sqltypes.Int64,
+		}},
+	})
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=5,vt_dead_letter_table=test_table_dlt2", db)
+	require.EqualError(t, err, "message missing from dead-letter table test_table_dlt2 of message table: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=5,vt_dead_letter_table=absent_dlt", db)
+	require.ErrorContains(t, err, "vt_dead_letter_table absent_dlt cannot be loaded for message table: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=5,vt_dead_letter_column=failed", db)
+	require.EqualError(t, err, "failed missing from message table: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=5,vt_dead_letter_column=epoch", db)
+	require.EqualError(t, err, "vt_dead_letter_column cannot be epoch for message table: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_dead_letter_table=test_table_dlt", db)
+	require.EqualError(t, err, "a dead-letter target requires vt_max_attempts for message table: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=5,vt_dead_letter_table=test_table_dlt,vt_dead_letter_column=message", db)
+	require.EqualError(t, err, "vt_dead_letter_table and vt_dead_letter_column are mutually exclusive for message table: test_table")
+
+	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30,vt_purge_after=120,vt_batch_size=1,vt_cache_size=10,vt_poller_interval=30,vt_max_attempts=-1", db)
+	require.EqualError(t, err, "vt_max_attempts must not be negative for message table: test_table")
+	want.MessageInfo.MaxAttempts = 0
+	want.MessageInfo.DeadLetterColumn = ""
+
 	// Missing property
 	_, err = newTestLoadTable("USER_TABLE", "vitess_message,vt_ack_wait=30", db)
 	wanterr := "not specified for message table"
@@ -128,10 +128,26 @@ type MessageInfo struct {
 
 	// IDType specifies the type of the ID column
 	IDType sqltypes.Type
+
+	// MaxAttempts specifies how many times a message is sent
+	// before it's dead-lettered. If zero, a message is resent
+	// until it's acked.
+	MaxAttempts int
+
+	// DeadLetterTable specifies the message table the
+	// dead-lettered messages are moved to. If neither
+	// DeadLetterTable nor DeadLetterColumn are set, the
+	// dead-lettered messages are acked.
+	DeadLetterTable string
+
+	// DeadLetterColumn specifies the column that is set to 1
+	// to mark the dead-lettered messages, which are then
+	// kept in the table, but not sent any more.
+	DeadLetterColumn string
 }
 
 func (mi *MessageInfo) String() string {
-	return fmt.Sprintf("MessageInfo: AckWaitDuration: %v, PurgeAfterDuration: %v, BatchSize: %v, CacheSize: %v, PollInterval: %v, MinBackoff: %v, MaxBackoff: %v, IDType: %v", mi.AckWaitDuration, mi.PurgeAfterDuration, mi.BatchSize, mi.CacheSize, mi.PollInterval, mi.MinBackoff, mi.MaxBackoff, mi.IDType)
+	return fmt.Sprintf("MessageInfo: AckWaitDuration: %v, PurgeAfterDuration: %v, BatchSize: %v, CacheSize: %v, PollInterval: %v, MinBackoff: %v, MaxBackoff: %v, IDType: %v, MaxAttempts: %v, DeadLetterTable: %v, DeadLetterColumn: %v", mi.AckWaitDuration, mi.PurgeAfterDuration, mi.BatchSize, mi.CacheSize, mi.PollInterval, mi.MinBackoff, mi.MaxBackoff, mi.IDType, mi.MaxAttempts, mi.DeadLetterTable, mi.DeadLetterColumn)
 }
 
 // NewTable creates a new Table.
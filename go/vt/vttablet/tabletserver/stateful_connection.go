@@ -31,6 +31,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/userquota"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -50,6 +51,8 @@ type StatefulConnection struct {
 	enforceTimeout bool
 	timeout        time.Duration
 	expiryTime     time.Time
+	// quotaSlot is the user quota slot held by the transaction.
+	quotaSlot *userquota.Slot
 }
 
 // Properties contains meta information about the connection
@@ -236,6 +239,12 @@ func (sc *StatefulConnection) CleanTxState() {
 	sc.txProps = nil
 }
 
+// releaseQuotaSlot returns the user quota slot held by the transaction.
+func (sc *StatefulConnection) releaseQuotaSlot() {
+	sc.quotaSlot.Release()
+	sc.quotaSlot = nil
+}
+
 // Stats implements the tx.IStatefulConnection interface
 func (sc *StatefulConnection) Stats() *tabletenv.Stats {
 	return sc.env.Stats()
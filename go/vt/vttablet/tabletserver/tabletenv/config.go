@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -181,6 +182,9 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	utils.SetFlagIntVar(fs, &currentConfig.HotRowProtection.MaxQueueSize, "hot-row-protection-max-queue-size", defaultConfig.HotRowProtection.MaxQueueSize, "Maximum number of BeginExecute RPCs which will be queued for the same row (range).")
 	utils.SetFlagIntVar(fs, &currentConfig.HotRowProtection.MaxGlobalQueueSize, "hot-row-protection-max-global-queue-size", defaultConfig.HotRowProtection.MaxGlobalQueueSize, "Global queue limit across all row (ranges). Useful to prevent that the queue can grow unbounded.")
 	utils.SetFlagIntVar(fs, &currentConfig.HotRowProtection.MaxConcurrency, "hot-row-protection-concurrent-transactions", defaultConfig.HotRowProtection.MaxConcurrency, "Number of concurrent transactions let through to the txpool/MySQL for the same hot row. Should be > 1 to have enough 'ready' transactions in MySQL and benefit from a pipelining effect.")
+	utils.SetFlagBoolVar(fs, &currentConfig.HotRowProtection.Autocommit, "hot-row-protection-autocommit", defaultConfig.HotRowProtection.Autocommit, "If true, UPDATEs and DELETEs outside of an explicit transaction will be queued by the hot row protection as well.")
+	utils.SetFlagBoolVar(fs, &currentConfig.HotRowProtection.Coalesce, "hot-row-protection-coalesce", defaultConfig.HotRowProtection.Coalesce, "If true, queued autocommit UPDATEs which only increment or decrement columns of the same row will be merged into a single statement. Requires --hot-row-protection-autocommit.")
+	utils.SetFlagBoolVar(fs, &currentConfig.HotRowProtection.KeyByPrimaryKey, "hot-row-protection-key-by-primary-key", defaultConfig.HotRowProtection.KeyByPrimaryKey, "If true, UPDATEs and DELETEs whose WHERE clause pins the primary key will be queued by the primary key values instead of the whole WHERE clause.")
 
 	utils.SetFlagBoolVar(fs, &currentConfig.EnableTransactionLimit, "enable-transaction-limit", defaultConfig.EnableTransactionLimit, "If true, limit on number of transactions open at the same time will be enforced for all users. User trying to open a new transaction after exhausting their limit will receive an error immediately, regardless of whether there are available slots or not.")
 	utils.SetFlagBoolVar(fs, &currentConfig.EnableTransactionLimitDryRun, "enable-transaction-limit-dry-run", defaultConfig.EnableTransactionLimitDryRun, "If true, limit on number of transactions open at the same time will be tracked for all users, but not enforced.")
@@ -190,6 +194,10 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	utils.SetFlagBoolVar(fs, &currentConfig.TransactionLimitByComponent, "transaction-limit-by-component", defaultConfig.TransactionLimitByComponent, "Include CallerID.component when considering who the user is for the purpose of transaction limit.")
 	utils.SetFlagBoolVar(fs, &currentConfig.TransactionLimitBySubcomponent, "transaction-limit-by-subcomponent", defaultConfig.TransactionLimitBySubcomponent, "Include CallerID.subcomponent when considering who the user is for the purpose of transaction limit.")
 
+	utils.SetFlagBoolVar(fs, &currentConfig.EnableUserQuotas, "enable-user-quotas", defaultConfig.EnableUserQuotas, "If true, the per-user and per-workload quotas of --user-quotas-file will be enforced on the transaction, OLTP and OLAP connection pools. Callers waiting for a saturated pool are served with weighted fair queueing.")
+	utils.SetFlagBoolVar(fs, &currentConfig.EnableUserQuotasDryRun, "enable-user-quotas-dry-run", defaultConfig.EnableUserQuotasDryRun, "If true, the per-user and per-workload quotas of --user-quotas-file will be tracked, but not enforced.")
+	utils.SetFlagStringVar(fs, &currentConfig.UserQuotasFile, "user-quotas-file", defaultConfig.UserQuotasFile, "JSON file with the per-user and per-workload connection pool quotas and weights.")
+
 	utils.SetFlagBoolVar(fs, &enableHeartbeat, "heartbeat-enable", false, "If true, vttablet records (if master) or checks (if replica) the current time of a replication heartbeat in the sidecar database's heartbeat table. The result is used to inform the serving state of the vttablet via healthchecks.")
 	utils.SetFlagDurationVar(fs, &heartbeatInterval, "heartbeat-interval", 1*time.Second, "How frequently to read and write replication heartbeat.")
 	utils.SetFlagDurationVar(fs, &heartbeatOnDemandDuration, "heartbeat-on-demand-duration", 0, "If non-zero, heartbeats are only written upon consumer request, and only run for up to given duration following the request. Frequent requests can keep the heartbeat running consistently; when requests are infrequent heartbeat may completely stop between requests")
@@ -363,6 +371,7 @@ type TabletConfig struct {
 	EnableTableGC bool `json:"-"` // can be turned off programmatically by tests
 
 	TransactionLimitConfig `json:"-"`
+	UserQuotaConfig        `json:"-"`
 
 	EnforceStrictTransTables bool `json:"-"`
 	EnableOnlineDDL          bool `json:"-"`
@@ -617,6 +626,17 @@ type HotRowProtectionConfig struct {
 	MaxQueueSize       int    `json:"maxQueueSize,omitempty"`
 	MaxGlobalQueueSize int    `json:"maxGlobalQueueSize,omitempty"`
 	MaxConcurrency     int    `json:"maxConcurrency,omitempty"`
+	// Autocommit enables the hot row protection for UPDATEs and DELETEs
+	// which are not part of an explicit transaction.
+	Autocommit bool `json:"autocommit,omitempty"`
+	// Coalesce merges queued autocommit UPDATEs which only increment or
+	// decrement columns of the same row into one statement. Constraints like
+	// UNSIGNED or CHECK are then only checked for the sum of the merged
+	// UPDATEs, which fail or succeed together.
+	Coalesce bool `json:"coalesce,omitempty"`
+	// KeyByPrimaryKey identifies rows by their primary key values rather
+	// than by the WHERE clause whenever the WHERE clause pins the primary key.
+	KeyByPrimaryKey bool `json:"keyByPrimaryKey,omitempty"`
 }
 
 // SemiSyncMonitorConfig contains the config for the semi-sync monitor.
@@ -840,6 +860,94 @@ type TransactionLimitConfig struct {
 	TransactionLimitBySubcomponent bool
 }
 
+// UserQuotaConfig captures configuration of the per-user and per-workload
+// connection pool quotas.
+type UserQuotaConfig struct {
+	EnableUserQuotas       bool
+	EnableUserQuotasDryRun bool
+	UserQuotasFile         string
+	// UserQuotas is loaded from UserQuotasFile when the config is verified.
+	UserQuotas *UserQuotas
+}
+
+// UserQuotas is the content of --user-quotas-file. A caller is identified by
+// its VTGateCallerID.username, and optionally by the workload name of its
+// queries. The default quota applies to every user without an entry of its own.
+type UserQuotas struct {
+	Default   UserQuota            `json:"default"`
+	Users     map[string]UserQuota `json:"users,omitempty"`
+	Workloads map[string]UserQuota `json:"workloads,omitempty"`
+}
+
+// UserQuota limits the number of connections a user or a workload may hold
+// concurrently in each pool. Zero means no limit. Weight is the share of a
+// saturated pool it is given relative to the other callers, and defaults to 1.
+type UserQuota struct {
+	MaxTransactions    int     `json:"maxTransactions,omitempty"`
+	MaxOltpConnections int     `json:"maxOltpConnections,omitempty"`
+	MaxOlapConnections int     `json:"maxOlapConnections,omitempty"`
+	Weight             float64 `json:"weight,omitempty"`
+}
+
+// ForUser returns the quota of the given user.
+func (q *UserQuotas) ForUser(user string) UserQuota {
+	if quota, ok := q.Users[user]; ok {
+		return quota
+	}
+	return q.Default
+}
+
+// ForWorkload returns the quota of the given workload, if there is one.
+func (q *UserQuotas) ForWorkload(workload string) (UserQuota, bool) {
+	if workload == "" {
+		return UserQuota{}, false
+	}
+	quota, ok := q.Workloads[workload]
+	return quota, ok
+}
+
+// LoadUserQuotas reads and validates a user quotas file.
+func LoadUserQuotas(path string) (*UserQuotas, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user quotas file %s: %w", path, err)
+	}
+	quotas := &UserQuotas{}
+	if err := json.Unmarshal(data, quotas); err != nil {
+		return nil, fmt.Errorf("failed to parse user quotas file %s: %w", path, err)
+	}
+	if err := quotas.verify(); err != nil {
+		return nil, fmt.Errorf("invalid user quotas file %s: %w", path, err)
+	}
+	return quotas, nil
+}
+
+func (q *UserQuotas) verify() error {
+	check := func(name string, quota UserQuota) error {
+		if quota.MaxTransactions < 0 || quota.MaxOltpConnections < 0 || quota.MaxOlapConnections < 0 {
+			return fmt.Errorf("quota of %s must not be negative", name)
+		}
+		if quota.Weight < 0 {
+			return fmt.Errorf("weight of %s must not be negative", name)
+		}
+		return nil
+	}
+	if err := check("the default user", q.Default); err != nil {
+		return err
+	}
+	for user, quota := range q.Users {
+		if err := check("user "+user, quota); err != nil {
+			return err
+		}
+	}
+	for workload, quota := range q.Workloads {
+		if err := check("workload "+workload, quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RowStreamerConfig contains configuration parameters for a vstreamer (source) that is
 // copying the contents of a table to a target
 type RowStreamerConfig struct {
@@ -902,6 +1010,9 @@ func (c *TabletConfig) Verify() error {
 	if err := c.verifyTxThrottlerConfig(); err != nil {
 		return err
 	}
+	if err := c.verifyUserQuotaConfig(); err != nil {
+		return err
+	}
 	if v := c.HotRowProtection.MaxQueueSize; v <= 0 {
 		return fmt.Errorf("--hot-row-protection-max-queue-size must be > 0 (specified value: %v)", v)
 	}
@@ -914,6 +1025,9 @@ func (c *TabletConfig) Verify() error {
 	if v := c.HotRowProtection.MaxConcurrency; v <= 0 {
 		return fmt.Errorf("--hot-row-protection-concurrent-transactions must be > 0 (specified value: %v)", v)
 	}
+	if c.HotRowProtection.Coalesce && !c.HotRowProtection.Autocommit {
+		return errors.New("--hot-row-protection-coalesce requires --hot-row-protection-autocommit")
+	}
 	return nil
 }
 
@@ -996,6 +1110,33 @@ func (c *TabletConfig) verifyTransactionLimitConfig() error {
 	return nil
 }
 
+// verifyUserQuotaConfig checks UserQuotaConfig for sanity, and loads the
+// quotas file.
+func (c *TabletConfig) verifyUserQuotaConfig() error {
+	actual, dryRun := c.EnableUserQuotas, c.EnableUserQuotasDryRun
+	if actual && dryRun {
+		return errors.New("only one of two flags allowed: --enable-user-quotas or --enable-user-quotas-dry-run")
+	}
+
+	// Skip other checks if this is not enabled
+	if !actual && !dryRun {
+		return nil
+	}
+
+	if c.UserQuotas != nil {
+		return c.UserQuotas.verify()
+	}
+	if c.UserQuotasFile == "" {
+		return errors.New("--user-quotas-file must be set when user quotas are enabled")
+	}
+	quotas, err := LoadUserQuotas(c.UserQuotasFile)
+	if err != nil {
+		return err
+	}
+	c.UserQuotas = quotas
+	return nil
+}
+
 // verifyTxThrottlerConfig checks the TxThrottler related config for sanity.
 func (c *TabletConfig) verifyTxThrottlerConfig() error {
 	if !c.EnableTxThrottler {
@@ -17,6 +17,8 @@ limitations under the License.
 package tabletenv
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -503,3 +505,62 @@ func TestVerifyUnmanagedTabletConfig(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "testPassword", config.DB.App.Password)
 }
+
+func TestVerifyUserQuotaConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	valid := writeFile("valid.json", `{
+		"default": {"maxTransactions": 5, "weight": 1},
+		"users": {"batch": {"maxTransactions": 2, "maxOlapConnections": 1}},
+		"workloads": {"reports": {"maxOltpConnections": 3, "weight": 0.5}}
+	}`)
+	negative := writeFile("negative.json", `{"users": {"batch": {"maxTransactions": -1}}}`)
+	malformed := writeFile("malformed.json", `{"users": [`)
+
+	tests := []struct {
+		name    string
+		enable  bool
+		dryRun  bool
+		file    string
+		wantErr string
+	}{
+		{name: "disabled"},
+		{name: "enabled", enable: true, file: valid},
+		{name: "dry run", dryRun: true, file: valid},
+		{name: "both", enable: true, dryRun: true, file: valid, wantErr: "only one of two flags allowed"},
+		{name: "no file", enable: true, wantErr: "--user-quotas-file must be set"},
+		{name: "missing file", enable: true, file: filepath.Join(dir, "missing.json"), wantErr: "failed to read user quotas file"},
+		{name: "malformed", enable: true, file: malformed, wantErr: "failed to parse user quotas file"},
+		{name: "negative", enable: true, file: negative, wantErr: "quota of user batch must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultConfig.Clone()
+			config.EnableUserQuotas = tt.enable
+			config.EnableUserQuotasDryRun = tt.dryRun
+			config.UserQuotasFile = tt.file
+
+			err := config.verifyUserQuotaConfig()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if !tt.enable && !tt.dryRun {
+				assert.Nil(t, config.UserQuotas)
+				return
+			}
+			assert.Equal(t, 2, config.UserQuotas.ForUser("batch").MaxTransactions)
+			assert.Equal(t, 5, config.UserQuotas.ForUser("api").MaxTransactions)
+			quota, ok := config.UserQuotas.ForWorkload("reports")
+			assert.True(t, ok)
+			assert.Equal(t, 3, quota.MaxOltpConnections)
+			_, ok = config.UserQuotas.ForWorkload("")
+			assert.False(t, ok)
+		})
+	}
+}
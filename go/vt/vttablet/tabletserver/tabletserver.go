@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"net/http"
 	"os"
@@ -941,7 +942,11 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 				targetTabletType: targetType,
 				setting:          connSetting,
 			}
-			result, err = qre.Execute()
+			if transactionID == 0 && reservedID == 0 && tsv.enableHotRowProtection && tsv.config.HotRowProtection.Autocommit {
+				result, err = tsv.executeWithHotRowProtection(qre)
+			} else {
+				result, err = qre.Execute()
+			}
 			if err != nil {
 				return err
 			}
@@ -966,6 +971,100 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 	return result, err
 }
 
+// executeWithHotRowProtection executes an UPDATE or DELETE outside of an
+// explicit transaction only after the transactions for the same row (range)
+// which are already in flight are done. See beginWaitForSameRangeTransactions.
+// If coalescing is enabled, queued UPDATEs which only increment or decrement
+// columns of the same row are merged into one statement instead.
+func (tsv *TabletServer) executeWithHotRowProtection(qre *QueryExecutor) (*sqltypes.Result, error) {
+	key, table := tsv.txSerializerKey(qre.query, qre.plan, qre.bindVars)
+	if key == "" {
+		// Query is not subject to tx serialization/hot row protection.
+		return qre.Execute()
+	}
+
+	startTime := time.Now()
+	if tsv.config.HotRowProtection.Coalesce && qre.plan.Increment != nil {
+		if batchKey, deltas, ok := incrementBatch(qre.plan.Increment, qre.bindVars, qre.setting); ok {
+			// Only the leader of a batch executes the merged UPDATE. The
+			// query rules and ACLs must be checked for every caller.
+			if err := qre.checkPermissions(); err != nil {
+				return nil, err
+			}
+			qre.permissionsChecked = true
+			result, waited, err := tsv.qe.txSerializer.Coalesce(qre.ctx, key, batchKey, table, deltas, func(deltas []int64) (*sqltypes.Result, error) {
+				return qre.executeIncrement(deltas)
+			})
+			if waited {
+				tsv.stats.WaitTimings.Record("TxSerializer", startTime)
+			}
+			return result, err
+		}
+	}
+
+	done, waited, err := tsv.qe.txSerializer.Wait(qre.ctx, key, table)
+	if waited {
+		tsv.stats.WaitTimings.Record("TxSerializer", startTime)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return qre.Execute()
+}
+
+// incrementBatch returns the deltas of an UPDATE which only increments or
+// decrements columns, and a key which is identical for all such UPDATEs
+// which differ in their deltas only and run with the same session settings.
+// ok is false if a delta is not an integer.
+func incrementBatch(incr *planbuilder.IncrementPlan, bindVariables map[string]*querypb.BindVariable, setting *smartconnpool.Setting) (batchKey string, deltas []int64, ok bool) {
+	bindVars := maps.Clone(bindVariables)
+	if bindVars == nil {
+		bindVars = make(map[string]*querypb.BindVariable, len(incr.Deltas)+1)
+	}
+	// The row limit is added by the QueryExecutor and the same for all queries.
+	bindVars["#maxLimit"] = sqltypes.Int64BindVariable(0)
+	deltas = make([]int64, len(incr.Deltas))
+	for i, expr := range incr.Deltas {
+		var val sqltypes.Value
+		switch expr := expr.(type) {
+		case *sqlparser.Literal:
+			val = sqltypes.MakeTrusted(sqltypes.Int64, []byte(expr.Val))
+		case *sqlparser.Argument:
+			bv, found := bindVariables[expr.Name]
+			if !found {
+				return "", nil, false
+			}
+			var err error
+			if val, err = sqltypes.BindVariableToValue(bv); err != nil {
+				return "", nil, false
+			}
+		}
+		if !val.IsIntegral() {
+			return "", nil, false
+		}
+		delta, err := val.ToInt64()
+		if err != nil {
+			return "", nil, false
+		}
+		if incr.Negate[i] {
+			delta = -delta
+		}
+		deltas[i] = delta
+		// The key must not depend on the deltas.
+		bindVars[planbuilder.IncrementBindVar(i)] = sqltypes.Int64BindVariable(0)
+	}
+	key, err := incr.Query.GenerateQuery(bindVars, nil)
+	if err != nil {
+		return "", nil, false
+	}
+	if setting != nil {
+		// The result of an UPDATE depends on settings like sql_mode.
+		key = setting.ApplyQuery() + "; " + key
+	}
+	return key, deltas, true
+}
+
 // smallerTimeout returns the smaller of the two timeouts.
 // 0 is treated as infinity.
 func smallerTimeout(t1, t2 time.Duration) time.Duration {
@@ -1143,7 +1242,12 @@ func (tsv *TabletServer) computeTxSerializerKey(ctx context.Context, logStats *t
 		logComputeRowSerializerKey.Errorf("failed to get plan for query: %v err: %v", sql, err)
 		return "", ""
 	}
+	return tsv.txSerializerKey(sql, plan, bindVariables)
+}
 
+// txSerializerKey is the same as computeTxSerializerKey but for an already
+// planned query.
+func (tsv *TabletServer) txSerializerKey(sql string, plan *TabletPlan, bindVariables map[string]*querypb.BindVariable) (string, string) {
 	switch plan.PlanID {
 	// Serialize only UPDATE or DELETE queries.
 	case planbuilder.PlanUpdate, planbuilder.PlanUpdateLimit,
@@ -1158,7 +1262,13 @@ func (tsv *TabletServer) computeTxSerializerKey(ctx context.Context, logStats *t
 		return "", ""
 	}
 
-	where, err := plan.WhereClause.GenerateQuery(bindVariables, nil)
+	whereClause := plan.WhereClause
+	if tsv.config.HotRowProtection.KeyByPrimaryKey && plan.PKWhereClause != nil {
+		// Identify the row by its primary key only, e.g. "where id = 1 and
+		// status = 'new'" becomes "where id = 1".
+		whereClause = plan.PKWhereClause
+	}
+	where, err := whereClause.GenerateQuery(bindVariables, nil)
 	if err != nil {
 		logComputeRowSerializerKey.Errorf("failed to substitute bind vars in where clause: %v query: %v bind vars: %v", err, sql, bindVariables)
 		return "", ""
@@ -1232,14 +1342,34 @@ func (tsv *TabletServer) PurgeMessages(ctx context.Context, target *querypb.Targ
 	})
 }
 
+// DeadLetterMessages dead-letters the list of messages for a given message table,
+// in one transaction. It returns the number of messages successfully dead-lettered.
+func (tsv *TabletServer) DeadLetterMessages(ctx context.Context, target *querypb.Target, querygen messager.QueryGenerator, ids []string) (count int64, err error) {
+	return tsv.execDMLs(ctx, target, func() ([]*querypb.BoundQuery, error) {
+		return querygen.GenerateDeadLetterQueries(ids), nil
+	})
+}
+
 func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, queryGenerator func() (string, map[string]*querypb.BindVariable, error)) (count int64, err error) {
+	return tsv.execDMLs(ctx, target, func() ([]*querypb.BoundQuery, error) {
+		query, bv, err := queryGenerator()
+		if err != nil {
+			return nil, err
+		}
+		return []*querypb.BoundQuery{{Sql: query, BindVariables: bv}}, nil
+	})
+}
+
+// execDMLs executes the generated queries in one transaction, and returns
+// the number of rows affected by the last one.
+func (tsv *TabletServer) execDMLs(ctx context.Context, target *querypb.Target, queryGenerator func() ([]*querypb.BoundQuery, error)) (count int64, err error) {
 	if err = tsv.sm.StartRequest(ctx, target, false /* allowOnShutdown */); err != nil {
 		return 0, err
 	}
 	defer tsv.sm.EndRequest()
 	defer tsv.handlePanicAndSendLogStats("ack", nil, nil)
 
-	query, bv, err := queryGenerator()
+	queries, err := queryGenerator()
 	if err != nil {
 		return 0, err
 	}
@@ -1255,16 +1385,19 @@ func (tsv *TabletServer) execDML(ctx context.Context, target *querypb.Target, qu
 			tsv.Rollback(ctx, target, state.TransactionID)
 		}
 	}()
-	qr, err := tsv.Execute(ctx, target, query, bv, state.TransactionID, 0, nil)
-	if err != nil {
-		return 0, err
+	for _, query := range queries {
+		qr, err := tsv.Execute(ctx, target, query.Sql, query.BindVariables, state.TransactionID, 0, nil)
+		if err != nil {
+			return 0, err
+		}
+		count = int64(qr.RowsAffected)
 	}
 	if _, err = tsv.Commit(ctx, target, state.TransactionID); err != nil {
 		state.TransactionID = 0
 		return 0, err
 	}
 	state.TransactionID = 0
-	return int64(qr.RowsAffected), nil
+	return count, nil
 }
 
 // VStream streams VReplication events.
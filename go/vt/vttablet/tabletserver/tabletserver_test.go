@@ -32,11 +32,16 @@ import (
 
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/mysql/sqlerror"
+	"vitess.io/vitess/go/pools/smartconnpool"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/callinfo/fakecallinfo"
 	"vitess.io/vitess/go/vt/sidecardb"
 	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/rules"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 
 	"vitess.io/vitess/go/mysql/fakesqldb"
@@ -1318,6 +1323,258 @@ func waitForTxSerializationPendingQueries(tsv *TabletServer, key string, i int)
 	}
 }
 
+func TestSerializeAutocommitSameRow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// tx1 and tx2 are autocommit UPDATEs for the same primary key. tx2 must not
+	// start until tx1 has finished, although the WHERE clauses differ.
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.Mode = tabletenv.Enable
+	cfg.HotRowProtection.MaxConcurrency = 1
+	cfg.HotRowProtection.Autocommit = true
+	cfg.HotRowProtection.KeyByPrimaryKey = true
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	countStart := tsv.stats.WaitTimings.Counts()["TabletServerTest.TxSerializer"]
+
+	q1 := "update test_table set name_string = 'tx1' where pk = :pk and `name` = :name"
+	q2 := "update test_table set name_string = 'tx2' where pk = :pk"
+	db.AddQuery("update test_table set name_string = 'tx2' where pk = 1 limit 10001", &sqltypes.Result{RowsAffected: 1})
+
+	tx1Started := make(chan struct{})
+	db.SetBeforeFunc("update test_table set name_string = 'tx1' where pk = 1 and `name` = 1 limit 10001",
+		func() {
+			close(tx1Started)
+			if err := waitForTxSerializationPendingQueries(tsv, "test_table where pk = 1", 2); err != nil {
+				t.Error(err)
+			}
+		})
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		bv := map[string]*querypb.BindVariable{
+			"pk":   sqltypes.Int64BindVariable(1),
+			"name": sqltypes.Int64BindVariable(1),
+		}
+		_, err := tsv.Execute(ctx, &target, q1, bv, 0, 0, nil)
+		assert.NoError(t, err)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		<-tx1Started
+		bv := map[string]*querypb.BindVariable{
+			"pk": sqltypes.Int64BindVariable(1),
+		}
+		_, err := tsv.Execute(ctx, &target, q2, bv, 0, 0, nil)
+		assert.NoError(t, err)
+	}()
+	wg.Wait()
+
+	got := tsv.stats.WaitTimings.Counts()["TabletServerTest.TxSerializer"]
+	assert.Equal(t, countStart+1, got, "tx2 should have been serialized")
+}
+
+func TestSerializeAutocommitCoalesce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// tx1 holds the row while the increments tx2, tx3 and tx4 queue up. They
+	// must be executed as a single UPDATE once tx1 is done.
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.Mode = tabletenv.Enable
+	cfg.HotRowProtection.MaxConcurrency = 1
+	cfg.HotRowProtection.Autocommit = true
+	cfg.HotRowProtection.Coalesce = true
+	cfg.HotRowProtection.KeyByPrimaryKey = true
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	coalesced := tsv.exporter.NewCountersWithSingleLabel("TxSerializerCoalesced", "", "table_name")
+	coalesced.ResetAll()
+
+	q1 := "update test_table set name_string = 'tx1' where pk = :pk and `name` = :name"
+	incr := "update test_table set `name` = `name` + :delta where pk = :pk"
+	merged := "update test_table set `name` = `name` + 6 where pk = 1 limit 10001"
+	db.AddQuery(merged, &sqltypes.Result{RowsAffected: 1})
+
+	tx1Started := make(chan struct{})
+	db.SetBeforeFunc("update test_table set name_string = 'tx1' where pk = 1 and `name` = 1 limit 10001",
+		func() {
+			close(tx1Started)
+			assert.Eventually(t, func() bool {
+				return coalesced.Counts()["test_table"] == 2
+			}, 10*time.Second, time.Millisecond)
+		})
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		bv := map[string]*querypb.BindVariable{
+			"pk":   sqltypes.Int64BindVariable(1),
+			"name": sqltypes.Int64BindVariable(1),
+		}
+		_, err := tsv.Execute(ctx, &target, q1, bv, 0, 0, nil)
+		assert.NoError(t, err)
+	}()
+	increment := func(delta int64) {
+		defer wg.Done()
+
+		bv := map[string]*querypb.BindVariable{
+			"pk":    sqltypes.Int64BindVariable(1),
+			"delta": sqltypes.Int64BindVariable(delta),
+		}
+		result, err := tsv.Execute(ctx, &target, incr, bv, 0, 0, nil)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 1, result.RowsAffected)
+		}
+	}
+
+	<-tx1Started
+	wg.Add(1)
+	go increment(1)
+	require.NoError(t, waitForTxSerializationPendingQueries(tsv, "test_table where pk = 1", 2))
+	wg.Add(2)
+	go increment(2)
+	go increment(3)
+	wg.Wait()
+
+	assert.Equal(t, 1, db.GetQueryCalledNum(merged))
+}
+
+func TestSerializeAutocommitCoalesceCheckPermissions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// tx1 holds the row while the increment of tx2 is queued. The increment
+	// of the denied user must fail instead of joining the batch of tx2.
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.Mode = tabletenv.Enable
+	cfg.HotRowProtection.MaxConcurrency = 1
+	cfg.HotRowProtection.Autocommit = true
+	cfg.HotRowProtection.Coalesce = true
+	cfg.HotRowProtection.KeyByPrimaryKey = true
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	denyRule := rules.NewQueryRule("deny updates", "deny updates", rules.QRFail)
+	denyRule.SetUserCond("denied")
+	denyRule.AddPlanCond(planbuilder.PlanUpdateLimit)
+	denyRules := rules.New()
+	denyRules.Add(denyRule)
+	tsv.qe.queryRuleSources.RegisterSource("denyUpdates")
+	defer tsv.qe.queryRuleSources.UnRegisterSource("denyUpdates")
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules("denyUpdates", denyRules))
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	q1 := "update test_table set name_string = 'tx1' where pk = :pk and `name` = :name"
+	incr := "update test_table set `name` = `name` + :delta where pk = :pk"
+	tx2 := "update test_table set `name` = `name` + 1 where pk = 1 limit 10001"
+	db.AddQuery(tx2, &sqltypes.Result{RowsAffected: 1})
+
+	tx1Started := make(chan struct{})
+	tx1Release := make(chan struct{})
+	db.SetBeforeFunc("update test_table set name_string = 'tx1' where pk = 1 and `name` = 1 limit 10001",
+		func() {
+			close(tx1Started)
+			<-tx1Release
+		})
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		bv := map[string]*querypb.BindVariable{
+			"pk":   sqltypes.Int64BindVariable(1),
+			"name": sqltypes.Int64BindVariable(1),
+		}
+		_, err := tsv.Execute(ctx, &target, q1, bv, 0, 0, nil)
+		assert.NoError(t, err)
+	}()
+	increment := func(ctx context.Context, delta int64) error {
+		bv := map[string]*querypb.BindVariable{
+			"pk":    sqltypes.Int64BindVariable(1),
+			"delta": sqltypes.Int64BindVariable(delta),
+		}
+		_, err := tsv.Execute(ctx, &target, incr, bv, 0, 0, nil)
+		return err
+	}
+
+	<-tx1Started
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, increment(ctx, 1))
+	}()
+	require.NoError(t, waitForTxSerializationPendingQueries(tsv, "test_table where pk = 1", 2))
+
+	deniedCtx := callinfo.NewContext(ctx, &fakecallinfo.FakeCallInfo{User: "denied"})
+	denied := make(chan error, 1)
+	go func() {
+		denied <- increment(deniedCtx, 2)
+	}()
+	select {
+	case err := <-denied:
+		assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err))
+	case <-time.After(10 * time.Second):
+		t.Error("the denied increment joined the batch")
+	}
+	close(tx1Release)
+	wg.Wait()
+
+	assert.Equal(t, 1, db.GetQueryCalledNum(tx2))
+}
+
+func TestIncrementBatchSetting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, tsv := setupTabletServerTest(t, ctx, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	logStats := tabletenv.NewLogStats(ctx, "IncrementBatch", streamlog.NewQueryLogConfigForTest())
+	plan, err := tsv.qe.GetPlan(ctx, logStats, "update test_table set `name` = `name` + :delta where pk = :pk", false, false)
+	require.NoError(t, err)
+	require.NotNil(t, plan.Increment)
+
+	bv := func(delta int64) map[string]*querypb.BindVariable {
+		return map[string]*querypb.BindVariable{
+			"pk":    sqltypes.Int64BindVariable(1),
+			"delta": sqltypes.Int64BindVariable(delta),
+		}
+	}
+	strict := smartconnpool.NewSetting("set @@sql_mode = 'STRICT_TRANS_TABLES'", "set @@sql_mode = default")
+	lenient := smartconnpool.NewSetting("set @@sql_mode = ''", "set @@sql_mode = default")
+
+	key1, deltas, ok := incrementBatch(plan.Increment, bv(1), nil)
+	require.True(t, ok)
+	assert.Equal(t, []int64{1}, deltas)
+	key2, _, ok := incrementBatch(plan.Increment, bv(2), nil)
+	require.True(t, ok)
+	assert.Equal(t, key1, key2)
+
+	strictKey1, _, ok := incrementBatch(plan.Increment, bv(1), strict)
+	require.True(t, ok)
+	strictKey2, _, ok := incrementBatch(plan.Increment, bv(2), strict)
+	require.True(t, ok)
+	lenientKey, _, ok := incrementBatch(plan.Increment, bv(1), lenient)
+	require.True(t, ok)
+	assert.Equal(t, strictKey1, strictKey2)
+	assert.NotEqual(t, key1, strictKey1)
+	assert.NotEqual(t, strictKey1, lenientKey)
+}
+
 func TestSerializeTransactionsSameRow_TooManyPendingRequests(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1657,6 +1914,27 @@ func TestPurgeMessages(t *testing.T) {
 	require.EqualValues(t, 1, count)
 }
 
+func TestDeadLetterMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, tsv, db, closer := newTestTxExecutor(t, ctx)
+	defer closer()
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+
+	gen, err := tsv.messager.GetGenerator("msg")
+	require.NoError(t, err)
+
+	// Without a dead-letter target, the messages are acked.
+	_, err = tsv.DeadLetterMessages(ctx, &target, gen, []string{"1", "2"})
+	want := "query: 'update msg set time_acked"
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), want)
+	db.AddQueryPattern("update msg set time_acked = .*", &sqltypes.Result{RowsAffected: 2})
+	count, err := tsv.DeadLetterMessages(ctx, &target, gen, []string{"1", "2"})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count)
+}
+
 func TestHandleExecUnknownError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -34,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tx"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txlimiter"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/userquota"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -68,6 +69,7 @@ type (
 		scp     *StatefulConnectionPool
 		ticks   *timer.Timer
 		limiter txlimiter.TxLimiter
+		quotas  userquota.Limiter
 
 		logMu   sync.Mutex
 		lastLog time.Time
@@ -78,11 +80,15 @@ type (
 // NewTxPool creates a new TxPool. It's not operational until it's Open'd.
 func NewTxPool(env tabletenv.Env, limiter txlimiter.TxLimiter) *TxPool {
 	config := env.Config()
+	scp := NewStatefulConnPool(env)
 	axp := &TxPool{
 		env:     env,
-		scp:     NewStatefulConnPool(env),
+		scp:     scp,
 		ticks:   timer.NewTimer(txKillerTimeoutInterval(config)),
 		limiter: limiter,
+		quotas: userquota.New(env, userquota.TxPool, func() int64 {
+			return int64(scp.Capacity())
+		}, config.TxPool.Timeout),
 		txStats: env.Exporter().NewTimings("Transactions", "Transaction stats", "operation"),
 	}
 	// Careful: conns also exports name+"xxx" vars,
@@ -249,14 +255,24 @@ func (tp *TxPool) Begin(ctx context.Context, options *querypb.ExecuteOptions, re
 		if !tp.limiter.Get(immediateCaller, effectiveCaller) {
 			return nil, "", "", vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "per-user transaction pool connection limit exceeded")
 		}
+		var slot *userquota.Slot
+		slot, err = tp.quotas.Acquire(ctx, options)
+		if err != nil {
+			tp.limiter.Release(immediateCaller, effectiveCaller)
+			return nil, "", "", err
+		}
 		conn, err = tp.createConn(ctx, options, setting)
 		defer func() {
 			if err != nil {
 				// The transaction limiter frees transactions on rollback or commit. If we fail to create the transaction,
 				// release immediately since there will be no rollback or commit.
 				tp.limiter.Release(immediateCaller, effectiveCaller)
+				slot.Release()
 			}
 		}()
+		if conn != nil {
+			conn.quotaSlot = slot
+		}
 	}
 	if err != nil {
 		return nil, "", "", err
@@ -442,6 +458,7 @@ func (tp *TxPool) LogActive() {
 func (tp *TxPool) txComplete(conn *StatefulConnection, reason tx.ReleaseReason) {
 	conn.LogTransaction(reason)
 	tp.limiter.Release(conn.TxProperties().ImmediateCaller, conn.TxProperties().EffectiveCaller)
+	conn.releaseQuotaSlot()
 	conn.CleanTxState()
 }
 
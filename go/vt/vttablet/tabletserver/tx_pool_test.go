@@ -787,6 +787,42 @@ func TestTxPoolBeginStatements(t *testing.T) {
 	}
 }
 
+func TestTxPoolUserQuota(t *testing.T) {
+	env := newEnv("TabletServerTest")
+	env.Config().EnableUserQuotas = true
+	env.Config().UserQuotas = &tabletenv.UserQuotas{
+		Users: map[string]tabletenv.UserQuota{"batch": {MaxTransactions: 1}},
+	}
+	_, txPool, limiter, closer := setupWithEnv(t, env)
+	defer closer()
+
+	ctx := callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID("batch"))
+	conn, _, _, err := txPool.Begin(ctx, &querypb.ExecuteOptions{}, false, 0, nil)
+	require.NoError(t, err)
+	conn.Unlock()
+
+	_, _, _, err = txPool.Begin(ctx, &querypb.ExecuteOptions{}, false, 0, nil)
+	require.ErrorContains(t, err, "per-user TransactionPool quota exceeded for user batch")
+	require.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	// The transaction limiter slot taken before the rejection is given back.
+	actions := limiter.Actions()
+	require.Len(t, actions, 3)
+	require.True(t, actions[2].isRelease)
+
+	// Other users are not affected.
+	other, _, _, err := txPool.Begin(context.Background(), &querypb.ExecuteOptions{}, false, 0, nil)
+	require.NoError(t, err)
+	txPool.RollbackAndRelease(context.Background(), other)
+
+	// Completing the transaction gives its quota slot back.
+	_, err = txPool.Commit(ctx, conn)
+	require.NoError(t, err)
+	conn.Release(tx.TxCommit)
+	conn, _, _, err = txPool.Begin(ctx, &querypb.ExecuteOptions{}, false, 0, nil)
+	require.NoError(t, err)
+	txPool.RollbackAndRelease(ctx, conn)
+}
+
 func newTxPool() (*TxPool, *fakeLimiter) {
 	return newTxPoolWithEnv(newEnv("TabletServerTest"))
 }
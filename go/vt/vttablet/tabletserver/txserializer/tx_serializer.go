@@ -21,12 +21,14 @@ package txserializer
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/streamlog"
 	"vitess.io/vitess/go/sync2"
@@ -77,8 +79,11 @@ type TxSerializer struct {
 	// been rejected due to exceeding the max queue size per row (range).
 	//
 	// globalQueueExceeded is the same as queueExceeded but for the global queue.
-	waits, waitsDryRun, queueExceeded, queueExceededDryRun *stats.CountersWithSingleLabel
-	globalQueueExceeded, globalQueueExceededDryRun         *stats.Counter
+	//
+	// coalesced counts per table how many UPDATEs were merged into the
+	// statement of another UPDATE for the same row (see Coalesce()).
+	waits, waitsDryRun, queueExceeded, queueExceededDryRun, coalesced *stats.CountersWithSingleLabel
+	globalQueueExceeded, globalQueueExceededDryRun                    *stats.Counter
 
 	log                          *logutil.ThrottledLogger
 	logDryRun                    *logutil.ThrottledLogger
@@ -88,6 +93,7 @@ type TxSerializer struct {
 
 	mu            sync.Mutex
 	queues        map[string]*queue
+	batches       map[string]*batch
 	globalSize    int
 	redactUIQuery bool
 }
@@ -118,6 +124,10 @@ func New(env tabletenv.Env) *TxSerializer {
 			"TxSerializerQueueExceededDryRun",
 			"Dry-run Number of transactions that were rejected because the max queue size was exceeded",
 			"table_name"),
+		coalesced: env.Exporter().NewCountersWithSingleLabel(
+			"TxSerializerCoalesced",
+			"Number of UPDATEs which were merged into the statement of another UPDATE for the same row",
+			"table_name"),
 		globalQueueExceeded: env.Exporter().NewCounter(
 			"TxSerializerGlobalQueueExceeded",
 			"Number of transactions that were rejected on the global queue because of exceeding the max queue size per row range"),
@@ -130,6 +140,7 @@ func New(env tabletenv.Env) *TxSerializer {
 		logQueueExceededDryRun:       logutil.NewThrottledLogger("HotRowProtection QueueExceeded DryRun", 5*time.Second),
 		logGlobalQueueExceededDryRun: logutil.NewThrottledLogger("HotRowProtection GlobalQueueExceeded DryRun", 5*time.Second),
 		queues:                       make(map[string]*queue),
+		batches:                      make(map[string]*batch),
 		redactUIQuery:                streamlog.NewQueryLogConfigForTest().RedactDebugUIQueries,
 	}
 
@@ -314,6 +325,170 @@ func (txs *TxSerializer) unlockLocked(key string, returnSlot bool) {
 	<-q.availableSlots
 }
 
+// ExecFunc executes the statement of a batch of coalesced UPDATEs.
+// deltas holds the sums of the deltas of all UPDATEs in the batch.
+type ExecFunc func(deltas []int64) (*sqltypes.Result, error)
+
+// Coalesce is an alternative to Wait() for UPDATEs which only increment or
+// decrement columns of a row. While such an UPDATE is queued, further UPDATEs
+// with the same batchKey are not queued separately but merged into it: Their
+// deltas are added to the deltas of the queued UPDATE. When the batch has its
+// turn, "exec" is called once with the summed deltas and all callers in the
+// batch receive its result.
+//
+// Note that constraints of the row are only checked for the summed deltas,
+// not for the intermediate values: For example, two UPDATEs which decrement
+// an UNSIGNED column (or one with a CHECK constraint) with the value 5 by 3
+// fail together if they are merged, while the first one would have succeeded
+// on its own. Vice versa, a decrement which would fail on its own succeeds if
+// it is merged with a large enough increment.
+// If a sum would overflow, the UPDATE is not merged but queued on its own.
+//
+// "key" and "table" are the same as for Wait(). "batchKey" must identify the
+// full statement except for the deltas, i.e. only identical UPDATEs of the
+// same row must share a batch.
+// "waited" is true if the caller was queued or merged into another batch.
+// If the caller which started the batch could not execute it, e.g. because
+// its context expired while it was queued, the other callers in the batch
+// are queued and executed on their own instead.
+func (txs *TxSerializer) Coalesce(ctx context.Context, key, batchKey, table string, deltas []int64, exec ExecFunc) (result *sqltypes.Result, waited bool, err error) {
+	if txs.dryRun {
+		// Do not change the statements in dry-run mode.
+		return txs.waitAndExec(ctx, key, table, deltas, exec)
+	}
+
+	txs.mu.Lock()
+	if b, ok := txs.batches[batchKey]; ok {
+		if addDeltas(b.deltas, deltas) {
+			return txs.joinBatchLocked(ctx, b, key, table, deltas, exec)
+		}
+		// The sums would overflow. Do not merge this UPDATE.
+		txs.mu.Unlock()
+		return txs.waitAndExec(ctx, key, table, deltas, exec)
+	}
+	b := &batch{
+		deltas: append([]int64(nil), deltas...),
+		done:   make(chan struct{}),
+	}
+	txs.batches[batchKey] = b
+	txs.mu.Unlock()
+
+	done, waited, err := txs.Wait(ctx, key, table)
+	if err == nil && ctx.Err() != nil {
+		// Our context expired while we got our turn. The statement would fail.
+		done()
+		err = ctx.Err()
+	}
+
+	// Close the batch. Further UPDATEs start a new one.
+	txs.mu.Lock()
+	delete(txs.batches, batchKey)
+	b.started = true
+	deltas = b.deltas
+	txs.mu.Unlock()
+
+	if err != nil {
+		// The statement was not executed. The other callers in the batch must
+		// not fail with our error and execute their UPDATEs on their own.
+		b.notExecuted = true
+		close(b.done)
+		return nil, waited, err
+	}
+	result, err = exec(deltas)
+	done()
+	b.result, b.err = result, err
+	if err != nil && ctx.Err() != nil {
+		b.err = vterrors.Errorf(vtrpcpb.Code_ABORTED,
+			"hot row protection: the coalesced UPDATE was interrupted because the context of the query which executed it expired: %v", err)
+	}
+	close(b.done)
+	return result, waited, err
+}
+
+// waitAndExec queues the UPDATE on its own and calls "exec" with its deltas
+// when it has its turn.
+func (txs *TxSerializer) waitAndExec(ctx context.Context, key, table string, deltas []int64, exec ExecFunc) (*sqltypes.Result, bool, error) {
+	done, waited, err := txs.Wait(ctx, key, table)
+	if err != nil {
+		return nil, waited, err
+	}
+	defer done()
+	result, err := exec(deltas)
+	return result, waited, err
+}
+
+// joinBatchLocked waits for the result of the batch "b" into which the
+// deltas were already merged. txs.mu must be locked and is unlocked on return.
+func (txs *TxSerializer) joinBatchLocked(ctx context.Context, b *batch, key, table string, deltas []int64, exec ExecFunc) (*sqltypes.Result, bool, error) {
+	if txs.globalSize >= txs.maxGlobalQueueSize {
+		withdrawDeltas(b.deltas, deltas)
+		txs.globalQueueExceeded.Add(1)
+		txs.mu.Unlock()
+		return nil, false, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED,
+			"hot row protection: too many queued transactions (%d >= %d)", txs.globalSize, txs.maxGlobalQueueSize)
+	}
+	txs.globalSize++
+	txs.coalesced.Add(table, 1)
+	txs.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		txs.mu.Lock()
+		// Withdraw our deltas if the batch has not been executed yet. This is
+		// not possible if the sums without them would overflow.
+		if !b.started && withdrawDeltas(b.deltas, deltas) {
+			txs.globalSize--
+			txs.mu.Unlock()
+			return nil, true, ctx.Err()
+		}
+		txs.mu.Unlock()
+		// Our deltas are part of the statement. Wait for its outcome instead
+		// of reporting a failure which may not have happened.
+		<-b.done
+	}
+
+	txs.mu.Lock()
+	txs.globalSize--
+	txs.mu.Unlock()
+	if b.notExecuted {
+		result, _, err := txs.waitAndExec(ctx, key, table, deltas, exec)
+		return result, true, err
+	}
+	if b.err != nil {
+		return nil, true, b.err
+	}
+	return b.result.Copy(), true, nil
+}
+
+// addDeltas adds "deltas" to "sums". It returns false and leaves "sums"
+// unchanged if one of the sums would overflow.
+func addDeltas(sums, deltas []int64) bool {
+	for i, delta := range deltas {
+		if (delta > 0 && sums[i] > math.MaxInt64-delta) || (delta < 0 && sums[i] < math.MinInt64-delta) {
+			return false
+		}
+	}
+	for i, delta := range deltas {
+		sums[i] += delta
+	}
+	return true
+}
+
+// withdrawDeltas subtracts "deltas" from "sums". It returns false and leaves
+// "sums" unchanged if one of the sums would overflow.
+func withdrawDeltas(sums, deltas []int64) bool {
+	for i, delta := range deltas {
+		if (delta < 0 && sums[i] > math.MaxInt64+delta) || (delta > 0 && sums[i] < math.MinInt64+delta) {
+			return false
+		}
+	}
+	for i, delta := range deltas {
+		sums[i] -= delta
+	}
+	return true
+}
+
 // Pending returns the number of queued transactions (including the ones which
 // are currently in flight.)
 func (txs *TxSerializer) Pending(key string) int {
@@ -387,6 +562,25 @@ type queue struct {
 	availableSlots chan struct{}
 }
 
+// batch represents UPDATEs to the same row which are merged into one
+// statement by Coalesce().
+type batch struct {
+	// NOTE: The following fields are guarded by TxSerializer.mu.
+	// deltas holds the sums of the deltas of all UPDATEs in the batch.
+	deltas []int64
+	// started is true once the statement of the batch is executed (or failed
+	// to be queued). From then on, no deltas can be added or withdrawn.
+	started bool
+
+	// done is closed when result and err (or notExecuted) are set.
+	done   chan struct{}
+	result *sqltypes.Result
+	err    error
+	// notExecuted is true if the statement of the batch was not executed
+	// because the caller which started the batch failed to be queued.
+	notExecuted bool
+}
+
 func newQueueForFirstTransaction(concurrentTransactions int) *queue {
 	return &queue{
 		size:  1,
@@ -17,15 +17,19 @@ limitations under the License.
 package txserializer
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
@@ -40,6 +44,7 @@ func resetVariables(txs *TxSerializer) {
 	txs.queueExceededDryRun.ResetAll()
 	txs.globalQueueExceeded.Reset()
 	txs.globalQueueExceededDryRun.Reset()
+	txs.coalesced.ResetAll()
 }
 
 func TestTxSerializer_NoHotRow(t *testing.T) {
@@ -410,6 +415,236 @@ func TestTxSerializerCancel(t *testing.T) {
 	}
 }
 
+// TestTxSerializerCoalesce verifies that UPDATEs which are queued behind the
+// same transaction are merged into one statement.
+func TestTxSerializerCoalesce(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.MaxQueueSize = 4
+	cfg.HotRowProtection.MaxGlobalQueueSize = 10
+	cfg.HotRowProtection.MaxConcurrency = 1
+	txs := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TxSerializerTest"))
+	resetVariables(txs)
+
+	var mu sync.Mutex
+	var executed [][]int64
+	exec := func(deltas []int64) (*sqltypes.Result, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		executed = append(executed, deltas)
+		return &sqltypes.Result{RowsAffected: 1}, nil
+	}
+	coalesce := func(ctx context.Context, delta int64) (*sqltypes.Result, bool, error) {
+		return txs.Coalesce(ctx, "t1 where1", "t1 set c where1", "t1", []int64{delta}, exec)
+	}
+
+	// tx1 holds the row.
+	done1, _, err := txs.Wait(context.Background(), "t1 where1", "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// tx2 is queued and starts the batch.
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		result, waited, err := coalesce(context.Background(), 1)
+		if err != nil {
+			t.Error(err)
+		}
+		if !waited || result.RowsAffected != 1 {
+			t.Errorf("tx2: waited = %v, result = %v", waited, result)
+		}
+	}()
+	if err := waitForPending(txs, "t1 where1", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// tx3 and tx4 are merged into the batch of tx2.
+	for _, delta := range []int64{2, -5} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, waited, err := coalesce(context.Background(), delta)
+			if err != nil {
+				t.Error(err)
+			}
+			if !waited || result.RowsAffected != 1 {
+				t.Errorf("delta %v: waited = %v, result = %v", delta, waited, result)
+			}
+		}()
+	}
+	if err := waitForCoalesced(txs, "t1", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// tx5 is merged as well but canceled before the batch executes.
+	ctx5, cancel5 := context.WithCancel(context.Background())
+	tx5Done := make(chan error)
+	go func() {
+		_, _, err := coalesce(ctx5, 100)
+		tx5Done <- err
+	}()
+	if err := waitForCoalesced(txs, "t1", 3); err != nil {
+		t.Fatal(err)
+	}
+	cancel5()
+	if err := <-tx5Done; err != context.Canceled {
+		t.Errorf("tx5 should have been canceled: %v", err)
+	}
+
+	done1()
+	wg.Wait()
+
+	if len(executed) != 1 || len(executed[0]) != 1 || executed[0][0] != -2 {
+		t.Errorf("batch should have been executed once with the summed deltas: %v", executed)
+	}
+	if len(txs.queues) != 0 || len(txs.batches) != 0 || txs.globalSize != 0 {
+		t.Errorf("queues and batches were not cleaned up: %v %v %v", txs.queues, txs.batches, txs.globalSize)
+	}
+}
+
+// TestTxSerializerCoalesceCancel verifies that the UPDATEs of a batch are
+// executed on their own if the UPDATE which started the batch fails to get its
+// turn.
+func TestTxSerializerCoalesceCancel(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.MaxQueueSize = 4
+	cfg.HotRowProtection.MaxGlobalQueueSize = 10
+	cfg.HotRowProtection.MaxConcurrency = 1
+	txs := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TxSerializerTest"))
+	resetVariables(txs)
+
+	var executed [][]int64
+	exec := func(deltas []int64) (*sqltypes.Result, error) {
+		executed = append(executed, deltas)
+		return &sqltypes.Result{RowsAffected: 1}, nil
+	}
+
+	done1, _, err := txs.Wait(context.Background(), "t1 where1", "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	tx2Done := make(chan error)
+	go func() {
+		_, _, err := txs.Coalesce(ctx2, "t1 where1", "t1 set c where1", "t1", []int64{1}, exec)
+		tx2Done <- err
+	}()
+	if err := waitForPending(txs, "t1 where1", 2); err != nil {
+		t.Fatal(err)
+	}
+	tx3Done := make(chan error)
+	go func() {
+		result, waited, err := txs.Coalesce(context.Background(), "t1 where1", "t1 set c where1", "t1", []int64{2}, exec)
+		if err == nil && (!waited || result.RowsAffected != 1) {
+			t.Errorf("tx3: waited = %v, result = %v", waited, result)
+		}
+		tx3Done <- err
+	}()
+	if err := waitForCoalesced(txs, "t1", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel2()
+	if err := <-tx2Done; err != context.Canceled {
+		t.Errorf("tx2 should have been canceled: %v", err)
+	}
+	// tx3 is queued on its own now.
+	if err := waitForPending(txs, "t1 where1", 2); err != nil {
+		t.Fatal(err)
+	}
+	done1()
+	if err := <-tx3Done; err != nil {
+		t.Errorf("tx3 should have been executed on its own: %v", err)
+	}
+
+	if len(executed) != 1 || len(executed[0]) != 1 || executed[0][0] != 2 {
+		t.Errorf("only the deltas of tx3 should have been executed: %v", executed)
+	}
+	if len(txs.queues) != 0 || len(txs.batches) != 0 || txs.globalSize != 0 {
+		t.Errorf("queues and batches were not cleaned up: %v %v %v", txs.queues, txs.batches, txs.globalSize)
+	}
+}
+
+// TestTxSerializerCoalesceOverflow verifies that an UPDATE is not merged into
+// a batch if the sum of the deltas would overflow.
+func TestTxSerializerCoalesceOverflow(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.MaxQueueSize = 4
+	cfg.HotRowProtection.MaxGlobalQueueSize = 10
+	cfg.HotRowProtection.MaxConcurrency = 1
+	txs := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TxSerializerTest"))
+	resetVariables(txs)
+
+	var mu sync.Mutex
+	var executed [][]int64
+	exec := func(deltas []int64) (*sqltypes.Result, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		executed = append(executed, deltas)
+		return &sqltypes.Result{RowsAffected: 1}, nil
+	}
+	coalesce := func(delta int64) {
+		if _, _, err := txs.Coalesce(context.Background(), "t1 where1", "t1 set c where1", "t1", []int64{delta}, exec); err != nil {
+			t.Error(err)
+		}
+	}
+
+	done1, _, err := txs.Wait(context.Background(), "t1 where1", "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		coalesce(math.MaxInt64 - 1)
+	}()
+	if err := waitForPending(txs, "t1 where1", 2); err != nil {
+		t.Fatal(err)
+	}
+	// The sum would overflow. The UPDATE is queued on its own.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		coalesce(2)
+	}()
+	if err := waitForPending(txs, "t1 where1", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	done1()
+	wg.Wait()
+
+	if got := txs.coalesced.Counts()["t1"]; got != 0 {
+		t.Errorf("no UPDATE should have been coalesced: %v", got)
+	}
+	slices.SortFunc(executed, func(a, b []int64) int { return cmp.Compare(a[0], b[0]) })
+	if len(executed) != 2 || executed[0][0] != 2 || executed[1][0] != math.MaxInt64-1 {
+		t.Errorf("both UPDATEs should have been executed on their own: %v", executed)
+	}
+}
+
+func waitForCoalesced(txs *TxSerializer, table string, i int64) error {
+	start := time.Now()
+	for {
+		got, want := txs.coalesced.Counts()[table], i
+		if got == want {
+			return nil
+		}
+
+		if time.Since(start) > 10*time.Second {
+			return fmt.Errorf("wait for TxSerializerCoalesced = %d timed out: got = %v, want = %v", i, got, want)
+		}
+		time.Sleep(1 * time.Millisecond)
+	}
+}
+
 // TestTxSerializerDryRun verifies that the dry-run mode does not serialize
 // the two concurrent transactions for the same key.
 func TestTxSerializerDryRun(t *testing.T) {
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package userquota enforces per-user and per-workload quotas on the
+// connection pools of the tablet server, and shares a saturated pool between
+// its callers with weighted fair queueing.
+package userquota
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const unknown string = "unknown"
+
+// Pool identifies the connection pool a Limiter guards.
+type Pool int
+
+const (
+	// TxPool is the transaction pool.
+	TxPool Pool = iota
+	// OltpPool is the pool of the non-transactional OLTP queries.
+	OltpPool
+	// OlapPool is the pool of the streaming OLAP queries.
+	OlapPool
+)
+
+// String returns the name the pool exports its stats under.
+func (p Pool) String() string {
+	switch p {
+	case TxPool:
+		return "TransactionPool"
+	case OltpPool:
+		return "ConnPool"
+	case OlapPool:
+		return "StreamConnPool"
+	}
+	return unknown
+}
+
+// limit returns the quota of the pool.
+func (p Pool) limit(quota tabletenv.UserQuota) int64 {
+	switch p {
+	case TxPool:
+		return int64(quota.MaxTransactions)
+	case OltpPool:
+		return int64(quota.MaxOltpConnections)
+	case OlapPool:
+		return int64(quota.MaxOlapConnections)
+	}
+	return 0
+}
+
+// Limiter is the user quota limiter interface.
+type Limiter interface {
+	// Acquire takes a slot of the pool for the caller of ctx, waiting if the
+	// pool is saturated. The returned slot must be released once the pool
+	// connection is returned.
+	Acquire(ctx context.Context, options *querypb.ExecuteOptions) (*Slot, error)
+}
+
+// New creates a new Limiter for the given pool. capacity returns the current
+// capacity of the pool, and timeout bounds the time a caller may wait for it.
+// If user quotas are not enabled, it returns an "allow-all" limiter.
+func New(env tabletenv.Env, pool Pool, capacity func() int64, timeout time.Duration) Limiter {
+	config := env.Config()
+	if !config.EnableUserQuotas && !config.EnableUserQuotasDryRun {
+		return &AllowAll{}
+	}
+	quotas := config.UserQuotas
+	if quotas == nil {
+		quotas = &tabletenv.UserQuotas{}
+	}
+
+	name := pool.String()
+	labels := []string{"User", "Workload"}
+	l := &Impl{
+		pool:             pool,
+		quotas:           quotas,
+		dryRun:           config.EnableUserQuotasDryRun,
+		timeout:          timeout,
+		scheduler:        newScheduler(capacity),
+		users:            make(map[string]int64),
+		workloads:        make(map[string]int64),
+		inUse:            env.Exporter().NewGaugesWithMultiLabels(name+"UserQuotaInUse", "connections in use per user and workload", labels),
+		rejections:       env.Exporter().NewCountersWithMultiLabels(name+"UserQuotaRejections", "rejections from the user quota limiter", labels),
+		rejectionsDryRun: env.Exporter().NewCountersWithMultiLabels(name+"UserQuotaRejectionsDryRun", "rejections from the user quota limiter in dry run", labels),
+		waits:            env.Exporter().NewMultiTimings(name+"UserQuotaWaits", "time spent queued for a saturated pool per user and workload", labels),
+	}
+	env.Exporter().NewGaugeFunc(name+"UserQuotaWaiting", "callers queued for a saturated pool", func() int64 {
+		return int64(l.scheduler.waiting())
+	})
+	return l
+}
+
+// AllowAll is a Limiter that allows all Acquire requests and does no tracking.
+// Implements Limiter.
+type AllowAll struct{}
+
+// Acquire always succeeds, and returns a nil slot.
+// Implements Limiter.Acquire
+func (a *AllowAll) Acquire(ctx context.Context, options *querypb.ExecuteOptions) (*Slot, error) {
+	return nil, nil
+}
+
+// Impl limits the number of connections of a pool a single user and a single
+// workload may use concurrently, and queues the callers fairly when the pool
+// is saturated.
+// Implements Limiter.
+type Impl struct {
+	pool    Pool
+	quotas  *tabletenv.UserQuotas
+	dryRun  bool
+	timeout time.Duration
+
+	scheduler *scheduler
+
+	mu        sync.Mutex
+	users     map[string]int64
+	workloads map[string]int64
+
+	inUse                        *stats.GaugesWithMultiLabels
+	rejections, rejectionsDryRun *stats.CountersWithMultiLabels
+	waits                        *servenv.MultiTimingsWrapper
+}
+
+// Acquire checks the quotas of the user and the workload of the request, and
+// then waits for a slot of the pool. In dry run, it only records which
+// requests would have been rejected, and never queues.
+// Implements Limiter.Acquire
+func (l *Impl) Acquire(ctx context.Context, options *querypb.ExecuteOptions) (*Slot, error) {
+	user := callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx))
+	if user == "" {
+		user = unknown
+	}
+	workload := options.GetWorkloadName()
+	userQuota := l.quotas.ForUser(user)
+	workloadQuota, hasWorkloadQuota := l.quotas.ForWorkload(workload)
+	labels := []string{user, workload}
+
+	l.mu.Lock()
+	overLimit := exceeds(l.users[user], l.pool.limit(userQuota))
+	if hasWorkloadQuota {
+		overLimit = overLimit || exceeds(l.workloads[workload], l.pool.limit(workloadQuota))
+	}
+	if overLimit {
+		if !l.dryRun {
+			l.mu.Unlock()
+			log.Infof("UserQuota: Over limit, rejecting %v request for user %s and workload %q", l.pool, user, workload)
+			l.rejections.Add(labels, 1)
+			return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "per-user %v quota exceeded for user %s", l.pool, user)
+		}
+		log.Infof("UserQuota: DRY RUN: user %s and workload %q over %v limit", user, workload, l.pool)
+		l.rejectionsDryRun.Add(labels, 1)
+	}
+	l.users[user]++
+	if hasWorkloadQuota {
+		l.workloads[workload]++
+	}
+	l.mu.Unlock()
+	l.inUse.Add(labels, 1)
+
+	slot := &Slot{limiter: l, user: user, workload: workload, countWorkload: hasWorkloadQuota, labels: labels}
+	if l.dryRun {
+		return slot, nil
+	}
+
+	flow, weight := user, userQuota.Weight
+	if hasWorkloadQuota {
+		flow = user + "/" + workload
+		if workloadQuota.Weight > 0 {
+			weight = workloadQuota.Weight
+		}
+	}
+	if weight <= 0 {
+		weight = 1
+	}
+
+	waitCtx := ctx
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	queued, err := l.scheduler.acquire(waitCtx, flow, weight)
+	if queued {
+		l.waits.Record(labels, start)
+	}
+	if err != nil {
+		l.untrack(slot)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "%v wait timed out for user %s", l.pool, user)
+		}
+		return nil, vterrors.Errorf(vterrors.Code(err), "%v wait aborted for user %s: %v", l.pool, user, err)
+	}
+	slot.admitted = true
+	return slot, nil
+}
+
+// exceeds tells whether one more connection would go over the limit. A limit
+// of zero means no limit.
+func exceeds(usage, limit int64) bool {
+	return limit > 0 && usage >= limit
+}
+
+func (l *Impl) untrack(slot *Slot) {
+	l.mu.Lock()
+	decrement(l.users, slot.user)
+	if slot.countWorkload {
+		decrement(l.workloads, slot.workload)
+	}
+	l.mu.Unlock()
+	l.inUse.Add(slot.labels, -1)
+}
+
+func decrement(usage map[string]int64, key string) {
+	if usage[key] <= 1 {
+		delete(usage, key)
+		return
+	}
+	usage[key]--
+}
+
+// Slot is a connection slot of a pool held by a user. A nil Slot is valid and
+// its Release does nothing.
+type Slot struct {
+	limiter       *Impl
+	user          string
+	workload      string
+	countWorkload bool
+	labels        []string
+	admitted      bool
+	once          sync.Once
+}
+
+// Release returns the slot. It is safe to call more than once.
+func (s *Slot) Release() {
+	if s == nil {
+		return
+	}
+	s.once.Do(func() {
+		s.limiter.untrack(s)
+		if s.admitted {
+			s.limiter.scheduler.release()
+		}
+	})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userquota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func newTestLimiter(t *testing.T, dryRun bool, capacity int64, timeout time.Duration) *Impl {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.EnableUserQuotas = !dryRun
+	cfg.EnableUserQuotasDryRun = dryRun
+	cfg.UserQuotas = &tabletenv.UserQuotas{
+		Default: tabletenv.UserQuota{MaxTransactions: 3},
+		Users: map[string]tabletenv.UserQuota{
+			"batch": {MaxTransactions: 1, MaxOlapConnections: 1},
+		},
+		Workloads: map[string]tabletenv.UserQuota{
+			"reports": {MaxTransactions: 2},
+		},
+	}
+	limiter := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "UserQuotaTest"), TxPool, fixedCapacity(capacity), timeout)
+	impl, ok := limiter.(*Impl)
+	require.Truef(t, ok, "New returned limiter of unexpected type: %T", limiter)
+	impl.rejections.ResetAll()
+	impl.rejectionsDryRun.ResetAll()
+	impl.inUse.ResetAll()
+	impl.waits.Reset()
+	return impl
+}
+
+func callerContext(user string) context.Context {
+	return callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID(user))
+}
+
+func workloadOptions(workload string) *querypb.ExecuteOptions {
+	return &querypb.ExecuteOptions{WorkloadName: workload}
+}
+
+func TestUserQuota_DisabledAllowsAll(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	limiter := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "UserQuotaTest"), OltpPool, fixedCapacity(1), 0)
+	require.IsType(t, &AllowAll{}, limiter)
+	for range 5 {
+		slot, err := limiter.Acquire(callerContext("batch"), nil)
+		require.NoError(t, err)
+		slot.Release()
+	}
+}
+
+func TestUserQuota_LimitsOnlyOffendingUser(t *testing.T) {
+	l := newTestLimiter(t, false, 10, 0)
+
+	batch, err := l.Acquire(callerContext("batch"), nil)
+	require.NoError(t, err)
+	_, err = l.Acquire(callerContext("batch"), nil)
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "per-user TransactionPool quota exceeded for user batch")
+
+	// Other users fall back to the default quota.
+	var api []*Slot
+	for range 3 {
+		slot, err := l.Acquire(callerContext("api"), nil)
+		require.NoError(t, err)
+		api = append(api, slot)
+	}
+	_, err = l.Acquire(callerContext("api"), nil)
+	require.Error(t, err)
+
+	assert.Equal(t, map[string]int64{"batch.": 1, "api.": 1}, l.rejections.Counts())
+	assert.EqualValues(t, 1, l.inUse.Counts()["batch."])
+	assert.EqualValues(t, 3, l.inUse.Counts()["api."])
+
+	// Releasing a slot frees the quota, and releasing twice is harmless.
+	batch.Release()
+	batch.Release()
+	batch, err = l.Acquire(callerContext("batch"), nil)
+	require.NoError(t, err)
+	batch.Release()
+	for _, slot := range api {
+		slot.Release()
+	}
+	assert.Empty(t, l.users)
+	assert.EqualValues(t, 0, l.scheduler.inUse)
+}
+
+func TestUserQuota_WorkloadQuota(t *testing.T) {
+	l := newTestLimiter(t, false, 10, 0)
+
+	// The workload quota is shared by all the users of the workload.
+	first, err := l.Acquire(callerContext("api"), workloadOptions("reports"))
+	require.NoError(t, err)
+	second, err := l.Acquire(callerContext("dashboard"), workloadOptions("reports"))
+	require.NoError(t, err)
+	_, err = l.Acquire(callerContext("other"), workloadOptions("reports"))
+	require.ErrorContains(t, err, "quota exceeded for user other")
+	assert.Equal(t, map[string]int64{"other.reports": 1}, l.rejections.Counts())
+
+	// Unknown workloads only count against the user quota.
+	unlisted, err := l.Acquire(callerContext("other"), workloadOptions("adhoc"))
+	require.NoError(t, err)
+
+	first.Release()
+	second.Release()
+	unlisted.Release()
+	assert.Empty(t, l.workloads)
+}
+
+func TestUserQuota_DryRun(t *testing.T) {
+	l := newTestLimiter(t, true, 1, 0)
+
+	var slots []*Slot
+	for range 3 {
+		// Neither the quota nor the pool capacity is enforced.
+		slot, err := l.Acquire(callerContext("batch"), nil)
+		require.NoError(t, err)
+		slots = append(slots, slot)
+	}
+	assert.Empty(t, l.rejections.Counts())
+	assert.Equal(t, map[string]int64{"batch.": 2}, l.rejectionsDryRun.Counts())
+	for _, slot := range slots {
+		slot.Release()
+	}
+	assert.Empty(t, l.users)
+}
+
+func TestUserQuota_QueueTimeout(t *testing.T) {
+	l := newTestLimiter(t, false, 1, 10*time.Millisecond)
+
+	holder, err := l.Acquire(callerContext("api"), nil)
+	require.NoError(t, err)
+	_, err = l.Acquire(callerContext("other"), nil)
+	require.Error(t, err)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.ErrorContains(t, err, "TransactionPool wait timed out for user other")
+	assert.EqualValues(t, 1, l.waits.Counts()["UserQuotaTest.other."])
+	assert.Equal(t, map[string]int64{"api": 1}, l.users)
+
+	holder.Release()
+	slot, err := l.Acquire(callerContext("other"), nil)
+	require.NoError(t, err)
+	slot.Release()
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userquota
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// scheduler admits callers into a pool up to its capacity. When the pool is
+// saturated, callers queue and are admitted in weighted fair queueing order:
+// every flow (user) is given a share of the freed slots proportional to its
+// weight, regardless of how many requests it queues.
+type scheduler struct {
+	capacity func() int64
+
+	mu    sync.Mutex
+	inUse int64
+	// virtualTime is the finish tag of the last admitted waiter. The finish
+	// tag of a flow is the virtual time at which its last queued request
+	// would be served.
+	virtualTime float64
+	finish      map[string]float64
+	queue       waitQueue
+	seq         uint64
+}
+
+type waiter struct {
+	flow  string
+	tag   float64
+	seq   uint64
+	index int
+	ready chan struct{}
+}
+
+func newScheduler(capacity func() int64) *scheduler {
+	return &scheduler{
+		capacity: capacity,
+		finish:   make(map[string]float64),
+	}
+}
+
+// acquire takes a slot in the pool for the given flow, and queues if there is
+// none available. It returns whether the caller had to queue, and the context
+// error if the context expired while queued.
+func (s *scheduler) acquire(ctx context.Context, flow string, weight float64) (queued bool, err error) {
+	s.mu.Lock()
+	if s.queue.Len() == 0 && s.inUse < s.capacity() {
+		s.inUse++
+		s.mu.Unlock()
+		return false, nil
+	}
+
+	tag := max(s.virtualTime, s.finish[flow]) + 1/weight
+	s.finish[flow] = tag
+	s.seq++
+	w := &waiter{flow: flow, tag: tag, seq: s.seq, ready: make(chan struct{})}
+	heap.Push(&s.queue, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return true, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if w.index >= 0 {
+			heap.Remove(&s.queue, w.index)
+			s.resetIfIdle()
+			return true, ctx.Err()
+		}
+		// The slot was handed over as the context expired: pass it on.
+		s.releaseLocked()
+		return true, ctx.Err()
+	}
+}
+
+// release returns a slot, handing it over to the next waiter if any.
+func (s *scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releaseLocked()
+}
+
+func (s *scheduler) releaseLocked() {
+	// The pool may have been shrunk, in which case the slot is not reused.
+	if s.queue.Len() == 0 || s.inUse > s.capacity() {
+		s.inUse--
+		return
+	}
+	w := heap.Pop(&s.queue).(*waiter)
+	s.virtualTime = w.tag
+	s.resetIfIdle()
+	close(w.ready)
+}
+
+// resetIfIdle forgets the finish tags once the queue is empty. Waiters are
+// admitted in tag order, so none of the flows has a tag ahead of the virtual
+// time anymore.
+func (s *scheduler) resetIfIdle() {
+	if s.queue.Len() == 0 {
+		s.virtualTime = 0
+		clear(s.finish)
+	}
+}
+
+// waiting returns the number of queued callers.
+func (s *scheduler) waiting() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queue.Len()
+}
+
+// waitQueue is a min-heap of waiters ordered by finish tag, then by arrival.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].tag != q[j].tag {
+		return q[i].tag < q[j].tag
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*q = old[:n-1]
+	return w
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userquota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixedCapacity(capacity int64) func() int64 {
+	return func() int64 { return capacity }
+}
+
+// enqueue starts a waiter and blocks until it is queued.
+func enqueue(t *testing.T, s *scheduler, flow string, weight float64, admitted chan<- string) {
+	waiting := s.waiting()
+	go func() {
+		queued, err := s.acquire(context.Background(), flow, weight)
+		assert.True(t, queued)
+		assert.NoError(t, err)
+		admitted <- flow
+	}()
+	require.Eventually(t, func() bool { return s.waiting() == waiting+1 }, 5*time.Second, time.Millisecond)
+}
+
+func TestSchedulerWeightedFairQueueing(t *testing.T) {
+	s := newScheduler(fixedCapacity(1))
+	queued, err := s.acquire(context.Background(), "holder", 1)
+	require.NoError(t, err)
+	require.False(t, queued)
+
+	admitted := make(chan string)
+	for range 4 {
+		enqueue(t, s, "batch", 1, admitted)
+	}
+	for range 4 {
+		enqueue(t, s, "api", 3, admitted)
+	}
+
+	var order []string
+	for range 8 {
+		s.release()
+		order = append(order, <-admitted)
+	}
+	// api is served three times as often as batch while both are queued.
+	assert.Equal(t, []string{"api", "api", "batch", "api", "api", "batch", "batch", "batch"}, order)
+
+	s.release()
+	assert.EqualValues(t, 0, s.inUse)
+	assert.Empty(t, s.finish)
+}
+
+func TestSchedulerCapacity(t *testing.T) {
+	capacity := int64(2)
+	s := newScheduler(func() int64 { return capacity })
+	for range 2 {
+		queued, err := s.acquire(context.Background(), "user", 1)
+		require.NoError(t, err)
+		require.False(t, queued)
+	}
+
+	admitted := make(chan string)
+	enqueue(t, s, "user", 1, admitted)
+
+	// Shrinking the pool drops the released slot instead of handing it over.
+	capacity = 1
+	s.release()
+	select {
+	case <-admitted:
+		t.Fatal("waiter admitted over capacity")
+	case <-time.After(10 * time.Millisecond):
+	}
+	s.release()
+	assert.Equal(t, "user", <-admitted)
+	assert.EqualValues(t, 1, s.inUse)
+}
+
+func TestSchedulerContextDone(t *testing.T) {
+	s := newScheduler(fixedCapacity(1))
+	_, err := s.acquire(context.Background(), "holder", 1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	queued, err := s.acquire(ctx, "user", 1)
+	assert.True(t, queued)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, s.waiting())
+
+	// The slot of the holder is free again once released.
+	s.release()
+	queued, err = s.acquire(context.Background(), "user", 1)
+	require.NoError(t, err)
+	assert.False(t, queued)
+}
@@ -64,8 +64,9 @@ type Plan struct {
 	// in the Filter's WHERE clause with the exception of the
 	// in_keyrange() function which is a filter that must be applied
 	// by the VStreamer (it's not a valid MySQL function). Note that
-	// the Filter cannot contain any MySQL functions because the
-	// VStreamer cannot filter binlog events using them.
+	// the Filter can only contain the functions that the evalengine
+	// supports because the VStreamer must filter binlog events using
+	// them.
 	whereExprsToPushDown []sqlparser.Expr
 
 	// Convert any integer values seen in the binlog events for ENUM or SET
@@ -107,6 +108,8 @@ const (
 	// in the plan we rewrite `x BETWEEN a AND b` to `x >= a AND x <= b`
 	// NotBetween is used to filter a comparable column if it doesn't lie within a specific range
 	NotBetween
+	// Expression is used to filter on an arbitrary expression of the columns
+	Expression
 )
 
 // Filter contains opcodes for filtering.
@@ -126,6 +129,10 @@ type Filter struct {
 	Vindex        vindexes.Vindex
 	VindexColumns []int
 	KeyRange      *topodatapb.KeyRange
+
+	// Expr is the expression to evaluate against the row for Expression.
+	// The row passes the filter if the expression is true.
+	Expr evalengine.Expr
 }
 
 // ColExpr represents a column expression.
@@ -143,6 +150,10 @@ type ColExpr struct {
 	Field *querypb.Field
 
 	FixedValue sqltypes.Value
+
+	// Expr, if set, is evaluated against the row of the table to
+	// generate the value. If so, ColNum is ignored.
+	Expr evalengine.Expr
 }
 
 // Table contains the metadata for a table.
@@ -305,6 +316,14 @@ func (plan *Plan) shouldFilter(values []sqltypes.Value, charsets []collations.ID
 			if err != nil || !isValueGreaterThanRightFilter {
 				return false, false, err
 			}
+		case Expression:
+			result, err := plan.evaluate(filter.Expr, values)
+			if err != nil {
+				return false, false, err
+			}
+			if !result.ToBoolean() {
+				return false, false, nil
+			}
 		default:
 			match, err := compare(filter.Opcode, values[filter.ColNum], filter.Value, plan.env.CollationEnv(), charsets[filter.ColNum])
 			if err != nil {
@@ -327,6 +346,14 @@ func (plan *Plan) mapValues(values []sqltypes.Value) ([]sqltypes.Value, error) {
 	result := make([]sqltypes.Value, len(plan.ColExprs))
 
 	for i, colExpr := range plan.ColExprs {
+		if colExpr.Expr != nil {
+			value, err := plan.evaluate(colExpr.Expr, values)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value.Value(plan.env.CollationEnv().DefaultConnectionCharset())
+			continue
+		}
 		if colExpr.ColNum == -1 {
 			result[i] = colExpr.FixedValue
 			continue
@@ -347,6 +374,14 @@ func (plan *Plan) mapValues(values []sqltypes.Value) ([]sqltypes.Value, error) {
 	return result, nil
 }
 
+// evaluate evaluates an expression of the columns of the table against a row.
+func (plan *Plan) evaluate(expr evalengine.Expr, values []sqltypes.Value) (evalengine.EvalResult, error) {
+	env := evalengine.EmptyExpressionEnv(plan.env)
+	env.Row = values
+	env.Fields = plan.Table.Fields
+	return env.Evaluate(expr)
+}
+
 func getKeyspaceID(values []sqltypes.Value, vindex vindexes.Vindex, vindexColumns []int, fields []*querypb.Field) (key.DestinationKeyspaceID, error) {
 	vindexValues := make([]sqltypes.Value, 0, len(vindexColumns))
 	for _, col := range vindexColumns {
@@ -634,19 +669,21 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 	if where == nil {
 		return nil
 	}
-	// Only a series of AND expressions are supported.
+	// The constraints that compare a column with literal values are applied
+	// with their opcodes, and the other constraints are evaluated with the
+	// evalengine.
 	exprs := splitAndExpression(nil, where.Expr)
 	for _, expr := range exprs {
 		switch expr := expr.(type) {
 		case *sqlparser.ComparisonExpr:
 			opcode, err := getOpcode(expr)
-			if err != nil {
-				return err
-			}
-			qualifiedName, ok := expr.Left.(*sqlparser.ColName)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+			if err != nil || !isColumn(expr.Left) || (opcode == In && !isLiteralTuple(expr.Right)) || (opcode != In && !isLiteral(expr.Right)) {
+				if err := plan.appendExpressionFilter(expr); err != nil {
+					return err
+				}
+				continue
 			}
+			qualifiedName := expr.Left.(*sqlparser.ColName)
 			if !qualifiedName.Qualifier.IsEmpty() {
 				return fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(qualifiedName))
 			}
@@ -654,15 +691,10 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 			if err != nil {
 				return err
 			}
-			// The Right Expr is typically expected to be a Literal value,
-			// except for the IN operator, where a Tuple value is expected.
-			// Handle the IN operator case first.
+			// The Right Expr is a Literal value, except for the IN operator,
+			// where it is a Tuple value. Handle the IN operator case first.
 			if opcode == In {
-				values, ok := expr.Right.(sqlparser.ValTuple)
-				if !ok {
-					return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
-				}
-				err := plan.appendTupleFilter(values, opcode, colnum)
+				err := plan.appendTupleFilter(expr.Right.(sqlparser.ValTuple), opcode, colnum)
 				if err != nil {
 					return err
 				}
@@ -682,19 +714,25 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 			// Add it to the expressions that get pushed down to mysqld.
 			plan.whereExprsToPushDown = append(plan.whereExprsToPushDown, expr)
 		case *sqlparser.FuncExpr:
-			// We cannot filter binlog events in VStreamer using MySQL functions so
-			// we only allow the in_keyrange() function, which is VStreamer specific.
+			// The in_keyrange() function is VStreamer specific, the other
+			// functions are evaluated with the evalengine.
 			if !expr.Name.EqualString("in_keyrange") {
-				return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
+				if err := plan.appendExpressionFilter(expr); err != nil {
+					return err
+				}
+				continue
 			}
 			if err := plan.analyzeInKeyRange(vschema, expr.Exprs); err != nil {
 				return err
 			}
 		case *sqlparser.IsExpr:
-			qualifiedName, ok := expr.Left.(*sqlparser.ColName)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+			if !isColumn(expr.Left) || (expr.Right != sqlparser.IsNullOp && expr.Right != sqlparser.IsNotNullOp) {
+				if err := plan.appendExpressionFilter(expr); err != nil {
+					return err
+				}
+				continue
 			}
+			qualifiedName := expr.Left.(*sqlparser.ColName)
 			if !qualifiedName.Qualifier.IsEmpty() {
 				return fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(qualifiedName))
 			}
@@ -719,10 +757,13 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 			// Add it to the expressions that get pushed down to mysqld.
 			plan.whereExprsToPushDown = append(plan.whereExprsToPushDown, expr)
 		case *sqlparser.BetweenExpr:
-			qualifiedName, ok := expr.Left.(*sqlparser.ColName)
-			if !ok {
-				return fmt.Errorf("unexpected: %v", sqlparser.String(expr))
+			if !isColumn(expr.Left) || !isLiteral(expr.From) || !isLiteral(expr.To) {
+				if err := plan.appendExpressionFilter(expr); err != nil {
+					return err
+				}
+				continue
 			}
+			qualifiedName := expr.Left.(*sqlparser.ColName)
 			if !qualifiedName.Qualifier.IsEmpty() {
 				return fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(qualifiedName))
 			}
@@ -769,12 +810,121 @@ func (plan *Plan) analyzeWhere(vschema *localVSchema, where *sqlparser.Where) er
 			// Add it to the expressions that get pushed down to mysqld.
 			plan.whereExprsToPushDown = append(plan.whereExprsToPushDown, expr)
 		default:
-			return fmt.Errorf("unsupported constraint: %v", sqlparser.String(expr))
+			if err := plan.appendExpressionFilter(expr); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// appendExpressionFilter adds a filter that evaluates the constraint against
+// the rows with the evalengine.
+func (plan *Plan) appendExpressionFilter(expr sqlparser.Expr) error {
+	evalExpr, err := plan.compileExpr(expr)
+	if err != nil {
+		return err
+	}
+	plan.Filters = append(plan.Filters, Filter{
+		Opcode: Expression,
+		Expr:   evalExpr,
+	})
+	// The evalengine evaluates the expressions like MySQL does, so it can
+	// be pushed down to mysqld.
+	plan.whereExprsToPushDown = append(plan.whereExprsToPushDown, expr)
+	return nil
+}
+
+// nonDeterministicFuncs are the functions that the evalengine supports but
+// that cannot be used in filters, as they would not evaluate to the same
+// value when the rows are copied and when their binlog events are streamed.
+var nonDeterministicFuncs = map[string]bool{
+	"curdate":        true,
+	"current_date":   true,
+	"utc_date":       true,
+	"unix_timestamp": true,
+	"uuid":           true,
+	"random_bytes":   true,
+	"user":           true,
+	"current_user":   true,
+	"session_user":   true,
+	"system_user":    true,
+	"database":       true,
+	"schema":         true,
+	"version":        true,
+	"last_insert_id": true,
+}
+
+// compileExpr compiles an expression of the columns of the table with the
+// evalengine. The expression must be deterministic.
+func (plan *Plan) compileExpr(expr sqlparser.Expr) (evalengine.Expr, error) {
+	err := sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			if !node.Qualifier.IsEmpty() {
+				return false, fmt.Errorf("unsupported qualifier for column: %v", sqlparser.String(node))
+			}
+		case sqlparser.AggrFunc:
+			return false, fmt.Errorf("unsupported aggregation function: %v", sqlparser.String(node))
+		case *sqlparser.Subquery:
+			return false, fmt.Errorf("unsupported subquery: %v", sqlparser.String(node))
+		case *sqlparser.CurTimeFuncExpr:
+			return false, fmt.Errorf("unsupported non-deterministic function: %v", sqlparser.String(node))
+		case *sqlparser.FuncExpr:
+			// unix_timestamp() is only deterministic with an argument.
+			if nonDeterministicFuncs[node.Name.Lowered()] && (node.Name.Lowered() != "unix_timestamp" || len(node.Exprs) == 0) {
+				return false, fmt.Errorf("unsupported non-deterministic function: %v", sqlparser.String(node))
+			}
+		}
+		return true, nil
+	}, expr)
+	if err != nil {
+		return nil, err
+	}
+	return evalengine.Translate(expr, &evalengine.Config{
+		ResolveColumn: func(name *sqlparser.ColName) (int, error) {
+			return findColumn(plan.Table, name.Name)
+		},
+		ResolveType: func(expr sqlparser.Expr) (evalengine.Type, bool) {
+			col, ok := expr.(*sqlparser.ColName)
+			if !ok {
+				return evalengine.Type{}, false
+			}
+			colnum, err := findColumn(plan.Table, col.Name)
+			if err != nil {
+				return evalengine.Type{}, false
+			}
+			field := plan.Table.Fields[colnum]
+			return evalengine.NewType(field.Type, collations.ID(field.Charset)), true
+		},
+		Collation:   plan.env.CollationEnv().DefaultConnectionCharset(),
+		Environment: plan.env,
+	})
+}
+
+func isColumn(expr sqlparser.Expr) bool {
+	_, ok := expr.(*sqlparser.ColName)
+	return ok
+}
+
+func isLiteral(expr sqlparser.Expr) bool {
+	_, ok := expr.(*sqlparser.Literal)
+	return ok
+}
+
+func isLiteralTuple(expr sqlparser.Expr) bool {
+	tuple, ok := expr.(sqlparser.ValTuple)
+	if !ok {
+		return false
+	}
+	for _, expr := range tuple {
+		if !isLiteral(expr) {
+			return false
+		}
+	}
+	return true
+}
+
 // splitAndExpression breaks up the Expr into AND-separated conditions
 // and appends them to filters, which can be shuffled and recombined
 // as needed.
@@ -895,7 +1045,7 @@ func (plan *Plan) analyzeExpr(vschema *localVSchema, selExpr sqlparser.SelectExp
 				Field:  field,
 			}, nil
 		default:
-			return ColExpr{}, fmt.Errorf("unsupported function: %v", sqlparser.String(inner))
+			return plan.analyzeExpression(aliased)
 		}
 	case *sqlparser.Literal:
 		// allow only intval 1
@@ -950,9 +1100,33 @@ func (plan *Plan) analyzeExpr(vschema *localVSchema, selExpr sqlparser.SelectExp
 			Field:  field,
 		}, nil
 	default:
-		log.Infof("Unsupported expression: %v", inner)
-		return ColExpr{}, fmt.Errorf("unsupported: %v", sqlparser.String(aliased.Expr))
+		return plan.analyzeExpression(aliased)
+	}
+}
+
+// analyzeExpression returns the ColExpr of an expression of the columns, which
+// is evaluated with the evalengine.
+func (plan *Plan) analyzeExpression(aliased *sqlparser.AliasedExpr) (ColExpr, error) {
+	expr, err := plan.compileExpr(aliased.Expr)
+	if err != nil {
+		return ColExpr{}, err
 	}
+	env := evalengine.EmptyExpressionEnv(plan.env)
+	env.Fields = plan.Table.Fields
+	typ, err := env.TypeOf(expr)
+	if err != nil {
+		return ColExpr{}, err
+	}
+	field := typ.ToField(aliased.ColumnName())
+	if sqltypes.IsTextOrBinary(field.Type) && field.Charset != collations.CollationBinaryID {
+		// The values are converted to the connection charset.
+		field.Charset = uint32(plan.env.CollationEnv().DefaultConnectionCharset())
+	}
+	return ColExpr{
+		ColNum: -1,
+		Field:  field,
+		Expr:   expr,
+	}, nil
 }
 
 // analyzeInKeyRange allows the following constructs: "in_keyrange('-80')",
@@ -705,7 +705,15 @@ func TestPlanBuilder(t *testing.T) {
 	}, {
 		inTable: t1,
 		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id, val from t1 where max(id)"},
-		outErr:  `unsupported constraint: max(id)`,
+		outErr:  `unsupported aggregation function: max(id)`,
+	}, {
+		inTable: t1,
+		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id, val from t1 where val = uuid()"},
+		outErr:  `unsupported non-deterministic function: uuid()`,
+	}, {
+		inTable: t1,
+		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id, val from t1 where id in (select id from t2)"},
+		outErr:  `unsupported subquery: (select id from t2)`,
 	}, {
 		inTable: t1,
 		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id, val from t1 where in_keyrange(id)"},
@@ -741,8 +749,12 @@ func TestPlanBuilder(t *testing.T) {
 		outErr:  `unsupported function: max(val)`,
 	}, {
 		inTable: t1,
-		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id+1, val from t1"},
-		outErr:  `unsupported: id + 1`,
+		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id, now() as ts from t1"},
+		outErr:  `unsupported non-deterministic function: now()`,
+	}, {
+		inTable: t1,
+		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select id + none as val from t1"},
+		outErr:  "column `none` not found in table t1",
 	}, {
 		inTable: t1,
 		inRule:  &binlogdatapb.Rule{Match: "t1", Filter: "select t1.id, val from t1"},
@@ -895,6 +907,130 @@ func TestPlanBuilderFilterComparison(t *testing.T) {
 	}
 }
 
+func TestPlanBuilderExpressions(t *testing.T) {
+	t1 := &Table{
+		Name: "t1",
+		Fields: []*querypb.Field{{
+			Name:    "id",
+			Type:    sqltypes.Int64,
+			Charset: collations.CollationBinaryID,
+			Flags:   uint32(querypb.MySqlFlag_NUM_FLAG),
+		}, {
+			Name:    "name",
+			Type:    sqltypes.VarChar,
+			Charset: collations.CollationUtf8mb4ID,
+		}, {
+			Name:    "created",
+			Type:    sqltypes.Datetime,
+			Charset: collations.CollationBinaryID,
+		}, {
+			Name:    "doc",
+			Type:    sqltypes.TypeJSON,
+			Charset: collations.CollationBinaryID,
+		}},
+	}
+	row := func(id int64, name string, created string, doc string) []sqltypes.Value {
+		return []sqltypes.Value{
+			sqltypes.NewInt64(id),
+			sqltypes.NewVarChar(name),
+			sqltypes.NewDatetime(created),
+			sqltypes.TestValue(sqltypes.TypeJSON, doc),
+		}
+	}
+
+	type rowcase struct {
+		in  []sqltypes.Value
+		out []sqltypes.Value
+	}
+	testcases := []struct {
+		name       string
+		filter     string
+		fields     []string
+		pushedDown []string
+		rows       []rowcase
+	}{{
+		name:       "arithmetic and string functions",
+		filter:     "select id, concat(name, '-', id) as label, id * 2 as double_id from t1 where (id > 10 or name = 'x') and json_extract(doc, '$.a') = 1",
+		fields:     []string{"id:INT64", "label:VARCHAR", "double_id:INT64"},
+		pushedDown: []string{"id > 10 or `name` = 'x'", "json_extract(doc, '$.a') = 1"},
+		rows: []rowcase{{
+			in:  row(11, "a", "2024-05-01 00:00:00", `{"a": 1}`),
+			out: []sqltypes.Value{sqltypes.NewInt64(11), sqltypes.NewVarChar("a-11"), sqltypes.NewInt64(22)},
+		}, {
+			in:  row(5, "x", "2024-05-01 00:00:00", `{"a": 1}`),
+			out: []sqltypes.Value{sqltypes.NewInt64(5), sqltypes.NewVarChar("x-5"), sqltypes.NewInt64(10)},
+		}, {
+			in: row(5, "y", "2024-05-01 00:00:00", `{"a": 1}`),
+		}, {
+			in: row(11, "a", "2024-05-01 00:00:00", `{"a": 2}`),
+		}},
+	}, {
+		name:       "case and date functions",
+		filter:     "select id, case when id > 10 then 'big' else 'small' end as size, date(created) as day from t1 where year(created) = 2024 and id = 1 + 10",
+		fields:     []string{"id:INT64", "size:VARCHAR", "day:DATE"},
+		pushedDown: []string{"year(created) = 2024", "id = 1 + 10"},
+		rows: []rowcase{{
+			in:  row(11, "a", "2024-05-01 10:00:00", `{}`),
+			out: []sqltypes.Value{sqltypes.NewInt64(11), sqltypes.NewVarChar("big"), sqltypes.NewDate("2024-05-01")},
+		}, {
+			in: row(11, "a", "2025-05-01 10:00:00", `{}`),
+		}, {
+			in: row(12, "a", "2024-05-01 10:00:00", `{}`),
+		}},
+	}, {
+		name:       "simple constraints and expressions",
+		filter:     "select id from t1 where id >= 10 and name like 'a%' and id not in (12, 13) and name is not true",
+		fields:     []string{"id:INT64"},
+		pushedDown: []string{"id >= 10", "`name` like 'a%'", "id not in (12, 13)", "`name` is not true"},
+		rows: []rowcase{{
+			in:  row(11, "abc", "2024-05-01 10:00:00", `{}`),
+			out: []sqltypes.Value{sqltypes.NewInt64(11)},
+		}, {
+			in: row(9, "abc", "2024-05-01 10:00:00", `{}`),
+		}, {
+			in: row(11, "bcd", "2024-05-01 10:00:00", `{}`),
+		}, {
+			in: row(12, "abc", "2024-05-01 10:00:00", `{}`),
+		}},
+	}}
+	for _, tcase := range testcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			plan, err := buildPlan(vtenv.NewTestEnv(), t1, testLocalVSchema, &binlogdatapb.Filter{
+				Rules: []*binlogdatapb.Rule{{Match: "t1", Filter: tcase.filter}},
+			})
+			require.NoError(t, err)
+
+			var fields []string
+			for _, field := range plan.fields() {
+				fields = append(fields, fmt.Sprintf("%s:%s", field.Name, field.Type))
+			}
+			assert.Equal(t, tcase.fields, fields)
+			var pushedDown []string
+			for _, expr := range plan.whereExprsToPushDown {
+				pushedDown = append(pushedDown, sqlparser.String(expr))
+			}
+			assert.Equal(t, tcase.pushedDown, pushedDown)
+
+			charsets := make([]collations.ID, len(t1.Fields))
+			for i, field := range t1.Fields {
+				charsets[i] = collations.ID(field.Charset)
+			}
+			for _, row := range tcase.rows {
+				ok, _, err := plan.shouldFilter(row.in, charsets)
+				require.NoError(t, err)
+				if row.out == nil {
+					assert.False(t, ok, "row %v", row.in)
+					continue
+				}
+				require.True(t, ok, "row %v", row.in)
+				out, err := plan.mapValues(row.in)
+				require.NoError(t, err)
+				assert.Equal(t, sqltypes.RowToProto3(row.out), sqltypes.RowToProto3(out))
+			}
+		})
+	}
+}
+
 func TestCompare(t *testing.T) {
 	type testcase struct {
 		opcode                   Opcode
@@ -1118,34 +1118,36 @@ func (ts *trafficSwitcher) validate(ctx context.Context) error {
 
 func (ts *trafficSwitcher) switchTableReads(ctx context.Context, cells []string, servedTypes []topodatapb.TabletType, direction workflow.TrafficSwitchDirection) error {
 	log.Infof("switchTableReads: servedTypes: %+v, direction %t", servedTypes, direction)
-	rules, err := topotools.GetRoutingRules(ctx, ts.TopoServer())
-	if err != nil {
-		return err
-	}
-	// We assume that the following rules were setup when the targets were created:
-	// table -> sourceKeyspace.table
-	// targetKeyspace.table -> sourceKeyspace.table
-	// For forward migration, we add tablet type specific rules to redirect traffic to the target.
-	// For backward, we redirect to source.
-	for _, servedType := range servedTypes {
-		tt := strings.ToLower(servedType.String())
-		for _, table := range ts.Tables() {
-			if direction == workflow.DirectionForward {
-				log.Infof("Route direction forward")
-				toTarget := []string{ts.TargetKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toTarget
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toTarget
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toTarget
-			} else {
-				log.Infof("Route direction backwards")
-				toSource := []string{ts.SourceKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toSource
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toSource
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toSource
+	reason := fmt.Sprintf("Switching reads for workflow %s.%s", ts.targetKeyspace, ts.workflow)
+	err := topotools.UpdateAllRoutingRules(ctx, ts.TopoServer(), reason,
+		func(ctx context.Context, all *topotools.RoutingRulesMaps) error {
+			rules := all.Rules
+			// We assume that the following rules were setup when the targets were created:
+			// table -> sourceKeyspace.table
+			// targetKeyspace.table -> sourceKeyspace.table
+			// For forward migration, we add tablet type specific rules to redirect traffic to the target.
+			// For backward, we redirect to source.
+			for _, servedType := range servedTypes {
+				tt := strings.ToLower(servedType.String())
+				for _, table := range ts.Tables() {
+					if direction == workflow.DirectionForward {
+						log.Infof("Route direction forward")
+						toTarget := []string{ts.TargetKeyspaceName() + "." + table}
+						rules[table+"@"+tt] = toTarget
+						rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toTarget
+						rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toTarget
+					} else {
+						log.Infof("Route direction backwards")
+						toSource := []string{ts.SourceKeyspaceName() + "." + table}
+						rules[table+"@"+tt] = toSource
+						rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toSource
+						rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toSource
+					}
+				}
 			}
-		}
-	}
-	if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
+			return nil
+		})
+	if err != nil {
 		return err
 	}
 	return ts.TopoServer().RebuildSrvVSchema(ctx, cells)
@@ -1562,37 +1564,32 @@ func (ts *trafficSwitcher) changeRouting(ctx context.Context) error {
 }
 
 func (ts *trafficSwitcher) changeWriteRoute(ctx context.Context) error {
-	if ts.isPartialMigration {
-		srr, err := topotools.GetShardRoutingRules(ctx, ts.TopoServer())
-		if err != nil {
-			return err
-		}
-		for _, si := range ts.SourceShards() {
-			delete(srr, fmt.Sprintf("%s.%s", ts.TargetKeyspaceName(), si.ShardName()))
-			ts.Logger().Infof("Deleted shard routing: %v:%v", ts.TargetKeyspaceName(), si.ShardName())
-			srr[fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), si.ShardName())] = ts.TargetKeyspaceName()
-			ts.Logger().Infof("Added shard routing: %v:%v", ts.SourceKeyspaceName(), si.ShardName())
-		}
-		if err := topotools.SaveShardRoutingRules(ctx, ts.TopoServer(), srr); err != nil {
-			return err
-		}
-	} else {
-		rules, err := topotools.GetRoutingRules(ctx, ts.TopoServer())
-		if err != nil {
-			return err
-		}
-		for _, table := range ts.Tables() {
-			targetKsTable := fmt.Sprintf("%s.%s", ts.TargetKeyspaceName(), table)
-			sourceKsTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table)
-			delete(rules, targetKsTable)
-			ts.Logger().Infof("Deleted routing: %s", targetKsTable)
-			rules[table] = []string{targetKsTable}
-			rules[sourceKsTable] = []string{targetKsTable}
-			ts.Logger().Infof("Added routing: %v %v", table, sourceKsTable)
-		}
-		if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
-			return err
-		}
+	reason := fmt.Sprintf("Switching writes for workflow %s.%s", ts.targetKeyspace, ts.workflow)
+	err := topotools.UpdateAllRoutingRules(ctx, ts.TopoServer(), reason,
+		func(ctx context.Context, rules *topotools.RoutingRulesMaps) error {
+			if ts.isPartialMigration {
+				srr := rules.ShardRules
+				for _, si := range ts.SourceShards() {
+					delete(srr, fmt.Sprintf("%s.%s", ts.TargetKeyspaceName(), si.ShardName()))
+					ts.Logger().Infof("Deleted shard routing: %v:%v", ts.TargetKeyspaceName(), si.ShardName())
+					srr[fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), si.ShardName())] = ts.TargetKeyspaceName()
+					ts.Logger().Infof("Added shard routing: %v:%v", ts.SourceKeyspaceName(), si.ShardName())
+				}
+				return nil
+			}
+			for _, table := range ts.Tables() {
+				targetKsTable := fmt.Sprintf("%s.%s", ts.TargetKeyspaceName(), table)
+				sourceKsTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table)
+				delete(rules.Rules, targetKsTable)
+				ts.Logger().Infof("Deleted routing: %s", targetKsTable)
+				rules.Rules[table] = []string{targetKsTable}
+				rules.Rules[sourceKsTable] = []string{targetKsTable}
+				ts.Logger().Infof("Added routing: %v %v", table, sourceKsTable)
+			}
+			return nil
+		})
+	if err != nil {
+		return err
 	}
 	return ts.TopoServer().RebuildSrvVSchema(ctx, nil)
 }
@@ -1639,17 +1636,14 @@ func (ts *trafficSwitcher) deleteShardRoutingRules(ctx context.Context) error {
 	if !ts.isPartialMigration {
 		return nil
 	}
-	srr, err := topotools.GetShardRoutingRules(ctx, ts.TopoServer())
-	if err != nil {
-		return err
-	}
-	for _, si := range ts.TargetShards() {
-		delete(srr, fmt.Sprintf("%s.%s", ts.targetKeyspace, si.ShardName()))
-	}
-	if err := topotools.SaveShardRoutingRules(ctx, ts.TopoServer(), srr); err != nil {
-		return err
-	}
-	return nil
+	reason := fmt.Sprintf("Deleting shard routing rules for workflow %s.%s", ts.targetKeyspace, ts.workflow)
+	return topotools.UpdateAllRoutingRules(ctx, ts.TopoServer(), reason,
+		func(ctx context.Context, rules *topotools.RoutingRulesMaps) error {
+			for _, si := range ts.TargetShards() {
+				delete(rules.ShardRules, fmt.Sprintf("%s.%s", ts.targetKeyspace, si.ShardName()))
+			}
+			return nil
+		})
 }
 
 func (ts *trafficSwitcher) startReverseVReplication(ctx context.Context) error {
@@ -1944,25 +1938,22 @@ func (ts *trafficSwitcher) dropTargetShards(ctx context.Context) error {
 }
 
 func (ts *trafficSwitcher) deleteRoutingRules(ctx context.Context) error {
-	rules, err := topotools.GetRoutingRules(ctx, ts.TopoServer())
-	if err != nil {
-		return err
-	}
-	for _, table := range ts.Tables() {
-		delete(rules, table)
-		delete(rules, table+"@replica")
-		delete(rules, table+"@rdonly")
-		delete(rules, ts.TargetKeyspaceName()+"."+table)
-		delete(rules, ts.TargetKeyspaceName()+"."+table+"@replica")
-		delete(rules, ts.TargetKeyspaceName()+"."+table+"@rdonly")
-		delete(rules, ts.SourceKeyspaceName()+"."+table)
-		delete(rules, ts.SourceKeyspaceName()+"."+table+"@replica")
-		delete(rules, ts.SourceKeyspaceName()+"."+table+"@rdonly")
-	}
-	if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
-		return err
-	}
-	return nil
+	reason := fmt.Sprintf("Deleting routing rules for workflow %s.%s", ts.targetKeyspace, ts.workflow)
+	return topotools.UpdateAllRoutingRules(ctx, ts.TopoServer(), reason,
+		func(ctx context.Context, rules *topotools.RoutingRulesMaps) error {
+			for _, table := range ts.Tables() {
+				delete(rules.Rules, table)
+				delete(rules.Rules, table+"@replica")
+				delete(rules.Rules, table+"@rdonly")
+				delete(rules.Rules, ts.TargetKeyspaceName()+"."+table)
+				delete(rules.Rules, ts.TargetKeyspaceName()+"."+table+"@replica")
+				delete(rules.Rules, ts.TargetKeyspaceName()+"."+table+"@rdonly")
+				delete(rules.Rules, ts.SourceKeyspaceName()+"."+table)
+				delete(rules.Rules, ts.SourceKeyspaceName()+"."+table+"@replica")
+				delete(rules.Rules, ts.SourceKeyspaceName()+"."+table+"@rdonly")
+			}
+			return nil
+		})
 }
 
 // addParticipatingTablesToKeyspace updates the vschema with the new tables that were created as part of the